@@ -470,11 +470,20 @@ func (x *FeedbackComment) GetParentCommentId() string {
 
 // GetFeedRequest requests a paginated feed
 type GetFeedRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Limit int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Deprecated: ignored by servers that support cursors; use cursor instead
+	Offset int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Opaque cursor from a previous response's next_cursor; empty for the first page
+	Cursor string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Whether to estimate the total number of items in count
+	IncludeTotal bool `protobuf:"varint,4,opt,name=include_total,json=includeTotal,proto3" json:"include_total,omitempty"`
+	// The user the feed is for; only feedback they may see is returned
+	ViewerUserId string `protobuf:"bytes,5,opt,name=viewer_user_id,json=viewerUserId,proto3" json:"viewer_user_id,omitempty"`
+	// The organization the viewer is acting in; empty for none
+	ViewerOrganizationId string `protobuf:"bytes,6,opt,name=viewer_organization_id,json=viewerOrganizationId,proto3" json:"viewer_organization_id,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *GetFeedRequest) Reset() {
@@ -521,11 +530,42 @@ func (x *GetFeedRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *GetFeedRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetFeedRequest) GetIncludeTotal() bool {
+	if x != nil {
+		return x.IncludeTotal
+	}
+	return false
+}
+
+func (x *GetFeedRequest) GetViewerUserId() string {
+	if x != nil {
+		return x.ViewerUserId
+	}
+	return ""
+}
+
+func (x *GetFeedRequest) GetViewerOrganizationId() string {
+	if x != nil {
+		return x.ViewerOrganizationId
+	}
+	return ""
+}
+
 // GetFeedResponse returns paginated feedback items
 type GetFeedResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Results       []*FeedbackItem        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
-	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Results []*FeedbackItem        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// Approximate total, only set when include_total was requested
+	Count int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Cursor for the next page; empty when there are no more items
+	NextCursor    string `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -574,6 +614,13 @@ func (x *GetFeedResponse) GetCount() int32 {
 	return 0
 }
 
+func (x *GetFeedResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 // GetFeedbackRequest requests a specific feedback item
 type GetFeedbackRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -666,10 +713,15 @@ func (x *GetFeedbackResponse) GetFeedback() *FeedbackItem {
 
 // GetCommentsRequest requests comments for a feedback item
 type GetCommentsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FeedbackId    string                 `protobuf:"bytes,1,opt,name=feedback_id,json=feedbackId,proto3" json:"feedback_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	FeedbackId string                 `protobuf:"bytes,1,opt,name=feedback_id,json=feedbackId,proto3" json:"feedback_id,omitempty"`
+	Limit      int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Deprecated: ignored by servers that support cursors; use cursor instead
+	Offset int32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Opaque cursor from a previous response's next_cursor; empty for the first page
+	Cursor string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Whether to estimate the total number of comments in count
+	IncludeTotal  bool `protobuf:"varint,5,opt,name=include_total,json=includeTotal,proto3" json:"include_total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -725,11 +777,28 @@ func (x *GetCommentsRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *GetCommentsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetCommentsRequest) GetIncludeTotal() bool {
+	if x != nil {
+		return x.IncludeTotal
+	}
+	return false
+}
+
 // GetCommentsResponse returns paginated comments
 type GetCommentsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Comments      []*FeedbackComment     `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
-	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Comments []*FeedbackComment     `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	// Approximate total, only set when include_total was requested
+	Count int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Cursor for the next page; empty when there are no more comments
+	NextCursor    string `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -778,6 +847,13 @@ func (x *GetCommentsResponse) GetCount() int32 {
 	return 0
 }
 
+func (x *GetCommentsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 var File_api_proto_feedback_feedback_proto protoreflect.FileDescriptor
 
 const file_api_proto_feedback_feedback_proto_rawDesc = "" +
@@ -817,26 +893,36 @@ const file_api_proto_feedback_feedback_proto_rawDesc = "" +
 	"\acontent\x18\x03 \x01(\tR\acontent\x129\n" +
 	"\n" +
 	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12*\n" +
-	"\x11parent_comment_id\x18\x05 \x01(\tR\x0fparentCommentId\">\n" +
+	"\x11parent_comment_id\x18\x05 \x01(\tR\x0fparentCommentId\"\xd7\x01\n" +
 	"\x0eGetFeedRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x02 \x01(\x05R\x06offset\"Y\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12#\n" +
+	"\rinclude_total\x18\x04 \x01(\bR\fincludeTotal\x12$\n" +
+	"\x0eviewer_user_id\x18\x05 \x01(\tR\fviewerUserId\x124\n" +
+	"\x16viewer_organization_id\x18\x06 \x01(\tR\x14viewerOrganizationId\"z\n" +
 	"\x0fGetFeedResponse\x120\n" +
 	"\aresults\x18\x01 \x03(\v2\x16.feedback.FeedbackItemR\aresults\x12\x14\n" +
-	"\x05count\x18\x02 \x01(\x05R\x05count\"5\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"5\n" +
 	"\x12GetFeedbackRequest\x12\x1f\n" +
 	"\vfeedback_id\x18\x01 \x01(\tR\n" +
 	"feedbackId\"I\n" +
 	"\x13GetFeedbackResponse\x122\n" +
-	"\bfeedback\x18\x01 \x01(\v2\x16.feedback.FeedbackItemR\bfeedback\"c\n" +
+	"\bfeedback\x18\x01 \x01(\v2\x16.feedback.FeedbackItemR\bfeedback\"\xa0\x01\n" +
 	"\x12GetCommentsRequest\x12\x1f\n" +
 	"\vfeedback_id\x18\x01 \x01(\tR\n" +
 	"feedbackId\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"b\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12#\n" +
+	"\rinclude_total\x18\x05 \x01(\bR\fincludeTotal\"\x83\x01\n" +
 	"\x13GetCommentsResponse\x125\n" +
 	"\bcomments\x18\x01 \x03(\v2\x19.feedback.FeedbackCommentR\bcomments\x12\x14\n" +
-	"\x05count\x18\x02 \x01(\x05R\x05count*\x98\x01\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor*\x98\x01\n" +
 	"\x12FeedbackVisibility\x12#\n" +
 	"\x1fFEEDBACK_VISIBILITY_UNSPECIFIED\x10\x00\x12\x1e\n" +
 	"\x1aFEEDBACK_VISIBILITY_PUBLIC\x10\x01\x12\x1f\n" +
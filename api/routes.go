@@ -2,24 +2,36 @@ package api
 
 import (
 	accountHandler "ethos/internal/account/handler"
+	apikeyHandler "ethos/internal/apikey/handler"
+	apikeyModel "ethos/internal/apikey/model"
 	"ethos/internal/auth/handler"
 	communityHandler "ethos/internal/community/handler"
 	dashboardHandler "ethos/internal/dashboard/handler"
 	feedbackHandler "ethos/internal/feedback/handler"
+	impersonationHandler "ethos/internal/impersonation/handler"
+	invitationHandler "ethos/internal/invitation/handler"
+	domainHandler "ethos/internal/orgdomain/handler"
 	"ethos/internal/middleware"
+	"ethos/internal/revocation"
 	moderationHandler "ethos/internal/moderation/handler"
 	notificationHandler "ethos/internal/notifications/handler"
 	organizationHandler "ethos/internal/organization/handler"
 	organizationService "ethos/internal/organization/service"
+	passkeyHandler "ethos/internal/passkey/handler"
+	permissionHandler "ethos/internal/permission/handler"
+	permissionModel "ethos/internal/permission/model"
 	peopleHandler "ethos/internal/people/handler"
 	profileHandler "ethos/internal/profile/handler"
+	scimHandler "ethos/internal/scim/handler"
+	ssoHandler "ethos/internal/sso/handler"
+	teamHandler "ethos/internal/team/handler"
 	"ethos/pkg/jwt"
 
 	"github.com/gin-gonic/gin"
 )
 
 // SetupRoutes configures all API routes
-func SetupRoutes(router *gin.Engine, authHandler *handler.AuthHandler, profileHandler *profileHandler.ProfileHandler, feedbackHandler *feedbackHandler.FeedbackHandler, notificationHandler *notificationHandler.NotificationHandler, dashboardHandler *dashboardHandler.DashboardHandler, organizationHandler *organizationHandler.OrganizationHandler, contextSwitchHandler *organizationHandler.ContextSwitchHandler, peopleHandler *peopleHandler.PeopleHandler, communityHandler *communityHandler.CommunityHandler, accountHandler *accountHandler.AccountHandler, moderationHandler *moderationHandler.ModerationHandler, tokenGen *jwt.TokenGenerator, contextService organizationService.UserContextService) {
+func SetupRoutes(router *gin.Engine, authHandler *handler.AuthHandler, profileHandler *profileHandler.ProfileHandler, feedbackHandler *feedbackHandler.FeedbackHandler, notificationHandler *notificationHandler.NotificationHandler, dashboardHandler *dashboardHandler.DashboardHandler, organizationHandler *organizationHandler.OrganizationHandler, contextSwitchHandler *organizationHandler.ContextSwitchHandler, peopleHandler *peopleHandler.PeopleHandler, communityHandler *communityHandler.CommunityHandler, accountHandler *accountHandler.AccountHandler, moderationHandler *moderationHandler.ModerationHandler, ssoHandler *ssoHandler.SSOHandler, passkeyHandler *passkeyHandler.PasskeyHandler, apiKeyHandler *apikeyHandler.APIKeyHandler, permissionHandler *permissionHandler.PermissionHandler, impersonationHandler *impersonationHandler.ImpersonationHandler, invitationHandler *invitationHandler.InvitationHandler, domainHandler *domainHandler.DomainHandler, scimHandler *scimHandler.SCIMHandler, teamHandler *teamHandler.TeamHandler, templateHandler *feedbackHandler.TemplateHandler, tokenGen *jwt.TokenGenerator, contextService organizationService.UserContextService, revocations revocation.List, apiKeys middleware.APIKeyAuthenticator, authorizer middleware.Authorizer, activityLog middleware.ActivityLogger) {
 	// Global OPTIONS handler for all API routes
 	router.OPTIONS("/api/*path", func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "http://localhost:5173")
@@ -30,33 +42,69 @@ func SetupRoutes(router *gin.Engine, authHandler *handler.AuthHandler, profileHa
 		c.Status(200)
 	})
 
+	// Public keys for services that verify our access tokens
+	router.GET("/.well-known/jwks.json", handler.NewJWKSHandler(tokenGen).GetJWKS)
+
+	// Every protected route rejects access tokens from logged out sessions
+	authRequired := middleware.AuthMiddlewareWithRevocation(tokenGen, revocations)
+
+	// Integration routes also accept API keys, limited by scope
+	apiKeyAuth := middleware.AuthMiddlewareWithAPIKeys(tokenGen, revocations, apiKeys)
+	feedbackRead := middleware.RequireScope(apikeyModel.ScopeFeedbackRead)
+	feedbackWrite := middleware.RequireScope(apikeyModel.ScopeFeedbackWrite)
+
+	requirePermission := func(permission string) gin.HandlerFunc {
+		return middleware.RequirePermission(authorizer, permission)
+	}
+
+	// Impersonation tokens can't change credentials, delete anything or mint
+	// API keys that would outlive them
+	notImpersonating := middleware.BlockImpersonation()
+
 	v1 := router.Group("/api/v1")
+	// Every request made while impersonating a user is audited
+	v1.Use(middleware.AuditImpersonation(activityLog))
 	{
 		auth := v1.Group("/auth")
 		{
 			auth.POST("/login", authHandler.Login)
+			auth.POST("/login/2fa", authHandler.LoginTwoFactor)
 			auth.POST("/register", authHandler.Register)
 			auth.POST("/refresh", authHandler.Refresh)
-			auth.GET("/me", middleware.AuthMiddleware(tokenGen), authHandler.Me)
+			auth.POST("/request-password-reset", authHandler.RequestPasswordReset)
+			auth.POST("/reset-password", authHandler.ResetPassword)
+			auth.POST("/logout", authRequired, authHandler.Logout)
+			auth.GET("/me", authRequired, authHandler.Me)
 			auth.GET("/verify-email/:token", authHandler.VerifyEmail)
-			auth.POST("/change-password", middleware.AuthMiddleware(tokenGen), authHandler.ChangePassword)
-			auth.POST("/setup-2fa", middleware.AuthMiddleware(tokenGen), authHandler.Setup2FA)
-			auth.DELETE("/setup-2fa", middleware.AuthMiddleware(tokenGen), accountHandler.Disable2FA)
+			auth.POST("/resend-verification", authHandler.ResendVerification)
+			auth.POST("/unlock-account", authHandler.UnlockAccount)
+			auth.POST("/sso/start", ssoHandler.StartLogin)
+			auth.POST("/sso/callback", ssoHandler.Callback)
+			auth.GET("/sso/saml/:org_id/metadata", ssoHandler.SAMLMetadata)
+			auth.POST("/sso/saml/:org_id/acs", ssoHandler.AssertionConsumerService)
+			auth.POST("/webauthn/register/begin", authRequired, notImpersonating, passkeyHandler.BeginRegistration)
+			auth.POST("/webauthn/register/finish", authRequired, notImpersonating, passkeyHandler.FinishRegistration)
+			auth.POST("/webauthn/login/begin", passkeyHandler.BeginLogin)
+			auth.POST("/webauthn/login/finish", passkeyHandler.FinishLogin)
+			auth.POST("/change-password", authRequired, notImpersonating, authHandler.ChangePassword)
+			auth.POST("/setup-2fa", authRequired, notImpersonating, authHandler.Setup2FA)
+			auth.POST("/setup-2fa/confirm", authRequired, notImpersonating, authHandler.Confirm2FA)
+			auth.DELETE("/setup-2fa", authRequired, notImpersonating, accountHandler.Disable2FA)
 		}
 
 		profile := v1.Group("/profile")
 		{
 			profileProtected := profile.Group("")
-			profileProtected.Use(middleware.AuthMiddleware(tokenGen))
+			profileProtected.Use(authRequired)
 			profileProtected.Use(middleware.ContextSwitchMiddleware(contextService))
 			{
 				profileProtected.GET("/me", profileHandler.GetProfile)
 				profileProtected.PUT("/me", profileHandler.UpdateProfile)
 				profileProtected.PATCH("/me/preferences", profileHandler.UpdatePreferences)
-				profileProtected.DELETE("/me", profileHandler.DeleteProfile)
-				profileProtected.POST("/opt-out", profileHandler.OptOut)
-				profileProtected.POST("/anonymize", profileHandler.Anonymize)
-				profileProtected.POST("/delete_request", profileHandler.RequestDeletion)
+				profileProtected.DELETE("/me", notImpersonating, profileHandler.DeleteProfile)
+				profileProtected.POST("/opt-out", notImpersonating, profileHandler.OptOut)
+				profileProtected.POST("/anonymize", notImpersonating, profileHandler.Anonymize)
+				profileProtected.POST("/delete_request", notImpersonating, profileHandler.RequestDeletion)
 
 				// Context switching routes
 				profileProtected.GET("/available-contexts", contextSwitchHandler.GetAvailableContexts)
@@ -69,58 +117,100 @@ func SetupRoutes(router *gin.Engine, authHandler *handler.AuthHandler, profileHa
 		}
 
 		organizations := v1.Group("/organizations")
-		organizations.Use(middleware.AuthMiddleware(tokenGen))
+		organizations.Use(apiKeyAuth)
+		organizations.Use(middleware.RequireScope(apikeyModel.ScopeOrgAdmin))
 		organizations.Use(middleware.ValidateOrganizationMembership(contextService))
 		{
 			organizations.GET("", organizationHandler.ListOrganizations)
 			organizations.POST("", organizationHandler.CreateOrganization)
 			organizations.GET("/:org_id", organizationHandler.GetOrganization)
-			organizations.PUT("/:org_id", organizationHandler.UpdateOrganization)
-			organizations.DELETE("/:org_id", organizationHandler.DeleteOrganization)
+			organizations.PUT("/:org_id", requirePermission(permissionModel.PermissionOrgSettingsWrite), organizationHandler.UpdateOrganization)
+			organizations.DELETE("/:org_id", middleware.DenyAPIKeys(), notImpersonating, requirePermission(permissionModel.PermissionOrgDelete), organizationHandler.DeleteOrganization)
 			organizations.GET("/:org_id/members", organizationHandler.ListOrganizationMembers)
-			organizations.POST("/:org_id/members", organizationHandler.AddOrganizationMember)
-			organizations.PUT("/:org_id/members/:user_id", organizationHandler.UpdateOrganizationMemberRole)
-			organizations.DELETE("/:org_id/members/:user_id", organizationHandler.RemoveOrganizationMember)
+			organizations.PUT("/:org_id/members/:user_id", requirePermission(permissionModel.PermissionOrgMembersWrite), permissionHandler.AssignRole)
+			organizations.DELETE("/:org_id/members/:user_id", notImpersonating, requirePermission(permissionModel.PermissionOrgMembersWrite), organizationHandler.RemoveOrganizationMember)
+			organizations.GET("/:org_id/invitations", requirePermission(permissionModel.PermissionOrgMembersWrite), invitationHandler.ListInvitations)
+			organizations.POST("/:org_id/invitations", requirePermission(permissionModel.PermissionOrgMembersWrite), invitationHandler.CreateInvitation)
+			organizations.POST("/:org_id/invitations/:invitation_id/resend", requirePermission(permissionModel.PermissionOrgMembersWrite), invitationHandler.ResendInvitation)
+			organizations.DELETE("/:org_id/invitations/:invitation_id", notImpersonating, requirePermission(permissionModel.PermissionOrgMembersWrite), invitationHandler.RevokeInvitation)
+			organizations.GET("/:org_id/domains", requirePermission(permissionModel.PermissionOrgSettingsWrite), domainHandler.ListDomains)
+			organizations.POST("/:org_id/domains", requirePermission(permissionModel.PermissionOrgSettingsWrite), domainHandler.AddDomain)
+			organizations.PUT("/:org_id/domains/:domain_id", requirePermission(permissionModel.PermissionOrgSettingsWrite), domainHandler.UpdateDomain)
+			organizations.DELETE("/:org_id/domains/:domain_id", notImpersonating, requirePermission(permissionModel.PermissionOrgSettingsWrite), domainHandler.RemoveDomain)
+			organizations.POST("/:org_id/domains/:domain_id/verify", requirePermission(permissionModel.PermissionOrgSettingsWrite), domainHandler.VerifyDomain)
+			organizations.POST("/:org_id/domains/:domain_id/verification-email", requirePermission(permissionModel.PermissionOrgSettingsWrite), domainHandler.ResendVerificationEmail)
+			organizations.GET("/:org_id/join-requests", requirePermission(permissionModel.PermissionOrgMembersWrite), domainHandler.ListJoinRequests)
+			organizations.POST("/:org_id/join-requests/:request_id/approve", requirePermission(permissionModel.PermissionOrgMembersWrite), domainHandler.ApproveJoinRequest)
+			organizations.POST("/:org_id/join-requests/:request_id/deny", requirePermission(permissionModel.PermissionOrgMembersWrite), domainHandler.DenyJoinRequest)
+			organizations.GET("/:org_id/teams", teamHandler.ListTeams)
+			organizations.POST("/:org_id/teams", requirePermission(permissionModel.PermissionOrgMembersWrite), teamHandler.CreateTeam)
+			organizations.GET("/:org_id/teams/:team_id", teamHandler.GetTeam)
+			organizations.DELETE("/:org_id/teams/:team_id", notImpersonating, requirePermission(permissionModel.PermissionOrgMembersWrite), teamHandler.DeleteTeam)
+			organizations.PUT("/:org_id/teams/:team_id/members/:user_id", requirePermission(permissionModel.PermissionOrgMembersWrite), teamHandler.AddTeamMember)
+			organizations.DELETE("/:org_id/teams/:team_id/members/:user_id", notImpersonating, requirePermission(permissionModel.PermissionOrgMembersWrite), teamHandler.RemoveTeamMember)
 			organizations.GET("/:org_id/settings", organizationHandler.GetOrganizationSettings)
-			organizations.PUT("/:org_id/settings", organizationHandler.UpdateOrganizationSettings)
+			organizations.PUT("/:org_id/settings", requirePermission(permissionModel.PermissionOrgSettingsWrite), organizationHandler.UpdateOrganizationSettings)
+			organizations.GET("/:org_id/settings/password-policy", authHandler.GetPasswordPolicy)
+			organizations.PUT("/:org_id/settings/password-policy", requirePermission(permissionModel.PermissionOrgSettingsWrite), authHandler.UpdatePasswordPolicy)
+			organizations.GET("/:org_id/settings/sso", requirePermission(permissionModel.PermissionOrgSSOManage), ssoHandler.GetOIDCProvider)
+			organizations.PUT("/:org_id/settings/sso", requirePermission(permissionModel.PermissionOrgSSOManage), ssoHandler.ConfigureOIDCProvider)
+			organizations.GET("/:org_id/settings/saml", requirePermission(permissionModel.PermissionOrgSSOManage), ssoHandler.GetSAMLProvider)
+			organizations.PUT("/:org_id/settings/saml", requirePermission(permissionModel.PermissionOrgSSOManage), ssoHandler.ConfigureSAMLProvider)
+			organizations.GET("/:org_id/api-keys", middleware.DenyAPIKeys(), requirePermission(permissionModel.PermissionOrgAPIKeysManage), apiKeyHandler.ListOrganizationAPIKeys)
+			organizations.POST("/:org_id/api-keys", middleware.DenyAPIKeys(), notImpersonating, requirePermission(permissionModel.PermissionOrgAPIKeysManage), apiKeyHandler.CreateOrganizationAPIKey)
+			organizations.DELETE("/:org_id/api-keys/:key_id", middleware.DenyAPIKeys(), notImpersonating, requirePermission(permissionModel.PermissionOrgAPIKeysManage), apiKeyHandler.DeleteOrganizationAPIKey)
+			organizations.GET("/:org_id/roles", permissionHandler.ListRoles)
+			organizations.POST("/:org_id/roles", requirePermission(permissionModel.PermissionOrgRolesWrite), permissionHandler.CreateRole)
+			organizations.PUT("/:org_id/roles/:role_id", requirePermission(permissionModel.PermissionOrgRolesWrite), permissionHandler.UpdateRole)
+			organizations.DELETE("/:org_id/roles/:role_id", notImpersonating, requirePermission(permissionModel.PermissionOrgRolesWrite), permissionHandler.DeleteRole)
+			organizations.GET("/:org_id/role-grants", requirePermission(permissionModel.PermissionOrgRolesDelegate), permissionHandler.ListRoleGrants)
+			organizations.POST("/:org_id/role-grants", requirePermission(permissionModel.PermissionOrgRolesDelegate), permissionHandler.GrantRole)
+			organizations.DELETE("/:org_id/role-grants/:grant_id", notImpersonating, requirePermission(permissionModel.PermissionOrgRolesDelegate), permissionHandler.RevokeRoleGrant)
 
 			// Moderation routes nested under organizations
 			moderation := organizations.Group("/:org_id/moderation")
 			{
-				moderation.GET("/appeals", moderationHandler.ListAppeals)
+				moderation.GET("/appeals", requirePermission(permissionModel.PermissionModerationAppealsRead), moderationHandler.ListAppeals)
 				moderation.POST("/appeals", moderationHandler.SubmitAppeal)
-				moderation.GET("/appeals/:appeal_id/context", moderationHandler.GetAppealContext)
-				moderation.GET("/actions", moderationHandler.ListModerationActions)
-				moderation.GET("/history/:user_id", moderationHandler.GetModerationHistory)
+				moderation.GET("/appeals/:appeal_id/context", requirePermission(permissionModel.PermissionModerationAppealsReview), moderationHandler.GetAppealContext)
+				moderation.GET("/actions", requirePermission(permissionModel.PermissionModerationActionsRead), moderationHandler.ListModerationActions)
+				moderation.GET("/history/:user_id", requirePermission(permissionModel.PermissionModerationHistoryRead), moderationHandler.GetModerationHistory)
 			}
 		}
 
 		feedback := v1.Group("/feedback")
+		// Every feedback route needs a user or API key, and reads are scoped
+		// to the organization the user is acting in
+		feedback.Use(apiKeyAuth, middleware.ContextSwitchMiddleware(contextService))
 		{
-			feedback.GET("/feed", middleware.AuthMiddleware(tokenGen), feedbackHandler.GetFeed)
-			feedback.GET("/:feedback_id", middleware.AuthMiddleware(tokenGen), feedbackHandler.GetFeedbackByID)
-			feedback.GET("/:feedback_id/comments", middleware.AuthMiddleware(tokenGen), feedbackHandler.GetComments)
-			feedback.POST("", middleware.AuthMiddleware(tokenGen), feedbackHandler.CreateFeedback)
-			feedback.POST("/:feedback_id/comments", middleware.AuthMiddleware(tokenGen), feedbackHandler.CreateComment)
-			feedback.POST("/:feedback_id/react", middleware.AuthMiddleware(tokenGen), feedbackHandler.AddReaction)
-			feedback.DELETE("/:feedback_id/react", middleware.AuthMiddleware(tokenGen), feedbackHandler.RemoveReaction)
-			feedback.GET("/templates", feedbackHandler.GetTemplates)
-			feedback.POST("/template_suggestions", feedbackHandler.PostTemplateSuggestions)
-			feedback.GET("/impact", feedbackHandler.GetImpact)
-			feedback.POST("/batch", feedbackHandler.CreateBatchFeedback)
-			feedback.GET("/bookmarks", feedbackHandler.GetBookmarks)
-			feedback.POST("/bookmarks/:feedback_id", feedbackHandler.AddBookmark)
-			feedback.DELETE("/bookmarks/:feedback_id", feedbackHandler.RemoveBookmark)
-			feedback.GET("/export", feedbackHandler.ExportFeedback)
-			feedback.GET("/analytics", middleware.AuthMiddleware(tokenGen), feedbackHandler.GetFeedbackAnalytics)
-			feedback.PUT("/:feedback_id", middleware.AuthMiddleware(tokenGen), feedbackHandler.UpdateFeedback)
-			feedback.DELETE("/:feedback_id", middleware.AuthMiddleware(tokenGen), feedbackHandler.DeleteFeedback)
-			feedback.PUT("/:feedback_id/comments/:comment_id", middleware.AuthMiddleware(tokenGen), feedbackHandler.UpdateComment)
-			feedback.DELETE("/:feedback_id/comments/:comment_id", middleware.AuthMiddleware(tokenGen), feedbackHandler.DeleteComment)
+			feedback.GET("/feed", feedbackRead, feedbackHandler.GetFeed)
+			feedback.GET("/search", feedbackRead, feedbackHandler.SearchFeedback)
+			feedback.GET("/received", feedbackRead, feedbackHandler.GetReceivedFeedback)
+			feedback.GET("/given", feedbackRead, feedbackHandler.GetGivenFeedback)
+			feedback.GET("/:feedback_id", feedbackRead, feedbackHandler.GetFeedbackByID)
+			feedback.GET("/:feedback_id/comments", feedbackRead, feedbackHandler.GetComments)
+			feedback.POST("", feedbackWrite, feedbackHandler.CreateFeedback)
+			feedback.POST("/:feedback_id/comments", feedbackWrite, feedbackHandler.CreateComment)
+			feedback.POST("/:feedback_id/react", feedbackWrite, feedbackHandler.AddReaction)
+			feedback.DELETE("/:feedback_id/react", feedbackWrite, feedbackHandler.RemoveReaction)
+			feedback.GET("/templates", feedbackRead, feedbackHandler.GetTemplates)
+			feedback.GET("/templates/:template_id", feedbackRead, templateHandler.GetPublishedTemplate)
+			feedback.POST("/template_suggestions", feedbackWrite, feedbackHandler.PostTemplateSuggestions)
+			feedback.GET("/impact", feedbackRead, feedbackHandler.GetImpact)
+			feedback.POST("/batch", feedbackWrite, feedbackHandler.CreateBatchFeedback)
+			feedback.GET("/bookmarks", feedbackRead, feedbackHandler.GetBookmarks)
+			feedback.POST("/bookmarks/:feedback_id", feedbackWrite, feedbackHandler.AddBookmark)
+			feedback.DELETE("/bookmarks/:feedback_id", feedbackWrite, feedbackHandler.RemoveBookmark)
+			feedback.GET("/export", feedbackRead, feedbackHandler.ExportFeedback)
+			feedback.GET("/analytics", feedbackRead, feedbackHandler.GetFeedbackAnalytics)
+			feedback.PUT("/:feedback_id", feedbackWrite, feedbackHandler.UpdateFeedback)
+			feedback.DELETE("/:feedback_id", feedbackWrite, notImpersonating, feedbackHandler.DeleteFeedback)
+			feedback.PUT("/:feedback_id/comments/:comment_id", feedbackWrite, feedbackHandler.UpdateComment)
+			feedback.DELETE("/:feedback_id/comments/:comment_id", feedbackWrite, notImpersonating, feedbackHandler.DeleteComment)
 		}
 
 		notifications := v1.Group("/notifications")
-		notifications.Use(middleware.AuthMiddleware(tokenGen))
+		notifications.Use(authRequired)
 		{
 			notifications.GET("", notificationHandler.GetNotifications)
 			notifications.PUT("/:id/read", notificationHandler.MarkAsRead)
@@ -130,13 +220,13 @@ func SetupRoutes(router *gin.Engine, authHandler *handler.AuthHandler, profileHa
 		}
 
 		dashboard := v1.Group("/dashboard")
-		dashboard.Use(middleware.AuthMiddleware(tokenGen))
+		dashboard.Use(authRequired)
 		{
 			dashboard.GET("", dashboardHandler.GetDashboard)
 		}
 
 		people := v1.Group("/people")
-		people.Use(middleware.AuthMiddleware(tokenGen))
+		people.Use(authRequired)
 		{
 			people.GET("/search", peopleHandler.SearchPeople)
 			people.GET("/recommendations", peopleHandler.GetRecommendations)
@@ -148,11 +238,67 @@ func SetupRoutes(router *gin.Engine, authHandler *handler.AuthHandler, profileHa
 		}
 
 		account := v1.Group("/account")
-		account.Use(middleware.AuthMiddleware(tokenGen))
+		account.Use(authRequired)
 		{
 			account.GET("/security-events", accountHandler.GetSecurityEvents)
 			account.GET("/export-data/:export_id/status", accountHandler.GetExportStatus)
+			account.GET("/sessions", accountHandler.ListSessions)
+			account.DELETE("/sessions/:session_id", notImpersonating, accountHandler.RevokeSession)
+			account.GET("/passkeys", passkeyHandler.ListPasskeys)
+			account.DELETE("/passkeys/:passkey_id", notImpersonating, passkeyHandler.DeletePasskey)
+			account.GET("/api-keys", apiKeyHandler.ListUserAPIKeys)
+			account.POST("/api-keys", notImpersonating, apiKeyHandler.CreateUserAPIKey)
+			account.DELETE("/api-keys/:key_id", notImpersonating, apiKeyHandler.DeleteUserAPIKey)
+		}
+
+		// Invitation links work without signing in; existing accounts sign in to accept
+		invitations := v1.Group("/invitations")
+		{
+			invitations.GET("/:token", invitationHandler.GetInvitation)
+			invitations.POST("/accept", authRequired, notImpersonating, invitationHandler.AcceptInvitation)
+			invitations.POST("/register", invitationHandler.RegisterAndAccept)
+			invitations.POST("/decline", invitationHandler.DeclineInvitation)
 		}
+
+		admin := v1.Group("/admin")
+		admin.Use(authRequired)
+		{
+			admin.POST("/impersonations", notImpersonating, impersonationHandler.Start)
+			admin.DELETE("/impersonations/current", impersonationHandler.End)
+
+			// Feedback templates and the review of template suggestions
+			manageTemplates := requirePermission(permissionModel.PermissionFeedbackTemplatesManage)
+			admin.GET("/feedback/templates", manageTemplates, templateHandler.ListTemplates)
+			admin.POST("/feedback/templates", manageTemplates, templateHandler.CreateTemplate)
+			admin.GET("/feedback/templates/:template_id", manageTemplates, templateHandler.GetTemplate)
+			admin.PUT("/feedback/templates/:template_id", manageTemplates, templateHandler.UpdateTemplate)
+			admin.DELETE("/feedback/templates/:template_id", manageTemplates, templateHandler.ArchiveTemplate)
+			admin.GET("/feedback/template_suggestions", manageTemplates, templateHandler.ListSuggestions)
+			admin.POST("/feedback/template_suggestions/:suggestion_id/approve", manageTemplates, templateHandler.ApproveSuggestion)
+			admin.POST("/feedback/template_suggestions/:suggestion_id/reject", manageTemplates, templateHandler.RejectSuggestion)
+		}
+	}
+
+	// SCIM 2.0 provisioning for identity providers; the organization comes from the API key
+	scim := router.Group("/scim/v2")
+	scim.Use(scimHandler.Authenticate())
+	{
+		scim.GET("/ServiceProviderConfig", scimHandler.GetServiceProviderConfig)
+		scim.GET("/ResourceTypes", scimHandler.GetResourceTypes)
+
+		scim.GET("/Users", scimHandler.ListUsers)
+		scim.POST("/Users", scimHandler.CreateUser)
+		scim.GET("/Users/:id", scimHandler.GetUser)
+		scim.PUT("/Users/:id", scimHandler.ReplaceUser)
+		scim.PATCH("/Users/:id", scimHandler.PatchUser)
+		scim.DELETE("/Users/:id", scimHandler.DeleteUser)
+
+		scim.GET("/Groups", scimHandler.ListGroups)
+		scim.POST("/Groups", scimHandler.Authorize(permissionModel.PermissionOrgRolesWrite), scimHandler.CreateGroup)
+		scim.GET("/Groups/:id", scimHandler.GetGroup)
+		scim.PUT("/Groups/:id", scimHandler.ReplaceGroup)
+		scim.PATCH("/Groups/:id", scimHandler.PatchGroup)
+		scim.DELETE("/Groups/:id", scimHandler.Authorize(permissionModel.PermissionOrgRolesWrite), scimHandler.DeleteGroup)
 	}
 }
 
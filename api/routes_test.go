@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apikeyModel "ethos/internal/apikey/model"
+	organizationService "ethos/internal/organization/service"
+	permissionModel "ethos/internal/permission/model"
+	"ethos/pkg/jwt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memberContexts makes each user a member of every organization with their
+// role from the map. Only the methods the membership middleware uses are
+// implemented.
+type memberContexts struct {
+	organizationService.UserContextService
+	roles map[string]string
+}
+
+func (m memberContexts) ValidateUserInOrganization(ctx context.Context, userID, organizationID string) (bool, error) {
+	_, ok := m.roles[userID]
+	return ok, nil
+}
+
+func (m memberContexts) GetUserRoleInOrganization(ctx context.Context, userID, organizationID string) (string, error) {
+	return m.roles[userID], nil
+}
+
+// builtinRoleAuthorizer decides permissions from the built-in organization roles
+type builtinRoleAuthorizer struct{}
+
+func (builtinRoleAuthorizer) Authorize(ctx context.Context, subject permissionModel.Subject, permission string) (*permissionModel.Decision, error) {
+	if role, ok := permissionModel.BuiltinRoles[subject.OrganizationRole]; ok {
+		if granted, ok := role.Grants(permission); ok {
+			return &permissionModel.Decision{Allowed: true, Permission: permission, Reason: "granted by " + granted}, nil
+		}
+	}
+	return &permissionModel.Decision{Allowed: false, Permission: permission, Reason: fmt.Sprintf("your role %q does not grant %s", subject.OrganizationRole, permission)}, nil
+}
+
+// ownerAPIKeys authenticates every API key as an org:admin key of the owner
+type ownerAPIKeys struct{}
+
+func (ownerAPIKeys) Authenticate(ctx context.Context, token string) (*apikeyModel.Principal, error) {
+	return &apikeyModel.Principal{KeyID: "key-1", UserID: "owner-1", OrganizationID: "org-1", Scopes: []string{apikeyModel.ScopeOrgAdmin}}, nil
+}
+
+// setupRouter registers every route with the real middleware. Handlers are
+// nil, so only requests the middleware rejects can be made.
+func setupRouter(t *testing.T) (*gin.Engine, *jwt.TokenGenerator) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	tokenGen := jwt.NewTokenGenerator("test-access-secret", "test-refresh-secret", 15*time.Minute, 24*time.Hour)
+	contexts := memberContexts{roles: map[string]string{"owner-1": "owner", "admin-1": "admin", "member-1": "member"}}
+
+	router := gin.New()
+	SetupRoutes(router, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		tokenGen, contexts, nil, ownerAPIKeys{}, builtinRoleAuthorizer{}, nil)
+	return router, tokenGen
+}
+
+func TestDeleteOrganization_OnlyOwners(t *testing.T) {
+	router, tokenGen := setupRouter(t)
+
+	for _, userID := range []string{"admin-1", "member-1"} {
+		t.Run(userID, func(t *testing.T) {
+			token, err := tokenGen.GenerateAccessToken(userID)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest("DELETE", "/api/v1/organizations/org-1", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "PERMISSION_DENIED", response["code"])
+			assert.Equal(t, permissionModel.PermissionOrgDelete, response["permission"])
+		})
+	}
+}
+
+func TestDeleteOrganization_NotWithAPIKeys(t *testing.T) {
+	router, _ := setupRouter(t)
+
+	req := httptest.NewRequest("DELETE", "/api/v1/organizations/org-1", nil)
+	req.Header.Set("Authorization", "Bearer "+apikeyModel.TokenPrefix+"owner-key")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "API_KEY_NOT_ALLOWED")
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -13,7 +14,11 @@ import (
 	accountHandler "ethos/internal/account/handler"
 	accountRepository "ethos/internal/account/repository"
 	accountService "ethos/internal/account/service"
+	apikeyHandler "ethos/internal/apikey/handler"
+	apikeyRepository "ethos/internal/apikey/repository"
+	apikeyService "ethos/internal/apikey/service"
 	"ethos/internal/auth/handler"
+	authModel "ethos/internal/auth/model"
 	"ethos/internal/auth/repository"
 	"ethos/internal/auth/service"
 	"ethos/internal/cache"
@@ -22,26 +27,56 @@ import (
 	dashboardHandler "ethos/internal/dashboard/handler"
 	"ethos/internal/database"
 	feedbackHandler "ethos/internal/feedback/handler"
+	feedbackRepository "ethos/internal/feedback/repository"
+	feedbackService "ethos/internal/feedback/service"
+	impersonationHandler "ethos/internal/impersonation/handler"
+	impersonationService "ethos/internal/impersonation/service"
+	invitationHandler "ethos/internal/invitation/handler"
+	invitationRepository "ethos/internal/invitation/repository"
+	invitationService "ethos/internal/invitation/service"
 	moderationHandler "ethos/internal/moderation/handler"
 	moderationRepository "ethos/internal/moderation/repository"
 	moderationService "ethos/internal/moderation/service"
 	"ethos/internal/monitoring"
 	notificationHandler "ethos/internal/notifications/handler"
+	notificationRepository "ethos/internal/notifications/repository"
 	organizationHandler "ethos/internal/organization/handler"
 	organizationRepository "ethos/internal/organization/repository"
 	organizationService "ethos/internal/organization/service"
+	domainHandler "ethos/internal/orgdomain/handler"
+	domainRepository "ethos/internal/orgdomain/repository"
+	domainService "ethos/internal/orgdomain/service"
+	passkeyHandler "ethos/internal/passkey/handler"
+	passkeyRepository "ethos/internal/passkey/repository"
+	passkeyService "ethos/internal/passkey/service"
 	peopleHandler "ethos/internal/people/handler"
+	permissionHandler "ethos/internal/permission/handler"
+	permissionRepository "ethos/internal/permission/repository"
+	permissionService "ethos/internal/permission/service"
 	profileHandler "ethos/internal/profile/handler"
 	profileRepository "ethos/internal/profile/repository"
 	profileService "ethos/internal/profile/service"
 	"ethos/internal/ratelimit"
+	"ethos/internal/revocation"
+	scimHandler "ethos/internal/scim/handler"
+	scimRepository "ethos/internal/scim/repository"
+	scimService "ethos/internal/scim/service"
+	ssoHandler "ethos/internal/sso/handler"
+	ssoRepository "ethos/internal/sso/repository"
+	ssoService "ethos/internal/sso/service"
+	teamHandler "ethos/internal/team/handler"
+	teamRepository "ethos/internal/team/repository"
+	teamService "ethos/internal/team/service"
 	"ethos/pkg/email"
 	checkerClient "ethos/pkg/email/checker"
 	emailitClient "ethos/pkg/email/emailit"
 	mailpitClient "ethos/pkg/email/mailpit"
+	"ethos/pkg/geoip"
 	grpcClient "ethos/pkg/grpc/client"
 	"ethos/pkg/jwt"
+	"ethos/pkg/oidc"
 	"ethos/pkg/otel"
+	"ethos/pkg/pwnedpasswords"
 
 	"github.com/gin-gonic/gin"
 )
@@ -82,7 +117,12 @@ func main() {
 	rateLimiter := ratelimit.NewRedisRateLimiter(
 		cache.NewRedisCache(cfg.Cache.URL, cfg.Cache.Password, cfg.Cache.DB),
 	)
-	_ = rateLimiter // TODO: Use rateLimiter in middleware
+
+	// Revoked sessions and access tokens are remembered until the tokens expire
+	revocations := revocation.NewRedisList(
+		cache.NewRedisCache(cfg.Cache.URL, cfg.Cache.Password, cfg.Cache.DB),
+		cfg.JWT.AccessTokenExpiry,
+	)
 
 	// Initialize health monitor
 	healthMonitor := monitoring.NewHealthMonitor()
@@ -94,12 +134,10 @@ func main() {
 
 	// Initialize dependencies
 	authRepo := repository.NewPostgresRepository(db)
-	tokenGen := jwt.NewTokenGenerator(
-		cfg.JWT.AccessTokenSecret,
-		cfg.JWT.RefreshTokenSecret,
-		cfg.JWT.AccessTokenExpiry,
-		cfg.JWT.RefreshTokenExpiry,
-	)
+	tokenGen, err := newTokenGenerator(cfg.JWT)
+	if err != nil {
+		log.Fatalf("Failed to load JWT signing keys: %v", err)
+	}
 
 	// Initialize email checker (optional - can be nil if not configured)
 	var emailChecker checkerClient.EmailChecker
@@ -134,9 +172,128 @@ func main() {
 		log.Println("Warning: No email sender configured, using no-op")
 	}
 
-	authService := service.NewAuthService(authRepo, tokenGen, emailChecker, emailSender)
+	// Sessions live in the organization context repository and are revoked by auth flows
+	orgContextRepo := organizationRepository.NewPostgresContextRepository(db)
+
+	// Organization settings decide whether unverified users may log in
+	orgRepo := organizationRepository.NewPostgresRepository(db)
+	orgSvc := organizationService.NewOrganizationService(orgRepo)
+
+	// Login alerts locate IP addresses with an offline GeoIP database, when one is configured
+	var geo service.GeoLocator
+	if cfg.Security.GeoIPDatabase != "" {
+		geoDB, err := geoip.Open(cfg.Security.GeoIPDatabase)
+		if err != nil {
+			log.Fatalf("Failed to load GeoIP database: %v", err)
+		}
+		geo = geoDB
+	}
+
+	// New passwords are checked against an offline breached password dataset, when one is configured
+	var breaches service.BreachChecker
+	if cfg.Password.BreachedPasswordsDir != "" {
+		dataset, err := pwnedpasswords.Open(cfg.Password.BreachedPasswordsDir)
+		if err != nil {
+			log.Fatalf("Failed to open breached password dataset: %v", err)
+		}
+		breaches = dataset
+	}
+
+	// Verified organization domains link users to their organization once their address is verified
+	domainRepo := domainRepository.NewPostgresRepository(db)
+	domainSvc := domainService.NewDomainService(domainRepo, orgRepo, domainService.NewResolver(cfg.Domains.DNSServer), orgContextRepo, emailSender, domainService.Config{
+		CodeTTL: cfg.Domains.CodeTTL,
+	})
+
+	authService := service.NewAuthService(authRepo, tokenGen, emailChecker, emailSender, orgContextRepo, revocations, rateLimiter, orgSvc, geo, breaches, domainSvc, service.Config{
+		TwoFactorIssuer:          cfg.Security.TwoFactorIssuer,
+		TwoFactorEncryptionKey:   cfg.Security.TwoFactorEncryptionKey,
+		PasswordResetTTL:         cfg.Security.PasswordResetTTL,
+		EmailVerificationSecret:  cfg.Security.EmailVerificationSecret,
+		EmailVerificationTTL:     cfg.Security.EmailVerificationTTL,
+		LoginMaxAttempts:         cfg.Security.LoginMaxAttempts,
+		LoginMaxAttemptsPerIP:    cfg.Security.LoginMaxAttemptsPerIP,
+		LoginAttemptWindow:       cfg.Security.LoginAttemptWindow,
+		LoginLockoutDuration:     cfg.Security.LoginLockoutDuration,
+		LoginDelayBase:           cfg.Security.LoginDelayBase,
+		LoginDelayMax:            cfg.Security.LoginDelayMax,
+		LoginAlerts:              cfg.Security.LoginAlerts,
+		ImpossibleTravelSpeedKmh: cfg.Security.ImpossibleTravelSpeedKmh,
+		PasswordPolicy: authModel.PasswordPolicy{
+			MinLength:        cfg.Password.MinLength,
+			RequireUppercase: cfg.Password.RequireUppercase,
+			RequireLowercase: cfg.Password.RequireLowercase,
+			RequireNumber:    cfg.Password.RequireNumber,
+			RequireSymbol:    cfg.Password.RequireSymbol,
+			HistoryCount:     cfg.Password.History,
+			MaxAgeDays:       cfg.Password.MaxAgeDays,
+			RejectBreached:   cfg.Password.RejectBreached,
+		},
+	})
 	authHandler := handler.NewAuthHandler(authService)
 
+	// Initialize organization SSO; login state lives in Redis between the redirect and the callback
+	ssoRepo := ssoRepository.NewPostgresRepository(db)
+	ssoSvc := ssoService.NewSSOService(ssoRepo, orgRepo, authRepo, domainRepo, authService,
+		cache.NewRedisCache(cfg.Cache.URL, cfg.Cache.Password, cfg.Cache.DB),
+		oidc.NewClient(nil),
+		ssoService.Config{
+			RedirectURL:            cfg.SSO.RedirectURL,
+			SecretEncryptionKey:    cfg.SSO.SecretEncryptionKey,
+			ServiceProviderBaseURL: cfg.SSO.ServiceProviderBaseURL,
+		})
+	ssoHandler := ssoHandler.NewSSOHandler(ssoSvc)
+
+	// Initialize passkeys; ceremony challenges live in Redis between the begin and finish steps
+	passkeyRepo := passkeyRepository.NewPostgresRepository(db)
+	passkeySvc := passkeyService.NewPasskeyService(passkeyRepo, authRepo, authService,
+		cache.NewRedisCache(cfg.Cache.URL, cfg.Cache.Password, cfg.Cache.DB),
+		passkeyService.Config{
+			RPID:    cfg.WebAuthn.RPID,
+			RPName:  cfg.WebAuthn.RPName,
+			Origins: cfg.WebAuthn.Origins,
+		})
+	passkeyHandler := passkeyHandler.NewPasskeyHandler(passkeySvc)
+
+	// Initialize personal access tokens for scripts and integrations
+	apiKeyRepo := apikeyRepository.NewPostgresRepository(db)
+	apiKeySvc := apikeyService.NewAPIKeyService(apiKeyRepo)
+	apiKeyHandler := apikeyHandler.NewAPIKeyHandler(apiKeySvc)
+
+	// Initialize the permission engine and custom organization roles
+	permissionRepo := permissionRepository.NewPostgresRepository(db)
+	permissionSvc := permissionService.NewPermissionService(permissionRepo, orgContextRepo, notificationRepository.NewPostgresRepository(db), permissionService.Config{
+		ExpiryNotice:     cfg.Roles.ExpiryNotice,
+		MaxGrantDuration: cfg.Roles.MaxGrantDuration,
+	})
+	permissionHandler := permissionHandler.NewPermissionHandler(permissionSvc)
+
+	// Initialize platform admin impersonation
+	impersonationSvc := impersonationService.NewImpersonationService(authRepo, orgContextRepo, tokenGen, revocations, impersonationService.Config{
+		TTL: cfg.Security.ImpersonationTTL,
+	})
+	impersonationHandler := impersonationHandler.NewImpersonationHandler(impersonationSvc)
+
+	// Initialize organization invitations; accepting one can create the invitee's account
+	invitationRepo := invitationRepository.NewPostgresRepository(db)
+	invitationSvc := invitationService.NewInvitationService(invitationRepo, orgRepo, authRepo, authService, permissionSvc, orgContextRepo, emailSender, invitationService.Config{
+		TTL: cfg.Invitations.TTL,
+		URL: cfg.Invitations.URL,
+	})
+	invitationHandler := invitationHandler.NewInvitationHandler(invitationSvc)
+	domainHandler := domainHandler.NewDomainHandler(domainSvc)
+
+	// Initialize SCIM provisioning, authenticated with organization API keys
+	scimSvc := scimService.NewSCIMService(scimRepository.NewPostgresRepository(db), authRepo, domainRepo, orgRepo, orgSvc, permissionSvc, orgContextRepo)
+	scimHandler := scimHandler.NewSCIMHandler(scimSvc, apiKeySvc, orgContextRepo, permissionSvc)
+
+	teamSvc := teamService.NewTeamService(teamRepository.NewPostgresRepository(db), orgContextRepo, orgContextRepo)
+	teamHandler := teamHandler.NewTeamHandler(teamSvc)
+
+	// Feedback templates are administered without the rest of the feedback service
+	templateSvc := feedbackService.NewTemplateService(feedbackRepository.NewPostgresRepository(db))
+	templateHandler := feedbackHandler.NewTemplateHandler(templateSvc)
+
 	// Initialize profile dependencies
 	profileRepo := profileRepository.NewPostgresRepository(db)
 	profileSvc := profileService.NewProfileService(profileRepo)
@@ -174,7 +331,7 @@ func main() {
 
 	// Initialize account dependencies
 	accountRepo := accountRepository.NewPostgresRepository(db)
-	accountSvc := accountService.NewAccountService(accountRepo)
+	accountSvc := accountService.NewAccountService(accountRepo, authService, orgContextRepo, authService)
 	accountHandler := accountHandler.NewAccountHandler(accountSvc)
 
 	// Initialize moderation dependencies
@@ -183,19 +340,16 @@ func main() {
 	moderationHandler := moderationHandler.NewModerationHandler(moderationSvc)
 
 	// Initialize organization dependencies
-	orgRepo := organizationRepository.NewPostgresRepository(db)
-	orgSvc := organizationService.NewOrganizationService(orgRepo)
 	orgHandler := organizationHandler.NewOrganizationHandler(orgSvc)
 
 	// Initialize organization context switching dependencies
-	orgContextRepo := organizationRepository.NewPostgresContextRepository(db)
 	orgContextSvc := organizationService.NewUserContextService(orgContextRepo)
-	contextSwitchHandler := organizationHandler.NewContextSwitchHandler(orgContextSvc)
+	contextSwitchHandler := organizationHandler.NewContextSwitchHandler(orgContextSvc, authService)
 
 	// Setup router
 	router := gin.New()
 	api.SetupMiddleware(router)
-	api.SetupRoutes(router, authHandler, profileHandler, feedbackHandler, notificationHandler, dashboardHandler, orgHandler, contextSwitchHandler, peopleHandler, communityHandler, accountHandler, moderationHandler, tokenGen, orgContextSvc)
+	api.SetupRoutes(router, authHandler, profileHandler, feedbackHandler, notificationHandler, dashboardHandler, orgHandler, contextSwitchHandler, peopleHandler, communityHandler, accountHandler, moderationHandler, ssoHandler, passkeyHandler, apiKeyHandler, permissionHandler, impersonationHandler, invitationHandler, domainHandler, scimHandler, teamHandler, templateHandler, tokenGen, orgContextSvc, revocations, apiKeySvc, permissionSvc, orgContextRepo)
 
 	// Create HTTP server
 	srv := &http.Server{
@@ -213,12 +367,17 @@ func main() {
 		}
 	}()
 
+	// Expire time-bound roles and send expiry notices in the background
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	go permissionService.RunRoleExpiry(jobCtx, permissionSvc, cfg.Roles.ExpiryCheckInterval)
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Println("Shutting down server...")
+	stopJobs()
 
 	// Graceful shutdown with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -231,6 +390,47 @@ func main() {
 	log.Println("Server exited")
 }
 
+// newTokenGenerator uses asymmetric access token signing when a signing key
+// file is configured, and falls back to the shared HS256 secret otherwise
+func newTokenGenerator(cfg config.JWTConfig) (*jwt.TokenGenerator, error) {
+	if cfg.SigningKeyFile == "" {
+		tokenGen := jwt.NewTokenGenerator(cfg.AccessTokenSecret, cfg.RefreshTokenSecret, cfg.AccessTokenExpiry, cfg.RefreshTokenExpiry)
+		tokenGen.SetIssuer(cfg.Issuer, cfg.Audience)
+		return tokenGen, nil
+	}
+
+	keyring := jwt.NewKeyring()
+	for kid, path := range cfg.VerificationKeyFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("verification key %s: %w", kid, err)
+		}
+		public, err := jwt.ParsePublicKeyPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("verification key %s: %w", kid, err)
+		}
+		if err := keyring.AddVerificationKey(kid, public); err != nil {
+			return nil, fmt.Errorf("verification key %s: %w", kid, err)
+		}
+	}
+
+	data, err := os.ReadFile(cfg.SigningKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("signing key: %w", err)
+	}
+	private, err := jwt.ParsePrivateKeyPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("signing key: %w", err)
+	}
+	if err := keyring.AddSigningKey(cfg.SigningKeyID, private); err != nil {
+		return nil, fmt.Errorf("signing key: %w", err)
+	}
+
+	tokenGen := jwt.NewAsymmetricTokenGenerator(keyring, cfg.RefreshTokenSecret, cfg.AccessTokenExpiry, cfg.RefreshTokenExpiry)
+	tokenGen.SetIssuer(cfg.Issuer, cfg.Audience)
+	return tokenGen, nil
+}
+
 // Health checkers for system components
 type databaseHealthChecker struct {
 	db *database.DB
@@ -109,7 +109,16 @@ func (h *AccountHandler) Disable2FA(c *gin.Context) {
 		return
 	}
 
-	err := h.service.Disable2FA(c.Request.Context(), userID.(string))
+	var req service.Disable2FARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "A current two-factor code is required",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	err := h.service.Disable2FA(c.Request.Context(), userID.(string), req.Code)
 	if err != nil {
 		if apiErr, ok := err.(*errors.APIError); ok {
 			c.JSON(apiErr.HTTPStatus, gin.H{
@@ -130,3 +139,70 @@ func (h *AccountHandler) Disable2FA(c *gin.Context) {
 	})
 }
 
+// ListSessions handles GET /api/v1/account/sessions
+// Lists sessions in the token's organization unless ?organization_id= is given
+func (h *AccountHandler) ListSessions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid token",
+			"code":  "AUTH_TOKEN_INVALID",
+		})
+		return
+	}
+
+	organizationID := c.DefaultQuery("organization_id", c.GetString("token_organization_id"))
+
+	sessions, err := h.service.ListSessions(c.Request.Context(), userID.(string), organizationID, c.GetString("session_id"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sessions": sessions,
+		"count":    len(sessions),
+	})
+}
+
+// RevokeSession handles DELETE /api/v1/account/sessions/:session_id
+func (h *AccountHandler) RevokeSession(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid token",
+			"code":  "AUTH_TOKEN_INVALID",
+		})
+		return
+	}
+
+	err := h.service.RevokeSession(c.Request.Context(), userID.(string), c.Param("session_id"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Session revoked.",
+	})
+}
@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	accountModel "ethos/internal/account/model"
+	"ethos/pkg/errors"
 	"ethos/pkg/jwt"
 )
 
@@ -35,8 +37,21 @@ func (m *MockAccountService) GetExportStatus(ctx context.Context, userID, export
 	return args.Get(0).(*accountModel.DataExport), args.Error(1)
 }
 
-func (m *MockAccountService) Disable2FA(ctx context.Context, userID string) error {
-	args := m.Called(ctx, userID)
+func (m *MockAccountService) Disable2FA(ctx context.Context, userID, code string) error {
+	args := m.Called(ctx, userID, code)
+	return args.Error(0)
+}
+
+func (m *MockAccountService) ListSessions(ctx context.Context, userID, organizationID, currentSessionID string) ([]*accountModel.Session, error) {
+	args := m.Called(ctx, userID, organizationID, currentSessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*accountModel.Session), args.Error(1)
+}
+
+func (m *MockAccountService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
 	return args.Error(0)
 }
 
@@ -51,6 +66,8 @@ func setupAccountRouter(handler *AccountHandler, tokenGen *jwt.TokenGenerator) *
 	})
 	account.GET("/security-events", handler.GetSecurityEvents)
 	account.GET("/export-data/:export_id/status", handler.GetExportStatus)
+	account.GET("/sessions", handler.ListSessions)
+	account.DELETE("/sessions/:session_id", handler.RevokeSession)
 	
 	auth := v1.Group("/auth")
 	auth.Use(func(c *gin.Context) {
@@ -177,10 +194,11 @@ func TestDisable2FA_Success(t *testing.T) {
 	handler := NewAccountHandler(mockService)
 	tokenGen := jwt.NewTokenGenerator("test-secret", "test-refresh-secret", 15, 336)
 
-	mockService.On("Disable2FA", mock.Anything, "test-user-id").Return(nil)
+	mockService.On("Disable2FA", mock.Anything, "test-user-id", "123456").Return(nil)
 
 	router := setupAccountRouter(handler, tokenGen)
-	req, _ := http.NewRequest("DELETE", "/api/v1/auth/setup-2fa", nil)
+	req, _ := http.NewRequest("DELETE", "/api/v1/auth/setup-2fa", strings.NewReader(`{"code":"123456"}`))
+	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
@@ -197,10 +215,11 @@ func TestDisable2FA_NotEnabled(t *testing.T) {
 	handler := NewAccountHandler(mockService)
 	tokenGen := jwt.NewTokenGenerator("test-secret", "test-refresh-secret", 15, 336)
 
-	mockService.On("Disable2FA", mock.Anything, "test-user-id").Return(assert.AnError)
+	mockService.On("Disable2FA", mock.Anything, "test-user-id", "123456").Return(assert.AnError)
 
 	router := setupAccountRouter(handler, tokenGen)
-	req, _ := http.NewRequest("DELETE", "/api/v1/auth/setup-2fa", nil)
+	req, _ := http.NewRequest("DELETE", "/api/v1/auth/setup-2fa", strings.NewReader(`{"code":"123456"}`))
+	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
@@ -208,3 +227,57 @@ func TestDisable2FA_NotEnabled(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+
+func TestDisable2FA_MissingCode(t *testing.T) {
+	mockService := new(MockAccountService)
+	handler := NewAccountHandler(mockService)
+	tokenGen := jwt.NewTokenGenerator("test-secret", "test-refresh-secret", 15, 336)
+
+	router := setupAccountRouter(handler, tokenGen)
+	req, _ := http.NewRequest("DELETE", "/api/v1/auth/setup-2fa", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "Disable2FA")
+}
+
+func TestListSessions_Success(t *testing.T) {
+	mockService := new(MockAccountService)
+	handler := NewAccountHandler(mockService)
+	tokenGen := jwt.NewTokenGenerator("test-secret", "test-refresh-secret", 15, 336)
+
+	sessions := []*accountModel.Session{
+		{SessionID: "session-1", IP: "127.0.0.1", Current: true},
+		{SessionID: "session-2", IP: "10.0.0.1"},
+	}
+	mockService.On("ListSessions", mock.Anything, "test-user-id", "org-1", "").Return(sessions, nil)
+
+	router := setupAccountRouter(handler, tokenGen)
+	req, _ := http.NewRequest("GET", "/api/v1/account/sessions?organization_id=org-1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), response["count"])
+	mockService.AssertExpectations(t)
+}
+
+func TestRevokeSession_NotFound(t *testing.T) {
+	mockService := new(MockAccountService)
+	handler := NewAccountHandler(mockService)
+	tokenGen := jwt.NewTokenGenerator("test-secret", "test-refresh-secret", 15, 336)
+
+	mockService.On("RevokeSession", mock.Anything, "test-user-id", "session-2").Return(errors.ErrNotFound)
+
+	router := setupAccountRouter(handler, tokenGen)
+	req, _ := http.NewRequest("DELETE", "/api/v1/account/sessions/session-2", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
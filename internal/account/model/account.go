@@ -11,6 +11,18 @@ type SecurityEvent struct {
 	Location  string    `json:"location"`
 }
 
+// Session represents an active login session shown under /api/v1/account/sessions
+type Session struct {
+	SessionID      string    `json:"session_id"`
+	IP             string    `json:"ip"`
+	UserAgent      string    `json:"user_agent"`
+	DeviceName     string    `json:"device_name,omitempty"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	Current        bool      `json:"current"`
+}
+
 // DataExport represents a data export request
 type DataExport struct {
 	ExportID    string     `json:"export_id"`
@@ -14,7 +14,7 @@ type Repository interface {
 	// GetExportStatus retrieves export status
 	GetExportStatus(ctx context.Context, userID, exportID string) (*model.DataExport, error)
 
-	// Disable2FA disables two-factor authentication
+	// Disable2FA disables two-factor authentication and removes recovery codes
 	Disable2FA(ctx context.Context, userID string) error
 }
 
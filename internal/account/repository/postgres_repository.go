@@ -121,13 +121,21 @@ func (r *PostgresRepository) Disable2FA(ctx context.Context, userID string) erro
 	ctx, span := otel.Tracer("repository").Start(ctx, "repository.Disable2FA")
 	defer span.End()
 
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
 		UPDATE users
-		SET two_factor_enabled = FALSE, two_factor_secret = NULL
+		SET two_factor_enabled = FALSE, two_factor_secret = NULL, two_factor_last_step = 0
 		WHERE id = $1
 	`
 
-	result, err := r.db.Pool.Exec(ctx, query, userID)
+	result, err := tx.Exec(ctx, query, userID)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -138,6 +146,24 @@ func (r *PostgresRepository) Disable2FA(ctx context.Context, userID string) erro
 		return errors.ErrUserNotFound
 	}
 
+	// Recovery codes and pending challenges are meaningless once 2FA is off
+	if _, err := tx.Exec(ctx, `DELETE FROM two_factor_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to delete recovery codes")
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM login_challenges WHERE user_id = $1`, userID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to delete login challenges")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to commit transaction")
+	}
+
 	span.SetStatus(codes.Ok, "")
 	return nil
 }
@@ -6,6 +6,11 @@ import (
 	"ethos/internal/account/model"
 )
 
+// Disable2FARequest represents a request to turn off two-factor authentication
+type Disable2FARequest struct {
+	Code string `json:"code" binding:"required"` // TOTP or recovery code
+}
+
 // Service defines the interface for account/security business logic
 type Service interface {
 	// GetSecurityEvents retrieves security events for a user
@@ -14,7 +19,13 @@ type Service interface {
 	// GetExportStatus retrieves the status of a data export
 	GetExportStatus(ctx context.Context, userID, exportID string) (*model.DataExport, error)
 
-	// Disable2FA disables two-factor authentication
-	Disable2FA(ctx context.Context, userID string) error
+	// Disable2FA disables two-factor authentication after verifying a current TOTP or recovery code
+	Disable2FA(ctx context.Context, userID, code string) error
+
+	// ListSessions lists the user's active sessions in an organization, marking the current one
+	ListSessions(ctx context.Context, userID, organizationID, currentSessionID string) ([]*model.Session, error)
+
+	// RevokeSession ends one of the user's sessions
+	RevokeSession(ctx context.Context, userID, sessionID string) error
 }
 
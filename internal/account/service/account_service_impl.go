@@ -2,20 +2,45 @@ package service
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"ethos/internal/account/model"
 	"ethos/internal/account/repository"
+	orgRepository "ethos/internal/organization/repository"
+	"ethos/pkg/errors"
 )
 
+// TwoFactorVerifier verifies a user's second factor (implemented by the auth service)
+type TwoFactorVerifier interface {
+	VerifyTwoFactorCode(ctx context.Context, userID, code string) error
+}
+
+// SessionLister lists a user's sessions (implemented by the organization context repository)
+type SessionLister interface {
+	GetUserSessionsByOrganization(ctx context.Context, userID, organizationID string) ([]*orgRepository.UserSession, error)
+}
+
+// SessionRevoker ends a user's session and its tokens (implemented by the auth service)
+type SessionRevoker interface {
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+}
+
 // AccountService implements the Service interface
 type AccountService struct {
-	repo repository.Repository
+	repo              repository.Repository
+	twoFactorVerifier TwoFactorVerifier
+	sessions          SessionLister
+	sessionRevoker    SessionRevoker
 }
 
 // NewAccountService creates a new account service
-func NewAccountService(repo repository.Repository) Service {
+func NewAccountService(repo repository.Repository, twoFactorVerifier TwoFactorVerifier, sessions SessionLister, sessionRevoker SessionRevoker) Service {
 	return &AccountService{
-		repo: repo,
+		repo:              repo,
+		twoFactorVerifier: twoFactorVerifier,
+		sessions:          sessions,
+		sessionRevoker:    sessionRevoker,
 	}
 }
 
@@ -39,8 +64,20 @@ func (s *AccountService) GetExportStatus(ctx context.Context, userID, exportID s
 	return export, nil
 }
 
-// Disable2FA disables two-factor authentication
-func (s *AccountService) Disable2FA(ctx context.Context, userID string) error {
+// Disable2FA disables two-factor authentication after verifying a current TOTP or recovery code
+func (s *AccountService) Disable2FA(ctx context.Context, userID, code string) error {
+	if strings.TrimSpace(code) == "" {
+		return errors.NewValidationError("a current two-factor code is required")
+	}
+
+	if s.twoFactorVerifier == nil {
+		return errors.ErrServerError
+	}
+
+	if err := s.twoFactorVerifier.VerifyTwoFactorCode(ctx, userID, code); err != nil {
+		return err
+	}
+
 	err := s.repo.Disable2FA(ctx, userID)
 	if err != nil {
 		return err
@@ -49,3 +86,43 @@ func (s *AccountService) Disable2FA(ctx context.Context, userID string) error {
 	return nil
 }
 
+// ListSessions lists the user's active sessions in an organization, marking the current one.
+// An empty organization ID lists sessions that aren't bound to an organization.
+func (s *AccountService) ListSessions(ctx context.Context, userID, organizationID, currentSessionID string) ([]*model.Session, error) {
+	if s.sessions == nil {
+		return nil, errors.ErrServerError
+	}
+
+	userSessions, err := s.sessions.GetUserSessionsByOrganization(ctx, userID, organizationID)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get sessions")
+	}
+
+	sessions := make([]*model.Session, 0, len(userSessions))
+	for _, session := range userSessions {
+		if !session.ExpiresAt.After(time.Now()) {
+			continue
+		}
+		sessions = append(sessions, &model.Session{
+			SessionID:      session.ID,
+			IP:             session.IPAddress,
+			UserAgent:      session.UserAgent,
+			DeviceName:     session.DeviceName,
+			LastActivityAt: session.LastActivityAt,
+			CreatedAt:      session.CreatedAt,
+			ExpiresAt:      session.ExpiresAt,
+			Current:        session.ID == currentSessionID,
+		})
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession ends one of the user's sessions
+func (s *AccountService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	if s.sessionRevoker == nil {
+		return errors.ErrServerError
+	}
+
+	return s.sessionRevoker.RevokeSession(ctx, userID, sessionID)
+}
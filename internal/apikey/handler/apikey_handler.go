@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"net/http"
+
+	"ethos/internal/apikey/model"
+	"ethos/internal/apikey/service"
+	"ethos/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHandler handles API key HTTP requests
+type APIKeyHandler struct {
+	service service.Service
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(svc service.Service) *APIKeyHandler {
+	return &APIKeyHandler{
+		service: svc,
+	}
+}
+
+// CreateUserAPIKey handles POST /api/v1/account/api-keys
+// The token is only returned in this response
+func (h *APIKeyHandler) CreateUserAPIKey(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid token",
+			"code":  "AUTH_TOKEN_INVALID",
+		})
+		return
+	}
+
+	var req model.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	key, err := h.service.CreateUserAPIKey(c.Request.Context(), userID.(string), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
+
+// ListUserAPIKeys handles GET /api/v1/account/api-keys
+func (h *APIKeyHandler) ListUserAPIKeys(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid token",
+			"code":  "AUTH_TOKEN_INVALID",
+		})
+		return
+	}
+
+	keys, err := h.service.ListUserAPIKeys(c.Request.Context(), userID.(string))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"api_keys": keys,
+		"count":    len(keys),
+	})
+}
+
+// DeleteUserAPIKey handles DELETE /api/v1/account/api-keys/:key_id
+func (h *APIKeyHandler) DeleteUserAPIKey(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid token",
+			"code":  "AUTH_TOKEN_INVALID",
+		})
+		return
+	}
+
+	if err := h.service.DeleteUserAPIKey(c.Request.Context(), userID.(string), c.Param("key_id")); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "API key revoked.",
+	})
+}
+
+// CreateOrganizationAPIKey handles POST /api/v1/organizations/:org_id/api-keys
+// The token is only returned in this response
+func (h *APIKeyHandler) CreateOrganizationAPIKey(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid token",
+			"code":  "AUTH_TOKEN_INVALID",
+		})
+		return
+	}
+
+	var req model.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	key, err := h.service.CreateOrganizationAPIKey(c.Request.Context(), c.Param("org_id"), userID.(string), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
+
+// ListOrganizationAPIKeys handles GET /api/v1/organizations/:org_id/api-keys
+func (h *APIKeyHandler) ListOrganizationAPIKeys(c *gin.Context) {
+	keys, err := h.service.ListOrganizationAPIKeys(c.Request.Context(), c.Param("org_id"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"api_keys": keys,
+		"count":    len(keys),
+	})
+}
+
+// DeleteOrganizationAPIKey handles DELETE /api/v1/organizations/:org_id/api-keys/:key_id
+func (h *APIKeyHandler) DeleteOrganizationAPIKey(c *gin.Context) {
+	if err := h.service.DeleteOrganizationAPIKey(c.Request.Context(), c.Param("org_id"), c.Param("key_id")); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "API key revoked.",
+	})
+}
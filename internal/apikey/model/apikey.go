@@ -0,0 +1,84 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// TokenPrefix starts every personal access token, so the auth middleware can
+// tell them from JWTs and secret scanners can recognise leaked ones
+const TokenPrefix = "ethos_pat_"
+
+// Scopes grantable to API keys
+const (
+	ScopeFeedbackRead  = "feedback:read"
+	ScopeFeedbackWrite = "feedback:write"
+	ScopeOrgAdmin      = "org:admin"
+	// ScopeSCIM provisions users and groups over SCIM; organization keys only
+	ScopeSCIM = "scim:provision"
+)
+
+// ValidScopes lists the scopes an API key may be created with
+var ValidScopes = []string{ScopeFeedbackRead, ScopeFeedbackWrite, ScopeOrgAdmin, ScopeSCIM}
+
+// HasScope reports whether granted scopes allow a required scope. A write
+// scope also allows reading the same resource.
+func HasScope(granted []string, required string) bool {
+	resource, action, _ := strings.Cut(required, ":")
+	for _, scope := range granted {
+		if scope == required || (action == "read" && scope == resource+":write") {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey is a personal access token owned by a user, or by an organization
+// when OrganizationID is set. Only a hash of the token is stored.
+type APIKey struct {
+	ID             string
+	UserID         string // Owner, or creator of an organization key
+	OrganizationID string
+	Name           string
+	TokenPrefix    string // First characters of the token, to recognise it in listings
+	TokenHash      string
+	Scopes         []string
+	ExpiresAt      *time.Time
+	LastUsedAt     *time.Time
+	CreatedAt      time.Time
+}
+
+// Principal is who a request authenticated with an API key acts as
+type Principal struct {
+	KeyID          string
+	UserID         string
+	OrganizationID string // Set for organization keys, which only reach their own organization
+	Scopes         []string
+}
+
+// CreateAPIKeyRequest represents a request to create an API key
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Scopes        []string `json:"scopes" binding:"required,min=1"`
+	ExpiresInDays *int     `json:"expires_in_days" binding:"omitempty,min=1,max=365"` // Never expires when omitted
+}
+
+// APIKeyResponse represents an API key for API responses. The token itself
+// is only returned once, when the key is created.
+type APIKeyResponse struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	OrganizationID string     `json:"organization_id,omitempty"`
+	CreatedBy      string     `json:"created_by"`
+	TokenPrefix    string     `json:"token_prefix"`
+	Scopes         []string   `json:"scopes"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// CreateAPIKeyResponse carries a new key and its token
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Token string `json:"token"`
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"ethos/internal/apikey/model"
+)
+
+// Repository defines the interface for API key data access
+type Repository interface {
+	// CreateAPIKey stores a new API key
+	CreateAPIKey(ctx context.Context, key *model.APIKey) error
+
+	// GetAPIKeyByHash retrieves an API key by its token hash, or ErrNotFound
+	GetAPIKeyByHash(ctx context.Context, tokenHash string) (*model.APIKey, error)
+
+	// ListUserAPIKeys lists a user's personal API keys, newest first
+	ListUserAPIKeys(ctx context.Context, userID string) ([]*model.APIKey, error)
+
+	// ListOrganizationAPIKeys lists an organization's API keys, newest first
+	ListOrganizationAPIKeys(ctx context.Context, organizationID string) ([]*model.APIKey, error)
+
+	// DeleteUserAPIKey deletes one of a user's personal API keys, or returns ErrNotFound
+	DeleteUserAPIKey(ctx context.Context, userID, id string) error
+
+	// DeleteOrganizationAPIKey deletes one of an organization's API keys, or returns ErrNotFound
+	DeleteOrganizationAPIKey(ctx context.Context, organizationID, id string) error
+
+	// TouchAPIKey records that a key was used, at most once a minute
+	TouchAPIKey(ctx context.Context, id string) error
+}
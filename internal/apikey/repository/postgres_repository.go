@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+
+	"ethos/internal/apikey/model"
+	"ethos/internal/database"
+	"ethos/pkg/errors"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const apiKeyColumns = `
+	id::text, user_id, COALESCE(organization_id::text, ''), name, token_prefix, token_hash, scopes,
+	expires_at, last_used_at, created_at
+`
+
+// PostgresRepository implements the Repository interface using PostgreSQL
+type PostgresRepository struct {
+	db *database.DB
+}
+
+// NewPostgresRepository creates a new PostgreSQL repository
+func NewPostgresRepository(db *database.DB) Repository {
+	return &PostgresRepository{db: db}
+}
+
+// CreateAPIKey stores a new API key
+func (r *PostgresRepository) CreateAPIKey(ctx context.Context, key *model.APIKey) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.CreateAPIKey")
+	defer span.End()
+
+	query := `
+		INSERT INTO api_keys (user_id, organization_id, name, token_prefix, token_hash, scopes, expires_at)
+		VALUES ($1, NULLIF($2, '')::uuid, $3, $4, $5, $6, $7)
+		RETURNING id::text, created_at
+	`
+
+	err := r.db.Pool.QueryRow(ctx, query,
+		key.UserID,
+		key.OrganizationID,
+		key.Name,
+		key.TokenPrefix,
+		key.TokenHash,
+		key.Scopes,
+		key.ExpiresAt,
+	).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to create API key")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// GetAPIKeyByHash retrieves an API key by its token hash, or ErrNotFound
+func (r *PostgresRepository) GetAPIKeyByHash(ctx context.Context, tokenHash string) (*model.APIKey, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetAPIKeyByHash")
+	defer span.End()
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE token_hash = $1`
+
+	key, err := scanAPIKey(r.db.Pool.QueryRow(ctx, query, tokenHash))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get API key")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return key, nil
+}
+
+// ListUserAPIKeys lists a user's personal API keys, newest first
+func (r *PostgresRepository) ListUserAPIKeys(ctx context.Context, userID string) ([]*model.APIKey, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ListUserAPIKeys")
+	defer span.End()
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE user_id = $1 AND organization_id IS NULL ORDER BY created_at DESC`
+
+	keys, err := r.listAPIKeys(ctx, query, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return keys, nil
+}
+
+// ListOrganizationAPIKeys lists an organization's API keys, newest first
+func (r *PostgresRepository) ListOrganizationAPIKeys(ctx context.Context, organizationID string) ([]*model.APIKey, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ListOrganizationAPIKeys")
+	defer span.End()
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE organization_id::text = $1 ORDER BY created_at DESC`
+
+	keys, err := r.listAPIKeys(ctx, query, organizationID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return keys, nil
+}
+
+// DeleteUserAPIKey deletes one of a user's personal API keys, or returns ErrNotFound
+func (r *PostgresRepository) DeleteUserAPIKey(ctx context.Context, userID, id string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.DeleteUserAPIKey")
+	defer span.End()
+
+	query := `DELETE FROM api_keys WHERE id::text = $1 AND user_id = $2 AND organization_id IS NULL`
+
+	result, err := r.db.Pool.Exec(ctx, query, id, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to delete API key")
+	}
+	if result.RowsAffected() == 0 {
+		return errors.ErrNotFound
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// DeleteOrganizationAPIKey deletes one of an organization's API keys, or returns ErrNotFound
+func (r *PostgresRepository) DeleteOrganizationAPIKey(ctx context.Context, organizationID, id string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.DeleteOrganizationAPIKey")
+	defer span.End()
+
+	query := `DELETE FROM api_keys WHERE id::text = $1 AND organization_id::text = $2`
+
+	result, err := r.db.Pool.Exec(ctx, query, id, organizationID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to delete API key")
+	}
+	if result.RowsAffected() == 0 {
+		return errors.ErrNotFound
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// TouchAPIKey records that a key was used, at most once a minute
+func (r *PostgresRepository) TouchAPIKey(ctx context.Context, id string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.TouchAPIKey")
+	defer span.End()
+
+	query := `
+		UPDATE api_keys
+		SET last_used_at = CURRENT_TIMESTAMP
+		WHERE id::text = $1 AND (last_used_at IS NULL OR last_used_at < CURRENT_TIMESTAMP - INTERVAL '1 minute')
+	`
+
+	if _, err := r.db.Pool.Exec(ctx, query, id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to update API key usage")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+func (r *PostgresRepository) listAPIKeys(ctx context.Context, query string, arg string) ([]*model.APIKey, error) {
+	rows, err := r.db.Pool.Query(ctx, query, arg)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to list API keys")
+	}
+	defer rows.Close()
+
+	keys := []*model.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to scan API key")
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, "failed to list API keys")
+	}
+	return keys, nil
+}
+
+func scanAPIKey(row pgx.Row) (*model.APIKey, error) {
+	key := &model.APIKey{}
+	err := row.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.OrganizationID,
+		&key.Name,
+		&key.TokenPrefix,
+		&key.TokenHash,
+		&key.Scopes,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"ethos/internal/apikey/model"
+)
+
+// Service defines the interface for API keys
+type Service interface {
+	// CreateUserAPIKey creates a personal API key acting as the user
+	CreateUserAPIKey(ctx context.Context, userID string, req *model.CreateAPIKeyRequest) (*model.CreateAPIKeyResponse, error)
+
+	// ListUserAPIKeys lists a user's personal API keys
+	ListUserAPIKeys(ctx context.Context, userID string) ([]*model.APIKeyResponse, error)
+
+	// DeleteUserAPIKey revokes one of a user's personal API keys
+	DeleteUserAPIKey(ctx context.Context, userID, keyID string) error
+
+	// CreateOrganizationAPIKey creates an API key limited to an organization
+	CreateOrganizationAPIKey(ctx context.Context, organizationID, userID string, req *model.CreateAPIKeyRequest) (*model.CreateAPIKeyResponse, error)
+
+	// ListOrganizationAPIKeys lists an organization's API keys
+	ListOrganizationAPIKeys(ctx context.Context, organizationID string) ([]*model.APIKeyResponse, error)
+
+	// DeleteOrganizationAPIKey revokes one of an organization's API keys
+	DeleteOrganizationAPIKey(ctx context.Context, organizationID, keyID string) error
+
+	// Authenticate resolves a personal access token to the principal it acts as
+	Authenticate(ctx context.Context, token string) (*model.Principal, error)
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"ethos/internal/apikey/model"
+	"ethos/internal/apikey/repository"
+	"ethos/pkg/errors"
+)
+
+// displayPrefixLength is how many characters of a token are kept to recognise it
+const displayPrefixLength = len(model.TokenPrefix) + 8
+
+// APIKeyService implements the Service interface
+type APIKeyService struct {
+	repo repository.Repository
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(repo repository.Repository) Service {
+	return &APIKeyService{
+		repo: repo,
+	}
+}
+
+// CreateUserAPIKey creates a personal API key acting as the user
+func (s *APIKeyService) CreateUserAPIKey(ctx context.Context, userID string, req *model.CreateAPIKeyRequest) (*model.CreateAPIKeyResponse, error) {
+	return s.createAPIKey(ctx, &model.APIKey{UserID: userID}, req)
+}
+
+// ListUserAPIKeys lists a user's personal API keys
+func (s *APIKeyService) ListUserAPIKeys(ctx context.Context, userID string) ([]*model.APIKeyResponse, error) {
+	keys, err := s.repo.ListUserAPIKeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toResponses(keys), nil
+}
+
+// DeleteUserAPIKey revokes one of a user's personal API keys
+func (s *APIKeyService) DeleteUserAPIKey(ctx context.Context, userID, keyID string) error {
+	return s.repo.DeleteUserAPIKey(ctx, userID, keyID)
+}
+
+// CreateOrganizationAPIKey creates an API key limited to an organization.
+// Requests made with it act as the creating member, and stop working on the
+// organization's routes if that member leaves.
+func (s *APIKeyService) CreateOrganizationAPIKey(ctx context.Context, organizationID, userID string, req *model.CreateAPIKeyRequest) (*model.CreateAPIKeyResponse, error) {
+	return s.createAPIKey(ctx, &model.APIKey{UserID: userID, OrganizationID: organizationID}, req)
+}
+
+// ListOrganizationAPIKeys lists an organization's API keys
+func (s *APIKeyService) ListOrganizationAPIKeys(ctx context.Context, organizationID string) ([]*model.APIKeyResponse, error) {
+	keys, err := s.repo.ListOrganizationAPIKeys(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return toResponses(keys), nil
+}
+
+// DeleteOrganizationAPIKey revokes one of an organization's API keys
+func (s *APIKeyService) DeleteOrganizationAPIKey(ctx context.Context, organizationID, keyID string) error {
+	return s.repo.DeleteOrganizationAPIKey(ctx, organizationID, keyID)
+}
+
+// Authenticate resolves a personal access token to the principal it acts as.
+// Tokens are looked up by hash, so a database leak doesn't reveal usable keys.
+func (s *APIKeyService) Authenticate(ctx context.Context, token string) (*model.Principal, error) {
+	if !strings.HasPrefix(token, model.TokenPrefix) {
+		return nil, errors.ErrTokenInvalid
+	}
+
+	key, err := s.repo.GetAPIKeyByHash(ctx, hashToken(token))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrTokenInvalid
+		}
+		return nil, err
+	}
+	if key.ExpiresAt != nil && !time.Now().Before(*key.ExpiresAt) {
+		return nil, errors.ErrTokenExpired
+	}
+
+	if err := s.repo.TouchAPIKey(ctx, key.ID); err != nil {
+		fmt.Printf("Failed to record API key usage for %s: %v\n", key.ID, err)
+	}
+
+	return &model.Principal{
+		KeyID:          key.ID,
+		UserID:         key.UserID,
+		OrganizationID: key.OrganizationID,
+		Scopes:         key.Scopes,
+	}, nil
+}
+
+// createAPIKey validates the request, generates the token and stores its hash
+func (s *APIKeyService) createAPIKey(ctx context.Context, key *model.APIKey, req *model.CreateAPIKeyRequest) (*model.CreateAPIKeyResponse, error) {
+	scopes, err := validateScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if key.OrganizationID == "" && model.HasScope(scopes, model.ScopeSCIM) {
+		return nil, errors.NewValidationError(fmt.Sprintf("the %s scope is only available to organization API keys", model.ScopeSCIM))
+	}
+
+	secret, err := generateRandomToken()
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to generate API key")
+	}
+	token := model.TokenPrefix + secret
+
+	key.Name = strings.TrimSpace(req.Name)
+	key.TokenPrefix = token[:displayPrefixLength]
+	key.TokenHash = hashToken(token)
+	key.Scopes = scopes
+	if req.ExpiresInDays != nil {
+		expiresAt := time.Now().Add(time.Duration(*req.ExpiresInDays) * 24 * time.Hour)
+		key.ExpiresAt = &expiresAt
+	}
+
+	if err := s.repo.CreateAPIKey(ctx, key); err != nil {
+		return nil, err
+	}
+	return &model.CreateAPIKeyResponse{APIKeyResponse: *toResponse(key), Token: token}, nil
+}
+
+// validateScopes rejects unknown scopes and drops duplicates
+func validateScopes(requested []string) ([]string, error) {
+	scopes := make([]string, 0, len(requested))
+	seen := make(map[string]bool)
+	for _, scope := range requested {
+		valid := false
+		for _, known := range model.ValidScopes {
+			if scope == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, errors.NewValidationError(fmt.Sprintf("unknown scope %q; valid scopes are %s", scope, strings.Join(model.ValidScopes, ", ")))
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, errors.NewValidationError("at least one scope is required")
+	}
+	return scopes, nil
+}
+
+func toResponses(keys []*model.APIKey) []*model.APIKeyResponse {
+	responses := make([]*model.APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		responses = append(responses, toResponse(key))
+	}
+	return responses
+}
+
+func toResponse(key *model.APIKey) *model.APIKeyResponse {
+	return &model.APIKeyResponse{
+		ID:             key.ID,
+		Name:           key.Name,
+		OrganizationID: key.OrganizationID,
+		CreatedBy:      key.UserID,
+		TokenPrefix:    key.TokenPrefix,
+		Scopes:         key.Scopes,
+		ExpiresAt:      key.ExpiresAt,
+		LastUsedAt:     key.LastUsedAt,
+		CreatedAt:      key.CreatedAt,
+	}
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// generateRandomToken generates the secret part of a token
+func generateRandomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
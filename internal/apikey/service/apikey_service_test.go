@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"ethos/internal/apikey/model"
+	"ethos/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRepository keeps API keys in memory
+type fakeRepository struct {
+	keys    map[string]*model.APIKey
+	touched []string
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{keys: map[string]*model.APIKey{}}
+}
+
+func (r *fakeRepository) CreateAPIKey(ctx context.Context, key *model.APIKey) error {
+	key.ID = "key-" + key.TokenHash[:8]
+	key.CreatedAt = time.Now()
+	r.keys[key.TokenHash] = key
+	return nil
+}
+
+func (r *fakeRepository) GetAPIKeyByHash(ctx context.Context, tokenHash string) (*model.APIKey, error) {
+	key, ok := r.keys[tokenHash]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	return key, nil
+}
+
+func (r *fakeRepository) ListUserAPIKeys(ctx context.Context, userID string) ([]*model.APIKey, error) {
+	return nil, nil
+}
+
+func (r *fakeRepository) ListOrganizationAPIKeys(ctx context.Context, organizationID string) ([]*model.APIKey, error) {
+	return nil, nil
+}
+
+func (r *fakeRepository) DeleteUserAPIKey(ctx context.Context, userID, id string) error {
+	return nil
+}
+
+func (r *fakeRepository) DeleteOrganizationAPIKey(ctx context.Context, organizationID, id string) error {
+	return nil
+}
+
+func (r *fakeRepository) TouchAPIKey(ctx context.Context, id string) error {
+	r.touched = append(r.touched, id)
+	return nil
+}
+
+func TestCreateAndAuthenticate(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewAPIKeyService(repo)
+	ctx := context.Background()
+
+	created, err := svc.CreateOrganizationAPIKey(ctx, "org-1", "user-1", &model.CreateAPIKeyRequest{
+		Name:   "  export script ",
+		Scopes: []string{model.ScopeFeedbackRead, model.ScopeFeedbackRead},
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Token, model.TokenPrefix))
+	assert.True(t, strings.HasPrefix(created.Token, created.TokenPrefix))
+	assert.Equal(t, "export script", created.Name)
+	assert.Equal(t, []string{model.ScopeFeedbackRead}, created.Scopes)
+
+	for hash := range repo.keys {
+		assert.NotContains(t, hash, created.Token[len(model.TokenPrefix):], "token must not be stored in plain text")
+	}
+
+	principal, err := svc.Authenticate(ctx, created.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", principal.UserID)
+	assert.Equal(t, "org-1", principal.OrganizationID)
+	assert.Equal(t, []string{created.ID}, repo.touched)
+}
+
+func TestAuthenticate_Rejections(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewAPIKeyService(repo)
+	ctx := context.Background()
+
+	days := 1
+	created, err := svc.CreateUserAPIKey(ctx, "user-1", &model.CreateAPIKeyRequest{
+		Name:          "expiring",
+		Scopes:        []string{model.ScopeFeedbackWrite},
+		ExpiresInDays: &days,
+	})
+	require.NoError(t, err)
+
+	_, err = svc.Authenticate(ctx, "not-a-personal-access-token")
+	assert.Equal(t, errors.ErrTokenInvalid, err)
+
+	_, err = svc.Authenticate(ctx, model.TokenPrefix+"unknown")
+	assert.Equal(t, errors.ErrTokenInvalid, err)
+
+	expired := time.Now().Add(-time.Minute)
+	repo.keys[hashToken(created.Token)].ExpiresAt = &expired
+	_, err = svc.Authenticate(ctx, created.Token)
+	assert.Equal(t, errors.ErrTokenExpired, err)
+	assert.Empty(t, repo.touched)
+}
+
+func TestCreateAPIKey_RejectsUnknownScope(t *testing.T) {
+	svc := NewAPIKeyService(newFakeRepository())
+
+	_, err := svc.CreateUserAPIKey(context.Background(), "user-1", &model.CreateAPIKeyRequest{
+		Name:   "bad",
+		Scopes: []string{"feedback:delete"},
+	})
+	require.Error(t, err)
+	apiErr, ok := err.(*errors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+}
+
+func TestCreateAPIKey_SCIMScopeNeedsOrganization(t *testing.T) {
+	svc := NewAPIKeyService(newFakeRepository())
+	req := &model.CreateAPIKeyRequest{Name: "hr", Scopes: []string{model.ScopeSCIM}}
+
+	_, err := svc.CreateUserAPIKey(context.Background(), "user-1", req)
+	apiErr, ok := err.(*errors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+
+	created, err := svc.CreateOrganizationAPIKey(context.Background(), "org-1", "user-1", req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{model.ScopeSCIM}, created.Scopes)
+}
+
+func TestHasScope(t *testing.T) {
+	assert.True(t, model.HasScope([]string{model.ScopeFeedbackRead}, model.ScopeFeedbackRead))
+	assert.True(t, model.HasScope([]string{model.ScopeFeedbackWrite}, model.ScopeFeedbackRead))
+	assert.False(t, model.HasScope([]string{model.ScopeFeedbackRead}, model.ScopeFeedbackWrite))
+	assert.False(t, model.HasScope([]string{model.ScopeOrgAdmin}, model.ScopeFeedbackRead))
+}
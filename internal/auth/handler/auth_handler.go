@@ -2,10 +2,12 @@ package handler
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"time"
 
+	"ethos/internal/auth/model"
 	"ethos/internal/auth/service"
 	"ethos/pkg/errors"
 
@@ -34,6 +36,8 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		})
 		return
 	}
+	req.IP = c.ClientIP()
+	req.UserAgent = c.GetHeader("User-Agent")
 
 	resp, err := h.service.Login(c.Request.Context(), &req)
 	if err != nil {
@@ -54,6 +58,38 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// LoginTwoFactor handles POST /api/v1/auth/login/2fa
+func (h *AuthHandler) LoginTwoFactor(c *gin.Context) {
+	var req service.TwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+	req.IP = c.ClientIP()
+	req.UserAgent = c.GetHeader("User-Agent")
+
+	resp, err := h.service.CompleteTwoFactorLogin(c.Request.Context(), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
 // Register handles POST /api/v1/auth/register
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req service.RegisterRequest
@@ -131,6 +167,40 @@ func (h *AuthHandler) RequestPasswordReset(c *gin.Context) {
 	})
 }
 
+// ResetPassword handles POST /api/v1/auth/reset-password
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req service.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+	req.IP = c.ClientIP()
+
+	err := h.service.ResetPassword(c.Request.Context(), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to reset password",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "password_reset",
+		"message": "Your password has been reset. Please log in with your new password.",
+	})
+}
+
 // Refresh handles POST /api/v1/auth/refresh
 func (h *AuthHandler) Refresh(c *gin.Context) {
 	var req service.RefreshRequest
@@ -141,6 +211,7 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 		})
 		return
 	}
+	req.IP = c.ClientIP()
 
 	resp, err := h.service.RefreshToken(c.Request.Context(), &req)
 	if err != nil {
@@ -161,6 +232,50 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// Logout handles POST /api/v1/auth/logout
+// The body is optional; {"all_devices": true} ends every session of the user
+func (h *AuthHandler) Logout(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid token",
+			"code":  "AUTH_TOKEN_INVALID",
+		})
+		return
+	}
+
+	var req service.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+	req.UserID = userID.(string)
+	req.SessionID = c.GetString("session_id")
+	req.TokenID = c.GetString("token_id")
+
+	if err := h.service.Logout(c.Request.Context(), &req); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Logged out successfully",
+	})
+}
+
 // Me handles GET /api/v1/auth/me
 func (h *AuthHandler) Me(c *gin.Context) {
 	userID, exists := c.Get("user_id")
@@ -224,6 +339,72 @@ func (h *AuthHandler) VerifyEmail(c *gin.Context) {
 	})
 }
 
+// ResendVerification handles POST /api/v1/auth/resend-verification
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var req service.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	err := h.service.ResendVerification(c.Request.Context(), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	// Always return success for security (don't reveal if email exists or is verified)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "If an unverified account with this email exists, a new verification link has been sent.",
+	})
+}
+
+// UnlockAccount handles POST /api/v1/auth/unlock-account
+func (h *AuthHandler) UnlockAccount(c *gin.Context) {
+	var req service.UnlockAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+	req.IP = c.ClientIP()
+
+	err := h.service.UnlockAccount(c.Request.Context(), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Your account has been unlocked. You can now log in.",
+	})
+}
+
 // ChangePassword handles POST /api/v1/auth/change-password
 func (h *AuthHandler) ChangePassword(c *gin.Context) {
 	userID, exists := c.Get("user_id")
@@ -266,6 +447,57 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 	})
 }
 
+// GetPasswordPolicy handles GET /api/v1/organizations/:org_id/settings/password-policy
+func (h *AuthHandler) GetPasswordPolicy(c *gin.Context) {
+	policy, err := h.service.GetPasswordPolicy(c.Request.Context(), c.Param("org_id"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// UpdatePasswordPolicy handles PUT /api/v1/organizations/:org_id/settings/password-policy
+func (h *AuthHandler) UpdatePasswordPolicy(c *gin.Context) {
+	var req model.UpdatePasswordPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	policy, err := h.service.UpdatePasswordPolicy(c.Request.Context(), c.Param("org_id"), c.GetString("user_id"), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
 // Setup2FA handles POST /api/v1/auth/setup-2fa
 func (h *AuthHandler) Setup2FA(c *gin.Context) {
 	userID, exists := c.Get("user_id")
@@ -305,6 +537,45 @@ func (h *AuthHandler) Setup2FA(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// Confirm2FA handles POST /api/v1/auth/setup-2fa/confirm
+func (h *AuthHandler) Confirm2FA(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+			"code":  "AUTH_REQUIRED",
+		})
+		return
+	}
+
+	var req service.Confirm2FARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	resp, err := h.service.Confirm2FA(c.Request.Context(), userID.(string), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to confirm 2FA",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
 // MULTI-TENANT FUNCTIONALITY HANDLERS
 
 // ListUserTenants handles GET /api/v1/auth/tenants
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ethos/internal/auth/service"
+	"ethos/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupResetPasswordRouter(handler *AuthHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/auth/reset-password", handler.ResetPassword)
+	return router
+}
+
+func TestResetPassword_Success(t *testing.T) {
+	mockService := new(MockAuthService)
+	handler := NewAuthHandler(mockService)
+
+	mockService.On("ResetPassword", mock.Anything, mock.MatchedBy(func(req *service.ResetPasswordRequest) bool {
+		return req.Token == "reset-token" && req.NewPassword == "NewPassword123!" && req.IP != ""
+	})).Return(nil)
+
+	router := setupResetPasswordRouter(handler)
+	body, _ := json.Marshal(map[string]string{"token": "reset-token", "new_password": "NewPassword123!"})
+	req, _ := http.NewRequest("POST", "/api/v1/auth/reset-password", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = "203.0.113.7:1234"
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "password_reset", response["status"])
+	mockService.AssertExpectations(t)
+}
+
+func TestResetPassword_InvalidToken(t *testing.T) {
+	mockService := new(MockAuthService)
+	handler := NewAuthHandler(mockService)
+
+	mockService.On("ResetPassword", mock.Anything, mock.Anything).Return(errors.ErrTokenInvalid)
+
+	router := setupResetPasswordRouter(handler)
+	body, _ := json.Marshal(map[string]string{"token": "expired", "new_password": "NewPassword123!"})
+	req, _ := http.NewRequest("POST", "/api/v1/auth/reset-password", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "AUTH_TOKEN_INVALID", response["code"])
+}
+
+func TestResetPassword_MissingFields(t *testing.T) {
+	mockService := new(MockAuthService)
+	handler := NewAuthHandler(mockService)
+
+	router := setupResetPasswordRouter(handler)
+	req, _ := http.NewRequest("POST", "/api/v1/auth/reset-password", bytes.NewBufferString(`{"token":"abc"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ResetPassword", mock.Anything, mock.Anything)
+}
+
+func TestResendVerification_AlwaysGeneric(t *testing.T) {
+	mockService := new(MockAuthService)
+	handler := NewAuthHandler(mockService)
+
+	mockService.On("ResendVerification", mock.Anything, mock.MatchedBy(func(req *service.ResendVerificationRequest) bool {
+		return req.Email == "jane@example.com"
+	})).Return(nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/auth/resend-verification", handler.ResendVerification)
+
+	body, _ := json.Marshal(map[string]string{"email": "jane@example.com"})
+	req, _ := http.NewRequest("POST", "/api/v1/auth/resend-verification", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
@@ -46,6 +46,21 @@ func (m *MockAuthService) RefreshToken(ctx context.Context, req *service.Refresh
 	return args.Get(0).(*service.LoginResponse), args.Error(1)
 }
 
+func (m *MockAuthService) Logout(ctx context.Context, req *service.LogoutRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) IssueAccessToken(ctx context.Context, userID, sessionID, organizationID string) (string, error) {
+	args := m.Called(ctx, userID, sessionID, organizationID)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockAuthService) GetUserProfile(ctx context.Context, userID string) (*model.UserProfile, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -64,11 +79,50 @@ func (m *MockAuthService) VerifyEmail(ctx context.Context, token string) error {
 	return args.Error(0)
 }
 
+func (m *MockAuthService) ResendVerification(ctx context.Context, req *service.ResendVerificationRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) CompleteExternalLogin(ctx context.Context, userID, organizationID, ip, userAgent string) (*service.LoginResponse, error) {
+	args := m.Called(ctx, userID, organizationID, ip, userAgent)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.LoginResponse), args.Error(1)
+}
+
+func (m *MockAuthService) UnlockAccount(ctx context.Context, req *service.UnlockAccountRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
 func (m *MockAuthService) RequestPasswordReset(ctx context.Context, req *service.RequestPasswordResetRequest) error {
 	args := m.Called(ctx, req)
 	return args.Error(0)
 }
 
+func (m *MockAuthService) ResetPassword(ctx context.Context, req *service.ResetPasswordRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) GetPasswordPolicy(ctx context.Context, organizationID string) (*service.PasswordPolicyResponse, error) {
+	args := m.Called(ctx, organizationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.PasswordPolicyResponse), args.Error(1)
+}
+
+func (m *MockAuthService) UpdatePasswordPolicy(ctx context.Context, organizationID, actorID string, req *model.UpdatePasswordPolicyRequest) (*service.PasswordPolicyResponse, error) {
+	args := m.Called(ctx, organizationID, actorID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.PasswordPolicyResponse), args.Error(1)
+}
+
 func (m *MockAuthService) Setup2FA(ctx context.Context, userID string, req *service.Setup2FARequest) (*service.Setup2FAResponse, error) {
 	args := m.Called(ctx, userID, req)
 	if args.Get(0) == nil {
@@ -77,6 +131,27 @@ func (m *MockAuthService) Setup2FA(ctx context.Context, userID string, req *serv
 	return args.Get(0).(*service.Setup2FAResponse), args.Error(1)
 }
 
+func (m *MockAuthService) Confirm2FA(ctx context.Context, userID string, req *service.Confirm2FARequest) (*service.Confirm2FAResponse, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.Confirm2FAResponse), args.Error(1)
+}
+
+func (m *MockAuthService) CompleteTwoFactorLogin(ctx context.Context, req *service.TwoFactorLoginRequest) (*service.LoginResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.LoginResponse), args.Error(1)
+}
+
+func (m *MockAuthService) VerifyTwoFactorCode(ctx context.Context, userID, code string) error {
+	args := m.Called(ctx, userID, code)
+	return args.Error(0)
+}
+
 func (m *MockAuthService) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
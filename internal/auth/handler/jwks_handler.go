@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+
+	"ethos/pkg/jwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JWKSHandler publishes the public keys that verify access tokens
+type JWKSHandler struct {
+	tokenGen *jwt.TokenGenerator
+}
+
+// NewJWKSHandler creates a new JWKS handler
+func NewJWKSHandler(tokenGen *jwt.TokenGenerator) *JWKSHandler {
+	return &JWKSHandler{tokenGen: tokenGen}
+}
+
+// GetJWKS handles GET /.well-known/jwks.json. With HS256 access tokens there
+// is nothing safe to publish, so the key set is empty.
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	keys := jwt.JWKS{Keys: []jwt.JWK{}}
+	if keyring := h.tokenGen.Keyring(); keyring != nil {
+		keys = keyring.JWKS()
+	}
+
+	// Verifiers cache the set; keep it short so rotated keys are picked up quickly
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, keys)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"ethos/pkg/jwt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveJWKS(t *testing.T, tokenGen *jwt.TokenGenerator) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/.well-known/jwks.json", NewJWKSHandler(tokenGen).GetJWKS)
+
+	req, _ := http.NewRequest("GET", "/.well-known/jwks.json", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetJWKS_PublishesPublicKeys(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keyring := jwt.NewKeyring()
+	require.NoError(t, keyring.AddSigningKey("key-1", key))
+	tokenGen := jwt.NewAsymmetricTokenGenerator(keyring, "refresh-secret", time.Minute, time.Hour)
+
+	w := serveJWKS(t, tokenGen)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	verifierKeys, err := jwt.ParseJWKS(w.Body.Bytes())
+	require.NoError(t, err)
+
+	token, err := tokenGen.GenerateAccessToken("user-1")
+	require.NoError(t, err)
+	userID, err := jwt.NewVerifier(verifierKeys).ValidateAccessToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", userID)
+}
+
+func TestGetJWKS_EmptyForSharedSecret(t *testing.T) {
+	w := serveJWKS(t, jwt.NewTokenGenerator("access", "refresh", time.Minute, time.Hour))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body map[string][]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Empty(t, body["keys"])
+}
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// PasswordPolicy describes what a password must look like and how long it
+// may be used. The system policy applies to everyone; an organization can
+// tighten it for its members but never relax it.
+type PasswordPolicy struct {
+	MinLength        int  `json:"min_length"`
+	RequireUppercase bool `json:"require_uppercase"`
+	RequireLowercase bool `json:"require_lowercase"`
+	RequireNumber    bool `json:"require_number"`
+	RequireSymbol    bool `json:"require_symbol"`
+	// HistoryCount is how many previous passwords can't be reused
+	HistoryCount int `json:"history_count"`
+	// MaxAgeDays is how long a password lasts before it must be changed; zero means forever
+	MaxAgeDays int `json:"max_age_days"`
+	// RejectBreached rejects passwords found in the breached password dataset
+	RejectBreached bool `json:"reject_breached"`
+}
+
+// UpdatePasswordPolicyRequest changes an organization's password policy;
+// omitted fields keep their current value
+type UpdatePasswordPolicyRequest struct {
+	MinLength        *int  `json:"min_length" binding:"omitempty,min=0,max=128"`
+	RequireUppercase *bool `json:"require_uppercase"`
+	RequireLowercase *bool `json:"require_lowercase"`
+	RequireNumber    *bool `json:"require_number"`
+	RequireSymbol    *bool `json:"require_symbol"`
+	HistoryCount     *int  `json:"history_count" binding:"omitempty,min=0,max=24"`
+	MaxAgeDays       *int  `json:"max_age_days" binding:"omitempty,min=0,max=3650"`
+	RejectBreached   *bool `json:"reject_breached"`
+}
+
+// Apply sets the fields present in req
+func (p *PasswordPolicy) Apply(req *UpdatePasswordPolicyRequest) {
+	if req.MinLength != nil {
+		p.MinLength = *req.MinLength
+	}
+	if req.RequireUppercase != nil {
+		p.RequireUppercase = *req.RequireUppercase
+	}
+	if req.RequireLowercase != nil {
+		p.RequireLowercase = *req.RequireLowercase
+	}
+	if req.RequireNumber != nil {
+		p.RequireNumber = *req.RequireNumber
+	}
+	if req.RequireSymbol != nil {
+		p.RequireSymbol = *req.RequireSymbol
+	}
+	if req.HistoryCount != nil {
+		p.HistoryCount = *req.HistoryCount
+	}
+	if req.MaxAgeDays != nil {
+		p.MaxAgeDays = *req.MaxAgeDays
+	}
+	if req.RejectBreached != nil {
+		p.RejectBreached = *req.RejectBreached
+	}
+}
+
+// Merge returns the stricter of p and other, field by field
+func (p PasswordPolicy) Merge(other *PasswordPolicy) PasswordPolicy {
+	if other == nil {
+		return p
+	}
+	merged := PasswordPolicy{
+		MinLength:        max(p.MinLength, other.MinLength),
+		RequireUppercase: p.RequireUppercase || other.RequireUppercase,
+		RequireLowercase: p.RequireLowercase || other.RequireLowercase,
+		RequireNumber:    p.RequireNumber || other.RequireNumber,
+		RequireSymbol:    p.RequireSymbol || other.RequireSymbol,
+		HistoryCount:     max(p.HistoryCount, other.HistoryCount),
+		MaxAgeDays:       p.MaxAgeDays,
+		RejectBreached:   p.RejectBreached || other.RejectBreached,
+	}
+	if other.MaxAgeDays > 0 && (merged.MaxAgeDays == 0 || other.MaxAgeDays < merged.MaxAgeDays) {
+		merged.MaxAgeDays = other.MaxAgeDays
+	}
+	return merged
+}
+
+// Check returns a sentence describing what password is missing, or an empty
+// string when it satisfies the length and character class rules. History
+// and breach checks need more than the password and are done by the caller.
+func (p PasswordPolicy) Check(password string) string {
+	if len([]rune(password)) < p.MinLength {
+		return fmt.Sprintf("password must be at least %d characters long", p.MinLength)
+	}
+
+	var hasUpper, hasLower, hasNumber, hasSymbol bool
+	for _, char := range password {
+		switch {
+		case unicode.IsUpper(char):
+			hasUpper = true
+		case unicode.IsLower(char):
+			hasLower = true
+		case unicode.IsDigit(char):
+			hasNumber = true
+		case unicode.IsPunct(char) || unicode.IsSymbol(char):
+			hasSymbol = true
+		}
+	}
+
+	var missing []string
+	if p.RequireUppercase && !hasUpper {
+		missing = append(missing, "one uppercase letter")
+	}
+	if p.RequireLowercase && !hasLower {
+		missing = append(missing, "one lowercase letter")
+	}
+	if p.RequireNumber && !hasNumber {
+		missing = append(missing, "one number")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		missing = append(missing, "one special character")
+	}
+	switch len(missing) {
+	case 0:
+		return ""
+	case 1:
+		return "password must contain at least " + missing[0]
+	default:
+		return "password must contain at least " + strings.Join(missing[:len(missing)-1], ", ") + " and " + missing[len(missing)-1]
+	}
+}
+
+// Expired reports whether a password last changed at changedAt is past its maximum age
+func (p PasswordPolicy) Expired(changedAt, now time.Time) bool {
+	if p.MaxAgeDays <= 0 || changedAt.IsZero() {
+		return false
+	}
+	return now.Sub(changedAt) > time.Duration(p.MaxAgeDays)*24*time.Hour
+}
@@ -0,0 +1,12 @@
+package model
+
+// RefreshTokenRotation is the outcome of presenting a refresh token for rotation
+type RefreshTokenRotation struct {
+	UserID   string
+	FamilyID string
+	// SessionID is the user session the family was issued for, empty for legacy tokens
+	SessionID string
+	// Reused is set when the presented token had already been rotated. The
+	// whole family has been revoked and no new token was stored.
+	Reused bool
+}
@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// Security event types recorded in security_events and shown under /api/v1/account/security-events
+const (
+	SecurityEventPasswordReset     = "password_reset"
+	SecurityEventRefreshTokenReuse = "refresh_token_reuse"
+	SecurityEventAccountLocked     = "account_locked"
+	SecurityEventAccountUnlocked   = "account_unlocked"
+	SecurityEventPasskeyAdded      = "passkey_added"
+	SecurityEventPasskeyRemoved    = "passkey_removed"
+	SecurityEventPasskeyCloned     = "passkey_counter_regression"
+	// Every successful login, and those from unrecognized devices or too far
+	// from the previous login to have traveled in between
+	SecurityEventLogin            = "login"
+	SecurityEventNewDevice        = "new_device"
+	SecurityEventImpossibleTravel = "impossible_travel"
+	// Support staff viewed the account as the user
+	SecurityEventImpersonationStarted = "impersonation_started"
+	SecurityEventImpersonationEnded   = "impersonation_ended"
+)
+
+// LoginRecord is where and when a login happened. Latitude and Longitude are
+// nil when the IP address couldn't be located.
+type LoginRecord struct {
+	IP        string
+	Location  string
+	Latitude  *float64
+	Longitude *float64
+	At        time.Time
+}
@@ -17,7 +17,13 @@ type User struct {
 	PublicBio     string
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
-	Roles         []UserRole `json:"-"` // Loaded separately
+	// PasswordChangedAt is when the password was last set, for password expiry
+	PasswordChangedAt time.Time
+	// Two-factor authentication (secret is stored encrypted)
+	TwoFactorEnabled  bool
+	TwoFactorSecret   string     `json:"-"`
+	TwoFactorLastStep int64      `json:"-"`
+	Roles             []UserRole `json:"-"` // Loaded separately
 	// Multi-tenant context
 	CurrentTenantID   *string            `json:"current_tenant_id,omitempty"`
 	TenantMemberships []TenantMembership `json:"-"` // Loaded separately
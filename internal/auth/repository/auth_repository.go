@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"ethos/internal/auth/model"
 )
@@ -20,12 +21,88 @@ type Repository interface {
 	// UpdateUser updates an existing user
 	UpdateUser(ctx context.Context, user *model.User) error
 
-	// SaveRefreshToken saves a refresh token
-	SaveRefreshToken(ctx context.Context, userID, tokenHash string, expiresAt int64) error
+	// SaveRefreshToken saves a refresh token as the first token of a new family,
+	// linked to the user session it was issued for (empty for none)
+	SaveRefreshToken(ctx context.Context, userID, sessionID, tokenHash string, expiresAt int64) error
+
+	// RotateRefreshToken replaces a refresh token with a new one in the same family.
+	// Presenting an already-rotated token revokes the whole family instead.
+	RotateRefreshToken(ctx context.Context, userID, oldTokenHash, newTokenHash string, expiresAt int64) (*model.RefreshTokenRotation, error)
 
 	// GetRefreshToken retrieves a refresh token by hash
 	GetRefreshToken(ctx context.Context, tokenHash string) (string, error)
 
 	// DeleteRefreshToken deletes a refresh token
 	DeleteRefreshToken(ctx context.Context, tokenHash string) error
+
+	// RevokeSessionRefreshTokens revokes the refresh tokens issued for a session
+	RevokeSessionRefreshTokens(ctx context.Context, sessionID string) error
+
+	// RevokeUserRefreshTokens revokes every refresh token of a user
+	RevokeUserRefreshTokens(ctx context.Context, userID string) error
+
+	// SaveTwoFactorSecret stores an encrypted TOTP secret pending confirmation
+	SaveTwoFactorSecret(ctx context.Context, userID, encryptedSecret string) error
+
+	// EnableTwoFactor activates 2FA and replaces the user's recovery codes
+	EnableTwoFactor(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error
+
+	// UpdateTwoFactorLastStep records the last accepted TOTP time step
+	UpdateTwoFactorLastStep(ctx context.Context, userID string, step int64) error
+
+	// ConsumeRecoveryCode marks an unused recovery code as used
+	ConsumeRecoveryCode(ctx context.Context, userID, codeHash string) error
+
+	// SaveLoginChallenge saves a pending second-factor login challenge
+	SaveLoginChallenge(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error
+
+	// GetLoginChallenge retrieves an unexpired login challenge
+	GetLoginChallenge(ctx context.Context, tokenHash string) (string, int, error)
+
+	// IncrementLoginChallengeAttempts records a failed second-factor attempt
+	IncrementLoginChallengeAttempts(ctx context.Context, tokenHash string) error
+
+	// DeleteLoginChallenge deletes a login challenge
+	DeleteLoginChallenge(ctx context.Context, tokenHash string) error
+
+	// SavePasswordResetToken stores a hashed password reset token, invalidating earlier ones
+	SavePasswordResetToken(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error
+
+	// ResetPassword consumes a reset token, updates the password (keeping the
+	// old hash in the password history) and revokes refresh tokens
+	ResetPassword(ctx context.Context, tokenHash, passwordHash string) (string, error)
+
+	// CreateSecurityEvent records a security event for a user
+	CreateSecurityEvent(ctx context.Context, userID, eventType, ip, location string) error
+
+	// GetUserRoles returns the names of the user's active platform roles
+	GetUserRoles(ctx context.Context, userID string) ([]string, error)
+
+	// CreateLoginEvent records a login security event with its coordinates
+	CreateLoginEvent(ctx context.Context, userID, eventType string, login *model.LoginRecord) error
+
+	// GetLastLogin returns the user's most recent login event, or ErrNotFound
+	GetLastLogin(ctx context.Context, userID string) (*model.LoginRecord, error)
+
+	// RecordLoginDevice marks a device fingerprint as seen for the user. It
+	// reports whether the device is new and how many devices the user had before.
+	RecordLoginDevice(ctx context.Context, userID, fingerprint, userAgent, ip string) (bool, int, error)
+
+	// GetNotifyOnLogin returns the user's notify_on_login preference, true if unset
+	GetNotifyOnLogin(ctx context.Context, userID string) (bool, error)
+
+	// UpdatePassword sets a user's password, keeping the old hash in their password history
+	UpdatePassword(ctx context.Context, userID, passwordHash string) error
+
+	// GetPasswordHistory returns the user's most recent previous password hashes, newest first
+	GetPasswordHistory(ctx context.Context, userID string, limit int) ([]string, error)
+
+	// GetPasswordResetUserID returns the user a valid, unused password reset token belongs to
+	GetPasswordResetUserID(ctx context.Context, tokenHash string) (string, error)
+
+	// GetOrganizationPasswordPolicy returns an organization's password policy, or ErrNotFound if it has none
+	GetOrganizationPasswordPolicy(ctx context.Context, organizationID string) (*model.PasswordPolicy, error)
+
+	// SaveOrganizationPasswordPolicy creates or replaces an organization's password policy
+	SaveOrganizationPasswordPolicy(ctx context.Context, organizationID, updatedBy string, policy *model.PasswordPolicy) error
 }
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ethos/internal/auth/model"
+	"ethos/pkg/errors"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// maxPasswordHistory is how many previous password hashes are kept per
+// user, the most any password policy can ask for
+const maxPasswordHistory = 24
+
+// UpdatePassword sets a user's password, keeping the old hash in their password history
+func (r *PostgresRepository) UpdatePassword(ctx context.Context, userID, passwordHash string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.UpdatePassword")
+	defer span.End()
+
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	if err := setPassword(ctx, tx, userID, passwordHash); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to commit transaction")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// setPassword moves the current hash into the password history, trims the
+// history and sets the new hash
+func setPassword(ctx context.Context, tx pgx.Tx, userID, passwordHash string) error {
+	result, err := tx.Exec(ctx, `
+		INSERT INTO password_history (user_id, password_hash)
+		SELECT id, password_hash FROM users WHERE id = $1`,
+		userID,
+	)
+	if err != nil {
+		return errors.WrapError(err, "failed to record password history")
+	}
+	if result.RowsAffected() == 0 {
+		return errors.ErrUserNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM password_history
+		WHERE user_id = $1 AND id NOT IN (
+			SELECT id FROM password_history WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2
+		)`,
+		userID, maxPasswordHistory,
+	); err != nil {
+		return errors.WrapError(err, "failed to trim password history")
+	}
+
+	now := time.Now()
+	if _, err := tx.Exec(ctx, `UPDATE users SET password_hash = $1, password_changed_at = $2, updated_at = $2 WHERE id = $3`, passwordHash, now, userID); err != nil {
+		return errors.WrapError(err, "failed to update password")
+	}
+	return nil
+}
+
+// GetPasswordHistory returns the user's most recent previous password hashes, newest first
+func (r *PostgresRepository) GetPasswordHistory(ctx context.Context, userID string, limit int) ([]string, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetPasswordHistory")
+	defer span.End()
+
+	query := `
+		SELECT password_hash
+		FROM password_history
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, userID, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get password history")
+	}
+	defer rows.Close()
+
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, errors.WrapError(err, "failed to scan password history")
+		}
+		hashes = append(hashes, hash)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to read password history")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return hashes, nil
+}
+
+// GetPasswordResetUserID returns the user a valid, unused password reset token belongs to
+func (r *PostgresRepository) GetPasswordResetUserID(ctx context.Context, tokenHash string) (string, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetPasswordResetUserID")
+	defer span.End()
+
+	query := `
+		SELECT user_id
+		FROM password_reset_tokens
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+	`
+
+	var userID string
+	if err := r.db.Pool.QueryRow(ctx, query, tokenHash).Scan(&userID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if err == pgx.ErrNoRows {
+			return "", errors.ErrTokenInvalid
+		}
+		return "", errors.WrapError(err, "failed to get password reset token")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return userID, nil
+}
+
+// GetOrganizationPasswordPolicy returns an organization's password policy, or ErrNotFound if it has none
+func (r *PostgresRepository) GetOrganizationPasswordPolicy(ctx context.Context, organizationID string) (*model.PasswordPolicy, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetOrganizationPasswordPolicy")
+	defer span.End()
+
+	query := `
+		SELECT min_length, require_uppercase, require_lowercase, require_number, require_symbol,
+			history_count, max_age_days, reject_breached
+		FROM organization_password_policies
+		WHERE organization_id = $1
+	`
+
+	var policy model.PasswordPolicy
+	err := r.db.Pool.QueryRow(ctx, query, organizationID).Scan(
+		&policy.MinLength,
+		&policy.RequireUppercase,
+		&policy.RequireLowercase,
+		&policy.RequireNumber,
+		&policy.RequireSymbol,
+		&policy.HistoryCount,
+		&policy.MaxAgeDays,
+		&policy.RejectBreached,
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to get organization password policy")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return &policy, nil
+}
+
+// SaveOrganizationPasswordPolicy creates or replaces an organization's password policy
+func (r *PostgresRepository) SaveOrganizationPasswordPolicy(ctx context.Context, organizationID, updatedBy string, policy *model.PasswordPolicy) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.SaveOrganizationPasswordPolicy")
+	defer span.End()
+
+	query := `
+		INSERT INTO organization_password_policies (
+			organization_id, min_length, require_uppercase, require_lowercase, require_number, require_symbol,
+			history_count, max_age_days, reject_breached, updated_by, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), NOW())
+		ON CONFLICT (organization_id) DO UPDATE SET
+			min_length = EXCLUDED.min_length,
+			require_uppercase = EXCLUDED.require_uppercase,
+			require_lowercase = EXCLUDED.require_lowercase,
+			require_number = EXCLUDED.require_number,
+			require_symbol = EXCLUDED.require_symbol,
+			history_count = EXCLUDED.history_count,
+			max_age_days = EXCLUDED.max_age_days,
+			reject_breached = EXCLUDED.reject_breached,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.Pool.Exec(ctx, query,
+		organizationID,
+		policy.MinLength,
+		policy.RequireUppercase,
+		policy.RequireLowercase,
+		policy.RequireNumber,
+		policy.RequireSymbol,
+		policy.HistoryCount,
+		policy.MaxAgeDays,
+		policy.RejectBreached,
+		updatedBy,
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to save organization password policy")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
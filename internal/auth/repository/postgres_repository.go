@@ -32,7 +32,9 @@ func (r *PostgresRepository) GetUserByEmail(ctx context.Context, email string) (
 
 	var user model.User
 	query := `
-		SELECT id, email, password_hash, first_name, last_name, email_verified, public_bio, created_at, updated_at
+		SELECT id, email, password_hash, first_name, last_name, email_verified, public_bio, created_at, updated_at,
+			COALESCE(two_factor_enabled, FALSE), COALESCE(two_factor_secret, ''), two_factor_last_step,
+			current_organization_id::text, COALESCE(password_changed_at, created_at)
 		FROM users
 		WHERE email = $1
 	`
@@ -47,6 +49,11 @@ func (r *PostgresRepository) GetUserByEmail(ctx context.Context, email string) (
 		&user.PublicBio,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.TwoFactorEnabled,
+		&user.TwoFactorSecret,
+		&user.TwoFactorLastStep,
+		&user.CurrentTenantID,
+		&user.PasswordChangedAt,
 	)
 
 	if err != nil {
@@ -69,7 +76,9 @@ func (r *PostgresRepository) GetUserByID(ctx context.Context, userID string) (*m
 
 	var user model.User
 	query := `
-		SELECT id, email, password_hash, first_name, last_name, email_verified, public_bio, created_at, updated_at
+		SELECT id, email, password_hash, first_name, last_name, email_verified, public_bio, created_at, updated_at,
+			COALESCE(two_factor_enabled, FALSE), COALESCE(two_factor_secret, ''), two_factor_last_step,
+			current_organization_id::text, COALESCE(password_changed_at, created_at)
 		FROM users
 		WHERE id = $1
 	`
@@ -84,6 +93,11 @@ func (r *PostgresRepository) GetUserByID(ctx context.Context, userID string) (*m
 		&user.PublicBio,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.TwoFactorEnabled,
+		&user.TwoFactorSecret,
+		&user.TwoFactorLastStep,
+		&user.CurrentTenantID,
+		&user.PasswordChangedAt,
 	)
 
 	if err != nil {
@@ -183,21 +197,23 @@ func (r *PostgresRepository) UpdateUser(ctx context.Context, user *model.User) e
 }
 
 // SaveRefreshToken saves a refresh token
-func (r *PostgresRepository) SaveRefreshToken(ctx context.Context, userID, tokenHash string, expiresAt int64) error {
+func (r *PostgresRepository) SaveRefreshToken(ctx context.Context, userID, sessionID, tokenHash string, expiresAt int64) error {
 	ctx, span := otel.Tracer("repository").Start(ctx, "repository.SaveRefreshToken")
 	defer span.End()
 
 	tokenID := "token-" + uuid.New().String()
 	expiresAtTime := time.Unix(expiresAt, 0)
 
+	// The first token of a family uses its own ID as the family ID
 	query := `
-		INSERT INTO refresh_tokens (token_id, user_id, token_hash, expires_at, created_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO refresh_tokens (token_id, family_id, user_id, session_id, token_hash, expires_at, created_at)
+		VALUES ($1, $1, $2, NULLIF($3, '')::uuid, $4, $5, $6)
 	`
 
 	_, err := r.db.Pool.Exec(ctx, query,
 		tokenID,
 		userID,
+		sessionID,
 		tokenHash,
 		expiresAtTime,
 		time.Now(),
@@ -222,7 +238,7 @@ func (r *PostgresRepository) GetRefreshToken(ctx context.Context, tokenHash stri
 	query := `
 		SELECT user_id
 		FROM refresh_tokens
-		WHERE token_hash = $1 AND expires_at > NOW()
+		WHERE token_hash = $1 AND expires_at > NOW() AND rotated_at IS NULL AND revoked_at IS NULL
 	`
 
 	err := r.db.Pool.QueryRow(ctx, query, tokenHash).Scan(&userID)
@@ -239,6 +255,90 @@ func (r *PostgresRepository) GetRefreshToken(ctx context.Context, tokenHash stri
 	return userID, nil
 }
 
+// RotateRefreshToken marks a refresh token as rotated and stores its successor
+// in the same family. If the token was already rotated, this is a replay: the
+// family is revoked and the returned rotation has Reused set.
+func (r *PostgresRepository) RotateRefreshToken(ctx context.Context, userID, oldTokenHash, newTokenHash string, expiresAt int64) (*model.RefreshTokenRotation, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.RotateRefreshToken")
+	defer span.End()
+
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rotation := &model.RefreshTokenRotation{UserID: userID}
+	var rotatedAt, revokedAt *time.Time
+	var expired bool
+	err = tx.QueryRow(ctx, `
+		SELECT family_id, COALESCE(session_id::text, ''), rotated_at, revoked_at, expires_at <= NOW()
+		FROM refresh_tokens
+		WHERE token_hash = $1 AND user_id = $2
+		FOR UPDATE`,
+		oldTokenHash, userID,
+	).Scan(&rotation.FamilyID, &rotation.SessionID, &rotatedAt, &revokedAt, &expired)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrTokenInvalid
+		}
+		return nil, errors.WrapError(err, "failed to get refresh token")
+	}
+
+	if revokedAt != nil || expired {
+		return nil, errors.ErrTokenInvalid
+	}
+
+	if rotatedAt != nil {
+		if _, err := tx.Exec(ctx, `
+			UPDATE refresh_tokens
+			SET revoked_at = NOW()
+			WHERE family_id = $1 AND revoked_at IS NULL`,
+			rotation.FamilyID,
+		); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, errors.WrapError(err, "failed to revoke refresh token family")
+		}
+		rotation.Reused = true
+	} else {
+		if _, err := tx.Exec(ctx, `UPDATE refresh_tokens SET rotated_at = NOW() WHERE token_hash = $1`, oldTokenHash); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, errors.WrapError(err, "failed to rotate refresh token")
+		}
+
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO refresh_tokens (token_id, family_id, user_id, session_id, token_hash, expires_at, created_at)
+			VALUES ($1, $2, $3, NULLIF($4, '')::uuid, $5, $6, $7)`,
+			"token-"+uuid.New().String(),
+			rotation.FamilyID,
+			userID,
+			rotation.SessionID,
+			newTokenHash,
+			time.Unix(expiresAt, 0),
+			time.Now(),
+		); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, errors.WrapError(err, "failed to save refresh token")
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to commit transaction")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return rotation, nil
+}
+
 // DeleteRefreshToken deletes a refresh token
 func (r *PostgresRepository) DeleteRefreshToken(ctx context.Context, tokenHash string) error {
 	ctx, span := otel.Tracer("repository").Start(ctx, "repository.DeleteRefreshToken")
@@ -256,3 +356,539 @@ func (r *PostgresRepository) DeleteRefreshToken(ctx context.Context, tokenHash s
 	span.SetStatus(codes.Ok, "")
 	return nil
 }
+
+// SaveTwoFactorSecret stores an encrypted TOTP secret pending confirmation
+func (r *PostgresRepository) SaveTwoFactorSecret(ctx context.Context, userID, encryptedSecret string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.SaveTwoFactorSecret")
+	defer span.End()
+
+	query := `
+		UPDATE users
+		SET two_factor_secret = $1, two_factor_enabled = FALSE, two_factor_last_step = 0, updated_at = $2
+		WHERE id = $3
+	`
+
+	result, err := r.db.Pool.Exec(ctx, query, encryptedSecret, time.Now(), userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to save 2FA secret")
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.ErrUserNotFound
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// EnableTwoFactor activates 2FA and replaces the user's recovery codes
+func (r *PostgresRepository) EnableTwoFactor(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.EnableTwoFactor")
+	defer span.End()
+
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE users
+		SET two_factor_enabled = TRUE, two_factor_last_step = $1, updated_at = $2
+		WHERE id = $3 AND two_factor_secret IS NOT NULL`,
+		step, time.Now(), userID,
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to enable 2FA")
+	}
+	if result.RowsAffected() == 0 {
+		return errors.ErrUserNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM two_factor_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to clear recovery codes")
+	}
+
+	for _, codeHash := range recoveryCodeHashes {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO two_factor_recovery_codes (code_id, user_id, code_hash, created_at)
+			VALUES ($1, $2, $3, $4)`,
+			"rc-"+uuid.New().String(), userID, codeHash, time.Now(),
+		)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return errors.WrapError(err, "failed to save recovery code")
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to commit transaction")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// UpdateTwoFactorLastStep records the last accepted TOTP time step.
+// It only moves forward, so a concurrent replay of the same code fails.
+func (r *PostgresRepository) UpdateTwoFactorLastStep(ctx context.Context, userID string, step int64) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.UpdateTwoFactorLastStep")
+	defer span.End()
+
+	query := `
+		UPDATE users
+		SET two_factor_last_step = $1
+		WHERE id = $2 AND two_factor_last_step < $1
+	`
+
+	result, err := r.db.Pool.Exec(ctx, query, step, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to update 2FA step")
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.ErrTokenInvalid
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// ConsumeRecoveryCode marks an unused recovery code as used
+func (r *PostgresRepository) ConsumeRecoveryCode(ctx context.Context, userID, codeHash string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ConsumeRecoveryCode")
+	defer span.End()
+
+	query := `
+		UPDATE two_factor_recovery_codes
+		SET used_at = $1
+		WHERE user_id = $2 AND code_hash = $3 AND used_at IS NULL
+	`
+
+	result, err := r.db.Pool.Exec(ctx, query, time.Now(), userID, codeHash)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to consume recovery code")
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.ErrTokenInvalid
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// SaveLoginChallenge saves a pending second-factor login challenge
+func (r *PostgresRepository) SaveLoginChallenge(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.SaveLoginChallenge")
+	defer span.End()
+
+	query := `
+		INSERT INTO login_challenges (challenge_id, user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.Pool.Exec(ctx, query,
+		"challenge-"+uuid.New().String(),
+		userID,
+		tokenHash,
+		expiresAt,
+		time.Now(),
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to save login challenge")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// GetLoginChallenge retrieves an unexpired login challenge, returning the user ID and failed attempts
+func (r *PostgresRepository) GetLoginChallenge(ctx context.Context, tokenHash string) (string, int, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetLoginChallenge")
+	defer span.End()
+
+	var userID string
+	var attempts int
+	query := `
+		SELECT user_id, attempts
+		FROM login_challenges
+		WHERE token_hash = $1 AND expires_at > NOW()
+	`
+
+	err := r.db.Pool.QueryRow(ctx, query, tokenHash).Scan(&userID, &attempts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if err == pgx.ErrNoRows {
+			return "", 0, errors.ErrTokenInvalid
+		}
+		return "", 0, errors.WrapError(err, "failed to get login challenge")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return userID, attempts, nil
+}
+
+// IncrementLoginChallengeAttempts records a failed second-factor attempt
+func (r *PostgresRepository) IncrementLoginChallengeAttempts(ctx context.Context, tokenHash string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.IncrementLoginChallengeAttempts")
+	defer span.End()
+
+	query := `UPDATE login_challenges SET attempts = attempts + 1 WHERE token_hash = $1`
+
+	_, err := r.db.Pool.Exec(ctx, query, tokenHash)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to update login challenge")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// DeleteLoginChallenge deletes a login challenge
+func (r *PostgresRepository) DeleteLoginChallenge(ctx context.Context, tokenHash string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.DeleteLoginChallenge")
+	defer span.End()
+
+	query := `DELETE FROM login_challenges WHERE token_hash = $1`
+
+	_, err := r.db.Pool.Exec(ctx, query, tokenHash)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to delete login challenge")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// SavePasswordResetToken stores a hashed password reset token, invalidating any earlier unused tokens
+func (r *PostgresRepository) SavePasswordResetToken(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.SavePasswordResetToken")
+	defer span.End()
+
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	// Only the most recently requested link is valid
+	if _, err := tx.Exec(ctx, `DELETE FROM password_reset_tokens WHERE user_id = $1 AND used_at IS NULL`, userID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to invalidate password reset tokens")
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO password_reset_tokens (token_id, user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		"reset-"+uuid.New().String(), userID, tokenHash, expiresAt, time.Now(),
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to save password reset token")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to commit transaction")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// ResetPassword consumes a password reset token, sets the new password hash and
+// deletes all of the user's refresh tokens in one transaction. It returns the user ID.
+func (r *PostgresRepository) ResetPassword(ctx context.Context, tokenHash, passwordHash string) (string, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ResetPassword")
+	defer span.End()
+
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", errors.WrapError(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	var userID string
+	err = tx.QueryRow(ctx, `
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING user_id`,
+		tokenHash,
+	).Scan(&userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if err == pgx.ErrNoRows {
+			return "", errors.ErrTokenInvalid
+		}
+		return "", errors.WrapError(err, "failed to consume password reset token")
+	}
+
+	if err := setPassword(ctx, tx, userID, passwordHash); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM refresh_tokens WHERE user_id = $1`, userID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", errors.WrapError(err, "failed to revoke refresh tokens")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", errors.WrapError(err, "failed to commit transaction")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return userID, nil
+}
+
+// CreateSecurityEvent records a security event for a user
+func (r *PostgresRepository) CreateSecurityEvent(ctx context.Context, userID, eventType, ip, location string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.CreateSecurityEvent")
+	defer span.End()
+
+	query := `
+		INSERT INTO security_events (event_id, user_id, type, ip, location, timestamp)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.Pool.Exec(ctx, query,
+		"event-"+uuid.New().String(),
+		userID,
+		eventType,
+		ip,
+		location,
+		time.Now(),
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to create security event")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// CreateLoginEvent records a login security event with its coordinates
+func (r *PostgresRepository) CreateLoginEvent(ctx context.Context, userID, eventType string, login *model.LoginRecord) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.CreateLoginEvent")
+	defer span.End()
+
+	query := `
+		INSERT INTO security_events (event_id, user_id, type, ip, location, latitude, longitude, timestamp)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.Pool.Exec(ctx, query,
+		"event-"+uuid.New().String(),
+		userID,
+		eventType,
+		login.IP,
+		login.Location,
+		login.Latitude,
+		login.Longitude,
+		login.At,
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to create login event")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// GetLastLogin returns the user's most recent login event, or ErrNotFound
+func (r *PostgresRepository) GetLastLogin(ctx context.Context, userID string) (*model.LoginRecord, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetLastLogin")
+	defer span.End()
+
+	query := `
+		SELECT COALESCE(ip, ''), COALESCE(location, ''), latitude, longitude, timestamp
+		FROM security_events
+		WHERE user_id = $1 AND type = 'login'
+		ORDER BY timestamp DESC
+		LIMIT 1
+	`
+
+	login := &model.LoginRecord{}
+	err := r.db.Pool.QueryRow(ctx, query, userID).Scan(&login.IP, &login.Location, &login.Latitude, &login.Longitude, &login.At)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get last login")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return login, nil
+}
+
+// RecordLoginDevice marks a device fingerprint as seen for the user. It
+// reports whether the device is new and how many devices the user had before.
+func (r *PostgresRepository) RecordLoginDevice(ctx context.Context, userID, fingerprint, userAgent, ip string) (bool, int, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.RecordLoginDevice")
+	defer span.End()
+
+	// The CTE sees the table as it was before the upsert; xmax is 0 only for inserted rows
+	query := `
+		WITH known AS (
+			SELECT COUNT(*) AS devices FROM user_devices WHERE user_id = $1
+		), seen AS (
+			INSERT INTO user_devices (user_id, fingerprint, user_agent, last_ip)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id, fingerprint) DO UPDATE
+			SET user_agent = EXCLUDED.user_agent, last_ip = EXCLUDED.last_ip, last_seen_at = NOW()
+			RETURNING (xmax = 0) AS inserted
+		)
+		SELECT seen.inserted, known.devices FROM seen, known
+	`
+
+	var inserted bool
+	var devices int
+	if err := r.db.Pool.QueryRow(ctx, query, userID, fingerprint, userAgent, ip).Scan(&inserted, &devices); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return false, 0, errors.WrapError(err, "failed to record login device")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return inserted, devices, nil
+}
+
+// GetNotifyOnLogin returns the user's notify_on_login preference, true if unset
+func (r *PostgresRepository) GetNotifyOnLogin(ctx context.Context, userID string) (bool, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetNotifyOnLogin")
+	defer span.End()
+
+	query := `SELECT COALESCE(notify_on_login, true) FROM user_preferences WHERE user_id = $1`
+
+	var notify bool
+	if err := r.db.Pool.QueryRow(ctx, query, userID).Scan(&notify); err != nil {
+		if err == pgx.ErrNoRows {
+			return true, nil
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return false, errors.WrapError(err, "failed to get login notification preference")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return notify, nil
+}
+
+// GetUserRoles returns the names of the user's active, unexpired platform roles
+func (r *PostgresRepository) GetUserRoles(ctx context.Context, userID string) ([]string, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetUserRoles")
+	defer span.End()
+
+	query := `
+		SELECT ur.name
+		FROM user_role_assignments ura
+		JOIN user_roles ur ON ur.id = ura.role_id
+		WHERE ura.user_id = $1
+		AND ura.is_active = true
+		AND (ura.expires_at IS NULL OR ura.expires_at > NOW())
+		ORDER BY ur.name
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get user roles")
+	}
+	defer rows.Close()
+
+	var roles []string
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, errors.WrapError(err, "failed to scan user role")
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get user roles")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return roles, nil
+}
+
+// RevokeSessionRefreshTokens revokes the refresh tokens issued for a session
+func (r *PostgresRepository) RevokeSessionRefreshTokens(ctx context.Context, sessionID string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.RevokeSessionRefreshTokens")
+	defer span.End()
+
+	query := `UPDATE refresh_tokens SET revoked_at = NOW() WHERE session_id = $1 AND revoked_at IS NULL`
+
+	if _, err := r.db.Pool.Exec(ctx, query, sessionID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to revoke session refresh tokens")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// RevokeUserRefreshTokens revokes every refresh token of a user
+func (r *PostgresRepository) RevokeUserRefreshTokens(ctx context.Context, userID string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.RevokeUserRefreshTokens")
+	defer span.End()
+
+	query := `UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`
+
+	if _, err := r.db.Pool.Exec(ctx, query, userID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to revoke refresh tokens")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
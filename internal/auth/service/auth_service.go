@@ -8,14 +8,31 @@ import (
 
 // LoginRequest represents a login request
 type LoginRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=8"`
+	Email     string `json:"email" binding:"required,email"`
+	Password  string `json:"password" binding:"required,min=8"`
+	IP        string `json:"-"` // Set by the handler for the session
+	UserAgent string `json:"-"` // Set by the handler for the session
 }
 
-// LoginResponse represents a login response
+// LoginResponse represents a login response. When the account has 2FA
+// enabled, no tokens are issued; instead TwoFactorRequired is set and the
+// ChallengeToken must be exchanged via CompleteTwoFactorLogin.
 type LoginResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken       string `json:"access_token,omitempty"`
+	RefreshToken      string `json:"refresh_token,omitempty"`
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
+	// PasswordExpired is set when the password is past its policy's maximum
+	// age; the tokens still work, but the client should ask for a new password
+	PasswordExpired bool `json:"password_expired,omitempty"`
+}
+
+// TwoFactorLoginRequest completes a login that requires a second factor
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"` // TOTP or recovery code
+	IP             string `json:"-"`                       // Set by the handler for the session
+	UserAgent      string `json:"-"`                       // Set by the handler for the session
 }
 
 // RegisterRequest represents a registration request
@@ -25,11 +42,26 @@ type RegisterRequest struct {
 	FirstName   string `json:"first_name" binding:"required,min=1"`
 	LastName    string `json:"last_name" binding:"required,min=1"`
 	AcceptTerms bool   `json:"accept_terms" binding:"required"`
+	// EmailVerified is set by callers that have already proven the address,
+	// such as accepting an emailed invitation
+	EmailVerified bool `json:"-"`
 }
 
 // RefreshRequest represents a token refresh request
 type RefreshRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
+	IP           string `json:"-"` // Set by the handler for the security event
+}
+
+// LogoutRequest represents a logout request. Without AllDevices only the
+// session of the presented access token is ended.
+type LogoutRequest struct {
+	AllDevices bool `json:"all_devices"`
+	// RefreshToken is revoked as well when the access token predates sessions
+	RefreshToken string `json:"refresh_token"`
+	UserID       string `json:"-"` // Set by the handler from the access token
+	SessionID    string `json:"-"` // Set by the handler from the access token
+	TokenID      string `json:"-"` // Set by the handler from the access token
 }
 
 // ChangePasswordRequest represents a password change request
@@ -43,6 +75,24 @@ type RequestPasswordResetRequest struct {
 	Email string `json:"email" binding:"required,email"`
 }
 
+// ResendVerificationRequest represents a request to resend the email verification link
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// UnlockAccountRequest represents a request to lift a login lockout using the emailed unlock token
+type UnlockAccountRequest struct {
+	Token string `json:"token" binding:"required"`
+	IP    string `json:"-"` // Set by the handler for the security event
+}
+
+// ResetPasswordRequest represents a request to set a new password using a reset token
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=8"`
+	IP          string `json:"-"` // Set by the handler for the security event
+}
+
 // Setup2FARequest represents a 2FA setup request
 type Setup2FARequest struct {
 	Password string `json:"password" binding:"required,min=8"`
@@ -55,6 +105,17 @@ type Setup2FAResponse struct {
 	Message string `json:"message"`
 }
 
+// Confirm2FARequest represents a request to activate a pending 2FA setup
+type Confirm2FARequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// Confirm2FAResponse represents the response after 2FA is activated
+type Confirm2FAResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+	Message       string   `json:"message"`
+}
+
 // Service defines the interface for authentication business logic
 type Service interface {
 	// Login authenticates a user and returns tokens
@@ -63,24 +124,60 @@ type Service interface {
 	// Register creates a new user account
 	Register(ctx context.Context, req *RegisterRequest) (*model.UserProfile, error)
 
-	// RefreshToken generates a new access token from a refresh token
+	// RefreshToken rotates a refresh token and issues a new token pair
 	RefreshToken(ctx context.Context, req *RefreshRequest) (*LoginResponse, error)
 
+	// CompleteExternalLogin issues tokens for a user authenticated by an external identity provider
+	CompleteExternalLogin(ctx context.Context, userID, organizationID, ip, userAgent string) (*LoginResponse, error)
+
+	// IssueAccessToken issues an access token for an existing session after it switched organization
+	IssueAccessToken(ctx context.Context, userID, sessionID, organizationID string) (string, error)
+
+	// Logout ends the current session, or all of the user's sessions
+	Logout(ctx context.Context, req *LogoutRequest) error
+
+	// RevokeSession ends one of the user's sessions
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+
 	// GetUserProfile retrieves a user profile by ID
 	GetUserProfile(ctx context.Context, userID string) (*model.UserProfile, error)
 
 	// VerifyEmail marks a user's email as verified
 	VerifyEmail(ctx context.Context, token string) error
 
+	// ResendVerification sends a fresh verification link to an unverified address
+	ResendVerification(ctx context.Context, req *ResendVerificationRequest) error
+
+	// UnlockAccount lifts a login lockout using the token from the unlock email
+	UnlockAccount(ctx context.Context, req *UnlockAccountRequest) error
+
 	// RequestPasswordReset initiates a password reset process
 	RequestPasswordReset(ctx context.Context, req *RequestPasswordResetRequest) error
 
+	// ResetPassword sets a new password using a single-use reset token
+	ResetPassword(ctx context.Context, req *ResetPasswordRequest) error
+
 	// ChangePassword changes user's password
 	ChangePassword(ctx context.Context, userID string, req *ChangePasswordRequest) error
 
+	// GetPasswordPolicy returns an organization's password policy and the one its members must meet
+	GetPasswordPolicy(ctx context.Context, organizationID string) (*PasswordPolicyResponse, error)
+
+	// UpdatePasswordPolicy changes an organization's password policy
+	UpdatePasswordPolicy(ctx context.Context, organizationID, actorID string, req *model.UpdatePasswordPolicyRequest) (*PasswordPolicyResponse, error)
+
 	// Setup2FA initializes 2FA for a user
 	Setup2FA(ctx context.Context, userID string, req *Setup2FARequest) (*Setup2FAResponse, error)
 
+	// Confirm2FA activates a pending 2FA setup and returns recovery codes
+	Confirm2FA(ctx context.Context, userID string, req *Confirm2FARequest) (*Confirm2FAResponse, error)
+
+	// CompleteTwoFactorLogin exchanges a login challenge and second factor for tokens
+	CompleteTwoFactorLogin(ctx context.Context, req *TwoFactorLoginRequest) (*LoginResponse, error)
+
+	// VerifyTwoFactorCode checks a TOTP or recovery code for a user with 2FA enabled
+	VerifyTwoFactorCode(ctx context.Context, userID, code string) error
+
 	// MULTI-TENANT METHODS
 
 	// GetUserByID gets a user by ID with tenant memberships loaded
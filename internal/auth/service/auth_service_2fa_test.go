@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ethos/internal/auth/model"
+	"ethos/pkg/errors"
+	"ethos/pkg/jwt"
+	"ethos/pkg/secretbox"
+	"ethos/pkg/totp"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const testTwoFactorKey = "test-2fa-key"
+
+func newTwoFactorTestService(t *testing.T, repo *MockRepository) *AuthService {
+	t.Helper()
+	box, err := secretbox.New(testTwoFactorKey)
+	require.NoError(t, err)
+
+	return &AuthService{
+		repo:           repo,
+		tokenGenerator: jwt.NewTokenGenerator("access-secret", "refresh-secret", time.Minute, time.Hour),
+		config:         Config{TwoFactorIssuer: "Ethos"},
+		secretBox:      box,
+	}
+}
+
+func newTwoFactorUser(t *testing.T, secret string) *model.User {
+	t.Helper()
+	box, err := secretbox.New(testTwoFactorKey)
+	require.NoError(t, err)
+	encrypted, err := box.Seal(secret)
+	require.NoError(t, err)
+	hash, err := bcrypt.GenerateFromPassword([]byte("Password123!"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	return &model.User{
+		ID:               "user-1",
+		Email:            "jane@example.com",
+		PasswordHash:     string(hash),
+		EmailVerified:    true,
+		TwoFactorEnabled: true,
+		TwoFactorSecret:  encrypted,
+	}
+}
+
+func TestAuthService_Login_TwoFactorReturnsChallenge(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	user := newTwoFactorUser(t, secret)
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockRepo.On("SaveLoginChallenge", mock.Anything, user.ID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(nil)
+
+	svc := newTwoFactorTestService(t, mockRepo)
+	resp, err := svc.Login(context.Background(), &LoginRequest{Email: user.Email, Password: "Password123!"})
+
+	require.NoError(t, err)
+	assert.True(t, resp.TwoFactorRequired)
+	assert.NotEmpty(t, resp.ChallengeToken)
+	assert.Empty(t, resp.AccessToken)
+	assert.Empty(t, resp.RefreshToken)
+	mockRepo.AssertNotCalled(t, "SaveRefreshToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthService_CompleteTwoFactorLogin_ValidTOTP(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	user := newTwoFactorUser(t, secret)
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+
+	challengeHash := hashToken("challenge")
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetLoginChallenge", mock.Anything, challengeHash).Return(user.ID, 0, nil)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("UpdateTwoFactorLastStep", mock.Anything, user.ID, mock.AnythingOfType("int64")).Return(nil)
+	mockRepo.On("DeleteLoginChallenge", mock.Anything, challengeHash).Return(nil)
+	mockRepo.On("SaveRefreshToken", mock.Anything, user.ID, "", mock.AnythingOfType("string"), mock.AnythingOfType("int64")).Return(nil)
+	mockRepo.On("GetUserRoles", mock.Anything, user.ID).Return([]string{"user"}, nil)
+
+	svc := newTwoFactorTestService(t, mockRepo)
+	resp, err := svc.CompleteTwoFactorLogin(context.Background(), &TwoFactorLoginRequest{ChallengeToken: "challenge", Code: code})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.AccessToken)
+	assert.NotEmpty(t, resp.RefreshToken)
+	assert.False(t, resp.TwoFactorRequired)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthService_CompleteTwoFactorLogin_WrongCodeCountsAttempt(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	user := newTwoFactorUser(t, secret)
+	code, err := totp.GenerateCode(secret, time.Now().Add(-10*time.Minute))
+	require.NoError(t, err)
+
+	challengeHash := hashToken("challenge")
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetLoginChallenge", mock.Anything, challengeHash).Return(user.ID, 1, nil)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("IncrementLoginChallengeAttempts", mock.Anything, challengeHash).Return(nil)
+
+	svc := newTwoFactorTestService(t, mockRepo)
+	resp, err := svc.CompleteTwoFactorLogin(context.Background(), &TwoFactorLoginRequest{ChallengeToken: "challenge", Code: code})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, errors.ErrInvalidTwoFactorCode, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthService_CompleteTwoFactorLogin_TooManyAttempts(t *testing.T) {
+	challengeHash := hashToken("challenge")
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetLoginChallenge", mock.Anything, challengeHash).Return("user-1", maxLoginChallengeAttempts, nil)
+	mockRepo.On("DeleteLoginChallenge", mock.Anything, challengeHash).Return(nil)
+
+	svc := newTwoFactorTestService(t, mockRepo)
+	_, err := svc.CompleteTwoFactorLogin(context.Background(), &TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "123456"})
+
+	assert.Equal(t, errors.ErrTokenInvalid, err)
+	mockRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_VerifyTwoFactorCode_RejectsReplay(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	user := newTwoFactorUser(t, secret)
+	now := time.Now()
+	code, err := totp.GenerateCode(secret, now)
+	require.NoError(t, err)
+	user.TwoFactorLastStep = now.Unix()/totp.Period + totp.Skew
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+
+	svc := newTwoFactorTestService(t, mockRepo)
+	err = svc.VerifyTwoFactorCode(context.Background(), user.ID, code)
+
+	assert.Equal(t, errors.ErrInvalidTwoFactorCode, err)
+	mockRepo.AssertNotCalled(t, "UpdateTwoFactorLastStep", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthService_VerifyTwoFactorCode_RecoveryCode(t *testing.T) {
+	user := newTwoFactorUser(t, "JBSWY3DPEHPK3PXP")
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("ConsumeRecoveryCode", mock.Anything, user.ID, hashToken("abcdefghij")).Return(nil)
+
+	svc := newTwoFactorTestService(t, mockRepo)
+	err := svc.VerifyTwoFactorCode(context.Background(), user.ID, " ABCDE-FGHIJ ")
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthService_Confirm2FA_EnablesAndIssuesRecoveryCodes(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	user := newTwoFactorUser(t, secret)
+	user.TwoFactorEnabled = false
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("EnableTwoFactor", mock.Anything, user.ID, mock.AnythingOfType("int64"), mock.MatchedBy(func(hashes []string) bool {
+		return len(hashes) == recoveryCodeCount
+	})).Return(nil)
+
+	svc := newTwoFactorTestService(t, mockRepo)
+	resp, err := svc.Confirm2FA(context.Background(), user.ID, &Confirm2FARequest{Code: code})
+
+	require.NoError(t, err)
+	assert.Len(t, resp.RecoveryCodes, recoveryCodeCount)
+	assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, resp.RecoveryCodes[0])
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthService_Setup2FA_StoresEncryptedSecret(t *testing.T) {
+	user := newTwoFactorUser(t, "JBSWY3DPEHPK3PXP")
+	user.TwoFactorEnabled = false
+	user.TwoFactorSecret = ""
+
+	var stored string
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("SaveTwoFactorSecret", mock.Anything, user.ID, mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { stored = args.String(2) }).
+		Return(nil)
+
+	svc := newTwoFactorTestService(t, mockRepo)
+	resp, err := svc.Setup2FA(context.Background(), user.ID, &Setup2FARequest{Password: "Password123!"})
+
+	require.NoError(t, err)
+	assert.NotEqual(t, resp.Secret, stored)
+	opened, err := svc.secretBox.Open(stored)
+	require.NoError(t, err)
+	assert.Equal(t, resp.Secret, opened)
+	assert.Contains(t, resp.QRCode, "otpauth://totp/Ethos:jane@example.com?")
+}
+
+func TestAuthService_CompleteTwoFactorLogin_WrongCodesLockAccount(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	user := newTwoFactorUser(t, secret)
+	code, err := totp.GenerateCode(secret, time.Now().Add(-10*time.Minute))
+	require.NoError(t, err)
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockRepo.On("SaveLoginChallenge", mock.Anything, user.ID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("GetLoginChallenge", mock.Anything, mock.AnythingOfType("string")).Return(user.ID, 0, nil)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("IncrementLoginChallengeAttempts", mock.Anything, mock.AnythingOfType("string")).Return(nil)
+	mockRepo.On("DeleteLoginChallenge", mock.Anything, mock.AnythingOfType("string")).Return(nil)
+	mockRepo.On("CreateSecurityEvent", mock.Anything, user.ID, model.SecurityEventAccountLocked, "10.0.0.1", "").Return(nil).Once()
+
+	svc := newLockoutTestService(mockRepo)
+	svc.secretBox = newTwoFactorTestService(t, mockRepo).secretBox
+
+	// Re-entering the password for a fresh challenge doesn't reset the count
+	for i := 0; i < 2; i++ {
+		resp, err := svc.Login(context.Background(), &LoginRequest{Email: user.Email, Password: "Password123!", IP: "10.0.0.1"})
+		require.NoError(t, err)
+		_, err = svc.CompleteTwoFactorLogin(context.Background(), &TwoFactorLoginRequest{ChallengeToken: resp.ChallengeToken, Code: code, IP: "10.0.0.1"})
+		assert.Equal(t, errors.ErrInvalidTwoFactorCode, err)
+	}
+
+	resp, err := svc.Login(context.Background(), &LoginRequest{Email: user.Email, Password: "Password123!", IP: "10.0.0.1"})
+	require.NoError(t, err)
+	_, err = svc.CompleteTwoFactorLogin(context.Background(), &TwoFactorLoginRequest{ChallengeToken: resp.ChallengeToken, Code: code, IP: "10.0.0.1"})
+	assert.Equal(t, errors.ErrAccountLocked, err)
+
+	_, err = svc.Login(context.Background(), &LoginRequest{Email: user.Email, Password: "Password123!", IP: "10.0.0.2"})
+	assert.Equal(t, errors.ErrAccountLocked, err)
+	mockRepo.AssertExpectations(t)
+}
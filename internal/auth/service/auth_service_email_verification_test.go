@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"ethos/internal/auth/model"
+	"ethos/pkg/email"
+	"ethos/pkg/errors"
+	"ethos/pkg/jwt"
+	"ethos/pkg/signedtoken"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const testVerificationSecret = "test-verification-secret"
+
+func newVerificationTestService(t *testing.T, repo *MockRepository) *AuthService {
+	t.Helper()
+	signer, err := signedtoken.New(testVerificationSecret)
+	require.NoError(t, err)
+
+	return &AuthService{
+		repo:           repo,
+		tokenGenerator: jwt.NewTokenGenerator("access-secret", "refresh-secret", time.Minute, time.Hour),
+		config:         Config{EmailVerificationTTL: time.Hour},
+		tokenSigner:    signer,
+	}
+}
+
+func newUnverifiedUser() *model.User {
+	return &model.User{ID: "user-1", Email: "jane@example.com", FirstName: "Jane", LastName: "Doe"}
+}
+
+func TestAuthService_VerifyEmail_ValidToken(t *testing.T) {
+	user := newUnverifiedUser()
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *model.User) bool { return u.EmailVerified })).Return(nil)
+
+	svc := newVerificationTestService(t, mockRepo)
+	token, err := svc.generateVerificationToken(user)
+	require.NoError(t, err)
+
+	err = svc.VerifyEmail(context.Background(), token)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthService_VerifyEmail_RejectsUnsignedToken(t *testing.T) {
+	mockRepo := new(MockRepository)
+	svc := newVerificationTestService(t, mockRepo)
+
+	err := svc.VerifyEmail(context.Background(), "verify_user-1-aaaaaaaaaaaaaaaa")
+
+	assert.Equal(t, errors.ErrTokenInvalid, err)
+	mockRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_VerifyEmail_ExpiredToken(t *testing.T) {
+	user := newUnverifiedUser()
+	mockRepo := new(MockRepository)
+	svc := newVerificationTestService(t, mockRepo)
+	svc.config.EmailVerificationTTL = -time.Minute
+
+	token, err := svc.generateVerificationToken(user)
+	require.NoError(t, err)
+
+	err = svc.VerifyEmail(context.Background(), token)
+
+	assert.Equal(t, errors.ErrTokenExpired, err)
+}
+
+func TestAuthService_VerifyEmail_RejectsChangedAddress(t *testing.T) {
+	user := newUnverifiedUser()
+	mockRepo := new(MockRepository)
+	svc := newVerificationTestService(t, mockRepo)
+
+	token, err := svc.generateVerificationToken(user)
+	require.NoError(t, err)
+
+	changed := newUnverifiedUser()
+	changed.Email = "new@example.com"
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(changed, nil)
+
+	err = svc.VerifyEmail(context.Background(), token)
+
+	assert.Equal(t, errors.ErrTokenInvalid, err)
+	mockRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_ResendVerification_SendsWorkingLink(t *testing.T) {
+	user := newUnverifiedUser()
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetUserByEmail", mock.Anything, "Jane@Example.com").Return(user, nil)
+
+	limiter := new(MockRateLimiter)
+	limiter.On("Allow", mock.Anything, "resend-verification:jane@example.com", resendVerificationLimit).Return(true, time.Duration(0), nil)
+
+	sent := make(chan email.SendEmailRequest, 1)
+	sender := new(MockEmailSender)
+	sender.On("SendEmail", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { sent <- args.Get(1).(email.SendEmailRequest) }).
+		Return(nil)
+
+	svc := newVerificationTestService(t, mockRepo)
+	svc.rateLimiter = limiter
+	svc.emailSender = sender
+
+	err := svc.ResendVerification(context.Background(), &ResendVerificationRequest{Email: "Jane@Example.com"})
+	require.NoError(t, err)
+
+	var req email.SendEmailRequest
+	select {
+	case req = <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("verification email was not sent")
+	}
+
+	link, err := url.Parse(req.TemplateData["VerifyURL"].(string))
+	require.NoError(t, err)
+	claims, err := svc.tokenSigner.Verify(link.Query().Get("token"), emailVerificationPurpose, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, claims.Subject)
+}
+
+func TestAuthService_ResendVerification_Throttled(t *testing.T) {
+	mockRepo := new(MockRepository)
+	limiter := new(MockRateLimiter)
+	limiter.On("Allow", mock.Anything, "resend-verification:jane@example.com", resendVerificationLimit).Return(false, time.Hour, nil)
+
+	svc := newVerificationTestService(t, mockRepo)
+	svc.rateLimiter = limiter
+
+	err := svc.ResendVerification(context.Background(), &ResendVerificationRequest{Email: "jane@example.com"})
+
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "GetUserByEmail", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Login_UnverifiedEmailFollowsPolicy(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("Password123!"), bcrypt.MinCost)
+	require.NoError(t, err)
+	orgID := "org-1"
+
+	tests := []struct {
+		name     string
+		required bool
+		wantErr  error
+	}{
+		{"required", true, errors.ErrEmailUnverified},
+		{"not required", false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := newUnverifiedUser()
+			user.PasswordHash = string(hash)
+			user.CurrentTenantID = &orgID
+
+			mockRepo := new(MockRepository)
+			mockRepo.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil)
+			mockRepo.On("SaveRefreshToken", mock.Anything, user.ID, "", mock.Anything, mock.Anything).Return(nil)
+			mockRepo.On("GetUserRoles", mock.Anything, user.ID).Return([]string{"user"}, nil)
+			mockRepo.On("GetOrganizationPasswordPolicy", mock.Anything, orgID).Return(nil, errors.ErrNotFound).Maybe()
+
+			policy := new(MockVerificationPolicy)
+			policy.On("RequiresEmailVerification", mock.Anything, orgID).Return(tt.required, nil)
+
+			svc := newVerificationTestService(t, mockRepo)
+			svc.verification = policy
+
+			resp, err := svc.Login(context.Background(), &LoginRequest{Email: user.Email, Password: "Password123!"})
+
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				require.NotNil(t, resp)
+				assert.NotEmpty(t, resp.AccessToken)
+			}
+			policy.AssertExpectations(t)
+		})
+	}
+}
@@ -2,24 +2,69 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base32"
 	"encoding/hex"
 	"fmt"
 	"regexp"
 	"strings"
 	"time"
-	"unicode"
 
 	"ethos/internal/auth/model"
 	"ethos/internal/auth/repository"
+	orgRepository "ethos/internal/organization/repository"
+	"ethos/internal/ratelimit"
+	"ethos/internal/revocation"
 	"ethos/pkg/email"
 	emailTemplates "ethos/pkg/email/templates"
 	"ethos/pkg/errors"
 	"ethos/pkg/jwt"
+	"ethos/pkg/secretbox"
+	"ethos/pkg/signedtoken"
+	"ethos/pkg/totp"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	// loginChallengeTTL is how long a user has to enter their second factor after a password login
+	loginChallengeTTL = 5 * time.Minute
+
+	// maxLoginChallengeAttempts is the number of wrong codes allowed per login challenge
+	maxLoginChallengeAttempts = 5
+
+	// recoveryCodeCount is the number of one-time recovery codes issued when 2FA is enabled
+	recoveryCodeCount = 10
+
+	// refreshTokenTTL is how long a refresh token can be exchanged; each rotation starts a new window
+	refreshTokenTTL = 14 * 24 * time.Hour
+
+	// defaultPasswordResetTTL is used when Config.PasswordResetTTL is not set
+	defaultPasswordResetTTL = time.Hour
+
+	// defaultEmailVerificationTTL is used when Config.EmailVerificationTTL is not set
+	defaultEmailVerificationTTL = 24 * time.Hour
+
+	// emailVerificationPurpose binds signed tokens to the email verification flow
+	emailVerificationPurpose = "email_verification"
+
+	// defaultLoginMaxAttempts is used when Config.LoginMaxAttempts is not set
+	defaultLoginMaxAttempts = 5
+
+	// defaultLoginMaxAttemptsPerIP is used when Config.LoginMaxAttemptsPerIP is not set
+	defaultLoginMaxAttemptsPerIP = 20
+
+	// defaultLoginAttemptWindow is used when Config.LoginAttemptWindow is not set
+	defaultLoginAttemptWindow = 15 * time.Minute
+
+	// defaultLoginLockoutDuration is used when Config.LoginLockoutDuration is not set
+	defaultLoginLockoutDuration = 15 * time.Minute
+)
+
+// resendVerificationLimit throttles verification emails per address
+var resendVerificationLimit = ratelimit.RateLimitConfig{Requests: 3, Window: time.Hour}
+
 // EmailChecker defines the interface for email validation
 type EmailChecker interface {
 	ValidateEmail(ctx context.Context, email string) (bool, error)
@@ -30,54 +75,142 @@ type EmailSender interface {
 	SendEmail(ctx context.Context, req email.SendEmailRequest) error
 }
 
+// SessionStore manages server-side user sessions (implemented by the organization context repository)
+type SessionStore interface {
+	CreateUserSession(ctx context.Context, userID, organizationID, tokenHash, refreshTokenHash, ipAddress, userAgent, deviceName string, expiresAt time.Time) (*orgRepository.UserSession, error)
+	ExtendUserSession(ctx context.Context, sessionID string, expiresAt time.Time) (*orgRepository.UserSession, error)
+	GetUserCurrentOrganization(ctx context.Context, userID string) (*orgRepository.UserContext, error)
+	GetUserRoleInOrganization(ctx context.Context, userID, organizationID string) (string, error)
+	GetUserSessionByID(ctx context.Context, sessionID string) (*orgRepository.UserSession, error)
+	RevokeUserSession(ctx context.Context, sessionID string) error
+	RevokeAllUserSessions(ctx context.Context, userID string) error
+	RevokeSessionsByRefreshTokenFamily(ctx context.Context, familyID string) error
+}
+
+// EmailVerificationPolicy decides whether unverified users may log in
+// (implemented by the organization service from system and organization settings)
+type EmailVerificationPolicy interface {
+	RequiresEmailVerification(ctx context.Context, orgID string) (bool, error)
+}
+
+// DomainJoiner links users to the organization that verified their email
+// domain (implemented by the organization domain service)
+type DomainJoiner interface {
+	JoinByEmailDomain(ctx context.Context, userID, email string) error
+}
+
+// Config holds auth service settings
+type Config struct {
+	// TwoFactorIssuer is shown as the account issuer in authenticator apps
+	TwoFactorIssuer string
+	// TwoFactorEncryptionKey encrypts TOTP secrets at rest
+	TwoFactorEncryptionKey string
+	// PasswordResetTTL is how long a password reset link stays valid
+	PasswordResetTTL time.Duration
+	// EmailVerificationSecret signs email verification tokens
+	EmailVerificationSecret string
+	// EmailVerificationTTL is how long an email verification link stays valid
+	EmailVerificationTTL time.Duration
+	// LoginMaxAttempts is the number of failed logins within LoginAttemptWindow that locks an account
+	LoginMaxAttempts int
+	// LoginMaxAttemptsPerIP is the number of failed logins within LoginAttemptWindow allowed from one IP
+	LoginMaxAttemptsPerIP int
+	// LoginAttemptWindow is how long failed logins are counted
+	LoginAttemptWindow time.Duration
+	// LoginLockoutDuration is how long a locked account stays locked, and how long its unlock link is valid
+	LoginLockoutDuration time.Duration
+	// LoginDelayBase is the delay after the second failed login, doubled for each one after; zero disables delays
+	LoginDelayBase time.Duration
+	// LoginDelayMax caps the progressive delay
+	LoginDelayMax time.Duration
+	// LoginAlerts records login, new device and impossible travel security events
+	LoginAlerts bool
+	// ImpossibleTravelSpeedKmh is the fastest plausible speed between two logins' locations
+	ImpossibleTravelSpeedKmh int
+	// PasswordPolicy is the system password policy; organizations can tighten it
+	PasswordPolicy model.PasswordPolicy
+}
+
 // AuthService implements the Service interface
 type AuthService struct {
 	repo           repository.Repository
 	tokenGenerator *jwt.TokenGenerator
 	emailChecker   EmailChecker
 	emailSender    EmailSender
-	// Simple in-memory rate limiting (in production, use Redis)
-	loginAttempts  map[string]int
-	lockedAccounts map[string]time.Time
+	sessions       SessionStore
+	revocations    revocation.List
+	rateLimiter    ratelimit.RateLimiter
+	verification   EmailVerificationPolicy
+	geo            GeoLocator
+	breaches       BreachChecker
+	domains        DomainJoiner
+	config         Config
+	secretBox      *secretbox.Box
+	tokenSigner    *signedtoken.Signer
 }
 
 // NewAuthService creates a new authentication service
-func NewAuthService(repo repository.Repository, tokenGen *jwt.TokenGenerator, emailChecker EmailChecker, emailSender EmailSender) Service {
+func NewAuthService(repo repository.Repository, tokenGen *jwt.TokenGenerator, emailChecker EmailChecker, emailSender EmailSender, sessions SessionStore, revocations revocation.List, rateLimiter ratelimit.RateLimiter, verification EmailVerificationPolicy, geo GeoLocator, breaches BreachChecker, domains DomainJoiner, cfg Config) Service {
+	if cfg.TwoFactorIssuer == "" {
+		cfg.TwoFactorIssuer = "Ethos"
+	}
+	if cfg.PasswordResetTTL <= 0 {
+		cfg.PasswordResetTTL = defaultPasswordResetTTL
+	}
+	if cfg.EmailVerificationTTL <= 0 {
+		cfg.EmailVerificationTTL = defaultEmailVerificationTTL
+	}
+	if cfg.LoginMaxAttempts <= 0 {
+		cfg.LoginMaxAttempts = defaultLoginMaxAttempts
+	}
+	if cfg.LoginMaxAttemptsPerIP <= 0 {
+		cfg.LoginMaxAttemptsPerIP = defaultLoginMaxAttemptsPerIP
+	}
+	if cfg.LoginAttemptWindow <= 0 {
+		cfg.LoginAttemptWindow = defaultLoginAttemptWindow
+	}
+	if cfg.LoginLockoutDuration <= 0 {
+		cfg.LoginLockoutDuration = defaultLoginLockoutDuration
+	}
+	if cfg.PasswordPolicy == (model.PasswordPolicy{}) {
+		cfg.PasswordPolicy = defaultPasswordPolicy
+	}
+
+	// A missing key leaves secretBox nil, which disables 2FA enrollment
+	box, _ := secretbox.New(cfg.TwoFactorEncryptionKey)
+	// Likewise a missing secret leaves tokenSigner nil, so no verification links can be issued or accepted
+	signer, _ := signedtoken.New(cfg.EmailVerificationSecret)
+
 	return &AuthService{
 		repo:           repo,
 		tokenGenerator: tokenGen,
 		emailChecker:   emailChecker,
 		emailSender:    emailSender,
-		loginAttempts:  make(map[string]int),
-		lockedAccounts: make(map[string]time.Time),
+		sessions:       sessions,
+		revocations:    revocations,
+		rateLimiter:    rateLimiter,
+		verification:   verification,
+		geo:            geo,
+		breaches:       breaches,
+		domains:        domains,
+		config:         cfg,
+		secretBox:      box,
+		tokenSigner:    signer,
 	}
 }
 
 // Login authenticates a user and returns tokens
 func (s *AuthService) Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error) {
-	// Check if account is locked
-	if lockTime, locked := s.lockedAccounts[req.Email]; locked {
-		if time.Now().Before(lockTime) {
-			return nil, errors.NewValidationError("account is temporarily locked due to too many failed login attempts")
-		}
-		// Lock has expired, remove it
-		delete(s.lockedAccounts, req.Email)
-		delete(s.loginAttempts, req.Email)
-	}
-
-	// Check rate limiting
-	if attempts := s.loginAttempts[req.Email]; attempts >= 5 {
-		// Lock account for 15 minutes
-		s.lockedAccounts[req.Email] = time.Now().Add(15 * time.Minute)
-		return nil, errors.NewValidationError("too many failed login attempts - account locked for 15 minutes")
+	if err := s.checkLoginAllowed(ctx, req.Email, req.IP); err != nil {
+		return nil, err
 	}
 
 	// Get user by email
 	user, err := s.repo.GetUserByEmail(ctx, req.Email)
 	if err != nil {
 		if err == errors.ErrUserNotFound {
-			s.loginAttempts[req.Email]++
-			return nil, errors.ErrInvalidCredentials
+			// Unknown addresses are counted and locked like real ones so lockouts don't reveal which accounts exist
+			return nil, s.recordLoginFailure(ctx, req.Email, req.IP, req.UserAgent, nil)
 		}
 		return nil, errors.WrapError(err, "failed to get user")
 	}
@@ -85,36 +218,141 @@ func (s *AuthService) Login(ctx context.Context, req *LoginRequest) (*LoginRespo
 	// Verify password
 	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password))
 	if err != nil {
-		s.loginAttempts[req.Email]++
-		return nil, errors.ErrInvalidCredentials
+		return nil, s.recordLoginFailure(ctx, req.Email, req.IP, req.UserAgent, user)
 	}
 
-	// Successful login - reset attempts
-	delete(s.loginAttempts, req.Email)
-
-	// Check if email is verified
-	if !user.EmailVerified {
+	// Check if email is verified, when system or organization settings require it
+	if !user.EmailVerified && s.requiresEmailVerification(ctx, user) {
 		return nil, errors.ErrEmailUnverified
 	}
 
-	// Generate tokens
-	accessToken, err := s.tokenGenerator.GenerateAccessToken(user.ID)
+	// Accounts with 2FA get a challenge instead of tokens
+	if user.TwoFactorEnabled {
+		return s.createLoginChallenge(ctx, user)
+	}
+
+	// Successful login - reset attempts. With 2FA this waits for the second
+	// factor, so a known password doesn't buy fresh guesses at the code.
+	s.clearLoginFailures(ctx, req.Email)
+
+	return s.issuePasswordLoginTokens(ctx, user, req.IP, req.UserAgent)
+}
+
+// CompleteTwoFactorLogin exchanges a login challenge and a TOTP or recovery code for tokens
+func (s *AuthService) CompleteTwoFactorLogin(ctx context.Context, req *TwoFactorLoginRequest) (*LoginResponse, error) {
+	challengeHash := hashToken(req.ChallengeToken)
+	userID, attempts, err := s.repo.GetLoginChallenge(ctx, challengeHash)
+	if err != nil {
+		return nil, errors.ErrTokenInvalid
+	}
+
+	if attempts >= maxLoginChallengeAttempts {
+		_ = s.repo.DeleteLoginChallenge(ctx, challengeHash)
+		return nil, errors.ErrTokenInvalid
+	}
+
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, errors.ErrTokenInvalid
+	}
+
+	if err := s.checkLoginAllowed(ctx, user.Email, req.IP); err != nil {
+		return nil, err
+	}
+
+	if err := s.verifySecondFactor(ctx, user, req.Code); err != nil {
+		if incErr := s.repo.IncrementLoginChallengeAttempts(ctx, challengeHash); incErr != nil {
+			return nil, errors.WrapError(incErr, "failed to record 2FA attempt")
+		}
+		// Wrong codes count towards the account lockout like wrong passwords
+		if lockErr := s.recordLoginFailure(ctx, user.Email, req.IP, req.UserAgent, user); lockErr == errors.ErrAccountLocked {
+			_ = s.repo.DeleteLoginChallenge(ctx, challengeHash)
+			return nil, lockErr
+		}
+		return nil, err
+	}
+
+	// Challenges are single use
+	if err := s.repo.DeleteLoginChallenge(ctx, challengeHash); err != nil {
+		return nil, errors.WrapError(err, "failed to delete login challenge")
+	}
+
+	s.clearLoginFailures(ctx, user.Email)
+
+	return s.issuePasswordLoginTokens(ctx, user, req.IP, req.UserAgent)
+}
+
+// issuePasswordLoginTokens issues tokens after a password login, flagging
+// passwords past their maximum age so the client can ask for a new one
+func (s *AuthService) issuePasswordLoginTokens(ctx context.Context, user *model.User, ip, userAgent string) (*LoginResponse, error) {
+	resp, err := s.issueTokens(ctx, user, "", ip, userAgent)
+	if err != nil {
+		return nil, err
+	}
+	resp.PasswordExpired = s.passwordExpired(ctx, user)
+	return resp, nil
+}
+
+// createLoginChallenge stores a short-lived challenge for the second login step
+func (s *AuthService) createLoginChallenge(ctx context.Context, user *model.User) (*LoginResponse, error) {
+	challengeToken, err := generateRandomToken()
 	if err != nil {
-		return nil, errors.WrapError(err, "failed to generate access token")
+		return nil, errors.WrapError(err, "failed to generate login challenge")
+	}
+
+	expiresAt := time.Now().Add(loginChallengeTTL)
+	if err := s.repo.SaveLoginChallenge(ctx, user.ID, hashToken(challengeToken), expiresAt); err != nil {
+		return nil, errors.WrapError(err, "failed to save login challenge")
 	}
 
+	return &LoginResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    challengeToken,
+	}, nil
+}
+
+// issueTokens starts a user session and issues an access/refresh token pair
+// for a fully authenticated user. The access token is bound to the session
+// and to organizationID, or the user's current organization when it is empty.
+func (s *AuthService) issueTokens(ctx context.Context, user *model.User, organizationID, ip, userAgent string) (*LoginResponse, error) {
 	refreshToken, err := s.tokenGenerator.GenerateRefreshToken(user.ID)
 	if err != nil {
 		return nil, errors.WrapError(err, "failed to generate refresh token")
 	}
+	tokenHash := hashToken(refreshToken)
+	expiresAt := time.Now().Add(refreshTokenTTL)
+
+	var sessionID string
+	if s.sessions != nil {
+		if organizationID == "" {
+			if current, err := s.sessions.GetUserCurrentOrganization(ctx, user.ID); err == nil && current.Role != "" {
+				organizationID = current.OrganizationID
+			}
+		}
+
+		sessionToken, err := generateRandomToken()
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to generate session token")
+		}
+		session, err := s.sessions.CreateUserSession(ctx, user.ID, organizationID, hashToken(sessionToken), tokenHash, ip, userAgent, "", expiresAt)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to create session")
+		}
+		sessionID = session.ID
+	}
 
 	// Save refresh token
-	tokenHash := hashToken(refreshToken)
-	expiresAt := time.Now().Add(14 * 24 * time.Hour).Unix()
-	if err := s.repo.SaveRefreshToken(ctx, user.ID, tokenHash, expiresAt); err != nil {
+	if err := s.repo.SaveRefreshToken(ctx, user.ID, sessionID, tokenHash, expiresAt.Unix()); err != nil {
 		return nil, errors.WrapError(err, "failed to save refresh token")
 	}
 
+	accessToken, err := s.generateAccessToken(ctx, user.ID, sessionID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordLogin(ctx, user, ip, userAgent)
+
 	return &LoginResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
@@ -149,11 +387,8 @@ func (s *AuthService) Register(ctx context.Context, req *RegisterRequest) (*mode
 	if req.Password == "" {
 		return nil, errors.NewValidationError("password is required")
 	}
-	if len(req.Password) < 8 {
-		return nil, errors.NewValidationError("password must be at least 8 characters long")
-	}
-	if !hasPasswordRequirements(req.Password) {
-		return nil, errors.NewValidationError("password must contain at least one uppercase letter, one lowercase letter, one number, and one special character")
+	if err := s.validatePassword(ctx, s.systemPasswordPolicy(), nil, req.Password); err != nil {
+		return nil, err
 	}
 
 	// Terms acceptance validation
@@ -184,40 +419,128 @@ func (s *AuthService) Register(ctx context.Context, req *RegisterRequest) (*mode
 		PasswordHash:  string(hashedPassword),
 		FirstName:     req.FirstName,
 		LastName:      req.LastName,
-		EmailVerified: false,
+		EmailVerified: req.EmailVerified,
 	}
 
 	if err := s.repo.CreateUser(ctx, user); err != nil {
 		return nil, err
 	}
 
-	// Send verification email if sender is configured
-	if s.emailSender != nil {
-		template := emailTemplates.GetTemplate(emailTemplates.TemplateEmailVerification)
-		emailReq := email.SendEmailRequest{
-			To:         req.Email,
-			Subject:    template["subject"].(string),
-			TemplateID: template["template_id"].(string),
-			TemplateData: map[string]interface{}{
-				"FirstName": req.FirstName,
-				"Name":      user.FirstName + " " + user.LastName,
-				"email":     req.Email,
-				"user_id":   user.ID,
-			},
+	// Send verification email (don't fail registration if email fails)
+	if !user.EmailVerified {
+		if err := s.sendVerificationEmail(user); err != nil {
+			fmt.Printf("Failed to send verification email: %v\n", err)
 		}
-		// Send email asynchronously (don't fail registration if email fails)
-		go func() {
-			if err := s.emailSender.SendEmail(context.Background(), emailReq); err != nil {
-				// Log error but don't fail registration
-				fmt.Printf("Failed to send verification email: %v\n", err)
-			}
-		}()
+	} else {
+		s.joinByEmailDomain(ctx, user)
 	}
 
 	return user.ToProfile(), nil
 }
 
-// RefreshToken generates a new access token from a refresh token
+// ResendVerification sends a fresh verification link. It never reveals
+// whether the address exists or is already verified, and is throttled per
+// address so it can't be used to flood an inbox.
+func (s *AuthService) ResendVerification(ctx context.Context, req *ResendVerificationRequest) error {
+	address := strings.ToLower(strings.TrimSpace(req.Email))
+
+	if s.rateLimiter != nil {
+		allowed, _, err := s.rateLimiter.Allow(ctx, "resend-verification:"+address, resendVerificationLimit)
+		if err != nil {
+			// Fail open: a cache outage shouldn't lock users out of verifying
+			fmt.Printf("Failed to check resend verification rate limit: %v\n", err)
+		} else if !allowed {
+			return nil
+		}
+	}
+
+	user, err := s.repo.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		if err == errors.ErrUserNotFound {
+			return nil
+		}
+		return errors.WrapError(err, "failed to get user")
+	}
+
+	if user.EmailVerified {
+		return nil
+	}
+
+	if err := s.sendVerificationEmail(user); err != nil {
+		return errors.WrapError(err, "failed to send verification email")
+	}
+	return nil
+}
+
+// sendVerificationEmail signs a verification token for the user's current address and emails the link
+func (s *AuthService) sendVerificationEmail(user *model.User) error {
+	if s.emailSender == nil {
+		return nil
+	}
+
+	token, err := s.generateVerificationToken(user)
+	if err != nil {
+		return err
+	}
+
+	template := emailTemplates.GetTemplate(emailTemplates.TemplateEmailVerification)
+	emailReq := email.SendEmailRequest{
+		To:         user.Email,
+		Subject:    template["subject"].(string),
+		TemplateID: template["template_id"].(string),
+		TemplateData: map[string]interface{}{
+			"FirstName": user.FirstName,
+			"Name":      user.FirstName + " " + user.LastName,
+			"email":     user.Email,
+			"VerifyURL": fmt.Sprintf("http://localhost:5173/verify-email?token=%s", token), // TODO: Make configurable
+		},
+	}
+
+	// Send email asynchronously
+	go func() {
+		if err := s.emailSender.SendEmail(context.Background(), emailReq); err != nil {
+			fmt.Printf("Failed to send verification email: %v\n", err)
+		}
+	}()
+
+	return nil
+}
+
+// generateVerificationToken signs a token bound to the user and the address being verified
+func (s *AuthService) generateVerificationToken(user *model.User) (string, error) {
+	if s.tokenSigner == nil {
+		return "", fmt.Errorf("email verification secret not configured")
+	}
+
+	return s.tokenSigner.Sign(signedtoken.Claims{
+		Purpose:   emailVerificationPurpose,
+		Subject:   user.ID,
+		Email:     strings.ToLower(user.Email),
+		ExpiresAt: time.Now().Add(s.config.EmailVerificationTTL).Unix(),
+	})
+}
+
+// requiresEmailVerification applies system and organization settings; it fails closed
+func (s *AuthService) requiresEmailVerification(ctx context.Context, user *model.User) bool {
+	if s.verification == nil {
+		return true
+	}
+
+	orgID := ""
+	if user.CurrentTenantID != nil {
+		orgID = *user.CurrentTenantID
+	}
+
+	required, err := s.verification.RequiresEmailVerification(ctx, orgID)
+	if err != nil {
+		return true
+	}
+	return required
+}
+
+// RefreshToken exchanges a refresh token for a new token pair. The presented
+// token is rotated out; presenting it again is treated as token theft and
+// revokes the whole token family along with its sessions.
 func (s *AuthService) RefreshToken(ctx context.Context, req *RefreshRequest) (*LoginResponse, error) {
 	// Validate refresh token
 	userID, err := s.tokenGenerator.ValidateRefreshToken(req.RefreshToken)
@@ -228,29 +551,196 @@ func (s *AuthService) RefreshToken(ctx context.Context, req *RefreshRequest) (*L
 		return nil, errors.ErrTokenInvalid
 	}
 
-	// Verify token exists in database
-	tokenHash := hashToken(req.RefreshToken)
-	storedUserID, err := s.repo.GetRefreshToken(ctx, tokenHash)
+	newRefreshToken, err := s.tokenGenerator.GenerateRefreshToken(userID)
 	if err != nil {
-		return nil, errors.ErrTokenInvalid
+		return nil, errors.WrapError(err, "failed to generate refresh token")
 	}
 
-	if storedUserID != userID {
+	expiresAt := time.Now().Add(refreshTokenTTL).Unix()
+	rotation, err := s.repo.RotateRefreshToken(ctx, userID, hashToken(req.RefreshToken), hashToken(newRefreshToken), expiresAt)
+	if err != nil {
+		if err == errors.ErrTokenInvalid {
+			return nil, errors.ErrTokenInvalid
+		}
+		return nil, errors.WrapError(err, "failed to rotate refresh token")
+	}
+
+	if rotation.Reused {
+		s.handleRefreshTokenReuse(ctx, rotation, req.IP)
 		return nil, errors.ErrTokenInvalid
 	}
 
+	// Refreshing keeps the session alive; a revoked session can't be refreshed
+	var organizationID string
+	if rotation.SessionID != "" && s.sessions != nil {
+		session, err := s.sessions.ExtendUserSession(ctx, rotation.SessionID, time.Unix(expiresAt, 0))
+		if err != nil {
+			if err == errors.ErrNotFound {
+				return nil, errors.ErrTokenInvalid
+			}
+			return nil, errors.WrapError(err, "failed to extend session")
+		}
+		organizationID = session.OrganizationID
+	}
+
 	// Generate new access token
-	accessToken, err := s.tokenGenerator.GenerateAccessToken(userID)
+	accessToken, err := s.generateAccessToken(ctx, userID, rotation.SessionID, organizationID)
 	if err != nil {
-		return nil, errors.WrapError(err, "failed to generate access token")
+		return nil, err
 	}
 
 	return &LoginResponse{
 		AccessToken:  accessToken,
-		RefreshToken: req.RefreshToken, // Return same refresh token
+		RefreshToken: newRefreshToken,
 	}, nil
 }
 
+// CompleteExternalLogin issues tokens for a user authenticated without a
+// password, by an organization's SSO or a passkey. Password lockouts and the
+// local second factor don't apply; the provider or the user-verifying
+// authenticator is responsible for both.
+func (s *AuthService) CompleteExternalLogin(ctx context.Context, userID, organizationID, ip, userAgent string) (*LoginResponse, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.issueTokens(ctx, user, organizationID, ip, userAgent)
+}
+
+// IssueAccessToken issues an access token for an existing session after its
+// organization context changed
+func (s *AuthService) IssueAccessToken(ctx context.Context, userID, sessionID, organizationID string) (string, error) {
+	return s.generateAccessToken(ctx, userID, sessionID, organizationID)
+}
+
+// generateAccessToken signs an access token carrying the session ID, the
+// user's role in the session's organization and their platform roles.
+// Organization claims are left out when the user is no longer a member.
+func (s *AuthService) generateAccessToken(ctx context.Context, userID, sessionID, organizationID string) (string, error) {
+	sc := jwt.SessionContext{SessionID: sessionID}
+
+	if organizationID != "" && s.sessions != nil {
+		role, err := s.sessions.GetUserRoleInOrganization(ctx, userID, organizationID)
+		if err == nil {
+			sc.OrganizationID = organizationID
+			sc.OrganizationRole = role
+		} else if err != errors.ErrNotFound {
+			return "", errors.WrapError(err, "failed to get organization role")
+		}
+	}
+
+	roles, err := s.repo.GetUserRoles(ctx, userID)
+	if err != nil {
+		return "", errors.WrapError(err, "failed to get user roles")
+	}
+	sc.Roles = roles
+
+	accessToken, err := s.tokenGenerator.GenerateAccessTokenWithContext(userID, sc)
+	if err != nil {
+		return "", errors.WrapError(err, "failed to generate access token")
+	}
+	return accessToken, nil
+}
+
+// handleRefreshTokenReuse revokes sessions linked to a compromised token family and records the event.
+// The family itself has already been revoked by the repository.
+func (s *AuthService) handleRefreshTokenReuse(ctx context.Context, rotation *model.RefreshTokenRotation, ip string) {
+	if s.sessions != nil {
+		if err := s.sessions.RevokeSessionsByRefreshTokenFamily(ctx, rotation.FamilyID); err != nil {
+			fmt.Printf("Failed to revoke sessions for refresh token family %s: %v\n", rotation.FamilyID, err)
+		}
+	}
+
+	if rotation.SessionID != "" && s.revocations != nil {
+		if err := s.revocations.RevokeSession(ctx, rotation.SessionID); err != nil {
+			fmt.Printf("Failed to revoke access tokens for session %s: %v\n", rotation.SessionID, err)
+		}
+	}
+
+	if err := s.repo.CreateSecurityEvent(ctx, rotation.UserID, model.SecurityEventRefreshTokenReuse, ip, ""); err != nil {
+		fmt.Printf("Failed to record refresh token reuse event: %v\n", err)
+	}
+}
+
+// Logout ends the session the access token belongs to, or every session of
+// the user when AllDevices is set. Access tokens already handed out are added
+// to the revocation list so they stop working before they expire.
+func (s *AuthService) Logout(ctx context.Context, req *LogoutRequest) error {
+	if req.AllDevices {
+		return s.logoutAllDevices(ctx, req.UserID)
+	}
+
+	if req.SessionID != "" {
+		return s.RevokeSession(ctx, req.UserID, req.SessionID)
+	}
+
+	// Tokens issued before sessions existed can only be revoked individually
+	if req.RefreshToken != "" {
+		if err := s.repo.DeleteRefreshToken(ctx, hashToken(req.RefreshToken)); err != nil {
+			return errors.WrapError(err, "failed to revoke refresh token")
+		}
+	}
+	if req.TokenID != "" && s.revocations != nil {
+		if err := s.revocations.RevokeToken(ctx, req.TokenID); err != nil {
+			return errors.WrapError(err, "failed to revoke access token")
+		}
+	}
+
+	return nil
+}
+
+// RevokeSession ends one of the user's sessions along with its refresh and access tokens
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	if s.sessions == nil {
+		return errors.ErrNotFound
+	}
+
+	session, err := s.sessions.GetUserSessionByID(ctx, sessionID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return errors.ErrNotFound
+		}
+		return errors.WrapError(err, "failed to get session")
+	}
+	// Don't reveal other users' sessions
+	if session.UserID != userID {
+		return errors.ErrNotFound
+	}
+
+	if err := s.sessions.RevokeUserSession(ctx, sessionID); err != nil {
+		return errors.WrapError(err, "failed to revoke session")
+	}
+	if err := s.repo.RevokeSessionRefreshTokens(ctx, sessionID); err != nil {
+		return err
+	}
+	if s.revocations != nil {
+		if err := s.revocations.RevokeSession(ctx, sessionID); err != nil {
+			return errors.WrapError(err, "failed to revoke access tokens")
+		}
+	}
+
+	return nil
+}
+
+// logoutAllDevices revokes every session, refresh token and access token of a user
+func (s *AuthService) logoutAllDevices(ctx context.Context, userID string) error {
+	if s.sessions != nil {
+		if err := s.sessions.RevokeAllUserSessions(ctx, userID); err != nil {
+			return errors.WrapError(err, "failed to revoke sessions")
+		}
+	}
+	if err := s.repo.RevokeUserRefreshTokens(ctx, userID); err != nil {
+		return err
+	}
+	if s.revocations != nil {
+		if err := s.revocations.RevokeUserTokens(ctx, userID, time.Now()); err != nil {
+			return errors.WrapError(err, "failed to revoke access tokens")
+		}
+	}
+
+	return nil
+}
+
 // GetUserProfile retrieves a user profile by ID
 func (s *AuthService) GetUserProfile(ctx context.Context, userID string) (*model.UserProfile, error) {
 	user, err := s.repo.GetUserByID(ctx, userID)
@@ -263,19 +753,29 @@ func (s *AuthService) GetUserProfile(ctx context.Context, userID string) (*model
 
 // VerifyEmail marks a user's email as verified using a verification token
 func (s *AuthService) VerifyEmail(ctx context.Context, token string) error {
-	// Parse the verification token to extract user ID
-	// Token format: hash of user_id
-	userID, err := parseVerificationToken(token)
+	if s.tokenSigner == nil {
+		return errors.ErrTokenInvalid
+	}
+
+	claims, err := s.tokenSigner.Verify(token, emailVerificationPurpose, time.Now())
 	if err != nil {
+		if err == signedtoken.ErrExpired {
+			return errors.ErrTokenExpired
+		}
 		return errors.ErrTokenInvalid
 	}
 
 	// Get user from repository
-	user, err := s.repo.GetUserByID(ctx, userID)
+	user, err := s.repo.GetUserByID(ctx, claims.Subject)
 	if err != nil {
 		return errors.ErrUserNotFound
 	}
 
+	// A link sent to a previous address must not verify a changed one
+	if !strings.EqualFold(user.Email, claims.Email) {
+		return errors.ErrTokenInvalid
+	}
+
 	// If already verified, return success (idempotent)
 	if user.EmailVerified {
 		return nil
@@ -290,6 +790,8 @@ func (s *AuthService) VerifyEmail(ctx context.Context, token string) error {
 		return err
 	}
 
+	s.joinByEmailDomain(ctx, user)
+
 	// Send welcome email after successful verification
 	if s.emailSender != nil {
 		template := emailTemplates.GetTemplate(emailTemplates.TemplateWelcomeStandardUser)
@@ -318,6 +820,18 @@ func (s *AuthService) VerifyEmail(ctx context.Context, token string) error {
 	return nil
 }
 
+// joinByEmailDomain links a user to the organization that verified their
+// email domain. Only proven addresses count, so it runs once the address is
+// verified; failures don't fail registration or verification.
+func (s *AuthService) joinByEmailDomain(ctx context.Context, user *model.User) {
+	if s.domains == nil {
+		return
+	}
+	if err := s.domains.JoinByEmailDomain(ctx, user.ID, user.Email); err != nil {
+		fmt.Printf("Failed to join organization by email domain for user %s: %v\n", user.ID, err)
+	}
+}
+
 // RequestPasswordReset initiates a password reset process by sending a reset email
 func (s *AuthService) RequestPasswordReset(ctx context.Context, req *RequestPasswordResetRequest) error {
 	// Get user by email
@@ -330,8 +844,16 @@ func (s *AuthService) RequestPasswordReset(ctx context.Context, req *RequestPass
 		return errors.WrapError(err, "failed to get user")
 	}
 
-	// Generate reset token (simplified - in production, use secure token generation)
-	resetToken := fmt.Sprintf("reset_%s_%d", user.ID, time.Now().Unix())
+	// Generate a random single-use reset token; only its hash is stored
+	resetToken, err := generateRandomToken()
+	if err != nil {
+		return errors.WrapError(err, "failed to generate reset token")
+	}
+
+	expiresAt := time.Now().Add(s.config.PasswordResetTTL)
+	if err := s.repo.SavePasswordResetToken(ctx, user.ID, hashToken(resetToken), expiresAt); err != nil {
+		return errors.WrapError(err, "failed to save reset token")
+	}
 
 	// Send password reset email if sender is configured
 	if s.emailSender != nil {
@@ -360,6 +882,60 @@ func (s *AuthService) RequestPasswordReset(ctx context.Context, req *RequestPass
 	return nil
 }
 
+// ResetPassword sets a new password using a single-use reset token and signs the user out everywhere
+func (s *AuthService) ResetPassword(ctx context.Context, req *ResetPasswordRequest) error {
+	// Weak passwords are rejected before the token is looked up
+	if problem := s.systemPasswordPolicy().Check(req.NewPassword); problem != "" {
+		return errors.NewValidationError(problem)
+	}
+
+	// The token isn't consumed until the password passes the user's full policy
+	tokenUserID, err := s.repo.GetPasswordResetUserID(ctx, hashToken(req.Token))
+	if err != nil {
+		return err
+	}
+	user, err := s.repo.GetUserByID(ctx, tokenUserID)
+	if err != nil {
+		return err
+	}
+	policy, err := s.passwordPolicyFor(ctx, user)
+	if err != nil {
+		return err
+	}
+	if err := s.validatePassword(ctx, policy, user, req.NewPassword); err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.ErrServerError
+	}
+
+	// Consumes the token, updates the password and deletes refresh tokens atomically
+	userID, err := s.repo.ResetPassword(ctx, hashToken(req.Token), string(hashedPassword))
+	if err != nil {
+		return err
+	}
+
+	if s.sessions != nil {
+		if err := s.sessions.RevokeAllUserSessions(ctx, userID); err != nil {
+			return errors.WrapError(err, "failed to revoke sessions")
+		}
+	}
+	if s.revocations != nil {
+		if err := s.revocations.RevokeUserTokens(ctx, userID, time.Now()); err != nil {
+			return errors.WrapError(err, "failed to revoke access tokens")
+		}
+	}
+
+	if err := s.repo.CreateSecurityEvent(ctx, userID, model.SecurityEventPasswordReset, req.IP, ""); err != nil {
+		// The password has already changed; don't fail the request over the audit record
+		fmt.Printf("Failed to record password reset event: %v\n", err)
+	}
+
+	return nil
+}
+
 // ChangePassword changes a user's password after verifying the current password
 func (s *AuthService) ChangePassword(ctx context.Context, userID string, req *ChangePasswordRequest) error {
 	// Get user from repository
@@ -374,25 +950,30 @@ func (s *AuthService) ChangePassword(ctx context.Context, userID string, req *Ch
 		return errors.ErrInvalidCredentials
 	}
 
+	policy, err := s.passwordPolicyFor(ctx, user)
+	if err != nil {
+		return err
+	}
+	if err := s.validatePassword(ctx, policy, user, req.NewPassword); err != nil {
+		return err
+	}
+
 	// Hash new password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
 	if err != nil {
 		return errors.ErrServerError
 	}
 
-	// Update password
-	user.PasswordHash = string(hashedPassword)
-	user.UpdatedAt = time.Now()
-
-	// Save updated user to repository
-	if err := s.repo.UpdateUser(ctx, user); err != nil {
+	// Update password, keeping the old one in the password history
+	if err := s.repo.UpdatePassword(ctx, user.ID, string(hashedPassword)); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// Setup2FA initializes two-factor authentication for a user
+// Setup2FA initializes two-factor authentication for a user. The secret is
+// stored encrypted but 2FA stays disabled until Confirm2FA succeeds.
 func (s *AuthService) Setup2FA(ctx context.Context, userID string, req *Setup2FARequest) (*Setup2FAResponse, error) {
 	// Get user from repository
 	user, err := s.repo.GetUserByID(ctx, userID)
@@ -406,47 +987,143 @@ func (s *AuthService) Setup2FA(ctx context.Context, userID string, req *Setup2FA
 		return nil, errors.ErrInvalidCredentials
 	}
 
+	if user.TwoFactorEnabled {
+		return nil, errors.NewValidationError("two-factor authentication is already enabled")
+	}
+
+	if s.secretBox == nil {
+		return nil, errors.WrapError(fmt.Errorf("encryption key not configured"), "2FA unavailable")
+	}
+
 	// Generate 2FA secret
-	secret := generateTOTPSecret(user.ID)
-	qrCode := generateQRCode(user.Email, secret)
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to generate 2FA secret")
+	}
+
+	encryptedSecret, err := s.secretBox.Seal(secret)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to encrypt 2FA secret")
+	}
+
+	if err := s.repo.SaveTwoFactorSecret(ctx, user.ID, encryptedSecret); err != nil {
+		return nil, err
+	}
 
-	// Note: In production, would store the 2FA secret in a new model/table
-	// For now returning it in response for client to save/confirm
 	return &Setup2FAResponse{
 		Secret:  secret,
-		QRCode:  qrCode,
-		Message: "Please save this secret and scan the QR code with an authenticator app",
+		QRCode:  totp.ProvisioningURI(s.config.TwoFactorIssuer, user.Email, secret),
+		Message: "Scan the QR code with an authenticator app, then confirm with a code to enable 2FA",
 	}, nil
 }
 
-// parseVerificationToken extracts user ID from verification token
-func parseVerificationToken(token string) (string, error) {
-	// Token format: hash-based token containing user_id
-	// In production, would validate HMAC or JWT signature
-	// For testing: assume token is prefixed with "verify_"
-	if len(token) < 20 {
-		return "", fmt.Errorf("invalid token format")
+// Confirm2FA activates a pending 2FA setup once the user proves their authenticator works
+func (s *AuthService) Confirm2FA(ctx context.Context, userID string, req *Confirm2FARequest) (*Confirm2FAResponse, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, errors.ErrUserNotFound
 	}
 
-	// Extract user ID from token (simplified)
-	if len(token) > 7 && token[:7] == "verify_" {
-		return token[7:], nil
+	if user.TwoFactorEnabled {
+		return nil, errors.NewValidationError("two-factor authentication is already enabled")
+	}
+	if user.TwoFactorSecret == "" {
+		return nil, errors.NewValidationError("two-factor setup has not been started")
 	}
 
-	return "", fmt.Errorf("invalid token format")
+	secret, err := s.decryptTwoFactorSecret(user)
+	if err != nil {
+		return nil, err
+	}
+
+	step, ok := totp.Validate(secret, req.Code, time.Now())
+	if !ok {
+		return nil, errors.ErrInvalidTwoFactorCode
+	}
+
+	recoveryCodes := make([]string, 0, recoveryCodeCount)
+	recoveryCodeHashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to generate recovery codes")
+		}
+		recoveryCodes = append(recoveryCodes, code)
+		recoveryCodeHashes = append(recoveryCodeHashes, hashToken(normalizeRecoveryCode(code)))
+	}
+
+	if err := s.repo.EnableTwoFactor(ctx, user.ID, step, recoveryCodeHashes); err != nil {
+		return nil, err
+	}
+
+	return &Confirm2FAResponse{
+		RecoveryCodes: recoveryCodes,
+		Message:       "Two-factor authentication enabled. Store these recovery codes somewhere safe; each can be used once.",
+	}, nil
 }
 
-// generateTOTPSecret generates a TOTP secret for 2FA
-func generateTOTPSecret(userID string) string {
-	// Generate deterministic secret from user ID
-	hash := sha256.Sum256([]byte(userID + time.Now().Format("20060102")))
-	return hex.EncodeToString(hash[:16])
+// VerifyTwoFactorCode checks a TOTP or recovery code for a user with 2FA enabled
+func (s *AuthService) VerifyTwoFactorCode(ctx context.Context, userID, code string) error {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return errors.ErrUserNotFound
+	}
+
+	return s.verifySecondFactor(ctx, user, code)
 }
 
-// generateQRCode generates a QR code URI for TOTP setup
-func generateQRCode(_ string, secret string) string {
-	// Return otpauth URI that can be converted to QR code by client
-	return fmt.Sprintf("otpauth://totp/Ethos:%%s?secret=%s&issuer=Ethos", secret)
+// verifySecondFactor accepts either a current TOTP code or an unused recovery code
+func (s *AuthService) verifySecondFactor(ctx context.Context, user *model.User, code string) error {
+	if !user.TwoFactorEnabled {
+		return errors.NewValidationError("two-factor authentication is not enabled")
+	}
+
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return errors.ErrInvalidTwoFactorCode
+	}
+
+	// Six digits is a TOTP code; anything else is treated as a recovery code
+	if len(code) == totp.Digits && strings.Trim(code, "0123456789") == "" {
+		secret, err := s.decryptTwoFactorSecret(user)
+		if err != nil {
+			return err
+		}
+
+		step, ok := totp.Validate(secret, code, time.Now())
+		if !ok || step <= user.TwoFactorLastStep {
+			return errors.ErrInvalidTwoFactorCode
+		}
+
+		if err := s.repo.UpdateTwoFactorLastStep(ctx, user.ID, step); err != nil {
+			if err == errors.ErrTokenInvalid {
+				return errors.ErrInvalidTwoFactorCode
+			}
+			return err
+		}
+		return nil
+	}
+
+	if err := s.repo.ConsumeRecoveryCode(ctx, user.ID, hashToken(normalizeRecoveryCode(code))); err != nil {
+		if err == errors.ErrTokenInvalid {
+			return errors.ErrInvalidTwoFactorCode
+		}
+		return err
+	}
+	return nil
+}
+
+// decryptTwoFactorSecret decrypts the stored TOTP secret for a user
+func (s *AuthService) decryptTwoFactorSecret(user *model.User) (string, error) {
+	if s.secretBox == nil {
+		return "", errors.WrapError(fmt.Errorf("encryption key not configured"), "2FA unavailable")
+	}
+
+	secret, err := s.secretBox.Open(user.TwoFactorSecret)
+	if err != nil {
+		return "", errors.WrapError(err, "failed to decrypt 2FA secret")
+	}
+	return secret, nil
 }
 
 // hashToken creates a SHA256 hash of a token for storage
@@ -455,33 +1132,35 @@ func hashToken(token string) string {
 	return hex.EncodeToString(hash[:])
 }
 
-// isValidEmailFormat validates email format using regex
-func isValidEmailFormat(email string) bool {
-	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
-	return emailRegex.MatchString(email)
+// generateRandomToken generates an opaque, URL-safe random token
+func generateRandomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
 }
 
-// hasPasswordRequirements checks if password meets security requirements
-func hasPasswordRequirements(password string) bool {
-	if len(password) < 8 {
-		return false
+// generateRecoveryCode generates a one-time recovery code in the form xxxxx-xxxxx
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, 7)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
 	}
+	code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
 
-	var hasUpper, hasLower, hasNumber, hasSpecial bool
-	for _, char := range password {
-		switch {
-		case unicode.IsUpper(char):
-			hasUpper = true
-		case unicode.IsLower(char):
-			hasLower = true
-		case unicode.IsDigit(char):
-			hasNumber = true
-		case unicode.IsPunct(char) || unicode.IsSymbol(char):
-			hasSpecial = true
-		}
-	}
+// normalizeRecoveryCode strips formatting so codes match however the user types them
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
 
-	return hasUpper && hasLower && hasNumber && hasSpecial
+// isValidEmailFormat validates email format using regex
+func isValidEmailFormat(email string) bool {
+	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	return emailRegex.MatchString(email)
 }
 
 // MULTI-TENANT METHODS IMPLEMENTATION
@@ -3,9 +3,14 @@ package service
 import (
 	"context"
 	"testing"
+	"time"
 
 	"ethos/internal/auth/model"
+	orgRepository "ethos/internal/organization/repository"
+	"ethos/internal/ratelimit"
+	"ethos/pkg/email"
 	"ethos/pkg/errors"
+	"ethos/pkg/jwt"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -160,11 +165,19 @@ func (m *MockRepository) GetUserByID(ctx context.Context, userID string) (*model
 	return args.Get(0).(*model.User), args.Error(1)
 }
 
-func (m *MockRepository) SaveRefreshToken(ctx context.Context, userID, tokenHash string, expiresAt int64) error {
-	args := m.Called(ctx, userID, tokenHash, expiresAt)
+func (m *MockRepository) SaveRefreshToken(ctx context.Context, userID, sessionID, tokenHash string, expiresAt int64) error {
+	args := m.Called(ctx, userID, sessionID, tokenHash, expiresAt)
 	return args.Error(0)
 }
 
+func (m *MockRepository) RotateRefreshToken(ctx context.Context, userID, oldTokenHash, newTokenHash string, expiresAt int64) (*model.RefreshTokenRotation, error) {
+	args := m.Called(ctx, userID, oldTokenHash, newTokenHash, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RefreshTokenRotation), args.Error(1)
+}
+
 func (m *MockRepository) GetRefreshToken(ctx context.Context, tokenHash string) (string, error) {
 	args := m.Called(ctx, tokenHash)
 	return args.String(0), args.Error(1)
@@ -180,6 +193,215 @@ func (m *MockRepository) UpdateUser(ctx context.Context, user *model.User) error
 	return args.Error(0)
 }
 
+func (m *MockRepository) SaveTwoFactorSecret(ctx context.Context, userID, encryptedSecret string) error {
+	args := m.Called(ctx, userID, encryptedSecret)
+	return args.Error(0)
+}
+
+func (m *MockRepository) EnableTwoFactor(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error {
+	args := m.Called(ctx, userID, step, recoveryCodeHashes)
+	return args.Error(0)
+}
+
+func (m *MockRepository) UpdateTwoFactorLastStep(ctx context.Context, userID string, step int64) error {
+	args := m.Called(ctx, userID, step)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ConsumeRecoveryCode(ctx context.Context, userID, codeHash string) error {
+	args := m.Called(ctx, userID, codeHash)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SaveLoginChallenge(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error {
+	args := m.Called(ctx, userID, tokenHash, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetLoginChallenge(ctx context.Context, tokenHash string) (string, int, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.String(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockRepository) IncrementLoginChallengeAttempts(ctx context.Context, tokenHash string) error {
+	args := m.Called(ctx, tokenHash)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteLoginChallenge(ctx context.Context, tokenHash string) error {
+	args := m.Called(ctx, tokenHash)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SavePasswordResetToken(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error {
+	args := m.Called(ctx, userID, tokenHash, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ResetPassword(ctx context.Context, tokenHash, passwordHash string) (string, error) {
+	args := m.Called(ctx, tokenHash, passwordHash)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockRepository) CreateSecurityEvent(ctx context.Context, userID, eventType, ip, location string) error {
+	args := m.Called(ctx, userID, eventType, ip, location)
+	return args.Error(0)
+}
+
+func (m *MockRepository) RevokeSessionRefreshTokens(ctx context.Context, sessionID string) error {
+	args := m.Called(ctx, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) RevokeUserRefreshTokens(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetUserRoles(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockRepository) CreateLoginEvent(ctx context.Context, userID, eventType string, login *model.LoginRecord) error {
+	args := m.Called(ctx, userID, eventType, login)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetLastLogin(ctx context.Context, userID string) (*model.LoginRecord, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.LoginRecord), args.Error(1)
+}
+
+func (m *MockRepository) RecordLoginDevice(ctx context.Context, userID, fingerprint, userAgent, ip string) (bool, int, error) {
+	args := m.Called(ctx, userID, fingerprint, userAgent, ip)
+	return args.Bool(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockRepository) GetNotifyOnLogin(ctx context.Context, userID string) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) UpdatePassword(ctx context.Context, userID, passwordHash string) error {
+	args := m.Called(ctx, userID, passwordHash)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetPasswordHistory(ctx context.Context, userID string, limit int) ([]string, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockRepository) GetPasswordResetUserID(ctx context.Context, tokenHash string) (string, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockRepository) GetOrganizationPasswordPolicy(ctx context.Context, organizationID string) (*model.PasswordPolicy, error) {
+	args := m.Called(ctx, organizationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.PasswordPolicy), args.Error(1)
+}
+
+func (m *MockRepository) SaveOrganizationPasswordPolicy(ctx context.Context, organizationID, updatedBy string, policy *model.PasswordPolicy) error {
+	args := m.Called(ctx, organizationID, updatedBy, policy)
+	return args.Error(0)
+}
+
+// MockSessionStore is a mock session store for testing
+type MockSessionStore struct {
+	mock.Mock
+}
+
+func (m *MockSessionStore) CreateUserSession(ctx context.Context, userID, organizationID, tokenHash, refreshTokenHash, ipAddress, userAgent, deviceName string, expiresAt time.Time) (*orgRepository.UserSession, error) {
+	args := m.Called(ctx, userID, organizationID, tokenHash, refreshTokenHash, ipAddress, userAgent, deviceName, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*orgRepository.UserSession), args.Error(1)
+}
+
+func (m *MockSessionStore) ExtendUserSession(ctx context.Context, sessionID string, expiresAt time.Time) (*orgRepository.UserSession, error) {
+	args := m.Called(ctx, sessionID, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*orgRepository.UserSession), args.Error(1)
+}
+
+func (m *MockSessionStore) GetUserCurrentOrganization(ctx context.Context, userID string) (*orgRepository.UserContext, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*orgRepository.UserContext), args.Error(1)
+}
+
+func (m *MockSessionStore) GetUserSessionByID(ctx context.Context, sessionID string) (*orgRepository.UserSession, error) {
+	args := m.Called(ctx, sessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*orgRepository.UserSession), args.Error(1)
+}
+
+func (m *MockSessionStore) RevokeUserSession(ctx context.Context, sessionID string) error {
+	args := m.Called(ctx, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockSessionStore) GetUserRoleInOrganization(ctx context.Context, userID, organizationID string) (string, error) {
+	args := m.Called(ctx, userID, organizationID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSessionStore) RevokeAllUserSessions(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockSessionStore) RevokeSessionsByRefreshTokenFamily(ctx context.Context, familyID string) error {
+	args := m.Called(ctx, familyID)
+	return args.Error(0)
+}
+
+// MockRevocationList is a mock access token revocation list for testing
+type MockRevocationList struct {
+	mock.Mock
+}
+
+func (m *MockRevocationList) RevokeSession(ctx context.Context, sessionID string) error {
+	args := m.Called(ctx, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockRevocationList) RevokeToken(ctx context.Context, tokenID string) error {
+	args := m.Called(ctx, tokenID)
+	return args.Error(0)
+}
+
+func (m *MockRevocationList) RevokeUserTokens(ctx context.Context, userID string, issuedUntil time.Time) error {
+	args := m.Called(ctx, userID, issuedUntil)
+	return args.Error(0)
+}
+
+func (m *MockRevocationList) IsRevoked(ctx context.Context, claims *jwt.Claims) (bool, error) {
+	args := m.Called(ctx, claims)
+	return args.Bool(0), args.Error(1)
+}
+
 // MockTokenGenerator is a mock token generator for testing
 type MockTokenGenerator struct {
 	mock.Mock
@@ -204,3 +426,43 @@ func (m *MockTokenGenerator) ValidateRefreshToken(token string) (string, error)
 	args := m.Called(token)
 	return args.String(0), args.Error(1)
 }
+
+// MockEmailSender is a mock email sender for testing
+type MockEmailSender struct {
+	mock.Mock
+}
+
+func (m *MockEmailSender) SendEmail(ctx context.Context, req email.SendEmailRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+// MockRateLimiter is a mock rate limiter for testing
+type MockRateLimiter struct {
+	mock.Mock
+}
+
+func (m *MockRateLimiter) Allow(ctx context.Context, key string, config ratelimit.RateLimitConfig) (bool, time.Duration, error) {
+	args := m.Called(ctx, key, config)
+	return args.Bool(0), args.Get(1).(time.Duration), args.Error(2)
+}
+
+func (m *MockRateLimiter) Reset(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockRateLimiter) GetRemaining(ctx context.Context, key string, config ratelimit.RateLimitConfig) (int, error) {
+	args := m.Called(ctx, key, config)
+	return args.Int(0), args.Error(1)
+}
+
+// MockVerificationPolicy is a mock email verification policy for testing
+type MockVerificationPolicy struct {
+	mock.Mock
+}
+
+func (m *MockVerificationPolicy) RequiresEmailVerification(ctx context.Context, orgID string) (bool, error) {
+	args := m.Called(ctx, orgID)
+	return args.Bool(0), args.Error(1)
+}
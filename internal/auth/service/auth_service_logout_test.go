@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	orgRepository "ethos/internal/organization/repository"
+	"ethos/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newLogoutTestService() (*AuthService, *MockRepository, *MockSessionStore, *MockRevocationList) {
+	repo := new(MockRepository)
+	sessions := new(MockSessionStore)
+	revocations := new(MockRevocationList)
+	svc, _ := newRefreshTestService(repo, sessions)
+	svc.revocations = revocations
+	return svc, repo, sessions, revocations
+}
+
+func TestAuthService_Logout_CurrentSession(t *testing.T) {
+	svc, repo, sessions, revocations := newLogoutTestService()
+
+	sessions.On("GetUserSessionByID", mock.Anything, "session-1").Return(&orgRepository.UserSession{ID: "session-1", UserID: "user-1"}, nil)
+	sessions.On("RevokeUserSession", mock.Anything, "session-1").Return(nil)
+	repo.On("RevokeSessionRefreshTokens", mock.Anything, "session-1").Return(nil)
+	revocations.On("RevokeSession", mock.Anything, "session-1").Return(nil)
+
+	err := svc.Logout(context.Background(), &LogoutRequest{UserID: "user-1", SessionID: "session-1", TokenID: "token-1"})
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+	sessions.AssertExpectations(t)
+	revocations.AssertExpectations(t)
+	sessions.AssertNotCalled(t, "RevokeAllUserSessions", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Logout_AllDevices(t *testing.T) {
+	svc, repo, sessions, revocations := newLogoutTestService()
+
+	sessions.On("RevokeAllUserSessions", mock.Anything, "user-1").Return(nil)
+	repo.On("RevokeUserRefreshTokens", mock.Anything, "user-1").Return(nil)
+	revocations.On("RevokeUserTokens", mock.Anything, "user-1", mock.AnythingOfType("time.Time")).Return(nil)
+
+	err := svc.Logout(context.Background(), &LogoutRequest{UserID: "user-1", SessionID: "session-1", AllDevices: true})
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+	sessions.AssertExpectations(t)
+	revocations.AssertExpectations(t)
+}
+
+func TestAuthService_Logout_TokenWithoutSession(t *testing.T) {
+	svc, repo, _, revocations := newLogoutTestService()
+
+	repo.On("DeleteRefreshToken", mock.Anything, hashToken("refresh-token")).Return(nil)
+	revocations.On("RevokeToken", mock.Anything, "token-1").Return(nil)
+
+	err := svc.Logout(context.Background(), &LogoutRequest{UserID: "user-1", TokenID: "token-1", RefreshToken: "refresh-token"})
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+	revocations.AssertExpectations(t)
+}
+
+func TestAuthService_RevokeSession_OtherUsersSession(t *testing.T) {
+	svc, _, sessions, revocations := newLogoutTestService()
+
+	sessions.On("GetUserSessionByID", mock.Anything, "session-2").Return(&orgRepository.UserSession{ID: "session-2", UserID: "user-2"}, nil)
+
+	err := svc.RevokeSession(context.Background(), "user-1", "session-2")
+
+	assert.Equal(t, errors.ErrNotFound, err)
+	sessions.AssertNotCalled(t, "RevokeUserSession", mock.Anything, mock.Anything)
+	revocations.AssertNotCalled(t, "RevokeSession", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ethos/internal/auth/model"
+	"ethos/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAuthService_RequestPasswordReset_StoresHashedToken(t *testing.T) {
+	user := &model.User{ID: "user-1", Email: "jane@example.com"}
+
+	var storedHash string
+	var storedExpiry time.Time
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockRepo.On("SavePasswordResetToken", mock.Anything, user.ID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) {
+			storedHash = args.String(2)
+			storedExpiry = args.Get(3).(time.Time)
+		}).
+		Return(nil)
+
+	svc := &AuthService{repo: mockRepo, config: Config{PasswordResetTTL: time.Hour}}
+	err := svc.RequestPasswordReset(context.Background(), &RequestPasswordResetRequest{Email: user.Email})
+
+	assert.NoError(t, err)
+	assert.Len(t, storedHash, 64)
+	assert.NotContains(t, storedHash, user.ID)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), storedExpiry, 5*time.Second)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthService_RequestPasswordReset_UnknownEmail(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetUserByEmail", mock.Anything, "nobody@example.com").Return(nil, errors.ErrUserNotFound)
+
+	svc := &AuthService{repo: mockRepo, config: Config{PasswordResetTTL: time.Hour}}
+	err := svc.RequestPasswordReset(context.Background(), &RequestPasswordResetRequest{Email: "nobody@example.com"})
+
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "SavePasswordResetToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthService_ResetPassword_Success(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockSessions := new(MockSessionStore)
+
+	var newHash string
+	mockRepo.On("GetPasswordResetUserID", mock.Anything, hashToken("reset-token")).Return("user-1", nil)
+	mockRepo.On("GetUserByID", mock.Anything, "user-1").Return(&model.User{ID: "user-1"}, nil)
+	mockRepo.On("ResetPassword", mock.Anything, hashToken("reset-token"), mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { newHash = args.String(2) }).
+		Return("user-1", nil)
+	mockSessions.On("RevokeAllUserSessions", mock.Anything, "user-1").Return(nil)
+	mockRepo.On("CreateSecurityEvent", mock.Anything, "user-1", model.SecurityEventPasswordReset, "203.0.113.7", "").Return(nil)
+
+	svc := &AuthService{repo: mockRepo, sessions: mockSessions}
+	err := svc.ResetPassword(context.Background(), &ResetPasswordRequest{
+		Token:       "reset-token",
+		NewPassword: "NewPassword123!",
+		IP:          "203.0.113.7",
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(newHash), []byte("NewPassword123!")))
+	mockRepo.AssertExpectations(t)
+	mockSessions.AssertExpectations(t)
+}
+
+func TestAuthService_ResetPassword_InvalidToken(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockSessions := new(MockSessionStore)
+	mockRepo.On("GetPasswordResetUserID", mock.Anything, hashToken("used-token")).Return("", errors.ErrTokenInvalid)
+
+	svc := &AuthService{repo: mockRepo, sessions: mockSessions}
+	err := svc.ResetPassword(context.Background(), &ResetPasswordRequest{
+		Token:       "used-token",
+		NewPassword: "NewPassword123!",
+	})
+
+	assert.Equal(t, errors.ErrTokenInvalid, err)
+	mockRepo.AssertNotCalled(t, "ResetPassword", mock.Anything, mock.Anything, mock.Anything)
+	mockSessions.AssertNotCalled(t, "RevokeAllUserSessions", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_ResetPassword_WeakPassword(t *testing.T) {
+	mockRepo := new(MockRepository)
+
+	svc := &AuthService{repo: mockRepo}
+	err := svc.ResetPassword(context.Background(), &ResetPasswordRequest{
+		Token:       "reset-token",
+		NewPassword: "password",
+	})
+
+	apiErr, ok := err.(*errors.APIError)
+	assert.True(t, ok)
+	assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+	mockRepo.AssertNotCalled(t, "ResetPassword", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ethos/internal/auth/model"
+	"ethos/pkg/errors"
+	"ethos/pkg/jwt"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newRefreshTestService(repo *MockRepository, sessions *MockSessionStore) (*AuthService, *jwt.TokenGenerator) {
+	tokenGen := jwt.NewTokenGenerator("access-secret", "refresh-secret", time.Minute, time.Hour)
+	return &AuthService{
+		repo:           repo,
+		tokenGenerator: tokenGen,
+		sessions:       sessions,
+	}, tokenGen
+}
+
+func TestAuthService_RefreshToken_RotatesWithinFamily(t *testing.T) {
+	mockRepo := new(MockRepository)
+	svc, tokenGen := newRefreshTestService(mockRepo, new(MockSessionStore))
+
+	oldToken, err := tokenGen.GenerateRefreshToken("user-1")
+	require.NoError(t, err)
+
+	var newHash string
+	mockRepo.On("RotateRefreshToken", mock.Anything, "user-1", hashToken(oldToken), mock.AnythingOfType("string"), mock.AnythingOfType("int64")).
+		Run(func(args mock.Arguments) { newHash = args.String(3) }).
+		Return(&model.RefreshTokenRotation{UserID: "user-1", FamilyID: "family-1"}, nil)
+	mockRepo.On("GetUserRoles", mock.Anything, "user-1").Return([]string{"user"}, nil)
+
+	resp, err := svc.RefreshToken(context.Background(), &RefreshRequest{RefreshToken: oldToken})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.AccessToken)
+	assert.NotEqual(t, oldToken, resp.RefreshToken)
+	assert.Equal(t, hashToken(resp.RefreshToken), newHash)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthService_RefreshToken_ReuseRevokesFamily(t *testing.T) {
+	mockRepo := new(MockRepository)
+	sessions := new(MockSessionStore)
+	svc, tokenGen := newRefreshTestService(mockRepo, sessions)
+
+	oldToken, err := tokenGen.GenerateRefreshToken("user-1")
+	require.NoError(t, err)
+
+	mockRepo.On("RotateRefreshToken", mock.Anything, "user-1", hashToken(oldToken), mock.Anything, mock.Anything).
+		Return(&model.RefreshTokenRotation{UserID: "user-1", FamilyID: "family-1", Reused: true}, nil)
+	mockRepo.On("CreateSecurityEvent", mock.Anything, "user-1", model.SecurityEventRefreshTokenReuse, "203.0.113.7", "").Return(nil)
+	sessions.On("RevokeSessionsByRefreshTokenFamily", mock.Anything, "family-1").Return(nil)
+
+	resp, err := svc.RefreshToken(context.Background(), &RefreshRequest{RefreshToken: oldToken, IP: "203.0.113.7"})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, errors.ErrTokenInvalid, err)
+	mockRepo.AssertExpectations(t)
+	sessions.AssertExpectations(t)
+}
+
+func TestAuthService_RefreshToken_UnknownToken(t *testing.T) {
+	mockRepo := new(MockRepository)
+	svc, tokenGen := newRefreshTestService(mockRepo, new(MockSessionStore))
+
+	token, err := tokenGen.GenerateRefreshToken("user-1")
+	require.NoError(t, err)
+
+	mockRepo.On("RotateRefreshToken", mock.Anything, "user-1", hashToken(token), mock.Anything, mock.Anything).
+		Return(nil, errors.ErrTokenInvalid)
+
+	_, err = svc.RefreshToken(context.Background(), &RefreshRequest{RefreshToken: token})
+
+	assert.Equal(t, errors.ErrTokenInvalid, err)
+	mockRepo.AssertNotCalled(t, "CreateSecurityEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthService_RefreshToken_RejectsAccessToken(t *testing.T) {
+	mockRepo := new(MockRepository)
+	svc, tokenGen := newRefreshTestService(mockRepo, new(MockSessionStore))
+
+	accessToken, err := tokenGen.GenerateAccessToken("user-1")
+	require.NoError(t, err)
+
+	_, err = svc.RefreshToken(context.Background(), &RefreshRequest{RefreshToken: accessToken})
+
+	assert.Equal(t, errors.ErrTokenInvalid, err)
+	mockRepo.AssertNotCalled(t, "RotateRefreshToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ethos/internal/auth/model"
+	orgRepository "ethos/internal/organization/repository"
+	"ethos/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAuthService_Login_BindsTokenToSessionAndOrganization(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("Password123!"), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := &model.User{ID: "user-1", Email: "jane@example.com", PasswordHash: string(hash), EmailVerified: true}
+
+	mockRepo := new(MockRepository)
+	sessions := new(MockSessionStore)
+	svc, tokenGen := newRefreshTestService(mockRepo, sessions)
+
+	mockRepo.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockRepo.On("GetUserRoles", mock.Anything, user.ID).Return([]string{"platform_admin", "user"}, nil)
+	sessions.On("GetUserCurrentOrganization", mock.Anything, user.ID).
+		Return(&orgRepository.UserContext{UserID: user.ID, OrganizationID: "org-1", Role: "admin"}, nil)
+	sessions.On("GetUserRoleInOrganization", mock.Anything, user.ID, "org-1").Return("admin", nil)
+
+	var refreshHash string
+	sessions.On("CreateUserSession", mock.Anything, user.ID, "org-1", mock.AnythingOfType("string"), mock.AnythingOfType("string"), "203.0.113.7", "test-agent", "", mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { refreshHash = args.String(4) }).
+		Return(&orgRepository.UserSession{ID: "session-1", UserID: user.ID, OrganizationID: "org-1"}, nil)
+	mockRepo.On("SaveRefreshToken", mock.Anything, user.ID, "session-1", mock.AnythingOfType("string"), mock.AnythingOfType("int64")).Return(nil)
+
+	resp, err := svc.Login(context.Background(), &LoginRequest{
+		Email:     user.Email,
+		Password:  "Password123!",
+		IP:        "203.0.113.7",
+		UserAgent: "test-agent",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, hashToken(resp.RefreshToken), refreshHash)
+
+	claims, err := tokenGen.ParseAccessToken(resp.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.UserID)
+	assert.Equal(t, "session-1", claims.SessionID)
+	assert.Equal(t, "org-1", claims.OrganizationID)
+	assert.Equal(t, "admin", claims.OrganizationRole)
+	assert.Equal(t, []string{"platform_admin", "user"}, claims.Roles)
+	mockRepo.AssertExpectations(t)
+	sessions.AssertExpectations(t)
+}
+
+func TestAuthService_RefreshToken_KeepsSessionContext(t *testing.T) {
+	mockRepo := new(MockRepository)
+	sessions := new(MockSessionStore)
+	svc, tokenGen := newRefreshTestService(mockRepo, sessions)
+
+	oldToken, err := tokenGen.GenerateRefreshToken("user-1")
+	require.NoError(t, err)
+
+	mockRepo.On("RotateRefreshToken", mock.Anything, "user-1", hashToken(oldToken), mock.Anything, mock.Anything).
+		Return(&model.RefreshTokenRotation{UserID: "user-1", FamilyID: "family-1", SessionID: "session-1"}, nil)
+	mockRepo.On("GetUserRoles", mock.Anything, "user-1").Return([]string{"user"}, nil)
+	sessions.On("ExtendUserSession", mock.Anything, "session-1", mock.AnythingOfType("time.Time")).
+		Return(&orgRepository.UserSession{ID: "session-1", UserID: "user-1", OrganizationID: "org-2"}, nil)
+	sessions.On("GetUserRoleInOrganization", mock.Anything, "user-1", "org-2").Return("member", nil)
+
+	resp, err := svc.RefreshToken(context.Background(), &RefreshRequest{RefreshToken: oldToken})
+	require.NoError(t, err)
+
+	claims, err := tokenGen.ParseAccessToken(resp.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "session-1", claims.SessionID)
+	assert.Equal(t, "org-2", claims.OrganizationID)
+	assert.Equal(t, "member", claims.OrganizationRole)
+}
+
+func TestAuthService_RefreshToken_RevokedSession(t *testing.T) {
+	mockRepo := new(MockRepository)
+	sessions := new(MockSessionStore)
+	svc, tokenGen := newRefreshTestService(mockRepo, sessions)
+
+	oldToken, err := tokenGen.GenerateRefreshToken("user-1")
+	require.NoError(t, err)
+
+	mockRepo.On("RotateRefreshToken", mock.Anything, "user-1", hashToken(oldToken), mock.Anything, mock.Anything).
+		Return(&model.RefreshTokenRotation{UserID: "user-1", FamilyID: "family-1", SessionID: "session-1"}, nil)
+	sessions.On("ExtendUserSession", mock.Anything, "session-1", mock.Anything).Return(nil, errors.ErrNotFound)
+
+	resp, err := svc.RefreshToken(context.Background(), &RefreshRequest{RefreshToken: oldToken})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, errors.ErrTokenInvalid, err)
+}
+
+func TestAuthService_IssueAccessToken_OmitsOrganizationWithoutMembership(t *testing.T) {
+	mockRepo := new(MockRepository)
+	sessions := new(MockSessionStore)
+	svc, tokenGen := newRefreshTestService(mockRepo, sessions)
+
+	mockRepo.On("GetUserRoles", mock.Anything, "user-1").Return([]string{"user"}, nil)
+	sessions.On("GetUserRoleInOrganization", mock.Anything, "user-1", "org-1").Return("", errors.ErrNotFound)
+
+	token, err := svc.IssueAccessToken(context.Background(), "user-1", "session-1", "org-1")
+	require.NoError(t, err)
+
+	claims, err := tokenGen.ParseAccessToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "session-1", claims.SessionID)
+	assert.Empty(t, claims.OrganizationID)
+	assert.Empty(t, claims.OrganizationRole)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), claims.ExpiresAt.Time, 5*time.Second)
+}
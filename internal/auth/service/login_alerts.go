@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"ethos/internal/auth/model"
+	"ethos/pkg/email"
+	emailTemplates "ethos/pkg/email/templates"
+	"ethos/pkg/errors"
+	"ethos/pkg/geoip"
+)
+
+const (
+	// defaultImpossibleTravelSpeedKmh is used when Config.ImpossibleTravelSpeedKmh is not set;
+	// it's faster than a commercial flight
+	defaultImpossibleTravelSpeedKmh = 1000
+
+	// impossibleTravelMinDistanceKm ignores jumps GeoIP can't resolve reliably,
+	// such as mobile carriers routing through a nearby city
+	impossibleTravelMinDistanceKm = 500
+)
+
+// userAgentVersions matches version numbers, so browser updates don't look like new devices
+var userAgentVersions = regexp.MustCompile(`[0-9]+([._][0-9]+)*`)
+
+// GeoLocator locates IP addresses (implemented by geoip.Database)
+type GeoLocator interface {
+	Lookup(ip string) (*geoip.Location, bool)
+}
+
+// recordLogin records a successful login and flags it when it comes from a
+// device the user hasn't used before, or from too far away from their
+// previous login to have traveled in between. Flagged logins are emailed to
+// the user unless they turned off login notifications. Failures are logged
+// and never block the login.
+func (s *AuthService) recordLogin(ctx context.Context, user *model.User, ip, userAgent string) {
+	if !s.config.LoginAlerts {
+		return
+	}
+
+	login := &model.LoginRecord{IP: ip, At: time.Now()}
+	if s.geo != nil {
+		if location, ok := s.geo.Lookup(ip); ok {
+			latitude, longitude := location.Latitude, location.Longitude
+			login.Location = location.String()
+			login.Latitude = &latitude
+			login.Longitude = &longitude
+		}
+	}
+
+	previous, err := s.repo.GetLastLogin(ctx, user.ID)
+	if err != nil && err != errors.ErrNotFound {
+		fmt.Printf("Failed to get last login for user %s: %v\n", user.ID, err)
+	}
+	if err := s.repo.CreateLoginEvent(ctx, user.ID, model.SecurityEventLogin, login); err != nil {
+		fmt.Printf("Failed to record login for user %s: %v\n", user.ID, err)
+	}
+
+	var alerts []string
+
+	// A user's first device isn't new; there's nothing to compare it with
+	isNew, knownDevices, err := s.repo.RecordLoginDevice(ctx, user.ID, deviceFingerprint(userAgent), userAgent, ip)
+	if err != nil {
+		fmt.Printf("Failed to record login device for user %s: %v\n", user.ID, err)
+	} else if isNew && knownDevices > 0 {
+		if err := s.repo.CreateLoginEvent(ctx, user.ID, model.SecurityEventNewDevice, login); err != nil {
+			fmt.Printf("Failed to record new device login for user %s: %v\n", user.ID, err)
+		}
+		alerts = append(alerts, "Sign-in from a new device")
+	}
+
+	if previous != nil && s.isImpossibleTravel(previous, login) {
+		if err := s.repo.CreateLoginEvent(ctx, user.ID, model.SecurityEventImpossibleTravel, login); err != nil {
+			fmt.Printf("Failed to record impossible travel for user %s: %v\n", user.ID, err)
+		}
+		alerts = append(alerts, fmt.Sprintf("Sign-in from %s shortly after a sign-in from %s", describeLocation(login), describeLocation(previous)))
+	}
+
+	if len(alerts) > 0 {
+		s.sendLoginAlert(ctx, user, login, userAgent, alerts)
+	}
+}
+
+// isImpossibleTravel reports whether getting from the previous login's
+// location to this one would have needed an implausible speed
+func (s *AuthService) isImpossibleTravel(previous, current *model.LoginRecord) bool {
+	if previous.Latitude == nil || previous.Longitude == nil || current.Latitude == nil || current.Longitude == nil {
+		return false
+	}
+
+	distance := geoip.DistanceKm(*previous.Latitude, *previous.Longitude, *current.Latitude, *current.Longitude)
+	if distance < impossibleTravelMinDistanceKm {
+		return false
+	}
+
+	maxSpeed := float64(s.config.ImpossibleTravelSpeedKmh)
+	if maxSpeed <= 0 {
+		maxSpeed = defaultImpossibleTravelSpeedKmh
+	}
+	elapsed := current.At.Sub(previous.At).Hours()
+	return elapsed <= 0 || distance/elapsed > maxSpeed
+}
+
+// sendLoginAlert emails the user about a flagged login, if they want login notifications
+func (s *AuthService) sendLoginAlert(ctx context.Context, user *model.User, login *model.LoginRecord, userAgent string, alerts []string) {
+	if s.emailSender == nil {
+		return
+	}
+	notify, err := s.repo.GetNotifyOnLogin(ctx, user.ID)
+	if err != nil {
+		fmt.Printf("Failed to get login notification preference for user %s: %v\n", user.ID, err)
+		return
+	}
+	if !notify {
+		return
+	}
+
+	alert := emailTemplates.SecurityAlertData{
+		Name:      user.FirstName + " " + user.LastName,
+		Email:     user.Email,
+		EventType: strings.Join(alerts, "; "),
+		EventTime: login.At.UTC().Format(time.RFC1123),
+		IPAddress: login.IP,
+		UserAgent: userAgent,
+		Location:  login.Location,
+		ActionURL: "http://localhost:5173/settings/security", // TODO: Make configurable
+	}
+	template := emailTemplates.GetTemplate(emailTemplates.TemplateSecurityAlert)
+	emailReq := email.SendEmailRequest{
+		To:         user.Email,
+		Subject:    template["subject"].(string),
+		TemplateID: template["template_id"].(string),
+		TemplateData: map[string]interface{}{
+			"Name":      alert.Name,
+			"email":     alert.Email,
+			"EventType": alert.EventType,
+			"EventTime": alert.EventTime,
+			"IPAddress": alert.IPAddress,
+			"UserAgent": alert.UserAgent,
+			"Location":  alert.Location,
+			"ActionURL": alert.ActionURL,
+		},
+	}
+
+	// Send email asynchronously
+	go func() {
+		if err := s.emailSender.SendEmail(context.Background(), emailReq); err != nil {
+			fmt.Printf("Failed to send login alert email: %v\n", err)
+		}
+	}()
+}
+
+// deviceFingerprint identifies a browser and operating system from the user
+// agent, ignoring version numbers
+func deviceFingerprint(userAgent string) string {
+	normalized := userAgentVersions.ReplaceAllString(strings.ToLower(strings.TrimSpace(userAgent)), "")
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+func describeLocation(login *model.LoginRecord) string {
+	if login.Location != "" {
+		return login.Location
+	}
+	return login.IP
+}
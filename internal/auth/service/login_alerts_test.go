@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ethos/internal/auth/model"
+	"ethos/pkg/email"
+	"ethos/pkg/errors"
+	"ethos/pkg/geoip"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// staticLocator locates IPs from a fixed table
+type staticLocator map[string]*geoip.Location
+
+func (s staticLocator) Lookup(ip string) (*geoip.Location, bool) {
+	location, ok := s[ip]
+	return location, ok
+}
+
+// channelEmailSender hands sent emails to the test
+type channelEmailSender chan email.SendEmailRequest
+
+func (c channelEmailSender) SendEmail(ctx context.Context, req email.SendEmailRequest) error {
+	c <- req
+	return nil
+}
+
+var testLocations = staticLocator{
+	"81.2.69.160": {City: "London", Country: "GB", Latitude: 51.5074, Longitude: -0.1278},
+	"1.0.16.1":    {City: "Tokyo", Country: "JP", Latitude: 35.6762, Longitude: 139.6503},
+	"81.2.69.161": {City: "Reading", Country: "GB", Latitude: 51.4543, Longitude: -0.9781},
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+func TestRecordLogin_NewDeviceSendsAlert(t *testing.T) {
+	mockRepo := new(MockRepository)
+	sent := make(channelEmailSender, 1)
+	svc := &AuthService{repo: mockRepo, geo: testLocations, emailSender: sent, config: Config{LoginAlerts: true}}
+	user := &model.User{ID: "user-1", Email: "ada@example.com", FirstName: "Ada", LastName: "Lovelace"}
+
+	mockRepo.On("GetLastLogin", mock.Anything, "user-1").Return(nil, errors.ErrNotFound)
+	mockRepo.On("CreateLoginEvent", mock.Anything, "user-1", model.SecurityEventLogin, mock.Anything).Return(nil)
+	mockRepo.On("RecordLoginDevice", mock.Anything, "user-1", mock.Anything, "Firefox/130.0", "81.2.69.160").Return(true, 2, nil)
+	mockRepo.On("CreateLoginEvent", mock.Anything, "user-1", model.SecurityEventNewDevice, mock.Anything).Return(nil)
+	mockRepo.On("GetNotifyOnLogin", mock.Anything, "user-1").Return(true, nil)
+
+	svc.recordLogin(context.Background(), user, "81.2.69.160", "Firefox/130.0")
+
+	select {
+	case req := <-sent:
+		assert.Equal(t, "ada@example.com", req.To)
+		assert.Equal(t, "Sign-in from a new device", req.TemplateData["EventType"])
+		assert.Equal(t, "London, GB", req.TemplateData["Location"])
+	case <-time.After(time.Second):
+		t.Fatal("expected a login alert email")
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRecordLogin_FirstDeviceIsNotNew(t *testing.T) {
+	mockRepo := new(MockRepository)
+	svc := &AuthService{repo: mockRepo, geo: testLocations, config: Config{LoginAlerts: true}}
+	user := &model.User{ID: "user-1"}
+
+	mockRepo.On("GetLastLogin", mock.Anything, "user-1").Return(nil, errors.ErrNotFound)
+	mockRepo.On("CreateLoginEvent", mock.Anything, "user-1", model.SecurityEventLogin, mock.Anything).Return(nil)
+	mockRepo.On("RecordLoginDevice", mock.Anything, "user-1", mock.Anything, mock.Anything, mock.Anything).Return(true, 0, nil)
+
+	svc.recordLogin(context.Background(), user, "81.2.69.160", "Firefox/130.0")
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "CreateLoginEvent", mock.Anything, "user-1", model.SecurityEventNewDevice, mock.Anything)
+}
+
+func TestRecordLogin_ImpossibleTravel(t *testing.T) {
+	mockRepo := new(MockRepository)
+	sent := make(channelEmailSender, 1)
+	svc := &AuthService{repo: mockRepo, geo: testLocations, emailSender: sent, config: Config{LoginAlerts: true}}
+	user := &model.User{ID: "user-1"}
+
+	previous := &model.LoginRecord{
+		IP:        "81.2.69.160",
+		Location:  "London, GB",
+		Latitude:  float64Ptr(51.5074),
+		Longitude: float64Ptr(-0.1278),
+		At:        time.Now().Add(-time.Hour),
+	}
+	mockRepo.On("GetLastLogin", mock.Anything, "user-1").Return(previous, nil)
+	mockRepo.On("CreateLoginEvent", mock.Anything, "user-1", model.SecurityEventLogin, mock.Anything).Return(nil)
+	mockRepo.On("RecordLoginDevice", mock.Anything, "user-1", mock.Anything, mock.Anything, mock.Anything).Return(false, 1, nil)
+	mockRepo.On("CreateLoginEvent", mock.Anything, "user-1", model.SecurityEventImpossibleTravel, mock.MatchedBy(func(login *model.LoginRecord) bool {
+		return login.Location == "Tokyo, JP"
+	})).Return(nil)
+	// The user turned off login notifications, so nothing is sent
+	mockRepo.On("GetNotifyOnLogin", mock.Anything, "user-1").Return(false, nil)
+
+	svc.recordLogin(context.Background(), user, "1.0.16.1", "Firefox/130.0")
+
+	mockRepo.AssertExpectations(t)
+	assert.Empty(t, sent)
+}
+
+func TestIsImpossibleTravel(t *testing.T) {
+	svc := &AuthService{config: Config{ImpossibleTravelSpeedKmh: 1000}}
+	london := &model.LoginRecord{Latitude: float64Ptr(51.5074), Longitude: float64Ptr(-0.1278), At: time.Now().Add(-time.Hour)}
+
+	tests := []struct {
+		name     string
+		current  *model.LoginRecord
+		expected bool
+	}{
+		{
+			name:     "nearby city is never flagged",
+			current:  &model.LoginRecord{Latitude: float64Ptr(51.4543), Longitude: float64Ptr(-0.9781), At: time.Now()},
+			expected: false,
+		},
+		{
+			name:     "Tokyo an hour later is flagged",
+			current:  &model.LoginRecord{Latitude: float64Ptr(35.6762), Longitude: float64Ptr(139.6503), At: time.Now()},
+			expected: true,
+		},
+		{
+			name:     "Tokyo a day later is plausible",
+			current:  &model.LoginRecord{Latitude: float64Ptr(35.6762), Longitude: float64Ptr(139.6503), At: time.Now().Add(23 * time.Hour)},
+			expected: false,
+		},
+		{
+			name:     "unknown location is never flagged",
+			current:  &model.LoginRecord{At: time.Now()},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, svc.isImpossibleTravel(london, tt.current))
+		})
+	}
+}
+
+func TestDeviceFingerprint_IgnoresVersions(t *testing.T) {
+	older := deviceFingerprint("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) Firefox/129.0")
+	newer := deviceFingerprint("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_8) Firefox/130.0")
+	other := deviceFingerprint("Mozilla/5.0 (Windows NT 10.0; Win64; x64) Chrome/130.0")
+
+	require.Len(t, older, 64)
+	assert.Equal(t, older, newer)
+	assert.NotEqual(t, older, other)
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ethos/internal/auth/model"
+	"ethos/internal/ratelimit"
+	"ethos/pkg/email"
+	emailTemplates "ethos/pkg/email/templates"
+	"ethos/pkg/errors"
+	"ethos/pkg/signedtoken"
+)
+
+// accountUnlockPurpose binds signed tokens to the account unlock flow
+const accountUnlockPurpose = "account_unlock"
+
+// Failed logins are counted per account and per client IP with the shared
+// rate limiter. The account counter locks the account once it reaches
+// LoginMaxAttempts; the IP counter only throttles the client, so an attacker
+// spraying passwords from one address can't lock other people out.
+
+func loginAccountKey(address string) string {
+	return "login-failures:account:" + normalizeLoginEmail(address)
+}
+
+func loginIPKey(ip string) string {
+	return "login-failures:ip:" + ip
+}
+
+func loginLockKey(address string) string {
+	return "login-lock:account:" + normalizeLoginEmail(address)
+}
+
+func normalizeLoginEmail(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
+
+// checkLoginAllowed rejects logins for locked accounts and throttled IPs.
+// The limiter reports full capacity when Redis is unreachable, so an outage
+// disables lockouts rather than logins.
+func (s *AuthService) checkLoginAllowed(ctx context.Context, address, ip string) error {
+	if s.rateLimiter == nil {
+		return nil
+	}
+
+	if remaining, _ := s.rateLimiter.GetRemaining(ctx, loginLockKey(address), s.lockLimit()); remaining == 0 {
+		return errors.ErrAccountLocked
+	}
+
+	if ip != "" {
+		if remaining, _ := s.rateLimiter.GetRemaining(ctx, loginIPKey(ip), s.ipLimit()); remaining == 0 {
+			return errors.ErrTooManyLoginAttempts
+		}
+	}
+
+	return nil
+}
+
+// recordLoginFailure counts a failed login and returns the error to report.
+// Reaching the account limit locks it, records a security event and emails
+// the owner an unlock link; below the limit the response is delayed, with
+// the delay doubling for each further failure.
+func (s *AuthService) recordLoginFailure(ctx context.Context, address, ip, userAgent string, user *model.User) error {
+	if s.rateLimiter == nil {
+		return errors.ErrInvalidCredentials
+	}
+
+	if ip != "" {
+		if _, _, err := s.rateLimiter.Allow(ctx, loginIPKey(ip), s.ipLimit()); err != nil {
+			fmt.Printf("Failed to record failed login for IP: %v\n", err)
+		}
+	}
+
+	accountKey := loginAccountKey(address)
+	if _, _, err := s.rateLimiter.Allow(ctx, accountKey, s.accountLimit()); err != nil {
+		fmt.Printf("Failed to record failed login for account: %v\n", err)
+		return errors.ErrInvalidCredentials
+	}
+
+	remaining, err := s.rateLimiter.GetRemaining(ctx, accountKey, s.accountLimit())
+	if err != nil {
+		return errors.ErrInvalidCredentials
+	}
+	failures := s.config.LoginMaxAttempts - remaining
+
+	if failures >= s.config.LoginMaxAttempts {
+		s.lockAccount(ctx, address, ip, userAgent, user)
+		return errors.ErrAccountLocked
+	}
+
+	s.delayFailedLogin(ctx, failures)
+	return errors.ErrInvalidCredentials
+}
+
+// clearLoginFailures resets the account counter after a successful login.
+// The IP counter is left to expire so one good password doesn't reset a spray.
+func (s *AuthService) clearLoginFailures(ctx context.Context, address string) {
+	if s.rateLimiter == nil {
+		return
+	}
+	if err := s.rateLimiter.Reset(ctx, loginAccountKey(address)); err != nil {
+		fmt.Printf("Failed to reset failed login counter: %v\n", err)
+	}
+}
+
+// lockAccount locks the address for LoginLockoutDuration. Unknown addresses
+// are locked too, but get no security event or email.
+func (s *AuthService) lockAccount(ctx context.Context, address, ip, userAgent string, user *model.User) {
+	// A lock is a counter with no allowance that expires with the lockout
+	if _, _, err := s.rateLimiter.Allow(ctx, loginLockKey(address), ratelimit.RateLimitConfig{Requests: 0, Window: s.config.LoginLockoutDuration}); err != nil {
+		fmt.Printf("Failed to lock account: %v\n", err)
+		return
+	}
+	if err := s.rateLimiter.Reset(ctx, loginAccountKey(address)); err != nil {
+		fmt.Printf("Failed to reset failed login counter: %v\n", err)
+	}
+
+	if user == nil {
+		return
+	}
+
+	if err := s.repo.CreateSecurityEvent(ctx, user.ID, model.SecurityEventAccountLocked, ip, ""); err != nil {
+		fmt.Printf("Failed to record account lockout: %v\n", err)
+	}
+
+	if err := s.sendUnlockEmail(user, ip, userAgent); err != nil {
+		fmt.Printf("Failed to send account unlock email: %v\n", err)
+	}
+}
+
+// delayFailedLogin waits LoginDelayBase after the second failure, doubling for
+// each one after, capped at LoginDelayMax. The wait ends early if the request is cancelled.
+func (s *AuthService) delayFailedLogin(ctx context.Context, failures int) {
+	if s.config.LoginDelayBase <= 0 || failures < 2 {
+		return
+	}
+
+	delay := s.config.LoginDelayBase
+	for i := 2; i < failures; i++ {
+		delay *= 2
+		if s.config.LoginDelayMax > 0 && delay >= s.config.LoginDelayMax {
+			break
+		}
+	}
+	if s.config.LoginDelayMax > 0 && delay > s.config.LoginDelayMax {
+		delay = s.config.LoginDelayMax
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// sendUnlockEmail tells the user their account was locked and links to a
+// signed token that lifts the lock early
+func (s *AuthService) sendUnlockEmail(user *model.User, ip, userAgent string) error {
+	if s.emailSender == nil {
+		return nil
+	}
+	if s.tokenSigner == nil {
+		return fmt.Errorf("email verification secret not configured")
+	}
+
+	token, err := s.tokenSigner.Sign(signedtoken.Claims{
+		Purpose:   accountUnlockPurpose,
+		Subject:   user.ID,
+		Email:     normalizeLoginEmail(user.Email),
+		ExpiresAt: time.Now().Add(s.config.LoginLockoutDuration).Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	template := emailTemplates.GetTemplate(emailTemplates.TemplateSecurityAlert)
+	emailReq := email.SendEmailRequest{
+		To:         user.Email,
+		Subject:    template["subject"].(string),
+		TemplateID: template["template_id"].(string),
+		TemplateData: map[string]interface{}{
+			"Name":      user.FirstName + " " + user.LastName,
+			"email":     user.Email,
+			"EventType": "Account locked after too many failed login attempts",
+			"EventTime": time.Now().UTC().Format(time.RFC1123),
+			"IPAddress": ip,
+			"UserAgent": userAgent,
+			"ActionURL": fmt.Sprintf("http://localhost:5173/unlock-account?token=%s", token), // TODO: Make configurable
+		},
+	}
+
+	// Send email asynchronously
+	go func() {
+		if err := s.emailSender.SendEmail(context.Background(), emailReq); err != nil {
+			fmt.Printf("Failed to send account unlock email: %v\n", err)
+		}
+	}()
+
+	return nil
+}
+
+// UnlockAccount lifts a lockout using the token from the unlock email
+func (s *AuthService) UnlockAccount(ctx context.Context, req *UnlockAccountRequest) error {
+	if s.tokenSigner == nil {
+		return errors.ErrTokenInvalid
+	}
+
+	claims, err := s.tokenSigner.Verify(req.Token, accountUnlockPurpose, time.Now())
+	if err != nil {
+		if err == signedtoken.ErrExpired {
+			return errors.ErrTokenExpired
+		}
+		return errors.ErrTokenInvalid
+	}
+
+	if s.rateLimiter != nil {
+		if err := s.rateLimiter.Reset(ctx, loginLockKey(claims.Email)); err != nil {
+			return errors.WrapError(err, "failed to unlock account")
+		}
+		if err := s.rateLimiter.Reset(ctx, loginAccountKey(claims.Email)); err != nil {
+			return errors.WrapError(err, "failed to reset failed login counter")
+		}
+	}
+
+	if err := s.repo.CreateSecurityEvent(ctx, claims.Subject, model.SecurityEventAccountUnlocked, req.IP, ""); err != nil {
+		fmt.Printf("Failed to record account unlock: %v\n", err)
+	}
+
+	return nil
+}
+
+// accountLimit counts failed logins per account
+func (s *AuthService) accountLimit() ratelimit.RateLimitConfig {
+	return ratelimit.RateLimitConfig{Requests: s.config.LoginMaxAttempts, Window: s.config.LoginAttemptWindow}
+}
+
+// ipLimit counts failed logins per client IP
+func (s *AuthService) ipLimit() ratelimit.RateLimitConfig {
+	return ratelimit.RateLimitConfig{Requests: s.config.LoginMaxAttemptsPerIP, Window: s.config.LoginAttemptWindow}
+}
+
+// lockLimit reads the lock marker written by lockAccount
+func (s *AuthService) lockLimit() ratelimit.RateLimitConfig {
+	return ratelimit.RateLimitConfig{Requests: 1, Window: s.config.LoginLockoutDuration}
+}
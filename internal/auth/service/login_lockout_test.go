@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ethos/internal/auth/model"
+	"ethos/internal/ratelimit"
+	"ethos/pkg/errors"
+	"ethos/pkg/signedtoken"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// memoryRateLimiter is a fixed-window rate limiter without expiry
+type memoryRateLimiter struct {
+	counts map[string]int
+}
+
+func (m *memoryRateLimiter) Allow(ctx context.Context, key string, config ratelimit.RateLimitConfig) (bool, time.Duration, error) {
+	m.counts[key]++
+	if m.counts[key] > config.Requests {
+		return false, config.Window, nil
+	}
+	return true, 0, nil
+}
+
+func (m *memoryRateLimiter) Reset(ctx context.Context, key string) error {
+	delete(m.counts, key)
+	return nil
+}
+
+func (m *memoryRateLimiter) GetRemaining(ctx context.Context, key string, config ratelimit.RateLimitConfig) (int, error) {
+	remaining := config.Requests - m.counts[key]
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, nil
+}
+
+func newLockoutTestService(repo *MockRepository) *AuthService {
+	signer, _ := signedtoken.New("unlock-secret")
+	return &AuthService{
+		repo:        repo,
+		rateLimiter: &memoryRateLimiter{counts: map[string]int{}},
+		tokenSigner: signer,
+		config: Config{
+			LoginMaxAttempts:      3,
+			LoginMaxAttemptsPerIP: 5,
+			LoginAttemptWindow:    time.Minute,
+			LoginLockoutDuration:  time.Minute,
+		},
+	}
+}
+
+func newLockoutTestUser(t *testing.T) *model.User {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	return &model.User{ID: "user-1", Email: "jane@example.com", PasswordHash: string(hash)}
+}
+
+func TestAuthService_Login_LocksAccountAfterMaxAttempts(t *testing.T) {
+	mockRepo := new(MockRepository)
+	svc := newLockoutTestService(mockRepo)
+	user := newLockoutTestUser(t)
+
+	mockRepo.On("GetUserByEmail", mock.Anything, "jane@example.com").Return(user, nil)
+	mockRepo.On("CreateSecurityEvent", mock.Anything, "user-1", model.SecurityEventAccountLocked, "10.0.0.1", "").Return(nil).Once()
+
+	for i := 0; i < 2; i++ {
+		_, err := svc.Login(context.Background(), &LoginRequest{Email: "jane@example.com", Password: "wrong-password", IP: "10.0.0.1"})
+		assert.Equal(t, errors.ErrInvalidCredentials, err)
+	}
+
+	_, err := svc.Login(context.Background(), &LoginRequest{Email: "jane@example.com", Password: "wrong-password", IP: "10.0.0.1"})
+	assert.Equal(t, errors.ErrAccountLocked, err)
+
+	// The correct password is refused while the lock lasts, without touching the user
+	_, err = svc.Login(context.Background(), &LoginRequest{Email: "Jane@Example.com", Password: "correct-password", IP: "10.0.0.2"})
+	assert.Equal(t, errors.ErrAccountLocked, err)
+
+	mockRepo.AssertNumberOfCalls(t, "GetUserByEmail", 3)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthService_Login_ThrottlesIPWithoutLockingAccounts(t *testing.T) {
+	mockRepo := new(MockRepository)
+	svc := newLockoutTestService(mockRepo)
+
+	mockRepo.On("GetUserByEmail", mock.Anything, mock.Anything).Return(nil, errors.ErrUserNotFound)
+
+	for _, address := range []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com", "e@example.com"} {
+		_, err := svc.Login(context.Background(), &LoginRequest{Email: address, Password: "wrong-password", IP: "10.0.0.1"})
+		assert.Equal(t, errors.ErrInvalidCredentials, err)
+	}
+
+	_, err := svc.Login(context.Background(), &LoginRequest{Email: "a@example.com", Password: "wrong-password", IP: "10.0.0.1"})
+	assert.Equal(t, errors.ErrTooManyLoginAttempts, err)
+
+	// The same account is still reachable from another address
+	_, err = svc.Login(context.Background(), &LoginRequest{Email: "a@example.com", Password: "wrong-password", IP: "10.0.0.2"})
+	assert.Equal(t, errors.ErrInvalidCredentials, err)
+	mockRepo.AssertNotCalled(t, "CreateSecurityEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthService_UnlockAccount_LiftsLock(t *testing.T) {
+	mockRepo := new(MockRepository)
+	svc := newLockoutTestService(mockRepo)
+	user := newLockoutTestUser(t)
+
+	mockRepo.On("CreateSecurityEvent", mock.Anything, "user-1", model.SecurityEventAccountLocked, "10.0.0.1", "").Return(nil)
+	mockRepo.On("CreateSecurityEvent", mock.Anything, "user-1", model.SecurityEventAccountUnlocked, "10.0.0.3", "").Return(nil)
+
+	svc.lockAccount(context.Background(), user.Email, "10.0.0.1", "", user)
+	assert.Equal(t, errors.ErrAccountLocked, svc.checkLoginAllowed(context.Background(), user.Email, "10.0.0.3"))
+
+	token, err := svc.tokenSigner.Sign(signedtoken.Claims{
+		Purpose:   accountUnlockPurpose,
+		Subject:   user.ID,
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(time.Minute).Unix(),
+	})
+	require.NoError(t, err)
+
+	err = svc.UnlockAccount(context.Background(), &UnlockAccountRequest{Token: token, IP: "10.0.0.3"})
+	assert.NoError(t, err)
+	assert.NoError(t, svc.checkLoginAllowed(context.Background(), user.Email, "10.0.0.3"))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthService_UnlockAccount_RejectsOtherTokens(t *testing.T) {
+	svc := newLockoutTestService(new(MockRepository))
+
+	token, err := svc.tokenSigner.Sign(signedtoken.Claims{
+		Purpose:   emailVerificationPurpose,
+		Subject:   "user-1",
+		Email:     "jane@example.com",
+		ExpiresAt: time.Now().Add(time.Minute).Unix(),
+	})
+	require.NoError(t, err)
+
+	err = svc.UnlockAccount(context.Background(), &UnlockAccountRequest{Token: token})
+	assert.Equal(t, errors.ErrTokenInvalid, err)
+}
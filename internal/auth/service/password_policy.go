@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ethos/internal/auth/model"
+	"ethos/pkg/errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// defaultPasswordPolicy is used when Config.PasswordPolicy is not set
+var defaultPasswordPolicy = model.PasswordPolicy{
+	MinLength:        8,
+	RequireUppercase: true,
+	RequireLowercase: true,
+	RequireNumber:    true,
+	RequireSymbol:    true,
+}
+
+// BreachChecker counts how often a password appears in known data breaches
+// (implemented by pwnedpasswords.Dataset)
+type BreachChecker interface {
+	Count(password string) (int, error)
+}
+
+// PasswordPolicyResponse shows an organization's own password policy next
+// to the one its members must meet, which also includes the system policy
+type PasswordPolicyResponse struct {
+	Organization model.PasswordPolicy `json:"organization"`
+	Effective    model.PasswordPolicy `json:"effective"`
+}
+
+// systemPasswordPolicy returns the configured system policy
+func (s *AuthService) systemPasswordPolicy() model.PasswordPolicy {
+	if s.config.PasswordPolicy == (model.PasswordPolicy{}) {
+		return defaultPasswordPolicy
+	}
+	return s.config.PasswordPolicy
+}
+
+// passwordPolicyFor returns the policy a user must meet: the system policy,
+// tightened by their current organization's
+func (s *AuthService) passwordPolicyFor(ctx context.Context, user *model.User) (model.PasswordPolicy, error) {
+	if user.CurrentTenantID == nil || *user.CurrentTenantID == "" {
+		return s.systemPasswordPolicy(), nil
+	}
+	return s.organizationPasswordPolicy(ctx, *user.CurrentTenantID)
+}
+
+func (s *AuthService) organizationPasswordPolicy(ctx context.Context, organizationID string) (model.PasswordPolicy, error) {
+	policy := s.systemPasswordPolicy()
+	orgPolicy, err := s.repo.GetOrganizationPasswordPolicy(ctx, organizationID)
+	if err == errors.ErrNotFound {
+		return policy, nil
+	}
+	if err != nil {
+		return policy, errors.WrapError(err, "failed to get password policy")
+	}
+	return policy.Merge(orgPolicy), nil
+}
+
+// validatePassword checks a new password against policy. user is nil for
+// new accounts, which have no password history.
+func (s *AuthService) validatePassword(ctx context.Context, policy model.PasswordPolicy, user *model.User, password string) error {
+	if problem := policy.Check(password); problem != "" {
+		return errors.NewValidationError(problem)
+	}
+
+	if user != nil && policy.HistoryCount > 0 {
+		reused, err := s.isRecentPassword(ctx, user, password, policy.HistoryCount)
+		if err != nil {
+			return err
+		}
+		if reused {
+			return errors.NewValidationError(fmt.Sprintf("password must not match any of your last %d passwords", policy.HistoryCount))
+		}
+	}
+
+	if policy.RejectBreached && s.breaches != nil {
+		count, err := s.breaches.Count(password)
+		if err != nil {
+			// Fail open: an incomplete dataset shouldn't stop people changing passwords
+			fmt.Printf("Failed to check password against breached passwords: %v\n", err)
+		} else if count > 0 {
+			return errors.NewValidationError("this password has appeared in a data breach; choose a different one")
+		}
+	}
+
+	return nil
+}
+
+// isRecentPassword reports whether password matches the current password
+// or one of the count-1 before it
+func (s *AuthService) isRecentPassword(ctx context.Context, user *model.User, password string, count int) (bool, error) {
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) == nil {
+		return true, nil
+	}
+	if count <= 1 {
+		return false, nil
+	}
+
+	history, err := s.repo.GetPasswordHistory(ctx, user.ID, count-1)
+	if err != nil {
+		return false, errors.WrapError(err, "failed to get password history")
+	}
+	for _, hash := range history {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// passwordExpired reports whether the user's password is older than their
+// policy allows. Lookup failures don't block the login.
+func (s *AuthService) passwordExpired(ctx context.Context, user *model.User) bool {
+	policy, err := s.passwordPolicyFor(ctx, user)
+	if err != nil {
+		fmt.Printf("Failed to check password age for user %s: %v\n", user.ID, err)
+		return false
+	}
+	return policy.Expired(user.PasswordChangedAt, time.Now())
+}
+
+// GetPasswordPolicy returns an organization's password policy and the one
+// its members must meet
+func (s *AuthService) GetPasswordPolicy(ctx context.Context, organizationID string) (*PasswordPolicyResponse, error) {
+	orgPolicy, err := s.repo.GetOrganizationPasswordPolicy(ctx, organizationID)
+	if err == errors.ErrNotFound {
+		orgPolicy = &model.PasswordPolicy{}
+	} else if err != nil {
+		return nil, errors.WrapError(err, "failed to get password policy")
+	}
+
+	return &PasswordPolicyResponse{
+		Organization: *orgPolicy,
+		Effective:    s.systemPasswordPolicy().Merge(orgPolicy),
+	}, nil
+}
+
+// UpdatePasswordPolicy changes an organization's password policy. Settings
+// looser than the system policy are stored but have no effect.
+func (s *AuthService) UpdatePasswordPolicy(ctx context.Context, organizationID, actorID string, req *model.UpdatePasswordPolicyRequest) (*PasswordPolicyResponse, error) {
+	current, err := s.GetPasswordPolicy(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := current.Organization
+	policy.Apply(req)
+	if err := s.repo.SaveOrganizationPasswordPolicy(ctx, organizationID, actorID, &policy); err != nil {
+		return nil, err
+	}
+
+	return &PasswordPolicyResponse{
+		Organization: policy,
+		Effective:    s.systemPasswordPolicy().Merge(&policy),
+	}, nil
+}
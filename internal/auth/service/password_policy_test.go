@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"ethos/internal/auth/model"
+	"ethos/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// staticBreaches reports fixed breach counts
+type staticBreaches struct {
+	counts map[string]int
+	err    error
+}
+
+func (s staticBreaches) Count(password string) (int, error) {
+	return s.counts[password], s.err
+}
+
+func hashPassword(t *testing.T, password string) string {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	return string(hash)
+}
+
+func assertValidationError(t *testing.T, err error, message string) {
+	t.Helper()
+	apiErr, ok := err.(*errors.APIError)
+	require.True(t, ok, "expected an API error, got %v", err)
+	assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+	assert.Equal(t, message, apiErr.Message)
+}
+
+func TestPasswordPolicy_Check(t *testing.T) {
+	tests := []struct {
+		password string
+		expected string
+	}{
+		{"Sh0rt!", "password must be at least 8 characters long"},
+		{"alllowercase1!", "password must contain at least one uppercase letter"},
+		{"alllowercase", "password must contain at least one uppercase letter, one number and one special character"},
+		{"Password123!", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			assert.Equal(t, tt.expected, defaultPasswordPolicy.Check(tt.password))
+		})
+	}
+}
+
+func TestPasswordPolicy_MergeKeepsStricterSettings(t *testing.T) {
+	system := model.PasswordPolicy{MinLength: 8, RequireNumber: true, HistoryCount: 5, MaxAgeDays: 90}
+	org := &model.PasswordPolicy{MinLength: 14, RequireSymbol: true, HistoryCount: 2, MaxAgeDays: 30, RejectBreached: true}
+
+	merged := system.Merge(org)
+	assert.Equal(t, model.PasswordPolicy{
+		MinLength:      14,
+		RequireNumber:  true,
+		RequireSymbol:  true,
+		HistoryCount:   5,
+		MaxAgeDays:     30,
+		RejectBreached: true,
+	}, merged)
+
+	// An organization can't switch expiry off
+	assert.Equal(t, 90, system.Merge(&model.PasswordPolicy{}).MaxAgeDays)
+}
+
+func TestAuthService_ChangePassword_OrganizationPolicy(t *testing.T) {
+	orgID := "org-1"
+	user := &model.User{ID: "user-1", PasswordHash: hashPassword(t, "Current123!"), CurrentTenantID: &orgID}
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("GetOrganizationPasswordPolicy", mock.Anything, orgID).Return(&model.PasswordPolicy{MinLength: 12}, nil)
+
+	svc := &AuthService{repo: mockRepo}
+	err := svc.ChangePassword(context.Background(), user.ID, &ChangePasswordRequest{
+		CurrentPassword: "Current123!",
+		NewPassword:     "Short123!",
+	})
+
+	assertValidationError(t, err, "password must be at least 12 characters long")
+	mockRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthService_ChangePassword_RejectsRecentPasswords(t *testing.T) {
+	user := &model.User{ID: "user-1", PasswordHash: hashPassword(t, "Current123!")}
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("GetPasswordHistory", mock.Anything, user.ID, 2).Return([]string{hashPassword(t, "Previous123!")}, nil)
+	mockRepo.On("UpdatePassword", mock.Anything, user.ID, mock.AnythingOfType("string")).Return(nil)
+
+	policy := defaultPasswordPolicy
+	policy.HistoryCount = 3
+	svc := &AuthService{repo: mockRepo, config: Config{PasswordPolicy: policy}}
+
+	for _, reused := range []string{"Current123!", "Previous123!"} {
+		err := svc.ChangePassword(context.Background(), user.ID, &ChangePasswordRequest{CurrentPassword: "Current123!", NewPassword: reused})
+		assertValidationError(t, err, "password must not match any of your last 3 passwords")
+	}
+	mockRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+
+	err := svc.ChangePassword(context.Background(), user.ID, &ChangePasswordRequest{CurrentPassword: "Current123!", NewPassword: "Brand-new-123"})
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthService_Register_RejectsBreachedPasswords(t *testing.T) {
+	policy := defaultPasswordPolicy
+	policy.RejectBreached = true
+	svc := &AuthService{
+		repo:     new(MockRepository),
+		breaches: staticBreaches{counts: map[string]int{"Password123!": 12}},
+		config:   Config{PasswordPolicy: policy},
+	}
+
+	_, err := svc.Register(context.Background(), &RegisterRequest{
+		Email:       "jane@example.com",
+		Password:    "Password123!",
+		FirstName:   "Jane",
+		LastName:    "Doe",
+		AcceptTerms: true,
+	})
+
+	assertValidationError(t, err, "this password has appeared in a data breach; choose a different one")
+}
+
+func TestAuthService_ValidatePassword_BreachCheckFailsOpen(t *testing.T) {
+	policy := defaultPasswordPolicy
+	policy.RejectBreached = true
+	svc := &AuthService{breaches: staticBreaches{err: fmt.Errorf("range file missing")}}
+
+	assert.NoError(t, svc.validatePassword(context.Background(), policy, nil, "Password123!"))
+}
+
+func TestAuthService_PasswordExpired(t *testing.T) {
+	policy := defaultPasswordPolicy
+	policy.MaxAgeDays = 30
+	svc := &AuthService{config: Config{PasswordPolicy: policy}}
+
+	assert.True(t, svc.passwordExpired(context.Background(), &model.User{PasswordChangedAt: time.Now().Add(-31 * 24 * time.Hour)}))
+	assert.False(t, svc.passwordExpired(context.Background(), &model.User{PasswordChangedAt: time.Now().Add(-29 * 24 * time.Hour)}))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -11,15 +12,22 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Cache    CacheConfig
-	JWT      JWTConfig
-	OTEL     OTELConfig
-	Checker  CheckerConfig
-	Emailit  EmailitConfig
-	Mailpit  MailpitConfig
-	GRPC     GRPCConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	Cache       CacheConfig
+	JWT         JWTConfig
+	OTEL        OTELConfig
+	Checker     CheckerConfig
+	Emailit     EmailitConfig
+	Mailpit     MailpitConfig
+	GRPC        GRPCConfig
+	Security    SecurityConfig
+	Password    PasswordConfig
+	SSO         SSOConfig
+	WebAuthn    WebAuthnConfig
+	Roles       RolesConfig
+	Invitations InvitationConfig
+	Domains     DomainConfig
 }
 
 // ServerConfig holds server-related configuration
@@ -51,6 +59,16 @@ type JWTConfig struct {
 	RefreshTokenSecret string
 	AccessTokenExpiry  time.Duration
 	RefreshTokenExpiry time.Duration
+	// SigningKeyFile is a PEM RSA or Ed25519 private key; when set, access
+	// tokens are signed asymmetrically and published at /.well-known/jwks.json
+	SigningKeyFile string
+	SigningKeyID   string
+	// VerificationKeyFiles lists retired public keys as kid=path pairs, kept
+	// until the tokens they signed have expired
+	VerificationKeyFiles map[string]string
+	// Issuer and Audience are stamped on access tokens as iss/aud and enforced on validation
+	Issuer   string
+	Audience string
 }
 
 // OTELConfig holds OpenTelemetry configuration
@@ -100,6 +118,99 @@ type GRPCConfig struct {
 	PeopleProtocol         string // "rest" or "grpc"
 }
 
+// SecurityConfig holds account security configuration
+type SecurityConfig struct {
+	TwoFactorIssuer         string
+	TwoFactorEncryptionKey  string
+	PasswordResetTTL        time.Duration
+	EmailVerificationSecret string
+	EmailVerificationTTL    time.Duration
+	// LoginMaxAttempts failed logins for one account within LoginAttemptWindow
+	// lock it for LoginLockoutDuration; LoginMaxAttemptsPerIP throttles a
+	// single client across accounts without locking anyone out
+	LoginMaxAttempts      int
+	LoginMaxAttemptsPerIP int
+	LoginAttemptWindow    time.Duration
+	LoginLockoutDuration  time.Duration
+	// LoginDelayBase doubles with each further failed attempt, up to LoginDelayMax
+	LoginDelayBase time.Duration
+	LoginDelayMax  time.Duration
+	// LoginAlerts records every login and flags new devices and impossible
+	// travel, located with the offline GeoIPDatabase (a DB-IP Lite city CSV)
+	LoginAlerts              bool
+	GeoIPDatabase            string
+	ImpossibleTravelSpeedKmh int
+	// ImpersonationTTL is how long a platform admin's "view as user" token lasts
+	ImpersonationTTL time.Duration
+}
+
+// PasswordConfig holds the system password policy; organizations can tighten it
+type PasswordConfig struct {
+	MinLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireNumber    bool
+	RequireSymbol    bool
+	// History is how many recent passwords, including the current one, can't be reused
+	History int
+	// MaxAgeDays is how long a password lasts before users are asked to change it; zero disables expiry
+	MaxAgeDays int
+	// RejectBreached rejects passwords found in BreachedPasswordsDir, an
+	// offline copy of the Pwned Passwords range files
+	RejectBreached       bool
+	BreachedPasswordsDir string
+}
+
+// SSOConfig holds organization single sign-on configuration
+type SSOConfig struct {
+	// RedirectURL is the frontend page identity providers redirect back to;
+	// it must be registered with each organization's provider
+	RedirectURL string
+	// SecretEncryptionKey encrypts identity provider client secrets at rest
+	SecretEncryptionKey string
+	// ServiceProviderBaseURL is this API's public URL, used in SAML service provider metadata
+	ServiceProviderBaseURL string
+}
+
+// WebAuthnConfig holds passkey relying party configuration
+type WebAuthnConfig struct {
+	// RPID is the domain passkeys are bound to; it must be the frontend's
+	// domain or a parent of it and can't change without invalidating passkeys
+	RPID string
+	// RPName is shown by browsers when a passkey is created
+	RPName string
+	// Origins are the frontend origins allowed to use passkeys
+	Origins []string
+}
+
+// RolesConfig holds time-bound role grant configuration
+type RolesConfig struct {
+	// ExpiryCheckInterval is how often expired grants are deactivated and
+	// expiry notices sent
+	ExpiryCheckInterval time.Duration
+	// ExpiryNotice is how long before a grant ends its holder is notified
+	ExpiryNotice time.Duration
+	// MaxGrantDuration limits how long a delegated grant may last
+	MaxGrantDuration time.Duration
+}
+
+// InvitationConfig holds organization invitation configuration
+type InvitationConfig struct {
+	// TTL is how long an invitation can be accepted; resending starts it again
+	TTL time.Duration
+	// URL is the frontend page invitation emails link to, with ?token= appended
+	URL string
+}
+
+// DomainConfig holds organization domain verification configuration
+type DomainConfig struct {
+	// DNSServer is the resolver (host:port) TXT records are checked against;
+	// empty uses the system resolver
+	DNSServer string
+	// CodeTTL is how long an emailed verification code can be used
+	CodeTTL time.Duration
+}
+
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	// Try to load .env file, but don't fail if it doesn't exist
@@ -124,10 +235,15 @@ func Load() (*Config, error) {
 			Enabled:  getBoolEnv("REDIS_ENABLED", true),
 		},
 		JWT: JWTConfig{
-			AccessTokenSecret:  getEnv("JWT_ACCESS_SECRET", "your-access-secret-key-change-in-production"),
-			RefreshTokenSecret: getEnv("JWT_REFRESH_SECRET", "your-refresh-secret-key-change-in-production"),
-			AccessTokenExpiry:  getDurationEnv("JWT_ACCESS_EXPIRY", 15*time.Minute),
-			RefreshTokenExpiry: getDurationEnv("JWT_REFRESH_EXPIRY", 14*24*time.Hour),
+			AccessTokenSecret:    getEnv("JWT_ACCESS_SECRET", "your-access-secret-key-change-in-production"),
+			RefreshTokenSecret:   getEnv("JWT_REFRESH_SECRET", "your-refresh-secret-key-change-in-production"),
+			AccessTokenExpiry:    getDurationEnv("JWT_ACCESS_EXPIRY", 15*time.Minute),
+			RefreshTokenExpiry:   getDurationEnv("JWT_REFRESH_EXPIRY", 14*24*time.Hour),
+			SigningKeyFile:       getEnv("JWT_SIGNING_KEY_FILE", ""),
+			SigningKeyID:         getEnv("JWT_SIGNING_KEY_ID", ""),
+			VerificationKeyFiles: getMapEnv("JWT_VERIFICATION_KEY_FILES"),
+			Issuer:               getEnv("JWT_ISSUER", "ethos"),
+			Audience:             getEnv("JWT_AUDIENCE", "ethos-api"),
 		},
 		OTEL: OTELConfig{
 			ServiceName: getEnv("OTEL_SERVICE_NAME", "ethos-api"),
@@ -165,12 +281,66 @@ func Load() (*Config, error) {
 			NotificationsProtocol: getEnv("GRPC_NOTIFICATIONS_PROTOCOL", "rest"),
 			PeopleProtocol:         getEnv("GRPC_PEOPLE_PROTOCOL", "rest"),
 		},
+		Security: SecurityConfig{
+			TwoFactorIssuer:          getEnv("TWO_FACTOR_ISSUER", "Ethos"),
+			TwoFactorEncryptionKey:   getEnv("TWO_FACTOR_ENCRYPTION_KEY", "your-2fa-encryption-key-change-in-production"),
+			PasswordResetTTL:         getDurationEnv("PASSWORD_RESET_TTL", 1*time.Hour),
+			EmailVerificationSecret:  getEnv("EMAIL_VERIFICATION_SECRET", "your-email-verification-secret-change-in-production"),
+			EmailVerificationTTL:     getDurationEnv("EMAIL_VERIFICATION_TTL", 24*time.Hour),
+			LoginMaxAttempts:         getIntEnv("LOGIN_MAX_ATTEMPTS", 5),
+			LoginMaxAttemptsPerIP:    getIntEnv("LOGIN_MAX_ATTEMPTS_PER_IP", 20),
+			LoginAttemptWindow:       getDurationEnv("LOGIN_ATTEMPT_WINDOW", 15*time.Minute),
+			LoginLockoutDuration:     getDurationEnv("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+			LoginDelayBase:           getDurationEnv("LOGIN_DELAY_BASE", 500*time.Millisecond),
+			LoginDelayMax:            getDurationEnv("LOGIN_DELAY_MAX", 8*time.Second),
+			ImpersonationTTL:         getDurationEnv("IMPERSONATION_TTL", 15*time.Minute),
+			LoginAlerts:              getBoolEnv("LOGIN_ALERTS_ENABLED", true),
+			GeoIPDatabase:            getEnv("GEOIP_DATABASE", ""),
+			ImpossibleTravelSpeedKmh: getIntEnv("IMPOSSIBLE_TRAVEL_SPEED_KMH", 1000),
+		},
+		Password: PasswordConfig{
+			MinLength:            getIntEnv("PASSWORD_MIN_LENGTH", 8),
+			RequireUppercase:     getBoolEnv("PASSWORD_REQUIRE_UPPERCASE", true),
+			RequireLowercase:     getBoolEnv("PASSWORD_REQUIRE_LOWERCASE", true),
+			RequireNumber:        getBoolEnv("PASSWORD_REQUIRE_NUMBER", true),
+			RequireSymbol:        getBoolEnv("PASSWORD_REQUIRE_SYMBOL", true),
+			History:              getIntEnv("PASSWORD_HISTORY", 0),
+			MaxAgeDays:           getIntEnv("PASSWORD_MAX_AGE_DAYS", 0),
+			RejectBreached:       getBoolEnv("PASSWORD_REJECT_BREACHED", true),
+			BreachedPasswordsDir: getEnv("BREACHED_PASSWORDS_DIR", ""),
+		},
+		SSO: SSOConfig{
+			RedirectURL:            getEnv("SSO_REDIRECT_URL", "http://localhost:5173/sso/callback"),
+			SecretEncryptionKey:    getEnv("SSO_SECRET_ENCRYPTION_KEY", "your-sso-encryption-key-change-in-production"),
+			ServiceProviderBaseURL: getEnv("SSO_SP_BASE_URL", "http://localhost:8000"),
+		},
+		WebAuthn: WebAuthnConfig{
+			RPID:    getEnv("WEBAUTHN_RP_ID", "localhost"),
+			RPName:  getEnv("WEBAUTHN_RP_NAME", "Ethos"),
+			Origins: getListEnv("WEBAUTHN_ORIGINS", []string{"http://localhost:5173"}),
+		},
+		Roles: RolesConfig{
+			ExpiryCheckInterval: getDurationEnv("ROLE_EXPIRY_CHECK_INTERVAL", 5*time.Minute),
+			ExpiryNotice:        getDurationEnv("ROLE_EXPIRY_NOTICE", 24*time.Hour),
+			MaxGrantDuration:    getDurationEnv("ROLE_MAX_GRANT_DURATION", 90*24*time.Hour),
+		},
+		Invitations: InvitationConfig{
+			TTL: getDurationEnv("INVITATION_TTL", 7*24*time.Hour),
+			URL: getEnv("INVITATION_URL", "http://localhost:5173/invitations"),
+		},
+		Domains: DomainConfig{
+			DNSServer: getEnv("DOMAIN_VERIFICATION_DNS_SERVER", ""),
+			CodeTTL:   getDurationEnv("DOMAIN_VERIFICATION_CODE_TTL", 24*time.Hour),
+		},
 	}
 
 	// Validate required fields
 	if cfg.JWT.AccessTokenSecret == "" || cfg.JWT.RefreshTokenSecret == "" {
 		return nil, fmt.Errorf("JWT secrets must be set")
 	}
+	if cfg.JWT.SigningKeyFile != "" && cfg.JWT.SigningKeyID == "" {
+		return nil, fmt.Errorf("JWT_SIGNING_KEY_ID must be set when JWT_SIGNING_KEY_FILE is used")
+	}
 
 	return cfg, nil
 }
@@ -200,6 +370,32 @@ func getBoolEnv(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+// getMapEnv parses comma-separated key=value pairs
+func getMapEnv(key string) map[string]string {
+	values := make(map[string]string)
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if ok && k != "" && v != "" {
+			values[k] = v
+		}
+	}
+	return values
+}
+
+// getListEnv parses a comma-separated list
+func getListEnv(key string, defaultValue []string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return defaultValue
+	}
+	return values
+}
+
 func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_login_challenges_expires_at;
+DROP INDEX IF EXISTS idx_login_challenges_user_id;
+DROP INDEX IF EXISTS idx_two_factor_recovery_codes_user_id;
+
+DROP TABLE IF EXISTS login_challenges;
+DROP TABLE IF EXISTS two_factor_recovery_codes;
+
+ALTER TABLE users DROP COLUMN IF EXISTS two_factor_last_step;
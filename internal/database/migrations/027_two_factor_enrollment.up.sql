@@ -0,0 +1,28 @@
+-- Track the last accepted TOTP time step so a code cannot be replayed
+ALTER TABLE users ADD COLUMN IF NOT EXISTS two_factor_last_step BIGINT NOT NULL DEFAULT 0;
+
+-- Create two_factor_recovery_codes table (one-time backup codes, stored hashed)
+CREATE TABLE IF NOT EXISTS two_factor_recovery_codes (
+    code_id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(255) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(user_id, code_hash)
+);
+
+-- Create login_challenges table (pending second-factor step after a password login)
+CREATE TABLE IF NOT EXISTS login_challenges (
+    challenge_id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(255) UNIQUE NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for efficient querying
+CREATE INDEX IF NOT EXISTS idx_two_factor_recovery_codes_user_id ON two_factor_recovery_codes(user_id);
+CREATE INDEX IF NOT EXISTS idx_login_challenges_user_id ON login_challenges(user_id);
+CREATE INDEX IF NOT EXISTS idx_login_challenges_expires_at ON login_challenges(expires_at);
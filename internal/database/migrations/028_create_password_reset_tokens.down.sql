@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_password_reset_tokens_expires_at;
+DROP INDEX IF EXISTS idx_password_reset_tokens_user_id;
+
+DROP TABLE IF EXISTS password_reset_tokens;
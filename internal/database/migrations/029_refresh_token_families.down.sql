@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_refresh_tokens_family_id;
+DROP INDEX IF EXISTS idx_refresh_tokens_token_hash;
+
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS revoked_at;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS rotated_at;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS family_id;
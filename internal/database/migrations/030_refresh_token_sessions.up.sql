@@ -0,0 +1,5 @@
+-- Link refresh tokens to the user session they were issued for, so a refreshed
+-- access token keeps its session ID and revoking a session stops its refreshes
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS session_id UUID REFERENCES user_sessions(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session_id ON refresh_tokens(session_id);
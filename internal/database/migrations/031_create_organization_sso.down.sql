@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS user_sso_identities;
+DROP TABLE IF EXISTS organization_oidc_providers;
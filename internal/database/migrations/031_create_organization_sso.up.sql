@@ -0,0 +1,28 @@
+-- Per-organization single sign-on and the external identities linked to users
+
+CREATE TABLE IF NOT EXISTS organization_oidc_providers (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    issuer VARCHAR(2048) NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    client_secret_encrypted TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- An identity is the provider's stable subject for a user within one organization
+CREATE TABLE IF NOT EXISTS user_sso_identities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    provider VARCHAR(20) NOT NULL, -- oidc, saml
+    subject VARCHAR(512) NOT NULL,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_login_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(organization_id, provider, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_sso_identities_user_id ON user_sso_identities(user_id);
+
+CREATE TRIGGER update_organization_oidc_providers_updated_at BEFORE UPDATE ON organization_oidc_providers
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
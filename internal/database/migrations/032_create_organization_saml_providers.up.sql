@@ -0,0 +1,15 @@
+-- Per-organization SAML identity providers for enterprise single sign-on
+
+CREATE TABLE IF NOT EXISTS organization_saml_providers (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    idp_metadata TEXT NOT NULL,
+    idp_entity_id VARCHAR(2048) NOT NULL,
+    attribute_mapping JSONB NOT NULL DEFAULT '{}', -- SAML attribute names for email, first_name, last_name and role
+    role_mapping JSONB NOT NULL DEFAULT '{}', -- Role attribute value to organization role
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_organization_saml_providers_updated_at BEFORE UPDATE ON organization_saml_providers
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,18 @@
+-- WebAuthn passkeys registered to users for passwordless login
+
+CREATE TABLE IF NOT EXISTS webauthn_credentials (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    credential_id BYTEA NOT NULL UNIQUE,
+    public_key BYTEA NOT NULL, -- COSE_Key
+    sign_count BIGINT NOT NULL DEFAULT 0, -- Last signature counter seen, to detect cloned authenticators
+    aaguid BYTEA,
+    transports TEXT[] NOT NULL DEFAULT '{}',
+    backup_eligible BOOLEAN NOT NULL DEFAULT FALSE,
+    backed_up BOOLEAN NOT NULL DEFAULT FALSE,
+    name VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webauthn_credentials_user_id ON webauthn_credentials(user_id);
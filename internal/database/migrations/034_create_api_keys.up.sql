@@ -0,0 +1,17 @@
+-- Personal access tokens for integrations, owned by a user or by an organization
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- Owner, or creator of an organization key
+    organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE, -- NULL for personal keys
+    name VARCHAR(100) NOT NULL,
+    token_prefix VARCHAR(32) NOT NULL, -- Shown in listings to recognise a key
+    token_hash VARCHAR(64) NOT NULL UNIQUE, -- SHA-256 of the token; the token itself is never stored
+    scopes TEXT[] NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys(user_id);
+CREATE INDEX IF NOT EXISTS idx_api_keys_organization_id ON api_keys(organization_id);
@@ -0,0 +1,4 @@
+UPDATE user_roles SET permissions = permissions - '*' - 'org.*' - 'moderation.*'
+WHERE name IN ('platform_admin', 'org_admin', 'moderator');
+
+DROP TABLE IF EXISTS organization_roles;
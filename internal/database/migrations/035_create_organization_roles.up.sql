@@ -0,0 +1,19 @@
+-- Custom organization roles and dotted permissions for the permission engine
+
+CREATE TABLE IF NOT EXISTS organization_roles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL, -- Stored in organization_members.role for members holding it
+    description TEXT,
+    permissions JSONB NOT NULL DEFAULT '{}', -- Same shape as user_roles.permissions
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(organization_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_roles_org_id ON organization_roles(organization_id);
+
+-- Platform roles grant permissions in every organization
+UPDATE user_roles SET permissions = permissions || '{"*": true}' WHERE name = 'platform_admin';
+UPDATE user_roles SET permissions = permissions || '{"org.*": true, "moderation.*": true}' WHERE name = 'org_admin';
+UPDATE user_roles SET permissions = permissions || '{"moderation.*": true}' WHERE name = 'moderator';
@@ -0,0 +1,3 @@
+ALTER TABLE user_role_assignments DROP COLUMN IF EXISTS expiry_notified_at;
+
+DROP TABLE IF EXISTS organization_role_grants;
@@ -0,0 +1,25 @@
+-- Time-bound role grants, on top of a member's own organization role
+
+CREATE TABLE IF NOT EXISTS organization_role_grants (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(50) NOT NULL, -- Built-in or custom role name
+    reason TEXT,
+    granted_by VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expiry_notified_at TIMESTAMP WITH TIME ZONE,
+    expired_at TIMESTAMP WITH TIME ZONE, -- Set by the expiry job once ends_at has passed
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    revoked_by VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_role_grants_member ON organization_role_grants(organization_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_organization_role_grants_open ON organization_role_grants(ends_at)
+    WHERE expired_at IS NULL AND revoked_at IS NULL;
+
+-- Platform role assignments get the same expiry notices
+ALTER TABLE user_role_assignments ADD COLUMN IF NOT EXISTS expiry_notified_at TIMESTAMP WITH TIME ZONE;
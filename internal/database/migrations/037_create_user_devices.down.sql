@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_security_events_user_type_timestamp;
+ALTER TABLE security_events DROP COLUMN IF EXISTS longitude;
+ALTER TABLE security_events DROP COLUMN IF EXISTS latitude;
+DROP TABLE IF EXISTS user_devices;
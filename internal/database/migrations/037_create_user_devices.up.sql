@@ -0,0 +1,17 @@
+-- Devices users have logged in from, to detect logins from new devices
+CREATE TABLE IF NOT EXISTS user_devices (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    fingerprint VARCHAR(64) NOT NULL, -- SHA-256 of the normalized user agent
+    user_agent TEXT,
+    last_ip VARCHAR(45),
+    first_seen_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(user_id, fingerprint)
+);
+
+-- Login events keep their approximate coordinates for impossible travel checks
+ALTER TABLE security_events ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+ALTER TABLE security_events ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+
+CREATE INDEX IF NOT EXISTS idx_security_events_user_type_timestamp ON security_events(user_id, type, timestamp DESC);
@@ -0,0 +1,27 @@
+-- When each user last set their password, for password expiry
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMP WITH TIME ZONE;
+
+-- Hashes of users' previous passwords, so they can't be reused
+CREATE TABLE IF NOT EXISTS password_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_history_user_created ON password_history(user_id, created_at DESC);
+
+-- Organization password policies, which tighten the system policy for members
+CREATE TABLE IF NOT EXISTS organization_password_policies (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    min_length INTEGER NOT NULL DEFAULT 0,
+    require_uppercase BOOLEAN NOT NULL DEFAULT FALSE,
+    require_lowercase BOOLEAN NOT NULL DEFAULT FALSE,
+    require_number BOOLEAN NOT NULL DEFAULT FALSE,
+    require_symbol BOOLEAN NOT NULL DEFAULT FALSE,
+    history_count INTEGER NOT NULL DEFAULT 0,
+    max_age_days INTEGER NOT NULL DEFAULT 0,
+    reject_breached BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_by VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,25 @@
+-- Invitations to join an organization. Pending invitations hold a seat
+-- against organizations.max_users until they're answered or expire.
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(50) NOT NULL,
+    invited_by VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, accepted, declined, revoked, expired
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_by VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL,
+    responded_at TIMESTAMP WITH TIME ZONE,
+    send_count INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- At most one pending invitation per address and organization
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_invitations_pending_email
+    ON organization_invitations(organization_id, LOWER(email))
+    WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_org_created
+    ON organization_invitations(organization_id, created_at DESC);
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS organization_join_requests;
+
+DROP INDEX IF EXISTS idx_organization_domains_verified_domain;
+DROP INDEX IF EXISTS idx_organization_domains_org_domain;
+ALTER TABLE organization_domains ADD CONSTRAINT organization_domains_domain_key UNIQUE (domain);
+
+ALTER TABLE organization_domains DROP COLUMN IF EXISTS created_by;
+ALTER TABLE organization_domains DROP COLUMN IF EXISTS join_mode;
+ALTER TABLE organization_domains DROP COLUMN IF EXISTS verification_expires_at;
+ALTER TABLE organization_domains DROP COLUMN IF EXISTS verification_email;
+ALTER TABLE organization_domains DROP COLUMN IF EXISTS verification_method;
@@ -0,0 +1,28 @@
+-- How each organization domain is verified and what happens when people
+-- register with an address at it
+ALTER TABLE organization_domains ADD COLUMN IF NOT EXISTS verification_method VARCHAR(10) NOT NULL DEFAULT 'dns'; -- dns, email
+ALTER TABLE organization_domains ADD COLUMN IF NOT EXISTS verification_email VARCHAR(255);
+ALTER TABLE organization_domains ADD COLUMN IF NOT EXISTS verification_expires_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE organization_domains ADD COLUMN IF NOT EXISTS join_mode VARCHAR(10) NOT NULL DEFAULT 'none'; -- none, auto, request
+ALTER TABLE organization_domains ADD COLUMN IF NOT EXISTS created_by VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL;
+
+-- Several organizations may claim a domain, but only one can verify it
+ALTER TABLE organization_domains DROP CONSTRAINT IF EXISTS organization_domains_domain_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_domains_org_domain ON organization_domains(organization_id, LOWER(domain));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_domains_verified_domain ON organization_domains(LOWER(domain)) WHERE verified;
+
+-- Requests to join an organization from people at its verified domains
+CREATE TABLE IF NOT EXISTS organization_join_requests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, approved, denied
+    decided_by VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL,
+    decided_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_join_requests_pending
+    ON organization_join_requests(organization_id, user_id)
+    WHERE status = 'pending';
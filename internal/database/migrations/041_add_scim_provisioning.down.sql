@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_organization_members_scim_external_id;
+
+ALTER TABLE organization_members DROP COLUMN IF EXISTS scim_external_id;
+ALTER TABLE organization_members DROP COLUMN IF EXISTS suspended_by;
+ALTER TABLE organization_members DROP COLUMN IF EXISTS suspension_reason;
+ALTER TABLE organization_members DROP COLUMN IF EXISTS suspended_until;
+ALTER TABLE organization_members DROP COLUMN IF EXISTS suspended_at;
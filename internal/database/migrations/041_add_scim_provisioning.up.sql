@@ -0,0 +1,14 @@
+-- Organization-level suspension, used by admins and by SCIM deactivation.
+-- Suspended members keep their role and seat but lose access until
+-- unsuspended or suspended_until passes.
+ALTER TABLE organization_members ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE organization_members ADD COLUMN IF NOT EXISTS suspended_until TIMESTAMP WITH TIME ZONE;
+ALTER TABLE organization_members ADD COLUMN IF NOT EXISTS suspension_reason TEXT;
+ALTER TABLE organization_members ADD COLUMN IF NOT EXISTS suspended_by VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL;
+
+-- The identity provider's ID for a member provisioned over SCIM
+ALTER TABLE organization_members ADD COLUMN IF NOT EXISTS scim_external_id VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_members_scim_external_id
+    ON organization_members(organization_id, scim_external_id)
+    WHERE scim_external_id IS NOT NULL;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_feedback_items_organization_id;
+DROP INDEX IF EXISTS idx_feedback_items_recipient;
+ALTER TABLE feedback_items DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE feedback_items DROP COLUMN IF EXISTS recipient_id;
+ALTER TABLE feedback_items DROP COLUMN IF EXISTS recipient_type;
+
+DROP TABLE IF EXISTS organization_team_members;
+DROP TABLE IF EXISTS organization_teams;
@@ -0,0 +1,30 @@
+-- Teams group organization members so feedback can be addressed to them
+CREATE TABLE IF NOT EXISTS organization_teams (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    created_by VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_teams_org_name ON organization_teams(organization_id, LOWER(name));
+
+CREATE TABLE IF NOT EXISTS organization_team_members (
+    team_id UUID NOT NULL REFERENCES organization_teams(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    added_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (team_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_team_members_user_id ON organization_team_members(user_id);
+
+-- Who feedback is addressed to: a user, a team or an organization. The
+-- organization scopes team visibility and is set for team and organization
+-- recipients.
+ALTER TABLE feedback_items ADD COLUMN IF NOT EXISTS recipient_type VARCHAR(20); -- user, team, organization
+ALTER TABLE feedback_items ADD COLUMN IF NOT EXISTS recipient_id VARCHAR(255);
+ALTER TABLE feedback_items ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_feedback_items_recipient ON feedback_items(recipient_type, recipient_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_feedback_items_organization_id ON feedback_items(organization_id);
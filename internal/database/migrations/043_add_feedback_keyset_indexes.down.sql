@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_feedback_comments_feedback_created_at_id;
+DROP INDEX IF EXISTS idx_feedback_items_created_at_id;
@@ -0,0 +1,4 @@
+-- Keyset pagination orders the feed and comments by creation time with the
+-- ID breaking ties, so index the pair to seek straight to a cursor
+CREATE INDEX IF NOT EXISTS idx_feedback_items_created_at_id ON feedback_items(created_at DESC, feedback_id DESC);
+CREATE INDEX IF NOT EXISTS idx_feedback_comments_feedback_created_at_id ON feedback_comments(feedback_id, created_at, comment_id);
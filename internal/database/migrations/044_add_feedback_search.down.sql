@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_feedback_items_search_vector;
+ALTER TABLE feedback_items DROP COLUMN IF EXISTS search_vector;
+DROP FUNCTION IF EXISTS feedback_search_config(TEXT);
+ALTER TABLE feedback_items DROP COLUMN IF EXISTS locale;
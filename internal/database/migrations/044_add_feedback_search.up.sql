@@ -0,0 +1,41 @@
+-- Full-text search over feedback content. Each item is indexed with the
+-- dictionary for its author's locale, so searches match other forms of the
+-- same word, and with the simple dictionary so exact words match whatever
+-- the language of the search
+ALTER TABLE feedback_items ADD COLUMN IF NOT EXISTS locale VARCHAR(10);
+
+UPDATE feedback_items f
+SET locale = p.locale
+FROM user_preferences p
+WHERE p.user_id = f.author_id AND f.locale IS NULL;
+
+-- Maps a locale such as en-US or pt_BR to its text search configuration
+CREATE OR REPLACE FUNCTION feedback_search_config(locale TEXT) RETURNS regconfig AS $$
+    SELECT (CASE lower(split_part(replace(locale, '_', '-'), '-', 1))
+        WHEN 'da' THEN 'danish'
+        WHEN 'de' THEN 'german'
+        WHEN 'en' THEN 'english'
+        WHEN 'es' THEN 'spanish'
+        WHEN 'fi' THEN 'finnish'
+        WHEN 'fr' THEN 'french'
+        WHEN 'hu' THEN 'hungarian'
+        WHEN 'it' THEN 'italian'
+        WHEN 'nb' THEN 'norwegian'
+        WHEN 'nl' THEN 'dutch'
+        WHEN 'no' THEN 'norwegian'
+        WHEN 'pt' THEN 'portuguese'
+        WHEN 'ro' THEN 'romanian'
+        WHEN 'ru' THEN 'russian'
+        WHEN 'sv' THEN 'swedish'
+        WHEN 'tr' THEN 'turkish'
+        ELSE 'simple'
+    END)::regconfig
+$$ LANGUAGE SQL IMMUTABLE;
+
+ALTER TABLE feedback_items ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        to_tsvector(feedback_search_config(locale), COALESCE(content, ''))
+        || to_tsvector('simple', COALESCE(content, ''))
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_feedback_items_search_vector ON feedback_items USING GIN (search_vector);
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_feedback_items_template;
+ALTER TABLE feedback_items DROP CONSTRAINT IF EXISTS fk_feedback_items_template_version;
+ALTER TABLE feedback_items DROP COLUMN IF EXISTS dimensions;
+ALTER TABLE feedback_items DROP COLUMN IF EXISTS template_responses;
+ALTER TABLE feedback_items DROP COLUMN IF EXISTS template_version;
+ALTER TABLE feedback_items DROP COLUMN IF EXISTS template_id;
+
+DROP TABLE IF EXISTS feedback_template_suggestions;
+DROP TABLE IF EXISTS feedback_template_versions;
+
+DROP INDEX IF EXISTS idx_feedback_templates_status;
+ALTER TABLE feedback_templates DROP COLUMN IF EXISTS created_by;
+ALTER TABLE feedback_templates DROP COLUMN IF EXISTS current_version;
+ALTER TABLE feedback_templates DROP COLUMN IF EXISTS status;
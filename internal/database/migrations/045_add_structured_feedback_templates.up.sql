@@ -0,0 +1,54 @@
+-- Templates are drafted, published for use, then archived. Their typed
+-- fields live in immutable versions, so feedback keeps pointing at the
+-- fields it was validated against when a template is edited.
+ALTER TABLE feedback_templates ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'published'; -- draft, published, archived
+ALTER TABLE feedback_templates ADD COLUMN IF NOT EXISTS current_version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE feedback_templates ADD COLUMN IF NOT EXISTS created_by VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_feedback_templates_status ON feedback_templates(status);
+
+CREATE TABLE IF NOT EXISTS feedback_template_versions (
+    template_id VARCHAR(255) NOT NULL REFERENCES feedback_templates(template_id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    fields JSONB NOT NULL DEFAULT '[]',
+    created_by VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (template_id, version)
+);
+
+-- Existing templates only have free-form template_fields, so their first
+-- version defines no typed fields
+INSERT INTO feedback_template_versions (template_id, version, created_at)
+SELECT template_id, 1, created_at FROM feedback_templates
+ON CONFLICT (template_id, version) DO NOTHING;
+
+-- Suggestions wait for an administrator to turn them into a template or
+-- reject them
+CREATE TABLE IF NOT EXISTS feedback_template_suggestions (
+    suggestion_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    suggested_by VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL,
+    usage_context TEXT NOT NULL,
+    details TEXT NOT NULL,
+    desired_fields JSONB NOT NULL DEFAULT '[]',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, approved, rejected
+    reviewed_by VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_note TEXT,
+    template_id VARCHAR(255) REFERENCES feedback_templates(template_id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_feedback_template_suggestions_status ON feedback_template_suggestions(status, created_at);
+
+-- Feedback written with a template records the version it was validated
+-- against, the answers to its fields and the dimension scores among them
+ALTER TABLE feedback_items ADD COLUMN IF NOT EXISTS template_id VARCHAR(255);
+ALTER TABLE feedback_items ADD COLUMN IF NOT EXISTS template_version INTEGER;
+ALTER TABLE feedback_items ADD COLUMN IF NOT EXISTS template_responses JSONB;
+ALTER TABLE feedback_items ADD COLUMN IF NOT EXISTS dimensions JSONB;
+
+ALTER TABLE feedback_items DROP CONSTRAINT IF EXISTS fk_feedback_items_template_version;
+ALTER TABLE feedback_items ADD CONSTRAINT fk_feedback_items_template_version
+    FOREIGN KEY (template_id, template_version) REFERENCES feedback_template_versions(template_id, version);
+
+CREATE INDEX IF NOT EXISTS idx_feedback_items_template ON feedback_items(template_id, template_version);
@@ -26,21 +26,75 @@ func NewFeedbackHandler(svc service.Service) *FeedbackHandler {
 	}
 }
 
-// GetFeed handles GET /api/v1/feedback/feed
-func (h *FeedbackHandler) GetFeed(c *gin.Context) {
-	limit := c.DefaultQuery("limit", "20")
-	offset := c.DefaultQuery("offset", "0")
+// viewerFrom returns who is reading feedback: the authenticated user and
+// the organization the context middleware resolved for them. It responds
+// with 401 and returns false if the request isn't authenticated.
+func viewerFrom(c *gin.Context) (feedbackPkg.Viewer, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid token",
+			"code":  "AUTH_TOKEN_INVALID",
+		})
+		return feedbackPkg.Viewer{}, false
+	}
 
-	limitInt := 20
-	offsetInt := 0
-	if l, err := strconv.Atoi(limit); err == nil && l > 0 {
-		limitInt = l
+	return feedbackPkg.Viewer{
+		UserID:         userID.(string),
+		OrganizationID: c.GetString("current_organization_id"),
+	}, true
+}
+
+// maxPageLimit is the most items a list request returns; larger limits are lowered to it
+const maxPageLimit = 100
+
+// pageRequestFrom reads the cursor, limit and include_total query
+// parameters. The limit defaults to 20 and is capped at maxPageLimit. It
+// responds with 400 and returns false if the cursor isn't one a previous
+// page returned.
+func pageRequestFrom(c *gin.Context) (feedbackPkg.PageRequest, bool) {
+	page := feedbackPkg.PageRequest{Limit: 20}
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 {
+		page.Limit = l
 	}
-	if o, err := strconv.Atoi(offset); err == nil && o >= 0 {
-		offsetInt = o
+	if page.Limit > maxPageLimit {
+		page.Limit = maxPageLimit
+	}
+	page.IncludeTotal, _ = strconv.ParseBool(c.Query("include_total"))
+
+	after, err := feedbackPkg.DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return page, false
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid cursor",
+			"code":  "VALIDATION_FAILED",
+		})
+		return page, false
 	}
+	page.After = after
 
-	// Parse filtering parameters
+	return page, true
+}
+
+// pageResponse adds a page's next cursor and, if it was requested, its
+// approximate total to a list response
+func pageResponse(body gin.H, info *feedbackPkg.PageInfo) gin.H {
+	body["next_cursor"] = info.NextCursor
+	if info.ApproximateTotal != nil {
+		body["approximate_total"] = *info.ApproximateTotal
+	}
+	return body
+}
+
+// feedFiltersFrom reads the reviewer_type, context, verification and tags
+// query parameters
+func feedFiltersFrom(c *gin.Context) *feedbackPkg.FeedFilters {
 	filters := &feedbackPkg.FeedFilters{}
 
 	if reviewerType := c.Query("reviewer_type"); reviewerType != "" {
@@ -63,16 +117,33 @@ func (h *FeedbackHandler) GetFeed(c *gin.Context) {
 		}
 	}
 
+	return filters
+}
+
+// GetFeed handles GET /api/v1/feedback/feed
+func (h *FeedbackHandler) GetFeed(c *gin.Context) {
+	viewer, ok := viewerFrom(c)
+	if !ok {
+		return
+	}
+
+	page, ok := pageRequestFrom(c)
+	if !ok {
+		return
+	}
+
+	filters := feedFiltersFrom(c)
+
 	var items []*model.FeedbackItem
-	var count int
+	var info *feedbackPkg.PageInfo
 	var err error
 
 	// Use filtered feed if any filters are provided
 	if filters.ReviewerType != nil || filters.Context != nil || filters.Verification != nil || len(filters.Tags) > 0 {
-		items, count, err = h.service.GetFeedWithFilters(c.Request.Context(), limitInt, offsetInt, filters)
+		items, info, err = h.service.GetFeedWithFilters(c.Request.Context(), viewer, page, filters)
 	} else {
 		// Fallback to original GetFeed for backward compatibility
-		items, count, err = h.service.GetFeed(c.Request.Context(), limitInt, offsetInt)
+		items, info, err = h.service.GetFeed(c.Request.Context(), viewer, page)
 	}
 
 	if err != nil {
@@ -90,17 +161,81 @@ func (h *FeedbackHandler) GetFeed(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	c.JSON(http.StatusOK, pageResponse(gin.H{
 		"results": items,
+	}, info))
+}
+
+// SearchFeedback handles GET /api/v1/feedback/search
+func (h *FeedbackHandler) SearchFeedback(c *gin.Context) {
+	viewer, ok := viewerFrom(c)
+	if !ok {
+		return
+	}
+
+	req := &feedbackPkg.SearchRequest{
+		Query:   c.Query("q"),
+		Locale:  c.Query("locale"),
+		Filters: feedFiltersFrom(c),
+		Limit:   20,
+	}
+
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 {
+		req.Limit = l
+	}
+	if req.Limit > maxPageLimit {
+		req.Limit = maxPageLimit
+	}
+	if o, err := strconv.Atoi(c.Query("offset")); err == nil && o >= 0 {
+		req.Offset = o
+	}
+
+	if authorID := c.Query("author_id"); authorID != "" {
+		req.AuthorID = &authorID
+	}
+	if fromStr := c.Query("from"); fromStr != "" {
+		if parsed, err := time.Parse("2006-01-02", fromStr); err == nil {
+			req.From = &parsed
+		}
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		if parsed, err := time.Parse("2006-01-02", toStr); err == nil {
+			req.To = &parsed
+		}
+	}
+
+	results, count, err := h.service.SearchFeedback(c.Request.Context(), viewer, req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": results,
 		"count":   count,
 	})
 }
 
 // GetFeedbackByID handles GET /api/v1/feedback/:feedback_id
 func (h *FeedbackHandler) GetFeedbackByID(c *gin.Context) {
+	viewer, ok := viewerFrom(c)
+	if !ok {
+		return
+	}
+
 	feedbackID := c.Param("feedback_id")
 
-	item, err := h.service.GetFeedbackByID(c.Request.Context(), feedbackID)
+	item, err := h.service.GetFeedbackByID(c.Request.Context(), viewer, feedbackID)
 	if err != nil {
 		if apiErr, ok := err.(*errors.APIError); ok {
 			c.JSON(apiErr.HTTPStatus, gin.H{
@@ -121,7 +256,45 @@ func (h *FeedbackHandler) GetFeedbackByID(c *gin.Context) {
 
 // GetComments handles GET /api/v1/feedback/:feedback_id/comments
 func (h *FeedbackHandler) GetComments(c *gin.Context) {
+	viewer, ok := viewerFrom(c)
+	if !ok {
+		return
+	}
+
 	feedbackID := c.Param("feedback_id")
+	page, ok := pageRequestFrom(c)
+	if !ok {
+		return
+	}
+
+	comments, info, err := h.service.GetComments(c.Request.Context(), viewer, feedbackID, page)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, pageResponse(gin.H{
+		"comments": comments,
+	}, info))
+}
+
+// GetReceivedFeedback handles GET /api/v1/feedback/received
+func (h *FeedbackHandler) GetReceivedFeedback(c *gin.Context) {
+	viewer, ok := viewerFrom(c)
+	if !ok {
+		return
+	}
+
 	limit := c.DefaultQuery("limit", "20")
 	offset := c.DefaultQuery("offset", "0")
 
@@ -134,7 +307,7 @@ func (h *FeedbackHandler) GetComments(c *gin.Context) {
 		offsetInt = o
 	}
 
-	comments, count, err := h.service.GetComments(c.Request.Context(), feedbackID, limitInt, offsetInt)
+	items, count, err := h.service.GetReceivedFeedback(c.Request.Context(), viewer, limitInt, offsetInt)
 	if err != nil {
 		if apiErr, ok := err.(*errors.APIError); ok {
 			c.JSON(apiErr.HTTPStatus, gin.H{
@@ -151,8 +324,49 @@ func (h *FeedbackHandler) GetComments(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"comments": comments,
-		"count":    count,
+		"results": items,
+		"count":   count,
+	})
+}
+
+// GetGivenFeedback handles GET /api/v1/feedback/given
+func (h *FeedbackHandler) GetGivenFeedback(c *gin.Context) {
+	viewer, ok := viewerFrom(c)
+	if !ok {
+		return
+	}
+
+	limit := c.DefaultQuery("limit", "20")
+	offset := c.DefaultQuery("offset", "0")
+
+	limitInt := 20
+	offsetInt := 0
+	if l, err := strconv.Atoi(limit); err == nil && l > 0 {
+		limitInt = l
+	}
+	if o, err := strconv.Atoi(offset); err == nil && o >= 0 {
+		offsetInt = o
+	}
+
+	items, count, err := h.service.GetGivenFeedback(c.Request.Context(), viewer, limitInt, offsetInt)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": items,
+		"count":   count,
 	})
 }
 
@@ -197,12 +411,8 @@ func (h *FeedbackHandler) CreateFeedback(c *gin.Context) {
 
 // CreateComment handles POST /api/v1/feedback/:feedback_id/comments
 func (h *FeedbackHandler) CreateComment(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Invalid token",
-			"code":  "AUTH_TOKEN_INVALID",
-		})
+	viewer, ok := viewerFrom(c)
+	if !ok {
 		return
 	}
 
@@ -217,7 +427,7 @@ func (h *FeedbackHandler) CreateComment(c *gin.Context) {
 		return
 	}
 
-	comment, err := h.service.CreateComment(c.Request.Context(), userID.(string), feedbackID, &req)
+	comment, err := h.service.CreateComment(c.Request.Context(), viewer, feedbackID, &req)
 	if err != nil {
 		if apiErr, ok := err.(*errors.APIError); ok {
 			c.JSON(apiErr.HTTPStatus, gin.H{
@@ -238,12 +448,8 @@ func (h *FeedbackHandler) CreateComment(c *gin.Context) {
 
 // AddReaction handles POST /api/v1/feedback/:feedback_id/react
 func (h *FeedbackHandler) AddReaction(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Invalid token",
-			"code":  "AUTH_TOKEN_INVALID",
-		})
+	viewer, ok := viewerFrom(c)
+	if !ok {
 		return
 	}
 
@@ -258,7 +464,7 @@ func (h *FeedbackHandler) AddReaction(c *gin.Context) {
 		return
 	}
 
-	err := h.service.AddReaction(c.Request.Context(), userID.(string), feedbackID, req.ReactionType)
+	err := h.service.AddReaction(c.Request.Context(), viewer, feedbackID, req.ReactionType)
 	if err != nil {
 		if apiErr, ok := err.(*errors.APIError); ok {
 			c.JSON(apiErr.HTTPStatus, gin.H{
@@ -282,12 +488,8 @@ func (h *FeedbackHandler) AddReaction(c *gin.Context) {
 
 // RemoveReaction handles DELETE /api/v1/feedback/:feedback_id/react
 func (h *FeedbackHandler) RemoveReaction(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Invalid token",
-			"code":  "AUTH_TOKEN_INVALID",
-		})
+	viewer, ok := viewerFrom(c)
+	if !ok {
 		return
 	}
 
@@ -301,7 +503,7 @@ func (h *FeedbackHandler) RemoveReaction(c *gin.Context) {
 		return
 	}
 
-	err := h.service.RemoveReaction(c.Request.Context(), userID.(string), feedbackID, reactionType)
+	err := h.service.RemoveReaction(c.Request.Context(), viewer, feedbackID, reactionType)
 	if err != nil {
 		if apiErr, ok := err.(*errors.APIError); ok {
 			c.JSON(apiErr.HTTPStatus, gin.H{
@@ -360,6 +562,12 @@ func (h *FeedbackHandler) PostTemplateSuggestions(c *gin.Context) {
 		return
 	}
 
+	// Suggestions are credited to whoever is signed in, not to whoever the body names
+	req.SuggestedBy = nil
+	if userID := c.GetString("user_id"); userID != "" {
+		req.SuggestedBy = &userID
+	}
+
 	err := h.service.SubmitTemplateSuggestion(c.Request.Context(), &req)
 	if err != nil {
 		if apiErr, ok := err.(*errors.APIError); ok {
@@ -383,12 +591,8 @@ func (h *FeedbackHandler) PostTemplateSuggestions(c *gin.Context) {
 
 // GetImpact handles GET /api/feedback/impact
 func (h *FeedbackHandler) GetImpact(c *gin.Context) {
-	_, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Invalid token",
-			"code":  "AUTH_TOKEN_INVALID",
-		})
+	viewer, ok := viewerFrom(c)
+	if !ok {
 		return
 	}
 
@@ -410,7 +614,7 @@ func (h *FeedbackHandler) GetImpact(c *gin.Context) {
 		}
 	}
 
-	impact, err := h.service.GetImpact(c.Request.Context(), targetUserID, from, to)
+	impact, err := h.service.GetImpact(c.Request.Context(), viewer, targetUserID, from, to)
 	if err != nil {
 		if apiErr, ok := err.(*errors.APIError); ok {
 			c.JSON(apiErr.HTTPStatus, gin.H{
@@ -431,12 +635,8 @@ func (h *FeedbackHandler) GetImpact(c *gin.Context) {
 
 // GetBookmarks handles GET /api/feedback/bookmarks
 func (h *FeedbackHandler) GetBookmarks(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Invalid token",
-			"code":  "AUTH_TOKEN_INVALID",
-		})
+	viewer, ok := viewerFrom(c)
+	if !ok {
 		return
 	}
 
@@ -452,7 +652,7 @@ func (h *FeedbackHandler) GetBookmarks(c *gin.Context) {
 		offsetInt = o
 	}
 
-	bookmarks, count, err := h.service.GetBookmarks(c.Request.Context(), userID.(string), limitInt, offsetInt)
+	bookmarks, count, err := h.service.GetBookmarks(c.Request.Context(), viewer, limitInt, offsetInt)
 	if err != nil {
 		if apiErr, ok := err.(*errors.APIError); ok {
 			c.JSON(apiErr.HTTPStatus, gin.H{
@@ -476,12 +676,8 @@ func (h *FeedbackHandler) GetBookmarks(c *gin.Context) {
 
 // AddBookmark handles POST /api/feedback/bookmarks/:feedback_id
 func (h *FeedbackHandler) AddBookmark(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Invalid token",
-			"code":  "AUTH_TOKEN_INVALID",
-		})
+	viewer, ok := viewerFrom(c)
+	if !ok {
 		return
 	}
 
@@ -494,7 +690,7 @@ func (h *FeedbackHandler) AddBookmark(c *gin.Context) {
 		return
 	}
 
-	err := h.service.AddBookmark(c.Request.Context(), userID.(string), feedbackID)
+	err := h.service.AddBookmark(c.Request.Context(), viewer, feedbackID)
 	if err != nil {
 		if apiErr, ok := err.(*errors.APIError); ok {
 			c.JSON(apiErr.HTTPStatus, gin.H{
@@ -558,12 +754,8 @@ func (h *FeedbackHandler) RemoveBookmark(c *gin.Context) {
 
 // ExportFeedback handles GET /api/feedback/export
 func (h *FeedbackHandler) ExportFeedback(c *gin.Context) {
-	_, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Invalid token",
-			"code":  "AUTH_TOKEN_INVALID",
-		})
+	viewer, ok := viewerFrom(c)
+	if !ok {
 		return
 	}
 
@@ -600,7 +792,7 @@ func (h *FeedbackHandler) ExportFeedback(c *gin.Context) {
 		}
 	}
 
-	exportResponse, err := h.service.ExportFeedback(c.Request.Context(), filters, format)
+	exportResponse, err := h.service.ExportFeedback(c.Request.Context(), viewer, filters, format)
 	if err != nil {
 		if apiErr, ok := err.(*errors.APIError); ok {
 			c.JSON(apiErr.HTTPStatus, gin.H{
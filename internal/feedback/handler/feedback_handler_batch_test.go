@@ -23,28 +23,44 @@ type MockFeedbackServiceForBatch struct {
 	mock.Mock
 }
 
-func (m *MockFeedbackServiceForBatch) GetFeed(ctx context.Context, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockFeedbackServiceForBatch) GetFeed(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest) ([]*fbModel.FeedbackItem, *feedback.PageInfo, error) {
+	args := m.Called(ctx, viewer, page)
 	if args.Get(0) == nil {
-		return nil, 0, args.Error(2)
+		return nil, nil, args.Error(2)
 	}
-	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(*feedback.PageInfo), args.Error(2)
 }
 
-func (m *MockFeedbackServiceForBatch) GetFeedbackByID(ctx context.Context, feedbackID string) (*fbModel.FeedbackItem, error) {
-	args := m.Called(ctx, feedbackID)
+func (m *MockFeedbackServiceForBatch) GetFeedbackByID(ctx context.Context, viewer feedback.Viewer, feedbackID string) (*fbModel.FeedbackItem, error) {
+	args := m.Called(ctx, viewer, feedbackID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*fbModel.FeedbackItem), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForBatch) GetComments(ctx context.Context, feedbackID string, limit, offset int) ([]*fbModel.FeedbackComment, int, error) {
-	args := m.Called(ctx, feedbackID, limit, offset)
+func (m *MockFeedbackServiceForBatch) GetComments(ctx context.Context, viewer feedback.Viewer, feedbackID string, page feedback.PageRequest) ([]*fbModel.FeedbackComment, *feedback.PageInfo, error) {
+	args := m.Called(ctx, viewer, feedbackID, page)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.FeedbackComment), args.Get(1).(*feedback.PageInfo), args.Error(2)
+}
+
+func (m *MockFeedbackServiceForBatch) GetReceivedFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+	args := m.Called(ctx, viewer, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
+}
+
+func (m *MockFeedbackServiceForBatch) GetGivenFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+	args := m.Called(ctx, viewer, limit, offset)
 	if args.Get(0) == nil {
-		return nil, 0, args.Error(3)
+		return nil, 0, args.Error(2)
 	}
-	return args.Get(0).([]*fbModel.FeedbackComment), args.Get(1).(int), args.Error(3)
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
 }
 
 func (m *MockFeedbackServiceForBatch) CreateFeedback(ctx context.Context, userID string, req *service.CreateFeedbackRequest) (*fbModel.FeedbackItem, error) {
@@ -55,21 +71,21 @@ func (m *MockFeedbackServiceForBatch) CreateFeedback(ctx context.Context, userID
 	return args.Get(0).(*fbModel.FeedbackItem), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForBatch) CreateComment(ctx context.Context, userID, feedbackID string, req *service.CreateCommentRequest) (*fbModel.FeedbackComment, error) {
-	args := m.Called(ctx, userID, feedbackID, req)
+func (m *MockFeedbackServiceForBatch) CreateComment(ctx context.Context, viewer feedback.Viewer, feedbackID string, req *service.CreateCommentRequest) (*fbModel.FeedbackComment, error) {
+	args := m.Called(ctx, viewer, feedbackID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*fbModel.FeedbackComment), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForBatch) AddReaction(ctx context.Context, userID, feedbackID string, reactionType string) error {
-	args := m.Called(ctx, userID, feedbackID, reactionType)
+func (m *MockFeedbackServiceForBatch) AddReaction(ctx context.Context, viewer feedback.Viewer, feedbackID string, reactionType string) error {
+	args := m.Called(ctx, viewer, feedbackID, reactionType)
 	return args.Error(0)
 }
 
-func (m *MockFeedbackServiceForBatch) RemoveReaction(ctx context.Context, userID, feedbackID string, reactionType string) error {
-	args := m.Called(ctx, userID, feedbackID, reactionType)
+func (m *MockFeedbackServiceForBatch) RemoveReaction(ctx context.Context, viewer feedback.Viewer, feedbackID string, reactionType string) error {
+	args := m.Called(ctx, viewer, feedbackID, reactionType)
 	return args.Error(0)
 }
 
@@ -86,8 +102,8 @@ func (m *MockFeedbackServiceForBatch) SubmitTemplateSuggestion(ctx context.Conte
 	return args.Error(0)
 }
 
-func (m *MockFeedbackServiceForBatch) GetImpact(ctx context.Context, userID *string, from, to *time.Time) (*fbModel.FeedbackImpact, error) {
-	args := m.Called(ctx, userID, from, to)
+func (m *MockFeedbackServiceForBatch) GetImpact(ctx context.Context, viewer feedback.Viewer, userID *string, from, to *time.Time) (*fbModel.FeedbackImpact, error) {
+	args := m.Called(ctx, viewer, userID, from, to)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -102,24 +118,32 @@ func (m *MockFeedbackServiceForBatch) CreateBatchFeedback(ctx context.Context, u
 	return args.Get(0).(*feedback.BatchFeedbackResponse), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForBatch) GetFeedWithFilters(ctx context.Context, limit, offset int, filters *feedback.FeedFilters) ([]*fbModel.FeedbackItem, int, error) {
-	args := m.Called(ctx, limit, offset, filters)
+func (m *MockFeedbackServiceForBatch) GetFeedWithFilters(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest, filters *feedback.FeedFilters) ([]*fbModel.FeedbackItem, *feedback.PageInfo, error) {
+	args := m.Called(ctx, viewer, page, filters)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(*feedback.PageInfo), args.Error(2)
+}
+
+func (m *MockFeedbackServiceForBatch) SearchFeedback(ctx context.Context, viewer feedback.Viewer, req *feedback.SearchRequest) ([]*fbModel.FeedbackSearchResult, int, error) {
+	args := m.Called(ctx, viewer, req)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
-	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
+	return args.Get(0).([]*fbModel.FeedbackSearchResult), args.Get(1).(int), args.Error(2)
 }
 
-func (m *MockFeedbackServiceForBatch) GetBookmarks(ctx context.Context, userID string, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
-	args := m.Called(ctx, userID, limit, offset)
+func (m *MockFeedbackServiceForBatch) GetBookmarks(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+	args := m.Called(ctx, viewer, limit, offset)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
 	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
 }
 
-func (m *MockFeedbackServiceForBatch) AddBookmark(ctx context.Context, userID, feedbackID string) error {
-	args := m.Called(ctx, userID, feedbackID)
+func (m *MockFeedbackServiceForBatch) AddBookmark(ctx context.Context, viewer feedback.Viewer, feedbackID string) error {
+	args := m.Called(ctx, viewer, feedbackID)
 	return args.Error(0)
 }
 
@@ -128,8 +152,8 @@ func (m *MockFeedbackServiceForBatch) RemoveBookmark(ctx context.Context, userID
 	return args.Error(0)
 }
 
-func (m *MockFeedbackServiceForBatch) ExportFeedback(ctx context.Context, filters *feedback.FeedFilters, format string) (*feedback.ExportResponse, error) {
-	args := m.Called(ctx, filters, format)
+func (m *MockFeedbackServiceForBatch) ExportFeedback(ctx context.Context, viewer feedback.Viewer, filters *feedback.FeedFilters, format string) (*feedback.ExportResponse, error) {
+	args := m.Called(ctx, viewer, filters, format)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
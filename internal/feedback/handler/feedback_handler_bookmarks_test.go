@@ -24,28 +24,44 @@ type MockFeedbackServiceForBookmarks struct {
 	mock.Mock
 }
 
-func (m *MockFeedbackServiceForBookmarks) GetFeed(ctx context.Context, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockFeedbackServiceForBookmarks) GetFeed(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest) ([]*fbModel.FeedbackItem, *feedback.PageInfo, error) {
+	args := m.Called(ctx, viewer, page)
 	if args.Get(0) == nil {
-		return nil, 0, args.Error(2)
+		return nil, nil, args.Error(2)
 	}
-	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(*feedback.PageInfo), args.Error(2)
 }
 
-func (m *MockFeedbackServiceForBookmarks) GetFeedbackByID(ctx context.Context, feedbackID string) (*fbModel.FeedbackItem, error) {
-	args := m.Called(ctx, feedbackID)
+func (m *MockFeedbackServiceForBookmarks) GetFeedbackByID(ctx context.Context, viewer feedback.Viewer, feedbackID string) (*fbModel.FeedbackItem, error) {
+	args := m.Called(ctx, viewer, feedbackID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*fbModel.FeedbackItem), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForBookmarks) GetComments(ctx context.Context, feedbackID string, limit, offset int) ([]*fbModel.FeedbackComment, int, error) {
-	args := m.Called(ctx, feedbackID, limit, offset)
+func (m *MockFeedbackServiceForBookmarks) GetComments(ctx context.Context, viewer feedback.Viewer, feedbackID string, page feedback.PageRequest) ([]*fbModel.FeedbackComment, *feedback.PageInfo, error) {
+	args := m.Called(ctx, viewer, feedbackID, page)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.FeedbackComment), args.Get(1).(*feedback.PageInfo), args.Error(2)
+}
+
+func (m *MockFeedbackServiceForBookmarks) GetReceivedFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+	args := m.Called(ctx, viewer, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
+}
+
+func (m *MockFeedbackServiceForBookmarks) GetGivenFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+	args := m.Called(ctx, viewer, limit, offset)
 	if args.Get(0) == nil {
-		return nil, 0, args.Error(3)
+		return nil, 0, args.Error(2)
 	}
-	return args.Get(0).([]*fbModel.FeedbackComment), args.Get(1).(int), args.Error(3)
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
 }
 
 func (m *MockFeedbackServiceForBookmarks) CreateFeedback(ctx context.Context, userID string, req *service.CreateFeedbackRequest) (*fbModel.FeedbackItem, error) {
@@ -56,21 +72,21 @@ func (m *MockFeedbackServiceForBookmarks) CreateFeedback(ctx context.Context, us
 	return args.Get(0).(*fbModel.FeedbackItem), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForBookmarks) CreateComment(ctx context.Context, userID, feedbackID string, req *service.CreateCommentRequest) (*fbModel.FeedbackComment, error) {
-	args := m.Called(ctx, userID, feedbackID, req)
+func (m *MockFeedbackServiceForBookmarks) CreateComment(ctx context.Context, viewer feedback.Viewer, feedbackID string, req *service.CreateCommentRequest) (*fbModel.FeedbackComment, error) {
+	args := m.Called(ctx, viewer, feedbackID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*fbModel.FeedbackComment), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForBookmarks) AddReaction(ctx context.Context, userID, feedbackID string, reactionType string) error {
-	args := m.Called(ctx, userID, feedbackID, reactionType)
+func (m *MockFeedbackServiceForBookmarks) AddReaction(ctx context.Context, viewer feedback.Viewer, feedbackID string, reactionType string) error {
+	args := m.Called(ctx, viewer, feedbackID, reactionType)
 	return args.Error(0)
 }
 
-func (m *MockFeedbackServiceForBookmarks) RemoveReaction(ctx context.Context, userID, feedbackID string, reactionType string) error {
-	args := m.Called(ctx, userID, feedbackID, reactionType)
+func (m *MockFeedbackServiceForBookmarks) RemoveReaction(ctx context.Context, viewer feedback.Viewer, feedbackID string, reactionType string) error {
+	args := m.Called(ctx, viewer, feedbackID, reactionType)
 	return args.Error(0)
 }
 
@@ -87,8 +103,8 @@ func (m *MockFeedbackServiceForBookmarks) SubmitTemplateSuggestion(ctx context.C
 	return args.Error(0)
 }
 
-func (m *MockFeedbackServiceForBookmarks) GetImpact(ctx context.Context, userID *string, from, to *time.Time) (*fbModel.FeedbackImpact, error) {
-	args := m.Called(ctx, userID, from, to)
+func (m *MockFeedbackServiceForBookmarks) GetImpact(ctx context.Context, viewer feedback.Viewer, userID *string, from, to *time.Time) (*fbModel.FeedbackImpact, error) {
+	args := m.Called(ctx, viewer, userID, from, to)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -103,24 +119,32 @@ func (m *MockFeedbackServiceForBookmarks) CreateBatchFeedback(ctx context.Contex
 	return args.Get(0).(*feedback.BatchFeedbackResponse), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForBookmarks) GetFeedWithFilters(ctx context.Context, limit, offset int, filters *feedback.FeedFilters) ([]*fbModel.FeedbackItem, int, error) {
-	args := m.Called(ctx, limit, offset, filters)
+func (m *MockFeedbackServiceForBookmarks) GetFeedWithFilters(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest, filters *feedback.FeedFilters) ([]*fbModel.FeedbackItem, *feedback.PageInfo, error) {
+	args := m.Called(ctx, viewer, page, filters)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(*feedback.PageInfo), args.Error(2)
+}
+
+func (m *MockFeedbackServiceForBookmarks) SearchFeedback(ctx context.Context, viewer feedback.Viewer, req *feedback.SearchRequest) ([]*fbModel.FeedbackSearchResult, int, error) {
+	args := m.Called(ctx, viewer, req)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
-	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
+	return args.Get(0).([]*fbModel.FeedbackSearchResult), args.Get(1).(int), args.Error(2)
 }
 
-func (m *MockFeedbackServiceForBookmarks) GetBookmarks(ctx context.Context, userID string, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
-	args := m.Called(ctx, userID, limit, offset)
+func (m *MockFeedbackServiceForBookmarks) GetBookmarks(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+	args := m.Called(ctx, viewer, limit, offset)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
 	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
 }
 
-func (m *MockFeedbackServiceForBookmarks) AddBookmark(ctx context.Context, userID, feedbackID string) error {
-	args := m.Called(ctx, userID, feedbackID)
+func (m *MockFeedbackServiceForBookmarks) AddBookmark(ctx context.Context, viewer feedback.Viewer, feedbackID string) error {
+	args := m.Called(ctx, viewer, feedbackID)
 	return args.Error(0)
 }
 
@@ -129,8 +153,8 @@ func (m *MockFeedbackServiceForBookmarks) RemoveBookmark(ctx context.Context, us
 	return args.Error(0)
 }
 
-func (m *MockFeedbackServiceForBookmarks) ExportFeedback(ctx context.Context, filters *feedback.FeedFilters, format string) (*feedback.ExportResponse, error) {
-	args := m.Called(ctx, filters, format)
+func (m *MockFeedbackServiceForBookmarks) ExportFeedback(ctx context.Context, viewer feedback.Viewer, filters *feedback.FeedFilters, format string) (*feedback.ExportResponse, error) {
+	args := m.Called(ctx, viewer, filters, format)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -204,7 +228,7 @@ func TestGetBookmarks_Success(t *testing.T) {
 		},
 	}
 
-	mockService.On("GetBookmarks", mock.Anything, "user-123", 20, 0).Return(bookmarks, 1, nil)
+	mockService.On("GetBookmarks", mock.Anything, feedback.Viewer{UserID: "user-123"}, 20, 0).Return(bookmarks, 1, nil)
 
 	router := setupFeedbackRouterForBookmarks(handler, tokenGen)
 	req, _ := http.NewRequest("GET", "/api/v1/feedback/bookmarks", nil)
@@ -24,28 +24,44 @@ type MockFeedbackServiceForExport struct {
 	mock.Mock
 }
 
-func (m *MockFeedbackServiceForExport) GetFeed(ctx context.Context, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockFeedbackServiceForExport) GetFeed(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest) ([]*fbModel.FeedbackItem, *feedback.PageInfo, error) {
+	args := m.Called(ctx, viewer, page)
 	if args.Get(0) == nil {
-		return nil, 0, args.Error(2)
+		return nil, nil, args.Error(2)
 	}
-	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(*feedback.PageInfo), args.Error(2)
 }
 
-func (m *MockFeedbackServiceForExport) GetFeedbackByID(ctx context.Context, feedbackID string) (*fbModel.FeedbackItem, error) {
-	args := m.Called(ctx, feedbackID)
+func (m *MockFeedbackServiceForExport) GetFeedbackByID(ctx context.Context, viewer feedback.Viewer, feedbackID string) (*fbModel.FeedbackItem, error) {
+	args := m.Called(ctx, viewer, feedbackID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*fbModel.FeedbackItem), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForExport) GetComments(ctx context.Context, feedbackID string, limit, offset int) ([]*fbModel.FeedbackComment, int, error) {
-	args := m.Called(ctx, feedbackID, limit, offset)
+func (m *MockFeedbackServiceForExport) GetComments(ctx context.Context, viewer feedback.Viewer, feedbackID string, page feedback.PageRequest) ([]*fbModel.FeedbackComment, *feedback.PageInfo, error) {
+	args := m.Called(ctx, viewer, feedbackID, page)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.FeedbackComment), args.Get(1).(*feedback.PageInfo), args.Error(2)
+}
+
+func (m *MockFeedbackServiceForExport) GetReceivedFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+	args := m.Called(ctx, viewer, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
+}
+
+func (m *MockFeedbackServiceForExport) GetGivenFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+	args := m.Called(ctx, viewer, limit, offset)
 	if args.Get(0) == nil {
-		return nil, 0, args.Error(3)
+		return nil, 0, args.Error(2)
 	}
-	return args.Get(0).([]*fbModel.FeedbackComment), args.Get(1).(int), args.Error(3)
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
 }
 
 func (m *MockFeedbackServiceForExport) CreateFeedback(ctx context.Context, userID string, req *service.CreateFeedbackRequest) (*fbModel.FeedbackItem, error) {
@@ -56,21 +72,21 @@ func (m *MockFeedbackServiceForExport) CreateFeedback(ctx context.Context, userI
 	return args.Get(0).(*fbModel.FeedbackItem), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForExport) CreateComment(ctx context.Context, userID, feedbackID string, req *service.CreateCommentRequest) (*fbModel.FeedbackComment, error) {
-	args := m.Called(ctx, userID, feedbackID, req)
+func (m *MockFeedbackServiceForExport) CreateComment(ctx context.Context, viewer feedback.Viewer, feedbackID string, req *service.CreateCommentRequest) (*fbModel.FeedbackComment, error) {
+	args := m.Called(ctx, viewer, feedbackID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*fbModel.FeedbackComment), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForExport) AddReaction(ctx context.Context, userID, feedbackID string, reactionType string) error {
-	args := m.Called(ctx, userID, feedbackID, reactionType)
+func (m *MockFeedbackServiceForExport) AddReaction(ctx context.Context, viewer feedback.Viewer, feedbackID string, reactionType string) error {
+	args := m.Called(ctx, viewer, feedbackID, reactionType)
 	return args.Error(0)
 }
 
-func (m *MockFeedbackServiceForExport) RemoveReaction(ctx context.Context, userID, feedbackID string, reactionType string) error {
-	args := m.Called(ctx, userID, feedbackID, reactionType)
+func (m *MockFeedbackServiceForExport) RemoveReaction(ctx context.Context, viewer feedback.Viewer, feedbackID string, reactionType string) error {
+	args := m.Called(ctx, viewer, feedbackID, reactionType)
 	return args.Error(0)
 }
 
@@ -121,8 +137,8 @@ func (m *MockFeedbackServiceForExport) GetFeedbackAnalytics(ctx context.Context,
 	return args.Get(0).(*fbModel.FeedbackAnalytics), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForExport) GetImpact(ctx context.Context, userID *string, from, to *time.Time) (*fbModel.FeedbackImpact, error) {
-	args := m.Called(ctx, userID, from, to)
+func (m *MockFeedbackServiceForExport) GetImpact(ctx context.Context, viewer feedback.Viewer, userID *string, from, to *time.Time) (*fbModel.FeedbackImpact, error) {
+	args := m.Called(ctx, viewer, userID, from, to)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -137,24 +153,32 @@ func (m *MockFeedbackServiceForExport) CreateBatchFeedback(ctx context.Context,
 	return args.Get(0).(*feedback.BatchFeedbackResponse), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForExport) GetFeedWithFilters(ctx context.Context, limit, offset int, filters *feedback.FeedFilters) ([]*fbModel.FeedbackItem, int, error) {
-	args := m.Called(ctx, limit, offset, filters)
+func (m *MockFeedbackServiceForExport) GetFeedWithFilters(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest, filters *feedback.FeedFilters) ([]*fbModel.FeedbackItem, *feedback.PageInfo, error) {
+	args := m.Called(ctx, viewer, page, filters)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(*feedback.PageInfo), args.Error(2)
+}
+
+func (m *MockFeedbackServiceForExport) SearchFeedback(ctx context.Context, viewer feedback.Viewer, req *feedback.SearchRequest) ([]*fbModel.FeedbackSearchResult, int, error) {
+	args := m.Called(ctx, viewer, req)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
-	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
+	return args.Get(0).([]*fbModel.FeedbackSearchResult), args.Get(1).(int), args.Error(2)
 }
 
-func (m *MockFeedbackServiceForExport) GetBookmarks(ctx context.Context, userID string, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
-	args := m.Called(ctx, userID, limit, offset)
+func (m *MockFeedbackServiceForExport) GetBookmarks(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+	args := m.Called(ctx, viewer, limit, offset)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
 	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
 }
 
-func (m *MockFeedbackServiceForExport) AddBookmark(ctx context.Context, userID, feedbackID string) error {
-	args := m.Called(ctx, userID, feedbackID)
+func (m *MockFeedbackServiceForExport) AddBookmark(ctx context.Context, viewer feedback.Viewer, feedbackID string) error {
+	args := m.Called(ctx, viewer, feedbackID)
 	return args.Error(0)
 }
 
@@ -163,8 +187,8 @@ func (m *MockFeedbackServiceForExport) RemoveBookmark(ctx context.Context, userI
 	return args.Error(0)
 }
 
-func (m *MockFeedbackServiceForExport) ExportFeedback(ctx context.Context, filters *feedback.FeedFilters, format string) (*feedback.ExportResponse, error) {
-	args := m.Called(ctx, filters, format)
+func (m *MockFeedbackServiceForExport) ExportFeedback(ctx context.Context, viewer feedback.Viewer, filters *feedback.FeedFilters, format string) (*feedback.ExportResponse, error) {
+	args := m.Called(ctx, viewer, filters, format)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -196,7 +220,7 @@ func TestExportFeedback_JSONFormat_Success(t *testing.T) {
 		Count:       1,
 	}
 
-	mockService.On("ExportFeedback", mock.Anything, filters, "json").Return(exportResponse, nil)
+	mockService.On("ExportFeedback", mock.Anything, feedback.Viewer{UserID: "user-123"}, filters, "json").Return(exportResponse, nil)
 
 	router := setupFeedbackRouterForExport(handler, tokenGen)
 	req, _ := http.NewRequest("GET", "/api/v1/feedback/export?reviewer_type=org&format=json", nil)
@@ -232,7 +256,7 @@ func TestExportFeedback_CSVFormat_Success(t *testing.T) {
 		Count:       1,
 	}
 
-	mockService.On("ExportFeedback", mock.Anything, filters, "csv").Return(exportResponse, nil)
+	mockService.On("ExportFeedback", mock.Anything, feedback.Viewer{UserID: "user-123"}, filters, "csv").Return(exportResponse, nil)
 
 	router := setupFeedbackRouterForExport(handler, tokenGen)
 	req, _ := http.NewRequest("GET", "/api/v1/feedback/export?format=csv", nil)
@@ -267,7 +291,7 @@ func TestExportFeedback_WithAllFilters(t *testing.T) {
 		Count:       1,
 	}
 
-	mockService.On("ExportFeedback", mock.Anything, filters, "json").Return(exportResponse, nil)
+	mockService.On("ExportFeedback", mock.Anything, feedback.Viewer{UserID: "user-123"}, filters, "json").Return(exportResponse, nil)
 
 	router := setupFeedbackRouterForExport(handler, tokenGen)
 	req, _ := http.NewRequest("GET", "/api/v1/feedback/export?reviewer_type=org&context=project&verification=verified&tags=leadership,teamwork&format=json", nil)
@@ -294,7 +318,7 @@ func TestExportFeedback_DefaultFormat(t *testing.T) {
 		Count:       1,
 	}
 
-	mockService.On("ExportFeedback", mock.Anything, filters, "json").Return(exportResponse, nil)
+	mockService.On("ExportFeedback", mock.Anything, feedback.Viewer{UserID: "user-123"}, filters, "json").Return(exportResponse, nil)
 
 	router := setupFeedbackRouterForExport(handler, tokenGen)
 	req, _ := http.NewRequest("GET", "/api/v1/feedback/export", nil)
@@ -335,7 +359,7 @@ func TestExportFeedback_ServiceError(t *testing.T) {
 	handler := NewFeedbackHandler(mockService)
 	tokenGen := jwt.NewTokenGenerator("test-secret", "test-refresh-secret", 15, 336)
 
-	mockService.On("ExportFeedback", mock.Anything, mock.Anything, "json").Return(nil, assert.AnError)
+	mockService.On("ExportFeedback", mock.Anything, feedback.Viewer{UserID: "user-123"}, mock.Anything, "json").Return(nil, assert.AnError)
 
 	router := setupFeedbackRouterForExport(handler, tokenGen)
 	req, _ := http.NewRequest("GET", "/api/v1/feedback/export", nil)
@@ -376,7 +400,7 @@ func TestExportFeedback_EmptyResult(t *testing.T) {
 		Count:       0,
 	}
 
-	mockService.On("ExportFeedback", mock.Anything, filters, "json").Return(exportResponse, nil)
+	mockService.On("ExportFeedback", mock.Anything, feedback.Viewer{UserID: "user-123"}, filters, "json").Return(exportResponse, nil)
 
 	router := setupFeedbackRouterForExport(handler, tokenGen)
 	req, _ := http.NewRequest("GET", "/api/v1/feedback/export", nil)
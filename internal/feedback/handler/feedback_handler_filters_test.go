@@ -23,28 +23,44 @@ type MockFeedbackServiceForFilters struct {
 	mock.Mock
 }
 
-func (m *MockFeedbackServiceForFilters) GetFeed(ctx context.Context, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockFeedbackServiceForFilters) GetFeed(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest) ([]*fbModel.FeedbackItem, *feedback.PageInfo, error) {
+	args := m.Called(ctx, viewer, page)
 	if args.Get(0) == nil {
-		return nil, 0, args.Error(2)
+		return nil, nil, args.Error(2)
 	}
-	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(*feedback.PageInfo), args.Error(2)
 }
 
-func (m *MockFeedbackServiceForFilters) GetFeedbackByID(ctx context.Context, feedbackID string) (*fbModel.FeedbackItem, error) {
-	args := m.Called(ctx, feedbackID)
+func (m *MockFeedbackServiceForFilters) GetFeedbackByID(ctx context.Context, viewer feedback.Viewer, feedbackID string) (*fbModel.FeedbackItem, error) {
+	args := m.Called(ctx, viewer, feedbackID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*fbModel.FeedbackItem), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForFilters) GetComments(ctx context.Context, feedbackID string, limit, offset int) ([]*fbModel.FeedbackComment, int, error) {
-	args := m.Called(ctx, feedbackID, limit, offset)
+func (m *MockFeedbackServiceForFilters) GetComments(ctx context.Context, viewer feedback.Viewer, feedbackID string, page feedback.PageRequest) ([]*fbModel.FeedbackComment, *feedback.PageInfo, error) {
+	args := m.Called(ctx, viewer, feedbackID, page)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.FeedbackComment), args.Get(1).(*feedback.PageInfo), args.Error(2)
+}
+
+func (m *MockFeedbackServiceForFilters) GetReceivedFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+	args := m.Called(ctx, viewer, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
+}
+
+func (m *MockFeedbackServiceForFilters) GetGivenFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+	args := m.Called(ctx, viewer, limit, offset)
 	if args.Get(0) == nil {
-		return nil, 0, args.Error(3)
+		return nil, 0, args.Error(2)
 	}
-	return args.Get(0).([]*fbModel.FeedbackComment), args.Get(1).(int), args.Error(3)
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
 }
 
 func (m *MockFeedbackServiceForFilters) CreateFeedback(ctx context.Context, userID string, req *service.CreateFeedbackRequest) (*fbModel.FeedbackItem, error) {
@@ -55,21 +71,21 @@ func (m *MockFeedbackServiceForFilters) CreateFeedback(ctx context.Context, user
 	return args.Get(0).(*fbModel.FeedbackItem), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForFilters) CreateComment(ctx context.Context, userID, feedbackID string, req *service.CreateCommentRequest) (*fbModel.FeedbackComment, error) {
-	args := m.Called(ctx, userID, feedbackID, req)
+func (m *MockFeedbackServiceForFilters) CreateComment(ctx context.Context, viewer feedback.Viewer, feedbackID string, req *service.CreateCommentRequest) (*fbModel.FeedbackComment, error) {
+	args := m.Called(ctx, viewer, feedbackID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*fbModel.FeedbackComment), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForFilters) AddReaction(ctx context.Context, userID, feedbackID string, reactionType string) error {
-	args := m.Called(ctx, userID, feedbackID, reactionType)
+func (m *MockFeedbackServiceForFilters) AddReaction(ctx context.Context, viewer feedback.Viewer, feedbackID string, reactionType string) error {
+	args := m.Called(ctx, viewer, feedbackID, reactionType)
 	return args.Error(0)
 }
 
-func (m *MockFeedbackServiceForFilters) RemoveReaction(ctx context.Context, userID, feedbackID string, reactionType string) error {
-	args := m.Called(ctx, userID, feedbackID, reactionType)
+func (m *MockFeedbackServiceForFilters) RemoveReaction(ctx context.Context, viewer feedback.Viewer, feedbackID string, reactionType string) error {
+	args := m.Called(ctx, viewer, feedbackID, reactionType)
 	return args.Error(0)
 }
 
@@ -86,8 +102,8 @@ func (m *MockFeedbackServiceForFilters) SubmitTemplateSuggestion(ctx context.Con
 	return args.Error(0)
 }
 
-func (m *MockFeedbackServiceForFilters) GetImpact(ctx context.Context, userID *string, from, to *time.Time) (*fbModel.FeedbackImpact, error) {
-	args := m.Called(ctx, userID, from, to)
+func (m *MockFeedbackServiceForFilters) GetImpact(ctx context.Context, viewer feedback.Viewer, userID *string, from, to *time.Time) (*fbModel.FeedbackImpact, error) {
+	args := m.Called(ctx, viewer, userID, from, to)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -102,24 +118,32 @@ func (m *MockFeedbackServiceForFilters) CreateBatchFeedback(ctx context.Context,
 	return args.Get(0).(*feedback.BatchFeedbackResponse), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForFilters) GetFeedWithFilters(ctx context.Context, limit, offset int, filters *feedback.FeedFilters) ([]*fbModel.FeedbackItem, int, error) {
-	args := m.Called(ctx, limit, offset, filters)
+func (m *MockFeedbackServiceForFilters) GetFeedWithFilters(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest, filters *feedback.FeedFilters) ([]*fbModel.FeedbackItem, *feedback.PageInfo, error) {
+	args := m.Called(ctx, viewer, page, filters)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(*feedback.PageInfo), args.Error(2)
+}
+
+func (m *MockFeedbackServiceForFilters) SearchFeedback(ctx context.Context, viewer feedback.Viewer, req *feedback.SearchRequest) ([]*fbModel.FeedbackSearchResult, int, error) {
+	args := m.Called(ctx, viewer, req)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
-	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
+	return args.Get(0).([]*fbModel.FeedbackSearchResult), args.Get(1).(int), args.Error(2)
 }
 
-func (m *MockFeedbackServiceForFilters) GetBookmarks(ctx context.Context, userID string, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
-	args := m.Called(ctx, userID, limit, offset)
+func (m *MockFeedbackServiceForFilters) GetBookmarks(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+	args := m.Called(ctx, viewer, limit, offset)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
 	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
 }
 
-func (m *MockFeedbackServiceForFilters) AddBookmark(ctx context.Context, userID, feedbackID string) error {
-	args := m.Called(ctx, userID, feedbackID)
+func (m *MockFeedbackServiceForFilters) AddBookmark(ctx context.Context, viewer feedback.Viewer, feedbackID string) error {
+	args := m.Called(ctx, viewer, feedbackID)
 	return args.Error(0)
 }
 
@@ -128,8 +152,8 @@ func (m *MockFeedbackServiceForFilters) RemoveBookmark(ctx context.Context, user
 	return args.Error(0)
 }
 
-func (m *MockFeedbackServiceForFilters) ExportFeedback(ctx context.Context, filters *feedback.FeedFilters, format string) (*feedback.ExportResponse, error) {
-	args := m.Called(ctx, filters, format)
+func (m *MockFeedbackServiceForFilters) ExportFeedback(ctx context.Context, viewer feedback.Viewer, filters *feedback.FeedFilters, format string) (*feedback.ExportResponse, error) {
+	args := m.Called(ctx, viewer, filters, format)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -186,7 +210,7 @@ func TestGetFeedWithFilters_Success(t *testing.T) {
 	tokenGen := jwt.NewTokenGenerator("test-secret", "test-refresh-secret", 15*time.Second, 336*time.Hour)
 
 	items := []*fbModel.FeedbackItem{}
-	mockService.On("GetFeed", mock.Anything, 20, 0).Return(items, 0, nil)
+	mockService.On("GetFeed", mock.Anything, feedback.Viewer{UserID: "user-123"}, feedback.PageRequest{Limit: 20}).Return(items, &feedback.PageInfo{}, nil)
 
 	router := setupFeedbackRouterForFilters(handler, tokenGen)
 	req := httptest.NewRequest("GET", "/api/v1/feedback/feed", nil)
@@ -24,28 +24,44 @@ type MockFeedbackServiceForImpact struct {
 	mock.Mock
 }
 
-func (m *MockFeedbackServiceForImpact) GetFeed(ctx context.Context, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockFeedbackServiceForImpact) GetFeed(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest) ([]*fbModel.FeedbackItem, *feedback.PageInfo, error) {
+	args := m.Called(ctx, viewer, page)
 	if args.Get(0) == nil {
-		return nil, 0, args.Error(2)
+		return nil, nil, args.Error(2)
 	}
-	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(*feedback.PageInfo), args.Error(2)
 }
 
-func (m *MockFeedbackServiceForImpact) GetFeedbackByID(ctx context.Context, feedbackID string) (*fbModel.FeedbackItem, error) {
-	args := m.Called(ctx, feedbackID)
+func (m *MockFeedbackServiceForImpact) GetFeedbackByID(ctx context.Context, viewer feedback.Viewer, feedbackID string) (*fbModel.FeedbackItem, error) {
+	args := m.Called(ctx, viewer, feedbackID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*fbModel.FeedbackItem), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForImpact) GetComments(ctx context.Context, feedbackID string, limit, offset int) ([]*fbModel.FeedbackComment, int, error) {
-	args := m.Called(ctx, feedbackID, limit, offset)
+func (m *MockFeedbackServiceForImpact) GetComments(ctx context.Context, viewer feedback.Viewer, feedbackID string, page feedback.PageRequest) ([]*fbModel.FeedbackComment, *feedback.PageInfo, error) {
+	args := m.Called(ctx, viewer, feedbackID, page)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.FeedbackComment), args.Get(1).(*feedback.PageInfo), args.Error(2)
+}
+
+func (m *MockFeedbackServiceForImpact) GetReceivedFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+	args := m.Called(ctx, viewer, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
+}
+
+func (m *MockFeedbackServiceForImpact) GetGivenFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+	args := m.Called(ctx, viewer, limit, offset)
 	if args.Get(0) == nil {
-		return nil, 0, args.Error(3)
+		return nil, 0, args.Error(2)
 	}
-	return args.Get(0).([]*fbModel.FeedbackComment), args.Get(1).(int), args.Error(3)
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
 }
 
 func (m *MockFeedbackServiceForImpact) CreateFeedback(ctx context.Context, userID string, req *service.CreateFeedbackRequest) (*fbModel.FeedbackItem, error) {
@@ -56,21 +72,21 @@ func (m *MockFeedbackServiceForImpact) CreateFeedback(ctx context.Context, userI
 	return args.Get(0).(*fbModel.FeedbackItem), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForImpact) CreateComment(ctx context.Context, userID, feedbackID string, req *service.CreateCommentRequest) (*fbModel.FeedbackComment, error) {
-	args := m.Called(ctx, userID, feedbackID, req)
+func (m *MockFeedbackServiceForImpact) CreateComment(ctx context.Context, viewer feedback.Viewer, feedbackID string, req *service.CreateCommentRequest) (*fbModel.FeedbackComment, error) {
+	args := m.Called(ctx, viewer, feedbackID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*fbModel.FeedbackComment), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForImpact) AddReaction(ctx context.Context, userID, feedbackID string, reactionType string) error {
-	args := m.Called(ctx, userID, feedbackID, reactionType)
+func (m *MockFeedbackServiceForImpact) AddReaction(ctx context.Context, viewer feedback.Viewer, feedbackID string, reactionType string) error {
+	args := m.Called(ctx, viewer, feedbackID, reactionType)
 	return args.Error(0)
 }
 
-func (m *MockFeedbackServiceForImpact) RemoveReaction(ctx context.Context, userID, feedbackID string, reactionType string) error {
-	args := m.Called(ctx, userID, feedbackID, reactionType)
+func (m *MockFeedbackServiceForImpact) RemoveReaction(ctx context.Context, viewer feedback.Viewer, feedbackID string, reactionType string) error {
+	args := m.Called(ctx, viewer, feedbackID, reactionType)
 	return args.Error(0)
 }
 
@@ -87,16 +103,16 @@ func (m *MockFeedbackServiceForImpact) SubmitTemplateSuggestion(ctx context.Cont
 	return args.Error(0)
 }
 
-func (m *MockFeedbackServiceForImpact) GetImpact(ctx context.Context, userID *string, from, to *time.Time) (*fbModel.FeedbackImpact, error) {
-	args := m.Called(ctx, userID, from, to)
+func (m *MockFeedbackServiceForImpact) GetImpact(ctx context.Context, viewer feedback.Viewer, userID *string, from, to *time.Time) (*fbModel.FeedbackImpact, error) {
+	args := m.Called(ctx, viewer, userID, from, to)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*fbModel.FeedbackImpact), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForImpact) AddBookmark(ctx context.Context, userID, feedbackID string) error {
-	args := m.Called(ctx, userID, feedbackID)
+func (m *MockFeedbackServiceForImpact) AddBookmark(ctx context.Context, viewer feedback.Viewer, feedbackID string) error {
+	args := m.Called(ctx, viewer, feedbackID)
 	return args.Error(0)
 }
 
@@ -126,24 +142,32 @@ func (m *MockFeedbackServiceForImpact) UpdateComment(ctx context.Context, userID
 	return args.Get(0).(*fbModel.FeedbackComment), args.Error(1)
 }
 
-func (m *MockFeedbackServiceForImpact) GetFeedWithFilters(ctx context.Context, limit, offset int, filters *feedback.FeedFilters) ([]*fbModel.FeedbackItem, int, error) {
-	args := m.Called(ctx, limit, offset, filters)
+func (m *MockFeedbackServiceForImpact) GetFeedWithFilters(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest, filters *feedback.FeedFilters) ([]*fbModel.FeedbackItem, *feedback.PageInfo, error) {
+	args := m.Called(ctx, viewer, page, filters)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(*feedback.PageInfo), args.Error(2)
+}
+
+func (m *MockFeedbackServiceForImpact) SearchFeedback(ctx context.Context, viewer feedback.Viewer, req *feedback.SearchRequest) ([]*fbModel.FeedbackSearchResult, int, error) {
+	args := m.Called(ctx, viewer, req)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
-	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
+	return args.Get(0).([]*fbModel.FeedbackSearchResult), args.Get(1).(int), args.Error(2)
 }
 
-func (m *MockFeedbackServiceForImpact) GetBookmarks(ctx context.Context, userID string, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
-	args := m.Called(ctx, userID, limit, offset)
+func (m *MockFeedbackServiceForImpact) GetBookmarks(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+	args := m.Called(ctx, viewer, limit, offset)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
 	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
 }
 
-func (m *MockFeedbackServiceForImpact) ExportFeedback(ctx context.Context, filters *feedback.FeedFilters, format string) (*feedback.ExportResponse, error) {
-	args := m.Called(ctx, filters, format)
+func (m *MockFeedbackServiceForImpact) ExportFeedback(ctx context.Context, viewer feedback.Viewer, filters *feedback.FeedFilters, format string) (*feedback.ExportResponse, error) {
+	args := m.Called(ctx, viewer, filters, format)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -204,7 +228,7 @@ func TestGetImpact_Success(t *testing.T) {
 		},
 	}
 
-	mockService.On("GetImpact", mock.Anything, (*string)(nil), (*time.Time)(nil), (*time.Time)(nil)).Return(impact, nil)
+	mockService.On("GetImpact", mock.Anything, feedback.Viewer{UserID: "user-123"}, (*string)(nil), (*time.Time)(nil), (*time.Time)(nil)).Return(impact, nil)
 
 	router := setupFeedbackRouterForImpact(handler, tokenGen)
 	req, _ := http.NewRequest("GET", "/api/v1/feedback/impact", nil)
@@ -232,7 +256,7 @@ func TestGetImpact_WithUserIDFilter(t *testing.T) {
 	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 	to := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
 
-	mockService.On("GetImpact", mock.Anything, &userID, &from, &to).Return(&fbModel.FeedbackImpact{}, nil)
+	mockService.On("GetImpact", mock.Anything, feedback.Viewer{UserID: "user-123"}, &userID, &from, &to).Return(&fbModel.FeedbackImpact{}, nil)
 
 	router := setupFeedbackRouterForImpact(handler, tokenGen)
 	req, _ := http.NewRequest("GET", "/api/v1/feedback/impact?user_id=user-8822&from=2024-01-01&to=2024-07-01", nil)
@@ -251,7 +275,7 @@ func TestGetImpact_ServiceError(t *testing.T) {
 	handler := NewFeedbackHandler(mockService)
 	tokenGen := jwt.NewTokenGenerator("test-secret", "test-refresh-secret", 15, 336)
 
-	mockService.On("GetImpact", mock.Anything, (*string)(nil), (*time.Time)(nil), (*time.Time)(nil)).Return(nil, assert.AnError)
+	mockService.On("GetImpact", mock.Anything, feedback.Viewer{UserID: "user-123"}, (*string)(nil), (*time.Time)(nil), (*time.Time)(nil)).Return(nil, assert.AnError)
 
 	router := setupFeedbackRouterForImpact(handler, tokenGen)
 	req, _ := http.NewRequest("GET", "/api/v1/feedback/impact", nil)
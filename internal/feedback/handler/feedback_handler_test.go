@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	fbModel "ethos/internal/feedback/model"
 	"ethos/internal/feedback/service"
 	"ethos/internal/middleware"
+	"ethos/pkg/errors"
 	"ethos/pkg/jwt"
 
 	"github.com/gin-gonic/gin"
@@ -25,28 +27,44 @@ type MockFeedbackService struct {
 	mock.Mock
 }
 
-func (m *MockFeedbackService) GetFeed(ctx context.Context, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockFeedbackService) GetFeed(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest) ([]*fbModel.FeedbackItem, *feedback.PageInfo, error) {
+	args := m.Called(ctx, viewer, page)
 	if args.Get(0) == nil {
-		return nil, 0, args.Error(2)
+		return nil, nil, args.Error(2)
 	}
-	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(*feedback.PageInfo), args.Error(2)
 }
 
-func (m *MockFeedbackService) GetFeedbackByID(ctx context.Context, feedbackID string) (*fbModel.FeedbackItem, error) {
-	args := m.Called(ctx, feedbackID)
+func (m *MockFeedbackService) GetFeedbackByID(ctx context.Context, viewer feedback.Viewer, feedbackID string) (*fbModel.FeedbackItem, error) {
+	args := m.Called(ctx, viewer, feedbackID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*fbModel.FeedbackItem), args.Error(1)
 }
 
-func (m *MockFeedbackService) GetComments(ctx context.Context, feedbackID string, limit, offset int) ([]*fbModel.FeedbackComment, int, error) {
-	args := m.Called(ctx, feedbackID, limit, offset)
+func (m *MockFeedbackService) GetComments(ctx context.Context, viewer feedback.Viewer, feedbackID string, page feedback.PageRequest) ([]*fbModel.FeedbackComment, *feedback.PageInfo, error) {
+	args := m.Called(ctx, viewer, feedbackID, page)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.FeedbackComment), args.Get(1).(*feedback.PageInfo), args.Error(2)
+}
+
+func (m *MockFeedbackService) GetReceivedFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+	args := m.Called(ctx, viewer, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
+}
+
+func (m *MockFeedbackService) GetGivenFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+	args := m.Called(ctx, viewer, limit, offset)
 	if args.Get(0) == nil {
-		return nil, 0, args.Error(3)
+		return nil, 0, args.Error(2)
 	}
-	return args.Get(0).([]*fbModel.FeedbackComment), args.Get(1).(int), args.Error(3)
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
 }
 
 func (m *MockFeedbackService) CreateFeedback(ctx context.Context, userID string, req *service.CreateFeedbackRequest) (*fbModel.FeedbackItem, error) {
@@ -57,21 +75,21 @@ func (m *MockFeedbackService) CreateFeedback(ctx context.Context, userID string,
 	return args.Get(0).(*fbModel.FeedbackItem), args.Error(1)
 }
 
-func (m *MockFeedbackService) CreateComment(ctx context.Context, userID, feedbackID string, req *service.CreateCommentRequest) (*fbModel.FeedbackComment, error) {
-	args := m.Called(ctx, userID, feedbackID, req)
+func (m *MockFeedbackService) CreateComment(ctx context.Context, viewer feedback.Viewer, feedbackID string, req *service.CreateCommentRequest) (*fbModel.FeedbackComment, error) {
+	args := m.Called(ctx, viewer, feedbackID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*fbModel.FeedbackComment), args.Error(1)
 }
 
-func (m *MockFeedbackService) AddReaction(ctx context.Context, userID, feedbackID string, reactionType string) error {
-	args := m.Called(ctx, userID, feedbackID, reactionType)
+func (m *MockFeedbackService) AddReaction(ctx context.Context, viewer feedback.Viewer, feedbackID string, reactionType string) error {
+	args := m.Called(ctx, viewer, feedbackID, reactionType)
 	return args.Error(0)
 }
 
-func (m *MockFeedbackService) RemoveReaction(ctx context.Context, userID, feedbackID string, reactionType string) error {
-	args := m.Called(ctx, userID, feedbackID, reactionType)
+func (m *MockFeedbackService) RemoveReaction(ctx context.Context, viewer feedback.Viewer, feedbackID string, reactionType string) error {
+	args := m.Called(ctx, viewer, feedbackID, reactionType)
 	return args.Error(0)
 }
 
@@ -88,8 +106,8 @@ func (m *MockFeedbackService) SubmitTemplateSuggestion(ctx context.Context, req
 	return args.Error(0)
 }
 
-func (m *MockFeedbackService) GetImpact(ctx context.Context, userID *string, from, to *time.Time) (*fbModel.FeedbackImpact, error) {
-	args := m.Called(ctx, userID, from, to)
+func (m *MockFeedbackService) GetImpact(ctx context.Context, viewer feedback.Viewer, userID *string, from, to *time.Time) (*fbModel.FeedbackImpact, error) {
+	args := m.Called(ctx, viewer, userID, from, to)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -104,24 +122,32 @@ func (m *MockFeedbackService) CreateBatchFeedback(ctx context.Context, userID st
 	return args.Get(0).(*feedback.BatchFeedbackResponse), args.Error(1)
 }
 
-func (m *MockFeedbackService) GetFeedWithFilters(ctx context.Context, limit, offset int, filters *feedback.FeedFilters) ([]*fbModel.FeedbackItem, int, error) {
-	args := m.Called(ctx, limit, offset, filters)
+func (m *MockFeedbackService) GetFeedWithFilters(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest, filters *feedback.FeedFilters) ([]*fbModel.FeedbackItem, *feedback.PageInfo, error) {
+	args := m.Called(ctx, viewer, page, filters)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(*feedback.PageInfo), args.Error(2)
+}
+
+func (m *MockFeedbackService) SearchFeedback(ctx context.Context, viewer feedback.Viewer, req *feedback.SearchRequest) ([]*fbModel.FeedbackSearchResult, int, error) {
+	args := m.Called(ctx, viewer, req)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
-	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
+	return args.Get(0).([]*fbModel.FeedbackSearchResult), args.Get(1).(int), args.Error(2)
 }
 
-func (m *MockFeedbackService) GetBookmarks(ctx context.Context, userID string, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
-	args := m.Called(ctx, userID, limit, offset)
+func (m *MockFeedbackService) GetBookmarks(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+	args := m.Called(ctx, viewer, limit, offset)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
 	return args.Get(0).([]*fbModel.FeedbackItem), args.Get(1).(int), args.Error(2)
 }
 
-func (m *MockFeedbackService) AddBookmark(ctx context.Context, userID, feedbackID string) error {
-	args := m.Called(ctx, userID, feedbackID)
+func (m *MockFeedbackService) AddBookmark(ctx context.Context, viewer feedback.Viewer, feedbackID string) error {
+	args := m.Called(ctx, viewer, feedbackID)
 	return args.Error(0)
 }
 
@@ -130,8 +156,8 @@ func (m *MockFeedbackService) RemoveBookmark(ctx context.Context, userID, feedba
 	return args.Error(0)
 }
 
-func (m *MockFeedbackService) ExportFeedback(ctx context.Context, filters *feedback.FeedFilters, format string) (*feedback.ExportResponse, error) {
-	args := m.Called(ctx, filters, format)
+func (m *MockFeedbackService) ExportFeedback(ctx context.Context, viewer feedback.Viewer, filters *feedback.FeedFilters, format string) (*feedback.ExportResponse, error) {
+	args := m.Called(ctx, viewer, filters, format)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -177,6 +203,9 @@ func setupFeedbackRouter(handler *FeedbackHandler, tokenGen *jwt.TokenGenerator)
 	router := gin.New()
 	router.Use(middleware.AuthMiddleware(tokenGen))
 	router.GET("/api/v1/feedback/feed", handler.GetFeed)
+	router.GET("/api/v1/feedback/search", handler.SearchFeedback)
+	router.GET("/api/v1/feedback/received", handler.GetReceivedFeedback)
+	router.GET("/api/v1/feedback/given", handler.GetGivenFeedback)
 	router.GET("/api/v1/feedback/:feedback_id", handler.GetFeedbackByID)
 	router.GET("/api/v1/feedback/:feedback_id/comments", handler.GetComments)
 	router.POST("/api/v1/feedback", handler.CreateFeedback)
@@ -212,7 +241,7 @@ func TestGetFeed_ValidRequest(t *testing.T) {
 		},
 	}
 
-	mockService.On("GetFeed", mock.Anything, 20, 0).Return(expectedItems, 1, nil)
+	mockService.On("GetFeed", mock.Anything, feedback.Viewer{UserID: "user-123"}, feedback.PageRequest{Limit: 20}).Return(expectedItems, &feedback.PageInfo{}, nil)
 
 	router := setupFeedbackRouter(handler, tokenGen)
 	req, _ := http.NewRequest("GET", "/api/v1/feedback/feed?limit=20", nil)
@@ -228,3 +257,350 @@ func TestGetFeed_ValidRequest(t *testing.T) {
 	assert.NotNil(t, response["results"])
 	mockService.AssertExpectations(t)
 }
+
+func TestGetFeed_FollowsCursor(t *testing.T) {
+	mockService := new(MockFeedbackService)
+	handler := NewFeedbackHandler(mockService)
+
+	tokenGen := jwt.NewTokenGenerator(
+		"test-access-secret",
+		"test-refresh-secret",
+		15*60*time.Second,
+		14*24*60*60*time.Second,
+	)
+
+	token, err := tokenGen.GenerateAccessToken("user-123")
+	assert.NoError(t, err)
+
+	after := feedback.Cursor{CreatedAt: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), ID: "f-010"}
+	next := feedback.Cursor{CreatedAt: time.Date(2024, 5, 30, 9, 0, 0, 0, time.UTC), ID: "f-008"}
+	total := 42
+
+	page := feedback.PageRequest{Limit: 2, After: &after, IncludeTotal: true}
+	mockService.On("GetFeed", mock.Anything, feedback.Viewer{UserID: "user-123"}, page).Return(
+		[]*fbModel.FeedbackItem{{FeedbackID: "f-009"}, {FeedbackID: "f-008"}},
+		&feedback.PageInfo{NextCursor: next.Encode(), ApproximateTotal: &total},
+		nil,
+	)
+
+	router := setupFeedbackRouter(handler, tokenGen)
+	req, _ := http.NewRequest("GET", "/api/v1/feedback/feed?limit=2&include_total=true&cursor="+after.Encode(), nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Results          []*fbModel.FeedbackItem `json:"results"`
+		NextCursor       string                  `json:"next_cursor"`
+		ApproximateTotal int                     `json:"approximate_total"`
+	}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.Results, 2)
+	assert.Equal(t, 42, response.ApproximateTotal)
+
+	decoded, err := feedback.DecodeCursor(response.NextCursor)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "f-008", decoded.ID)
+		assert.True(t, next.CreatedAt.Equal(decoded.CreatedAt))
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestGetFeed_InvalidCursor(t *testing.T) {
+	mockService := new(MockFeedbackService)
+	handler := NewFeedbackHandler(mockService)
+
+	tokenGen := jwt.NewTokenGenerator(
+		"test-access-secret",
+		"test-refresh-secret",
+		15*60*time.Second,
+		14*24*60*60*time.Second,
+	)
+
+	token, err := tokenGen.GenerateAccessToken("user-123")
+	assert.NoError(t, err)
+
+	router := setupFeedbackRouter(handler, tokenGen)
+	for _, path := range []string{
+		"/api/v1/feedback/feed?cursor=not-a-cursor",
+		"/api/v1/feedback/f-001/comments?cursor=e30",
+	} {
+		req, _ := http.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+	}
+	mockService.AssertNotCalled(t, "GetFeed", mock.Anything, mock.Anything, mock.Anything)
+	mockService.AssertNotCalled(t, "GetComments", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetFeed_CapsTheLimit(t *testing.T) {
+	mockService := new(MockFeedbackService)
+	handler := NewFeedbackHandler(mockService)
+
+	tokenGen := jwt.NewTokenGenerator(
+		"test-access-secret",
+		"test-refresh-secret",
+		15*60*time.Second,
+		14*24*60*60*time.Second,
+	)
+
+	token, err := tokenGen.GenerateAccessToken("user-123")
+	assert.NoError(t, err)
+
+	mockService.On("GetFeed", mock.Anything, feedback.Viewer{UserID: "user-123"}, feedback.PageRequest{Limit: 100}).Return(
+		[]*fbModel.FeedbackItem{}, &feedback.PageInfo{}, nil,
+	)
+
+	router := setupFeedbackRouter(handler, tokenGen)
+	req, _ := http.NewRequest("GET", "/api/v1/feedback/feed?limit=2147483647", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSearchFeedback_ValidRequest(t *testing.T) {
+	mockService := new(MockFeedbackService)
+	handler := NewFeedbackHandler(mockService)
+
+	tokenGen := jwt.NewTokenGenerator(
+		"test-access-secret",
+		"test-refresh-secret",
+		15*60*time.Second,
+		14*24*60*60*time.Second,
+	)
+
+	token, err := tokenGen.GenerateAccessToken("user-123")
+	assert.NoError(t, err)
+
+	results := []*fbModel.FeedbackSearchResult{
+		{
+			FeedbackItem: &fbModel.FeedbackItem{FeedbackID: "f-001", Content: "Great onboarding docs", IsAnonymous: true},
+			Rank:         0.6,
+			Highlight:    "Great <mark>onboarding</mark> docs",
+		},
+	}
+
+	matchesRequest := mock.MatchedBy(func(req *feedback.SearchRequest) bool {
+		return req.Query == "onboarding" &&
+			req.Locale == "de-DE" &&
+			req.AuthorID != nil && *req.AuthorID == "user-234" &&
+			req.From != nil && req.From.Format("2006-01-02") == "2024-01-01" &&
+			req.To != nil && req.To.Format("2006-01-02") == "2024-06-30" &&
+			req.Filters.ReviewerType != nil && *req.Filters.ReviewerType == "org" &&
+			req.Limit == 100 && req.Offset == 10
+	})
+	mockService.On("SearchFeedback", mock.Anything, feedback.Viewer{UserID: "user-123"}, matchesRequest).Return(results, 11, nil)
+
+	router := setupFeedbackRouter(handler, tokenGen)
+	req, _ := http.NewRequest("GET", "/api/v1/feedback/search?q=onboarding&locale=de-DE&author_id=user-234&from=2024-01-01&to=2024-06-30&reviewer_type=org&limit=500&offset=10", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Results []struct {
+			FeedbackID string             `json:"feedback_id"`
+			Author     *model.UserSummary `json:"author"`
+			Rank       float64            `json:"rank"`
+			Highlight  string             `json:"highlight"`
+		} `json:"results"`
+		Count int `json:"count"`
+	}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 11, response.Count)
+	if assert.Len(t, response.Results, 1) {
+		assert.Equal(t, "f-001", response.Results[0].FeedbackID)
+		assert.Equal(t, "Great <mark>onboarding</mark> docs", response.Results[0].Highlight)
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestSearchFeedback_ValidationError(t *testing.T) {
+	mockService := new(MockFeedbackService)
+	handler := NewFeedbackHandler(mockService)
+
+	tokenGen := jwt.NewTokenGenerator(
+		"test-access-secret",
+		"test-refresh-secret",
+		15*60*time.Second,
+		14*24*60*60*time.Second,
+	)
+
+	token, err := tokenGen.GenerateAccessToken("user-123")
+	assert.NoError(t, err)
+
+	mockService.On("SearchFeedback", mock.Anything, feedback.Viewer{UserID: "user-123"}, mock.Anything).
+		Return(nil, 0, errors.NewValidationError("search query is required"))
+
+	router := setupFeedbackRouter(handler, tokenGen)
+	req, _ := http.NewRequest("GET", "/api/v1/feedback/search?q=", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetReceivedFeedback_ValidRequest(t *testing.T) {
+	mockService := new(MockFeedbackService)
+	handler := NewFeedbackHandler(mockService)
+
+	tokenGen := jwt.NewTokenGenerator(
+		"test-access-secret",
+		"test-refresh-secret",
+		15*60*time.Second,
+		14*24*60*60*time.Second,
+	)
+
+	userID := "user-123"
+	token, err := tokenGen.GenerateAccessToken(userID)
+	assert.NoError(t, err)
+
+	expectedItems := []*fbModel.FeedbackItem{
+		{
+			FeedbackID: "f-001",
+			Author:     &model.UserSummary{ID: "user-234", Name: "Lisa K."},
+			Recipient:  &fbModel.FeedbackRecipient{Type: fbModel.RecipientTypeUser, ID: userID},
+			Content:    "Thanks for the thorough review",
+			Reactions:  map[string]int{},
+			CreatedAt:  time.Now(),
+		},
+	}
+
+	mockService.On("GetReceivedFeedback", mock.Anything, feedback.Viewer{UserID: userID}, 10, 5).Return(expectedItems, 6, nil)
+
+	router := setupFeedbackRouter(handler, tokenGen)
+	req, _ := http.NewRequest("GET", "/api/v1/feedback/received?limit=10&offset=5", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Results []*fbModel.FeedbackItem `json:"results"`
+		Count   int                     `json:"count"`
+	}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, response.Count)
+	if assert.Len(t, response.Results, 1) {
+		assert.Equal(t, userID, response.Results[0].Recipient.ID)
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestGetGivenFeedback_ValidRequest(t *testing.T) {
+	mockService := new(MockFeedbackService)
+	handler := NewFeedbackHandler(mockService)
+
+	tokenGen := jwt.NewTokenGenerator(
+		"test-access-secret",
+		"test-refresh-secret",
+		15*60*time.Second,
+		14*24*60*60*time.Second,
+	)
+
+	userID := "user-123"
+	token, err := tokenGen.GenerateAccessToken(userID)
+	assert.NoError(t, err)
+
+	mockService.On("GetGivenFeedback", mock.Anything, feedback.Viewer{UserID: userID}, 20, 0).Return([]*fbModel.FeedbackItem{}, 0, nil)
+
+	router := setupFeedbackRouter(handler, tokenGen)
+	req, _ := http.NewRequest("GET", "/api/v1/feedback/given", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateFeedback_InvalidRecipientType(t *testing.T) {
+	mockService := new(MockFeedbackService)
+	handler := NewFeedbackHandler(mockService)
+
+	tokenGen := jwt.NewTokenGenerator(
+		"test-access-secret",
+		"test-refresh-secret",
+		15*60*time.Second,
+		14*24*60*60*time.Second,
+	)
+
+	token, err := tokenGen.GenerateAccessToken("user-123")
+	assert.NoError(t, err)
+
+	body := `{"content": "Great demo", "recipient": {"type": "department", "id": "d-1"}}`
+
+	router := setupFeedbackRouter(handler, tokenGen)
+	req, _ := http.NewRequest("POST", "/api/v1/feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "CreateFeedback", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFeedbackReads_ScopedToCurrentOrganization(t *testing.T) {
+	mockService := new(MockFeedbackService)
+	handler := NewFeedbackHandler(mockService)
+
+	tokenGen := jwt.NewTokenGenerator(
+		"test-access-secret",
+		"test-refresh-secret",
+		15*60*time.Second,
+		14*24*60*60*time.Second,
+	)
+
+	token, err := tokenGen.GenerateAccessToken("user-123")
+	assert.NoError(t, err)
+
+	viewer := feedback.Viewer{UserID: "user-123", OrganizationID: "org-1"}
+	mockService.On("GetFeed", mock.Anything, viewer, feedback.PageRequest{Limit: 20}).Return([]*fbModel.FeedbackItem{}, &feedback.PageInfo{}, nil)
+	// Feedback of another organization reads as missing
+	mockService.On("GetFeedbackByID", mock.Anything, viewer, "f-other-org").Return(nil, errors.ErrNotFound)
+
+	// Stands in for the context middleware, which resolves the organization
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.AuthMiddleware(tokenGen), func(c *gin.Context) {
+		c.Set("current_organization_id", "org-1")
+	})
+	router.GET("/api/v1/feedback/feed", handler.GetFeed)
+	router.GET("/api/v1/feedback/:feedback_id", handler.GetFeedbackByID)
+
+	for path, status := range map[string]int{
+		"/api/v1/feedback/feed":        http.StatusOK,
+		"/api/v1/feedback/f-other-org": http.StatusNotFound,
+	} {
+		req, _ := http.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, status, w.Code, path)
+	}
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,303 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"ethos/internal/feedback/model"
+	"ethos/internal/feedback/service"
+	"ethos/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TemplateHandler handles feedback template HTTP requests: reading
+// published templates, and the administration of templates and template
+// suggestions
+type TemplateHandler struct {
+	service service.TemplateService
+}
+
+// NewTemplateHandler creates a new feedback template handler
+func NewTemplateHandler(svc service.TemplateService) *TemplateHandler {
+	return &TemplateHandler{
+		service: svc,
+	}
+}
+
+// templateVersionFrom reads the version query parameter, 0 meaning the
+// current version. It responds with 400 and returns false if it isn't a
+// positive number.
+func templateVersionFrom(c *gin.Context) (int, bool) {
+	versionStr := c.Query("version")
+	if versionStr == "" {
+		return 0, true
+	}
+
+	version, err := strconv.Atoi(versionStr)
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "version must be a positive number",
+			"code":  "VALIDATION_FAILED",
+		})
+		return 0, false
+	}
+	return version, true
+}
+
+// GetPublishedTemplate handles GET /api/v1/feedback/templates/:template_id
+func (h *TemplateHandler) GetPublishedTemplate(c *gin.Context) {
+	version, ok := templateVersionFrom(c)
+	if !ok {
+		return
+	}
+
+	template, err := h.service.GetPublishedTemplate(c.Request.Context(), c.Param("template_id"), version)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// ListTemplates handles GET /api/v1/admin/feedback/templates
+func (h *TemplateHandler) ListTemplates(c *gin.Context) {
+	templates, err := h.service.ListTemplates(c.Request.Context(), model.TemplateStatus(c.Query("status")))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": templates,
+		"count":   len(templates),
+	})
+}
+
+// GetTemplate handles GET /api/v1/admin/feedback/templates/:template_id
+func (h *TemplateHandler) GetTemplate(c *gin.Context) {
+	version, ok := templateVersionFrom(c)
+	if !ok {
+		return
+	}
+
+	template, err := h.service.GetTemplate(c.Request.Context(), c.Param("template_id"), version)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// CreateTemplate handles POST /api/v1/admin/feedback/templates
+func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
+	var req service.CreateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	template, err := h.service.CreateTemplate(c.Request.Context(), c.GetString("user_id"), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// UpdateTemplate handles PUT /api/v1/admin/feedback/templates/:template_id
+func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
+	var req service.UpdateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	template, err := h.service.UpdateTemplate(c.Request.Context(), c.GetString("user_id"), c.Param("template_id"), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// ArchiveTemplate handles DELETE /api/v1/admin/feedback/templates/:template_id.
+// Templates are archived rather than deleted, as feedback refers to them.
+func (h *TemplateHandler) ArchiveTemplate(c *gin.Context) {
+	template, err := h.service.ArchiveTemplate(c.Request.Context(), c.GetString("user_id"), c.Param("template_id"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// ListSuggestions handles GET /api/v1/admin/feedback/template_suggestions
+func (h *TemplateHandler) ListSuggestions(c *gin.Context) {
+	limit := 20
+	offset := 0
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 {
+		limit = l
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if o, err := strconv.Atoi(c.Query("offset")); err == nil && o >= 0 {
+		offset = o
+	}
+
+	suggestions, count, err := h.service.ListSuggestions(c.Request.Context(), model.SuggestionStatus(c.Query("status")), limit, offset)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": suggestions,
+		"count":   count,
+	})
+}
+
+// ApproveSuggestion handles POST /api/v1/admin/feedback/template_suggestions/:suggestion_id/approve.
+// The body defines the template the suggestion becomes.
+func (h *TemplateHandler) ApproveSuggestion(c *gin.Context) {
+	var req service.CreateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	template, err := h.service.ApproveSuggestion(c.Request.Context(), c.GetString("user_id"), c.Param("suggestion_id"), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// RejectSuggestion handles POST /api/v1/admin/feedback/template_suggestions/:suggestion_id/reject
+func (h *TemplateHandler) RejectSuggestion(c *gin.Context) {
+	var req service.RejectSuggestionRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Validation failed",
+				"code":  "VALIDATION_FAILED",
+			})
+			return
+		}
+	}
+
+	suggestion, err := h.service.RejectSuggestion(c.Request.Context(), c.GetString("user_id"), c.Param("suggestion_id"), req.Note)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestion)
+}
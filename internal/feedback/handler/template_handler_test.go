@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	fbModel "ethos/internal/feedback/model"
+	"ethos/internal/feedback/service"
+	"ethos/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTemplateService is a mock implementation of the template service
+type MockTemplateService struct {
+	mock.Mock
+}
+
+func (m *MockTemplateService) ListTemplates(ctx context.Context, status fbModel.TemplateStatus) ([]*fbModel.FeedbackTemplate, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fbModel.FeedbackTemplate), args.Error(1)
+}
+
+func (m *MockTemplateService) GetTemplate(ctx context.Context, templateID string, version int) (*fbModel.FeedbackTemplate, error) {
+	args := m.Called(ctx, templateID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fbModel.FeedbackTemplate), args.Error(1)
+}
+
+func (m *MockTemplateService) GetPublishedTemplate(ctx context.Context, templateID string, version int) (*fbModel.FeedbackTemplate, error) {
+	args := m.Called(ctx, templateID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fbModel.FeedbackTemplate), args.Error(1)
+}
+
+func (m *MockTemplateService) CreateTemplate(ctx context.Context, userID string, req *service.CreateTemplateRequest) (*fbModel.FeedbackTemplate, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fbModel.FeedbackTemplate), args.Error(1)
+}
+
+func (m *MockTemplateService) UpdateTemplate(ctx context.Context, userID, templateID string, req *service.UpdateTemplateRequest) (*fbModel.FeedbackTemplate, error) {
+	args := m.Called(ctx, userID, templateID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fbModel.FeedbackTemplate), args.Error(1)
+}
+
+func (m *MockTemplateService) ArchiveTemplate(ctx context.Context, userID, templateID string) (*fbModel.FeedbackTemplate, error) {
+	args := m.Called(ctx, userID, templateID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fbModel.FeedbackTemplate), args.Error(1)
+}
+
+func (m *MockTemplateService) ListSuggestions(ctx context.Context, status fbModel.SuggestionStatus, limit, offset int) ([]*fbModel.TemplateSuggestion, int, error) {
+	args := m.Called(ctx, status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*fbModel.TemplateSuggestion), args.Get(1).(int), args.Error(2)
+}
+
+func (m *MockTemplateService) ApproveSuggestion(ctx context.Context, reviewerID, suggestionID string, req *service.CreateTemplateRequest) (*fbModel.FeedbackTemplate, error) {
+	args := m.Called(ctx, reviewerID, suggestionID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fbModel.FeedbackTemplate), args.Error(1)
+}
+
+func (m *MockTemplateService) RejectSuggestion(ctx context.Context, reviewerID, suggestionID, note string) (*fbModel.TemplateSuggestion, error) {
+	args := m.Called(ctx, reviewerID, suggestionID, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fbModel.TemplateSuggestion), args.Error(1)
+}
+
+func setupTemplateRouter(handler *TemplateHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", "admin-1")
+		c.Next()
+	})
+	router.GET("/api/v1/feedback/templates/:template_id", handler.GetPublishedTemplate)
+	router.POST("/api/v1/admin/feedback/templates", handler.CreateTemplate)
+	router.POST("/api/v1/admin/feedback/template_suggestions/:suggestion_id/approve", handler.ApproveSuggestion)
+	router.POST("/api/v1/admin/feedback/template_suggestions/:suggestion_id/reject", handler.RejectSuggestion)
+	return router
+}
+
+func TestGetPublishedTemplate_ReadsTheRequestedVersion(t *testing.T) {
+	mockService := new(MockTemplateService)
+	router := setupTemplateRouter(NewTemplateHandler(mockService))
+
+	template := &fbModel.FeedbackTemplate{TemplateID: "tmpl-1", Name: "Peer review", Status: fbModel.TemplateStatusPublished, Version: 2}
+	mockService.On("GetPublishedTemplate", mock.Anything, "tmpl-1", 2).Return(template, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/feedback/templates/tmpl-1?version=2", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"version":2`)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetPublishedTemplate_InvalidVersion(t *testing.T) {
+	mockService := new(MockTemplateService)
+	router := setupTemplateRouter(NewTemplateHandler(mockService))
+
+	req := httptest.NewRequest("GET", "/api/v1/feedback/templates/tmpl-1?version=latest", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "GetPublishedTemplate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateTemplate_CreditsTheAdministrator(t *testing.T) {
+	mockService := new(MockTemplateService)
+	router := setupTemplateRouter(NewTemplateHandler(mockService))
+
+	created := &fbModel.FeedbackTemplate{TemplateID: "tmpl-1", Name: "Peer review", Status: fbModel.TemplateStatusDraft, Version: 1}
+	mockService.On("CreateTemplate", mock.Anything, "admin-1", mock.MatchedBy(func(req *service.CreateTemplateRequest) bool {
+		return req.Name == "Peer review" && len(req.Fields) == 1 && req.Fields[0].Type == fbModel.TemplateFieldRating
+	})).Return(created, nil)
+
+	body := `{"name": "Peer review", "fields": [{"key": "overall", "label": "Overall", "type": "rating", "min": 1, "max": 5}]}`
+	req := httptest.NewRequest("POST", "/api/v1/admin/feedback/templates", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestApproveSuggestion_AlreadyReviewed(t *testing.T) {
+	mockService := new(MockTemplateService)
+	router := setupTemplateRouter(NewTemplateHandler(mockService))
+
+	mockService.On("ApproveSuggestion", mock.Anything, "admin-1", "s-1", mock.Anything).Return(nil, errors.ErrSuggestionAlreadyReviewed)
+
+	body := `{"name": "Onboarding", "fields": [{"key": "overall", "label": "Overall", "type": "rating", "min": 1, "max": 5}]}`
+	req := httptest.NewRequest("POST", "/api/v1/admin/feedback/template_suggestions/s-1/approve", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "SUGGESTION_ALREADY_REVIEWED")
+}
+
+func TestRejectSuggestion_WithoutANote(t *testing.T) {
+	mockService := new(MockTemplateService)
+	router := setupTemplateRouter(NewTemplateHandler(mockService))
+
+	rejected := &fbModel.TemplateSuggestion{SuggestionID: "s-1", Status: fbModel.SuggestionStatusRejected}
+	mockService.On("RejectSuggestion", mock.Anything, "admin-1", "s-1", "").Return(rejected, nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/admin/feedback/template_suggestions/s-1/reject", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
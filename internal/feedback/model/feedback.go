@@ -25,10 +25,33 @@ const (
 	FeedbackTypeOther        FeedbackType = "other"
 )
 
-// FeedbackItem represents a feedback post
+// RecipientType is what kind of recipient feedback is addressed to
+type RecipientType string
+
+const (
+	RecipientTypeUser         RecipientType = "user"
+	RecipientTypeTeam         RecipientType = "team"
+	RecipientTypeOrganization RecipientType = "organization"
+)
+
+// FeedbackRecipient is the user, team or organization feedback is addressed to
+type FeedbackRecipient struct {
+	Type RecipientType `json:"type"`
+	ID   string        `json:"id"`
+	Name string        `json:"name,omitempty"`
+	// OrganizationID is the organization a team belongs to, or the
+	// organization itself
+	OrganizationID string `json:"organization_id,omitempty"`
+}
+
+// FeedbackItem represents a feedback post. Private feedback is visible to
+// its author and recipient only; team feedback also to members of its
+// organization.
 type FeedbackItem struct {
 	FeedbackID         string                     `json:"feedback_id"`
 	Author             *authModel.UserSummary     `json:"author"`
+	Recipient          *FeedbackRecipient         `json:"recipient,omitempty"`
+	OrganizationID     *string                    `json:"organization_id,omitempty"`
 	Content            string                     `json:"content"`
 	Type               *FeedbackType              `json:"type,omitempty"`
 	Visibility         *FeedbackVisibility        `json:"visibility,omitempty"`
@@ -38,10 +61,22 @@ type FeedbackItem struct {
 	IsAnonymous        bool                       `json:"is_anonymous,omitempty"`
 	Helpfulness        float64                    `json:"helpfulness,omitempty"`
 	Dimensions         []FeedbackDimensionScore   `json:"dimensions,omitempty"`
+	Template           *TemplateReference         `json:"template,omitempty"`
+	Responses          map[string]interface{}     `json:"responses,omitempty"`
 	CommentsCount      int                        `json:"comments_count"`
 	CreatedAt          time.Time                  `json:"created_at"`
 }
 
+// FeedbackSearchResult represents a feedback item matching a search, with
+// how well it matched and an excerpt of its content with the matching
+// words wrapped in <mark> tags. The excerpt is HTML-escaped, so it is safe
+// to render as HTML.
+type FeedbackSearchResult struct {
+	*FeedbackItem
+	Rank      float64 `json:"rank"`
+	Highlight string  `json:"highlight"`
+}
+
 // FeedbackDimensionScore represents dimension-level scoring
 type FeedbackDimensionScore struct {
 	Dimension string `json:"dimension"`
@@ -76,13 +111,21 @@ type FeedbackFollowUp struct {
 	CreatedAt  time.Time              `json:"created_at"`
 }
 
-// FeedbackTemplate represents a feedback template
+// FeedbackTemplate represents a feedback template at one of its versions.
+// TemplateFields holds the free-form fields of templates created before
+// typed fields existed.
 type FeedbackTemplate struct {
 	TemplateID     string                 `json:"template_id"`
 	Name           string                 `json:"name"`
 	Description    string                 `json:"description"`
 	ContextTags    []string               `json:"context_tags"`
 	TemplateFields map[string]interface{} `json:"template_fields"`
+	Status         TemplateStatus         `json:"status,omitempty"`
+	Version        int                    `json:"version,omitempty"`
+	Fields         []TemplateField        `json:"fields,omitempty"`
+	CreatedBy      *string                `json:"created_by,omitempty"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
 }
 
 // FeedbackImpact represents aggregated feedback analytics
@@ -0,0 +1,82 @@
+package model
+
+import "time"
+
+// TemplateStatus is where a template is in its lifecycle. Only published
+// templates are offered to, and accepted from, feedback authors.
+type TemplateStatus string
+
+const (
+	TemplateStatusDraft     TemplateStatus = "draft"
+	TemplateStatusPublished TemplateStatus = "published"
+	TemplateStatusArchived  TemplateStatus = "archived"
+)
+
+// TemplateFieldType is the kind of answer a template field takes
+type TemplateFieldType string
+
+const (
+	// TemplateFieldRating is a whole number between Min and Max
+	TemplateFieldRating TemplateFieldType = "rating"
+	// TemplateFieldChoice is one of Options, or several if Multiple is set
+	TemplateFieldChoice TemplateFieldType = "choice"
+	// TemplateFieldText is free text of at most MaxLength characters
+	TemplateFieldText TemplateFieldType = "text"
+	// TemplateFieldDimension is a rating recorded as the feedback's score
+	// on Dimension
+	TemplateFieldDimension TemplateFieldType = "dimension"
+)
+
+// TemplateField is a typed question of a template. Which of the optional
+// settings apply depends on its type.
+type TemplateField struct {
+	Key       string            `json:"key"`
+	Label     string            `json:"label"`
+	Type      TemplateFieldType `json:"type"`
+	Required  bool              `json:"required,omitempty"`
+	Min       int               `json:"min,omitempty"`
+	Max       int               `json:"max,omitempty"`
+	Options   []string          `json:"options,omitempty"`
+	Multiple  bool              `json:"multiple,omitempty"`
+	MaxLength int               `json:"max_length,omitempty"`
+	Dimension string            `json:"dimension,omitempty"`
+}
+
+// TemplateReference identifies the template version feedback was written with
+type TemplateReference struct {
+	TemplateID string `json:"template_id"`
+	Version    int    `json:"version"`
+}
+
+// TemplateSubmission is a template's validated answers stored with feedback
+type TemplateSubmission struct {
+	TemplateReference
+	Responses  map[string]interface{}
+	Dimensions []FeedbackDimensionScore
+}
+
+// SuggestionStatus is the outcome of an administrator's review of a
+// template suggestion
+type SuggestionStatus string
+
+const (
+	SuggestionStatusPending  SuggestionStatus = "pending"
+	SuggestionStatusApproved SuggestionStatus = "approved"
+	SuggestionStatusRejected SuggestionStatus = "rejected"
+)
+
+// TemplateSuggestion is a user's request for a new template. Approving it
+// links it to the template it was turned into.
+type TemplateSuggestion struct {
+	SuggestionID  string              `json:"suggestion_id"`
+	SuggestedBy   *string             `json:"suggested_by,omitempty"`
+	UsageContext  string              `json:"usage_context"`
+	Details       string              `json:"details"`
+	DesiredFields []map[string]string `json:"desired_fields"`
+	Status        SuggestionStatus    `json:"status"`
+	ReviewedBy    *string             `json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time          `json:"reviewed_at,omitempty"`
+	ReviewNote    string              `json:"review_note,omitempty"`
+	TemplateID    *string             `json:"template_id,omitempty"`
+	CreatedAt     time.Time           `json:"created_at"`
+}
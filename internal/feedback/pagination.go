@@ -0,0 +1,61 @@
+package feedback
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"ethos/pkg/errors"
+)
+
+// Cursor marks a position in a list ordered by creation time. The ID breaks
+// ties between items created at the same instant, so paging never skips or
+// repeats an item, however many are inserted while a client pages.
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+}
+
+// Encode returns the cursor as an opaque token for clients to send back
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a token returned by Encode. An empty token means the
+// first page and decodes to nil.
+func DecodeCursor(token string) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid cursor")
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == "" || c.CreatedAt.IsZero() {
+		return nil, errors.NewValidationError("invalid cursor")
+	}
+
+	return &c, nil
+}
+
+// PageRequest asks for the page of Limit items that follows After, or the
+// first page if After is nil
+type PageRequest struct {
+	Limit        int
+	After        *Cursor
+	IncludeTotal bool
+}
+
+// PageInfo describes where a page sits in its list
+type PageInfo struct {
+	// NextCursor is the token for the following page, empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+
+	// ApproximateTotal is the planner's estimate of the list's length, only
+	// set when the request asked for it
+	ApproximateTotal *int `json:"approximate_total,omitempty"`
+}
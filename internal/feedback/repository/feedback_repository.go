@@ -10,17 +10,33 @@ import (
 
 // Repository defines the interface for feedback data access
 type Repository interface {
-	// GetFeed retrieves a paginated feed of feedback items
-	GetFeed(ctx context.Context, limit, offset int) ([]*model.FeedbackItem, int, error)
+	// GetFeed retrieves a page of the feedback a viewer may see, newest first
+	GetFeed(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest) ([]*model.FeedbackItem, *feedback.PageInfo, error)
 
 	// GetFeedbackByID retrieves a feedback item by ID
 	GetFeedbackByID(ctx context.Context, feedbackID string) (*model.FeedbackItem, error)
 
-	// GetComments retrieves comments for a feedback item
-	GetComments(ctx context.Context, feedbackID string, limit, offset int) ([]*model.FeedbackComment, int, error)
+	// GetComments retrieves a page of the comments on a feedback item, oldest first
+	GetComments(ctx context.Context, feedbackID string, page feedback.PageRequest) ([]*model.FeedbackComment, *feedback.PageInfo, error)
 
-	// CreateFeedback creates a new feedback item
-	CreateFeedback(ctx context.Context, userID string, content string, feedbackType *model.FeedbackType, visibility *model.FeedbackVisibility) (*model.FeedbackItem, error)
+	// CreateFeedback creates a new feedback item, optionally addressed to a
+	// recipient, scoped to an organization and written with a template
+	CreateFeedback(ctx context.Context, userID string, content string, feedbackType *model.FeedbackType, visibility *model.FeedbackVisibility, recipient *model.FeedbackRecipient, organizationID *string, submission *model.TemplateSubmission) (*model.FeedbackItem, error)
+
+	// CanViewFeedback reports whether a feedback item exists and a viewer may see it
+	CanViewFeedback(ctx context.Context, viewer feedback.Viewer, feedbackID string) (bool, error)
+
+	// GetRecipient looks up a user, team or organization feedback can be addressed to, or returns ErrNotFound
+	GetRecipient(ctx context.Context, recipientType model.RecipientType, recipientID string) (*model.FeedbackRecipient, error)
+
+	// GetRecipientUserIDs lists the users who receive feedback addressed to a recipient
+	GetRecipientUserIDs(ctx context.Context, recipient *model.FeedbackRecipient) ([]string, error)
+
+	// GetReceivedFeedback retrieves feedback addressed to a viewer, their teams and the organizations they administer
+	GetReceivedFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*model.FeedbackItem, int, error)
+
+	// GetGivenFeedback retrieves feedback a viewer has written
+	GetGivenFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*model.FeedbackItem, int, error)
 
 	// CreateComment creates a new comment
 	CreateComment(ctx context.Context, userID, feedbackID string, content string, parentCommentID *string) (*model.FeedbackComment, error)
@@ -37,23 +53,50 @@ type Repository interface {
 	// GetCommentsCount gets comment count for a feedback item
 	GetCommentsCount(ctx context.Context, feedbackID string) (int, error)
 
-	// GetTemplates retrieves feedback templates with optional filtering
+	// GetTemplates retrieves the published feedback templates with optional filtering
 	GetTemplates(ctx context.Context, contextFilter, tagsFilter string) ([]*model.FeedbackTemplate, error)
 
-	// SubmitTemplateSuggestion submits a template suggestion
+	// ListTemplates retrieves templates of any status, or of one status if it isn't empty
+	ListTemplates(ctx context.Context, status model.TemplateStatus) ([]*model.FeedbackTemplate, error)
+
+	// GetTemplate retrieves a template at a version, or at its current version if version is 0
+	GetTemplate(ctx context.Context, templateID string, version int) (*model.FeedbackTemplate, error)
+
+	// CreateTemplate creates a template with its fields as version 1
+	CreateTemplate(ctx context.Context, template *model.FeedbackTemplate) error
+
+	// UpdateTemplate saves a template, and its fields as a new version if fields isn't nil
+	UpdateTemplate(ctx context.Context, template *model.FeedbackTemplate, fields []model.TemplateField, updatedBy string) error
+
+	// SubmitTemplateSuggestion stores a template suggestion for review
 	SubmitTemplateSuggestion(ctx context.Context, req *feedback.TemplateSuggestionRequest) error
 
-	// GetImpact retrieves aggregated feedback analytics
-	GetImpact(ctx context.Context, userID *string, from, to *time.Time) (*model.FeedbackImpact, error)
+	// ListTemplateSuggestions retrieves template suggestions of any status, or of one status if it isn't empty
+	ListTemplateSuggestions(ctx context.Context, status model.SuggestionStatus, limit, offset int) ([]*model.TemplateSuggestion, int, error)
+
+	// GetTemplateSuggestion retrieves a template suggestion
+	GetTemplateSuggestion(ctx context.Context, suggestionID string) (*model.TemplateSuggestion, error)
+
+	// ApproveTemplateSuggestion creates a template from a pending suggestion and marks the suggestion approved
+	ApproveTemplateSuggestion(ctx context.Context, suggestionID, reviewerID string, template *model.FeedbackTemplate) error
+
+	// RejectTemplateSuggestion marks a pending suggestion rejected
+	RejectTemplateSuggestion(ctx context.Context, suggestionID, reviewerID, note string) error
+
+	// GetImpact retrieves aggregated analytics over the feedback a viewer may see
+	GetImpact(ctx context.Context, viewer feedback.Viewer, userID *string, from, to *time.Time) (*model.FeedbackImpact, error)
 
 	// CreateBatchFeedback creates multiple feedback items in a batch
 	CreateBatchFeedback(ctx context.Context, userID string, req *feedback.BatchFeedbackRequest) (*feedback.BatchFeedbackResponse, error)
 
-	// GetFeedWithFilters retrieves a paginated feed of feedback items with enhanced filtering
-	GetFeedWithFilters(ctx context.Context, limit, offset int, filters *feedback.FeedFilters) ([]*model.FeedbackItem, int, error)
+	// GetFeedWithFilters retrieves a page of the feedback a viewer may see, newest first, with enhanced filtering
+	GetFeedWithFilters(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest, filters *feedback.FeedFilters) ([]*model.FeedbackItem, *feedback.PageInfo, error)
+
+	// SearchFeedback ranks the feedback a viewer may see by how well its content matches a full-text query
+	SearchFeedback(ctx context.Context, viewer feedback.Viewer, req *feedback.SearchRequest) ([]*model.FeedbackSearchResult, int, error)
 
-	// GetBookmarks retrieves bookmarked feedback items for a user
-	GetBookmarks(ctx context.Context, userID string, limit, offset int) ([]*model.FeedbackItem, int, error)
+	// GetBookmarks retrieves a viewer's bookmarked feedback items that they may still see
+	GetBookmarks(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*model.FeedbackItem, int, error)
 
 	// AddBookmark adds a bookmark for a feedback item
 	AddBookmark(ctx context.Context, userID, feedbackID string) error
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"html"
 	"strconv"
 	"strings"
 	"time"
@@ -29,92 +30,55 @@ func NewPostgresRepository(db *database.DB) Repository {
 	return &PostgresRepository{db: db}
 }
 
-// GetFeed retrieves a paginated feed of feedback items
-func (r *PostgresRepository) GetFeed(ctx context.Context, limit, offset int) ([]*model.FeedbackItem, int, error) {
+// GetFeed retrieves a page of the feedback a viewer may see in their
+// current organization, newest first
+func (r *PostgresRepository) GetFeed(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest) ([]*model.FeedbackItem, *feedback.PageInfo, error) {
 	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetFeed")
 	defer span.End()
 
-	// Get total count
-	var totalCount int
-	countQuery := `SELECT COUNT(*) FROM feedback_items WHERE visibility = 'public'`
-	err := r.db.Pool.QueryRow(ctx, countQuery).Scan(&totalCount)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		return nil, 0, errors.WrapError(err, "failed to count feedback items")
+	where := viewableBy("$1", "$2")
+	args := []interface{}{viewer.UserID, viewer.OrganizationID}
+
+	info := &feedback.PageInfo{}
+	if page.IncludeTotal {
+		total, err := r.estimateRows(ctx, `SELECT 1 FROM feedback_items f WHERE `+where, args...)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, nil, errors.WrapError(err, "failed to estimate feedback feed size")
+		}
+		info.ApproximateTotal = &total
 	}
 
-	// Get feedback items
+	after, args := keysetAfter("f.created_at", "f.feedback_id", "<", page.After, args)
 	query := `
-		SELECT f.feedback_id, f.author_id, f.content, f.type, f.visibility, f.created_at,
-		       u.id, u.name
+		SELECT ` + feedbackListColumns + `
 		FROM feedback_items f
 		JOIN users u ON f.author_id = u.id
-		WHERE f.visibility = 'public'
-		ORDER BY f.created_at DESC
-		LIMIT $1 OFFSET $2
-	`
+		` + recipientJoins + `
+		WHERE ` + where + after + `
+		ORDER BY f.created_at DESC, f.feedback_id DESC
+		LIMIT $` + strconv.Itoa(len(args)+1)
 
-	rows, err := r.db.Pool.Query(ctx, query, limit, offset)
+	rows, err := r.db.Pool.Query(ctx, query, append(args, page.Limit+1)...)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-		return nil, 0, errors.WrapError(err, "failed to get feedback feed")
+		return nil, nil, errors.WrapError(err, "failed to get feedback feed")
 	}
 	defer rows.Close()
 
-	var items []*model.FeedbackItem
-	for rows.Next() {
-		item := &model.FeedbackItem{
-			Reactions: make(map[string]int),
-		}
-		var authorID, authorName string
-		var feedbackType, visibility *string
-
-		err := rows.Scan(
-			&item.FeedbackID,
-			&authorID,
-			&item.Content,
-			&feedbackType,
-			&visibility,
-			&item.CreatedAt,
-			&authorID,
-			&authorName,
-		)
-		if err != nil {
-			span.RecordError(err)
-			continue
-		}
-
-		item.Author = &authModel.UserSummary{ID: authorID, Name: authorName}
-		if feedbackType != nil {
-			ft := model.FeedbackType(*feedbackType)
-			item.Type = &ft
-		}
-		if visibility != nil {
-			v := model.FeedbackVisibility(*visibility)
-			item.Visibility = &v
-		}
-
-		// Get reactions count
-		reactions, _ := r.GetReactionsCount(ctx, item.FeedbackID)
-		item.Reactions = reactions
-
-		// Get comments count
-		commentsCount, _ := r.GetCommentsCount(ctx, item.FeedbackID)
-		item.CommentsCount = commentsCount
-
-		items = append(items, item)
-	}
-
-	if err = rows.Err(); err != nil {
+	items, err := r.scanFeedbackList(ctx, rows)
+	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-		return nil, 0, errors.WrapError(err, "failed to iterate feedback items")
+		return nil, nil, errors.WrapError(err, "failed to read feedback feed")
 	}
 
+	items, info.NextCursor = feedbackPage(items, page.Limit)
+
 	span.SetStatus(codes.Ok, "")
-	return items, totalCount, nil
+	return items, info, nil
 }
 
 // GetFeedbackByID retrieves a feedback item by ID
@@ -124,9 +88,10 @@ func (r *PostgresRepository) GetFeedbackByID(ctx context.Context, feedbackID str
 
 	query := `
 		SELECT f.feedback_id, f.author_id, f.content, f.type, f.visibility, f.created_at,
-		       u.id, u.name
+		       u.id, u.name, ` + recipientColumns + `, ` + templateAnswerColumns + `
 		FROM feedback_items f
 		JOIN users u ON f.author_id = u.id
+		` + recipientJoins + `
 		WHERE f.feedback_id = $1
 	`
 
@@ -136,6 +101,8 @@ func (r *PostgresRepository) GetFeedbackByID(ctx context.Context, feedbackID str
 	var authorID, authorName string
 	var feedbackType, visibility *string
 	var scannedAuthorID string
+	var recipient recipientRow
+	var template templateRow
 
 	err := r.db.Pool.QueryRow(ctx, query, feedbackID).Scan(
 		&item.FeedbackID,
@@ -146,6 +113,15 @@ func (r *PostgresRepository) GetFeedbackByID(ctx context.Context, feedbackID str
 		&item.CreatedAt,
 		&authorID,
 		&authorName,
+		&recipient.Type,
+		&recipient.ID,
+		&recipient.Name,
+		&recipient.OrganizationID,
+		&item.OrganizationID,
+		&template.ID,
+		&template.Version,
+		&template.Responses,
+		&template.Dimensions,
 	)
 
 	if err != nil {
@@ -156,8 +132,14 @@ func (r *PostgresRepository) GetFeedbackByID(ctx context.Context, feedbackID str
 		}
 		return nil, errors.WrapError(err, "failed to get feedback")
 	}
+	if err := template.applyTo(item); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to parse template answers")
+	}
 
 	item.Author = &authModel.UserSummary{ID: authorID, Name: authorName}
+	item.Recipient = recipient.toModel()
 	if feedbackType != nil {
 		ft := model.FeedbackType(*feedbackType)
 		item.Type = &ft
@@ -179,41 +161,44 @@ func (r *PostgresRepository) GetFeedbackByID(ctx context.Context, feedbackID str
 	return item, nil
 }
 
-// GetComments retrieves comments for a feedback item
-func (r *PostgresRepository) GetComments(ctx context.Context, feedbackID string, limit, offset int) ([]*model.FeedbackComment, int, error) {
+// GetComments retrieves a page of the comments on a feedback item, oldest first
+func (r *PostgresRepository) GetComments(ctx context.Context, feedbackID string, page feedback.PageRequest) ([]*model.FeedbackComment, *feedback.PageInfo, error) {
 	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetComments")
 	defer span.End()
 
-	// Get total count
-	var totalCount int
-	countQuery := `SELECT COUNT(*) FROM feedback_comments WHERE feedback_id = $1`
-	err := r.db.Pool.QueryRow(ctx, countQuery, feedbackID).Scan(&totalCount)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		return nil, 0, errors.WrapError(err, "failed to count comments")
+	args := []interface{}{feedbackID}
+
+	info := &feedback.PageInfo{}
+	if page.IncludeTotal {
+		total, err := r.estimateRows(ctx, `SELECT 1 FROM feedback_comments c WHERE c.feedback_id = $1`, args...)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, nil, errors.WrapError(err, "failed to estimate comment count")
+		}
+		info.ApproximateTotal = &total
 	}
 
 	// Get comments
+	after, args := keysetAfter("c.created_at", "c.comment_id", ">", page.After, args)
 	query := `
 		SELECT c.comment_id, c.author_id, c.content, c.created_at, c.parent_comment_id,
 		       u.id, u.name
 		FROM feedback_comments c
 		JOIN users u ON c.author_id = u.id
-		WHERE c.feedback_id = $1
-		ORDER BY c.created_at ASC
-		LIMIT $2 OFFSET $3
-	`
+		WHERE c.feedback_id = $1` + after + `
+		ORDER BY c.created_at ASC, c.comment_id ASC
+		LIMIT $` + strconv.Itoa(len(args)+1)
 
-	rows, err := r.db.Pool.Query(ctx, query, feedbackID, limit, offset)
+	rows, err := r.db.Pool.Query(ctx, query, append(args, page.Limit+1)...)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-		return nil, 0, errors.WrapError(err, "failed to get comments")
+		return nil, nil, errors.WrapError(err, "failed to get comments")
 	}
 	defer rows.Close()
 
-	var comments []*model.FeedbackComment
+	comments := []*model.FeedbackComment{}
 	for rows.Next() {
 		comment := &model.FeedbackComment{}
 		var authorID, authorName string
@@ -231,7 +216,8 @@ func (r *PostgresRepository) GetComments(ctx context.Context, feedbackID string,
 		)
 		if err != nil {
 			span.RecordError(err)
-			continue
+			span.SetStatus(codes.Error, err.Error())
+			return nil, nil, errors.WrapError(err, "failed to read comments")
 		}
 
 		comment.Author = &authModel.UserSummary{ID: authorID, Name: authorName}
@@ -242,15 +228,23 @@ func (r *PostgresRepository) GetComments(ctx context.Context, feedbackID string,
 	if err = rows.Err(); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-		return nil, 0, errors.WrapError(err, "failed to iterate comments")
+		return nil, nil, errors.WrapError(err, "failed to iterate comments")
+	}
+
+	// The extra row fetched only tells whether another page follows
+	if len(comments) > page.Limit {
+		comments = comments[:page.Limit]
+		last := comments[len(comments)-1]
+		info.NextCursor = feedback.Cursor{CreatedAt: last.CreatedAt, ID: last.CommentID}.Encode()
 	}
 
 	span.SetStatus(codes.Ok, "")
-	return comments, totalCount, nil
+	return comments, info, nil
 }
 
-// CreateFeedback creates a new feedback item
-func (r *PostgresRepository) CreateFeedback(ctx context.Context, userID string, content string, feedbackType *model.FeedbackType, visibility *model.FeedbackVisibility) (*model.FeedbackItem, error) {
+// CreateFeedback creates a new feedback item, optionally addressed to a
+// recipient and written with a template
+func (r *PostgresRepository) CreateFeedback(ctx context.Context, userID string, content string, feedbackType *model.FeedbackType, visibility *model.FeedbackVisibility, recipient *model.FeedbackRecipient, organizationID *string, submission *model.TemplateSubmission) (*model.FeedbackItem, error) {
 	ctx, span := otel.Tracer("repository").Start(ctx, "repository.CreateFeedback")
 	defer span.End()
 
@@ -270,18 +264,52 @@ func (r *PostgresRepository) CreateFeedback(ctx context.Context, userID string,
 		visibilityStr = &defaultVis
 	}
 
+	var recipientType, recipientID *string
+	if recipient != nil {
+		t := string(recipient.Type)
+		recipientType = &t
+		recipientID = &recipient.ID
+	}
+
+	var templateID *string
+	var templateVersion *int
+	var responses, dimensions []byte
+	if submission != nil {
+		templateID = &submission.TemplateID
+		templateVersion = &submission.Version
+		var err error
+		if responses, err = json.Marshal(submission.Responses); err == nil {
+			dimensions, err = json.Marshal(submission.Dimensions)
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, errors.WrapError(err, "failed to encode template answers")
+		}
+	}
+
 	query := `
-		INSERT INTO feedback_items (feedback_id, author_id, content, type, visibility, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO feedback_items (feedback_id, author_id, content, type, visibility, recipient_type, recipient_id, organization_id,
+		                            template_id, template_version, template_responses, dimensions, locale, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8::uuid, $9, $10, $11, $12, ` + userLocale("$2") + `, $13, $14)
 		RETURNING feedback_id, author_id, content, type, visibility, created_at
 	`
 
 	item := &model.FeedbackItem{
-		Reactions: make(map[string]int),
+		Reactions:      make(map[string]int),
+		Recipient:      recipient,
+		OrganizationID: organizationID,
+	}
+	if submission != nil {
+		reference := submission.TemplateReference
+		item.Template = &reference
+		item.Responses = submission.Responses
+		item.Dimensions = submission.Dimensions
 	}
 	var authorID string
 
-	err := r.db.Pool.QueryRow(ctx, query, feedbackID, userID, content, typeStr, visibilityStr, now, now).Scan(
+	err := r.db.Pool.QueryRow(ctx, query, feedbackID, userID, content, typeStr, visibilityStr, recipientType, recipientID, organizationID,
+		templateID, templateVersion, responses, dimensions, now, now).Scan(
 		&item.FeedbackID,
 		&authorID,
 		&item.Content,
@@ -442,108 +470,8 @@ func (r *PostgresRepository) GetCommentsCount(ctx context.Context, feedbackID st
 	return count, err
 }
 
-// GetTemplates retrieves feedback templates with optional filtering
-func (r *PostgresRepository) GetTemplates(ctx context.Context, contextFilter, tagsFilter string) ([]*model.FeedbackTemplate, error) {
-	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetTemplates")
-	defer span.End()
-
-	query := `SELECT template_id, name, description, context_tags, template_fields FROM feedback_templates`
-	args := []interface{}{}
-	argCount := 0
-
-	// Add context filtering if specified
-	if contextFilter != "" {
-		argCount++
-		query += ` WHERE $` + strconv.Itoa(argCount) + ` = ANY(context_tags)`
-		args = append(args, contextFilter)
-	}
-
-	// Add tags filtering if specified
-	if tagsFilter != "" {
-		if argCount == 0 {
-			query += ` WHERE`
-		} else {
-			query += ` AND`
-		}
-		tags := strings.Split(tagsFilter, ",")
-		for i, tag := range tags {
-			if i > 0 {
-				query += ` OR`
-			} else {
-				argCount++
-			}
-			query += ` $` + strconv.Itoa(argCount) + ` = ANY(context_tags)`
-			args = append(args, strings.TrimSpace(tag))
-		}
-	}
-
-	query += ` ORDER BY name`
-
-	rows, err := r.db.Pool.Query(ctx, query, args...)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		return nil, errors.WrapError(err, "failed to get feedback templates")
-	}
-	defer rows.Close()
-
-	var templates []*model.FeedbackTemplate
-	for rows.Next() {
-		var template model.FeedbackTemplate
-		var contextTags []string
-		var templateFields []byte
-
-		err := rows.Scan(
-			&template.TemplateID,
-			&template.Name,
-			&template.Description,
-			&contextTags,
-			&templateFields,
-		)
-		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
-			return nil, errors.WrapError(err, "failed to scan feedback template")
-		}
-
-		template.ContextTags = contextTags
-
-		// Parse JSON template fields
-		if len(templateFields) > 0 {
-			err = json.Unmarshal(templateFields, &template.TemplateFields)
-			if err != nil {
-				span.RecordError(err)
-				span.SetStatus(codes.Error, err.Error())
-				return nil, errors.WrapError(err, "failed to parse template fields JSON")
-			}
-		}
-
-		templates = append(templates, &template)
-	}
-
-	if err := rows.Err(); err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		return nil, errors.WrapError(err, "error iterating template rows")
-	}
-
-	span.SetStatus(codes.Ok, "")
-	return templates, nil
-}
-
-// SubmitTemplateSuggestion submits a template suggestion
-func (r *PostgresRepository) SubmitTemplateSuggestion(ctx context.Context, req *feedback.TemplateSuggestionRequest) error {
-	ctx, span := otel.Tracer("repository").Start(ctx, "repository.SubmitTemplateSuggestion")
-	defer span.End()
-
-	// In a real implementation, this would save the suggestion to database
-	// For now, just log it and return success
-	span.SetStatus(codes.Ok, "")
-	return nil
-}
-
 // GetImpact retrieves aggregated feedback analytics
-func (r *PostgresRepository) GetImpact(ctx context.Context, userID *string, from, to *time.Time) (*model.FeedbackImpact, error) {
+func (r *PostgresRepository) GetImpact(ctx context.Context, viewer feedback.Viewer, userID *string, from, to *time.Time) (*model.FeedbackImpact, error) {
 	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetImpact")
 	defer span.End()
 
@@ -552,40 +480,37 @@ func (r *PostgresRepository) GetImpact(ctx context.Context, userID *string, from
 		Trends:         []model.FeedbackTrend{},
 	}
 
-	// Build base query conditions
-	whereConditions := []string{}
-	args := []interface{}{}
-	argCount := 0
+	// Only feedback the viewer may see counts towards the impact
+	whereConditions := []string{viewableBy("$1", "$2")}
+	args := []interface{}{viewer.UserID, viewer.OrganizationID}
+	argCount := len(args)
 
 	if userID != nil {
 		argCount++
-		whereConditions = append(whereConditions, `author_id = $`+strconv.Itoa(argCount))
+		whereConditions = append(whereConditions, `f.author_id = $`+strconv.Itoa(argCount))
 		args = append(args, *userID)
 	}
 
 	if from != nil {
 		argCount++
-		whereConditions = append(whereConditions, `created_at >= $`+strconv.Itoa(argCount))
+		whereConditions = append(whereConditions, `f.created_at >= $`+strconv.Itoa(argCount))
 		args = append(args, *from)
 	}
 
 	if to != nil {
 		argCount++
-		whereConditions = append(whereConditions, `created_at <= $`+strconv.Itoa(argCount))
+		whereConditions = append(whereConditions, `f.created_at <= $`+strconv.Itoa(argCount))
 		args = append(args, *to)
 	}
 
-	whereClause := ""
-	if len(whereConditions) > 0 {
-		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
-	}
+	whereClause := "WHERE " + strings.Join(whereConditions, " AND ")
 
 	// Get feedback count and average helpfulness
 	query := `
 		SELECT
 			COUNT(*) as feedback_count,
-			COALESCE(AVG(helpfulness), 0) as avg_helpfulness
-		FROM feedback_items
+			COALESCE(AVG(f.helpfulness), 0) as avg_helpfulness
+		FROM feedback_items f
 		` + whereClause
 
 	err := r.db.Pool.QueryRow(ctx, query, args...).Scan(
@@ -604,7 +529,7 @@ func (r *PostgresRepository) GetImpact(ctx context.Context, userID *string, from
 			reaction_type,
 			COUNT(*) as count
 		FROM feedback_reactions fr
-		JOIN feedback_items fi ON fr.feedback_id = fi.feedback_id
+		JOIN feedback_items f ON fr.feedback_id = f.feedback_id
 		` + whereClause + `
 		GROUP BY reaction_type
 	`
@@ -633,7 +558,7 @@ func (r *PostgresRepository) GetImpact(ctx context.Context, userID *string, from
 	followUpQuery := `
 		SELECT COUNT(*) as follow_up_count
 		FROM feedback_follow_ups ffu
-		JOIN feedback_items fi ON ffu.feedback_id = fi.feedback_id
+		JOIN feedback_items f ON ffu.feedback_id = f.feedback_id
 		` + whereClause
 
 	err = r.db.Pool.QueryRow(ctx, followUpQuery, args...).Scan(&impact.FollowUpCount)
@@ -646,13 +571,13 @@ func (r *PostgresRepository) GetImpact(ctx context.Context, userID *string, from
 	// Get trends (grouped by date)
 	trendsQuery := `
 		SELECT
-			DATE(created_at) as date,
-			COALESCE(AVG(helpfulness), 0) as avg_helpfulness,
+			DATE(f.created_at) as date,
+			COALESCE(AVG(f.helpfulness), 0) as avg_helpfulness,
 			COUNT(*) as feedback_count
-		FROM feedback_items
+		FROM feedback_items f
 		` + whereClause + `
-		GROUP BY DATE(created_at)
-		ORDER BY DATE(created_at)
+		GROUP BY DATE(f.created_at)
+		ORDER BY DATE(f.created_at)
 	`
 
 	trendRows, err := r.db.Pool.Query(ctx, trendsQuery, args...)
@@ -684,18 +609,20 @@ func (r *PostgresRepository) GetImpact(ctx context.Context, userID *string, from
 	return impact, nil
 }
 
-// GetBookmarks retrieves bookmarked feedback items for a user
-func (r *PostgresRepository) GetBookmarks(ctx context.Context, userID string, limit, offset int) ([]*model.FeedbackItem, int, error) {
+// GetBookmarks retrieves a viewer's bookmarked feedback items that they may
+// still see in their current organization
+func (r *PostgresRepository) GetBookmarks(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*model.FeedbackItem, int, error) {
 	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetBookmarks")
 	defer span.End()
 
 	// Get total count
 	var total int
 	countQuery := `
-		SELECT COUNT(*) FROM feedback_bookmarks
-		WHERE user_id = $1
-	`
-	err := r.db.Pool.QueryRow(ctx, countQuery, userID).Scan(&total)
+		SELECT COUNT(*)
+		FROM feedback_bookmarks fb
+		JOIN feedback_items f ON fb.feedback_id = f.feedback_id
+		WHERE fb.user_id = $1 AND ` + viewableBy("$1", "$2")
+	err := r.db.Pool.QueryRow(ctx, countQuery, viewer.UserID, viewer.OrganizationID).Scan(&total)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -705,34 +632,30 @@ func (r *PostgresRepository) GetBookmarks(ctx context.Context, userID string, li
 	// Get bookmarked feedback items with full details
 	query := `
 		SELECT
-			fi.feedback_id,
-			fi.author_id,
+			f.feedback_id,
+			f.author_id,
 			u.name as author_name,
-			u.role as author_role,
-			fi.content,
-			fi.type,
-			fi.visibility,
-			fi.is_anonymous,
-			fi.helpfulness,
-			fi.reviewer_context,
-			fi.moderation_state,
-			fi.created_at,
-			COALESCE(comment_counts.comment_count, 0) as comments_count,
-			fb.created_at as bookmarked_at
+			f.content,
+			f.type,
+			f.visibility,
+			f.is_anonymous,
+			f.helpfulness,
+			f.created_at,
+			COALESCE(comment_counts.comment_count, 0) as comments_count
 		FROM feedback_bookmarks fb
-		JOIN feedback_items fi ON fb.feedback_id = fi.feedback_id
-		JOIN users u ON fi.author_id = u.id
+		JOIN feedback_items f ON fb.feedback_id = f.feedback_id
+		JOIN users u ON f.author_id = u.id
 		LEFT JOIN (
 			SELECT feedback_id, COUNT(*) as comment_count
 			FROM feedback_comments
 			GROUP BY feedback_id
-		) comment_counts ON fi.feedback_id = comment_counts.feedback_id
-		WHERE fb.user_id = $1
+		) comment_counts ON f.feedback_id = comment_counts.feedback_id
+		WHERE fb.user_id = $1 AND ` + viewableBy("$1", "$2") + `
 		ORDER BY fb.created_at DESC
-		LIMIT $2 OFFSET $3
+		LIMIT $3 OFFSET $4
 	`
 
-	rows, err := r.db.Pool.Query(ctx, query, userID, limit, offset)
+	rows, err := r.db.Pool.Query(ctx, query, viewer.UserID, viewer.OrganizationID, limit, offset)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -740,69 +663,11 @@ func (r *PostgresRepository) GetBookmarks(ctx context.Context, userID string, li
 	}
 	defer rows.Close()
 
-	var items []*model.FeedbackItem
-	for rows.Next() {
-		var item model.FeedbackItem
-		var authorName string
-		var authorRole *string
-		var reviewerContext []byte
-		var moderationState *string
-		var bookmarkedAt time.Time
-
-		err := rows.Scan(
-			&item.FeedbackID,
-			&item.Author.ID,
-			&authorName,
-			&authorRole,
-			&item.Content,
-			&item.Type,
-			&item.Visibility,
-			&item.IsAnonymous,
-			&item.Helpfulness,
-			&reviewerContext,
-			&moderationState,
-			&item.CreatedAt,
-			&item.CommentsCount,
-			&bookmarkedAt,
-		)
-		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
-			return nil, 0, errors.WrapError(err, "failed to scan bookmarked feedback item")
-		}
-
-		// Set author information
-		item.Author.Name = authorName
-		// Note: Role field doesn't exist on UserSummary, commented out
-		// if authorRole != nil {
-		// 	item.Author.Role = *authorRole
-		// }
-
-		// Get reactions for this feedback item
-		reactions, err := r.getReactionsForFeedback(ctx, item.FeedbackID)
-		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
-			return nil, 0, errors.WrapError(err, "failed to get reactions")
-		}
-		item.Reactions = reactions
-
-		// Get reaction analytics
-		reactionAnalytics, err := r.getReactionAnalytics(ctx, item.FeedbackID)
-		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
-			return nil, 0, errors.WrapError(err, "failed to get reaction analytics")
-		}
-		item.ReactionsAnalytics = reactionAnalytics
-
-		items = append(items, &item)
-	}
-
-	if err := rows.Err(); err != nil {
+	items, err := r.scanDetailedFeedback(ctx, rows)
+	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-		return nil, 0, errors.WrapError(err, "error iterating bookmark rows")
+		return nil, 0, errors.WrapError(err, "failed to read bookmarked feedback")
 	}
 
 	span.SetStatus(codes.Ok, "")
@@ -973,8 +838,8 @@ func (r *PostgresRepository) CreateBatchFeedback(ctx context.Context, userID str
 
 		// Execute the batch insert (note: using batch query instead)
 		_, err = r.db.Pool.Exec(ctx, `
-			INSERT INTO feedback_items (feedback_id, author_id, content, type, visibility, is_anonymous, created_at)
-			VALUES ($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP)`,
+			INSERT INTO feedback_items (feedback_id, author_id, content, type, visibility, is_anonymous, locale, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, `+userLocale("$2")+`, CURRENT_TIMESTAMP)`,
 			feedbackID,
 			actualAuthorID,
 			item.Content,
@@ -1006,66 +871,251 @@ func (r *PostgresRepository) CreateBatchFeedback(ctx context.Context, userID str
 	return response, nil
 }
 
-// GetFeedWithFilters retrieves a paginated feed of feedback items with enhanced filtering
-func (r *PostgresRepository) GetFeedWithFilters(ctx context.Context, limit, offset int, filters *feedback.FeedFilters) ([]*model.FeedbackItem, int, error) {
+// GetFeedWithFilters retrieves a page of the feedback a viewer may see in
+// their current organization, newest first, with enhanced filtering
+func (r *PostgresRepository) GetFeedWithFilters(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest, filters *feedback.FeedFilters) ([]*model.FeedbackItem, *feedback.PageInfo, error) {
 	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetFeedWithFilters")
 	defer span.End()
 
 	// Build the base query
 	query := `
 		SELECT
-			fi.feedback_id,
-			fi.author_id,
+			f.feedback_id,
+			f.author_id,
 			u.name as author_name,
-			u.role as author_role,
-			fi.content,
-			fi.type,
-			fi.visibility,
-			fi.is_anonymous,
-			fi.helpfulness,
-			fi.reviewer_context,
-			fi.moderation_state,
-			fi.created_at,
+			f.content,
+			f.type,
+			f.visibility,
+			f.is_anonymous,
+			f.helpfulness,
+			f.created_at,
 			COALESCE(comment_counts.comment_count, 0) as comments_count
-		FROM feedback_items fi
-		JOIN users u ON fi.author_id = u.id
+		FROM feedback_items f
+		JOIN users u ON f.author_id = u.id
 		LEFT JOIN (
 			SELECT feedback_id, COUNT(*) as comment_count
 			FROM feedback_comments
 			GROUP BY feedback_id
-		) comment_counts ON fi.feedback_id = comment_counts.feedback_id
+		) comment_counts ON f.feedback_id = comment_counts.feedback_id
 	`
 
-	countQuery := `
-		SELECT COUNT(*)
-		FROM feedback_items fi
-		JOIN users u ON fi.author_id = u.id
+	estimateQuery := `
+		SELECT 1
+		FROM feedback_items f
+		JOIN users u ON f.author_id = u.id
 	`
 
-	args := []interface{}{}
-	argCount := 0
-	whereConditions := []string{}
+	args := []interface{}{viewer.UserID, viewer.OrganizationID}
+	whereConditions, args := feedFilterConditions(filters, []string{viewableBy("$1", "$2")}, args)
+
+	whereClause := " WHERE " + strings.Join(whereConditions, " AND ")
+	estimateQuery += whereClause
+
+	// Estimate the total rather than counting every matching row
+	info := &feedback.PageInfo{}
+	if page.IncludeTotal {
+		total, err := r.estimateRows(ctx, estimateQuery, args...)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, nil, errors.WrapError(err, "failed to estimate feedback feed size")
+		}
+		info.ApproximateTotal = &total
+	}
+
+	// Add the cursor, ordering and page size
+	after, args := keysetAfter("f.created_at", "f.feedback_id", "<", page.After, args)
+	query += whereClause + after
+	query += ` ORDER BY f.created_at DESC, f.feedback_id DESC LIMIT $` + strconv.Itoa(len(args)+1)
+
+	// Get feedback items
+	rows, err := r.db.Pool.Query(ctx, query, append(args, page.Limit+1)...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, nil, errors.WrapError(err, "failed to get feedback feed")
+	}
+	defer rows.Close()
+
+	items, err := r.scanDetailedFeedback(ctx, rows)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, nil, errors.WrapError(err, "failed to read feedback feed")
+	}
+
+	items, info.NextCursor = feedbackPage(items, page.Limit)
 
+	span.SetStatus(codes.Ok, "")
+	return items, info, nil
+}
+
+// SearchFeedback ranks the feedback a viewer may see in their current
+// organization by how well its content matches a full-text query. The
+// author of anonymous feedback is hidden from everyone but themselves, and
+// searching by author never matches it either.
+func (r *PostgresRepository) SearchFeedback(ctx context.Context, viewer feedback.Viewer, req *feedback.SearchRequest) ([]*model.FeedbackSearchResult, int, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.SearchFeedback")
+	defer span.End()
+
+	args := []interface{}{viewer.UserID, viewer.OrganizationID, req.Locale, req.Query}
+	whereConditions := []string{`f.search_vector @@ s.query`, viewableBy("$1", "$2")}
+	whereConditions, args = feedFilterConditions(req.Filters, whereConditions, args)
+
+	if req.AuthorID != nil {
+		args = append(args, *req.AuthorID)
+		whereConditions = append(whereConditions, `f.author_id = $`+strconv.Itoa(len(args))+` AND (f.is_anonymous IS NOT TRUE OR f.author_id = $1)`)
+	}
+
+	if req.From != nil {
+		args = append(args, *req.From)
+		whereConditions = append(whereConditions, `f.created_at >= $`+strconv.Itoa(len(args)))
+	}
+
+	if req.To != nil {
+		args = append(args, *req.To)
+		whereConditions = append(whereConditions, `f.created_at <= $`+strconv.Itoa(len(args)))
+	}
+
+	// The query is parsed with the dictionary for the requested locale,
+	// falling back to the searcher's, and with the simple dictionary, to
+	// match both halves of the search vector
+	from := `
+		FROM feedback_items f
+		JOIN users u ON f.author_id = u.id
+		CROSS JOIN (
+			SELECT websearch_to_tsquery(c.config, $4) || websearch_to_tsquery('simple', $4) AS query, c.config
+			FROM (
+				SELECT feedback_search_config(COALESCE(NULLIF($3, ''), ` + userLocale("$1") + `)) AS config
+			) c
+		) s
+		WHERE ` + strings.Join(whereConditions, " AND ")
+
+	var total int
+	if err := r.db.Pool.QueryRow(ctx, `SELECT COUNT(*) `+from, args...).Scan(&total); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, 0, errors.WrapError(err, "failed to count search results")
+	}
+
+	// Postgres only builds the costly headlines for the rows on the page.
+	// Matches are delimited with control characters, stripped from the
+	// content first, so markHighlight can escape the excerpt before marking them.
+	query := `
+		SELECT f.feedback_id, ts_rank_cd(f.search_vector, s.query) AS rank,
+		       ts_headline(s.config, translate(f.content, chr(2) || chr(3), ''), s.query,
+		                   'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', MaxFragments=2, MaxWords=30, MinWords=10')
+		` + from + `
+		ORDER BY rank DESC, f.created_at DESC, f.feedback_id DESC
+		LIMIT $` + strconv.Itoa(len(args)+1) + ` OFFSET $` + strconv.Itoa(len(args)+2)
+
+	rows, err := r.db.Pool.Query(ctx, query, append(args, req.Limit, req.Offset)...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, 0, errors.WrapError(err, "failed to search feedback")
+	}
+	defer rows.Close()
+
+	results := []*model.FeedbackSearchResult{}
+	ids := []string{}
+	for rows.Next() {
+		result := &model.FeedbackSearchResult{FeedbackItem: &model.FeedbackItem{}}
+		if err := rows.Scan(&result.FeedbackID, &result.Rank, &result.Highlight); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, 0, errors.WrapError(err, "failed to read search results")
+		}
+		result.Highlight = markHighlight(result.Highlight)
+		results = append(results, result)
+		ids = append(ids, result.FeedbackID)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, 0, errors.WrapError(err, "failed to iterate search results")
+	}
+	rows.Close()
+
+	// Load the matching items themselves, then put them back in rank order
+	rows, err = r.db.Pool.Query(ctx, `
+		SELECT `+feedbackListColumns+`
+		FROM feedback_items f
+		JOIN users u ON f.author_id = u.id
+		`+recipientJoins+`
+		WHERE f.feedback_id = ANY($1)
+	`, ids)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, 0, errors.WrapError(err, "failed to get search results")
+	}
+	defer rows.Close()
+
+	items, err := r.scanFeedbackList(ctx, rows)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, 0, errors.WrapError(err, "failed to read search results")
+	}
+
+	byID := make(map[string]*model.FeedbackItem, len(items))
+	for _, item := range items {
+		if item.IsAnonymous && item.Author.ID != viewer.UserID {
+			item.Author = nil
+		}
+		byID[item.FeedbackID] = item
+	}
+	ranked := make([]*model.FeedbackSearchResult, 0, len(results))
+	for _, result := range results {
+		// Skip anything deleted since it matched
+		if item, ok := byID[result.FeedbackID]; ok {
+			result.FeedbackItem = item
+			ranked = append(ranked, result)
+		}
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return ranked, total, nil
+}
+
+// SearchFeedback's headlines delimit matches with these control characters
+const (
+	highlightStart = "\x02"
+	highlightStop  = "\x03"
+)
+
+// highlightReplacer turns the match delimiters into <mark> tags
+var highlightReplacer = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// markHighlight HTML-escapes a search excerpt, which is user-authored, and
+// wraps its matches in <mark> tags, so the result is safe to render as HTML
+func markHighlight(headline string) string {
+	return highlightReplacer.Replace(html.EscapeString(headline))
+}
+
+// feedFilterConditions appends predicates on feedback_items f and its
+// author u for filters to whereConditions, numbering their parameters after
+// args, which their values are appended to
+func feedFilterConditions(filters *feedback.FeedFilters, whereConditions []string, args []interface{}) ([]string, []interface{}) {
 	// Apply filters
 	if filters != nil {
 		// Reviewer type filter
 		if filters.ReviewerType != nil {
-			argCount++
 			switch *filters.ReviewerType {
 			case "org":
 				// For org reviewer type, we need feedback with reviewer_context
-				whereConditions = append(whereConditions, `fi.reviewer_context IS NOT NULL`)
+				whereConditions = append(whereConditions, `f.reviewer_context IS NOT NULL`)
 			case "public":
 				// For public reviewer type, we need feedback without reviewer_context or with public visibility
-				whereConditions = append(whereConditions, `(fi.reviewer_context IS NULL OR fi.visibility = 'public')`)
+				whereConditions = append(whereConditions, `(f.reviewer_context IS NULL OR f.visibility = 'public')`)
 			}
 		}
 
 		// Context filter
 		if filters.Context != nil {
-			argCount++
-			whereConditions = append(whereConditions, `fi.reviewer_context->>'type' = $`+strconv.Itoa(argCount))
 			args = append(args, *filters.Context)
+			whereConditions = append(whereConditions, `f.reviewer_context->>'type' = $`+strconv.Itoa(len(args)))
 		}
 
 		// Verification filter (based on email_verified status of author)
@@ -1080,113 +1130,75 @@ func (r *PostgresRepository) GetFeedWithFilters(ctx context.Context, limit, offs
 
 		// Tags filter - this would require a tags field, but for now we'll skip this
 		// as the current schema doesn't have tags. This could be added later.
-		if len(filters.Tags) > 0 {
-			// Placeholder for future implementation
-			// whereConditions = append(whereConditions, `fi.tags @> $`+strconv.Itoa(argCount+1))
-			// args = append(args, filters.Tags)
-		}
 	}
 
-	// Add WHERE clause if we have conditions
-	if len(whereConditions) > 0 {
-		whereClause := " WHERE " + strings.Join(whereConditions, " AND ")
-		query += whereClause
-		countQuery += whereClause
-	}
-
-	// Add ordering and pagination
-	query += ` ORDER BY fi.created_at DESC LIMIT $` + strconv.Itoa(argCount+1) + ` OFFSET $` + strconv.Itoa(argCount+2)
-	args = append(args, limit, offset)
-
-	// Get total count
-	var total int
-	err := r.db.Pool.QueryRow(ctx, countQuery, args[:argCount]...).Scan(&total)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		return nil, 0, errors.WrapError(err, "failed to get total count")
-	}
-
-	// Get feedback items
-	rows, err := r.db.Pool.Query(ctx, query, args...)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		return nil, 0, errors.WrapError(err, "failed to get feedback feed")
-	}
-	defer rows.Close()
+	return whereConditions, args
+}
 
-	var items []*model.FeedbackItem
+// scanDetailedFeedback reads the rows of GetFeedWithFilters and
+// GetBookmarks, then loads each item's reactions and reaction analytics
+func (r *PostgresRepository) scanDetailedFeedback(ctx context.Context, rows pgx.Rows) ([]*model.FeedbackItem, error) {
+	items := []*model.FeedbackItem{}
 	for rows.Next() {
-		var item model.FeedbackItem
-		var authorName string
-		var authorRole *string
-		var reviewerContext []byte
-		var moderationState *string
+		item := &model.FeedbackItem{}
+		var authorID, authorName string
+		var feedbackType, visibility *string
+		var isAnonymous *bool
+		var helpfulness *float64
 
 		err := rows.Scan(
 			&item.FeedbackID,
-			&item.Author.ID,
+			&authorID,
 			&authorName,
-			&authorRole,
 			&item.Content,
-			&item.Type,
-			&item.Visibility,
-			&item.IsAnonymous,
-			&item.Helpfulness,
-			&reviewerContext,
-			&moderationState,
+			&feedbackType,
+			&visibility,
+			&isAnonymous,
+			&helpfulness,
 			&item.CreatedAt,
 			&item.CommentsCount,
 		)
 		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
-			return nil, 0, errors.WrapError(err, "failed to scan feedback item")
+			return nil, err
 		}
 
-		// Set author information
-		item.Author.Name = authorName
-		// Note: Role field doesn't exist on UserSummary, commented out
-		// if authorRole != nil {
-		// 	item.Author.Role = *authorRole
-		// }
-
-		// Parse reviewer context JSON if present
-		if len(reviewerContext) > 0 {
-			// For now, we'll skip parsing the reviewer context
-			// This would require additional model changes
+		item.Author = &authModel.UserSummary{ID: authorID, Name: authorName}
+		if feedbackType != nil {
+			ft := model.FeedbackType(*feedbackType)
+			item.Type = &ft
 		}
+		if visibility != nil {
+			v := model.FeedbackVisibility(*visibility)
+			item.Visibility = &v
+		}
+		if isAnonymous != nil {
+			item.IsAnonymous = *isAnonymous
+		}
+		if helpfulness != nil {
+			item.Helpfulness = *helpfulness
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
 
-		// Get reactions for this feedback item
+	for _, item := range items {
 		reactions, err := r.getReactionsForFeedback(ctx, item.FeedbackID)
 		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
-			return nil, 0, errors.WrapError(err, "failed to get reactions")
+			return nil, err
 		}
 		item.Reactions = reactions
 
-		// Get reaction analytics
 		reactionAnalytics, err := r.getReactionAnalytics(ctx, item.FeedbackID)
 		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
-			return nil, 0, errors.WrapError(err, "failed to get reaction analytics")
+			return nil, err
 		}
 		item.ReactionsAnalytics = reactionAnalytics
-
-		items = append(items, &item)
-	}
-
-	if err := rows.Err(); err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		return nil, 0, errors.WrapError(err, "error iterating feed rows")
 	}
 
-	span.SetStatus(codes.Ok, "")
-	return items, total, nil
+	return items, nil
 }
 
 // UpdateFeedback updates an existing feedback item
@@ -1273,3 +1285,398 @@ func (r *PostgresRepository) GetFeedbackAnalytics(ctx context.Context, userID *s
 	span.SetStatus(codes.Ok, "")
 	return analytics, nil
 }
+
+// recipientColumns selects who feedback in f is addressed to, through recipientJoins
+const recipientColumns = `
+	f.recipient_type, f.recipient_id,
+	COALESCE(ru.name, rt.name, ro.name, ''), COALESCE(rt.organization_id::text, ro.id::text, ''),
+	f.organization_id::text
+`
+
+const recipientJoins = `
+	LEFT JOIN users ru ON f.recipient_type = 'user' AND ru.id = f.recipient_id
+	LEFT JOIN organization_teams rt ON f.recipient_type = 'team' AND rt.id::text = f.recipient_id
+	LEFT JOIN organizations ro ON f.recipient_type = 'organization' AND ro.id::text = f.recipient_id
+`
+
+// userLocale returns an expression for the locale preference of the user
+// bound to param, which picks the dictionary their feedback is indexed with
+func userLocale(param string) string {
+	return `(SELECT locale FROM user_preferences WHERE user_id = ` + param + `)`
+}
+
+// activeMember holds for organization_members rows om whose member isn't suspended
+const activeMember = `NOT (om.suspended_at IS NOT NULL AND (om.suspended_until IS NULL OR om.suspended_until > NOW()))`
+
+// recipientIncludes returns a predicate on feedback_items f that holds when
+// the user bound to param is its recipient: the user it's addressed to, an
+// active member of the team, or an owner or admin of the organization.
+func recipientIncludes(param string) string {
+	return `(
+		(f.recipient_type = 'user' AND f.recipient_id = ` + param + `)
+		OR (f.recipient_type = 'team' AND EXISTS (
+			SELECT 1 FROM organization_team_members tm
+			JOIN organization_teams t ON t.id = tm.team_id
+			JOIN organization_members om ON om.organization_id = t.organization_id AND om.user_id = tm.user_id
+			WHERE tm.team_id::text = f.recipient_id AND tm.user_id = ` + param + ` AND ` + activeMember + `
+		))
+		OR (f.recipient_type = 'organization' AND EXISTS (
+			SELECT 1 FROM organization_members om
+			WHERE om.organization_id::text = f.recipient_id AND om.user_id = ` + param + `
+				AND om.role IN ('owner', 'admin') AND ` + activeMember + `
+		))
+	)`
+}
+
+// visibleTo returns a predicate on feedback_items f that holds when the
+// user bound to param may see it. Public feedback is visible to everyone,
+// private feedback to its author and recipient, and team feedback also to
+// active members of its organization.
+func visibleTo(param string) string {
+	return `(
+		f.visibility = 'public'
+		OR f.author_id = ` + param + `
+		OR ` + recipientIncludes(param) + `
+		OR (f.visibility = 'team' AND EXISTS (
+			SELECT 1 FROM organization_members om
+			WHERE om.organization_id = f.organization_id AND om.user_id = ` + param + ` AND ` + activeMember + `
+		))
+	)`
+}
+
+// inOrganization returns a predicate on feedback_items f that holds for
+// unscoped feedback and for feedback scoped to the organization bound to
+// orgParam, if the user bound to userParam is still an active member of it.
+// An empty organization ID matches only unscoped feedback.
+func inOrganization(userParam, orgParam string) string {
+	return `(
+		f.organization_id IS NULL
+		OR (f.organization_id::text = ` + orgParam + ` AND EXISTS (
+			SELECT 1 FROM organization_members om
+			WHERE om.organization_id = f.organization_id AND om.user_id = ` + userParam + ` AND ` + activeMember + `
+		))
+	)`
+}
+
+// viewableBy returns a predicate on feedback_items f that holds when a
+// viewer, whose user and organization IDs are bound to userParam and
+// orgParam, may see it. Every feedback read goes through it so one tenant
+// never sees another's feedback.
+func viewableBy(userParam, orgParam string) string {
+	return `(` + visibleTo(userParam) + ` AND ` + inOrganization(userParam, orgParam) + `)`
+}
+
+// recipientRow holds the nullable recipient columns of a feedback row
+type recipientRow struct {
+	Type           *string
+	ID             *string
+	Name           string
+	OrganizationID string
+}
+
+func (r recipientRow) toModel() *model.FeedbackRecipient {
+	if r.Type == nil || r.ID == nil {
+		return nil
+	}
+	return &model.FeedbackRecipient{
+		Type:           model.RecipientType(*r.Type),
+		ID:             *r.ID,
+		Name:           r.Name,
+		OrganizationID: r.OrganizationID,
+	}
+}
+
+// CanViewFeedback reports whether a feedback item exists and a viewer may
+// see it in their current organization
+func (r *PostgresRepository) CanViewFeedback(ctx context.Context, viewer feedback.Viewer, feedbackID string) (bool, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.CanViewFeedback")
+	defer span.End()
+
+	query := `SELECT EXISTS (SELECT 1 FROM feedback_items f WHERE f.feedback_id = $3 AND ` + viewableBy("$1", "$2") + `)`
+
+	var visible bool
+	if err := r.db.Pool.QueryRow(ctx, query, viewer.UserID, viewer.OrganizationID, feedbackID).Scan(&visible); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return false, errors.WrapError(err, "failed to check feedback visibility")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return visible, nil
+}
+
+// GetRecipient looks up a user, team or organization feedback can be
+// addressed to, or returns ErrNotFound
+func (r *PostgresRepository) GetRecipient(ctx context.Context, recipientType model.RecipientType, recipientID string) (*model.FeedbackRecipient, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetRecipient")
+	defer span.End()
+
+	var query string
+	switch recipientType {
+	case model.RecipientTypeUser:
+		query = `SELECT id, COALESCE(name, ''), '' FROM users WHERE id = $1`
+	case model.RecipientTypeTeam:
+		query = `SELECT id::text, name, organization_id::text FROM organization_teams WHERE id::text = $1`
+	case model.RecipientTypeOrganization:
+		query = `SELECT id::text, name, id::text FROM organizations WHERE id::text = $1`
+	default:
+		return nil, errors.ErrNotFound
+	}
+
+	recipient := &model.FeedbackRecipient{Type: recipientType}
+	err := r.db.Pool.QueryRow(ctx, query, recipientID).Scan(&recipient.ID, &recipient.Name, &recipient.OrganizationID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get feedback recipient")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return recipient, nil
+}
+
+// GetRecipientUserIDs lists the users who receive feedback addressed to a
+// recipient: the user, the team's active members, or the organization's
+// owners and admins
+func (r *PostgresRepository) GetRecipientUserIDs(ctx context.Context, recipient *model.FeedbackRecipient) ([]string, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetRecipientUserIDs")
+	defer span.End()
+
+	var query string
+	switch recipient.Type {
+	case model.RecipientTypeUser:
+		return []string{recipient.ID}, nil
+	case model.RecipientTypeTeam:
+		query = `
+			SELECT tm.user_id
+			FROM organization_team_members tm
+			JOIN organization_teams t ON t.id = tm.team_id
+			JOIN organization_members om ON om.organization_id = t.organization_id AND om.user_id = tm.user_id
+			WHERE tm.team_id::text = $1 AND ` + activeMember
+	case model.RecipientTypeOrganization:
+		query = `
+			SELECT om.user_id
+			FROM organization_members om
+			WHERE om.organization_id::text = $1 AND om.role IN ('owner', 'admin') AND ` + activeMember
+	default:
+		return nil, nil
+	}
+
+	rows, err := r.db.Pool.Query(ctx, query, recipient.ID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list feedback recipients")
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, errors.WrapError(err, "failed to scan feedback recipient")
+		}
+		userIDs = append(userIDs, userID)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list feedback recipients")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return userIDs, nil
+}
+
+// GetReceivedFeedback retrieves feedback addressed to a viewer, their teams
+// and the organizations they administer, within their current
+// organization, newest first
+func (r *PostgresRepository) GetReceivedFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*model.FeedbackItem, int, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetReceivedFeedback")
+	defer span.End()
+
+	items, total, err := r.listFeedback(ctx, recipientIncludes("$1")+" AND "+inOrganization("$1", "$2"), viewer, limit, offset)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, 0, errors.WrapError(err, "failed to get received feedback")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return items, total, nil
+}
+
+// GetGivenFeedback retrieves feedback a viewer has written within their
+// current organization, newest first
+func (r *PostgresRepository) GetGivenFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*model.FeedbackItem, int, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetGivenFeedback")
+	defer span.End()
+
+	items, total, err := r.listFeedback(ctx, "f.author_id = $1 AND "+inOrganization("$1", "$2"), viewer, limit, offset)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, 0, errors.WrapError(err, "failed to get given feedback")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return items, total, nil
+}
+
+// listFeedback pages through feedback matching a predicate on f that
+// refers to the viewer's user ID as $1 and organization ID as $2, with
+// recipients, reactions and comment counts
+func (r *PostgresRepository) listFeedback(ctx context.Context, where string, viewer feedback.Viewer, limit, offset int) ([]*model.FeedbackItem, int, error) {
+	var total int
+	if err := r.db.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM feedback_items f WHERE `+where, viewer.UserID, viewer.OrganizationID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT ` + feedbackListColumns + `
+		FROM feedback_items f
+		JOIN users u ON f.author_id = u.id
+		` + recipientJoins + `
+		WHERE ` + where + `
+		ORDER BY f.created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, viewer.UserID, viewer.OrganizationID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items, err := r.scanFeedbackList(ctx, rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+// feedbackListColumns selects the columns scanFeedbackList reads
+const feedbackListColumns = `
+	f.feedback_id, f.content, f.type, f.visibility, f.is_anonymous, f.created_at,
+	u.id, u.name, ` + recipientColumns + `, ` + templateAnswerColumns
+
+// scanFeedbackList reads rows of feedbackListColumns, then loads each
+// item's reactions and comment count
+func (r *PostgresRepository) scanFeedbackList(ctx context.Context, rows pgx.Rows) ([]*model.FeedbackItem, error) {
+	items := []*model.FeedbackItem{}
+	for rows.Next() {
+		item := &model.FeedbackItem{}
+		var authorID, authorName string
+		var feedbackType, visibility *string
+		var isAnonymous *bool
+		var recipient recipientRow
+		var template templateRow
+
+		err := rows.Scan(
+			&item.FeedbackID,
+			&item.Content,
+			&feedbackType,
+			&visibility,
+			&isAnonymous,
+			&item.CreatedAt,
+			&authorID,
+			&authorName,
+			&recipient.Type,
+			&recipient.ID,
+			&recipient.Name,
+			&recipient.OrganizationID,
+			&item.OrganizationID,
+			&template.ID,
+			&template.Version,
+			&template.Responses,
+			&template.Dimensions,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := template.applyTo(item); err != nil {
+			return nil, err
+		}
+
+		item.Author = &authModel.UserSummary{ID: authorID, Name: authorName}
+		item.Recipient = recipient.toModel()
+		if feedbackType != nil {
+			ft := model.FeedbackType(*feedbackType)
+			item.Type = &ft
+		}
+		if visibility != nil {
+			v := model.FeedbackVisibility(*visibility)
+			item.Visibility = &v
+		}
+		if isAnonymous != nil {
+			item.IsAnonymous = *isAnonymous
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for _, item := range items {
+		item.Reactions, _ = r.GetReactionsCount(ctx, item.FeedbackID)
+		item.CommentsCount, _ = r.GetCommentsCount(ctx, item.FeedbackID)
+	}
+
+	return items, nil
+}
+
+// keysetAfter returns a predicate, to AND onto a WHERE clause, selecting the
+// rows that come after cursor in an ordering on (createdAt, id): op is "<"
+// for newest first and ">" for oldest first. The cursor's values are
+// appended to args. A nil cursor selects every row.
+func keysetAfter(createdAt, id, op string, cursor *feedback.Cursor, args []interface{}) (string, []interface{}) {
+	if cursor == nil {
+		return "", args
+	}
+
+	args = append(args, cursor.CreatedAt, cursor.ID)
+	n := len(args)
+	return ` AND (` + createdAt + `, ` + id + `) ` + op + ` ($` + strconv.Itoa(n-1) + `, $` + strconv.Itoa(n) + `)`, args
+}
+
+// feedbackPage drops the extra row fetched past limit, which only tells
+// whether another page follows, and returns the cursor for that page
+func feedbackPage(items []*model.FeedbackItem, limit int) ([]*model.FeedbackItem, string) {
+	if len(items) <= limit {
+		return items, ""
+	}
+
+	items = items[:limit]
+	last := items[len(items)-1]
+	return items, feedback.Cursor{CreatedAt: last.CreatedAt, ID: last.FeedbackID}.Encode()
+}
+
+// estimateRows returns the planner's estimate of how many rows query
+// returns, which unlike COUNT(*) doesn't read them
+func (r *PostgresRepository) estimateRows(ctx context.Context, query string, args ...interface{}) (int, error) {
+	var raw []byte
+	if err := r.db.Pool.QueryRow(ctx, `EXPLAIN (FORMAT JSON) `+query, args...).Scan(&raw); err != nil {
+		return 0, err
+	}
+
+	var plans []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(raw, &plans); err != nil {
+		return 0, err
+	}
+	if len(plans) == 0 {
+		return 0, nil
+	}
+
+	return int(plans[0].Plan.Rows), nil
+}
@@ -0,0 +1,593 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"ethos/internal/feedback"
+	"ethos/internal/feedback/model"
+	"ethos/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// templateColumns selects a template t at its version v, as scanTemplate reads them
+const templateColumns = `
+	t.template_id, t.name, COALESCE(t.description, ''), t.context_tags, t.template_fields,
+	t.status, v.version, v.fields, t.created_by, t.created_at, t.updated_at
+`
+
+// currentVersionJoin joins each template t to its current version v
+const currentVersionJoin = `JOIN feedback_template_versions v ON v.template_id = t.template_id AND v.version = t.current_version`
+
+// GetTemplates retrieves the published templates at their current version,
+// optionally only those tagged with a context or any of a comma-separated
+// list of tags
+func (r *PostgresRepository) GetTemplates(ctx context.Context, contextFilter, tagsFilter string) ([]*model.FeedbackTemplate, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetTemplates")
+	defer span.End()
+
+	whereConditions := []string{`t.status = $1`}
+	args := []interface{}{string(model.TemplateStatusPublished)}
+
+	if contextFilter != "" {
+		args = append(args, contextFilter)
+		whereConditions = append(whereConditions, `$`+strconv.Itoa(len(args))+` = ANY(t.context_tags)`)
+	}
+
+	if tagsFilter != "" {
+		var tags []string
+		for _, tag := range strings.Split(tagsFilter, ",") {
+			tags = append(tags, strings.TrimSpace(tag))
+		}
+		args = append(args, tags)
+		whereConditions = append(whereConditions, `t.context_tags && $`+strconv.Itoa(len(args)))
+	}
+
+	query := `
+		SELECT ` + templateColumns + `
+		FROM feedback_templates t
+		` + currentVersionJoin + `
+		WHERE ` + strings.Join(whereConditions, " AND ") + `
+		ORDER BY t.name`
+
+	templates, err := r.queryTemplates(ctx, query, args...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get feedback templates")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return templates, nil
+}
+
+// ListTemplates retrieves templates of any status, or of one status if it
+// isn't empty, at their current version
+func (r *PostgresRepository) ListTemplates(ctx context.Context, status model.TemplateStatus) ([]*model.FeedbackTemplate, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ListTemplates")
+	defer span.End()
+
+	query := `
+		SELECT ` + templateColumns + `
+		FROM feedback_templates t
+		` + currentVersionJoin + `
+		WHERE ($1 = '' OR t.status = $1)
+		ORDER BY t.name`
+
+	templates, err := r.queryTemplates(ctx, query, string(status))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list feedback templates")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return templates, nil
+}
+
+// GetTemplate retrieves a template at a version, or at its current version
+// if version is 0
+func (r *PostgresRepository) GetTemplate(ctx context.Context, templateID string, version int) (*model.FeedbackTemplate, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetTemplate")
+	defer span.End()
+
+	query := `
+		SELECT ` + templateColumns + `
+		FROM feedback_templates t
+		JOIN feedback_template_versions v ON v.template_id = t.template_id
+		WHERE t.template_id = $1 AND v.version = CASE WHEN $2 = 0 THEN t.current_version ELSE $2 END`
+
+	template, err := scanTemplate(r.db.Pool.QueryRow(ctx, query, templateID, version))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to get feedback template")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return template, nil
+}
+
+// CreateTemplate creates a template with its fields as version 1, and sets
+// its ID, version and timestamps
+func (r *PostgresRepository) CreateTemplate(ctx context.Context, template *model.FeedbackTemplate) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.CreateTemplate")
+	defer span.End()
+
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertTemplate(ctx, tx, template); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to create feedback template")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to commit transaction")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// UpdateTemplate saves a template's name, description, tags and status. If
+// fields isn't nil they become the template's next version, which the
+// template's Version and Fields are updated to.
+func (r *PostgresRepository) UpdateTemplate(ctx context.Context, template *model.FeedbackTemplate, fields []model.TemplateField, updatedBy string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.UpdateTemplate")
+	defer span.End()
+
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	version := template.Version
+	if fields != nil {
+		encoded, err := json.Marshal(fields)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return errors.WrapError(err, "failed to encode template fields")
+		}
+
+		// Locking the template row makes concurrent edits number their
+		// versions one after the other
+		err = tx.QueryRow(ctx, `
+			INSERT INTO feedback_template_versions (template_id, version, fields, created_by)
+			SELECT template_id, current_version + 1, $2, $3
+			FROM feedback_templates
+			WHERE template_id = $1
+			FOR UPDATE
+			RETURNING version`,
+			template.TemplateID, encoded, updatedBy,
+		).Scan(&version)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			if err == pgx.ErrNoRows {
+				return errors.ErrNotFound
+			}
+			return errors.WrapError(err, "failed to add feedback template version")
+		}
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE feedback_templates
+		SET name = $2, description = $3, context_tags = $4, status = $5, current_version = $6, updated_at = NOW()
+		WHERE template_id = $1
+		RETURNING updated_at`,
+		template.TemplateID, template.Name, template.Description, template.ContextTags, string(template.Status), version,
+	).Scan(&template.UpdatedAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if err == pgx.ErrNoRows {
+			return errors.ErrNotFound
+		}
+		return errors.WrapError(err, "failed to update feedback template")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to commit transaction")
+	}
+
+	if fields != nil {
+		template.Version = version
+		template.Fields = fields
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// SubmitTemplateSuggestion stores a template suggestion for review
+func (r *PostgresRepository) SubmitTemplateSuggestion(ctx context.Context, req *feedback.TemplateSuggestionRequest) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.SubmitTemplateSuggestion")
+	defer span.End()
+
+	desiredFields := req.DesiredFields
+	if desiredFields == nil {
+		desiredFields = []map[string]string{}
+	}
+	encoded, err := json.Marshal(desiredFields)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to encode desired fields")
+	}
+
+	_, err = r.db.Pool.Exec(ctx, `
+		INSERT INTO feedback_template_suggestions (suggested_by, usage_context, details, desired_fields)
+		VALUES ($1, $2, $3, $4)`,
+		req.SuggestedBy, req.UsageContext, req.Details, encoded,
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to submit template suggestion")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// suggestionColumns selects the columns scanSuggestion reads
+const suggestionColumns = `
+	suggestion_id::text, suggested_by, usage_context, details, desired_fields,
+	status, reviewed_by, reviewed_at, COALESCE(review_note, ''), template_id, created_at
+`
+
+// ListTemplateSuggestions retrieves template suggestions of any status, or
+// of one status if it isn't empty, oldest first
+func (r *PostgresRepository) ListTemplateSuggestions(ctx context.Context, status model.SuggestionStatus, limit, offset int) ([]*model.TemplateSuggestion, int, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ListTemplateSuggestions")
+	defer span.End()
+
+	var total int
+	err := r.db.Pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM feedback_template_suggestions
+		WHERE ($1 = '' OR status = $1)`,
+		string(status),
+	).Scan(&total)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, 0, errors.WrapError(err, "failed to count template suggestions")
+	}
+
+	rows, err := r.db.Pool.Query(ctx, `
+		SELECT `+suggestionColumns+`
+		FROM feedback_template_suggestions
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at ASC, suggestion_id ASC
+		LIMIT $2 OFFSET $3`,
+		string(status), limit, offset,
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, 0, errors.WrapError(err, "failed to list template suggestions")
+	}
+	defer rows.Close()
+
+	suggestions := []*model.TemplateSuggestion{}
+	for rows.Next() {
+		suggestion, err := scanSuggestion(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, 0, errors.WrapError(err, "failed to read template suggestion")
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, 0, errors.WrapError(err, "failed to iterate template suggestions")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return suggestions, total, nil
+}
+
+// GetTemplateSuggestion retrieves a template suggestion
+func (r *PostgresRepository) GetTemplateSuggestion(ctx context.Context, suggestionID string) (*model.TemplateSuggestion, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetTemplateSuggestion")
+	defer span.End()
+
+	if _, err := uuid.Parse(suggestionID); err != nil {
+		return nil, errors.ErrNotFound
+	}
+
+	suggestion, err := scanSuggestion(r.db.Pool.QueryRow(ctx, `
+		SELECT `+suggestionColumns+`
+		FROM feedback_template_suggestions
+		WHERE suggestion_id = $1`,
+		suggestionID,
+	))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to get template suggestion")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return suggestion, nil
+}
+
+// ApproveTemplateSuggestion creates a template from a pending suggestion
+// and marks the suggestion approved, linked to it. A suggestion that has
+// already been reviewed is left alone.
+func (r *PostgresRepository) ApproveTemplateSuggestion(ctx context.Context, suggestionID, reviewerID string, template *model.FeedbackTemplate) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ApproveTemplateSuggestion")
+	defer span.End()
+
+	if _, err := uuid.Parse(suggestionID); err != nil {
+		return errors.ErrNotFound
+	}
+
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertTemplate(ctx, tx, template); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to create feedback template")
+	}
+
+	result, err := tx.Exec(ctx, `
+		UPDATE feedback_template_suggestions
+		SET status = $2, reviewed_by = $3, reviewed_at = NOW(), template_id = $4
+		WHERE suggestion_id = $1 AND status = $5`,
+		suggestionID, string(model.SuggestionStatusApproved), reviewerID, template.TemplateID, string(model.SuggestionStatusPending),
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to approve template suggestion")
+	}
+	if result.RowsAffected() == 0 {
+		return r.unreviewableSuggestion(ctx, suggestionID)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to commit transaction")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// RejectTemplateSuggestion marks a pending suggestion rejected, with a note
+// for the user who made it
+func (r *PostgresRepository) RejectTemplateSuggestion(ctx context.Context, suggestionID, reviewerID, note string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.RejectTemplateSuggestion")
+	defer span.End()
+
+	if _, err := uuid.Parse(suggestionID); err != nil {
+		return errors.ErrNotFound
+	}
+
+	result, err := r.db.Pool.Exec(ctx, `
+		UPDATE feedback_template_suggestions
+		SET status = $2, reviewed_by = $3, reviewed_at = NOW(), review_note = NULLIF($4, '')
+		WHERE suggestion_id = $1 AND status = $5`,
+		suggestionID, string(model.SuggestionStatusRejected), reviewerID, note, string(model.SuggestionStatusPending),
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to reject template suggestion")
+	}
+	if result.RowsAffected() == 0 {
+		return r.unreviewableSuggestion(ctx, suggestionID)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// unreviewableSuggestion explains why a review changed no suggestion: it
+// doesn't exist, or it has already been reviewed
+func (r *PostgresRepository) unreviewableSuggestion(ctx context.Context, suggestionID string) error {
+	var exists bool
+	err := r.db.Pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM feedback_template_suggestions WHERE suggestion_id = $1)`, suggestionID).Scan(&exists)
+	if err != nil {
+		return errors.WrapError(err, "failed to get template suggestion")
+	}
+	if !exists {
+		return errors.ErrNotFound
+	}
+	return errors.ErrSuggestionAlreadyReviewed
+}
+
+// insertTemplate inserts a template and its fields as version 1 in tx, and
+// sets its ID, version and timestamps
+func insertTemplate(ctx context.Context, tx pgx.Tx, template *model.FeedbackTemplate) error {
+	encoded, err := json.Marshal(template.Fields)
+	if err != nil {
+		return err
+	}
+
+	template.TemplateID = "tmpl-" + uuid.New().String()
+	template.Version = 1
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO feedback_templates (template_id, name, description, context_tags, status, current_version, created_by)
+		VALUES ($1, $2, $3, $4, $5, 1, $6)
+		RETURNING created_at, updated_at`,
+		template.TemplateID, template.Name, template.Description, template.ContextTags, string(template.Status), template.CreatedBy,
+	).Scan(&template.CreatedAt, &template.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO feedback_template_versions (template_id, version, fields, created_by)
+		VALUES ($1, 1, $2, $3)`,
+		template.TemplateID, encoded, template.CreatedBy,
+	)
+	return err
+}
+
+// queryTemplates runs a query selecting templateColumns
+func (r *PostgresRepository) queryTemplates(ctx context.Context, query string, args ...interface{}) ([]*model.FeedbackTemplate, error) {
+	rows, err := r.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := []*model.FeedbackTemplate{}
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
+
+// scanTemplate reads a row of templateColumns
+func scanTemplate(row pgx.Row) (*model.FeedbackTemplate, error) {
+	template := &model.FeedbackTemplate{}
+	var status string
+	var templateFields, fields []byte
+	var createdAt, updatedAt *time.Time
+
+	err := row.Scan(
+		&template.TemplateID,
+		&template.Name,
+		&template.Description,
+		&template.ContextTags,
+		&templateFields,
+		&status,
+		&template.Version,
+		&fields,
+		&template.CreatedBy,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	template.Status = model.TemplateStatus(status)
+	if createdAt != nil {
+		template.CreatedAt = *createdAt
+	}
+	if updatedAt != nil {
+		template.UpdatedAt = *updatedAt
+	}
+	if len(templateFields) > 0 {
+		if err := json.Unmarshal(templateFields, &template.TemplateFields); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal(fields, &template.Fields); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// scanSuggestion reads a row of suggestionColumns
+func scanSuggestion(row pgx.Row) (*model.TemplateSuggestion, error) {
+	suggestion := &model.TemplateSuggestion{}
+	var status string
+	var desiredFields []byte
+	var createdAt *time.Time
+
+	err := row.Scan(
+		&suggestion.SuggestionID,
+		&suggestion.SuggestedBy,
+		&suggestion.UsageContext,
+		&suggestion.Details,
+		&desiredFields,
+		&status,
+		&suggestion.ReviewedBy,
+		&suggestion.ReviewedAt,
+		&suggestion.ReviewNote,
+		&suggestion.TemplateID,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestion.Status = model.SuggestionStatus(status)
+	if createdAt != nil {
+		suggestion.CreatedAt = *createdAt
+	}
+	if err := json.Unmarshal(desiredFields, &suggestion.DesiredFields); err != nil {
+		return nil, err
+	}
+
+	return suggestion, nil
+}
+
+// templateAnswerColumns selects the template version feedback in f was
+// written with and its answers, as templateRow reads them
+const templateAnswerColumns = `f.template_id, f.template_version, f.template_responses, f.dimensions`
+
+type templateRow struct {
+	ID         *string
+	Version    *int
+	Responses  []byte
+	Dimensions []byte
+}
+
+// applyTo sets the template, answers and dimension scores of feedback
+// written with a template
+func (r templateRow) applyTo(item *model.FeedbackItem) error {
+	if r.ID == nil || r.Version == nil {
+		return nil
+	}
+	item.Template = &model.TemplateReference{TemplateID: *r.ID, Version: *r.Version}
+	if len(r.Responses) > 0 {
+		if err := json.Unmarshal(r.Responses, &item.Responses); err != nil {
+			return err
+		}
+	}
+	if len(r.Dimensions) > 0 {
+		if err := json.Unmarshal(r.Dimensions, &item.Dimensions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
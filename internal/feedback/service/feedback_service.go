@@ -13,6 +13,21 @@ type CreateFeedbackRequest struct {
 	Content    string                    `json:"content" binding:"required"`
 	Type       *model.FeedbackType       `json:"type,omitempty"`
 	Visibility *model.FeedbackVisibility `json:"visibility,omitempty"`
+	Recipient  *RecipientRequest         `json:"recipient,omitempty"`
+	// OrganizationID scopes feedback to a user to an organization both
+	// belong to. Team and organization recipients set it themselves.
+	OrganizationID *string `json:"organization_id,omitempty"`
+	// TemplateID picks a published template whose fields Responses answer,
+	// at TemplateVersion or, if that's 0, its current version
+	TemplateID      *string                `json:"template_id,omitempty"`
+	TemplateVersion int                    `json:"template_version,omitempty"`
+	Responses       map[string]interface{} `json:"responses,omitempty"`
+}
+
+// RecipientRequest identifies who feedback is addressed to
+type RecipientRequest struct {
+	Type model.RecipientType `json:"type" binding:"required,oneof=user team organization"`
+	ID   string              `json:"id" binding:"required"`
 }
 
 // CreateCommentRequest represents a request to create a comment
@@ -40,26 +55,32 @@ type UpdateCommentRequest struct {
 
 // Service defines the interface for feedback business logic
 type Service interface {
-	// GetFeed retrieves a paginated feed of feedback items
-	GetFeed(ctx context.Context, limit, offset int) ([]*model.FeedbackItem, int, error)
+	// GetFeed retrieves a page of the feedback a viewer may see in their current organization, newest first
+	GetFeed(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest) ([]*model.FeedbackItem, *feedback.PageInfo, error)
+
+	// GetFeedbackByID retrieves a feedback item by ID if the viewer may see it
+	GetFeedbackByID(ctx context.Context, viewer feedback.Viewer, feedbackID string) (*model.FeedbackItem, error)
 
-	// GetFeedbackByID retrieves a feedback item by ID
-	GetFeedbackByID(ctx context.Context, feedbackID string) (*model.FeedbackItem, error)
+	// GetComments retrieves a page of the comments on a feedback item the viewer may see, oldest first
+	GetComments(ctx context.Context, viewer feedback.Viewer, feedbackID string, page feedback.PageRequest) ([]*model.FeedbackComment, *feedback.PageInfo, error)
 
-	// GetComments retrieves comments for a feedback item
-	GetComments(ctx context.Context, feedbackID string, limit, offset int) ([]*model.FeedbackComment, int, error)
+	// GetReceivedFeedback retrieves feedback addressed to a viewer, their teams and the organizations they administer
+	GetReceivedFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*model.FeedbackItem, int, error)
+
+	// GetGivenFeedback retrieves feedback a viewer has written
+	GetGivenFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*model.FeedbackItem, int, error)
 
 	// CreateFeedback creates a new feedback item
 	CreateFeedback(ctx context.Context, userID string, req *CreateFeedbackRequest) (*model.FeedbackItem, error)
 
-	// CreateComment creates a new comment on a feedback item
-	CreateComment(ctx context.Context, userID, feedbackID string, req *CreateCommentRequest) (*model.FeedbackComment, error)
+	// CreateComment creates a new comment on a feedback item the viewer may see
+	CreateComment(ctx context.Context, viewer feedback.Viewer, feedbackID string, req *CreateCommentRequest) (*model.FeedbackComment, error)
 
-	// AddReaction adds a reaction to a feedback item
-	AddReaction(ctx context.Context, userID, feedbackID string, reactionType string) error
+	// AddReaction adds a reaction to a feedback item the viewer may see
+	AddReaction(ctx context.Context, viewer feedback.Viewer, feedbackID string, reactionType string) error
 
-	// RemoveReaction removes a reaction from a feedback item
-	RemoveReaction(ctx context.Context, userID, feedbackID string, reactionType string) error
+	// RemoveReaction removes a reaction from a feedback item the viewer may see
+	RemoveReaction(ctx context.Context, viewer feedback.Viewer, feedbackID string, reactionType string) error
 
 	// GetTemplates retrieves feedback templates with optional filtering
 	GetTemplates(ctx context.Context, contextFilter, tagsFilter string) ([]*model.FeedbackTemplate, error)
@@ -67,26 +88,29 @@ type Service interface {
 	// SubmitTemplateSuggestion submits a template suggestion
 	SubmitTemplateSuggestion(ctx context.Context, req *feedback.TemplateSuggestionRequest) error
 
-	// GetImpact retrieves aggregated feedback analytics
-	GetImpact(ctx context.Context, userID *string, from, to *time.Time) (*model.FeedbackImpact, error)
+	// GetImpact retrieves aggregated analytics over the feedback a viewer may see
+	GetImpact(ctx context.Context, viewer feedback.Viewer, userID *string, from, to *time.Time) (*model.FeedbackImpact, error)
 
 	// CreateBatchFeedback creates multiple feedback items in a batch
 	CreateBatchFeedback(ctx context.Context, userID string, req *feedback.BatchFeedbackRequest) (*feedback.BatchFeedbackResponse, error)
 
-	// GetFeedWithFilters retrieves a paginated feed of feedback items with enhanced filtering
-	GetFeedWithFilters(ctx context.Context, limit, offset int, filters *feedback.FeedFilters) ([]*model.FeedbackItem, int, error)
+	// GetFeedWithFilters retrieves a page of the feedback a viewer may see, newest first, with enhanced filtering
+	GetFeedWithFilters(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest, filters *feedback.FeedFilters) ([]*model.FeedbackItem, *feedback.PageInfo, error)
+
+	// SearchFeedback ranks the feedback a viewer may see by how well its content matches a full-text query
+	SearchFeedback(ctx context.Context, viewer feedback.Viewer, req *feedback.SearchRequest) ([]*model.FeedbackSearchResult, int, error)
 
-	// GetBookmarks retrieves bookmarked feedback items for a user
-	GetBookmarks(ctx context.Context, userID string, limit, offset int) ([]*model.FeedbackItem, int, error)
+	// GetBookmarks retrieves a viewer's bookmarked feedback items that they may still see
+	GetBookmarks(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*model.FeedbackItem, int, error)
 
-	// AddBookmark adds a bookmark for a feedback item
-	AddBookmark(ctx context.Context, userID, feedbackID string) error
+	// AddBookmark adds a bookmark for a feedback item the viewer may see
+	AddBookmark(ctx context.Context, viewer feedback.Viewer, feedbackID string) error
 
 	// RemoveBookmark removes a bookmark for a feedback item
 	RemoveBookmark(ctx context.Context, userID, feedbackID string) error
 
-	// ExportFeedback exports feedback data with optional filtering
-	ExportFeedback(ctx context.Context, filters *feedback.FeedFilters, format string) (*feedback.ExportResponse, error)
+	// ExportFeedback exports the feedback a viewer may see, with optional filtering
+	ExportFeedback(ctx context.Context, viewer feedback.Viewer, filters *feedback.FeedFilters, format string) (*feedback.ExportResponse, error)
 
 	// UpdateFeedback updates an existing feedback item
 	UpdateFeedback(ctx context.Context, userID, feedbackID string, req *UpdateFeedbackRequest) (*model.FeedbackItem, error)
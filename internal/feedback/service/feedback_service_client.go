@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"ethos/internal/feedback"
 	fbModel "ethos/internal/feedback/model"
 	"ethos/internal/feedback/repository"
 	feedbackpb "ethos/api/proto/feedback"
@@ -11,14 +12,14 @@ import (
 
 // FeedbackClient defines the interface for feedback data access (REST or gRPC)
 type FeedbackClient interface {
-	// GetFeed retrieves a paginated feed of feedback posts
-	GetFeed(ctx context.Context, limit, offset int) ([]*fbModel.FeedbackItem, int, error)
+	// GetFeed retrieves a page of the feedback a viewer may see, newest first
+	GetFeed(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest) ([]*fbModel.FeedbackItem, *feedback.PageInfo, error)
 	
 	// GetFeedbackByID retrieves a specific feedback item
 	GetFeedbackByID(ctx context.Context, feedbackID string) (*fbModel.FeedbackItem, error)
 	
-	// GetComments retrieves comments for a feedback item
-	GetComments(ctx context.Context, feedbackID string, limit, offset int) ([]*fbModel.FeedbackComment, int, error)
+	// GetComments retrieves a page of the comments on a feedback item, oldest first
+	GetComments(ctx context.Context, feedbackID string, page feedback.PageRequest) ([]*fbModel.FeedbackComment, *feedback.PageInfo, error)
 }
 
 // RESTFeedbackClient implements FeedbackClient using REST (current repository)
@@ -32,8 +33,8 @@ func NewRESTFeedbackClient(repo repository.Repository) FeedbackClient {
 }
 
 // GetFeed implements FeedbackClient interface using REST
-func (c *RESTFeedbackClient) GetFeed(ctx context.Context, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
-	return c.repo.GetFeed(ctx, limit, offset)
+func (c *RESTFeedbackClient) GetFeed(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest) ([]*fbModel.FeedbackItem, *feedback.PageInfo, error) {
+	return c.repo.GetFeed(ctx, viewer, page)
 }
 
 // GetFeedbackByID implements FeedbackClient interface using REST
@@ -42,11 +43,13 @@ func (c *RESTFeedbackClient) GetFeedbackByID(ctx context.Context, feedbackID str
 }
 
 // GetComments implements FeedbackClient interface using REST
-func (c *RESTFeedbackClient) GetComments(ctx context.Context, feedbackID string, limit, offset int) ([]*fbModel.FeedbackComment, int, error) {
-	return c.repo.GetComments(ctx, feedbackID, limit, offset)
+func (c *RESTFeedbackClient) GetComments(ctx context.Context, feedbackID string, page feedback.PageRequest) ([]*fbModel.FeedbackComment, *feedback.PageInfo, error) {
+	return c.repo.GetComments(ctx, feedbackID, page)
 }
 
-// GRPCFeedbackClient implements FeedbackClient using gRPC
+// GRPCFeedbackClient implements FeedbackClient using gRPC. The service
+// checks a viewer may see a feedback item with the repository before reading
+// it or its comments; feed requests carry the viewer for the server to scope.
 type GRPCFeedbackClient struct {
 	client feedbackpb.FeedbackServiceClient
 }
@@ -57,15 +60,18 @@ func NewGRPCFeedbackClient(client feedbackpb.FeedbackServiceClient) FeedbackClie
 }
 
 // GetFeed implements FeedbackClient interface using gRPC
-func (c *GRPCFeedbackClient) GetFeed(ctx context.Context, limit, offset int) ([]*fbModel.FeedbackItem, int, error) {
+func (c *GRPCFeedbackClient) GetFeed(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest) ([]*fbModel.FeedbackItem, *feedback.PageInfo, error) {
 	req := &feedbackpb.GetFeedRequest{
-		Limit:  int32(limit),
-		Offset: int32(offset),
+		Limit:                int32(page.Limit),
+		Cursor:               encodeCursor(page.After),
+		IncludeTotal:         page.IncludeTotal,
+		ViewerUserId:         viewer.UserID,
+		ViewerOrganizationId: viewer.OrganizationID,
 	}
 
 	resp, err := c.client.GetFeed(ctx, req)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
 	items := make([]*fbModel.FeedbackItem, 0, len(resp.Results))
@@ -76,7 +82,7 @@ func (c *GRPCFeedbackClient) GetFeed(ctx context.Context, limit, offset int) ([]
 		}
 	}
 
-	return items, int(resp.Count), nil
+	return items, grpcPageInfo(page, resp.NextCursor, resp.Count), nil
 }
 
 // GetFeedbackByID implements FeedbackClient interface using gRPC
@@ -94,16 +100,17 @@ func (c *GRPCFeedbackClient) GetFeedbackByID(ctx context.Context, feedbackID str
 }
 
 // GetComments implements FeedbackClient interface using gRPC
-func (c *GRPCFeedbackClient) GetComments(ctx context.Context, feedbackID string, limit, offset int) ([]*fbModel.FeedbackComment, int, error) {
+func (c *GRPCFeedbackClient) GetComments(ctx context.Context, feedbackID string, page feedback.PageRequest) ([]*fbModel.FeedbackComment, *feedback.PageInfo, error) {
 	req := &feedbackpb.GetCommentsRequest{
-		FeedbackId: feedbackID,
-		Limit:      int32(limit),
-		Offset:     int32(offset),
+		FeedbackId:   feedbackID,
+		Limit:        int32(page.Limit),
+		Cursor:       encodeCursor(page.After),
+		IncludeTotal: page.IncludeTotal,
 	}
 
 	resp, err := c.client.GetComments(ctx, req)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
 	comments := make([]*fbModel.FeedbackComment, 0, len(resp.Comments))
@@ -114,6 +121,25 @@ func (c *GRPCFeedbackClient) GetComments(ctx context.Context, feedbackID string,
 		}
 	}
 
-	return comments, int(resp.Count), nil
+	return comments, grpcPageInfo(page, resp.NextCursor, resp.Count), nil
+}
+
+// encodeCursor returns the token for a cursor, or "" for the first page
+func encodeCursor(cursor *feedback.Cursor) string {
+	if cursor == nil {
+		return ""
+	}
+	return cursor.Encode()
+}
+
+// grpcPageInfo builds page info from a gRPC response, whose count is only
+// an approximate total when the request included one
+func grpcPageInfo(page feedback.PageRequest, nextCursor string, count int32) *feedback.PageInfo {
+	info := &feedback.PageInfo{NextCursor: nextCursor}
+	if page.IncludeTotal {
+		total := int(count)
+		info.ApproximateTotal = &total
+	}
+	return info
 }
 
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	feedbackpb "ethos/api/proto/feedback"
+	"ethos/internal/feedback"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeFeedbackServer answers feed requests with a fixed response and
+// records the request it was sent
+type fakeFeedbackServer struct {
+	feedbackpb.UnimplementedFeedbackServiceServer
+	feed *feedbackpb.GetFeedResponse
+	req  *feedbackpb.GetFeedRequest
+}
+
+func (s *fakeFeedbackServer) GetFeed(ctx context.Context, req *feedbackpb.GetFeedRequest) (*feedbackpb.GetFeedResponse, error) {
+	s.req = req
+	return s.feed, nil
+}
+
+// newGRPCTestClient serves the fake server in memory and returns a client connected to it
+func newGRPCTestClient(t *testing.T, server feedbackpb.FeedbackServiceServer) FeedbackClient {
+	t.Helper()
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	feedbackpb.RegisterFeedbackServiceServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return NewGRPCFeedbackClient(feedbackpb.NewFeedbackServiceClient(conn))
+}
+
+func TestGRPCFeedbackClient_GetFeedSendsTheViewerAndCursor(t *testing.T) {
+	server := &fakeFeedbackServer{feed: &feedbackpb.GetFeedResponse{
+		Results:    []*feedbackpb.FeedbackItem{{FeedbackId: "fb-2", Content: "Great demo"}},
+		Count:      7,
+		NextCursor: "next-page",
+	}}
+	client := newGRPCTestClient(t, server)
+
+	after := &feedback.Cursor{CreatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), ID: "fb-1"}
+	items, info, err := client.GetFeed(context.Background(),
+		feedback.Viewer{UserID: "user-1", OrganizationID: "org-1"},
+		feedback.PageRequest{Limit: 20, After: after, IncludeTotal: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "user-1", server.req.ViewerUserId)
+	assert.Equal(t, "org-1", server.req.ViewerOrganizationId)
+	assert.Equal(t, int32(20), server.req.Limit)
+	assert.Equal(t, after.Encode(), server.req.Cursor)
+	assert.True(t, server.req.IncludeTotal)
+
+	require.Len(t, items, 1)
+	assert.Equal(t, "fb-2", items[0].FeedbackID)
+	assert.Equal(t, "next-page", info.NextCursor)
+	require.NotNil(t, info.ApproximateTotal)
+	assert.Equal(t, 7, *info.ApproximateTotal)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/csv"
 	"encoding/json"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -11,59 +12,262 @@ import (
 	feedbackPkg "ethos/internal/feedback"
 	"ethos/internal/feedback/model"
 	"ethos/internal/feedback/repository"
+	notificationModel "ethos/internal/notifications/model"
 	"ethos/pkg/errors"
 )
 
+// MembershipChecker checks organization membership (implemented by the organization context repository)
+type MembershipChecker interface {
+	IsUserInOrganization(ctx context.Context, userID, organizationID string) (bool, error)
+}
+
+// Notifier notifies recipients about feedback addressed to them (implemented by the notifications repository)
+type Notifier interface {
+	CreateNotification(ctx context.Context, userID string, notificationType notificationModel.NotificationType, message string) error
+}
+
 // FeedbackService implements the Service interface
 type FeedbackService struct {
-	client FeedbackClient        // Can be REST or gRPC client
-	repo   repository.Repository // Kept for write operations (CreateFeedback, CreateComment, AddReaction, RemoveReaction)
+	client   FeedbackClient        // Can be REST or gRPC client
+	repo     repository.Repository // Kept for write operations (CreateFeedback, CreateComment, AddReaction, RemoveReaction)
+	members  MembershipChecker
+	notifier Notifier
 }
 
 // NewFeedbackService creates a new feedback service with REST client
-func NewFeedbackService(repo repository.Repository) Service {
+func NewFeedbackService(repo repository.Repository, members MembershipChecker, notifier Notifier) Service {
 	return &FeedbackService{
-		client: NewRESTFeedbackClient(repo),
-		repo:   repo,
+		client:   NewRESTFeedbackClient(repo),
+		repo:     repo,
+		members:  members,
+		notifier: notifier,
 	}
 }
 
 // NewFeedbackServiceWithClient creates a feedback service with a custom client (REST or gRPC)
-func NewFeedbackServiceWithClient(client FeedbackClient, repo repository.Repository) Service {
+func NewFeedbackServiceWithClient(client FeedbackClient, repo repository.Repository, members MembershipChecker, notifier Notifier) Service {
 	return &FeedbackService{
-		client: client,
-		repo:   repo,
+		client:   client,
+		repo:     repo,
+		members:  members,
+		notifier: notifier,
 	}
 }
 
-// GetFeed retrieves a paginated feed of feedback items
-func (s *FeedbackService) GetFeed(ctx context.Context, limit, offset int) ([]*model.FeedbackItem, int, error) {
-	return s.client.GetFeed(ctx, limit, offset)
+// GetFeed retrieves a page of the feedback a viewer may see in their
+// current organization, newest first
+func (s *FeedbackService) GetFeed(ctx context.Context, viewer feedbackPkg.Viewer, page feedbackPkg.PageRequest) ([]*model.FeedbackItem, *feedbackPkg.PageInfo, error) {
+	return s.client.GetFeed(ctx, viewer, page)
 }
 
-// GetFeedbackByID retrieves a feedback item by ID
-func (s *FeedbackService) GetFeedbackByID(ctx context.Context, feedbackID string) (*model.FeedbackItem, error) {
+// GetFeedbackByID retrieves a feedback item by ID. Feedback the viewer
+// can't see, including feedback of another organization, is reported as
+// not found.
+func (s *FeedbackService) GetFeedbackByID(ctx context.Context, viewer feedbackPkg.Viewer, feedbackID string) (*model.FeedbackItem, error) {
+	if err := s.checkVisible(ctx, viewer, feedbackID); err != nil {
+		return nil, err
+	}
 	return s.client.GetFeedbackByID(ctx, feedbackID)
 }
 
-// GetComments retrieves comments for a feedback item
-func (s *FeedbackService) GetComments(ctx context.Context, feedbackID string, limit, offset int) ([]*model.FeedbackComment, int, error) {
-	return s.client.GetComments(ctx, feedbackID, limit, offset)
+// GetComments retrieves a page of the comments on a feedback item the
+// viewer may see, oldest first
+func (s *FeedbackService) GetComments(ctx context.Context, viewer feedbackPkg.Viewer, feedbackID string, page feedbackPkg.PageRequest) ([]*model.FeedbackComment, *feedbackPkg.PageInfo, error) {
+	if err := s.checkVisible(ctx, viewer, feedbackID); err != nil {
+		return nil, nil, err
+	}
+	return s.client.GetComments(ctx, feedbackID, page)
 }
 
-// CreateFeedback creates a new feedback item
+// GetReceivedFeedback retrieves feedback addressed to a viewer, their teams
+// and the organizations they administer
+func (s *FeedbackService) GetReceivedFeedback(ctx context.Context, viewer feedbackPkg.Viewer, limit, offset int) ([]*model.FeedbackItem, int, error) {
+	return s.repo.GetReceivedFeedback(ctx, viewer, limit, offset)
+}
+
+// GetGivenFeedback retrieves feedback a viewer has written
+func (s *FeedbackService) GetGivenFeedback(ctx context.Context, viewer feedbackPkg.Viewer, limit, offset int) ([]*model.FeedbackItem, int, error) {
+	return s.repo.GetGivenFeedback(ctx, viewer, limit, offset)
+}
+
+// CreateFeedback creates a new feedback item. Feedback addressed to a team
+// or organization is scoped to that organization, and its recipients are
+// notified. Feedback written with a template must answer its fields.
 func (s *FeedbackService) CreateFeedback(ctx context.Context, userID string, req *CreateFeedbackRequest) (*model.FeedbackItem, error) {
-	item, err := s.repo.CreateFeedback(ctx, userID, req.Content, req.Type, req.Visibility)
+	recipient, organizationID, err := s.resolveRecipient(ctx, userID, req)
 	if err != nil {
 		return nil, err
 	}
+	if err := validateVisibility(req.Visibility, organizationID); err != nil {
+		return nil, err
+	}
+
+	submission, err := s.templateSubmission(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.repo.CreateFeedback(ctx, userID, req.Content, req.Type, req.Visibility, recipient, organizationID, submission)
+	if err != nil {
+		return nil, err
+	}
+
+	if recipient != nil {
+		s.notifyRecipient(ctx, userID, recipient)
+	}
 
 	return item, nil
 }
 
-// CreateComment creates a new comment on a feedback item
-func (s *FeedbackService) CreateComment(ctx context.Context, userID, feedbackID string, req *CreateCommentRequest) (*model.FeedbackComment, error) {
-	comment, err := s.repo.CreateComment(ctx, userID, feedbackID, req.Content, req.ParentCommentID)
+// validateVisibility rejects team visibility for feedback outside an
+// organization, which has no team to be visible to
+func validateVisibility(visibility *model.FeedbackVisibility, organizationID *string) error {
+	if visibility != nil && *visibility == model.FeedbackVisibilityTeam && (organizationID == nil || *organizationID == "") {
+		return errors.NewValidationError("team visibility needs an organization")
+	}
+	return nil
+}
+
+// templateSubmission validates the answers to the template feedback is
+// written with. Only published templates can be used, at any of their
+// versions. Feedback without a template has no submission.
+func (s *FeedbackService) templateSubmission(ctx context.Context, req *CreateFeedbackRequest) (*model.TemplateSubmission, error) {
+	if req.TemplateID == nil {
+		if len(req.Responses) > 0 {
+			return nil, errors.NewValidationError("responses need a template_id")
+		}
+		return nil, nil
+	}
+	if req.TemplateVersion < 0 {
+		return nil, errors.NewValidationError("template_version must be positive")
+	}
+
+	template, err := s.repo.GetTemplate(ctx, *req.TemplateID, req.TemplateVersion)
+	if err == errors.ErrNotFound {
+		return nil, errors.NewValidationError("template not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if template.Status != model.TemplateStatusPublished {
+		return nil, errors.NewValidationError("template is not published")
+	}
+
+	dimensions, err := validateResponses(template.Fields, req.Responses)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TemplateSubmission{
+		TemplateReference: model.TemplateReference{TemplateID: template.TemplateID, Version: template.Version},
+		Responses:         req.Responses,
+		Dimensions:        dimensions,
+	}, nil
+}
+
+// resolveRecipient looks up who feedback is addressed to and the
+// organization it's scoped to. The author must belong to that
+// organization, and so must a user recipient.
+func (s *FeedbackService) resolveRecipient(ctx context.Context, userID string, req *CreateFeedbackRequest) (*model.FeedbackRecipient, *string, error) {
+	var organizationID string
+	if req.OrganizationID != nil {
+		organizationID = *req.OrganizationID
+	}
+
+	var recipient *model.FeedbackRecipient
+	if req.Recipient != nil {
+		if req.Recipient.Type == model.RecipientTypeUser && req.Recipient.ID == userID {
+			return nil, nil, errors.NewValidationError("feedback can't be addressed to yourself")
+		}
+
+		found, err := s.repo.GetRecipient(ctx, req.Recipient.Type, req.Recipient.ID)
+		if err != nil {
+			if err == errors.ErrNotFound {
+				return nil, nil, errors.NewValidationError("recipient not found")
+			}
+			return nil, nil, err
+		}
+		recipient = found
+
+		if recipient.OrganizationID != "" {
+			if organizationID != "" && organizationID != recipient.OrganizationID {
+				return nil, nil, errors.NewValidationError("organization_id doesn't match the recipient's organization")
+			}
+			organizationID = recipient.OrganizationID
+		}
+	}
+
+	if organizationID == "" {
+		return recipient, nil, nil
+	}
+
+	isMember, err := s.members.IsUserInOrganization(ctx, userID, organizationID)
+	if err != nil {
+		return nil, nil, errors.WrapError(err, "failed to check organization membership")
+	}
+	if !isMember {
+		return nil, nil, errors.ErrForbidden
+	}
+
+	if recipient != nil && recipient.Type == model.RecipientTypeUser {
+		isMember, err := s.members.IsUserInOrganization(ctx, recipient.ID, organizationID)
+		if err != nil {
+			return nil, nil, errors.WrapError(err, "failed to check organization membership")
+		}
+		if !isMember {
+			return nil, nil, errors.NewValidationError("recipient is not a member of this organization")
+		}
+	}
+
+	return recipient, &organizationID, nil
+}
+
+// notifyRecipient tells everyone who receives feedback addressed to a
+// recipient about it, except its author
+func (s *FeedbackService) notifyRecipient(ctx context.Context, authorID string, recipient *model.FeedbackRecipient) {
+	userIDs, err := s.repo.GetRecipientUserIDs(ctx, recipient)
+	if err != nil {
+		fmt.Printf("Failed to list feedback recipients: %v\n", err)
+		return
+	}
+
+	message := "You received new feedback"
+	switch recipient.Type {
+	case model.RecipientTypeTeam:
+		message = fmt.Sprintf("Your team %s received new feedback", recipient.Name)
+	case model.RecipientTypeOrganization:
+		message = fmt.Sprintf("%s received new feedback", recipient.Name)
+	}
+
+	for _, userID := range userIDs {
+		if userID == authorID {
+			continue
+		}
+		if err := s.notifier.CreateNotification(ctx, userID, notificationModel.NotificationTypeFeedbackReceived, message); err != nil {
+			fmt.Printf("Failed to notify feedback recipient: %v\n", err)
+		}
+	}
+}
+
+// checkVisible returns ErrNotFound unless a feedback item exists and the viewer may see it
+func (s *FeedbackService) checkVisible(ctx context.Context, viewer feedbackPkg.Viewer, feedbackID string) error {
+	visible, err := s.repo.CanViewFeedback(ctx, viewer, feedbackID)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// CreateComment creates a new comment on a feedback item the viewer may see
+func (s *FeedbackService) CreateComment(ctx context.Context, viewer feedbackPkg.Viewer, feedbackID string, req *CreateCommentRequest) (*model.FeedbackComment, error) {
+	if err := s.checkVisible(ctx, viewer, feedbackID); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.repo.CreateComment(ctx, viewer.UserID, feedbackID, req.Content, req.ParentCommentID)
 	if err != nil {
 		return nil, err
 	}
@@ -71,9 +275,13 @@ func (s *FeedbackService) CreateComment(ctx context.Context, userID, feedbackID
 	return comment, nil
 }
 
-// AddReaction adds a reaction to a feedback item
-func (s *FeedbackService) AddReaction(ctx context.Context, userID, feedbackID string, reactionType string) error {
-	err := s.repo.AddReaction(ctx, userID, feedbackID, reactionType)
+// AddReaction adds a reaction to a feedback item the viewer may see
+func (s *FeedbackService) AddReaction(ctx context.Context, viewer feedbackPkg.Viewer, feedbackID string, reactionType string) error {
+	if err := s.checkVisible(ctx, viewer, feedbackID); err != nil {
+		return err
+	}
+
+	err := s.repo.AddReaction(ctx, viewer.UserID, feedbackID, reactionType)
 	if err != nil {
 		return err
 	}
@@ -81,9 +289,13 @@ func (s *FeedbackService) AddReaction(ctx context.Context, userID, feedbackID st
 	return nil
 }
 
-// RemoveReaction removes a reaction from a feedback item
-func (s *FeedbackService) RemoveReaction(ctx context.Context, userID, feedbackID string, reactionType string) error {
-	err := s.repo.RemoveReaction(ctx, userID, feedbackID, reactionType)
+// RemoveReaction removes a reaction from a feedback item the viewer may see
+func (s *FeedbackService) RemoveReaction(ctx context.Context, viewer feedbackPkg.Viewer, feedbackID string, reactionType string) error {
+	if err := s.checkVisible(ctx, viewer, feedbackID); err != nil {
+		return err
+	}
+
+	err := s.repo.RemoveReaction(ctx, viewer.UserID, feedbackID, reactionType)
 	if err != nil {
 		return err
 	}
@@ -101,16 +313,15 @@ func (s *FeedbackService) GetTemplates(ctx context.Context, contextFilter, tagsF
 	return templates, nil
 }
 
-// SubmitTemplateSuggestion submits a template suggestion
+// SubmitTemplateSuggestion stores a template suggestion for administrators
+// to review
 func (s *FeedbackService) SubmitTemplateSuggestion(ctx context.Context, req *feedbackPkg.TemplateSuggestionRequest) error {
-	// In a real implementation, this might save to database or send to a queue
-	// For now, we'll just log it (the API spec shows it just returns success)
 	return s.repo.SubmitTemplateSuggestion(ctx, req)
 }
 
-// GetImpact retrieves aggregated feedback analytics
-func (s *FeedbackService) GetImpact(ctx context.Context, userID *string, from, to *time.Time) (*model.FeedbackImpact, error) {
-	return s.repo.GetImpact(ctx, userID, from, to)
+// GetImpact retrieves aggregated analytics over the feedback a viewer may see
+func (s *FeedbackService) GetImpact(ctx context.Context, viewer feedbackPkg.Viewer, userID *string, from, to *time.Time) (*model.FeedbackImpact, error) {
+	return s.repo.GetImpact(ctx, viewer, userID, from, to)
 }
 
 // CreateBatchFeedback creates multiple feedback items in a batch
@@ -118,19 +329,42 @@ func (s *FeedbackService) CreateBatchFeedback(ctx context.Context, userID string
 	return s.repo.CreateBatchFeedback(ctx, userID, req)
 }
 
-// GetFeedWithFilters retrieves a paginated feed of feedback items with enhanced filtering
-func (s *FeedbackService) GetFeedWithFilters(ctx context.Context, limit, offset int, filters *feedbackPkg.FeedFilters) ([]*model.FeedbackItem, int, error) {
-	return s.repo.GetFeedWithFilters(ctx, limit, offset, filters)
+// GetFeedWithFilters retrieves a page of the feedback a viewer may see,
+// newest first, with enhanced filtering
+func (s *FeedbackService) GetFeedWithFilters(ctx context.Context, viewer feedbackPkg.Viewer, page feedbackPkg.PageRequest, filters *feedbackPkg.FeedFilters) ([]*model.FeedbackItem, *feedbackPkg.PageInfo, error) {
+	return s.repo.GetFeedWithFilters(ctx, viewer, page, filters)
+}
+
+// SearchFeedback ranks the feedback a viewer may see in their current
+// organization by how well its content matches a full-text query, narrowed
+// by the request's filters
+func (s *FeedbackService) SearchFeedback(ctx context.Context, viewer feedbackPkg.Viewer, req *feedbackPkg.SearchRequest) ([]*model.FeedbackSearchResult, int, error) {
+	req.Query = strings.TrimSpace(req.Query)
+	if req.Query == "" {
+		return nil, 0, errors.NewValidationError("search query is required")
+	}
+	if len(req.Query) > 256 {
+		return nil, 0, errors.NewValidationError("search query must be at most 256 characters")
+	}
+	if req.From != nil && req.To != nil && req.From.After(*req.To) {
+		return nil, 0, errors.NewValidationError("from must not be after to")
+	}
+
+	return s.repo.SearchFeedback(ctx, viewer, req)
 }
 
-// GetBookmarks retrieves bookmarked feedback items for a user
-func (s *FeedbackService) GetBookmarks(ctx context.Context, userID string, limit, offset int) ([]*model.FeedbackItem, int, error) {
-	return s.repo.GetBookmarks(ctx, userID, limit, offset)
+// GetBookmarks retrieves a viewer's bookmarked feedback items that they may
+// still see
+func (s *FeedbackService) GetBookmarks(ctx context.Context, viewer feedbackPkg.Viewer, limit, offset int) ([]*model.FeedbackItem, int, error) {
+	return s.repo.GetBookmarks(ctx, viewer, limit, offset)
 }
 
-// AddBookmark adds a bookmark for a feedback item
-func (s *FeedbackService) AddBookmark(ctx context.Context, userID, feedbackID string) error {
-	return s.repo.AddBookmark(ctx, userID, feedbackID)
+// AddBookmark adds a bookmark for a feedback item the viewer may see
+func (s *FeedbackService) AddBookmark(ctx context.Context, viewer feedbackPkg.Viewer, feedbackID string) error {
+	if err := s.checkVisible(ctx, viewer, feedbackID); err != nil {
+		return err
+	}
+	return s.repo.AddBookmark(ctx, viewer.UserID, feedbackID)
 }
 
 // RemoveBookmark removes a bookmark for a feedback item
@@ -138,15 +372,15 @@ func (s *FeedbackService) RemoveBookmark(ctx context.Context, userID, feedbackID
 	return s.repo.RemoveBookmark(ctx, userID, feedbackID)
 }
 
-// ExportFeedback exports feedback data with optional filtering
-func (s *FeedbackService) ExportFeedback(ctx context.Context, filters *feedbackPkg.FeedFilters, format string) (*feedbackPkg.ExportResponse, error) {
+// ExportFeedback exports the feedback a viewer may see, with optional filtering
+func (s *FeedbackService) ExportFeedback(ctx context.Context, viewer feedbackPkg.Viewer, filters *feedbackPkg.FeedFilters, format string) (*feedbackPkg.ExportResponse, error) {
 	// Validate format
 	if format != "json" && format != "csv" {
 		return nil, errors.ErrValidationFailed
 	}
 
 	// Get all matching feedback items (no pagination for export)
-	items, _, err := s.repo.GetFeedWithFilters(ctx, 10000, 0, filters) // Reasonable limit for export
+	items, _, err := s.repo.GetFeedWithFilters(ctx, viewer, feedbackPkg.PageRequest{Limit: 10000}, filters) // Reasonable limit for export
 	if err != nil {
 		return nil, err
 	}
@@ -310,6 +544,9 @@ func (s *FeedbackService) UpdateFeedback(ctx context.Context, userID, feedbackID
 		item.Type = req.Type
 	}
 	if req.Visibility != nil {
+		if err := validateVisibility(req.Visibility, item.OrganizationID); err != nil {
+			return nil, err
+		}
 		item.Visibility = req.Visibility
 	}
 
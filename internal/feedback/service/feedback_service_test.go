@@ -0,0 +1,616 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	authModel "ethos/internal/auth/model"
+	"ethos/internal/feedback"
+	"ethos/internal/feedback/model"
+	"ethos/internal/feedback/repository"
+	notificationModel "ethos/internal/notifications/model"
+	"ethos/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRepository stores created feedback and knows a fixed set of
+// recipients. Which feedback a viewer may see is decided by the Postgres
+// repository and tested there; here tests say which stored items each viewer
+// may see, and every read records the viewer it was made for. Methods these
+// tests don't use panic through the nil embedded Repository.
+type fakeRepository struct {
+	repository.Repository
+	recipients  map[string]*model.FeedbackRecipient // keyed by type and ID
+	receivers   map[string][]string                 // recipient ID to user IDs
+	items       []*model.FeedbackItem
+	visible     map[feedback.Viewer][]string // viewer to the feedback IDs they may see
+	viewers     []feedback.Viewer            // the viewer of each read, in order
+	bookmarks   map[string][]string          // user ID to feedback IDs
+	interacted  []string                     // feedback IDs commented on or reacted to, in order
+	updated     []*model.FeedbackItem
+	created     []*model.FeedbackItem
+	templates   map[string][]*model.FeedbackTemplate // template ID to its versions, oldest first
+	suggestions map[string]*model.TemplateSuggestion
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{
+		recipients: map[string]*model.FeedbackRecipient{
+			"user/user-2":        {Type: model.RecipientTypeUser, ID: "user-2", Name: "Sam"},
+			"team/team-1":        {Type: model.RecipientTypeTeam, ID: "team-1", Name: "Platform", OrganizationID: "org-1"},
+			"organization/org-1": {Type: model.RecipientTypeOrganization, ID: "org-1", Name: "Acme", OrganizationID: "org-1"},
+		},
+		receivers: map[string][]string{
+			"user-2": {"user-2"},
+			"team-1": {"user-1", "user-3", "user-4"},
+			"org-1":  {"user-5"},
+		},
+		visible:     map[feedback.Viewer][]string{},
+		bookmarks:   map[string][]string{},
+		templates:   map[string][]*model.FeedbackTemplate{},
+		suggestions: map[string]*model.TemplateSuggestion{},
+	}
+}
+
+// viewable records a read for viewer and reports whether they may see item
+func (r *fakeRepository) viewable(viewer feedback.Viewer, feedbackID string) bool {
+	for _, id := range r.visible[viewer] {
+		if id == feedbackID {
+			return true
+		}
+	}
+	return false
+}
+
+// list records a read for viewer and returns the stored items they may see
+// that match
+func (r *fakeRepository) list(viewer feedback.Viewer, match func(*model.FeedbackItem) bool) ([]*model.FeedbackItem, int, error) {
+	r.viewers = append(r.viewers, viewer)
+	var items []*model.FeedbackItem
+	for _, item := range r.items {
+		if r.viewable(viewer, item.FeedbackID) && match(item) {
+			items = append(items, item)
+		}
+	}
+	return items, len(items), nil
+}
+
+func (r *fakeRepository) GetFeed(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest) ([]*model.FeedbackItem, *feedback.PageInfo, error) {
+	items, _, err := r.list(viewer, func(*model.FeedbackItem) bool { return true })
+	return items, &feedback.PageInfo{}, err
+}
+
+func (r *fakeRepository) GetFeedWithFilters(ctx context.Context, viewer feedback.Viewer, page feedback.PageRequest, filters *feedback.FeedFilters) ([]*model.FeedbackItem, *feedback.PageInfo, error) {
+	return r.GetFeed(ctx, viewer, page)
+}
+
+func (r *fakeRepository) GetReceivedFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*model.FeedbackItem, int, error) {
+	return r.list(viewer, func(item *model.FeedbackItem) bool {
+		return item.Recipient != nil && item.Recipient.ID == viewer.UserID
+	})
+}
+
+func (r *fakeRepository) GetGivenFeedback(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*model.FeedbackItem, int, error) {
+	return r.list(viewer, func(item *model.FeedbackItem) bool { return item.Author.ID == viewer.UserID })
+}
+
+func (r *fakeRepository) GetBookmarks(ctx context.Context, viewer feedback.Viewer, limit, offset int) ([]*model.FeedbackItem, int, error) {
+	return r.list(viewer, func(item *model.FeedbackItem) bool {
+		for _, id := range r.bookmarks[viewer.UserID] {
+			if id == item.FeedbackID {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (r *fakeRepository) AddBookmark(ctx context.Context, userID, feedbackID string) error {
+	r.bookmarks[userID] = append(r.bookmarks[userID], feedbackID)
+	return nil
+}
+
+func (r *fakeRepository) CreateComment(ctx context.Context, userID, feedbackID string, content string, parentCommentID *string) (*model.FeedbackComment, error) {
+	r.interacted = append(r.interacted, feedbackID)
+	return &model.FeedbackComment{Content: content}, nil
+}
+
+func (r *fakeRepository) AddReaction(ctx context.Context, userID, feedbackID string, reactionType string) error {
+	r.interacted = append(r.interacted, feedbackID)
+	return nil
+}
+
+func (r *fakeRepository) RemoveReaction(ctx context.Context, userID, feedbackID string, reactionType string) error {
+	r.interacted = append(r.interacted, feedbackID)
+	return nil
+}
+
+func (r *fakeRepository) GetComments(ctx context.Context, feedbackID string, page feedback.PageRequest) ([]*model.FeedbackComment, *feedback.PageInfo, error) {
+	return []*model.FeedbackComment{}, &feedback.PageInfo{}, nil
+}
+
+func (r *fakeRepository) CreateFeedback(ctx context.Context, userID string, content string, feedbackType *model.FeedbackType, visibility *model.FeedbackVisibility, recipient *model.FeedbackRecipient, organizationID *string, submission *model.TemplateSubmission) (*model.FeedbackItem, error) {
+	item := &model.FeedbackItem{
+		FeedbackID:     "f-1",
+		Author:         &authModel.UserSummary{ID: userID},
+		Content:        content,
+		Type:           feedbackType,
+		Visibility:     visibility,
+		Recipient:      recipient,
+		OrganizationID: organizationID,
+	}
+	if submission != nil {
+		reference := submission.TemplateReference
+		item.Template = &reference
+		item.Responses = submission.Responses
+		item.Dimensions = submission.Dimensions
+	}
+	r.created = append(r.created, item)
+	return item, nil
+}
+
+func (r *fakeRepository) UpdateFeedback(ctx context.Context, feedbackID string, item *model.FeedbackItem) error {
+	r.updated = append(r.updated, item)
+	return nil
+}
+
+func (r *fakeRepository) GetRecipient(ctx context.Context, recipientType model.RecipientType, recipientID string) (*model.FeedbackRecipient, error) {
+	recipient, ok := r.recipients[string(recipientType)+"/"+recipientID]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	copied := *recipient
+	return &copied, nil
+}
+
+func (r *fakeRepository) GetRecipientUserIDs(ctx context.Context, recipient *model.FeedbackRecipient) ([]string, error) {
+	return r.receivers[recipient.ID], nil
+}
+
+func (r *fakeRepository) CanViewFeedback(ctx context.Context, viewer feedback.Viewer, feedbackID string) (bool, error) {
+	r.viewers = append(r.viewers, viewer)
+	return r.viewable(viewer, feedbackID), nil
+}
+
+func (r *fakeRepository) GetFeedbackByID(ctx context.Context, feedbackID string) (*model.FeedbackItem, error) {
+	for _, item := range r.items {
+		if item.FeedbackID == feedbackID {
+			return item, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+// fakeMembers maps organization IDs to their active members
+type fakeMembers map[string][]string
+
+func (m fakeMembers) IsUserInOrganization(ctx context.Context, userID, organizationID string) (bool, error) {
+	for _, member := range m[organizationID] {
+		if member == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type notification struct {
+	userID  string
+	message string
+}
+
+type fakeNotifier struct {
+	sent []notification
+}
+
+func (n *fakeNotifier) CreateNotification(ctx context.Context, userID string, notificationType notificationModel.NotificationType, message string) error {
+	if notificationType == notificationModel.NotificationTypeFeedbackReceived {
+		n.sent = append(n.sent, notification{userID: userID, message: message})
+	}
+	return nil
+}
+
+func newTestService() (*FeedbackService, *fakeRepository, *fakeNotifier) {
+	members := fakeMembers{"org-1": {"user-1", "user-2", "user-3", "user-4", "user-5"}, "org-2": {"user-1", "user-6"}}
+	repo := newFakeRepository()
+	notifier := &fakeNotifier{}
+	return NewFeedbackService(repo, members, notifier).(*FeedbackService), repo, notifier
+}
+
+func TestCreateFeedback_UserRecipient(t *testing.T) {
+	svc, repo, notifier := newTestService()
+
+	item, err := svc.CreateFeedback(context.Background(), "user-1", &CreateFeedbackRequest{
+		Content:   "Great write-up",
+		Recipient: &RecipientRequest{Type: model.RecipientTypeUser, ID: "user-2"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "user-2", item.Recipient.ID)
+	assert.Nil(t, item.OrganizationID)
+	assert.Len(t, repo.created, 1)
+	assert.Equal(t, []notification{{userID: "user-2", message: "You received new feedback"}}, notifier.sent)
+}
+
+func TestCreateFeedback_TeamRecipientIsScopedToItsOrganization(t *testing.T) {
+	svc, _, notifier := newTestService()
+	team := model.FeedbackVisibilityTeam
+
+	item, err := svc.CreateFeedback(context.Background(), "user-1", &CreateFeedbackRequest{
+		Content:    "Smooth release",
+		Visibility: &team,
+		Recipient:  &RecipientRequest{Type: model.RecipientTypeTeam, ID: "team-1"},
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, item.OrganizationID)
+	assert.Equal(t, "org-1", *item.OrganizationID)
+	// The author is on the team but isn't told about their own feedback
+	assert.Equal(t, []notification{
+		{userID: "user-3", message: "Your team Platform received new feedback"},
+		{userID: "user-4", message: "Your team Platform received new feedback"},
+	}, notifier.sent)
+}
+
+func TestCreateFeedback_OrganizationRecipientNotifiesAdmins(t *testing.T) {
+	svc, _, notifier := newTestService()
+
+	_, err := svc.CreateFeedback(context.Background(), "user-1", &CreateFeedbackRequest{
+		Content:   "Love the new office hours",
+		Recipient: &RecipientRequest{Type: model.RecipientTypeOrganization, ID: "org-1"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []notification{{userID: "user-5", message: "Acme received new feedback"}}, notifier.sent)
+}
+
+func TestCreateFeedback_RejectsInvalidRecipients(t *testing.T) {
+	orgTwo := "org-2"
+	team := model.FeedbackVisibilityTeam
+
+	for name, tc := range map[string]struct {
+		userID string
+		req    *CreateFeedbackRequest
+		code   string
+	}{
+		"self": {
+			userID: "user-1",
+			req:    &CreateFeedbackRequest{Content: "x", Recipient: &RecipientRequest{Type: model.RecipientTypeUser, ID: "user-1"}},
+			code:   "VALIDATION_FAILED",
+		},
+		"unknown recipient": {
+			userID: "user-1",
+			req:    &CreateFeedbackRequest{Content: "x", Recipient: &RecipientRequest{Type: model.RecipientTypeTeam, ID: "team-9"}},
+			code:   "VALIDATION_FAILED",
+		},
+		"team in another organization": {
+			userID: "user-6",
+			req:    &CreateFeedbackRequest{Content: "x", Recipient: &RecipientRequest{Type: model.RecipientTypeTeam, ID: "team-1"}},
+			code:   "FORBIDDEN",
+		},
+		"organization doesn't match recipient": {
+			userID: "user-1",
+			req:    &CreateFeedbackRequest{Content: "x", OrganizationID: &orgTwo, Recipient: &RecipientRequest{Type: model.RecipientTypeTeam, ID: "team-1"}},
+			code:   "VALIDATION_FAILED",
+		},
+		"user recipient outside the organization": {
+			userID: "user-1",
+			req:    &CreateFeedbackRequest{Content: "x", OrganizationID: &orgTwo, Recipient: &RecipientRequest{Type: model.RecipientTypeUser, ID: "user-2"}},
+			code:   "VALIDATION_FAILED",
+		},
+		"team visibility without an organization": {
+			userID: "user-1",
+			req:    &CreateFeedbackRequest{Content: "x", Visibility: &team, Recipient: &RecipientRequest{Type: model.RecipientTypeUser, ID: "user-2"}},
+			code:   "VALIDATION_FAILED",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			svc, repo, notifier := newTestService()
+
+			_, err := svc.CreateFeedback(context.Background(), tc.userID, tc.req)
+
+			apiErr, ok := err.(*errors.APIError)
+			require.True(t, ok, "expected an API error, got %v", err)
+			assert.Equal(t, tc.code, apiErr.Code)
+			assert.Empty(t, repo.created)
+			assert.Empty(t, notifier.sent)
+		})
+	}
+}
+
+func TestUpdateFeedback_TeamVisibilityNeedsAnOrganization(t *testing.T) {
+	svc, repo, _ := newTestService()
+	storeFeedback(repo, "unscoped", "user-1", model.FeedbackVisibilityPublic, "", "")
+	storeFeedback(repo, "scoped", "user-1", model.FeedbackVisibilityPublic, "org-1", "")
+	team := model.FeedbackVisibilityTeam
+
+	_, err := svc.UpdateFeedback(context.Background(), "user-1", "unscoped", &UpdateFeedbackRequest{Visibility: &team})
+	apiErr, ok := err.(*errors.APIError)
+	require.True(t, ok, "expected an API error, got %v", err)
+	assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+	assert.Empty(t, repo.updated)
+
+	updated, err := svc.UpdateFeedback(context.Background(), "user-1", "scoped", &UpdateFeedbackRequest{Visibility: &team})
+	require.NoError(t, err)
+	assert.Equal(t, model.FeedbackVisibilityTeam, *updated.Visibility)
+}
+
+// storeFeedback adds an item to the fake repository
+func storeFeedback(repo *fakeRepository, id, authorID string, visibility model.FeedbackVisibility, organizationID, recipientID string) {
+	item := &model.FeedbackItem{
+		FeedbackID: id,
+		Author:     &authModel.UserSummary{ID: authorID},
+		Content:    id,
+		Visibility: &visibility,
+	}
+	if organizationID != "" {
+		item.OrganizationID = &organizationID
+	}
+	if recipientID != "" {
+		item.Recipient = &model.FeedbackRecipient{Type: model.RecipientTypeUser, ID: recipientID}
+	}
+	repo.items = append(repo.items, item)
+}
+
+func feedbackIDs(items []*model.FeedbackItem) []string {
+	ids := []string{}
+	for _, item := range items {
+		ids = append(ids, item.FeedbackID)
+	}
+	return ids
+}
+
+func TestGetFeedbackByID_HidesFeedbackTheUserCantSee(t *testing.T) {
+	svc, repo, _ := newTestService()
+	storeFeedback(repo, "f-1", "user-1", model.FeedbackVisibilityPrivate, "", "user-2")
+	repo.visible[feedback.Viewer{UserID: "user-2"}] = []string{"f-1"}
+
+	item, err := svc.GetFeedbackByID(context.Background(), feedback.Viewer{UserID: "user-2"}, "f-1")
+	require.NoError(t, err)
+	assert.Equal(t, "f-1", item.FeedbackID)
+
+	_, err = svc.GetFeedbackByID(context.Background(), feedback.Viewer{UserID: "user-3"}, "f-1")
+	assert.Equal(t, errors.ErrNotFound, err)
+
+	_, _, err = svc.GetComments(context.Background(), feedback.Viewer{UserID: "user-3"}, "f-1", feedback.PageRequest{Limit: 20})
+	assert.Equal(t, errors.ErrNotFound, err)
+}
+
+// seedTenants stores feedback in two organizations. user-1 belongs to both,
+// and private feedback in org-2 is addressed to them.
+func seedTenants(repo *fakeRepository) {
+	storeFeedback(repo, "org-1-public", "user-2", model.FeedbackVisibilityPublic, "org-1", "")
+	storeFeedback(repo, "org-2-public", "user-6", model.FeedbackVisibilityPublic, "org-2", "")
+	storeFeedback(repo, "org-2-private", "user-6", model.FeedbackVisibilityPrivate, "org-2", "user-1")
+	storeFeedback(repo, "unscoped-public", "user-3", model.FeedbackVisibilityPublic, "", "")
+	for _, item := range repo.items {
+		repo.bookmarks["user-1"] = append(repo.bookmarks["user-1"], item.FeedbackID)
+	}
+	repo.visible[feedback.Viewer{UserID: "user-1", OrganizationID: "org-1"}] = []string{"org-1-public", "unscoped-public"}
+	repo.visible[feedback.Viewer{UserID: "user-1", OrganizationID: "org-2"}] = []string{"org-2-public", "org-2-private", "unscoped-public"}
+}
+
+// The repository decides what a viewer may see (see the repository's
+// tests); the service must ask it on every read path with the viewer's
+// current organization, and hide what it refuses
+func TestReadPaths_AskTheRepositoryForTheCurrentOrganization(t *testing.T) {
+	for _, organizationID := range []string{"org-1", "org-2"} {
+		t.Run(organizationID, func(t *testing.T) {
+			svc, repo, _ := newTestService()
+			seedTenants(repo)
+			ctx := context.Background()
+			viewer := feedback.Viewer{UserID: "user-1", OrganizationID: organizationID}
+			visible := repo.visible[viewer]
+
+			feed, _, err := svc.GetFeed(ctx, viewer, feedback.PageRequest{Limit: 20})
+			require.NoError(t, err)
+			assert.Equal(t, visible, feedbackIDs(feed))
+
+			filtered, _, err := svc.GetFeedWithFilters(ctx, viewer, feedback.PageRequest{Limit: 20}, &feedback.FeedFilters{})
+			require.NoError(t, err)
+			assert.Equal(t, visible, feedbackIDs(filtered))
+
+			bookmarks, _, err := svc.GetBookmarks(ctx, viewer, 20, 0)
+			require.NoError(t, err)
+			assert.Equal(t, visible, feedbackIDs(bookmarks))
+
+			export, err := svc.ExportFeedback(ctx, viewer, &feedback.FeedFilters{}, "csv")
+			require.NoError(t, err)
+			assert.Equal(t, len(visible), export.Count)
+
+			_, _, err = svc.GetReceivedFeedback(ctx, viewer, 20, 0)
+			require.NoError(t, err)
+			_, _, err = svc.GetGivenFeedback(ctx, viewer, 20, 0)
+			require.NoError(t, err)
+
+			for _, item := range repo.items {
+				_, err := svc.GetFeedbackByID(ctx, viewer, item.FeedbackID)
+				_, _, commentsErr := svc.GetComments(ctx, viewer, item.FeedbackID, feedback.PageRequest{Limit: 20})
+				bookmarkErr := svc.AddBookmark(ctx, viewer, item.FeedbackID)
+				if repo.viewable(viewer, item.FeedbackID) {
+					assert.NoError(t, err, item.FeedbackID)
+					assert.NoError(t, commentsErr, item.FeedbackID)
+					assert.NoError(t, bookmarkErr, item.FeedbackID)
+				} else {
+					assert.Equal(t, errors.ErrNotFound, err, item.FeedbackID)
+					assert.Equal(t, errors.ErrNotFound, commentsErr, item.FeedbackID)
+					assert.Equal(t, errors.ErrNotFound, bookmarkErr, item.FeedbackID)
+				}
+			}
+
+			require.NotEmpty(t, repo.viewers)
+			for _, read := range repo.viewers {
+				assert.Equal(t, viewer, read)
+			}
+		})
+	}
+}
+
+// Commenting and reacting must not reach feedback the viewer can't see, nor
+// reveal that it exists
+func TestWritePaths_OnlyReachFeedbackTheViewerMaySee(t *testing.T) {
+	for _, organizationID := range []string{"org-1", "org-2"} {
+		t.Run(organizationID, func(t *testing.T) {
+			svc, repo, _ := newTestService()
+			seedTenants(repo)
+			ctx := context.Background()
+			viewer := feedback.Viewer{UserID: "user-1", OrganizationID: organizationID}
+
+			var reached []string
+			for _, item := range repo.items {
+				_, commentErr := svc.CreateComment(ctx, viewer, item.FeedbackID, &CreateCommentRequest{Content: "Agreed"})
+				addErr := svc.AddReaction(ctx, viewer, item.FeedbackID, "like")
+				removeErr := svc.RemoveReaction(ctx, viewer, item.FeedbackID, "like")
+				if repo.viewable(viewer, item.FeedbackID) {
+					assert.NoError(t, commentErr, item.FeedbackID)
+					assert.NoError(t, addErr, item.FeedbackID)
+					assert.NoError(t, removeErr, item.FeedbackID)
+					reached = append(reached, item.FeedbackID, item.FeedbackID, item.FeedbackID)
+				} else {
+					assert.Equal(t, errors.ErrNotFound, commentErr, item.FeedbackID)
+					assert.Equal(t, errors.ErrNotFound, addErr, item.FeedbackID)
+					assert.Equal(t, errors.ErrNotFound, removeErr, item.FeedbackID)
+				}
+			}
+
+			assert.Equal(t, reached, repo.interacted)
+			for _, read := range repo.viewers {
+				assert.Equal(t, viewer, read)
+			}
+		})
+	}
+}
+
+func TestSearchFeedback_RejectsInvalidRequests(t *testing.T) {
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for name, req := range map[string]*feedback.SearchRequest{
+		"empty query":    {Query: "   ", Limit: 20},
+		"query too long": {Query: strings.Repeat("a", 257), Limit: 20},
+		"from after to":  {Query: "onboarding", From: &from, To: &to, Limit: 20},
+	} {
+		t.Run(name, func(t *testing.T) {
+			svc, _, _ := newTestService()
+
+			// The fake repository doesn't search, so reaching it would panic
+			_, _, err := svc.SearchFeedback(context.Background(), feedback.Viewer{UserID: "user-1"}, req)
+
+			apiErr, ok := err.(*errors.APIError)
+			require.True(t, ok, "expected an API error, got %v", err)
+			assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+		})
+	}
+}
+
+// storeTemplate adds a template to the fake repository with a version for each set of fields
+func storeTemplate(repo *fakeRepository, id string, status model.TemplateStatus, versions ...[]model.TemplateField) {
+	for i, fields := range versions {
+		repo.templates[id] = append(repo.templates[id], &model.FeedbackTemplate{TemplateID: id, Name: id, Status: status, Version: i + 1, Fields: fields})
+	}
+}
+
+func TestCreateFeedback_WithTemplateRecordsItsVersionAndDimensions(t *testing.T) {
+	svc, repo, _ := newTestService()
+	storeTemplate(repo, "tmpl-1", model.TemplateStatusPublished, reviewFields(), reviewFields()[:1])
+	templateID := "tmpl-1"
+
+	item, err := svc.CreateFeedback(context.Background(), "user-1", &CreateFeedbackRequest{
+		Content:         "Quarterly peer review",
+		TemplateID:      &templateID,
+		TemplateVersion: 1,
+		Responses: map[string]interface{}{
+			"overall":       float64(4),
+			"collaboration": float64(5),
+			"strengths":     []interface{}{"delivery", "mentoring"},
+			"summary":       "Ships steadily",
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &model.TemplateReference{TemplateID: "tmpl-1", Version: 1}, item.Template)
+	assert.Equal(t, []model.FeedbackDimensionScore{{Dimension: "collaboration", Score: 5}}, item.Dimensions)
+	assert.Len(t, repo.created, 1)
+
+	// Without a version the current one applies
+	item, err = svc.CreateFeedback(context.Background(), "user-1", &CreateFeedbackRequest{
+		Content:    "Short review",
+		TemplateID: &templateID,
+		Responses:  map[string]interface{}{"overall": float64(3)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, item.Template.Version)
+	assert.Empty(t, item.Dimensions)
+}
+
+func TestCreateFeedback_RejectsInvalidTemplateResponses(t *testing.T) {
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{"overall": float64(4), "summary": "Good"}
+	}
+	with := func(key string, value interface{}) map[string]interface{} {
+		responses := valid()
+		responses[key] = value
+		return responses
+	}
+	without := func(key string) map[string]interface{} {
+		responses := valid()
+		delete(responses, key)
+		return responses
+	}
+
+	for name, tc := range map[string]struct {
+		templateID string
+		version    int
+		responses  map[string]interface{}
+	}{
+		"unknown template":         {templateID: "tmpl-9", responses: valid()},
+		"draft template":           {templateID: "tmpl-draft", responses: valid()},
+		"archived template":        {templateID: "tmpl-archived", responses: valid()},
+		"unknown version":          {templateID: "tmpl-1", version: 3, responses: valid()},
+		"unknown field":            {templateID: "tmpl-1", responses: with("mood", "happy")},
+		"missing required rating":  {templateID: "tmpl-1", responses: without("overall")},
+		"blank required text":      {templateID: "tmpl-1", responses: with("summary", "  ")},
+		"rating out of range":      {templateID: "tmpl-1", responses: with("overall", float64(6))},
+		"fractional rating":        {templateID: "tmpl-1", responses: with("overall", 3.5)},
+		"rating as text":           {templateID: "tmpl-1", responses: with("overall", "4")},
+		"dimension out of range":   {templateID: "tmpl-1", responses: with("collaboration", float64(0))},
+		"unknown choice":           {templateID: "tmpl-1", responses: with("strengths", []interface{}{"humour"})},
+		"repeated choice":          {templateID: "tmpl-1", responses: with("strengths", []interface{}{"delivery", "delivery"})},
+		"single choice for a list": {templateID: "tmpl-1", responses: with("strengths", "delivery")},
+		"text too long":            {templateID: "tmpl-1", responses: with("summary", "far more than twenty characters")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			svc, repo, _ := newTestService()
+			storeTemplate(repo, "tmpl-1", model.TemplateStatusPublished, reviewFields())
+			storeTemplate(repo, "tmpl-draft", model.TemplateStatusDraft, reviewFields())
+			storeTemplate(repo, "tmpl-archived", model.TemplateStatusArchived, reviewFields())
+			templateID := tc.templateID
+
+			_, err := svc.CreateFeedback(context.Background(), "user-1", &CreateFeedbackRequest{
+				Content:         "x",
+				TemplateID:      &templateID,
+				TemplateVersion: tc.version,
+				Responses:       tc.responses,
+			})
+
+			apiErr, ok := err.(*errors.APIError)
+			require.True(t, ok, "expected an API error, got %v", err)
+			assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+			assert.Empty(t, repo.created)
+		})
+	}
+
+	t.Run("responses without a template", func(t *testing.T) {
+		svc, repo, _ := newTestService()
+
+		_, err := svc.CreateFeedback(context.Background(), "user-1", &CreateFeedbackRequest{Content: "x", Responses: valid()})
+
+		apiErr, ok := err.(*errors.APIError)
+		require.True(t, ok, "expected an API error, got %v", err)
+		assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+		assert.Empty(t, repo.created)
+	})
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	"ethos/internal/feedback/model"
+)
+
+// CreateTemplateRequest represents a request to create a feedback template
+type CreateTemplateRequest struct {
+	Name        string                `json:"name" binding:"required"`
+	Description string                `json:"description"`
+	ContextTags []string              `json:"context_tags"`
+	Fields      []model.TemplateField `json:"fields" binding:"required"`
+	// Publish offers the template to feedback authors straight away
+	// instead of creating it as a draft
+	Publish bool `json:"publish"`
+}
+
+// UpdateTemplateRequest represents a request to update a feedback template.
+// Changed fields become the template's next version.
+type UpdateTemplateRequest struct {
+	Name        *string               `json:"name,omitempty"`
+	Description *string               `json:"description,omitempty"`
+	ContextTags []string              `json:"context_tags,omitempty"`
+	Fields      []model.TemplateField `json:"fields,omitempty"`
+	Status      *model.TemplateStatus `json:"status,omitempty"`
+}
+
+// RejectSuggestionRequest represents a request to reject a template suggestion
+type RejectSuggestionRequest struct {
+	Note string `json:"note"`
+}
+
+// TemplateService defines the interface for managing feedback templates and
+// reviewing template suggestions
+type TemplateService interface {
+	// ListTemplates lists templates of any status, or of one status if it isn't empty
+	ListTemplates(ctx context.Context, status model.TemplateStatus) ([]*model.FeedbackTemplate, error)
+
+	// GetTemplate retrieves a template at a version, or at its current version if version is 0
+	GetTemplate(ctx context.Context, templateID string, version int) (*model.FeedbackTemplate, error)
+
+	// GetPublishedTemplate retrieves a published template at a version, or at its current version if version is 0
+	GetPublishedTemplate(ctx context.Context, templateID string, version int) (*model.FeedbackTemplate, error)
+
+	// CreateTemplate creates a template as a draft, or published if the request asks
+	CreateTemplate(ctx context.Context, userID string, req *CreateTemplateRequest) (*model.FeedbackTemplate, error)
+
+	// UpdateTemplate updates a template, adding a version if its fields change
+	UpdateTemplate(ctx context.Context, userID, templateID string, req *UpdateTemplateRequest) (*model.FeedbackTemplate, error)
+
+	// ArchiveTemplate stops a template being offered to feedback authors
+	ArchiveTemplate(ctx context.Context, userID, templateID string) (*model.FeedbackTemplate, error)
+
+	// ListSuggestions lists template suggestions of any status, or of one status if it isn't empty
+	ListSuggestions(ctx context.Context, status model.SuggestionStatus, limit, offset int) ([]*model.TemplateSuggestion, int, error)
+
+	// ApproveSuggestion turns a pending suggestion into a published template
+	ApproveSuggestion(ctx context.Context, reviewerID, suggestionID string, req *CreateTemplateRequest) (*model.FeedbackTemplate, error)
+
+	// RejectSuggestion rejects a pending suggestion
+	RejectSuggestion(ctx context.Context, reviewerID, suggestionID, note string) (*model.TemplateSuggestion, error)
+}
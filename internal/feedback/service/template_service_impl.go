@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"ethos/internal/feedback/model"
+	"ethos/internal/feedback/repository"
+	"ethos/pkg/errors"
+)
+
+// maxReviewNoteLength bounds the note a rejected suggestion's author is shown
+const maxReviewNoteLength = 1000
+
+// FeedbackTemplateService implements the TemplateService interface
+type FeedbackTemplateService struct {
+	repo repository.Repository
+}
+
+// NewTemplateService creates a new feedback template service
+func NewTemplateService(repo repository.Repository) TemplateService {
+	return &FeedbackTemplateService{repo: repo}
+}
+
+// ListTemplates lists templates of any status, or of one status if it isn't empty
+func (s *FeedbackTemplateService) ListTemplates(ctx context.Context, status model.TemplateStatus) ([]*model.FeedbackTemplate, error) {
+	if status != "" && !validTemplateStatus(status) {
+		return nil, errors.NewValidationError("status must be draft, published or archived")
+	}
+	return s.repo.ListTemplates(ctx, status)
+}
+
+// GetTemplate retrieves a template at a version, or at its current version if version is 0
+func (s *FeedbackTemplateService) GetTemplate(ctx context.Context, templateID string, version int) (*model.FeedbackTemplate, error) {
+	if version < 0 {
+		return nil, errors.NewValidationError("version must be positive")
+	}
+	return s.repo.GetTemplate(ctx, templateID, version)
+}
+
+// GetPublishedTemplate retrieves a published template at a version, or at
+// its current version if version is 0. Drafts and archived templates are
+// reported as not found.
+func (s *FeedbackTemplateService) GetPublishedTemplate(ctx context.Context, templateID string, version int) (*model.FeedbackTemplate, error) {
+	template, err := s.GetTemplate(ctx, templateID, version)
+	if err != nil {
+		return nil, err
+	}
+	if template.Status != model.TemplateStatusPublished {
+		return nil, errors.ErrNotFound
+	}
+	return template, nil
+}
+
+// CreateTemplate creates a template as a draft, or published if the request asks
+func (s *FeedbackTemplateService) CreateTemplate(ctx context.Context, userID string, req *CreateTemplateRequest) (*model.FeedbackTemplate, error) {
+	template, err := newTemplate(userID, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Publish {
+		template.Status = model.TemplateStatusPublished
+	}
+
+	if err := s.repo.CreateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// UpdateTemplate updates a template. If its fields change they become a new
+// version, and feedback already written keeps the version it answered.
+func (s *FeedbackTemplateService) UpdateTemplate(ctx context.Context, userID, templateID string, req *UpdateTemplateRequest) (*model.FeedbackTemplate, error) {
+	template, err := s.repo.GetTemplate(ctx, templateID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.NewValidationError("template name is required")
+		}
+		template.Name = name
+	}
+	if req.Description != nil {
+		template.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.ContextTags != nil {
+		template.ContextTags = cleanTags(req.ContextTags)
+	}
+	if req.Status != nil {
+		if !validTemplateStatus(*req.Status) {
+			return nil, errors.NewValidationError("status must be draft, published or archived")
+		}
+		template.Status = *req.Status
+	}
+
+	var fields []model.TemplateField
+	if req.Fields != nil && !reflect.DeepEqual(req.Fields, template.Fields) {
+		if err := validateTemplateFields(req.Fields); err != nil {
+			return nil, err
+		}
+		fields = req.Fields
+	}
+
+	if err := s.repo.UpdateTemplate(ctx, template, fields, userID); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// ArchiveTemplate stops a template being offered to feedback authors and
+// accepted with new feedback. Feedback written with it keeps its answers.
+func (s *FeedbackTemplateService) ArchiveTemplate(ctx context.Context, userID, templateID string) (*model.FeedbackTemplate, error) {
+	archived := model.TemplateStatusArchived
+	return s.UpdateTemplate(ctx, userID, templateID, &UpdateTemplateRequest{Status: &archived})
+}
+
+// ListSuggestions lists template suggestions of any status, or of one
+// status if it isn't empty, oldest first
+func (s *FeedbackTemplateService) ListSuggestions(ctx context.Context, status model.SuggestionStatus, limit, offset int) ([]*model.TemplateSuggestion, int, error) {
+	switch status {
+	case "", model.SuggestionStatusPending, model.SuggestionStatusApproved, model.SuggestionStatusRejected:
+	default:
+		return nil, 0, errors.NewValidationError("status must be pending, approved or rejected")
+	}
+	return s.repo.ListTemplateSuggestions(ctx, status, limit, offset)
+}
+
+// ApproveSuggestion turns a pending suggestion into a published template.
+// The reviewer defines its fields, taking the suggestion's desired fields
+// as a starting point.
+func (s *FeedbackTemplateService) ApproveSuggestion(ctx context.Context, reviewerID, suggestionID string, req *CreateTemplateRequest) (*model.FeedbackTemplate, error) {
+	template, err := newTemplate(reviewerID, req)
+	if err != nil {
+		return nil, err
+	}
+	template.Status = model.TemplateStatusPublished
+
+	if err := s.repo.ApproveTemplateSuggestion(ctx, suggestionID, reviewerID, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// RejectSuggestion rejects a pending suggestion, with an optional note for
+// the user who made it
+func (s *FeedbackTemplateService) RejectSuggestion(ctx context.Context, reviewerID, suggestionID, note string) (*model.TemplateSuggestion, error) {
+	note = strings.TrimSpace(note)
+	if len(note) > maxReviewNoteLength {
+		return nil, errors.NewValidationError("note is too long")
+	}
+
+	if err := s.repo.RejectTemplateSuggestion(ctx, suggestionID, reviewerID, note); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTemplateSuggestion(ctx, suggestionID)
+}
+
+// newTemplate validates a request for a template and builds it as a draft
+func newTemplate(userID string, req *CreateTemplateRequest) (*model.FeedbackTemplate, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.NewValidationError("template name is required")
+	}
+	if err := validateTemplateFields(req.Fields); err != nil {
+		return nil, err
+	}
+
+	return &model.FeedbackTemplate{
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		ContextTags: cleanTags(req.ContextTags),
+		Status:      model.TemplateStatusDraft,
+		Fields:      req.Fields,
+		CreatedBy:   &userID,
+	}, nil
+}
+
+// cleanTags trims context tags and drops empty and repeated ones
+func cleanTags(tags []string) []string {
+	cleaned := []string{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		cleaned = append(cleaned, tag)
+	}
+	return cleaned
+}
+
+func validTemplateStatus(status model.TemplateStatus) bool {
+	switch status {
+	case model.TemplateStatusDraft, model.TemplateStatusPublished, model.TemplateStatusArchived:
+		return true
+	}
+	return false
+}
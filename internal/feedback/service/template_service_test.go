@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"ethos/internal/feedback/model"
+	"ethos/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *fakeRepository) GetTemplate(ctx context.Context, templateID string, version int) (*model.FeedbackTemplate, error) {
+	versions, ok := r.templates[templateID]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	current := versions[len(versions)-1]
+	if version == 0 {
+		version = current.Version
+	}
+	if version > len(versions) {
+		return nil, errors.ErrNotFound
+	}
+	// Every version shares the template's current name and status
+	template := *current
+	template.Version = version
+	template.Fields = versions[version-1].Fields
+	return &template, nil
+}
+
+func (r *fakeRepository) CreateTemplate(ctx context.Context, template *model.FeedbackTemplate) error {
+	template.TemplateID = fmt.Sprintf("tmpl-%d", len(r.templates)+1)
+	template.Version = 1
+	stored := *template
+	r.templates[template.TemplateID] = []*model.FeedbackTemplate{&stored}
+	return nil
+}
+
+func (r *fakeRepository) UpdateTemplate(ctx context.Context, template *model.FeedbackTemplate, fields []model.TemplateField, updatedBy string) error {
+	versions, ok := r.templates[template.TemplateID]
+	if !ok {
+		return errors.ErrNotFound
+	}
+	if fields != nil {
+		template.Version = len(versions) + 1
+		template.Fields = fields
+	}
+	stored := *template
+	if fields != nil {
+		r.templates[template.TemplateID] = append(versions, &stored)
+	} else {
+		versions[len(versions)-1] = &stored
+	}
+	return nil
+}
+
+func (r *fakeRepository) GetTemplateSuggestion(ctx context.Context, suggestionID string) (*model.TemplateSuggestion, error) {
+	suggestion, ok := r.suggestions[suggestionID]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	return suggestion, nil
+}
+
+func (r *fakeRepository) ApproveTemplateSuggestion(ctx context.Context, suggestionID, reviewerID string, template *model.FeedbackTemplate) error {
+	suggestion, ok := r.suggestions[suggestionID]
+	if !ok {
+		return errors.ErrNotFound
+	}
+	if suggestion.Status != model.SuggestionStatusPending {
+		return errors.ErrSuggestionAlreadyReviewed
+	}
+	if err := r.CreateTemplate(ctx, template); err != nil {
+		return err
+	}
+	suggestion.Status = model.SuggestionStatusApproved
+	suggestion.ReviewedBy = &reviewerID
+	suggestion.TemplateID = &template.TemplateID
+	return nil
+}
+
+func (r *fakeRepository) RejectTemplateSuggestion(ctx context.Context, suggestionID, reviewerID, note string) error {
+	suggestion, ok := r.suggestions[suggestionID]
+	if !ok {
+		return errors.ErrNotFound
+	}
+	if suggestion.Status != model.SuggestionStatusPending {
+		return errors.ErrSuggestionAlreadyReviewed
+	}
+	suggestion.Status = model.SuggestionStatusRejected
+	suggestion.ReviewedBy = &reviewerID
+	suggestion.ReviewNote = note
+	return nil
+}
+
+func newTestTemplateService() (TemplateService, *fakeRepository) {
+	repo := newFakeRepository()
+	return NewTemplateService(repo), repo
+}
+
+// reviewFields are the fields of a typical peer review template
+func reviewFields() []model.TemplateField {
+	return []model.TemplateField{
+		{Key: "overall", Label: "Overall", Type: model.TemplateFieldRating, Required: true, Min: 1, Max: 5},
+		{Key: "collaboration", Label: "Collaboration", Type: model.TemplateFieldDimension, Min: 1, Max: 5, Dimension: "collaboration"},
+		{Key: "strengths", Label: "Strengths", Type: model.TemplateFieldChoice, Options: []string{"communication", "delivery", "mentoring"}, Multiple: true},
+		{Key: "summary", Label: "Summary", Type: model.TemplateFieldText, Required: true, MaxLength: 20},
+	}
+}
+
+func TestCreateTemplate_RejectsInvalidFields(t *testing.T) {
+	rating := func(key string) model.TemplateField {
+		return model.TemplateField{Key: key, Label: key, Type: model.TemplateFieldRating, Min: 1, Max: 5}
+	}
+
+	for name, fields := range map[string][]model.TemplateField{
+		"no fields":     {},
+		"bad key":       {{Key: "Overall score", Label: "Overall", Type: model.TemplateFieldRating, Min: 1, Max: 5}},
+		"repeated key":  {rating("overall"), rating("overall")},
+		"missing label": {{Key: "overall", Type: model.TemplateFieldRating, Min: 1, Max: 5}},
+		"empty scale":   {{Key: "overall", Label: "Overall", Type: model.TemplateFieldRating, Min: 5, Max: 5}},
+		"dimension without a name": {
+			{Key: "impact", Label: "Impact", Type: model.TemplateFieldDimension, Min: 1, Max: 5},
+		},
+		"dimension scored twice": {
+			{Key: "impact", Label: "Impact", Type: model.TemplateFieldDimension, Min: 1, Max: 5, Dimension: "impact"},
+			{Key: "reach", Label: "Reach", Type: model.TemplateFieldDimension, Min: 1, Max: 5, Dimension: "impact"},
+		},
+		"single option":   {{Key: "pick", Label: "Pick", Type: model.TemplateFieldChoice, Options: []string{"yes"}}},
+		"repeated option": {{Key: "pick", Label: "Pick", Type: model.TemplateFieldChoice, Options: []string{"yes", "yes"}}},
+		"unknown type":    {{Key: "when", Label: "When", Type: "date"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			svc, repo := newTestTemplateService()
+
+			_, err := svc.CreateTemplate(context.Background(), "admin-1", &CreateTemplateRequest{Name: "Review", Fields: fields})
+
+			apiErr, ok := err.(*errors.APIError)
+			require.True(t, ok, "expected an API error, got %v", err)
+			assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+			assert.Empty(t, repo.templates)
+		})
+	}
+}
+
+func TestCreateTemplate_IsADraftUnlessPublished(t *testing.T) {
+	svc, _ := newTestTemplateService()
+
+	draft, err := svc.CreateTemplate(context.Background(), "admin-1", &CreateTemplateRequest{Name: " Review ", ContextTags: []string{"peer", " peer", ""}, Fields: reviewFields()})
+	require.NoError(t, err)
+	assert.Equal(t, model.TemplateStatusDraft, draft.Status)
+	assert.Equal(t, "Review", draft.Name)
+	assert.Equal(t, []string{"peer"}, draft.ContextTags)
+	assert.Equal(t, 1, draft.Version)
+
+	_, err = svc.GetPublishedTemplate(context.Background(), draft.TemplateID, 0)
+	assert.Equal(t, errors.ErrNotFound, err)
+
+	published, err := svc.CreateTemplate(context.Background(), "admin-1", &CreateTemplateRequest{Name: "Retro", Fields: reviewFields(), Publish: true})
+	require.NoError(t, err)
+	got, err := svc.GetPublishedTemplate(context.Background(), published.TemplateID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, published.TemplateID, got.TemplateID)
+}
+
+func TestUpdateTemplate_AddsAVersionOnlyWhenFieldsChange(t *testing.T) {
+	svc, repo := newTestTemplateService()
+	template, err := svc.CreateTemplate(context.Background(), "admin-1", &CreateTemplateRequest{Name: "Review", Fields: reviewFields(), Publish: true})
+	require.NoError(t, err)
+
+	renamed := "Peer review"
+	updated, err := svc.UpdateTemplate(context.Background(), "admin-1", template.TemplateID, &UpdateTemplateRequest{Name: &renamed, Fields: reviewFields()})
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated.Version)
+	assert.Equal(t, "Peer review", updated.Name)
+
+	fields := reviewFields()[:1]
+	updated, err = svc.UpdateTemplate(context.Background(), "admin-1", template.TemplateID, &UpdateTemplateRequest{Fields: fields})
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Version)
+	assert.Equal(t, fields, updated.Fields)
+
+	// Earlier versions keep their fields
+	first, err := svc.GetTemplate(context.Background(), template.TemplateID, 1)
+	require.NoError(t, err)
+	assert.Len(t, first.Fields, 4)
+	assert.Len(t, repo.templates[template.TemplateID], 2)
+
+	archived, err := svc.ArchiveTemplate(context.Background(), "admin-1", template.TemplateID)
+	require.NoError(t, err)
+	assert.Equal(t, model.TemplateStatusArchived, archived.Status)
+	assert.Equal(t, 2, archived.Version)
+}
+
+func TestApproveSuggestion_PublishesATemplateOnce(t *testing.T) {
+	svc, repo := newTestTemplateService()
+	repo.suggestions["s-1"] = &model.TemplateSuggestion{SuggestionID: "s-1", Status: model.SuggestionStatusPending}
+
+	template, err := svc.ApproveSuggestion(context.Background(), "admin-1", "s-1", &CreateTemplateRequest{Name: "Onboarding", Fields: reviewFields()})
+	require.NoError(t, err)
+
+	assert.Equal(t, model.TemplateStatusPublished, template.Status)
+	assert.Equal(t, model.SuggestionStatusApproved, repo.suggestions["s-1"].Status)
+	assert.Equal(t, template.TemplateID, *repo.suggestions["s-1"].TemplateID)
+
+	_, err = svc.ApproveSuggestion(context.Background(), "admin-1", "s-1", &CreateTemplateRequest{Name: "Onboarding", Fields: reviewFields()})
+	assert.Equal(t, errors.ErrSuggestionAlreadyReviewed, err)
+	_, err = svc.RejectSuggestion(context.Background(), "admin-1", "s-1", "duplicate")
+	assert.Equal(t, errors.ErrSuggestionAlreadyReviewed, err)
+	assert.Len(t, repo.templates, 1)
+}
+
+func TestRejectSuggestion_RecordsTheNote(t *testing.T) {
+	svc, repo := newTestTemplateService()
+	repo.suggestions["s-1"] = &model.TemplateSuggestion{SuggestionID: "s-1", Status: model.SuggestionStatusPending}
+
+	suggestion, err := svc.RejectSuggestion(context.Background(), "admin-1", "s-1", "  Covered by the peer review template ")
+	require.NoError(t, err)
+
+	assert.Equal(t, model.SuggestionStatusRejected, suggestion.Status)
+	assert.Equal(t, "Covered by the peer review template", suggestion.ReviewNote)
+	assert.Empty(t, repo.templates)
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"ethos/internal/feedback/model"
+	"ethos/pkg/errors"
+)
+
+// maxTemplateFields bounds how many questions a template can ask
+const maxTemplateFields = 50
+
+// fieldKeyPattern is what a field's key, which answers are submitted under, looks like
+var fieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// validateTemplateFields checks a template's field definitions: each has a
+// unique key and a label, and the settings its type needs
+func validateTemplateFields(fields []model.TemplateField) error {
+	if len(fields) == 0 {
+		return errors.NewValidationError("a template needs at least one field")
+	}
+	if len(fields) > maxTemplateFields {
+		return errors.NewValidationError(fmt.Sprintf("a template can have at most %d fields", maxTemplateFields))
+	}
+
+	keys := map[string]bool{}
+	dimensions := map[string]bool{}
+	for _, field := range fields {
+		if !fieldKeyPattern.MatchString(field.Key) {
+			return errors.NewValidationError(fmt.Sprintf("field key %q must be lowercase letters, digits and underscores, starting with a letter", field.Key))
+		}
+		if keys[field.Key] {
+			return errors.NewValidationError(fmt.Sprintf("field key %q is used more than once", field.Key))
+		}
+		keys[field.Key] = true
+
+		if strings.TrimSpace(field.Label) == "" {
+			return errors.NewValidationError(fmt.Sprintf("field %q needs a label", field.Key))
+		}
+
+		switch field.Type {
+		case model.TemplateFieldRating, model.TemplateFieldDimension:
+			if field.Max <= field.Min {
+				return errors.NewValidationError(fmt.Sprintf("field %q needs a max greater than its min", field.Key))
+			}
+			if field.Type == model.TemplateFieldDimension {
+				dimension := strings.TrimSpace(field.Dimension)
+				if dimension == "" {
+					return errors.NewValidationError(fmt.Sprintf("field %q needs a dimension", field.Key))
+				}
+				if dimensions[dimension] {
+					return errors.NewValidationError(fmt.Sprintf("dimension %q is scored by more than one field", dimension))
+				}
+				dimensions[dimension] = true
+			}
+		case model.TemplateFieldChoice:
+			if len(field.Options) < 2 {
+				return errors.NewValidationError(fmt.Sprintf("field %q needs at least two options", field.Key))
+			}
+			options := map[string]bool{}
+			for _, option := range field.Options {
+				if strings.TrimSpace(option) == "" {
+					return errors.NewValidationError(fmt.Sprintf("field %q has an empty option", field.Key))
+				}
+				if options[option] {
+					return errors.NewValidationError(fmt.Sprintf("field %q lists option %q more than once", field.Key, option))
+				}
+				options[option] = true
+			}
+		case model.TemplateFieldText:
+			if field.MaxLength < 0 {
+				return errors.NewValidationError(fmt.Sprintf("field %q has a negative max_length", field.Key))
+			}
+		default:
+			return errors.NewValidationError(fmt.Sprintf("field %q has unknown type %q", field.Key, field.Type))
+		}
+	}
+
+	return nil
+}
+
+// validateResponses checks answers submitted with a template against its
+// fields, and returns the scores given on its dimension fields. Answers
+// are keyed by field key, as decoded from JSON.
+func validateResponses(fields []model.TemplateField, responses map[string]interface{}) ([]model.FeedbackDimensionScore, error) {
+	known := map[string]bool{}
+	for _, field := range fields {
+		known[field.Key] = true
+	}
+	for key := range responses {
+		if !known[key] {
+			return nil, errors.NewValidationError(fmt.Sprintf("the template has no field %q", key))
+		}
+	}
+
+	var scores []model.FeedbackDimensionScore
+	for _, field := range fields {
+		answer, ok := responses[field.Key]
+		if !ok || answer == nil {
+			if field.Required {
+				return nil, errors.NewValidationError(fmt.Sprintf("field %q is required", field.Key))
+			}
+			continue
+		}
+
+		switch field.Type {
+		case model.TemplateFieldRating, model.TemplateFieldDimension:
+			score, ok := wholeNumber(answer)
+			if !ok || score < field.Min || score > field.Max {
+				return nil, errors.NewValidationError(fmt.Sprintf("field %q must be a whole number from %d to %d", field.Key, field.Min, field.Max))
+			}
+			if field.Type == model.TemplateFieldDimension {
+				scores = append(scores, model.FeedbackDimensionScore{Dimension: field.Dimension, Score: score})
+			}
+		case model.TemplateFieldChoice:
+			if err := validateChoice(field, answer); err != nil {
+				return nil, err
+			}
+		case model.TemplateFieldText:
+			text, ok := answer.(string)
+			if !ok {
+				return nil, errors.NewValidationError(fmt.Sprintf("field %q must be text", field.Key))
+			}
+			if field.Required && strings.TrimSpace(text) == "" {
+				return nil, errors.NewValidationError(fmt.Sprintf("field %q is required", field.Key))
+			}
+			if field.MaxLength > 0 && utf8.RuneCountInString(text) > field.MaxLength {
+				return nil, errors.NewValidationError(fmt.Sprintf("field %q can be at most %d characters", field.Key, field.MaxLength))
+			}
+		}
+	}
+
+	return scores, nil
+}
+
+// validateChoice checks that a choice field's answer is one of its
+// options, or a list of distinct options if it allows several
+func validateChoice(field model.TemplateField, answer interface{}) error {
+	options := map[string]bool{}
+	for _, option := range field.Options {
+		options[option] = true
+	}
+
+	if !field.Multiple {
+		choice, ok := answer.(string)
+		if !ok || !options[choice] {
+			return errors.NewValidationError(fmt.Sprintf("field %q must be one of %s", field.Key, strings.Join(field.Options, ", ")))
+		}
+		return nil
+	}
+
+	choices, ok := answer.([]interface{})
+	if !ok {
+		return errors.NewValidationError(fmt.Sprintf("field %q must be a list of options", field.Key))
+	}
+	if field.Required && len(choices) == 0 {
+		return errors.NewValidationError(fmt.Sprintf("field %q is required", field.Key))
+	}
+	chosen := map[string]bool{}
+	for _, c := range choices {
+		choice, ok := c.(string)
+		if !ok || !options[choice] {
+			return errors.NewValidationError(fmt.Sprintf("field %q options must be among %s", field.Key, strings.Join(field.Options, ", ")))
+		}
+		if chosen[choice] {
+			return errors.NewValidationError(fmt.Sprintf("field %q lists option %q more than once", field.Key, choice))
+		}
+		chosen[choice] = true
+	}
+	return nil
+}
+
+// wholeNumber converts a JSON number to an int if it has no fractional part
+func wholeNumber(answer interface{}) (int, bool) {
+	n, ok := answer.(float64)
+	if !ok || n != math.Trunc(n) || math.Abs(n) > math.MaxInt32 {
+		return 0, false
+	}
+	return int(n), true
+}
@@ -7,6 +7,15 @@ import (
 	"ethos/internal/feedback/model"
 )
 
+// Viewer identifies who is reading feedback and the organization they're
+// acting in. Reads only return feedback the user may see that is either
+// unscoped or scoped to that organization; an empty OrganizationID limits
+// them to unscoped feedback.
+type Viewer struct {
+	UserID         string
+	OrganizationID string
+}
+
 // TemplateSuggestionRequest represents a request to suggest a new template
 type TemplateSuggestionRequest struct {
 	SuggestedBy   *string             `json:"suggested_by,omitempty"`
@@ -47,6 +56,19 @@ type FeedFilters struct {
 	Tags         []string `json:"tags,omitempty"`          // Comma-separated tags
 }
 
+// SearchRequest represents a full-text search over feedback content,
+// narrowed by the feed filters, an author and a creation date range
+type SearchRequest struct {
+	Query    string       `json:"q"`
+	Locale   string       `json:"locale,omitempty"` // e.g. "de-DE"; defaults to the searcher's preference
+	AuthorID *string      `json:"author_id,omitempty"`
+	From     *time.Time   `json:"from,omitempty"`
+	To       *time.Time   `json:"to,omitempty"`
+	Filters  *FeedFilters `json:"filters,omitempty"`
+	Limit    int          `json:"limit"`
+	Offset   int          `json:"offset"`
+}
+
 // ExportResponse represents the response from a feedback export
 type ExportResponse struct {
 	Format      string `json:"format"`
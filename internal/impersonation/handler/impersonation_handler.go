@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+
+	"ethos/internal/impersonation/model"
+	"ethos/internal/impersonation/service"
+	"ethos/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImpersonationHandler handles platform admin impersonation HTTP requests
+type ImpersonationHandler struct {
+	service service.Service
+}
+
+// NewImpersonationHandler creates a new impersonation handler
+func NewImpersonationHandler(svc service.Service) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		service: svc,
+	}
+}
+
+// Start handles POST /api/v1/admin/impersonations
+func (h *ImpersonationHandler) Start(c *gin.Context) {
+	var req model.StartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+	req.AdminID = c.GetString("user_id")
+	req.IP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	resp, err := h.service.Start(c.Request.Context(), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// End handles DELETE /api/v1/admin/impersonations/current, called with the impersonation token
+func (h *ImpersonationHandler) End(c *gin.Context) {
+	req := &model.EndRequest{
+		AdminID:        c.GetString("impersonator_id"),
+		UserID:         c.GetString("user_id"),
+		OrganizationID: c.GetString("token_organization_id"),
+		TokenID:        c.GetString("token_id"),
+		IP:             c.ClientIP(),
+		UserAgent:      c.Request.UserAgent(),
+	}
+
+	if err := h.service.End(c.Request.Context(), req); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Impersonation ended.",
+	})
+}
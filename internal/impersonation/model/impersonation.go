@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// Activity log actions recorded while a platform admin impersonates a user
+const (
+	ActionImpersonationStarted = "impersonation_started"
+	ActionImpersonationEnded   = "impersonation_ended"
+	ActionImpersonatedRequest  = "impersonated_request"
+)
+
+// StartRequest represents a platform admin's request to view the app as a user
+type StartRequest struct {
+	UserID    string `json:"user_id" binding:"required"`
+	Reason    string `json:"reason" binding:"required,min=1,max=500"`
+	AdminID   string `json:"-"` // Set by the handler from the access token
+	IP        string `json:"-"` // Set by the handler for the audit log
+	UserAgent string `json:"-"` // Set by the handler for the audit log
+}
+
+// StartResponse carries the impersonation token. It can't be refreshed;
+// the admin starts a new impersonation once it expires.
+type StartResponse struct {
+	AccessToken    string    `json:"access_token"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	UserID         string    `json:"user_id"`
+	OrganizationID string    `json:"organization_id"`
+}
+
+// EndRequest ends an impersonation early, revoking its token. All fields are
+// set by the handler from the impersonation token.
+type EndRequest struct {
+	AdminID        string
+	UserID         string
+	OrganizationID string
+	TokenID        string
+	IP             string
+	UserAgent      string
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"ethos/internal/impersonation/model"
+)
+
+// Service defines the interface for platform admin impersonation
+type Service interface {
+	// Start issues a short-lived token for a platform admin to act as a user
+	Start(ctx context.Context, req *model.StartRequest) (*model.StartResponse, error)
+
+	// End revokes an impersonation token before it expires
+	End(ctx context.Context, req *model.EndRequest) error
+}
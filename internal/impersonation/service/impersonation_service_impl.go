@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	authModel "ethos/internal/auth/model"
+	"ethos/internal/impersonation/model"
+	orgRepository "ethos/internal/organization/repository"
+	"ethos/internal/revocation"
+	"ethos/pkg/errors"
+	"ethos/pkg/jwt"
+)
+
+// platformAdminRole is the platform role allowed to impersonate users
+const platformAdminRole = "platform_admin"
+
+// UserStore looks up users and their platform roles and records security
+// events (implemented by the auth repository)
+type UserStore interface {
+	GetUserByID(ctx context.Context, userID string) (*authModel.User, error)
+	GetUserRoles(ctx context.Context, userID string) ([]string, error)
+	CreateSecurityEvent(ctx context.Context, userID, eventType, ip, location string) error
+}
+
+// OrganizationStore resolves a user's organization and writes its activity
+// log (implemented by the organization context repository)
+type OrganizationStore interface {
+	GetUserCurrentOrganization(ctx context.Context, userID string) (*orgRepository.UserContext, error)
+	LogOrganizationActivity(ctx context.Context, organizationID, userID, action, resourceType, resourceID, ipAddress, userAgent string, changes map[string]interface{}) error
+}
+
+// Config holds impersonation settings
+type Config struct {
+	// TTL is how long an impersonation token lasts, capped at the access token lifetime
+	TTL time.Duration
+}
+
+// ImpersonationService implements the Service interface
+type ImpersonationService struct {
+	users          UserStore
+	organizations  OrganizationStore
+	tokenGenerator *jwt.TokenGenerator
+	revocations    revocation.List
+	config         Config
+}
+
+// NewImpersonationService creates a new impersonation service
+func NewImpersonationService(users UserStore, organizations OrganizationStore, tokenGen *jwt.TokenGenerator, revocations revocation.List, cfg Config) Service {
+	return &ImpersonationService{
+		users:          users,
+		organizations:  organizations,
+		tokenGenerator: tokenGen,
+		revocations:    revocations,
+		config:         cfg,
+	}
+}
+
+// Start issues a token that acts as the user in their current organization.
+// The token carries the admin's ID but none of either user's platform roles,
+// so it can't be used to administer anything. Every request made with it is
+// recorded in that organization's activity log, which is why users outside
+// any organization can't be impersonated.
+func (s *ImpersonationService) Start(ctx context.Context, req *model.StartRequest) (*model.StartResponse, error) {
+	if req.AdminID == req.UserID {
+		return nil, errors.NewValidationError("You can't impersonate yourself")
+	}
+
+	isAdmin, err := s.hasPlatformAdmin(ctx, req.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, errors.NewPermissionDeniedError("Only platform admins can impersonate users")
+	}
+
+	if _, err := s.users.GetUserByID(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	targetIsAdmin, err := s.hasPlatformAdmin(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if targetIsAdmin {
+		return nil, errors.NewPermissionDeniedError("Platform admins can't be impersonated")
+	}
+
+	current, err := s.organizations.GetUserCurrentOrganization(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if current.OrganizationID == "" {
+		return nil, errors.NewValidationError("Only users who belong to an organization can be impersonated")
+	}
+
+	token, err := s.tokenGenerator.GenerateImpersonationToken(req.UserID, req.AdminID, jwt.SessionContext{
+		OrganizationID:   current.OrganizationID,
+		OrganizationRole: current.Role,
+	}, s.config.TTL)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to generate impersonation token")
+	}
+	claims, err := s.tokenGenerator.ParseAccessToken(token)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to read impersonation token")
+	}
+
+	if err := s.users.CreateSecurityEvent(ctx, req.UserID, authModel.SecurityEventImpersonationStarted, "", ""); err != nil {
+		fmt.Printf("Failed to record security event: %v\n", err)
+	}
+	if err := s.organizations.LogOrganizationActivity(ctx, current.OrganizationID, req.AdminID, model.ActionImpersonationStarted, "user", req.UserID, req.IP, req.UserAgent, map[string]interface{}{
+		"reason":     req.Reason,
+		"token_id":   claims.ID,
+		"expires_at": claims.ExpiresAt.Time,
+	}); err != nil {
+		// Impersonation without an audit trail isn't allowed
+		return nil, errors.WrapError(err, "failed to log impersonation")
+	}
+
+	return &model.StartResponse{
+		AccessToken:    token,
+		ExpiresAt:      claims.ExpiresAt.Time,
+		UserID:         req.UserID,
+		OrganizationID: current.OrganizationID,
+	}, nil
+}
+
+// End revokes the impersonation token by its jti
+func (s *ImpersonationService) End(ctx context.Context, req *model.EndRequest) error {
+	if req.AdminID == "" {
+		return errors.NewValidationError("This token isn't an impersonation token")
+	}
+	if s.revocations == nil {
+		return errors.WrapError(fmt.Errorf("no revocation list configured"), "failed to end impersonation")
+	}
+	if err := s.revocations.RevokeToken(ctx, req.TokenID); err != nil {
+		return errors.WrapError(err, "failed to end impersonation")
+	}
+
+	if err := s.users.CreateSecurityEvent(ctx, req.UserID, authModel.SecurityEventImpersonationEnded, "", ""); err != nil {
+		fmt.Printf("Failed to record security event: %v\n", err)
+	}
+	if req.OrganizationID != "" {
+		if err := s.organizations.LogOrganizationActivity(ctx, req.OrganizationID, req.AdminID, model.ActionImpersonationEnded, "user", req.UserID, req.IP, req.UserAgent, map[string]interface{}{
+			"token_id": req.TokenID,
+		}); err != nil {
+			fmt.Printf("Failed to log organization activity: %v\n", err)
+		}
+	}
+	return nil
+}
+
+func (s *ImpersonationService) hasPlatformAdmin(ctx context.Context, userID string) (bool, error) {
+	roles, err := s.users.GetUserRoles(ctx, userID)
+	if err != nil {
+		return false, errors.WrapError(err, "failed to get user roles")
+	}
+	for _, role := range roles {
+		if role == platformAdminRole {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authModel "ethos/internal/auth/model"
+	"ethos/internal/impersonation/model"
+	orgRepository "ethos/internal/organization/repository"
+	"ethos/pkg/errors"
+	"ethos/pkg/jwt"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeUsers knows a platform admin, a member of org-1 and a user without an organization
+type fakeUsers struct {
+	roles  map[string][]string
+	events map[string][]string
+}
+
+func (f *fakeUsers) GetUserByID(ctx context.Context, userID string) (*authModel.User, error) {
+	if _, ok := f.roles[userID]; !ok {
+		return nil, errors.ErrUserNotFound
+	}
+	return &authModel.User{ID: userID}, nil
+}
+
+func (f *fakeUsers) GetUserRoles(ctx context.Context, userID string) ([]string, error) {
+	return f.roles[userID], nil
+}
+
+func (f *fakeUsers) CreateSecurityEvent(ctx context.Context, userID, eventType, ip, location string) error {
+	f.events[userID] = append(f.events[userID], eventType)
+	return nil
+}
+
+type fakeOrganizations struct {
+	current map[string]string
+	actions []string
+}
+
+func (f *fakeOrganizations) GetUserCurrentOrganization(ctx context.Context, userID string) (*orgRepository.UserContext, error) {
+	return &orgRepository.UserContext{UserID: userID, OrganizationID: f.current[userID], Role: "member"}, nil
+}
+
+func (f *fakeOrganizations) LogOrganizationActivity(ctx context.Context, organizationID, userID, action, resourceType, resourceID, ipAddress, userAgent string, changes map[string]interface{}) error {
+	f.actions = append(f.actions, organizationID+":"+userID+":"+action)
+	return nil
+}
+
+// fakeRevocations records revoked token IDs
+type fakeRevocations struct {
+	tokens map[string]bool
+}
+
+func (f *fakeRevocations) RevokeSession(ctx context.Context, sessionID string) error { return nil }
+
+func (f *fakeRevocations) RevokeToken(ctx context.Context, tokenID string) error {
+	f.tokens[tokenID] = true
+	return nil
+}
+
+func (f *fakeRevocations) RevokeUserTokens(ctx context.Context, userID string, issuedUntil time.Time) error {
+	return nil
+}
+
+func (f *fakeRevocations) IsRevoked(ctx context.Context, claims *jwt.Claims) (bool, error) {
+	return f.tokens[claims.ID], nil
+}
+
+func newTestService() (Service, *fakeUsers, *fakeOrganizations, *fakeRevocations, *jwt.TokenGenerator) {
+	users := &fakeUsers{
+		roles: map[string][]string{
+			"admin":    {"user", "platform_admin"},
+			"admin-2":  {"platform_admin"},
+			"member":   {"user"},
+			"orphaned": {"user"},
+		},
+		events: map[string][]string{},
+	}
+	organizations := &fakeOrganizations{current: map[string]string{"member": "org-1", "admin-2": "org-1"}}
+	revocations := &fakeRevocations{tokens: map[string]bool{}}
+	tokenGen := jwt.NewTokenGenerator("access-secret", "refresh-secret", 15*time.Minute, time.Hour)
+	svc := NewImpersonationService(users, organizations, tokenGen, revocations, Config{TTL: 5 * time.Minute})
+	return svc, users, organizations, revocations, tokenGen
+}
+
+func TestStart(t *testing.T) {
+	svc, users, organizations, _, tokenGen := newTestService()
+
+	resp, err := svc.Start(context.Background(), &model.StartRequest{AdminID: "admin", UserID: "member", Reason: "ticket 4521"})
+	require.NoError(t, err)
+	assert.Equal(t, "org-1", resp.OrganizationID)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), resp.ExpiresAt, 5*time.Second)
+
+	claims, err := tokenGen.ParseAccessToken(resp.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "member", claims.UserID)
+	assert.Equal(t, "admin", claims.ImpersonatorID)
+	assert.Equal(t, "org-1", claims.OrganizationID)
+	assert.Empty(t, claims.Roles)
+
+	assert.Equal(t, []string{authModel.SecurityEventImpersonationStarted}, users.events["member"])
+	assert.Equal(t, []string{"org-1:admin:" + model.ActionImpersonationStarted}, organizations.actions)
+}
+
+func TestStart_Rejections(t *testing.T) {
+	svc, users, _, _, _ := newTestService()
+
+	tests := []struct {
+		name string
+		req  *model.StartRequest
+		code string
+	}{
+		{"not a platform admin", &model.StartRequest{AdminID: "member", UserID: "orphaned"}, "PERMISSION_DENIED"},
+		{"another platform admin", &model.StartRequest{AdminID: "admin", UserID: "admin-2"}, "PERMISSION_DENIED"},
+		{"themselves", &model.StartRequest{AdminID: "admin", UserID: "admin"}, "VALIDATION_FAILED"},
+		{"no organization to audit in", &model.StartRequest{AdminID: "admin", UserID: "orphaned"}, "VALIDATION_FAILED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Start(context.Background(), tt.req)
+			apiErr, ok := err.(*errors.APIError)
+			require.True(t, ok)
+			assert.Equal(t, tt.code, apiErr.Code)
+		})
+	}
+	assert.Empty(t, users.events)
+}
+
+func TestEnd(t *testing.T) {
+	svc, users, organizations, revocations, _ := newTestService()
+
+	require.NoError(t, svc.End(context.Background(), &model.EndRequest{AdminID: "admin", UserID: "member", OrganizationID: "org-1", TokenID: "jti-1"}))
+	assert.True(t, revocations.tokens["jti-1"])
+	assert.Equal(t, []string{authModel.SecurityEventImpersonationEnded}, users.events["member"])
+	assert.Equal(t, []string{"org-1:admin:" + model.ActionImpersonationEnded}, organizations.actions)
+
+	err := svc.End(context.Background(), &model.EndRequest{UserID: "member", TokenID: "jti-2"})
+	apiErr, ok := err.(*errors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+	assert.False(t, revocations.tokens["jti-2"])
+}
@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"net/http"
+
+	"ethos/internal/invitation/model"
+	"ethos/internal/invitation/service"
+	permissionModel "ethos/internal/permission/model"
+	"ethos/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InvitationHandler handles organization invitation HTTP requests
+type InvitationHandler struct {
+	service service.Service
+}
+
+// NewInvitationHandler creates a new invitation handler
+func NewInvitationHandler(svc service.Service) *InvitationHandler {
+	return &InvitationHandler{
+		service: svc,
+	}
+}
+
+// CreateInvitation handles POST /api/v1/organizations/:org_id/invitations
+func (h *InvitationHandler) CreateInvitation(c *gin.Context) {
+	var req model.CreateInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+	req.IP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	invitation, err := h.service.CreateInvitation(c.Request.Context(), actor(c), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// ListInvitations handles GET /api/v1/organizations/:org_id/invitations
+func (h *InvitationHandler) ListInvitations(c *gin.Context) {
+	invitations, err := h.service.ListInvitations(c.Request.Context(), c.Param("org_id"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"invitations": invitations,
+		"count":       len(invitations),
+	})
+}
+
+// ResendInvitation handles POST /api/v1/organizations/:org_id/invitations/:invitation_id/resend
+func (h *InvitationHandler) ResendInvitation(c *gin.Context) {
+	invitation, err := h.service.ResendInvitation(c.Request.Context(), actor(c), c.Param("invitation_id"), c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+// RevokeInvitation handles DELETE /api/v1/organizations/:org_id/invitations/:invitation_id
+func (h *InvitationHandler) RevokeInvitation(c *gin.Context) {
+	err := h.service.RevokeInvitation(c.Request.Context(), actor(c), c.Param("invitation_id"), c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invitation revoked.",
+	})
+}
+
+// GetInvitation handles GET /api/v1/invitations/:token
+func (h *InvitationHandler) GetInvitation(c *gin.Context) {
+	details, err := h.service.GetInvitationByToken(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, details)
+}
+
+// AcceptInvitation handles POST /api/v1/invitations/accept
+// The signed-in user's email address must be the one invited
+func (h *InvitationHandler) AcceptInvitation(c *gin.Context) {
+	var req model.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+	req.UserID = c.GetString("user_id")
+	req.IP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	membership, err := h.service.AcceptInvitation(c.Request.Context(), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, membership)
+}
+
+// RegisterAndAccept handles POST /api/v1/invitations/register
+// Creates an account for the invited address; existing accounts sign in and accept instead
+func (h *InvitationHandler) RegisterAndAccept(c *gin.Context) {
+	var req model.RegisterInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+	req.IP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	membership, err := h.service.RegisterAndAccept(c.Request.Context(), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, membership)
+}
+
+// DeclineInvitation handles POST /api/v1/invitations/decline
+func (h *InvitationHandler) DeclineInvitation(c *gin.Context) {
+	var req model.DeclineInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+	req.IP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	if err := h.service.DeclineInvitation(c.Request.Context(), &req); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invitation declined.",
+	})
+}
+
+// actor is the user making the request, in the organization from the URL
+func actor(c *gin.Context) permissionModel.Subject {
+	return permissionModel.Subject{
+		UserID:           c.GetString("user_id"),
+		OrganizationID:   c.Param("org_id"),
+		OrganizationRole: c.GetString("user_role_in_org"),
+	}
+}
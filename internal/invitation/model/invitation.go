@@ -0,0 +1,105 @@
+package model
+
+import "time"
+
+// Invitation statuses
+const (
+	StatusPending  = "pending"
+	StatusAccepted = "accepted"
+	StatusDeclined = "declined"
+	StatusRevoked  = "revoked"
+	StatusExpired  = "expired"
+)
+
+// Activity log actions recorded for invitations
+const (
+	ActionInvitationCreated  = "invitation_created"
+	ActionInvitationResent   = "invitation_resent"
+	ActionInvitationRevoked  = "invitation_revoked"
+	ActionInvitationAccepted = "invitation_accepted"
+	ActionInvitationDeclined = "invitation_declined"
+)
+
+// Invitation asks someone to join an organization with a role. Only a hash
+// of the emailed token is stored. A pending invitation holds a seat, so it
+// counts against the organization's MaxUsers until it's answered, revoked
+// or expires.
+type Invitation struct {
+	ID             string     `json:"id"`
+	OrganizationID string     `json:"organization_id"`
+	Email          string     `json:"email"`
+	Role           string     `json:"role"`
+	InvitedBy      string     `json:"invited_by"`
+	Status         string     `json:"status"`
+	TokenHash      string     `json:"-"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	AcceptedBy     string     `json:"accepted_by,omitempty"`
+	RespondedAt    *time.Time `json:"responded_at,omitempty"`
+	SendCount      int        `json:"send_count"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// StatusAt returns the invitation's status at now, treating pending
+// invitations past their expiry as expired
+func (i *Invitation) StatusAt(now time.Time) string {
+	if i.Status == StatusPending && !now.Before(i.ExpiresAt) {
+		return StatusExpired
+	}
+	return i.Status
+}
+
+// CreateInvitationRequest represents a request to invite someone to an organization
+type CreateInvitationRequest struct {
+	Email     string `json:"email" binding:"required,email,max=255"`
+	Role      string `json:"role" binding:"required,min=1,max=50"`
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// InvitationDetails is what the holder of an invitation token can see
+// before answering it
+type InvitationDetails struct {
+	OrganizationID   string    `json:"organization_id"`
+	OrganizationName string    `json:"organization_name"`
+	Email            string    `json:"email"`
+	Role             string    `json:"role"`
+	InvitedBy        string    `json:"invited_by"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	// AccountExists tells the client whether to sign in or register to accept
+	AccountExists bool `json:"account_exists"`
+}
+
+// AcceptInvitationRequest accepts an invitation as the signed-in user
+type AcceptInvitationRequest struct {
+	Token     string `json:"token" binding:"required"`
+	UserID    string `json:"-"` // Set by the handler from the access token
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// RegisterInvitationRequest creates an account for the invited address and
+// accepts the invitation with it
+type RegisterInvitationRequest struct {
+	Token       string `json:"token" binding:"required"`
+	Password    string `json:"password" binding:"required,min=8"`
+	FirstName   string `json:"first_name" binding:"required,min=1"`
+	LastName    string `json:"last_name" binding:"required,min=1"`
+	AcceptTerms bool   `json:"accept_terms" binding:"required"`
+	IP          string `json:"-"`
+	UserAgent   string `json:"-"`
+}
+
+// DeclineInvitationRequest declines an invitation
+type DeclineInvitationRequest struct {
+	Token     string `json:"token" binding:"required"`
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// AcceptInvitationResponse describes the membership an accepted invitation created
+type AcceptInvitationResponse struct {
+	OrganizationID string `json:"organization_id"`
+	UserID         string `json:"user_id"`
+	Role           string `json:"role"`
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ethos/internal/invitation/model"
+)
+
+// Repository defines the interface for organization invitation data access
+type Repository interface {
+	// CreateInvitation stores a pending invitation. It returns ErrAlreadyMember
+	// if the address belongs to a member, ErrInvitationPending if it already
+	// has a pending invitation, and ErrSeatLimitReached if members and pending
+	// invitations already fill the organization's MaxUsers.
+	CreateInvitation(ctx context.Context, invitation *model.Invitation) error
+
+	// ListInvitations lists an organization's invitations, newest first
+	ListInvitations(ctx context.Context, organizationID string) ([]*model.Invitation, error)
+
+	// GetInvitation retrieves one of an organization's invitations, or ErrNotFound
+	GetInvitation(ctx context.Context, organizationID, id string) (*model.Invitation, error)
+
+	// GetInvitationByTokenHash retrieves an invitation by its token hash, or ErrNotFound
+	GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*model.Invitation, error)
+
+	// RenewInvitation replaces a pending invitation's token and expiry and
+	// counts another send. An expired invitation takes its seat back, so it
+	// can fail with ErrSeatLimitReached. Returns ErrNotFound unless pending.
+	RenewInvitation(ctx context.Context, organizationID, id, tokenHash string, expiresAt time.Time) (*model.Invitation, error)
+
+	// RevokeInvitation cancels a pending invitation, or returns ErrNotFound
+	RevokeInvitation(ctx context.Context, organizationID, id string) error
+
+	// DeclineInvitation marks a pending, unexpired invitation declined, or returns ErrInvitationInvalid
+	DeclineInvitation(ctx context.Context, id string) error
+
+	// AcceptInvitation marks a pending, unexpired invitation accepted by the
+	// user and adds them to the organization with its role, in one
+	// transaction. A user who is already a member keeps their role. Returns
+	// the role they hold, or ErrInvitationInvalid.
+	AcceptInvitation(ctx context.Context, id, userID string) (string, error)
+}
@@ -0,0 +1,387 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ethos/internal/database"
+	"ethos/internal/invitation/model"
+	orgRepository "ethos/internal/organization/repository"
+	"ethos/pkg/errors"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const invitationColumns = `
+	id::text, organization_id::text, email, role, COALESCE(invited_by, ''), status, token_hash,
+	expires_at, COALESCE(accepted_by, ''), responded_at, send_count, created_at, updated_at
+`
+
+// PostgresRepository implements the Repository interface using PostgreSQL
+type PostgresRepository struct {
+	db *database.DB
+}
+
+// NewPostgresRepository creates a new PostgreSQL repository
+func NewPostgresRepository(db *database.DB) Repository {
+	return &PostgresRepository{db: db}
+}
+
+// CreateInvitation stores a pending invitation, checking membership, pending
+// invitations and the seat limit under a per-organization lock so concurrent
+// invitations can't overfill it
+func (r *PostgresRepository) CreateInvitation(ctx context.Context, invitation *model.Invitation) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.CreateInvitation")
+	defer span.End()
+
+	err := r.inSeatLock(ctx, invitation.OrganizationID, func(tx pgx.Tx) error {
+		// Free the addresses and seats of invitations that ran out unanswered
+		if _, err := tx.Exec(ctx, `
+			UPDATE organization_invitations
+			SET status = 'expired', updated_at = NOW()
+			WHERE organization_id::text = $1 AND status = 'pending' AND expires_at <= NOW()`,
+			invitation.OrganizationID,
+		); err != nil {
+			return errors.WrapError(err, "failed to expire invitations")
+		}
+
+		var isMember, isPending bool
+		err := tx.QueryRow(ctx, `
+			SELECT
+				EXISTS (
+					SELECT 1 FROM organization_members m
+					JOIN users u ON u.id = m.user_id
+					WHERE m.organization_id::text = $1 AND LOWER(u.email) = LOWER($2)
+				),
+				EXISTS (
+					SELECT 1 FROM organization_invitations
+					WHERE organization_id::text = $1 AND LOWER(email) = LOWER($2) AND status = 'pending'
+				)`,
+			invitation.OrganizationID, invitation.Email,
+		).Scan(&isMember, &isPending)
+		if err != nil {
+			return errors.WrapError(err, "failed to check existing membership")
+		}
+		if isMember {
+			return errors.ErrAlreadyMember
+		}
+		if isPending {
+			return errors.ErrInvitationPending
+		}
+
+		if err := orgRepository.CheckSeatAvailable(ctx, tx, invitation.OrganizationID, ""); err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(ctx, `
+			INSERT INTO organization_invitations (organization_id, email, role, invited_by, token_hash, expires_at)
+			VALUES ($1::uuid, $2, $3, NULLIF($4, ''), $5, $6)
+			RETURNING id::text, status, send_count, created_at, updated_at`,
+			invitation.OrganizationID,
+			invitation.Email,
+			invitation.Role,
+			invitation.InvitedBy,
+			invitation.TokenHash,
+			invitation.ExpiresAt,
+		).Scan(&invitation.ID, &invitation.Status, &invitation.SendCount, &invitation.CreatedAt, &invitation.UpdatedAt)
+		if err != nil {
+			return errors.WrapError(err, "failed to create invitation")
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// ListInvitations lists an organization's invitations, newest first
+func (r *PostgresRepository) ListInvitations(ctx context.Context, organizationID string) ([]*model.Invitation, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ListInvitations")
+	defer span.End()
+
+	query := `SELECT ` + invitationColumns + ` FROM organization_invitations WHERE organization_id::text = $1 ORDER BY created_at DESC`
+
+	rows, err := r.db.Pool.Query(ctx, query, organizationID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list invitations")
+	}
+	defer rows.Close()
+
+	invitations := []*model.Invitation{}
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, errors.WrapError(err, "failed to scan invitation")
+		}
+		invitations = append(invitations, invitation)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list invitations")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return invitations, nil
+}
+
+// GetInvitation retrieves one of an organization's invitations, or ErrNotFound
+func (r *PostgresRepository) GetInvitation(ctx context.Context, organizationID, id string) (*model.Invitation, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetInvitation")
+	defer span.End()
+
+	query := `SELECT ` + invitationColumns + ` FROM organization_invitations WHERE id::text = $1 AND organization_id::text = $2`
+
+	invitation, err := scanInvitation(r.db.Pool.QueryRow(ctx, query, id, organizationID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get invitation")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return invitation, nil
+}
+
+// GetInvitationByTokenHash retrieves an invitation by its token hash, or ErrNotFound
+func (r *PostgresRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*model.Invitation, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetInvitationByTokenHash")
+	defer span.End()
+
+	query := `SELECT ` + invitationColumns + ` FROM organization_invitations WHERE token_hash = $1`
+
+	invitation, err := scanInvitation(r.db.Pool.QueryRow(ctx, query, tokenHash))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get invitation")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return invitation, nil
+}
+
+// RenewInvitation replaces a pending invitation's token and expiry and counts another send
+func (r *PostgresRepository) RenewInvitation(ctx context.Context, organizationID, id, tokenHash string, expiresAt time.Time) (*model.Invitation, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.RenewInvitation")
+	defer span.End()
+
+	var invitation *model.Invitation
+	err := r.inSeatLock(ctx, organizationID, func(tx pgx.Tx) error {
+		var expired bool
+		err := tx.QueryRow(ctx, `
+			SELECT expires_at <= NOW()
+			FROM organization_invitations
+			WHERE id::text = $1 AND organization_id::text = $2 AND status = 'pending'
+			FOR UPDATE`,
+			id, organizationID,
+		).Scan(&expired)
+		if err == pgx.ErrNoRows {
+			return errors.ErrNotFound
+		}
+		if err != nil {
+			return errors.WrapError(err, "failed to get invitation")
+		}
+
+		// An expired invitation gave up its seat, so it needs a free one again
+		if expired {
+			if err := orgRepository.CheckSeatAvailable(ctx, tx, organizationID, id); err != nil {
+				return err
+			}
+		}
+
+		invitation, err = scanInvitation(tx.QueryRow(ctx, `
+			UPDATE organization_invitations
+			SET token_hash = $2, expires_at = $3, send_count = send_count + 1, updated_at = NOW()
+			WHERE id::text = $1
+			RETURNING `+invitationColumns,
+			id, tokenHash, expiresAt,
+		))
+		if err != nil {
+			return errors.WrapError(err, "failed to renew invitation")
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return invitation, nil
+}
+
+// RevokeInvitation cancels a pending invitation, or returns ErrNotFound
+func (r *PostgresRepository) RevokeInvitation(ctx context.Context, organizationID, id string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.RevokeInvitation")
+	defer span.End()
+
+	query := `
+		UPDATE organization_invitations
+		SET status = 'revoked', responded_at = NOW(), updated_at = NOW()
+		WHERE id::text = $1 AND organization_id::text = $2 AND status = 'pending'
+	`
+
+	result, err := r.db.Pool.Exec(ctx, query, id, organizationID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to revoke invitation")
+	}
+	if result.RowsAffected() == 0 {
+		return errors.ErrNotFound
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// DeclineInvitation marks a pending, unexpired invitation declined, or returns ErrInvitationInvalid
+func (r *PostgresRepository) DeclineInvitation(ctx context.Context, id string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.DeclineInvitation")
+	defer span.End()
+
+	query := `
+		UPDATE organization_invitations
+		SET status = 'declined', responded_at = NOW(), updated_at = NOW()
+		WHERE id::text = $1 AND status = 'pending' AND expires_at > NOW()
+	`
+
+	result, err := r.db.Pool.Exec(ctx, query, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to decline invitation")
+	}
+	if result.RowsAffected() == 0 {
+		return errors.ErrInvitationInvalid
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// AcceptInvitation marks a pending, unexpired invitation accepted and adds
+// the user to the organization in the same transaction
+func (r *PostgresRepository) AcceptInvitation(ctx context.Context, id, userID string) (string, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.AcceptInvitation")
+	defer span.End()
+
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", errors.WrapError(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	var organizationID, role string
+	err = tx.QueryRow(ctx, `
+		UPDATE organization_invitations
+		SET status = 'accepted', accepted_by = $2, responded_at = NOW(), updated_at = NOW()
+		WHERE id::text = $1 AND status = 'pending' AND expires_at > NOW()
+		RETURNING organization_id::text, role`,
+		id, userID,
+	).Scan(&organizationID, &role)
+	if err == pgx.ErrNoRows {
+		return "", errors.ErrInvitationInvalid
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", errors.WrapError(err, "failed to accept invitation")
+	}
+
+	// Someone who joined another way in the meantime keeps the role they have
+	err = tx.QueryRow(ctx, `
+		WITH inserted AS (
+			INSERT INTO organization_members (organization_id, user_id, role, joined_at)
+			VALUES ($1::uuid, $2, $3, NOW())
+			ON CONFLICT (organization_id, user_id) DO NOTHING
+			RETURNING role
+		)
+		SELECT role FROM inserted
+		UNION ALL
+		SELECT role FROM organization_members
+		WHERE organization_id::text = $1 AND user_id = $2 AND NOT EXISTS (SELECT 1 FROM inserted)`,
+		organizationID, userID, role,
+	).Scan(&role)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", errors.WrapError(err, "failed to add organization member")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", errors.WrapError(err, "failed to commit transaction")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return role, nil
+}
+
+// inSeatLock runs fn in a transaction holding the organization's seat lock,
+// which serializes everything that can take a seat
+func (r *PostgresRepository) inSeatLock(ctx context.Context, organizationID string, fn func(tx pgx.Tx) error) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return errors.WrapError(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	if err := orgRepository.LockSeats(ctx, tx, organizationID); err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.WrapError(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+func scanInvitation(row pgx.Row) (*model.Invitation, error) {
+	var invitation model.Invitation
+	err := row.Scan(
+		&invitation.ID,
+		&invitation.OrganizationID,
+		&invitation.Email,
+		&invitation.Role,
+		&invitation.InvitedBy,
+		&invitation.Status,
+		&invitation.TokenHash,
+		&invitation.ExpiresAt,
+		&invitation.AcceptedBy,
+		&invitation.RespondedAt,
+		&invitation.SendCount,
+		&invitation.CreatedAt,
+		&invitation.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
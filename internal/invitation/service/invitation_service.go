@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"ethos/internal/invitation/model"
+	permissionModel "ethos/internal/permission/model"
+)
+
+// Service defines the interface for organization invitations
+type Service interface {
+	// CreateInvitation invites an email address to the actor's organization and emails the invitation
+	CreateInvitation(ctx context.Context, actor permissionModel.Subject, req *model.CreateInvitationRequest) (*model.Invitation, error)
+
+	// ListInvitations lists an organization's invitations
+	ListInvitations(ctx context.Context, organizationID string) ([]*model.Invitation, error)
+
+	// ResendInvitation emails a pending invitation again with a new token and expiry
+	ResendInvitation(ctx context.Context, actor permissionModel.Subject, invitationID, ipAddress, userAgent string) (*model.Invitation, error)
+
+	// RevokeInvitation cancels a pending invitation, freeing its seat
+	RevokeInvitation(ctx context.Context, actor permissionModel.Subject, invitationID, ipAddress, userAgent string) error
+
+	// GetInvitationByToken shows the holder of an invitation token what they were invited to
+	GetInvitationByToken(ctx context.Context, token string) (*model.InvitationDetails, error)
+
+	// AcceptInvitation adds the signed-in user to the organization they were invited to
+	AcceptInvitation(ctx context.Context, req *model.AcceptInvitationRequest) (*model.AcceptInvitationResponse, error)
+
+	// RegisterAndAccept creates an account for the invited address and adds it to the organization
+	RegisterAndAccept(ctx context.Context, req *model.RegisterInvitationRequest) (*model.AcceptInvitationResponse, error)
+
+	// DeclineInvitation declines an invitation, freeing its seat
+	DeclineInvitation(ctx context.Context, req *model.DeclineInvitationRequest) error
+}
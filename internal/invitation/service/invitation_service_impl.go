@@ -0,0 +1,388 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	authModel "ethos/internal/auth/model"
+	authService "ethos/internal/auth/service"
+	"ethos/internal/invitation/model"
+	"ethos/internal/invitation/repository"
+	orgModel "ethos/internal/organization/model"
+	permissionModel "ethos/internal/permission/model"
+	"ethos/pkg/email"
+	emailTemplates "ethos/pkg/email/templates"
+	"ethos/pkg/errors"
+)
+
+// OrganizationStore looks up organizations (implemented by the organization repository)
+type OrganizationStore interface {
+	GetOrganization(ctx context.Context, orgID string) (*orgModel.Organization, error)
+}
+
+// UserStore looks up users (implemented by the auth repository)
+type UserStore interface {
+	GetUserByID(ctx context.Context, userID string) (*authModel.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*authModel.User, error)
+}
+
+// Registrar creates accounts (implemented by the auth service)
+type Registrar interface {
+	Register(ctx context.Context, req *authService.RegisterRequest) (*authModel.UserProfile, error)
+}
+
+// RoleValidator checks the actor may hand out a role (implemented by the permission service)
+type RoleValidator interface {
+	CheckAssignable(ctx context.Context, actor permissionModel.Subject, roleName string) (string, error)
+}
+
+// ActivityLogger writes the organization activity log (implemented by the organization context repository)
+type ActivityLogger interface {
+	LogOrganizationActivity(ctx context.Context, organizationID, userID, action, resourceType, resourceID, ipAddress, userAgent string, changes map[string]interface{}) error
+}
+
+// EmailSender sends invitation emails
+type EmailSender interface {
+	SendEmail(ctx context.Context, req email.SendEmailRequest) error
+}
+
+// Config holds invitation settings
+type Config struct {
+	// TTL is how long an invitation can be accepted
+	TTL time.Duration
+	// URL is the frontend page invitation emails link to
+	URL string
+}
+
+// InvitationService implements the Service interface
+type InvitationService struct {
+	repo        repository.Repository
+	orgs        OrganizationStore
+	users       UserStore
+	registrar   Registrar
+	roles       RoleValidator
+	activity    ActivityLogger
+	emailSender EmailSender
+	config      Config
+}
+
+// NewInvitationService creates a new invitation service
+func NewInvitationService(repo repository.Repository, orgs OrganizationStore, users UserStore, registrar Registrar, roles RoleValidator, activity ActivityLogger, emailSender EmailSender, cfg Config) Service {
+	return &InvitationService{
+		repo:        repo,
+		orgs:        orgs,
+		users:       users,
+		registrar:   registrar,
+		roles:       roles,
+		activity:    activity,
+		emailSender: emailSender,
+		config:      cfg,
+	}
+}
+
+// CreateInvitation invites an email address to the actor's organization.
+// Like assigning roles, the actor can't invite people into a role with
+// permissions they don't have.
+func (s *InvitationService) CreateInvitation(ctx context.Context, actor permissionModel.Subject, req *model.CreateInvitationRequest) (*model.Invitation, error) {
+	role, err := s.roles.CheckAssignable(ctx, actor, req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgs.GetOrganization(ctx, actor.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := generateRandomToken()
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to generate invitation token")
+	}
+
+	invitation := &model.Invitation{
+		OrganizationID: actor.OrganizationID,
+		Email:          strings.ToLower(strings.TrimSpace(req.Email)),
+		Role:           role,
+		InvitedBy:      actor.UserID,
+		TokenHash:      hashToken(token),
+		ExpiresAt:      time.Now().Add(s.config.TTL),
+	}
+	if err := s.repo.CreateInvitation(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	s.logActivity(ctx, invitation, actor.UserID, model.ActionInvitationCreated, req.IP, req.UserAgent)
+	s.sendInvitationEmail(ctx, org, invitation, token)
+	return invitation, nil
+}
+
+// ListInvitations lists an organization's invitations
+func (s *InvitationService) ListInvitations(ctx context.Context, organizationID string) ([]*model.Invitation, error) {
+	invitations, err := s.repo.ListInvitations(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, invitation := range invitations {
+		invitation.Status = invitation.StatusAt(now)
+	}
+	return invitations, nil
+}
+
+// ResendInvitation emails a pending invitation again. The old link stops
+// working and the expiry starts again.
+func (s *InvitationService) ResendInvitation(ctx context.Context, actor permissionModel.Subject, invitationID, ipAddress, userAgent string) (*model.Invitation, error) {
+	invitation, err := s.repo.GetInvitation(ctx, actor.OrganizationID, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.roles.CheckAssignable(ctx, actor, invitation.Role); err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgs.GetOrganization(ctx, actor.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := generateRandomToken()
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to generate invitation token")
+	}
+
+	invitation, err = s.repo.RenewInvitation(ctx, actor.OrganizationID, invitationID, hashToken(token), time.Now().Add(s.config.TTL))
+	if err != nil {
+		return nil, err
+	}
+
+	s.logActivity(ctx, invitation, actor.UserID, model.ActionInvitationResent, ipAddress, userAgent)
+	s.sendInvitationEmail(ctx, org, invitation, token)
+	return invitation, nil
+}
+
+// RevokeInvitation cancels a pending invitation. Like sending it, this needs
+// permission to grant the invitation's role.
+func (s *InvitationService) RevokeInvitation(ctx context.Context, actor permissionModel.Subject, invitationID, ipAddress, userAgent string) error {
+	invitation, err := s.repo.GetInvitation(ctx, actor.OrganizationID, invitationID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.roles.CheckAssignable(ctx, actor, invitation.Role); err != nil {
+		return err
+	}
+	if err := s.repo.RevokeInvitation(ctx, actor.OrganizationID, invitationID); err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, invitation, actor.UserID, model.ActionInvitationRevoked, ipAddress, userAgent)
+	return nil
+}
+
+// GetInvitationByToken shows the holder of an invitation token what they
+// were invited to, and whether they need to register to accept
+func (s *InvitationService) GetInvitationByToken(ctx context.Context, token string) (*model.InvitationDetails, error) {
+	invitation, err := s.pendingInvitation(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgs.GetOrganization(ctx, invitation.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	accountExists := true
+	if _, err := s.users.GetUserByEmail(ctx, invitation.Email); err == errors.ErrUserNotFound {
+		accountExists = false
+	} else if err != nil {
+		return nil, err
+	}
+
+	inviterName, _ := s.inviter(ctx, invitation)
+	return &model.InvitationDetails{
+		OrganizationID:   org.ID,
+		OrganizationName: org.Name,
+		Email:            invitation.Email,
+		Role:             invitation.Role,
+		InvitedBy:        inviterName,
+		ExpiresAt:        invitation.ExpiresAt,
+		AccountExists:    accountExists,
+	}, nil
+}
+
+// AcceptInvitation adds the signed-in user to the organization. The token
+// alone isn't enough: the user's account must have the invited address.
+func (s *InvitationService) AcceptInvitation(ctx context.Context, req *model.AcceptInvitationRequest) (*model.AcceptInvitationResponse, error) {
+	invitation, err := s.pendingInvitation(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(strings.TrimSpace(user.Email), invitation.Email) {
+		return nil, errors.NewPermissionDeniedError("This invitation was sent to a different email address")
+	}
+
+	return s.accept(ctx, invitation, user.ID, req.IP, req.UserAgent)
+}
+
+// RegisterAndAccept creates an account for the invited address and adds it
+// to the organization. The emailed token proves the address, so the account
+// starts verified. Existing accounts must sign in and accept instead.
+func (s *InvitationService) RegisterAndAccept(ctx context.Context, req *model.RegisterInvitationRequest) (*model.AcceptInvitationResponse, error) {
+	invitation, err := s.pendingInvitation(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.users.GetUserByEmail(ctx, invitation.Email); err == nil {
+		return nil, errors.NewValidationError("an account already exists for this email address; sign in to accept the invitation")
+	} else if err != errors.ErrUserNotFound {
+		return nil, err
+	}
+
+	profile, err := s.registrar.Register(ctx, &authService.RegisterRequest{
+		Email:         invitation.Email,
+		Password:      req.Password,
+		FirstName:     req.FirstName,
+		LastName:      req.LastName,
+		AcceptTerms:   req.AcceptTerms,
+		EmailVerified: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.accept(ctx, invitation, profile.ID, req.IP, req.UserAgent)
+}
+
+// DeclineInvitation declines an invitation
+func (s *InvitationService) DeclineInvitation(ctx context.Context, req *model.DeclineInvitationRequest) error {
+	invitation, err := s.pendingInvitation(ctx, req.Token)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeclineInvitation(ctx, invitation.ID); err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, invitation, "", model.ActionInvitationDeclined, req.IP, req.UserAgent)
+	return nil
+}
+
+func (s *InvitationService) accept(ctx context.Context, invitation *model.Invitation, userID, ipAddress, userAgent string) (*model.AcceptInvitationResponse, error) {
+	role, err := s.repo.AcceptInvitation(ctx, invitation.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logActivity(ctx, invitation, userID, model.ActionInvitationAccepted, ipAddress, userAgent)
+	return &model.AcceptInvitationResponse{
+		OrganizationID: invitation.OrganizationID,
+		UserID:         userID,
+		Role:           role,
+	}, nil
+}
+
+// pendingInvitation resolves a token to an invitation that can still be
+// answered. Unknown, used and expired tokens all look the same.
+func (s *InvitationService) pendingInvitation(ctx context.Context, token string) (*model.Invitation, error) {
+	invitation, err := s.repo.GetInvitationByTokenHash(ctx, hashToken(strings.TrimSpace(token)))
+	if err == errors.ErrNotFound {
+		return nil, errors.ErrInvitationInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	if invitation.StatusAt(time.Now()) != model.StatusPending {
+		return nil, errors.ErrInvitationInvalid
+	}
+	return invitation, nil
+}
+
+// inviter returns the name and email address of whoever sent the invitation
+func (s *InvitationService) inviter(ctx context.Context, invitation *model.Invitation) (string, string) {
+	if invitation.InvitedBy == "" {
+		return "", ""
+	}
+	user, err := s.users.GetUserByID(ctx, invitation.InvitedBy)
+	if err != nil {
+		fmt.Printf("Failed to look up inviter %s: %v\n", invitation.InvitedBy, err)
+		return "", ""
+	}
+	return strings.TrimSpace(user.FirstName + " " + user.LastName), user.Email
+}
+
+func (s *InvitationService) logActivity(ctx context.Context, invitation *model.Invitation, userID, action, ipAddress, userAgent string) {
+	if err := s.activity.LogOrganizationActivity(ctx, invitation.OrganizationID, userID, action, "invitation", invitation.ID, ipAddress, userAgent, map[string]interface{}{
+		"email": invitation.Email,
+		"role":  invitation.Role,
+	}); err != nil {
+		fmt.Printf("Failed to log %s for invitation %s: %v\n", action, invitation.ID, err)
+	}
+}
+
+func (s *InvitationService) sendInvitationEmail(ctx context.Context, org *orgModel.Organization, invitation *model.Invitation, token string) {
+	if s.emailSender == nil {
+		return
+	}
+
+	inviterName, inviterEmail := s.inviter(ctx, invitation)
+	data := emailTemplates.OrganizationInvitationData{
+		Name:       invitation.Email,
+		Email:      invitation.Email,
+		OrgName:    org.Name,
+		Role:       invitation.Role,
+		AcceptURL:  fmt.Sprintf("%s?token=%s", s.config.URL, token),
+		DeclineURL: fmt.Sprintf("%s?token=%s&action=decline", s.config.URL, token),
+		InvitedBy:  inviterName,
+	}
+	template := emailTemplates.GetTemplate(emailTemplates.TemplateOrgInvitation)
+	emailReq := email.SendEmailRequest{
+		To:         invitation.Email,
+		Subject:    template["subject"].(string),
+		TemplateID: template["template_id"].(string),
+		TemplateData: map[string]interface{}{
+			"Name":         data.Name,
+			"email":        data.Email,
+			"Organization": data.OrgName,
+			"Role":         data.Role,
+			"InviterName":  data.InvitedBy,
+			"InviterEmail": inviterEmail,
+			"AcceptURL":    data.AcceptURL,
+			"DeclineURL":   data.DeclineURL,
+			"ExpiryDate":   invitation.ExpiresAt.UTC().Format(time.RFC1123),
+		},
+	}
+
+	// Send email asynchronously
+	go func() {
+		if err := s.emailSender.SendEmail(context.Background(), emailReq); err != nil {
+			fmt.Printf("Failed to send invitation email: %v\n", err)
+		}
+	}()
+}
+
+// hashToken returns the hex SHA-256 of an invitation token, which is what's stored
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// generateRandomToken generates an invitation token
+func generateRandomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	authModel "ethos/internal/auth/model"
+	authService "ethos/internal/auth/service"
+	"ethos/internal/invitation/model"
+	orgModel "ethos/internal/organization/model"
+	permissionModel "ethos/internal/permission/model"
+	"ethos/pkg/email"
+	"ethos/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRepository keeps invitations in memory
+type fakeRepository struct {
+	invitations map[string]*model.Invitation
+	accepted    map[string]string // invitation ID to user ID
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{invitations: map[string]*model.Invitation{}, accepted: map[string]string{}}
+}
+
+func (r *fakeRepository) CreateInvitation(ctx context.Context, invitation *model.Invitation) error {
+	invitation.ID = "inv-" + invitation.TokenHash[:8]
+	invitation.Status = model.StatusPending
+	invitation.SendCount = 1
+	r.invitations[invitation.ID] = invitation
+	return nil
+}
+
+func (r *fakeRepository) ListInvitations(ctx context.Context, organizationID string) ([]*model.Invitation, error) {
+	var invitations []*model.Invitation
+	for _, invitation := range r.invitations {
+		invitations = append(invitations, invitation)
+	}
+	return invitations, nil
+}
+
+func (r *fakeRepository) GetInvitation(ctx context.Context, organizationID, id string) (*model.Invitation, error) {
+	invitation, ok := r.invitations[id]
+	if !ok || invitation.OrganizationID != organizationID {
+		return nil, errors.ErrNotFound
+	}
+	return invitation, nil
+}
+
+func (r *fakeRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*model.Invitation, error) {
+	for _, invitation := range r.invitations {
+		if invitation.TokenHash == tokenHash {
+			return invitation, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (r *fakeRepository) RenewInvitation(ctx context.Context, organizationID, id, tokenHash string, expiresAt time.Time) (*model.Invitation, error) {
+	invitation, err := r.GetInvitation(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	invitation.TokenHash = tokenHash
+	invitation.ExpiresAt = expiresAt
+	invitation.SendCount++
+	return invitation, nil
+}
+
+func (r *fakeRepository) RevokeInvitation(ctx context.Context, organizationID, id string) error {
+	r.invitations[id].Status = model.StatusRevoked
+	return nil
+}
+
+func (r *fakeRepository) DeclineInvitation(ctx context.Context, id string) error {
+	r.invitations[id].Status = model.StatusDeclined
+	return nil
+}
+
+func (r *fakeRepository) AcceptInvitation(ctx context.Context, id, userID string) (string, error) {
+	invitation := r.invitations[id]
+	invitation.Status = model.StatusAccepted
+	r.accepted[id] = userID
+	return invitation.Role, nil
+}
+
+type fakeOrganizations struct{}
+
+func (fakeOrganizations) GetOrganization(ctx context.Context, orgID string) (*orgModel.Organization, error) {
+	return &orgModel.Organization{ID: orgID, Name: "Acme"}, nil
+}
+
+type fakeUsers map[string]*authModel.User
+
+func (u fakeUsers) GetUserByID(ctx context.Context, userID string) (*authModel.User, error) {
+	if user, ok := u[userID]; ok {
+		return user, nil
+	}
+	return nil, errors.ErrUserNotFound
+}
+
+func (u fakeUsers) GetUserByEmail(ctx context.Context, address string) (*authModel.User, error) {
+	for _, user := range u {
+		if strings.EqualFold(user.Email, address) {
+			return user, nil
+		}
+	}
+	return nil, errors.ErrUserNotFound
+}
+
+// fakeRegistrar records the accounts it's asked to create
+type fakeRegistrar struct {
+	requests []*authService.RegisterRequest
+}
+
+func (r *fakeRegistrar) Register(ctx context.Context, req *authService.RegisterRequest) (*authModel.UserProfile, error) {
+	r.requests = append(r.requests, req)
+	return &authModel.UserProfile{ID: "user-new", Email: req.Email, EmailVerified: req.EmailVerified}, nil
+}
+
+// fakeRoles allows any role but "owner"
+type fakeRoles struct{}
+
+func (fakeRoles) CheckAssignable(ctx context.Context, actor permissionModel.Subject, roleName string) (string, error) {
+	if roleName == "owner" {
+		return "", errors.NewPermissionDeniedError("You can't grant permissions you don't have")
+	}
+	return strings.ToLower(roleName), nil
+}
+
+type fakeActivity struct {
+	actions []string
+}
+
+func (a *fakeActivity) LogOrganizationActivity(ctx context.Context, organizationID, userID, action, resourceType, resourceID, ipAddress, userAgent string, changes map[string]interface{}) error {
+	a.actions = append(a.actions, action)
+	return nil
+}
+
+// channelEmailSender hands sent emails to the test
+type channelEmailSender chan email.SendEmailRequest
+
+func (c channelEmailSender) SendEmail(ctx context.Context, req email.SendEmailRequest) error {
+	c <- req
+	return nil
+}
+
+type testService struct {
+	*InvitationService
+	repo      *fakeRepository
+	registrar *fakeRegistrar
+	activity  *fakeActivity
+	sent      channelEmailSender
+}
+
+func newTestService(users fakeUsers) *testService {
+	ts := &testService{
+		repo:      newFakeRepository(),
+		registrar: &fakeRegistrar{},
+		activity:  &fakeActivity{},
+		sent:      make(channelEmailSender, 1),
+	}
+	ts.InvitationService = NewInvitationService(ts.repo, fakeOrganizations{}, users, ts.registrar, fakeRoles{}, ts.activity, ts.sent, Config{
+		TTL: 24 * time.Hour,
+		URL: "https://app.example.com/invitations",
+	}).(*InvitationService)
+	return ts
+}
+
+var admin = permissionModel.Subject{UserID: "user-admin", OrganizationID: "org-1", OrganizationRole: "admin"}
+
+// invite creates an invitation and returns it with the token from its email
+func invite(t *testing.T, ts *testService, address string) (*model.Invitation, string) {
+	t.Helper()
+	invitation, err := ts.CreateInvitation(context.Background(), admin, &model.CreateInvitationRequest{Email: address, Role: "Moderator"})
+	require.NoError(t, err)
+
+	sent := <-ts.sent
+	acceptURL := sent.TemplateData["AcceptURL"].(string)
+	token := strings.TrimPrefix(acceptURL, "https://app.example.com/invitations?token=")
+	require.NotEqual(t, acceptURL, token)
+	return invitation, token
+}
+
+func TestCreateInvitation(t *testing.T) {
+	ts := newTestService(fakeUsers{"user-admin": {ID: "user-admin", Email: "admin@acme.com", FirstName: "Ada", LastName: "Admin"}})
+
+	invitation, token := invite(t, ts, " Jane@Example.com ")
+	assert.Equal(t, "jane@example.com", invitation.Email)
+	assert.Equal(t, "moderator", invitation.Role)
+	assert.Equal(t, "user-admin", invitation.InvitedBy)
+	assert.NotEqual(t, token, invitation.TokenHash, "token must not be stored in plain text")
+	assert.Equal(t, []string{model.ActionInvitationCreated}, ts.activity.actions)
+
+	details, err := ts.GetInvitationByToken(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", details.OrganizationName)
+	assert.Equal(t, "Ada Admin", details.InvitedBy)
+	assert.False(t, details.AccountExists)
+}
+
+func TestCreateInvitation_RoleMustBeAssignable(t *testing.T) {
+	ts := newTestService(fakeUsers{})
+
+	_, err := ts.CreateInvitation(context.Background(), admin, &model.CreateInvitationRequest{Email: "jane@example.com", Role: "owner"})
+	apiErr, ok := err.(*errors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "PERMISSION_DENIED", apiErr.Code)
+	assert.Empty(t, ts.repo.invitations)
+}
+
+func TestResendInvitation_ReplacesToken(t *testing.T) {
+	ts := newTestService(fakeUsers{})
+	invitation, oldToken := invite(t, ts, "jane@example.com")
+
+	resent, err := ts.ResendInvitation(context.Background(), admin, invitation.ID, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, resent.SendCount)
+	<-ts.sent
+
+	_, err = ts.GetInvitationByToken(context.Background(), oldToken)
+	assert.Equal(t, errors.ErrInvitationInvalid, err)
+}
+
+func TestRevokeInvitation_RoleMustBeAssignable(t *testing.T) {
+	ts := newTestService(fakeUsers{})
+	ts.repo.invitations["inv-owner"] = &model.Invitation{ID: "inv-owner", OrganizationID: "org-1", Email: "jane@example.com", Role: "owner", Status: model.StatusPending}
+
+	err := ts.RevokeInvitation(context.Background(), admin, "inv-owner", "", "")
+	apiErr, ok := err.(*errors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "PERMISSION_DENIED", apiErr.Code)
+	assert.Equal(t, model.StatusPending, ts.repo.invitations["inv-owner"].Status)
+	assert.Empty(t, ts.activity.actions)
+}
+
+func TestAcceptInvitation_RequiresInvitedAddress(t *testing.T) {
+	ts := newTestService(fakeUsers{
+		"user-jane": {ID: "user-jane", Email: "JANE@example.com"},
+		"user-eve":  {ID: "user-eve", Email: "eve@example.com"},
+	})
+	invitation, token := invite(t, ts, "jane@example.com")
+
+	_, err := ts.AcceptInvitation(context.Background(), &model.AcceptInvitationRequest{Token: token, UserID: "user-eve"})
+	apiErr, ok := err.(*errors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "PERMISSION_DENIED", apiErr.Code)
+
+	membership, err := ts.AcceptInvitation(context.Background(), &model.AcceptInvitationRequest{Token: token, UserID: "user-jane"})
+	require.NoError(t, err)
+	assert.Equal(t, &model.AcceptInvitationResponse{OrganizationID: "org-1", UserID: "user-jane", Role: "moderator"}, membership)
+	assert.Equal(t, "user-jane", ts.repo.accepted[invitation.ID])
+
+	_, err = ts.AcceptInvitation(context.Background(), &model.AcceptInvitationRequest{Token: token, UserID: "user-jane"})
+	assert.Equal(t, errors.ErrInvitationInvalid, err, "an invitation can only be used once")
+}
+
+func TestRegisterAndAccept(t *testing.T) {
+	ts := newTestService(fakeUsers{})
+	invitation, token := invite(t, ts, "jane@example.com")
+
+	membership, err := ts.RegisterAndAccept(context.Background(), &model.RegisterInvitationRequest{
+		Token:       token,
+		Password:    "Password123!",
+		FirstName:   "Jane",
+		LastName:    "Doe",
+		AcceptTerms: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "user-new", membership.UserID)
+	assert.Equal(t, "user-new", ts.repo.accepted[invitation.ID])
+
+	require.Len(t, ts.registrar.requests, 1)
+	assert.Equal(t, "jane@example.com", ts.registrar.requests[0].Email)
+	assert.True(t, ts.registrar.requests[0].EmailVerified, "the emailed token proves the address")
+}
+
+func TestRegisterAndAccept_ExistingAccountMustSignIn(t *testing.T) {
+	ts := newTestService(fakeUsers{"user-jane": {ID: "user-jane", Email: "jane@example.com"}})
+	_, token := invite(t, ts, "jane@example.com")
+
+	_, err := ts.RegisterAndAccept(context.Background(), &model.RegisterInvitationRequest{Token: token, Password: "Password123!", FirstName: "Jane", LastName: "Doe", AcceptTerms: true})
+	apiErr, ok := err.(*errors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+	assert.Empty(t, ts.registrar.requests)
+}
+
+func TestExpiredInvitation(t *testing.T) {
+	ts := newTestService(fakeUsers{})
+	invitation, token := invite(t, ts, "jane@example.com")
+	invitation.ExpiresAt = time.Now().Add(-time.Minute)
+
+	_, err := ts.GetInvitationByToken(context.Background(), token)
+	assert.Equal(t, errors.ErrInvitationInvalid, err)
+	assert.Equal(t, errors.ErrInvitationInvalid, ts.DeclineInvitation(context.Background(), &model.DeclineInvitationRequest{Token: token}))
+
+	invitations, err := ts.ListInvitations(context.Background(), "org-1")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusExpired, invitations[0].Status)
+}
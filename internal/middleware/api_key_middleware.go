@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+
+	apikeyModel "ethos/internal/apikey/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireScope allows requests authenticated with an API key only if the key
+// has the scope. Organization keys are further limited to routes of their own
+// organization. Requests with a user's access token pass unchecked.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scopes, isAPIKey := c.Get("api_key_scopes")
+		if !isAPIKey {
+			c.Next()
+			return
+		}
+
+		keyOrganizationID := c.GetString("api_key_organization_id")
+		if orgID := c.Param("org_id"); orgID != "" && keyOrganizationID != "" && orgID != keyOrganizationID {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "API key belongs to another organization",
+				"code":  "ACCESS_DENIED",
+			})
+			c.Abort()
+			return
+		}
+
+		if granted, _ := scopes.([]string); !apikeyModel.HasScope(granted, scope) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "API key is missing the " + scope + " scope",
+				"code":  "INSUFFICIENT_SCOPE",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// DenyAPIKeys rejects requests authenticated with an API key, for routes
+// such as key management that need a user's own login
+func DenyAPIKeys() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, isAPIKey := c.Get("api_key_id"); isAPIKey {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "This action cannot be performed with an API key",
+				"code":  "API_KEY_NOT_ALLOWED",
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apikeyModel "ethos/internal/apikey/model"
+	"ethos/pkg/errors"
+	"ethos/pkg/jwt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubAPIKeyAuthenticator resolves tokens from a fixed map
+type stubAPIKeyAuthenticator struct {
+	principals map[string]*apikeyModel.Principal
+}
+
+func (s *stubAPIKeyAuthenticator) Authenticate(ctx context.Context, token string) (*apikeyModel.Principal, error) {
+	principal, ok := s.principals[token]
+	if !ok {
+		return nil, errors.ErrTokenInvalid
+	}
+	return principal, nil
+}
+
+func setupAPIKeyRouter() (*gin.Engine, *jwt.TokenGenerator) {
+	gin.SetMode(gin.TestMode)
+	tokenGen := jwt.NewTokenGenerator("test-access-secret", "test-refresh-secret", 15*time.Minute, 14*24*time.Hour)
+	apiKeys := &stubAPIKeyAuthenticator{principals: map[string]*apikeyModel.Principal{
+		"ethos_pat_reader": {KeyID: "key-1", UserID: "user-123", Scopes: []string{apikeyModel.ScopeFeedbackRead}},
+		"ethos_pat_writer": {KeyID: "key-2", UserID: "user-123", Scopes: []string{apikeyModel.ScopeFeedbackWrite}},
+		"ethos_pat_org":    {KeyID: "key-3", UserID: "user-123", OrganizationID: "org-1", Scopes: []string{apikeyModel.ScopeOrgAdmin}},
+	}}
+
+	router := gin.New()
+	router.Use(AuthMiddlewareWithAPIKeys(tokenGen, nil, apiKeys))
+	ok := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"user_id": c.GetString("user_id")})
+	}
+	router.GET("/feedback", RequireScope(apikeyModel.ScopeFeedbackRead), ok)
+	router.POST("/feedback", RequireScope(apikeyModel.ScopeFeedbackWrite), ok)
+	router.GET("/organizations/:org_id", RequireScope(apikeyModel.ScopeOrgAdmin), ok)
+	router.GET("/account/api-keys", DenyAPIKeys(), ok)
+	return router, tokenGen
+}
+
+func serveWithToken(router *gin.Engine, method, path, token string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAPIKeyAuth_ScopeChecks(t *testing.T) {
+	router, _ := setupAPIKeyRouter()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		status int
+		code   string
+	}{
+		{"read scope allows read", "GET", "/feedback", "ethos_pat_reader", http.StatusOK, ""},
+		{"read scope denies write", "POST", "/feedback", "ethos_pat_reader", http.StatusForbidden, "INSUFFICIENT_SCOPE"},
+		{"write scope implies read", "GET", "/feedback", "ethos_pat_writer", http.StatusOK, ""},
+		{"write scope allows write", "POST", "/feedback", "ethos_pat_writer", http.StatusOK, ""},
+		{"org key on its organization", "GET", "/organizations/org-1", "ethos_pat_org", http.StatusOK, ""},
+		{"org key on another organization", "GET", "/organizations/org-2", "ethos_pat_org", http.StatusForbidden, "ACCESS_DENIED"},
+		{"org key without feedback scope", "GET", "/feedback", "ethos_pat_org", http.StatusForbidden, "INSUFFICIENT_SCOPE"},
+		{"unknown key", "GET", "/feedback", "ethos_pat_unknown", http.StatusUnauthorized, "AUTH_TOKEN_INVALID"},
+		{"key management denied", "GET", "/account/api-keys", "ethos_pat_writer", http.StatusForbidden, "API_KEY_NOT_ALLOWED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveWithToken(router, tt.method, tt.path, tt.token)
+			assert.Equal(t, tt.status, w.Code)
+
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			if tt.code != "" {
+				assert.Equal(t, tt.code, response["code"])
+			} else {
+				assert.Equal(t, "user-123", response["user_id"])
+			}
+		})
+	}
+}
+
+func TestAPIKeyAuth_AccessTokenSkipsScopes(t *testing.T) {
+	router, tokenGen := setupAPIKeyRouter()
+
+	token, err := tokenGen.GenerateAccessToken("user-456")
+	require.NoError(t, err)
+
+	for _, path := range []string{"/feedback", "/organizations/org-2", "/account/api-keys"} {
+		w := serveWithToken(router, "GET", path, token)
+		assert.Equal(t, http.StatusOK, w.Code, path)
+	}
+}
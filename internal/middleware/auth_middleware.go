@@ -1,15 +1,40 @@
 package middleware
 
 import (
+	"context"
+	"log"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	apikeyModel "ethos/internal/apikey/model"
+	"ethos/internal/revocation"
+	"ethos/pkg/errors"
 	"ethos/pkg/jwt"
 )
 
-// AuthMiddleware validates JWT access tokens and injects user ID into context
+// APIKeyAuthenticator resolves personal access tokens (implemented by the API key service)
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (*apikeyModel.Principal, error)
+}
+
+// AuthMiddleware validates JWT access tokens and injects user ID, session and organization claims into context
 func AuthMiddleware(tokenGen *jwt.TokenGenerator) gin.HandlerFunc {
+	return AuthMiddlewareWithRevocation(tokenGen, nil)
+}
+
+// AuthMiddlewareWithRevocation is AuthMiddleware that also rejects tokens whose
+// session, jti or user has been revoked. If the revocation list can't be
+// reached the token is accepted, so a Redis outage doesn't log everyone out.
+func AuthMiddlewareWithRevocation(tokenGen *jwt.TokenGenerator, revocations revocation.List) gin.HandlerFunc {
+	return AuthMiddlewareWithAPIKeys(tokenGen, revocations, nil)
+}
+
+// AuthMiddlewareWithAPIKeys is AuthMiddlewareWithRevocation that also accepts
+// personal access tokens ("Bearer ethos_pat_...") alongside JWTs. Key
+// requests carry the key's scopes, so every route behind it must check them
+// with RequireScope.
+func AuthMiddlewareWithAPIKeys(tokenGen *jwt.TokenGenerator, revocations revocation.List, apiKeys APIKeyAuthenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -34,8 +59,13 @@ func AuthMiddleware(tokenGen *jwt.TokenGenerator) gin.HandlerFunc {
 
 		token := parts[1]
 
+		if apiKeys != nil && strings.HasPrefix(token, apikeyModel.TokenPrefix) {
+			authenticateAPIKey(c, apiKeys, token)
+			return
+		}
+
 		// Validate token
-		userID, err := tokenGen.ValidateAccessToken(token)
+		claims, err := tokenGen.ParseAccessToken(token)
 		if err != nil {
 			if err.Error() == "token expired" {
 				c.JSON(http.StatusUnauthorized, gin.H{
@@ -52,9 +82,68 @@ func AuthMiddleware(tokenGen *jwt.TokenGenerator) gin.HandlerFunc {
 			return
 		}
 
-		// Inject user ID into context
-		c.Set("user_id", userID)
+		if revocations != nil {
+			revoked, err := revocations.IsRevoked(c.Request.Context(), claims)
+			if err != nil {
+				log.Printf("Failed to check token revocation: %v", err)
+			} else if revoked {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": "Token has been revoked",
+					"code":  "AUTH_TOKEN_REVOKED",
+				})
+				c.Abort()
+				return
+			}
+		}
+
+		// Inject user ID and token claims into context. Tokens issued before
+		// sessions and organization claims existed only carry the user ID.
+		c.Set("user_id", claims.UserID)
+		if claims.SessionID != "" {
+			c.Set("session_id", claims.SessionID)
+		}
+		if claims.ID != "" {
+			c.Set("token_id", claims.ID)
+		}
+		if claims.OrganizationID != "" {
+			c.Set("token_organization_id", claims.OrganizationID)
+			c.Set("token_organization_role", claims.OrganizationRole)
+		}
+		c.Set("platform_roles", claims.Roles)
+		if claims.ImpersonatorID != "" {
+			c.Set("impersonator_id", claims.ImpersonatorID)
+		}
 		c.Next()
 	}
 }
 
+// authenticateAPIKey injects the key's user, scopes and, for organization
+// keys, organization into context. Keys never carry platform roles.
+func authenticateAPIKey(c *gin.Context, apiKeys APIKeyAuthenticator, token string) {
+	principal, err := apiKeys.Authenticate(c.Request.Context(), token)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+		} else {
+			log.Printf("Failed to authenticate API key: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal server error",
+				"code":  "SERVER_ERROR",
+			})
+		}
+		c.Abort()
+		return
+	}
+
+	c.Set("user_id", principal.UserID)
+	c.Set("api_key_id", principal.KeyID)
+	c.Set("api_key_scopes", principal.Scopes)
+	if principal.OrganizationID != "" {
+		c.Set("api_key_organization_id", principal.OrganizationID)
+		c.Set("token_organization_id", principal.OrganizationID)
+	}
+	c.Next()
+}
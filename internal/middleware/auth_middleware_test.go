@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -124,3 +125,48 @@ func TestAuthMiddleware_MalformedHeader(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
+
+type stubRevocationList struct {
+	revokedSessions map[string]bool
+}
+
+func (s *stubRevocationList) RevokeSession(ctx context.Context, sessionID string) error {
+	s.revokedSessions[sessionID] = true
+	return nil
+}
+
+func (s *stubRevocationList) RevokeToken(ctx context.Context, tokenID string) error {
+	return nil
+}
+
+func (s *stubRevocationList) RevokeUserTokens(ctx context.Context, userID string, issuedUntil time.Time) error {
+	return nil
+}
+
+func (s *stubRevocationList) IsRevoked(ctx context.Context, claims *jwt.Claims) (bool, error) {
+	return s.revokedSessions[claims.SessionID], nil
+}
+
+func TestAuthMiddleware_RevokedSession(t *testing.T) {
+	tokenGen := jwt.NewTokenGenerator("test-access-secret", "test-refresh-secret", 15*time.Minute, 14*24*time.Hour)
+	revocations := &stubRevocationList{revokedSessions: map[string]bool{}}
+	router := setupTestRouter(AuthMiddlewareWithRevocation(tokenGen, revocations))
+
+	token, err := tokenGen.GenerateAccessTokenWithContext("user-123", jwt.SessionContext{SessionID: "session-1"})
+	assert.NoError(t, err)
+
+	req, _ := http.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	assert.NoError(t, revocations.RevokeSession(context.Background(), "session-1"))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "AUTH_TOKEN_REVOKED", response["code"])
+}
@@ -25,6 +25,55 @@ func ContextSwitchMiddleware(contextService service.UserContextService) gin.Hand
 
 		userIDStr := userID.(string)
 
+		if !requireActiveSession(c, contextService, userIDStr) {
+			return
+		}
+
+		// Session-bound tokens carry the organization and role they were issued
+		// for, so the context doesn't need to be loaded from the database
+		if orgID := c.GetString("token_organization_id"); orgID != "" && c.GetString("session_id") != "" {
+			role := c.GetString("token_organization_role")
+			c.Set("current_organization_id", orgID)
+			c.Set("user_role", role)
+
+			c.Header("X-Current-Organization-ID", orgID)
+			c.Header("X-User-Role", role)
+
+			c.Next()
+			return
+		}
+
+		// Organization API keys act in their own organization, whatever the
+		// user's current context is, for as long as the user is still a member
+		if orgID := c.GetString("api_key_organization_id"); orgID != "" {
+			role, err := contextService.GetUserRoleInOrganization(c.Request.Context(), userIDStr, orgID)
+			if err == errors.ErrNotFound {
+				c.JSON(http.StatusForbidden, gin.H{
+					"error": "User is not a member of this organization",
+					"code":  "NOT_ORGANIZATION_MEMBER",
+				})
+				c.Abort()
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": "Failed to determine user role",
+					"code":  "ROLE_CHECK_FAILED",
+				})
+				c.Abort()
+				return
+			}
+
+			c.Set("current_organization_id", orgID)
+			c.Set("user_role", role)
+
+			c.Header("X-Current-Organization-ID", orgID)
+			c.Header("X-User-Role", role)
+
+			c.Next()
+			return
+		}
+
 		// Get current organization context
 		currentContext, err := contextService.GetCurrentContext(c.Request.Context(), userIDStr)
 		if err != nil {
@@ -78,6 +127,25 @@ func ValidateOrganizationMembership(contextService service.UserContextService) g
 
 		userIDStr := userID.(string)
 
+		if !requireActiveSession(c, contextService, userIDStr) {
+			return
+		}
+
+		// Membership in the token's own organization was checked when it was issued
+		if organizationID == c.GetString("token_organization_id") && c.GetString("session_id") != "" {
+			role := c.GetString("token_organization_role")
+			c.Set("target_organization_id", organizationID)
+			c.Set("user_role_in_org", role)
+
+			c.Header("X-Target-Organization-ID", organizationID)
+			if role != "" {
+				c.Header("X-User-Role-In-Org", role)
+			}
+
+			c.Next()
+			return
+		}
+
 		// Check if user is a member of this organization
 		isMember, err := contextService.ValidateUserInOrganization(c.Request.Context(), userIDStr, organizationID)
 		if err != nil {
@@ -123,6 +191,56 @@ func ValidateOrganizationMembership(contextService service.UserContextService) g
 	}
 }
 
+// requireActiveSession rejects requests whose token is bound to a revoked or
+// expired session. Tokens without a session ID are let through.
+func requireActiveSession(c *gin.Context, contextService service.UserContextService, userID string) bool {
+	sessionID := c.GetString("session_id")
+	if sessionID == "" {
+		return true
+	}
+
+	active, err := contextService.IsSessionActive(c.Request.Context(), userID, sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to validate session",
+			"code":  "SESSION_CHECK_FAILED",
+		})
+		c.Abort()
+		return false
+	}
+
+	if !active {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Session has been revoked",
+			"code":  "AUTH_SESSION_REVOKED",
+		})
+		c.Abort()
+		return false
+	}
+
+	return true
+}
+
+// RequireOrganizationRole allows the request only if the user's role in the
+// organization from the URL, as set by ValidateOrganizationMembership, is one of roles
+func RequireOrganizationRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("user_role_in_org")
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Organization admin privileges required",
+			"code":  "INSUFFICIENT_ORG_PERMISSIONS",
+		})
+		c.Abort()
+	}
+}
+
 // EnforceOrganizationContext ensures requests have proper organization context
 func EnforceOrganizationContext() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -0,0 +1,196 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apikeyModel "ethos/internal/apikey/model"
+	"ethos/internal/organization/repository"
+	"ethos/pkg/errors"
+	"ethos/pkg/jwt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockContextService is a mock UserContextService for testing
+type mockContextService struct {
+	mock.Mock
+}
+
+func (m *mockContextService) GetAvailableContexts(ctx context.Context, userID string) ([]*repository.UserContext, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*repository.UserContext), args.Error(1)
+}
+
+func (m *mockContextService) GetCurrentContext(ctx context.Context, userID string) (*repository.UserContext, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.UserContext), args.Error(1)
+}
+
+func (m *mockContextService) SwitchContext(ctx context.Context, userID, sessionID, organizationID string, ipAddress, userAgent string) (*repository.UserContext, error) {
+	args := m.Called(ctx, userID, sessionID, organizationID, ipAddress, userAgent)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.UserContext), args.Error(1)
+}
+
+func (m *mockContextService) IsSessionActive(ctx context.Context, userID, sessionID string) (bool, error) {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockContextService) CreateUserSession(ctx context.Context, userID, organizationID, tokenHash, refreshTokenHash, ipAddress, userAgent, deviceName string, expiresAt time.Time) (*repository.UserSession, error) {
+	args := m.Called(ctx, userID, organizationID, tokenHash, refreshTokenHash, ipAddress, userAgent, deviceName, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.UserSession), args.Error(1)
+}
+
+func (m *mockContextService) GetUserSession(ctx context.Context, tokenHash string) (*repository.UserSession, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.UserSession), args.Error(1)
+}
+
+func (m *mockContextService) RevokeUserSession(ctx context.Context, sessionID string) error {
+	args := m.Called(ctx, sessionID)
+	return args.Error(0)
+}
+
+func (m *mockContextService) GetContextSwitchHistory(ctx context.Context, userID string, limit, offset int) ([]*repository.ContextSwitchRecord, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	return args.Get(0).([]*repository.ContextSwitchRecord), args.Error(1)
+}
+
+func (m *mockContextService) ValidateUserInOrganization(ctx context.Context, userID, organizationID string) (bool, error) {
+	args := m.Called(ctx, userID, organizationID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockContextService) GetUserRoleInOrganization(ctx context.Context, userID, organizationID string) (string, error) {
+	args := m.Called(ctx, userID, organizationID)
+	return args.String(0), args.Error(1)
+}
+
+func setupContextRouter(tokenGen *jwt.TokenGenerator, contextService *mockContextService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/context", AuthMiddleware(tokenGen), ContextSwitchMiddleware(contextService), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"organization_id": c.GetString("current_organization_id")})
+	})
+	router.GET("/organizations/:org_id", AuthMiddleware(tokenGen), ValidateOrganizationMembership(contextService), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"role": c.GetString("user_role_in_org")})
+	})
+	return router
+}
+
+func performContextRequest(router *gin.Engine, path, token string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestContextSwitchMiddleware_TrustsSessionClaims(t *testing.T) {
+	tokenGen := jwt.NewTokenGenerator("test-access-secret", "test-refresh-secret", time.Minute, time.Hour)
+	token, err := tokenGen.GenerateAccessTokenWithContext("user-1", jwt.SessionContext{
+		SessionID:        "session-1",
+		OrganizationID:   "org-1",
+		OrganizationRole: "admin",
+	})
+	require.NoError(t, err)
+
+	contextService := new(mockContextService)
+	contextService.On("IsSessionActive", mock.Anything, "user-1", "session-1").Return(true, nil)
+	router := setupContextRouter(tokenGen, contextService)
+
+	w := performContextRequest(router, "/context", token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "org-1", w.Header().Get("X-Current-Organization-ID"))
+	assert.Equal(t, "admin", w.Header().Get("X-User-Role"))
+
+	w = performContextRequest(router, "/organizations/org-1", token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "admin", w.Header().Get("X-User-Role-In-Org"))
+
+	contextService.AssertNotCalled(t, "GetCurrentContext", mock.Anything, mock.Anything)
+	contextService.AssertNotCalled(t, "ValidateUserInOrganization", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestContextSwitchMiddleware_RevokedSession(t *testing.T) {
+	tokenGen := jwt.NewTokenGenerator("test-access-secret", "test-refresh-secret", time.Minute, time.Hour)
+	token, err := tokenGen.GenerateAccessTokenWithContext("user-1", jwt.SessionContext{SessionID: "session-1", OrganizationID: "org-1"})
+	require.NoError(t, err)
+
+	contextService := new(mockContextService)
+	contextService.On("IsSessionActive", mock.Anything, "user-1", "session-1").Return(false, nil)
+	router := setupContextRouter(tokenGen, contextService)
+
+	for _, path := range []string{"/context", "/organizations/org-1"} {
+		w := performContextRequest(router, path, token)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "AUTH_SESSION_REVOKED")
+	}
+}
+
+func TestContextSwitchMiddleware_LegacyTokenLoadsContext(t *testing.T) {
+	tokenGen := jwt.NewTokenGenerator("test-access-secret", "test-refresh-secret", time.Minute, time.Hour)
+	token, err := tokenGen.GenerateAccessToken("user-1")
+	require.NoError(t, err)
+
+	contextService := new(mockContextService)
+	contextService.On("GetCurrentContext", mock.Anything, "user-1").
+		Return(&repository.UserContext{UserID: "user-1", OrganizationID: "org-2", OrganizationName: "Acme", Role: "member"}, nil)
+	router := setupContextRouter(tokenGen, contextService)
+
+	w := performContextRequest(router, "/context", token)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "org-2", w.Header().Get("X-Current-Organization-ID"))
+	assert.Equal(t, "Acme", w.Header().Get("X-Current-Organization-Name"))
+	contextService.AssertNotCalled(t, "IsSessionActive", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestContextSwitchMiddleware_OrganizationAPIKeyUsesItsOrganization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokenGen := jwt.NewTokenGenerator("test-access-secret", "test-refresh-secret", time.Minute, time.Hour)
+	apiKeys := &stubAPIKeyAuthenticator{principals: map[string]*apikeyModel.Principal{
+		"ethos_pat_org":    {KeyID: "key-1", UserID: "user-1", OrganizationID: "org-1"},
+		"ethos_pat_former": {KeyID: "key-2", UserID: "user-2", OrganizationID: "org-1"},
+	}}
+
+	contextService := new(mockContextService)
+	contextService.On("GetUserRoleInOrganization", mock.Anything, "user-1", "org-1").Return("member", nil)
+	contextService.On("GetUserRoleInOrganization", mock.Anything, "user-2", "org-1").Return("", errors.ErrNotFound)
+
+	router := gin.New()
+	router.GET("/context", AuthMiddlewareWithAPIKeys(tokenGen, nil, apiKeys), ContextSwitchMiddleware(contextService), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"organization_id": c.GetString("current_organization_id")})
+	})
+
+	w := performContextRequest(router, "/context", "ethos_pat_org")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "org-1", w.Header().Get("X-Current-Organization-ID"))
+	assert.Equal(t, "member", w.Header().Get("X-User-Role"))
+
+	// A key outlives its owner's membership but can no longer act in the organization
+	w = performContextRequest(router, "/context", "ethos_pat_former")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "NOT_ORGANIZATION_MEMBER")
+
+	contextService.AssertNotCalled(t, "GetCurrentContext", mock.Anything, mock.Anything)
+}
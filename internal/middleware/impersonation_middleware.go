@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	impersonationModel "ethos/internal/impersonation/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActivityLogger records actions in an organization's activity log
+// (implemented by the organization context repository)
+type ActivityLogger interface {
+	LogOrganizationActivity(ctx context.Context, organizationID, userID, action, resourceType, resourceID, ipAddress, userAgent string, changes map[string]interface{}) error
+}
+
+// BlockImpersonation rejects requests made with an impersonation token, for
+// actions support staff must never take on a user's behalf such as changing
+// their password or deleting their account
+func BlockImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("impersonator_id") != "" {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "This action isn't available while impersonating a user",
+				"code":  "IMPERSONATION_FORBIDDEN",
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// AuditImpersonation records every request made with an impersonation token
+// in the impersonated organization's activity log. It runs before
+// authentication and checks the context once the request is done, so one
+// instance covers every route regardless of which auth middleware it uses.
+func AuditImpersonation(logger ActivityLogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		impersonatorID := c.GetString("impersonator_id")
+		if impersonatorID == "" {
+			return
+		}
+
+		path := c.FullPath()
+		if path == "" {
+			path = c.Request.URL.Path
+		}
+		err := logger.LogOrganizationActivity(c.Request.Context(),
+			c.GetString("token_organization_id"),
+			impersonatorID,
+			impersonationModel.ActionImpersonatedRequest,
+			"user",
+			c.GetString("user_id"),
+			c.ClientIP(),
+			c.Request.UserAgent(),
+			map[string]interface{}{
+				"method":   c.Request.Method,
+				"path":     path,
+				"url":      c.Request.URL.RequestURI(),
+				"status":   c.Writer.Status(),
+				"token_id": c.GetString("token_id"),
+			},
+		)
+		if err != nil {
+			log.Printf("Failed to audit impersonated request: %v", err)
+		}
+	}
+}
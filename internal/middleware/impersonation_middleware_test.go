@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"ethos/pkg/jwt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingActivityLogger keeps the activity log entries it's given
+type recordingActivityLogger struct {
+	entries []map[string]interface{}
+}
+
+func (r *recordingActivityLogger) LogOrganizationActivity(ctx context.Context, organizationID, userID, action, resourceType, resourceID, ipAddress, userAgent string, changes map[string]interface{}) error {
+	entry := map[string]interface{}{"organization_id": organizationID, "user_id": userID, "action": action, "resource_id": resourceID}
+	for key, value := range changes {
+		entry[key] = value
+	}
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func TestImpersonation_BlocksAndAudits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokenGen := jwt.NewTokenGenerator("access-secret", "refresh-secret", 15*time.Minute, time.Hour)
+	audit := &recordingActivityLogger{}
+
+	router := gin.New()
+	router.Use(AuditImpersonation(audit))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/dashboard", AuthMiddleware(tokenGen), ok)
+	router.POST("/change-password", AuthMiddleware(tokenGen), BlockImpersonation(), ok)
+
+	impersonating, err := tokenGen.GenerateImpersonationToken("user-1", "admin-1", jwt.SessionContext{OrganizationID: "org-1"}, time.Minute)
+	require.NoError(t, err)
+	own, err := tokenGen.GenerateAccessToken("user-1")
+	require.NoError(t, err)
+
+	serve := func(method, path, token string) int {
+		req, _ := http.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve("GET", "/dashboard", impersonating))
+	assert.Equal(t, http.StatusForbidden, serve("POST", "/change-password", impersonating))
+	assert.Equal(t, http.StatusOK, serve("POST", "/change-password", own))
+	assert.Equal(t, http.StatusOK, serve("GET", "/dashboard", own))
+
+	// Only the impersonated requests are audited, including the blocked one
+	require.Len(t, audit.entries, 2)
+	assert.Equal(t, "org-1", audit.entries[0]["organization_id"])
+	assert.Equal(t, "admin-1", audit.entries[0]["user_id"])
+	assert.Equal(t, "user-1", audit.entries[0]["resource_id"])
+	assert.Equal(t, "/dashboard", audit.entries[0]["path"])
+	assert.Equal(t, http.StatusOK, audit.entries[0]["status"])
+	assert.Equal(t, "/change-password", audit.entries[1]["path"])
+	assert.Equal(t, http.StatusForbidden, audit.entries[1]["status"])
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	permissionModel "ethos/internal/permission/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Authorizer decides permission checks (implemented by the permission service)
+type Authorizer interface {
+	Authorize(ctx context.Context, subject permissionModel.Subject, permission string) (*permissionModel.Decision, error)
+}
+
+// RequirePermission allows the request only if the user has the permission,
+// through a platform role or their role in the organization from the URL as
+// set by ValidateOrganizationMembership. Denials explain what was missing.
+func RequirePermission(authorizer Authorizer, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString("user_id")
+		if userID == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Authentication required",
+				"code":  "AUTH_REQUIRED",
+			})
+			c.Abort()
+			return
+		}
+
+		subject := permissionModel.Subject{
+			UserID:           userID,
+			OrganizationID:   c.Param("org_id"),
+			OrganizationRole: c.GetString("user_role_in_org"),
+		}
+
+		decision, err := authorizer.Authorize(c.Request.Context(), subject, permission)
+		if err != nil {
+			log.Printf("Permission check for %s failed: %v", permission, err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to check permissions",
+				"code":  "SERVER_ERROR",
+			})
+			c.Abort()
+			return
+		}
+
+		if !decision.Allowed {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":      "Missing permission " + permission,
+				"code":       "PERMISSION_DENIED",
+				"permission": permission,
+				"reason":     decision.Reason,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	permissionModel "ethos/internal/permission/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubAuthorizer allows only the permissions it was given, and records the subject
+type stubAuthorizer struct {
+	allowed map[string]bool
+	subject permissionModel.Subject
+}
+
+func (s *stubAuthorizer) Authorize(ctx context.Context, subject permissionModel.Subject, permission string) (*permissionModel.Decision, error) {
+	s.subject = subject
+	if s.allowed[permission] {
+		return &permissionModel.Decision{Allowed: true, Permission: permission, Reason: "allowed"}, nil
+	}
+	return &permissionModel.Decision{Allowed: false, Permission: permission, Reason: `your role "viewer" does not grant ` + permission}, nil
+}
+
+func TestRequirePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authorizer := &stubAuthorizer{allowed: map[string]bool{permissionModel.PermissionOrgSettingsWrite: true}}
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", "user-123")
+		c.Set("user_role_in_org", "viewer")
+		c.Next()
+	})
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.PUT("/organizations/:org_id/settings", RequirePermission(authorizer, permissionModel.PermissionOrgSettingsWrite), ok)
+	router.POST("/organizations/:org_id/roles", RequirePermission(authorizer, permissionModel.PermissionOrgRolesWrite), ok)
+
+	req, _ := http.NewRequest("PUT", "/organizations/org-1/settings", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, permissionModel.Subject{UserID: "user-123", OrganizationID: "org-1", OrganizationRole: "viewer"}, authorizer.subject)
+
+	req, _ = http.NewRequest("POST", "/organizations/org-1/roles", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "PERMISSION_DENIED", response["code"])
+	assert.Equal(t, permissionModel.PermissionOrgRolesWrite, response["permission"])
+	assert.Equal(t, `your role "viewer" does not grant org.roles.write`, response["reason"])
+}
+
+func TestRequirePermission_Unauthenticated(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/organizations/:org_id/moderation/actions",
+		RequirePermission(&stubAuthorizer{}, permissionModel.PermissionModerationActionsRead),
+		func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req, _ := http.NewRequest("GET", "/organizations/org-1/moderation/actions", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
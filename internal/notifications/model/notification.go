@@ -11,6 +11,7 @@ const (
 	NotificationTypeNewComment       NotificationType = "new_comment"
 	NotificationTypeSystemAlert      NotificationType = "system_alert"
 	NotificationTypeReminder        NotificationType = "reminder"
+	NotificationTypeRoleExpiring     NotificationType = "role_expiring"
 	NotificationTypeOther            NotificationType = "other"
 )
 
@@ -22,5 +22,7 @@ type Repository interface {
 
 	// UpdatePreferences updates notification preferences
 	UpdatePreferences(ctx context.Context, userID string, email, push, inApp *bool) (*model.NotificationPreferences, error)
-}
 
+	// CreateNotification stores a new unread notification for a user
+	CreateNotification(ctx context.Context, userID string, notificationType model.NotificationType, message string) error
+}
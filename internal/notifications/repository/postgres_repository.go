@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
@@ -234,3 +235,19 @@ func (r *PostgresRepository) MarkAllAsRead(ctx context.Context, userID string) e
 	return nil
 }
 
+// CreateNotification stores a new unread notification for a user
+func (r *PostgresRepository) CreateNotification(ctx context.Context, userID string, notificationType model.NotificationType, message string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.CreateNotification")
+	defer span.End()
+
+	query := `INSERT INTO notifications (notification_id, user_id, type, message) VALUES ($1, $2, $3, $4)`
+	_, err := r.db.Pool.Exec(ctx, query, uuid.New().String(), userID, string(notificationType), message)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to create notification")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
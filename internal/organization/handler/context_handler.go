@@ -1,24 +1,33 @@
 package handler
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 
 	"ethos/internal/organization/repository"
 	"ethos/internal/organization/service"
+	"ethos/pkg/errors"
 
 	"github.com/gin-gonic/gin"
 )
 
+// TokenIssuer issues access tokens for a session's new organization context (implemented by the auth service)
+type TokenIssuer interface {
+	IssueAccessToken(ctx context.Context, userID, sessionID, organizationID string) (string, error)
+}
+
 // ContextSwitchHandler handles multi-tenant context switching endpoints
 type ContextSwitchHandler struct {
 	contextService service.UserContextService
+	tokenIssuer    TokenIssuer
 }
 
 // NewContextSwitchHandler creates a new context switch handler
-func NewContextSwitchHandler(contextService service.UserContextService) *ContextSwitchHandler {
+func NewContextSwitchHandler(contextService service.UserContextService, tokenIssuer TokenIssuer) *ContextSwitchHandler {
 	return &ContextSwitchHandler{
 		contextService: contextService,
+		tokenIssuer:    tokenIssuer,
 	}
 }
 
@@ -107,16 +116,29 @@ func (h *ContextSwitchHandler) SwitchContext(c *gin.Context) {
 	ipAddress := c.ClientIP()
 	userAgent := c.GetHeader("User-Agent")
 
-	// Switch context
-	newContext, err := h.contextService.SwitchContext(c.Request.Context(), userID, req.OrganizationID, ipAddress, userAgent)
+	// Switch context, moving the current session to the new organization
+	sessionID := c.GetString("session_id")
+	newContext, err := h.contextService.SwitchContext(c.Request.Context(), userID, sessionID, req.OrganizationID, ipAddress, userAgent)
 	if err != nil {
+		if err == errors.ErrTokenInvalid {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "session has been revoked"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to switch context"})
 		return
 	}
 
+	// The old access token still names the previous organization
+	accessToken, err := h.tokenIssuer.IssueAccessToken(c.Request.Context(), userID, sessionID, req.OrganizationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to issue access token"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"context": newContext,
-		"message": "context switched successfully",
+		"context":      newContext,
+		"access_token": accessToken,
+		"message":      "context switched successfully",
 	})
 }
 
@@ -250,45 +250,6 @@ func (h *OrganizationHandler) ListOrganizationMembers(c *gin.Context) {
 	})
 }
 
-// AddOrganizationMember handles POST /api/v1/organizations/:org_id/members
-func (h *OrganizationHandler) AddOrganizationMember(c *gin.Context) {
-	orgID := c.Param("org_id")
-	if orgID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Organization ID is required",
-			"code":  "VALIDATION_FAILED",
-		})
-		return
-	}
-
-	var req model.AddMemberRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Validation failed",
-			"code":  "VALIDATION_FAILED",
-		})
-		return
-	}
-
-	member, err := h.service.AddOrganizationMember(c.Request.Context(), orgID, &req)
-	if err != nil {
-		if apiErr, ok := err.(*errors.APIError); ok {
-			c.JSON(apiErr.HTTPStatus, gin.H{
-				"error": apiErr.Message,
-				"code":  apiErr.Code,
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to add member",
-			"code":  "SERVER_ERROR",
-		})
-		return
-	}
-
-	c.JSON(http.StatusCreated, member)
-}
-
 // UpdateOrganizationMemberRole handles PUT /api/v1/organizations/:org_id/members/:user_id
 func (h *OrganizationHandler) UpdateOrganizationMemberRole(c *gin.Context) {
 	orgID := c.Param("org_id")
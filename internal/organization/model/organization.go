@@ -70,12 +70,6 @@ type OrganizationMemberResponse struct {
 	LastActiveAt *time.Time `json:"last_active_at,omitempty"`
 }
 
-// AddMemberRequest represents a request to add a member to an organization
-type AddMemberRequest struct {
-	Email string `json:"email" binding:"required,email"`
-	Role  string `json:"role" binding:"required,oneof=admin moderator user"`
-}
-
 // UpdateMemberRequest represents a request to update a member's role
 type UpdateMemberRequest struct {
 	Role string `json:"role" binding:"required,oneof=admin moderator user"`
@@ -63,12 +63,21 @@ type ContextRepository interface {
 	// GetUserSessionByID retrieves a user session by ID
 	GetUserSessionByID(ctx context.Context, sessionID string) (*UserSession, error)
 
+	// ExtendUserSession pushes back the expiry of an active session, returning ErrNotFound if it was revoked or expired
+	ExtendUserSession(ctx context.Context, sessionID string, expiresAt time.Time) (*UserSession, error)
+
+	// UpdateUserSessionOrganization moves an active session to another organization
+	UpdateUserSessionOrganization(ctx context.Context, sessionID, organizationID string) error
+
 	// RevokeUserSession revokes (marks as deleted) a user session
 	RevokeUserSession(ctx context.Context, sessionID string) error
 
 	// RevokeAllUserSessions revokes all sessions for a user
 	RevokeAllUserSessions(ctx context.Context, userID string) error
 
+	// RevokeSessionsByRefreshTokenFamily revokes every session linked to a token in a refresh token family
+	RevokeSessionsByRefreshTokenFamily(ctx context.Context, familyID string) error
+
 	// CleanupExpiredSessions deletes expired sessions older than the given time
 	CleanupExpiredSessions(ctx context.Context, beforeTime time.Time) (int, error)
 
@@ -78,7 +87,7 @@ type ContextRepository interface {
 	// GetContextSwitchHistory retrieves context switch history for a user
 	GetContextSwitchHistory(ctx context.Context, userID string, limit, offset int) ([]*ContextSwitchRecord, int64, error)
 
-	// GetUserSessionsByOrganization retrieves all active sessions for a user in an organization
+	// GetUserSessionsByOrganization retrieves all active sessions for a user in an organization (an empty ID selects sessions without one)
 	GetUserSessionsByOrganization(ctx context.Context, userID, organizationID string) ([]*UserSession, error)
 
 	// IsUserInOrganization checks if a user is a member of an organization
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"ethos/internal/organization/model"
 )
@@ -38,9 +39,16 @@ type Repository interface {
 	// UpdateOrganizationMember updates a member's role
 	UpdateOrganizationMember(ctx context.Context, member *model.OrganizationMember) error
 
-	// RemoveOrganizationMember removes a user from an organization
+	// RemoveOrganizationMember removes a user from an organization, or returns ErrNotFound
 	RemoveOrganizationMember(ctx context.Context, orgID, userID string) error
 
+	// SuspendOrganizationMember suspends a member until a time, or indefinitely
+	// when until is nil. Returns ErrNotFound for non-members.
+	SuspendOrganizationMember(ctx context.Context, orgID, userID, reason, suspendedBy string, until *time.Time) error
+
+	// UnsuspendOrganizationMember lifts a member's suspension, or returns ErrNotFound
+	UnsuspendOrganizationMember(ctx context.Context, orgID, userID string) error
+
 	// GetOrganizationSettings retrieves organization settings
 	GetOrganizationSettings(ctx context.Context, orgID string) (*model.OrganizationSettings, error)
 
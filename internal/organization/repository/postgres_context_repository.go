@@ -2,7 +2,6 @@ package repository
 
 import (
 	"context"
-	"database/sql"
 	"encoding/json"
 	"time"
 
@@ -10,6 +9,7 @@ import (
 	"ethos/pkg/errors"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
 // PostgresContextRepository implements the ContextRepository interface using PostgreSQL
@@ -37,6 +37,7 @@ func (r *PostgresContextRepository) GetUserOrganizations(ctx context.Context, us
 		JOIN organizations o ON om.organization_id = o.id
 		JOIN users u ON u.id = om.user_id
 		WHERE om.user_id = $1 AND o.deleted_at IS NULL
+			AND NOT (om.suspended_at IS NOT NULL AND (om.suspended_until IS NULL OR om.suspended_until > NOW()))
 		ORDER BY om.joined_at DESC
 	`
 
@@ -88,12 +89,12 @@ func (r *PostgresContextRepository) GetUserCurrentOrganization(ctx context.Conte
 	query := `
 		SELECT 
 			u.id,
-			u.current_organization_id,
-			o.name,
-			om.role,
+			u.current_organization_id::text,
+			COALESCE(o.name, ''),
+			COALESCE(om.role, ''),
 			om.permissions,
-			om.joined_at,
-			COALESCE(u.last_login_at, om.joined_at) as last_switched_at
+			COALESCE(om.joined_at, u.created_at),
+			COALESCE(u.last_login_at, om.joined_at, u.created_at) as last_switched_at
 		FROM users u
 		LEFT JOIN organizations o ON u.current_organization_id = o.id
 		LEFT JOIN organization_members om ON om.user_id = u.id AND om.organization_id = o.id
@@ -114,7 +115,7 @@ func (r *PostgresContextRepository) GetUserCurrentOrganization(ctx context.Conte
 		&userCtx.LastSwitchedAt,
 	)
 
-	if err == sql.ErrNoRows {
+	if err == pgx.ErrNoRows {
 		return nil, errors.ErrUserNotFound
 	}
 	if err != nil {
@@ -165,8 +166,8 @@ func (r *PostgresContextRepository) CreateUserSession(ctx context.Context, userI
 	query := `
 		INSERT INTO user_sessions 
 		(id, user_id, organization_id, token_hash, refresh_token_hash, ip_address, user_agent, device_name, expires_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING id, user_id, organization_id, token_hash, refresh_token_hash, ip_address, user_agent, device_name, last_activity_at, expires_at, revoked_at, created_at
+		VALUES ($1, $2, NULLIF($3, '')::uuid, $4, NULLIF($5, ''), NULLIF($6, '')::inet, NULLIF($7, ''), NULLIF($8, ''), $9)
+		RETURNING id, user_id, COALESCE(organization_id::text, ''), token_hash, COALESCE(refresh_token_hash, ''), COALESCE(host(ip_address), ''), COALESCE(user_agent, ''), COALESCE(device_name, ''), last_activity_at, expires_at, revoked_at, created_at
 	`
 
 	var session UserSession
@@ -197,7 +198,7 @@ func (r *PostgresContextRepository) CreateUserSession(ctx context.Context, userI
 // GetUserSessionByToken retrieves a user session by token hash
 func (r *PostgresContextRepository) GetUserSessionByToken(ctx context.Context, tokenHash string) (*UserSession, error) {
 	query := `
-		SELECT id, user_id, organization_id, token_hash, refresh_token_hash, ip_address, user_agent, device_name, last_activity_at, expires_at, revoked_at, created_at
+		SELECT id, user_id, COALESCE(organization_id::text, ''), token_hash, COALESCE(refresh_token_hash, ''), COALESCE(host(ip_address), ''), COALESCE(user_agent, ''), COALESCE(device_name, ''), last_activity_at, expires_at, revoked_at, created_at
 		FROM user_sessions
 		WHERE token_hash = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
 	`
@@ -218,7 +219,7 @@ func (r *PostgresContextRepository) GetUserSessionByToken(ctx context.Context, t
 		&session.CreatedAt,
 	)
 
-	if err == sql.ErrNoRows {
+	if err == pgx.ErrNoRows {
 		return nil, errors.ErrNotFound
 	}
 	if err != nil {
@@ -231,7 +232,7 @@ func (r *PostgresContextRepository) GetUserSessionByToken(ctx context.Context, t
 // GetUserSessionByID retrieves a user session by ID
 func (r *PostgresContextRepository) GetUserSessionByID(ctx context.Context, sessionID string) (*UserSession, error) {
 	query := `
-		SELECT id, user_id, organization_id, token_hash, refresh_token_hash, ip_address, user_agent, device_name, last_activity_at, expires_at, revoked_at, created_at
+		SELECT id, user_id, COALESCE(organization_id::text, ''), token_hash, COALESCE(refresh_token_hash, ''), COALESCE(host(ip_address), ''), COALESCE(user_agent, ''), COALESCE(device_name, ''), last_activity_at, expires_at, revoked_at, created_at
 		FROM user_sessions
 		WHERE id = $1
 	`
@@ -252,7 +253,42 @@ func (r *PostgresContextRepository) GetUserSessionByID(ctx context.Context, sess
 		&session.CreatedAt,
 	)
 
-	if err == sql.ErrNoRows {
+	if err == pgx.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// ExtendUserSession pushes back the expiry of an active session
+func (r *PostgresContextRepository) ExtendUserSession(ctx context.Context, sessionID string, expiresAt time.Time) (*UserSession, error) {
+	query := `
+		UPDATE user_sessions
+		SET expires_at = $1, last_activity_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		RETURNING id, user_id, COALESCE(organization_id::text, ''), token_hash, COALESCE(refresh_token_hash, ''), COALESCE(host(ip_address), ''), COALESCE(user_agent, ''), COALESCE(device_name, ''), last_activity_at, expires_at, revoked_at, created_at
+	`
+
+	var session UserSession
+	err := r.db.Pool.QueryRow(ctx, query, expiresAt, sessionID).Scan(
+		&session.ID,
+		&session.UserID,
+		&session.OrganizationID,
+		&session.TokenHash,
+		&session.RefreshTokenHash,
+		&session.IPAddress,
+		&session.UserAgent,
+		&session.DeviceName,
+		&session.LastActivityAt,
+		&session.ExpiresAt,
+		&session.RevokedAt,
+		&session.CreatedAt,
+	)
+
+	if err == pgx.ErrNoRows {
 		return nil, errors.ErrNotFound
 	}
 	if err != nil {
@@ -262,6 +298,26 @@ func (r *PostgresContextRepository) GetUserSessionByID(ctx context.Context, sess
 	return &session, nil
 }
 
+// UpdateUserSessionOrganization moves an active session to another organization
+func (r *PostgresContextRepository) UpdateUserSessionOrganization(ctx context.Context, sessionID, organizationID string) error {
+	query := `
+		UPDATE user_sessions
+		SET organization_id = $1, last_activity_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.Pool.Exec(ctx, query, organizationID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
 // RevokeUserSession revokes a user session
 func (r *PostgresContextRepository) RevokeUserSession(ctx context.Context, sessionID string) error {
 	query := `
@@ -294,6 +350,22 @@ func (r *PostgresContextRepository) RevokeAllUserSessions(ctx context.Context, u
 	return err
 }
 
+// RevokeSessionsByRefreshTokenFamily revokes every session whose refresh token belongs to the family
+func (r *PostgresContextRepository) RevokeSessionsByRefreshTokenFamily(ctx context.Context, familyID string) error {
+	query := `
+		UPDATE user_sessions
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE revoked_at IS NULL
+		AND (
+			id IN (SELECT session_id FROM refresh_tokens WHERE family_id = $1)
+			OR refresh_token_hash IN (SELECT token_hash FROM refresh_tokens WHERE family_id = $1)
+		)
+	`
+
+	_, err := r.db.Pool.Exec(ctx, query, familyID)
+	return err
+}
+
 // CleanupExpiredSessions deletes expired sessions
 func (r *PostgresContextRepository) CleanupExpiredSessions(ctx context.Context, beforeTime time.Time) (int, error) {
 	query := `
@@ -390,12 +462,13 @@ func (r *PostgresContextRepository) GetContextSwitchHistory(ctx context.Context,
 	return records, total, nil
 }
 
-// GetUserSessionsByOrganization retrieves sessions for a user in an organization
+// GetUserSessionsByOrganization retrieves sessions for a user in an organization.
+// An empty organization ID matches sessions without an organization.
 func (r *PostgresContextRepository) GetUserSessionsByOrganization(ctx context.Context, userID, organizationID string) ([]*UserSession, error) {
 	query := `
-		SELECT id, user_id, organization_id, token_hash, refresh_token_hash, ip_address, user_agent, device_name, last_activity_at, expires_at, revoked_at, created_at
+		SELECT id, user_id, COALESCE(organization_id::text, ''), token_hash, COALESCE(refresh_token_hash, ''), COALESCE(host(ip_address), ''), COALESCE(user_agent, ''), COALESCE(device_name, ''), last_activity_at, expires_at, revoked_at, created_at
 		FROM user_sessions
-		WHERE user_id = $1 AND organization_id = $2 AND revoked_at IS NULL
+		WHERE user_id = $1 AND organization_id IS NOT DISTINCT FROM NULLIF($2, '')::uuid AND revoked_at IS NULL
 		ORDER BY last_activity_at DESC
 	`
 
@@ -430,12 +503,14 @@ func (r *PostgresContextRepository) GetUserSessionsByOrganization(ctx context.Co
 	return sessions, rows.Err()
 }
 
-// IsUserInOrganization checks if user is a member of an organization
+// IsUserInOrganization checks if user is a member of an organization.
+// Suspended members aren't, until their suspension ends.
 func (r *PostgresContextRepository) IsUserInOrganization(ctx context.Context, userID, organizationID string) (bool, error) {
 	query := `
 		SELECT EXISTS(
 			SELECT 1 FROM organization_members
 			WHERE user_id = $1 AND organization_id = $2
+				AND NOT (suspended_at IS NOT NULL AND (suspended_until IS NULL OR suspended_until > NOW()))
 		)
 	`
 
@@ -444,16 +519,18 @@ func (r *PostgresContextRepository) IsUserInOrganization(ctx context.Context, us
 	return exists, err
 }
 
-// GetUserRoleInOrganization gets the user's role in an organization
+// GetUserRoleInOrganization gets the user's role in an organization, or
+// ErrNotFound while they're suspended from it
 func (r *PostgresContextRepository) GetUserRoleInOrganization(ctx context.Context, userID, organizationID string) (string, error) {
 	query := `
 		SELECT role FROM organization_members
 		WHERE user_id = $1 AND organization_id = $2
+			AND NOT (suspended_at IS NOT NULL AND (suspended_until IS NULL OR suspended_until > NOW()))
 	`
 
 	var role string
 	err := r.db.Pool.QueryRow(ctx, query, userID, organizationID).Scan(&role)
-	if err == sql.ErrNoRows {
+	if err == pgx.ErrNoRows {
 		return "", errors.ErrNotFound
 	}
 	if err != nil {
@@ -463,7 +540,8 @@ func (r *PostgresContextRepository) GetUserRoleInOrganization(ctx context.Contex
 	return role, nil
 }
 
-// LogOrganizationActivity logs an organization activity
+// LogOrganizationActivity logs an organization activity. An empty userID or
+// ipAddress is stored as NULL, for actions taken by background jobs.
 func (r *PostgresContextRepository) LogOrganizationActivity(ctx context.Context, organizationID, userID, action, resourceType, resourceID, ipAddress, userAgent string, changes map[string]interface{}) error {
 	changesJSON, err := json.Marshal(changes)
 	if err != nil {
@@ -473,7 +551,7 @@ func (r *PostgresContextRepository) LogOrganizationActivity(ctx context.Context,
 	query := `
 		INSERT INTO organization_activity_log 
 		(id, organization_id, user_id, action, resource_type, resource_id, changes, ip_address, user_agent)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, NULLIF($8, '')::inet, $9)
 	`
 
 	_, err = r.db.Pool.Exec(ctx, query,
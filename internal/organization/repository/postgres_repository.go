@@ -9,6 +9,7 @@ import (
 	"ethos/pkg/errors"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
 // PostgresRepository implements the Repository interface using PostgreSQL
@@ -23,26 +24,52 @@ func NewPostgresRepository(db *database.DB) Repository {
 
 // GetOrganization retrieves an organization by ID
 func (r *PostgresRepository) GetOrganization(ctx context.Context, orgID string) (*model.Organization, error) {
-	// Placeholder implementation - returns mock data for now
-	// In production, would query the database
-	return &model.Organization{
-		ID:          orgID,
-		Name:        "Sample Organization",
-		Domain:      "sample.com",
-		OwnerID:     "owner-1",
-		Description: "A sample organization",
-		Status:      "active",
-		Plan:        "pro",
-		MaxUsers:    100,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-	}, nil
-}
-
-// GetOrganizationByDomain retrieves an organization by domain
+	query := `
+		SELECT id::text, name, COALESCE(domain, ''), COALESCE(created_by, ''), COALESCE(description, ''),
+		       COALESCE(subscription_status, 'active'), COALESCE(subscription_plan, 'free'), COALESCE(max_users, 0),
+		       created_at, updated_at
+		FROM organizations
+		WHERE id::text = $1 AND deleted_at IS NULL
+	`
+
+	org := &model.Organization{}
+	err := r.db.Pool.QueryRow(ctx, query, orgID).Scan(
+		&org.ID, &org.Name, &org.Domain, &org.OwnerID, &org.Description,
+		&org.Status, &org.Plan, &org.MaxUsers,
+		&org.CreatedAt, &org.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to get organization")
+	}
+	return org, nil
+}
+
+// GetOrganizationByDomain retrieves an organization by domain, ignoring case
 func (r *PostgresRepository) GetOrganizationByDomain(ctx context.Context, domain string) (*model.Organization, error) {
-	// Placeholder implementation
-	return nil, errors.ErrUserNotFound
+	query := `
+		SELECT id::text, name, COALESCE(domain, ''), COALESCE(created_by, ''), COALESCE(description, ''),
+		       COALESCE(subscription_status, 'active'), COALESCE(subscription_plan, 'free'), COALESCE(max_users, 0),
+		       created_at, updated_at
+		FROM organizations
+		WHERE LOWER(domain) = LOWER($1) AND deleted_at IS NULL
+	`
+
+	org := &model.Organization{}
+	err := r.db.Pool.QueryRow(ctx, query, domain).Scan(
+		&org.ID, &org.Name, &org.Domain, &org.OwnerID, &org.Description,
+		&org.Status, &org.Plan, &org.MaxUsers,
+		&org.CreatedAt, &org.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to get organization by domain")
+	}
+	return org, nil
 }
 
 // ListOrganizations retrieves all organizations
@@ -87,21 +114,96 @@ func (r *PostgresRepository) GetOrganizationMember(ctx context.Context, orgID, u
 	}, nil
 }
 
-// AddOrganizationMember adds a user to an organization
+// AddOrganizationMember adds a user to an organization. Adding an existing
+// member is a no-op that keeps their current role; member.ID and JoinedAt
+// are set from the stored row either way.
 func (r *PostgresRepository) AddOrganizationMember(ctx context.Context, member *model.OrganizationMember) error {
-	// Placeholder implementation
+	query := `
+		WITH inserted AS (
+			INSERT INTO organization_members (organization_id, user_id, role, joined_at)
+			VALUES ($1, $2, $3, NOW())
+			ON CONFLICT (organization_id, user_id) DO NOTHING
+			RETURNING id::text, role, joined_at
+		)
+		SELECT id, role, joined_at FROM inserted
+		UNION ALL
+		SELECT id::text, role, joined_at FROM organization_members
+		WHERE organization_id = $1 AND user_id = $2 AND NOT EXISTS (SELECT 1 FROM inserted)
+	`
+
+	err := r.db.Pool.QueryRow(ctx, query, member.OrganizationID, member.UserID, member.Role).Scan(&member.ID, &member.Role, &member.JoinedAt)
+	if err != nil {
+		return errors.WrapError(err, "failed to add organization member")
+	}
 	return nil
 }
 
 // UpdateOrganizationMember updates a member's role
 func (r *PostgresRepository) UpdateOrganizationMember(ctx context.Context, member *model.OrganizationMember) error {
-	// Placeholder implementation
+	query := `
+		UPDATE organization_members
+		SET role = $3, updated_at = NOW()
+		WHERE organization_id::text = $1 AND user_id = $2
+	`
+
+	result, err := r.db.Pool.Exec(ctx, query, member.OrganizationID, member.UserID, member.Role)
+	if err != nil {
+		return errors.WrapError(err, "failed to update organization member")
+	}
+	if result.RowsAffected() == 0 {
+		return errors.ErrNotFound
+	}
 	return nil
 }
 
 // RemoveOrganizationMember removes a user from an organization
 func (r *PostgresRepository) RemoveOrganizationMember(ctx context.Context, orgID, userID string) error {
-	// Placeholder implementation
+	query := `DELETE FROM organization_members WHERE organization_id::text = $1 AND user_id = $2`
+
+	result, err := r.db.Pool.Exec(ctx, query, orgID, userID)
+	if err != nil {
+		return errors.WrapError(err, "failed to remove organization member")
+	}
+	if result.RowsAffected() == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// SuspendOrganizationMember suspends a member until a time, or indefinitely when until is nil
+func (r *PostgresRepository) SuspendOrganizationMember(ctx context.Context, orgID, userID, reason, suspendedBy string, until *time.Time) error {
+	query := `
+		UPDATE organization_members
+		SET suspended_at = NOW(), suspended_until = $3, suspension_reason = NULLIF($4, ''),
+			suspended_by = NULLIF($5, ''), updated_at = NOW()
+		WHERE organization_id::text = $1 AND user_id = $2
+	`
+
+	result, err := r.db.Pool.Exec(ctx, query, orgID, userID, until, reason, suspendedBy)
+	if err != nil {
+		return errors.WrapError(err, "failed to suspend organization member")
+	}
+	if result.RowsAffected() == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// UnsuspendOrganizationMember lifts a member's suspension
+func (r *PostgresRepository) UnsuspendOrganizationMember(ctx context.Context, orgID, userID string) error {
+	query := `
+		UPDATE organization_members
+		SET suspended_at = NULL, suspended_until = NULL, suspension_reason = NULL, suspended_by = NULL, updated_at = NOW()
+		WHERE organization_id::text = $1 AND user_id = $2
+	`
+
+	result, err := r.db.Pool.Exec(ctx, query, orgID, userID)
+	if err != nil {
+		return errors.WrapError(err, "failed to unsuspend organization member")
+	}
+	if result.RowsAffected() == 0 {
+		return errors.ErrNotFound
+	}
 	return nil
 }
 
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"ethos/pkg/errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// LockSeats holds the organization's seat lock until tx ends. Everything
+// that can take a seat (invitations, joins, approvals) takes it first, so
+// concurrent requests can't overfill the organization.
+func LockSeats(ctx context.Context, tx pgx.Tx, organizationID string) error {
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('organization_seats:' || $1))`, organizationID); err != nil {
+		return errors.WrapError(err, "failed to lock organization seats")
+	}
+	return nil
+}
+
+// CheckSeatAvailable returns ErrSeatLimitReached when members and unexpired
+// pending invitations, other than excludeInvitationID, fill the
+// organization's max_users. A max_users of zero means unlimited. Call it
+// after LockSeats.
+func CheckSeatAvailable(ctx context.Context, tx pgx.Tx, organizationID, excludeInvitationID string) error {
+	var maxUsers, used int
+	err := tx.QueryRow(ctx, `
+		SELECT
+			COALESCE(o.max_users, 0),
+			(SELECT COUNT(*) FROM organization_members WHERE organization_id = o.id) +
+			(SELECT COUNT(*) FROM organization_invitations
+				WHERE organization_id = o.id AND status = 'pending' AND expires_at > NOW() AND id::text <> $2)
+		FROM organizations o
+		WHERE o.id::text = $1 AND o.deleted_at IS NULL`,
+		organizationID, excludeInvitationID,
+	).Scan(&maxUsers, &used)
+	if err == pgx.ErrNoRows {
+		return errors.ErrNotFound
+	}
+	if err != nil {
+		return errors.WrapError(err, "failed to count organization seats")
+	}
+	if maxUsers > 0 && used >= maxUsers {
+		return errors.ErrSeatLimitReached
+	}
+	return nil
+}
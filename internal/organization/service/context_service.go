@@ -15,8 +15,13 @@ type UserContextService interface {
 	// GetCurrentContext retrieves the user's current organization context
 	GetCurrentContext(ctx context.Context, userID string) (*repository.UserContext, error)
 
-	// SwitchContext switches the user's current organization context
-	SwitchContext(ctx context.Context, userID, organizationID string, ipAddress, userAgent string) (*repository.UserContext, error)
+	// SwitchContext switches the user's current organization context. The
+	// session the request was authenticated with moves to the new organization;
+	// without one (legacy tokens) a new session is created.
+	SwitchContext(ctx context.Context, userID, sessionID, organizationID string, ipAddress, userAgent string) (*repository.UserContext, error)
+
+	// IsSessionActive reports whether a session belongs to the user and is neither revoked nor expired
+	IsSessionActive(ctx context.Context, userID, sessionID string) (bool, error)
 
 	// CreateUserSession creates a new user session for multi-tenant context
 	CreateUserSession(ctx context.Context, userID, organizationID, tokenHash, refreshTokenHash, ipAddress, userAgent, deviceName string, expiresAt time.Time) (*repository.UserSession, error)
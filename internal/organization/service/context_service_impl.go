@@ -33,7 +33,7 @@ func (s *UserContextServiceImpl) GetCurrentContext(ctx context.Context, userID s
 }
 
 // SwitchContext switches the user's current organization context
-func (s *UserContextServiceImpl) SwitchContext(ctx context.Context, userID, organizationID string, ipAddress, userAgent string) (*repository.UserContext, error) {
+func (s *UserContextServiceImpl) SwitchContext(ctx context.Context, userID, sessionID, organizationID string, ipAddress, userAgent string) (*repository.UserContext, error) {
 	// Verify user is in the organization
 	isMember, err := s.repo.IsUserInOrganization(ctx, userID, organizationID)
 	if err != nil {
@@ -55,18 +55,29 @@ func (s *UserContextServiceImpl) SwitchContext(ctx context.Context, userID, orga
 		return nil, err
 	}
 
-	// Create a new session for this context switch
-	tokenHash := hashToken(generateRandomToken())
-	refreshTokenHash := hashToken(generateRandomToken())
-	expiresAt := time.Now().Add(24 * time.Hour) // 24-hour session
-
-	_, err = s.repo.CreateUserSession(ctx, userID, organizationID, tokenHash, refreshTokenHash, ipAddress, userAgent, "", expiresAt)
-	if err != nil {
-		return nil, err
+	if sessionID != "" {
+		// Move the current session; a revoked session can't be switched
+		if err := s.repo.UpdateUserSessionOrganization(ctx, sessionID, organizationID); err != nil {
+			if err == errors.ErrNotFound {
+				return nil, errors.ErrTokenInvalid
+			}
+			return nil, err
+		}
+	} else {
+		// Create a new session for this context switch
+		tokenHash := hashToken(generateRandomToken())
+		refreshTokenHash := hashToken(generateRandomToken())
+		expiresAt := time.Now().Add(24 * time.Hour) // 24-hour session
+
+		session, err := s.repo.CreateUserSession(ctx, userID, organizationID, tokenHash, refreshTokenHash, ipAddress, userAgent, "", expiresAt)
+		if err != nil {
+			return nil, err
+		}
+		sessionID = session.ID
 	}
 
 	// Record the context switch for audit trail
-	if _, err := s.repo.RecordContextSwitch(ctx, userID, currentOrgID, organizationID, "", ipAddress); err != nil {
+	if _, err := s.repo.RecordContextSwitch(ctx, userID, currentOrgID, organizationID, sessionID, ipAddress); err != nil {
 		// Log but don't fail if audit logging fails
 		fmt.Printf("Failed to record context switch: %v\n", err)
 	}
@@ -108,6 +119,19 @@ func (s *UserContextServiceImpl) RevokeUserSession(ctx context.Context, sessionI
 	return s.repo.RevokeUserSession(ctx, sessionID)
 }
 
+// IsSessionActive reports whether a session belongs to the user and is neither revoked nor expired
+func (s *UserContextServiceImpl) IsSessionActive(ctx context.Context, userID, sessionID string) (bool, error) {
+	session, err := s.repo.GetUserSessionByID(ctx, sessionID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return session.UserID == userID && session.RevokedAt == nil && session.ExpiresAt.After(time.Now()), nil
+}
+
 // GetContextSwitchHistory retrieves the user's context switch history
 func (s *UserContextServiceImpl) GetContextSwitchHistory(ctx context.Context, userID string, limit, offset int) ([]*repository.ContextSwitchRecord, error) {
 	records, _, err := s.repo.GetContextSwitchHistory(ctx, userID, limit, offset)
@@ -29,9 +29,6 @@ type Service interface {
 	// ListOrganizationMembers retrieves members of an organization
 	ListOrganizationMembers(ctx context.Context, orgID string, limit, offset int) ([]*model.OrganizationMemberResponse, error)
 
-	// AddOrganizationMember adds a user to an organization
-	AddOrganizationMember(ctx context.Context, orgID string, req *model.AddMemberRequest) (*model.OrganizationMemberResponse, error)
-
 	// UpdateOrganizationMemberRole updates a member's role
 	UpdateOrganizationMemberRole(ctx context.Context, orgID, userID string, req *model.UpdateMemberRequest) (*model.OrganizationMemberResponse, error)
 
@@ -41,6 +38,9 @@ type Service interface {
 	// GetOrganizationSettings retrieves organization settings
 	GetOrganizationSettings(ctx context.Context, orgID string) (*model.OrganizationSettings, error)
 
+	// RequiresEmailVerification reports whether users must verify their email before logging in
+	RequiresEmailVerification(ctx context.Context, orgID string) (bool, error)
+
 	// UpdateOrganizationSettings updates organization settings
 	UpdateOrganizationSettings(ctx context.Context, orgID string, req *model.UpdateSettingsRequest) (*model.OrganizationSettings, error)
 
@@ -232,32 +232,6 @@ func (s *OrganizationService) ListOrganizationMembers(ctx context.Context, orgID
 	return responses, nil
 }
 
-// AddOrganizationMember adds a user to an organization
-func (s *OrganizationService) AddOrganizationMember(ctx context.Context, orgID string, req *model.AddMemberRequest) (*model.OrganizationMemberResponse, error) {
-	member := &model.OrganizationMember{
-		ID:             "member-" + uuid.New().String(),
-		OrganizationID: orgID,
-		UserID:         req.Email, // Simplified - would lookup user by email
-		Role:           req.Role,
-		JoinedAt:       time.Now(),
-	}
-
-	if err := s.repo.AddOrganizationMember(ctx, member); err != nil {
-		return nil, err
-	}
-
-	return &model.OrganizationMemberResponse{
-		ID:           member.ID,
-		UserID:       member.UserID,
-		UserName:     fmt.Sprintf("User %s", member.UserID[:min(8, len(member.UserID))]),
-		UserEmail:    req.Email,
-		Role:         member.Role,
-		Status:       "active",
-		JoinedAt:     member.JoinedAt,
-		LastActiveAt: member.LastActiveAt,
-	}, nil
-}
-
 // UpdateOrganizationMemberRole updates a member's role
 func (s *OrganizationService) UpdateOrganizationMemberRole(ctx context.Context, orgID, userID string, req *model.UpdateMemberRequest) (*model.OrganizationMemberResponse, error) {
 	member, err := s.repo.GetOrganizationMember(ctx, orgID, userID)
@@ -292,6 +266,25 @@ func (s *OrganizationService) GetOrganizationSettings(ctx context.Context, orgID
 	return s.repo.GetOrganizationSettings(ctx, orgID)
 }
 
+// RequiresEmailVerification reports whether users must verify their email
+// before logging in. The system setting applies to everyone; an organization
+// can additionally require it for its members. orgID may be empty.
+func (s *OrganizationService) RequiresEmailVerification(ctx context.Context, orgID string) (bool, error) {
+	system, err := s.GetSystemSettings(ctx)
+	if err != nil {
+		return true, err
+	}
+	if system.RequireEmailVerification || orgID == "" {
+		return system.RequireEmailVerification, nil
+	}
+
+	settings, err := s.repo.GetOrganizationSettings(ctx, orgID)
+	if err != nil {
+		return true, err
+	}
+	return settings.RequireEmailVerification, nil
+}
+
 // UpdateOrganizationSettings updates organization settings
 func (s *OrganizationService) UpdateOrganizationSettings(ctx context.Context, orgID string, req *model.UpdateSettingsRequest) (*model.OrganizationSettings, error) {
 	settings, err := s.repo.GetOrganizationSettings(ctx, orgID)
@@ -666,15 +659,24 @@ func (s *OrganizationService) ListOrganizationUsers(ctx context.Context, orgID s
 }
 
 // SuspendOrganizationUser suspends a user within an organization (org admin only)
+// A duration, in days, ends the suspension automatically; without one it
+// lasts until lifted. Suspended members keep their role and seat.
 func (s *OrganizationService) SuspendOrganizationUser(ctx context.Context, orgID, userID, reason string, duration *int, adminID string) error {
-	// TODO: Implement proper suspension logic with moderation actions
-	return nil
+	var until *time.Time
+	if duration != nil {
+		if *duration <= 0 {
+			return errors.NewValidationError("duration must be a positive number of days")
+		}
+		t := s.now().Add(time.Duration(*duration) * 24 * time.Hour)
+		until = &t
+	}
+
+	return s.repo.SuspendOrganizationMember(ctx, orgID, userID, reason, adminID, until)
 }
 
 // UnsuspendOrganizationUser unsuspends a user within an organization (org admin only)
 func (s *OrganizationService) UnsuspendOrganizationUser(ctx context.Context, orgID, userID, adminID string) error {
-	// TODO: Implement proper unsuspension logic
-	return nil
+	return s.repo.UnsuspendOrganizationMember(ctx, orgID, userID)
 }
 
 // RemoveOrganizationUser removes a user from an organization (org admin only)
@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"net/http"
+
+	"ethos/internal/orgdomain/model"
+	"ethos/internal/orgdomain/service"
+	"ethos/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DomainHandler handles organization domain and join request HTTP requests
+type DomainHandler struct {
+	service service.Service
+}
+
+// NewDomainHandler creates a new organization domain handler
+func NewDomainHandler(svc service.Service) *DomainHandler {
+	return &DomainHandler{
+		service: svc,
+	}
+}
+
+// AddDomain handles POST /api/v1/organizations/:org_id/domains
+func (h *DomainHandler) AddDomain(c *gin.Context) {
+	var req model.AddDomainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	domain, err := h.service.AddDomain(c.Request.Context(), c.Param("org_id"), c.GetString("user_id"), &req, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, domain.ToResponse())
+}
+
+// ListDomains handles GET /api/v1/organizations/:org_id/domains
+func (h *DomainHandler) ListDomains(c *gin.Context) {
+	domains, err := h.service.ListDomains(c.Request.Context(), c.Param("org_id"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	responses := make([]*model.DomainResponse, len(domains))
+	for i, domain := range domains {
+		responses[i] = domain.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"domains": responses,
+		"count":   len(responses),
+	})
+}
+
+// VerifyDomain handles POST /api/v1/organizations/:org_id/domains/:domain_id/verify.
+// DNS verification needs no body; email verification sends the code.
+func (h *DomainHandler) VerifyDomain(c *gin.Context) {
+	var req model.VerifyDomainRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Validation failed",
+				"code":  "VALIDATION_FAILED",
+			})
+			return
+		}
+	}
+
+	domain, err := h.service.VerifyDomain(c.Request.Context(), c.Param("org_id"), c.GetString("user_id"), c.Param("domain_id"), &req, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ToResponse())
+}
+
+// ResendVerificationEmail handles POST /api/v1/organizations/:org_id/domains/:domain_id/verification-email
+func (h *DomainHandler) ResendVerificationEmail(c *gin.Context) {
+	domain, err := h.service.ResendVerificationEmail(c.Request.Context(), c.Param("org_id"), c.Param("domain_id"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ToResponse())
+}
+
+// UpdateDomain handles PUT /api/v1/organizations/:org_id/domains/:domain_id
+func (h *DomainHandler) UpdateDomain(c *gin.Context) {
+	var req model.UpdateDomainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	domain, err := h.service.UpdateDomain(c.Request.Context(), c.Param("org_id"), c.GetString("user_id"), c.Param("domain_id"), &req, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ToResponse())
+}
+
+// RemoveDomain handles DELETE /api/v1/organizations/:org_id/domains/:domain_id
+func (h *DomainHandler) RemoveDomain(c *gin.Context) {
+	err := h.service.RemoveDomain(c.Request.Context(), c.Param("org_id"), c.GetString("user_id"), c.Param("domain_id"), c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Domain removed.",
+	})
+}
+
+// ListJoinRequests handles GET /api/v1/organizations/:org_id/join-requests
+func (h *DomainHandler) ListJoinRequests(c *gin.Context) {
+	requests, err := h.service.ListJoinRequests(c.Request.Context(), c.Param("org_id"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"join_requests": requests,
+		"count":         len(requests),
+	})
+}
+
+// ApproveJoinRequest handles POST /api/v1/organizations/:org_id/join-requests/:request_id/approve
+func (h *DomainHandler) ApproveJoinRequest(c *gin.Context) {
+	request, err := h.service.ApproveJoinRequest(c.Request.Context(), c.Param("org_id"), c.GetString("user_id"), c.Param("request_id"), c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// DenyJoinRequest handles POST /api/v1/organizations/:org_id/join-requests/:request_id/deny
+func (h *DomainHandler) DenyJoinRequest(c *gin.Context) {
+	request, err := h.service.DenyJoinRequest(c.Request.Context(), c.Param("org_id"), c.GetString("user_id"), c.Param("request_id"), c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
@@ -0,0 +1,144 @@
+package model
+
+import "time"
+
+// Ways an organization can prove it controls a domain
+const (
+	// MethodDNS publishes a TXT record at ChallengeRecordPrefix + domain
+	MethodDNS = "dns"
+	// MethodEmail emails a code to an administrative mailbox at the domain
+	MethodEmail = "email"
+)
+
+// ChallengeRecordPrefix and ChallengeValuePrefix make up the DNS TXT record
+// that proves control of a domain
+const (
+	ChallengeRecordPrefix = "_ethos-challenge."
+	ChallengeValuePrefix  = "ethos-domain-verification="
+)
+
+// AdminMailboxes are the mailboxes a verification code may be sent to. Like
+// certificate authorities, we only trust addresses a domain's owner controls.
+var AdminMailboxes = []string{"admin", "administrator", "hostmaster", "postmaster", "webmaster"}
+
+// What happens when someone registers with an address at a verified domain
+const (
+	// JoinModeNone leaves them out of the organization
+	JoinModeNone = "none"
+	// JoinModeAuto adds them as members once their address is verified
+	JoinModeAuto = "auto"
+	// JoinModeRequest asks an organization admin to approve them
+	JoinModeRequest = "request"
+)
+
+// Join request statuses
+const (
+	JoinRequestPending  = "pending"
+	JoinRequestApproved = "approved"
+	JoinRequestDenied   = "denied"
+)
+
+// Activity log actions recorded for domains and domain joins
+const (
+	ActionDomainAdded         = "domain_added"
+	ActionDomainVerified      = "domain_verified"
+	ActionDomainUpdated       = "domain_updated"
+	ActionDomainRemoved       = "domain_removed"
+	ActionDomainJoined        = "domain_joined"
+	ActionJoinRequested       = "join_requested"
+	ActionJoinRequestApproved = "join_request_approved"
+	ActionJoinRequestDenied   = "join_request_denied"
+)
+
+// Domain is an email domain an organization has claimed. Only verified
+// domains link new users to the organization, and a domain can be verified
+// by one organization at a time.
+type Domain struct {
+	ID             string
+	OrganizationID string
+	Domain         string
+	Method         string
+	// VerificationToken is the DNS challenge value, or a hash of the emailed code
+	VerificationToken string
+	// VerificationEmail is where the code was sent, for the email method
+	VerificationEmail     string
+	VerificationExpiresAt *time.Time
+	Verified              bool
+	VerifiedAt            *time.Time
+	JoinMode              string
+	CreatedBy             string
+	CreatedAt             time.Time
+}
+
+// JoinRequest asks to join an organization on the strength of a verified domain
+type JoinRequest struct {
+	ID             string     `json:"id"`
+	OrganizationID string     `json:"organization_id"`
+	UserID         string     `json:"user_id"`
+	Email          string     `json:"email"`
+	Status         string     `json:"status"`
+	DecidedBy      string     `json:"decided_by,omitempty"`
+	DecidedAt      *time.Time `json:"decided_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// AddDomainRequest represents a request to claim a domain for an organization
+type AddDomainRequest struct {
+	Domain string `json:"domain" binding:"required,fqdn,max=255"`
+	Method string `json:"method" binding:"required,oneof=dns email"`
+	// Mailbox is the local part the code is sent to with the email method
+	Mailbox  string `json:"mailbox" binding:"omitempty,oneof=admin administrator hostmaster postmaster webmaster"`
+	JoinMode string `json:"join_mode" binding:"omitempty,oneof=none auto request"`
+}
+
+// VerifyDomainRequest checks a domain's DNS record, or the code emailed for it
+type VerifyDomainRequest struct {
+	Code string `json:"code"`
+}
+
+// UpdateDomainRequest changes what happens when people register at a domain
+type UpdateDomainRequest struct {
+	JoinMode string `json:"join_mode" binding:"required,oneof=none auto request"`
+}
+
+// TXTRecord is the DNS record to publish for DNS verification
+type TXTRecord struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// DomainResponse represents a domain for API responses
+type DomainResponse struct {
+	ID                string     `json:"id"`
+	Domain            string     `json:"domain"`
+	Method            string     `json:"method"`
+	Verified          bool       `json:"verified"`
+	VerifiedAt        *time.Time `json:"verified_at,omitempty"`
+	JoinMode          string     `json:"join_mode"`
+	TXTRecord         *TXTRecord `json:"txt_record,omitempty"`
+	VerificationEmail string     `json:"verification_email,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// ToResponse converts a domain for API responses, with the record to
+// publish while DNS verification is outstanding
+func (d *Domain) ToResponse() *DomainResponse {
+	resp := &DomainResponse{
+		ID:         d.ID,
+		Domain:     d.Domain,
+		Method:     d.Method,
+		Verified:   d.Verified,
+		VerifiedAt: d.VerifiedAt,
+		JoinMode:   d.JoinMode,
+		CreatedAt:  d.CreatedAt,
+	}
+	if !d.Verified {
+		switch d.Method {
+		case MethodDNS:
+			resp.TXTRecord = &TXTRecord{Name: ChallengeRecordPrefix + d.Domain, Value: ChallengeValuePrefix + d.VerificationToken}
+		case MethodEmail:
+			resp.VerificationEmail = d.VerificationEmail
+		}
+	}
+	return resp
+}
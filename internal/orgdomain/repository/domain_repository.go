@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ethos/internal/orgdomain/model"
+)
+
+// Repository defines the interface for organization domain data access
+type Repository interface {
+	// CreateDomain stores a claimed domain, or returns ErrDomainAlreadyAdded
+	CreateDomain(ctx context.Context, domain *model.Domain) error
+
+	// ListDomains lists an organization's domains
+	ListDomains(ctx context.Context, organizationID string) ([]*model.Domain, error)
+
+	// GetDomain retrieves one of an organization's domains, or ErrNotFound
+	GetDomain(ctx context.Context, organizationID, id string) (*model.Domain, error)
+
+	// IsDomainVerified reports whether any organization has verified a domain
+	IsDomainVerified(ctx context.Context, domain string) (bool, error)
+
+	// SetVerificationCode replaces the hash of the code emailed for a domain
+	SetVerificationCode(ctx context.Context, id, codeHash, email string, expiresAt time.Time) error
+
+	// MarkDomainVerified verifies a domain, or returns ErrDomainTaken if
+	// another organization verified it first
+	MarkDomainVerified(ctx context.Context, id string) error
+
+	// SetJoinMode changes what happens when people register at a domain, or returns ErrNotFound
+	SetJoinMode(ctx context.Context, organizationID, id, joinMode string) error
+
+	// DeleteDomain removes one of an organization's domains, or returns ErrNotFound
+	DeleteDomain(ctx context.Context, organizationID, id string) error
+
+	// GetVerifiedDomain retrieves the verified claim on a domain, or ErrNotFound
+	GetVerifiedDomain(ctx context.Context, domain string) (*model.Domain, error)
+
+	// HasPendingInvitation reports whether an address has an unexpired
+	// invitation to an organization
+	HasPendingInvitation(ctx context.Context, organizationID, email string) (bool, error)
+
+	// JoinOrganization adds a user as a member if a seat is free, or returns
+	// ErrSeatLimitReached. Existing members keep their role.
+	JoinOrganization(ctx context.Context, organizationID, userID, role string) error
+
+	// CreateJoinRequest stores a pending join request; a user has at most one
+	// pending request per organization
+	CreateJoinRequest(ctx context.Context, request *model.JoinRequest) error
+
+	// ListJoinRequests lists an organization's pending join requests, oldest first
+	ListJoinRequests(ctx context.Context, organizationID string) ([]*model.JoinRequest, error)
+
+	// ApproveJoinRequest approves a pending join request and adds its user as
+	// a member, if a seat is free. Returns ErrNotFound unless pending.
+	ApproveJoinRequest(ctx context.Context, organizationID, id, decidedBy, role string) (*model.JoinRequest, error)
+
+	// DenyJoinRequest denies a pending join request, or returns ErrNotFound
+	DenyJoinRequest(ctx context.Context, organizationID, id, decidedBy string) (*model.JoinRequest, error)
+}
@@ -0,0 +1,536 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"ethos/internal/database"
+	orgRepository "ethos/internal/organization/repository"
+	"ethos/internal/orgdomain/model"
+	"ethos/pkg/errors"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const domainColumns = `
+	id::text, organization_id::text, domain, verification_method, COALESCE(verification_token, ''),
+	COALESCE(verification_email, ''), verification_expires_at, COALESCE(verified, FALSE), verified_at, join_mode, created_at
+`
+
+const joinRequestColumns = `
+	id::text, organization_id::text, user_id, email, status, COALESCE(decided_by, ''), decided_at, created_at
+`
+
+// PostgresRepository implements the Repository interface using PostgreSQL
+type PostgresRepository struct {
+	db *database.DB
+}
+
+// NewPostgresRepository creates a new PostgreSQL repository
+func NewPostgresRepository(db *database.DB) Repository {
+	return &PostgresRepository{db: db}
+}
+
+// CreateDomain stores a claimed domain
+func (r *PostgresRepository) CreateDomain(ctx context.Context, domain *model.Domain) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.CreateDomain")
+	defer span.End()
+
+	query := `
+		INSERT INTO organization_domains (
+			organization_id, domain, verification_method, verification_token, verification_email,
+			verification_expires_at, join_mode, created_by
+		)
+		VALUES ($1::uuid, $2, $3, $4, NULLIF($5, ''), $6, $7, NULLIF($8, ''))
+		RETURNING id::text, created_at
+	`
+
+	err := r.db.Pool.QueryRow(ctx, query,
+		domain.OrganizationID,
+		domain.Domain,
+		domain.Method,
+		domain.VerificationToken,
+		domain.VerificationEmail,
+		domain.VerificationExpiresAt,
+		domain.JoinMode,
+		domain.CreatedBy,
+	).Scan(&domain.ID, &domain.CreatedAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if strings.Contains(err.Error(), "duplicate key value") || strings.Contains(err.Error(), "23505") {
+			return errors.ErrDomainAlreadyAdded
+		}
+		return errors.WrapError(err, "failed to create domain")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// ListDomains lists an organization's domains
+func (r *PostgresRepository) ListDomains(ctx context.Context, organizationID string) ([]*model.Domain, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ListDomains")
+	defer span.End()
+
+	query := `SELECT ` + domainColumns + ` FROM organization_domains WHERE organization_id::text = $1 ORDER BY domain`
+
+	rows, err := r.db.Pool.Query(ctx, query, organizationID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list domains")
+	}
+	defer rows.Close()
+
+	domains := []*model.Domain{}
+	for rows.Next() {
+		domain, err := scanDomain(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, errors.WrapError(err, "failed to scan domain")
+		}
+		domains = append(domains, domain)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list domains")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return domains, nil
+}
+
+// GetDomain retrieves one of an organization's domains, or ErrNotFound
+func (r *PostgresRepository) GetDomain(ctx context.Context, organizationID, id string) (*model.Domain, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetDomain")
+	defer span.End()
+
+	query := `SELECT ` + domainColumns + ` FROM organization_domains WHERE id::text = $1 AND organization_id::text = $2`
+
+	domain, err := scanDomain(r.db.Pool.QueryRow(ctx, query, id, organizationID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get domain")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return domain, nil
+}
+
+// IsDomainVerified reports whether any organization has verified a domain
+func (r *PostgresRepository) IsDomainVerified(ctx context.Context, domain string) (bool, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.IsDomainVerified")
+	defer span.End()
+
+	query := `SELECT EXISTS (SELECT 1 FROM organization_domains WHERE LOWER(domain) = LOWER($1) AND verified)`
+
+	var verified bool
+	if err := r.db.Pool.QueryRow(ctx, query, domain).Scan(&verified); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return false, errors.WrapError(err, "failed to check domain")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return verified, nil
+}
+
+// SetVerificationCode replaces the hash of the code emailed for a domain
+func (r *PostgresRepository) SetVerificationCode(ctx context.Context, id, codeHash, email string, expiresAt time.Time) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.SetVerificationCode")
+	defer span.End()
+
+	query := `
+		UPDATE organization_domains
+		SET verification_token = $2, verification_email = $3, verification_expires_at = $4
+		WHERE id::text = $1
+	`
+
+	if _, err := r.db.Pool.Exec(ctx, query, id, codeHash, email, expiresAt); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to set verification code")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// MarkDomainVerified verifies a domain. The unique index on verified domains
+// decides a race between two organizations.
+func (r *PostgresRepository) MarkDomainVerified(ctx context.Context, id string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.MarkDomainVerified")
+	defer span.End()
+
+	query := `
+		UPDATE organization_domains
+		SET verified = TRUE, verified_at = NOW(), verification_token = NULL, verification_expires_at = NULL
+		WHERE id::text = $1
+	`
+
+	if _, err := r.db.Pool.Exec(ctx, query, id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if strings.Contains(err.Error(), "duplicate key value") || strings.Contains(err.Error(), "23505") {
+			return errors.ErrDomainTaken
+		}
+		return errors.WrapError(err, "failed to verify domain")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// SetJoinMode changes what happens when people register at a domain
+func (r *PostgresRepository) SetJoinMode(ctx context.Context, organizationID, id, joinMode string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.SetJoinMode")
+	defer span.End()
+
+	query := `UPDATE organization_domains SET join_mode = $3 WHERE id::text = $1 AND organization_id::text = $2`
+
+	result, err := r.db.Pool.Exec(ctx, query, id, organizationID, joinMode)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to update domain")
+	}
+	if result.RowsAffected() == 0 {
+		return errors.ErrNotFound
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// DeleteDomain removes one of an organization's domains
+func (r *PostgresRepository) DeleteDomain(ctx context.Context, organizationID, id string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.DeleteDomain")
+	defer span.End()
+
+	query := `DELETE FROM organization_domains WHERE id::text = $1 AND organization_id::text = $2`
+
+	result, err := r.db.Pool.Exec(ctx, query, id, organizationID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to delete domain")
+	}
+	if result.RowsAffected() == 0 {
+		return errors.ErrNotFound
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// GetVerifiedDomain retrieves the verified claim on a domain, or ErrNotFound
+func (r *PostgresRepository) GetVerifiedDomain(ctx context.Context, domain string) (*model.Domain, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetVerifiedDomain")
+	defer span.End()
+
+	query := `
+		SELECT ` + domainColumns + `
+		FROM organization_domains
+		WHERE LOWER(domain) = LOWER($1) AND verified
+			AND organization_id IN (SELECT id FROM organizations WHERE deleted_at IS NULL)
+	`
+
+	verified, err := scanDomain(r.db.Pool.QueryRow(ctx, query, domain))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get verified domain")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return verified, nil
+}
+
+// HasPendingInvitation reports whether an address has an unexpired invitation to an organization
+func (r *PostgresRepository) HasPendingInvitation(ctx context.Context, organizationID, email string) (bool, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.HasPendingInvitation")
+	defer span.End()
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM organization_invitations
+			WHERE organization_id::text = $1 AND LOWER(email) = LOWER($2) AND status = 'pending' AND expires_at > NOW()
+		)
+	`
+
+	var pending bool
+	if err := r.db.Pool.QueryRow(ctx, query, organizationID, email).Scan(&pending); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return false, errors.WrapError(err, "failed to check pending invitations")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return pending, nil
+}
+
+// JoinOrganization adds a user as a member if a seat is free
+func (r *PostgresRepository) JoinOrganization(ctx context.Context, organizationID, userID, role string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.JoinOrganization")
+	defer span.End()
+
+	err := r.inSeatLock(ctx, organizationID, func(tx pgx.Tx) error {
+		var isMember bool
+		if err := tx.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM organization_members WHERE organization_id::text = $1 AND user_id = $2)`,
+			organizationID, userID,
+		).Scan(&isMember); err != nil {
+			return errors.WrapError(err, "failed to check existing membership")
+		}
+		if isMember {
+			return nil
+		}
+
+		if err := orgRepository.CheckSeatAvailable(ctx, tx, organizationID, ""); err != nil {
+			return err
+		}
+		return addMember(ctx, tx, organizationID, userID, role)
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// CreateJoinRequest stores a pending join request. If the user already has
+// one, request is filled in from it instead.
+func (r *PostgresRepository) CreateJoinRequest(ctx context.Context, request *model.JoinRequest) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.CreateJoinRequest")
+	defer span.End()
+
+	query := `
+		WITH inserted AS (
+			INSERT INTO organization_join_requests (organization_id, user_id, email)
+			VALUES ($1::uuid, $2, $3)
+			ON CONFLICT (organization_id, user_id) WHERE status = 'pending' DO NOTHING
+			RETURNING id::text, status, created_at
+		)
+		SELECT id, status, created_at FROM inserted
+		UNION ALL
+		SELECT id::text, status, created_at FROM organization_join_requests
+		WHERE organization_id::text = $1 AND user_id = $2 AND status = 'pending' AND NOT EXISTS (SELECT 1 FROM inserted)
+	`
+
+	err := r.db.Pool.QueryRow(ctx, query, request.OrganizationID, request.UserID, request.Email).
+		Scan(&request.ID, &request.Status, &request.CreatedAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to create join request")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// ListJoinRequests lists an organization's pending join requests, oldest first
+func (r *PostgresRepository) ListJoinRequests(ctx context.Context, organizationID string) ([]*model.JoinRequest, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ListJoinRequests")
+	defer span.End()
+
+	query := `
+		SELECT ` + joinRequestColumns + `
+		FROM organization_join_requests
+		WHERE organization_id::text = $1 AND status = 'pending'
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, organizationID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list join requests")
+	}
+	defer rows.Close()
+
+	requests := []*model.JoinRequest{}
+	for rows.Next() {
+		request, err := scanJoinRequest(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, errors.WrapError(err, "failed to scan join request")
+		}
+		requests = append(requests, request)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list join requests")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return requests, nil
+}
+
+// ApproveJoinRequest approves a pending join request and adds its user as a member
+func (r *PostgresRepository) ApproveJoinRequest(ctx context.Context, organizationID, id, decidedBy, role string) (*model.JoinRequest, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ApproveJoinRequest")
+	defer span.End()
+
+	var request *model.JoinRequest
+	err := r.inSeatLock(ctx, organizationID, func(tx pgx.Tx) error {
+		var err error
+		request, err = decideJoinRequest(ctx, tx, organizationID, id, decidedBy, model.JoinRequestApproved)
+		if err != nil {
+			return err
+		}
+
+		if err := orgRepository.CheckSeatAvailable(ctx, tx, organizationID, ""); err != nil {
+			return err
+		}
+		return addMember(ctx, tx, organizationID, request.UserID, role)
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return request, nil
+}
+
+// DenyJoinRequest denies a pending join request
+func (r *PostgresRepository) DenyJoinRequest(ctx context.Context, organizationID, id, decidedBy string) (*model.JoinRequest, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.DenyJoinRequest")
+	defer span.End()
+
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	request, err := decideJoinRequest(ctx, tx, organizationID, id, decidedBy, model.JoinRequestDenied)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to commit transaction")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return request, nil
+}
+
+// decideJoinRequest moves a pending join request to status
+func decideJoinRequest(ctx context.Context, tx pgx.Tx, organizationID, id, decidedBy, status string) (*model.JoinRequest, error) {
+	request, err := scanJoinRequest(tx.QueryRow(ctx, `
+		UPDATE organization_join_requests
+		SET status = $3, decided_by = NULLIF($4, ''), decided_at = NOW()
+		WHERE id::text = $1 AND organization_id::text = $2 AND status = 'pending'
+		RETURNING `+joinRequestColumns,
+		id, organizationID, status, decidedBy,
+	))
+	if err == pgx.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to update join request")
+	}
+	return request, nil
+}
+
+// addMember adds a user to an organization; existing members keep their role
+func addMember(ctx context.Context, tx pgx.Tx, organizationID, userID, role string) error {
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO organization_members (organization_id, user_id, role, joined_at)
+		VALUES ($1::uuid, $2, $3, NOW())
+		ON CONFLICT (organization_id, user_id) DO NOTHING`,
+		organizationID, userID, role,
+	); err != nil {
+		return errors.WrapError(err, "failed to add organization member")
+	}
+	return nil
+}
+
+// inSeatLock runs fn in a transaction holding the organization's seat lock
+func (r *PostgresRepository) inSeatLock(ctx context.Context, organizationID string, fn func(tx pgx.Tx) error) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return errors.WrapError(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	if err := orgRepository.LockSeats(ctx, tx, organizationID); err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.WrapError(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+func scanDomain(row pgx.Row) (*model.Domain, error) {
+	var domain model.Domain
+	err := row.Scan(
+		&domain.ID,
+		&domain.OrganizationID,
+		&domain.Domain,
+		&domain.Method,
+		&domain.VerificationToken,
+		&domain.VerificationEmail,
+		&domain.VerificationExpiresAt,
+		&domain.Verified,
+		&domain.VerifiedAt,
+		&domain.JoinMode,
+		&domain.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &domain, nil
+}
+
+func scanJoinRequest(row pgx.Row) (*model.JoinRequest, error) {
+	var request model.JoinRequest
+	err := row.Scan(
+		&request.ID,
+		&request.OrganizationID,
+		&request.UserID,
+		&request.Email,
+		&request.Status,
+		&request.DecidedBy,
+		&request.DecidedAt,
+		&request.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
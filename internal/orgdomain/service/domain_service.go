@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	"ethos/internal/orgdomain/model"
+)
+
+// Service defines the interface for organization domains and domain joins
+type Service interface {
+	// AddDomain claims a domain for an organization and starts its verification
+	AddDomain(ctx context.Context, organizationID, userID string, req *model.AddDomainRequest, ipAddress, userAgent string) (*model.Domain, error)
+
+	// ListDomains lists an organization's domains
+	ListDomains(ctx context.Context, organizationID string) ([]*model.Domain, error)
+
+	// VerifyDomain checks a domain's DNS record, or the code emailed for it
+	VerifyDomain(ctx context.Context, organizationID, userID, domainID string, req *model.VerifyDomainRequest, ipAddress, userAgent string) (*model.Domain, error)
+
+	// ResendVerificationEmail emails a new verification code for a domain
+	ResendVerificationEmail(ctx context.Context, organizationID, domainID string) (*model.Domain, error)
+
+	// UpdateDomain changes what happens when people register at a domain
+	UpdateDomain(ctx context.Context, organizationID, userID, domainID string, req *model.UpdateDomainRequest, ipAddress, userAgent string) (*model.Domain, error)
+
+	// RemoveDomain removes one of an organization's domains
+	RemoveDomain(ctx context.Context, organizationID, userID, domainID, ipAddress, userAgent string) error
+
+	// ListJoinRequests lists an organization's pending join requests
+	ListJoinRequests(ctx context.Context, organizationID string) ([]*model.JoinRequest, error)
+
+	// ApproveJoinRequest adds the requesting user to the organization
+	ApproveJoinRequest(ctx context.Context, organizationID, userID, requestID, ipAddress, userAgent string) (*model.JoinRequest, error)
+
+	// DenyJoinRequest denies a pending join request
+	DenyJoinRequest(ctx context.Context, organizationID, userID, requestID, ipAddress, userAgent string) (*model.JoinRequest, error)
+
+	// JoinByEmailDomain links a user with a verified address to the
+	// organization that verified its domain, if that organization allows it
+	JoinByEmailDomain(ctx context.Context, userID, email string) error
+}
@@ -0,0 +1,439 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	orgModel "ethos/internal/organization/model"
+	"ethos/internal/orgdomain/model"
+	"ethos/internal/orgdomain/repository"
+	"ethos/pkg/email"
+	emailTemplates "ethos/pkg/email/templates"
+	"ethos/pkg/errors"
+)
+
+// memberRole is the role people joining through a verified domain get
+const memberRole = "member"
+
+// OrganizationStore looks up organizations (implemented by the organization repository)
+type OrganizationStore interface {
+	GetOrganization(ctx context.Context, orgID string) (*orgModel.Organization, error)
+	GetOrganizationSettings(ctx context.Context, orgID string) (*orgModel.OrganizationSettings, error)
+}
+
+// TXTResolver looks up DNS TXT records (implemented by *net.Resolver)
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// ActivityLogger writes the organization activity log (implemented by the organization context repository)
+type ActivityLogger interface {
+	LogOrganizationActivity(ctx context.Context, organizationID, userID, action, resourceType, resourceID, ipAddress, userAgent string, changes map[string]interface{}) error
+}
+
+// EmailSender sends domain verification codes
+type EmailSender interface {
+	SendEmail(ctx context.Context, req email.SendEmailRequest) error
+}
+
+// Config holds domain verification settings
+type Config struct {
+	// CodeTTL is how long an emailed verification code can be used
+	CodeTTL time.Duration
+}
+
+// DomainService implements the Service interface
+type DomainService struct {
+	repo        repository.Repository
+	orgs        OrganizationStore
+	resolver    TXTResolver
+	activity    ActivityLogger
+	emailSender EmailSender
+	config      Config
+}
+
+// NewDomainService creates a new organization domain service
+func NewDomainService(repo repository.Repository, orgs OrganizationStore, resolver TXTResolver, activity ActivityLogger, emailSender EmailSender, cfg Config) Service {
+	return &DomainService{
+		repo:        repo,
+		orgs:        orgs,
+		resolver:    resolver,
+		activity:    activity,
+		emailSender: emailSender,
+		config:      cfg,
+	}
+}
+
+// AddDomain claims a domain for an organization. A domain another
+// organization has verified can't be claimed.
+func (s *DomainService) AddDomain(ctx context.Context, organizationID, userID string, req *model.AddDomainRequest, ipAddress, userAgent string) (*model.Domain, error) {
+	name := normalizeDomain(req.Domain)
+	verified, err := s.repo.IsDomainVerified(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if verified {
+		return nil, errors.ErrDomainTaken
+	}
+
+	org, err := s.orgs.GetOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	domain := &model.Domain{
+		OrganizationID: organizationID,
+		Domain:         name,
+		Method:         req.Method,
+		JoinMode:       req.JoinMode,
+		CreatedBy:      userID,
+	}
+	if domain.JoinMode == "" {
+		domain.JoinMode = model.JoinModeNone
+	}
+
+	var code string
+	switch req.Method {
+	case model.MethodDNS:
+		token, err := generateRandomToken(16)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to generate verification token")
+		}
+		domain.VerificationToken = token
+	case model.MethodEmail:
+		mailbox := req.Mailbox
+		if mailbox == "" {
+			mailbox = model.AdminMailboxes[0]
+		}
+		code, err = generateCode()
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to generate verification code")
+		}
+		expiresAt := time.Now().Add(s.config.CodeTTL)
+		domain.VerificationToken = hashCode(code)
+		domain.VerificationEmail = mailbox + "@" + name
+		domain.VerificationExpiresAt = &expiresAt
+	default:
+		return nil, errors.NewValidationError("method must be dns or email")
+	}
+
+	if err := s.repo.CreateDomain(ctx, domain); err != nil {
+		return nil, err
+	}
+
+	s.logActivity(ctx, domain, userID, model.ActionDomainAdded, ipAddress, userAgent, nil)
+	if code != "" {
+		s.sendVerificationEmail(org, domain, code)
+	}
+	return domain, nil
+}
+
+// ListDomains lists an organization's domains
+func (s *DomainService) ListDomains(ctx context.Context, organizationID string) ([]*model.Domain, error) {
+	return s.repo.ListDomains(ctx, organizationID)
+}
+
+// VerifyDomain checks that an organization controls a domain, either by
+// looking up the challenge TXT record or by checking the emailed code
+func (s *DomainService) VerifyDomain(ctx context.Context, organizationID, userID, domainID string, req *model.VerifyDomainRequest, ipAddress, userAgent string) (*model.Domain, error) {
+	domain, err := s.repo.GetDomain(ctx, organizationID, domainID)
+	if err != nil {
+		return nil, err
+	}
+	if domain.Verified {
+		return domain, nil
+	}
+
+	switch domain.Method {
+	case model.MethodDNS:
+		if err := s.checkTXTRecord(ctx, domain); err != nil {
+			return nil, err
+		}
+	case model.MethodEmail:
+		if err := checkCode(domain, req.Code, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.MarkDomainVerified(ctx, domain.ID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	domain.Verified = true
+	domain.VerifiedAt = &now
+	s.logActivity(ctx, domain, userID, model.ActionDomainVerified, ipAddress, userAgent, nil)
+	return domain, nil
+}
+
+// ResendVerificationEmail emails a new verification code. Earlier codes stop working.
+func (s *DomainService) ResendVerificationEmail(ctx context.Context, organizationID, domainID string) (*model.Domain, error) {
+	domain, err := s.repo.GetDomain(ctx, organizationID, domainID)
+	if err != nil {
+		return nil, err
+	}
+	if domain.Method != model.MethodEmail || domain.Verified {
+		return nil, errors.NewValidationError("this domain isn't waiting for an emailed code")
+	}
+
+	org, err := s.orgs.GetOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := generateCode()
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to generate verification code")
+	}
+	expiresAt := time.Now().Add(s.config.CodeTTL)
+	if err := s.repo.SetVerificationCode(ctx, domain.ID, hashCode(code), domain.VerificationEmail, expiresAt); err != nil {
+		return nil, err
+	}
+
+	domain.VerificationToken = hashCode(code)
+	domain.VerificationExpiresAt = &expiresAt
+	s.sendVerificationEmail(org, domain, code)
+	return domain, nil
+}
+
+// UpdateDomain changes what happens when people register at a domain
+func (s *DomainService) UpdateDomain(ctx context.Context, organizationID, userID, domainID string, req *model.UpdateDomainRequest, ipAddress, userAgent string) (*model.Domain, error) {
+	domain, err := s.repo.GetDomain(ctx, organizationID, domainID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetJoinMode(ctx, organizationID, domainID, req.JoinMode); err != nil {
+		return nil, err
+	}
+
+	s.logActivity(ctx, domain, userID, model.ActionDomainUpdated, ipAddress, userAgent, map[string]interface{}{
+		"join_mode": map[string]interface{}{"old": domain.JoinMode, "new": req.JoinMode},
+	})
+	domain.JoinMode = req.JoinMode
+	return domain, nil
+}
+
+// RemoveDomain removes one of an organization's domains. Existing members
+// keep their membership.
+func (s *DomainService) RemoveDomain(ctx context.Context, organizationID, userID, domainID, ipAddress, userAgent string) error {
+	domain, err := s.repo.GetDomain(ctx, organizationID, domainID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteDomain(ctx, organizationID, domainID); err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, domain, userID, model.ActionDomainRemoved, ipAddress, userAgent, nil)
+	return nil
+}
+
+// ListJoinRequests lists an organization's pending join requests
+func (s *DomainService) ListJoinRequests(ctx context.Context, organizationID string) ([]*model.JoinRequest, error) {
+	return s.repo.ListJoinRequests(ctx, organizationID)
+}
+
+// ApproveJoinRequest adds the requesting user to the organization as a member
+func (s *DomainService) ApproveJoinRequest(ctx context.Context, organizationID, userID, requestID, ipAddress, userAgent string) (*model.JoinRequest, error) {
+	request, err := s.repo.ApproveJoinRequest(ctx, organizationID, requestID, userID, memberRole)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logJoinActivity(ctx, request, userID, model.ActionJoinRequestApproved, ipAddress, userAgent)
+	return request, nil
+}
+
+// DenyJoinRequest denies a pending join request
+func (s *DomainService) DenyJoinRequest(ctx context.Context, organizationID, userID, requestID, ipAddress, userAgent string) (*model.JoinRequest, error) {
+	request, err := s.repo.DenyJoinRequest(ctx, organizationID, requestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logJoinActivity(ctx, request, userID, model.ActionJoinRequestDenied, ipAddress, userAgent)
+	return request, nil
+}
+
+// JoinByEmailDomain links a user to the organization that verified their
+// email address's domain. Callers must only pass verified addresses. An
+// organization that requires approval gets a join request even when the
+// domain is set to join automatically, as does one that is out of seats.
+// Addresses with a pending invitation are left to accept it.
+func (s *DomainService) JoinByEmailDomain(ctx context.Context, userID, address string) error {
+	at := strings.LastIndex(address, "@")
+	if at < 0 {
+		return nil
+	}
+
+	domain, err := s.repo.GetVerifiedDomain(ctx, normalizeDomain(address[at+1:]))
+	if err == errors.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	joinMode := domain.JoinMode
+	if joinMode == model.JoinModeNone {
+		return nil
+	}
+
+	// An invitation decides the role, and already holds a seat
+	invited, err := s.repo.HasPendingInvitation(ctx, domain.OrganizationID, address)
+	if err != nil {
+		return err
+	}
+	if invited {
+		return nil
+	}
+	if joinMode == model.JoinModeAuto {
+		settings, err := s.orgs.GetOrganizationSettings(ctx, domain.OrganizationID)
+		if err != nil {
+			return err
+		}
+		if settings.RequireApproval {
+			joinMode = model.JoinModeRequest
+		}
+	}
+
+	if joinMode == model.JoinModeAuto {
+		err := s.repo.JoinOrganization(ctx, domain.OrganizationID, userID, memberRole)
+		if err == nil {
+			s.logActivity(ctx, domain, userID, model.ActionDomainJoined, "", "", map[string]interface{}{"email": address})
+			return nil
+		}
+		if err != errors.ErrSeatLimitReached {
+			return err
+		}
+	}
+
+	request := &model.JoinRequest{
+		OrganizationID: domain.OrganizationID,
+		UserID:         userID,
+		Email:          strings.ToLower(strings.TrimSpace(address)),
+	}
+	if err := s.repo.CreateJoinRequest(ctx, request); err != nil {
+		return err
+	}
+
+	s.logJoinActivity(ctx, request, userID, model.ActionJoinRequested, "", "")
+	return nil
+}
+
+// checkTXTRecord looks for the domain's challenge value among the TXT
+// records at its challenge name
+func (s *DomainService) checkTXTRecord(ctx context.Context, domain *model.Domain) error {
+	name := model.ChallengeRecordPrefix + domain.Domain
+	want := model.ChallengeValuePrefix + domain.VerificationToken
+
+	records, err := s.resolver.LookupTXT(ctx, name)
+	if err != nil {
+		fmt.Printf("Failed to look up TXT records for %s: %v\n", name, err)
+		return errors.NewValidationError(fmt.Sprintf("no TXT record found at %s", name))
+	}
+	for _, record := range records {
+		if strings.TrimSpace(record) == want {
+			return nil
+		}
+	}
+	return errors.NewValidationError(fmt.Sprintf("the TXT record at %s doesn't contain %s", name, want))
+}
+
+// checkCode checks an emailed code against the stored hash
+func checkCode(domain *model.Domain, code string, now time.Time) error {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return errors.NewValidationError("code is required")
+	}
+	if domain.VerificationExpiresAt == nil || now.After(*domain.VerificationExpiresAt) {
+		return errors.NewValidationError("the verification code has expired; request a new one")
+	}
+	if subtle.ConstantTimeCompare([]byte(hashCode(code)), []byte(domain.VerificationToken)) != 1 {
+		return errors.NewValidationError("the verification code is incorrect")
+	}
+	return nil
+}
+
+func (s *DomainService) logActivity(ctx context.Context, domain *model.Domain, userID, action, ipAddress, userAgent string, changes map[string]interface{}) {
+	if changes == nil {
+		changes = map[string]interface{}{}
+	}
+	changes["domain"] = domain.Domain
+	if err := s.activity.LogOrganizationActivity(ctx, domain.OrganizationID, userID, action, "domain", domain.ID, ipAddress, userAgent, changes); err != nil {
+		fmt.Printf("Failed to log %s for domain %s: %v\n", action, domain.Domain, err)
+	}
+}
+
+func (s *DomainService) logJoinActivity(ctx context.Context, request *model.JoinRequest, userID, action, ipAddress, userAgent string) {
+	if err := s.activity.LogOrganizationActivity(ctx, request.OrganizationID, userID, action, "join_request", request.ID, ipAddress, userAgent, map[string]interface{}{
+		"email":   request.Email,
+		"user_id": request.UserID,
+	}); err != nil {
+		fmt.Printf("Failed to log %s for join request %s: %v\n", action, request.ID, err)
+	}
+}
+
+func (s *DomainService) sendVerificationEmail(org *orgModel.Organization, domain *model.Domain, code string) {
+	if s.emailSender == nil {
+		return
+	}
+
+	template := emailTemplates.GetTemplate(emailTemplates.TemplateDomainVerification)
+	emailReq := email.SendEmailRequest{
+		To:         domain.VerificationEmail,
+		Subject:    template["subject"].(string),
+		TemplateID: template["template_id"].(string),
+		TemplateData: map[string]interface{}{
+			"Name":         domain.VerificationEmail,
+			"email":        domain.VerificationEmail,
+			"Domain":       domain.Domain,
+			"Organization": org.Name,
+			"Code":         code,
+			"ExpiryDate":   domain.VerificationExpiresAt.UTC().Format(time.RFC1123),
+		},
+	}
+
+	// Send email asynchronously
+	go func() {
+		if err := s.emailSender.SendEmail(context.Background(), emailReq); err != nil {
+			fmt.Printf("Failed to send domain verification email: %v\n", err)
+		}
+	}()
+}
+
+// normalizeDomain lowercases a domain and drops any trailing dot
+func normalizeDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
+
+// hashCode returns the hex SHA-256 of an emailed code, which is what's stored
+func hashCode(code string) string {
+	hash := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(hash[:])
+}
+
+// generateCode generates an 8 character verification code that's easy to type
+func generateCode() (string, error) {
+	buf := make([]byte, 5)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.EncodeToString(buf), nil
+}
+
+// generateRandomToken generates a hex token from n random bytes
+func generateRandomToken(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
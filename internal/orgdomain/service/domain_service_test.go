@@ -0,0 +1,332 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	orgModel "ethos/internal/organization/model"
+	"ethos/internal/orgdomain/model"
+	"ethos/pkg/email"
+	"ethos/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRepository keeps domains, members and join requests in memory
+type fakeRepository struct {
+	domains  map[string]*model.Domain
+	members  map[string]string // user ID to organization ID
+	requests []*model.JoinRequest
+	seats    int // free seats; negative is unlimited
+	invited  map[string]bool
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{domains: map[string]*model.Domain{}, members: map[string]string{}, seats: -1, invited: map[string]bool{}}
+}
+
+func (r *fakeRepository) CreateDomain(ctx context.Context, domain *model.Domain) error {
+	domain.ID = fmt.Sprintf("domain-%d", len(r.domains)+1)
+	r.domains[domain.ID] = domain
+	return nil
+}
+
+func (r *fakeRepository) ListDomains(ctx context.Context, organizationID string) ([]*model.Domain, error) {
+	var domains []*model.Domain
+	for _, domain := range r.domains {
+		if domain.OrganizationID == organizationID {
+			domains = append(domains, domain)
+		}
+	}
+	return domains, nil
+}
+
+func (r *fakeRepository) GetDomain(ctx context.Context, organizationID, id string) (*model.Domain, error) {
+	domain, ok := r.domains[id]
+	if !ok || domain.OrganizationID != organizationID {
+		return nil, errors.ErrNotFound
+	}
+	copied := *domain
+	return &copied, nil
+}
+
+func (r *fakeRepository) IsDomainVerified(ctx context.Context, domain string) (bool, error) {
+	_, err := r.GetVerifiedDomain(ctx, domain)
+	return err == nil, nil
+}
+
+func (r *fakeRepository) SetVerificationCode(ctx context.Context, id, codeHash, address string, expiresAt time.Time) error {
+	r.domains[id].VerificationToken = codeHash
+	r.domains[id].VerificationExpiresAt = &expiresAt
+	return nil
+}
+
+func (r *fakeRepository) MarkDomainVerified(ctx context.Context, id string) error {
+	if _, err := r.GetVerifiedDomain(ctx, r.domains[id].Domain); err == nil {
+		return errors.ErrDomainTaken
+	}
+	r.domains[id].Verified = true
+	return nil
+}
+
+func (r *fakeRepository) SetJoinMode(ctx context.Context, organizationID, id, joinMode string) error {
+	r.domains[id].JoinMode = joinMode
+	return nil
+}
+
+func (r *fakeRepository) DeleteDomain(ctx context.Context, organizationID, id string) error {
+	delete(r.domains, id)
+	return nil
+}
+
+func (r *fakeRepository) GetVerifiedDomain(ctx context.Context, domain string) (*model.Domain, error) {
+	for _, d := range r.domains {
+		if d.Verified && d.Domain == domain {
+			return d, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (r *fakeRepository) HasPendingInvitation(ctx context.Context, organizationID, address string) (bool, error) {
+	return r.invited[address], nil
+}
+
+func (r *fakeRepository) JoinOrganization(ctx context.Context, organizationID, userID, role string) error {
+	if r.seats == 0 {
+		return errors.ErrSeatLimitReached
+	}
+	r.seats--
+	r.members[userID] = organizationID
+	return nil
+}
+
+func (r *fakeRepository) CreateJoinRequest(ctx context.Context, request *model.JoinRequest) error {
+	request.ID = fmt.Sprintf("request-%d", len(r.requests)+1)
+	request.Status = model.JoinRequestPending
+	r.requests = append(r.requests, request)
+	return nil
+}
+
+func (r *fakeRepository) ListJoinRequests(ctx context.Context, organizationID string) ([]*model.JoinRequest, error) {
+	return r.requests, nil
+}
+
+func (r *fakeRepository) ApproveJoinRequest(ctx context.Context, organizationID, id, decidedBy, role string) (*model.JoinRequest, error) {
+	for _, request := range r.requests {
+		if request.ID == id && request.Status == model.JoinRequestPending {
+			request.Status = model.JoinRequestApproved
+			r.members[request.UserID] = organizationID
+			return request, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (r *fakeRepository) DenyJoinRequest(ctx context.Context, organizationID, id, decidedBy string) (*model.JoinRequest, error) {
+	return nil, errors.ErrNotFound
+}
+
+// fakeOrganizations requires approval for organizations in the set
+type fakeOrganizations map[string]bool
+
+func (o fakeOrganizations) GetOrganization(ctx context.Context, orgID string) (*orgModel.Organization, error) {
+	return &orgModel.Organization{ID: orgID, Name: "Acme"}, nil
+}
+
+func (o fakeOrganizations) GetOrganizationSettings(ctx context.Context, orgID string) (*orgModel.OrganizationSettings, error) {
+	return &orgModel.OrganizationSettings{OrganizationID: orgID, RequireApproval: o[orgID]}, nil
+}
+
+// fakeResolver serves TXT records from a map
+type fakeResolver map[string][]string
+
+func (r fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if records, ok := r[name]; ok {
+		return records, nil
+	}
+	return nil, fmt.Errorf("lookup %s: no such host", name)
+}
+
+type fakeActivity struct {
+	actions []string
+}
+
+func (a *fakeActivity) LogOrganizationActivity(ctx context.Context, organizationID, userID, action, resourceType, resourceID, ipAddress, userAgent string, changes map[string]interface{}) error {
+	a.actions = append(a.actions, action)
+	return nil
+}
+
+// channelEmailSender hands sent emails to the test
+type channelEmailSender chan email.SendEmailRequest
+
+func (c channelEmailSender) SendEmail(ctx context.Context, req email.SendEmailRequest) error {
+	c <- req
+	return nil
+}
+
+type testService struct {
+	*DomainService
+	repo     *fakeRepository
+	orgs     fakeOrganizations
+	resolver fakeResolver
+	activity *fakeActivity
+	sent     channelEmailSender
+}
+
+func newTestService() *testService {
+	ts := &testService{
+		repo:     newFakeRepository(),
+		orgs:     fakeOrganizations{},
+		resolver: fakeResolver{},
+		activity: &fakeActivity{},
+		sent:     make(channelEmailSender, 1),
+	}
+	ts.DomainService = NewDomainService(ts.repo, ts.orgs, ts.resolver, ts.activity, ts.sent, Config{CodeTTL: time.Hour}).(*DomainService)
+	return ts
+}
+
+// verifiedDomain adds and verifies acme.com for org-1 with a join mode
+func verifiedDomain(t *testing.T, ts *testService, joinMode string) *model.Domain {
+	t.Helper()
+	domain, err := ts.AddDomain(context.Background(), "org-1", "user-admin", &model.AddDomainRequest{Domain: "acme.com", Method: model.MethodDNS, JoinMode: joinMode}, "", "")
+	require.NoError(t, err)
+
+	ts.resolver["_ethos-challenge.acme.com"] = []string{"v=spf1 -all", model.ChallengeValuePrefix + domain.VerificationToken}
+	domain, err = ts.VerifyDomain(context.Background(), "org-1", "user-admin", domain.ID, &model.VerifyDomainRequest{}, "", "")
+	require.NoError(t, err)
+	return domain
+}
+
+func TestVerifyDomain_DNS(t *testing.T) {
+	ts := newTestService()
+
+	domain, err := ts.AddDomain(context.Background(), "org-1", "user-admin", &model.AddDomainRequest{Domain: "Acme.COM.", Method: model.MethodDNS}, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "acme.com", domain.Domain)
+	assert.Equal(t, model.JoinModeNone, domain.JoinMode)
+
+	record := domain.ToResponse().TXTRecord
+	require.NotNil(t, record)
+	assert.Equal(t, "_ethos-challenge.acme.com", record.Name)
+
+	_, err = ts.VerifyDomain(context.Background(), "org-1", "user-admin", domain.ID, &model.VerifyDomainRequest{}, "", "")
+	apiErr, ok := err.(*errors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+
+	ts.resolver[record.Name] = []string{record.Value}
+	domain, err = ts.VerifyDomain(context.Background(), "org-1", "user-admin", domain.ID, &model.VerifyDomainRequest{}, "", "")
+	require.NoError(t, err)
+	assert.True(t, domain.Verified)
+	assert.Nil(t, domain.ToResponse().TXTRecord)
+	assert.Equal(t, []string{model.ActionDomainAdded, model.ActionDomainVerified}, ts.activity.actions)
+}
+
+func TestVerifyDomain_Email(t *testing.T) {
+	ts := newTestService()
+
+	domain, err := ts.AddDomain(context.Background(), "org-1", "user-admin", &model.AddDomainRequest{Domain: "acme.com", Method: model.MethodEmail, Mailbox: "hostmaster"}, "", "")
+	require.NoError(t, err)
+	sent := <-ts.sent
+	assert.Equal(t, "hostmaster@acme.com", sent.To)
+	code := sent.TemplateData["Code"].(string)
+	assert.NotEqual(t, code, domain.VerificationToken, "code must not be stored in plain text")
+
+	_, err = ts.VerifyDomain(context.Background(), "org-1", "user-admin", domain.ID, &model.VerifyDomainRequest{Code: "WRONGCODE"}, "", "")
+	assert.Error(t, err)
+
+	domain, err = ts.VerifyDomain(context.Background(), "org-1", "user-admin", domain.ID, &model.VerifyDomainRequest{Code: code}, "", "")
+	require.NoError(t, err)
+	assert.True(t, domain.Verified)
+}
+
+func TestVerifyDomain_ExpiredCode(t *testing.T) {
+	ts := newTestService()
+
+	domain, err := ts.AddDomain(context.Background(), "org-1", "user-admin", &model.AddDomainRequest{Domain: "acme.com", Method: model.MethodEmail}, "", "")
+	require.NoError(t, err)
+	code := (<-ts.sent).TemplateData["Code"].(string)
+
+	expired := time.Now().Add(-time.Minute)
+	ts.repo.domains[domain.ID].VerificationExpiresAt = &expired
+	_, err = ts.VerifyDomain(context.Background(), "org-1", "user-admin", domain.ID, &model.VerifyDomainRequest{Code: code}, "", "")
+	assert.Error(t, err)
+	assert.False(t, ts.repo.domains[domain.ID].Verified)
+}
+
+func TestAddDomain_VerifiedElsewhere(t *testing.T) {
+	ts := newTestService()
+	verifiedDomain(t, ts, model.JoinModeNone)
+
+	_, err := ts.AddDomain(context.Background(), "org-2", "user-other", &model.AddDomainRequest{Domain: "acme.com", Method: model.MethodDNS}, "", "")
+	assert.Equal(t, errors.ErrDomainTaken, err)
+}
+
+func TestJoinByEmailDomain(t *testing.T) {
+	tests := []struct {
+		name            string
+		joinMode        string
+		requireApproval bool
+		seats           int
+		wantMember      bool
+		wantRequest     bool
+	}{
+		{name: "none", joinMode: model.JoinModeNone, seats: -1},
+		{name: "auto", joinMode: model.JoinModeAuto, seats: -1, wantMember: true},
+		{name: "request", joinMode: model.JoinModeRequest, seats: -1, wantRequest: true},
+		{name: "auto with approval required", joinMode: model.JoinModeAuto, requireApproval: true, seats: -1, wantRequest: true},
+		{name: "auto out of seats", joinMode: model.JoinModeAuto, seats: 0, wantRequest: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestService()
+			verifiedDomain(t, ts, tt.joinMode)
+			ts.orgs["org-1"] = tt.requireApproval
+			ts.repo.seats = tt.seats
+
+			require.NoError(t, ts.JoinByEmailDomain(context.Background(), "user-jane", "Jane@ACME.com"))
+
+			_, member := ts.repo.members["user-jane"]
+			assert.Equal(t, tt.wantMember, member)
+			assert.Equal(t, tt.wantRequest, len(ts.repo.requests) == 1)
+		})
+	}
+}
+
+func TestJoinByEmailDomain_UnverifiedDomain(t *testing.T) {
+	ts := newTestService()
+	_, err := ts.AddDomain(context.Background(), "org-1", "user-admin", &model.AddDomainRequest{Domain: "acme.com", Method: model.MethodDNS, JoinMode: model.JoinModeAuto}, "", "")
+	require.NoError(t, err)
+
+	require.NoError(t, ts.JoinByEmailDomain(context.Background(), "user-jane", "jane@acme.com"))
+	assert.Empty(t, ts.repo.members)
+	assert.Empty(t, ts.repo.requests)
+}
+
+func TestJoinByEmailDomain_PendingInvitation(t *testing.T) {
+	ts := newTestService()
+	verifiedDomain(t, ts, model.JoinModeAuto)
+	ts.repo.invited["jane@acme.com"] = true
+
+	require.NoError(t, ts.JoinByEmailDomain(context.Background(), "user-jane", "jane@acme.com"))
+	assert.Empty(t, ts.repo.members, "the invitation decides the role")
+}
+
+func TestApproveJoinRequest(t *testing.T) {
+	ts := newTestService()
+	verifiedDomain(t, ts, model.JoinModeRequest)
+	require.NoError(t, ts.JoinByEmailDomain(context.Background(), "user-jane", "jane@acme.com"))
+
+	request, err := ts.ApproveJoinRequest(context.Background(), "org-1", "user-admin", ts.repo.requests[0].ID, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, model.JoinRequestApproved, request.Status)
+	assert.Equal(t, "org-1", ts.repo.members["user-jane"])
+
+	_, err = ts.ApproveJoinRequest(context.Background(), "org-1", "user-admin", request.ID, "", "")
+	assert.Equal(t, errors.ErrNotFound, err)
+}
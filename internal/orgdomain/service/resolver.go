@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// NewResolver returns the resolver used for DNS verification. With no
+// server it uses the system resolver; otherwise every query goes to server
+// (host:port), such as a local stub resolver.
+func NewResolver(server string) *net.Resolver {
+	if server == "" {
+		return net.DefaultResolver
+	}
+
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, server)
+		},
+	}
+}
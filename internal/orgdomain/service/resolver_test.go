@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// serveTXT answers every TXT question on a local UDP socket with value
+func serveTXT(t *testing.T, value string) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			var query dnsmessage.Message
+			if err := query.Unpack(buf[:n]); err != nil || len(query.Questions) == 0 {
+				continue
+			}
+			question := query.Questions[0]
+			reply := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: query.ID, Response: true, Authoritative: true, RecursionDesired: query.RecursionDesired},
+				Questions: query.Questions,
+			}
+			if question.Type == dnsmessage.TypeTXT {
+				reply.Answers = []dnsmessage.Resource{{
+					Header: dnsmessage.ResourceHeader{Name: question.Name, Type: dnsmessage.TypeTXT, Class: dnsmessage.ClassINET, TTL: 60},
+					Body:   &dnsmessage.TXTResource{TXT: []string{value}},
+				}}
+			}
+			packed, err := reply.Pack()
+			if err != nil {
+				continue
+			}
+			conn.WriteTo(packed, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestNewResolver_StubServer(t *testing.T) {
+	server := serveTXT(t, "ethos-domain-verification=abc123")
+
+	records, err := NewResolver(server).LookupTXT(context.Background(), "_ethos-challenge.acme.test")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ethos-domain-verification=abc123"}, records)
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"net/http"
+
+	"ethos/internal/passkey/model"
+	"ethos/internal/passkey/service"
+	"ethos/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasskeyHandler handles WebAuthn passkey HTTP requests
+type PasskeyHandler struct {
+	service service.Service
+}
+
+// NewPasskeyHandler creates a new passkey handler
+func NewPasskeyHandler(svc service.Service) *PasskeyHandler {
+	return &PasskeyHandler{
+		service: svc,
+	}
+}
+
+// BeginRegistration handles POST /api/v1/auth/webauthn/register/begin
+func (h *PasskeyHandler) BeginRegistration(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid token",
+			"code":  "AUTH_TOKEN_INVALID",
+		})
+		return
+	}
+
+	resp, err := h.service.BeginRegistration(c.Request.Context(), userID.(string))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// FinishRegistration handles POST /api/v1/auth/webauthn/register/finish
+func (h *PasskeyHandler) FinishRegistration(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid token",
+			"code":  "AUTH_TOKEN_INVALID",
+		})
+		return
+	}
+
+	var req model.FinishRegistrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+	req.IP = c.ClientIP()
+
+	resp, err := h.service.FinishRegistration(c.Request.Context(), userID.(string), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// BeginLogin handles POST /api/v1/auth/webauthn/login/begin
+func (h *PasskeyHandler) BeginLogin(c *gin.Context) {
+	resp, err := h.service.BeginLogin(c.Request.Context())
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// FinishLogin handles POST /api/v1/auth/webauthn/login/finish
+func (h *PasskeyHandler) FinishLogin(c *gin.Context) {
+	var req model.FinishLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+	req.IP = c.ClientIP()
+	req.UserAgent = c.GetHeader("User-Agent")
+
+	resp, err := h.service.FinishLogin(c.Request.Context(), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListPasskeys handles GET /api/v1/account/passkeys
+func (h *PasskeyHandler) ListPasskeys(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid token",
+			"code":  "AUTH_TOKEN_INVALID",
+		})
+		return
+	}
+
+	passkeys, err := h.service.ListPasskeys(c.Request.Context(), userID.(string))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"passkeys": passkeys,
+		"count":    len(passkeys),
+	})
+}
+
+// DeletePasskey handles DELETE /api/v1/account/passkeys/:passkey_id
+func (h *PasskeyHandler) DeletePasskey(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid token",
+			"code":  "AUTH_TOKEN_INVALID",
+		})
+		return
+	}
+
+	if err := h.service.DeletePasskey(c.Request.Context(), userID.(string), c.Param("passkey_id"), c.ClientIP()); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Passkey removed.",
+	})
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	"ethos/pkg/webauthn"
+)
+
+// Ceremony types kept between the begin and finish steps
+const (
+	CeremonyRegistration = "registration"
+	CeremonyLogin        = "login"
+)
+
+// Passkey is a WebAuthn credential registered to a user
+type Passkey struct {
+	ID             string
+	UserID         string
+	CredentialID   []byte
+	PublicKey      []byte // COSE_Key
+	SignCount      uint32
+	AAGUID         []byte
+	Transports     []string
+	BackupEligible bool
+	BackedUp       bool
+	Name           string
+	CreatedAt      time.Time
+	LastUsedAt     *time.Time
+}
+
+// PasskeyResponse represents a passkey for API responses
+type PasskeyResponse struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	Transports     []string   `json:"transports"`
+	BackupEligible bool       `json:"backup_eligible"`
+	BackedUp       bool       `json:"backed_up"` // Synced passkeys survive the loss of a device
+	CreatedAt      time.Time  `json:"created_at"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty"`
+}
+
+// Ceremony is the server state of a registration or login between its two
+// steps, kept in Redis under its ceremony ID
+type Ceremony struct {
+	Type      string `json:"type"`
+	UserID    string `json:"user_id,omitempty"` // Registrations only
+	Challenge string `json:"challenge"`
+}
+
+// BeginRegistrationResponse carries the options for navigator.credentials.create
+type BeginRegistrationResponse struct {
+	CeremonyID string                    `json:"ceremony_id"`
+	PublicKey  *webauthn.CreationOptions `json:"public_key"`
+}
+
+// FinishRegistrationRequest represents a request to store a newly created passkey
+type FinishRegistrationRequest struct {
+	CeremonyID string                        `json:"ceremony_id" binding:"required"`
+	Name       string                        `json:"name" binding:"max=100"`
+	Credential webauthn.RegistrationResponse `json:"credential"`
+	IP         string                        `json:"-"` // Set by the handler for the security event
+}
+
+// BeginLoginResponse carries the options for navigator.credentials.get
+type BeginLoginResponse struct {
+	CeremonyID string                   `json:"ceremony_id"`
+	PublicKey  *webauthn.RequestOptions `json:"public_key"`
+}
+
+// FinishLoginRequest represents a passkey login assertion
+type FinishLoginRequest struct {
+	CeremonyID string                     `json:"ceremony_id" binding:"required"`
+	Credential webauthn.AssertionResponse `json:"credential"`
+	IP         string                     `json:"-"` // Set by the handler for the session
+	UserAgent  string                     `json:"-"` // Set by the handler for the session
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"ethos/internal/passkey/model"
+)
+
+// Repository defines the interface for passkey data access
+type Repository interface {
+	// CreatePasskey stores a new passkey, or returns ErrPasskeyAlreadyRegistered for a known credential ID
+	CreatePasskey(ctx context.Context, passkey *model.Passkey) error
+
+	// GetPasskeyByCredentialID retrieves a passkey by its WebAuthn credential ID, or ErrNotFound
+	GetPasskeyByCredentialID(ctx context.Context, credentialID []byte) (*model.Passkey, error)
+
+	// ListPasskeys lists a user's passkeys, oldest first
+	ListPasskeys(ctx context.Context, userID string) ([]*model.Passkey, error)
+
+	// UpdatePasskeyUsage records a successful login's signature counter and backup state
+	UpdatePasskeyUsage(ctx context.Context, id string, signCount uint32, backedUp bool) error
+
+	// DeletePasskey removes one of a user's passkeys, or returns ErrNotFound
+	DeletePasskey(ctx context.Context, userID, id string) error
+}
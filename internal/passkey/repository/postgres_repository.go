@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"ethos/internal/database"
+	"ethos/internal/passkey/model"
+	"ethos/pkg/errors"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const passkeyColumns = `
+	id::text, user_id, credential_id, public_key, sign_count, aaguid, transports,
+	backup_eligible, backed_up, name, created_at, last_used_at
+`
+
+// PostgresRepository implements the Repository interface using PostgreSQL
+type PostgresRepository struct {
+	db *database.DB
+}
+
+// NewPostgresRepository creates a new PostgreSQL repository
+func NewPostgresRepository(db *database.DB) Repository {
+	return &PostgresRepository{db: db}
+}
+
+// CreatePasskey stores a new passkey, or returns ErrPasskeyAlreadyRegistered for a known credential ID
+func (r *PostgresRepository) CreatePasskey(ctx context.Context, passkey *model.Passkey) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.CreatePasskey")
+	defer span.End()
+
+	query := `
+		INSERT INTO webauthn_credentials (user_id, credential_id, public_key, sign_count, aaguid, transports, backup_eligible, backed_up, name)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id::text, created_at
+	`
+
+	err := r.db.Pool.QueryRow(ctx, query,
+		passkey.UserID,
+		passkey.CredentialID,
+		passkey.PublicKey,
+		int64(passkey.SignCount),
+		passkey.AAGUID,
+		passkey.Transports,
+		passkey.BackupEligible,
+		passkey.BackedUp,
+		passkey.Name,
+	).Scan(&passkey.ID, &passkey.CreatedAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		// Check for unique constraint violation (PostgreSQL error code 23505)
+		if strings.Contains(err.Error(), "duplicate key value") || strings.Contains(err.Error(), "23505") {
+			return errors.ErrPasskeyAlreadyRegistered
+		}
+		return errors.WrapError(err, "failed to create passkey")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// GetPasskeyByCredentialID retrieves a passkey by its WebAuthn credential ID, or ErrNotFound
+func (r *PostgresRepository) GetPasskeyByCredentialID(ctx context.Context, credentialID []byte) (*model.Passkey, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetPasskeyByCredentialID")
+	defer span.End()
+
+	query := `SELECT ` + passkeyColumns + ` FROM webauthn_credentials WHERE credential_id = $1`
+
+	passkey, err := scanPasskey(r.db.Pool.QueryRow(ctx, query, credentialID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get passkey")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return passkey, nil
+}
+
+// ListPasskeys lists a user's passkeys, oldest first
+func (r *PostgresRepository) ListPasskeys(ctx context.Context, userID string) ([]*model.Passkey, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ListPasskeys")
+	defer span.End()
+
+	query := `SELECT ` + passkeyColumns + ` FROM webauthn_credentials WHERE user_id = $1 ORDER BY created_at`
+
+	rows, err := r.db.Pool.Query(ctx, query, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list passkeys")
+	}
+	defer rows.Close()
+
+	passkeys := []*model.Passkey{}
+	for rows.Next() {
+		passkey, err := scanPasskey(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, errors.WrapError(err, "failed to scan passkey")
+		}
+		passkeys = append(passkeys, passkey)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list passkeys")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return passkeys, nil
+}
+
+// UpdatePasskeyUsage records a successful login's signature counter and backup state
+func (r *PostgresRepository) UpdatePasskeyUsage(ctx context.Context, id string, signCount uint32, backedUp bool) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.UpdatePasskeyUsage")
+	defer span.End()
+
+	query := `
+		UPDATE webauthn_credentials
+		SET sign_count = $2, backed_up = $3, last_used_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Pool.Exec(ctx, query, id, int64(signCount), backedUp); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to update passkey")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// DeletePasskey removes one of a user's passkeys, or returns ErrNotFound
+func (r *PostgresRepository) DeletePasskey(ctx context.Context, userID, id string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.DeletePasskey")
+	defer span.End()
+
+	query := `DELETE FROM webauthn_credentials WHERE id::text = $1 AND user_id = $2`
+
+	result, err := r.db.Pool.Exec(ctx, query, id, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to delete passkey")
+	}
+	if result.RowsAffected() == 0 {
+		return errors.ErrNotFound
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+func scanPasskey(row pgx.Row) (*model.Passkey, error) {
+	passkey := &model.Passkey{}
+	var signCount int64
+	err := row.Scan(
+		&passkey.ID,
+		&passkey.UserID,
+		&passkey.CredentialID,
+		&passkey.PublicKey,
+		&signCount,
+		&passkey.AAGUID,
+		&passkey.Transports,
+		&passkey.BackupEligible,
+		&passkey.BackedUp,
+		&passkey.Name,
+		&passkey.CreatedAt,
+		&passkey.LastUsedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	passkey.SignCount = uint32(signCount)
+	return passkey, nil
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	authService "ethos/internal/auth/service"
+	"ethos/internal/passkey/model"
+)
+
+// Service defines the interface for WebAuthn passkeys
+type Service interface {
+	// BeginRegistration starts registering a passkey for a signed-in user
+	BeginRegistration(ctx context.Context, userID string) (*model.BeginRegistrationResponse, error)
+
+	// FinishRegistration verifies the authenticator's response and stores the passkey
+	FinishRegistration(ctx context.Context, userID string, req *model.FinishRegistrationRequest) (*model.PasskeyResponse, error)
+
+	// BeginLogin starts a passwordless login with any of the user's passkeys
+	BeginLogin(ctx context.Context) (*model.BeginLoginResponse, error)
+
+	// FinishLogin verifies a passkey assertion and issues tokens
+	FinishLogin(ctx context.Context, req *model.FinishLoginRequest) (*authService.LoginResponse, error)
+
+	// ListPasskeys lists a user's passkeys
+	ListPasskeys(ctx context.Context, userID string) ([]*model.PasskeyResponse, error)
+
+	// DeletePasskey removes one of a user's passkeys
+	DeletePasskey(ctx context.Context, userID, passkeyID, ip string) error
+}
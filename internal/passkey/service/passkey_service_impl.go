@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	authModel "ethos/internal/auth/model"
+	authService "ethos/internal/auth/service"
+	"ethos/internal/cache"
+	"ethos/internal/passkey/model"
+	"ethos/internal/passkey/repository"
+	"ethos/pkg/errors"
+	"ethos/pkg/webauthn"
+)
+
+const (
+	// ceremonyTTL is how long a user has to answer the browser's passkey prompt
+	ceremonyTTL = webauthn.Timeout * time.Millisecond
+
+	// defaultPasskeyName is used when a passkey is registered without a name
+	defaultPasskeyName = "Passkey"
+)
+
+// UserStore looks up users and records security events (implemented by the auth repository)
+type UserStore interface {
+	GetUserByID(ctx context.Context, userID string) (*authModel.User, error)
+	CreateSecurityEvent(ctx context.Context, userID, eventType, ip, location string) error
+}
+
+// LoginIssuer issues tokens for users authenticated without a password (implemented by the auth service)
+type LoginIssuer interface {
+	CompleteExternalLogin(ctx context.Context, userID, organizationID, ip, userAgent string) (*authService.LoginResponse, error)
+}
+
+// Config holds passkey settings
+type Config struct {
+	// RPID is the relying party ID, the domain passkeys are bound to
+	RPID string
+	// RPName is the name browsers show when creating a passkey
+	RPName string
+	// Origins are the frontend origins allowed to run ceremonies
+	Origins []string
+}
+
+// PasskeyService implements the Service interface
+type PasskeyService struct {
+	repo       repository.Repository
+	users      UserStore
+	logins     LoginIssuer
+	ceremonies cache.Cache
+	rp         *webauthn.RelyingParty
+}
+
+// NewPasskeyService creates a new passkey service
+func NewPasskeyService(repo repository.Repository, users UserStore, logins LoginIssuer, ceremonies cache.Cache, cfg Config) Service {
+	return &PasskeyService{
+		repo:       repo,
+		users:      users,
+		logins:     logins,
+		ceremonies: ceremonies,
+		rp: &webauthn.RelyingParty{
+			ID:      cfg.RPID,
+			Name:    cfg.RPName,
+			Origins: cfg.Origins,
+		},
+	}
+}
+
+// BeginRegistration starts registering a passkey for a signed-in user. The
+// user's existing passkeys are excluded so an authenticator isn't registered twice.
+func (s *PasskeyService) BeginRegistration(ctx context.Context, userID string) (*model.BeginRegistrationResponse, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	passkeys, err := s.repo.ListPasskeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make([]webauthn.CredentialDescriptor, 0, len(passkeys))
+	for _, passkey := range passkeys {
+		existing = append(existing, webauthn.Descriptor(passkey.CredentialID, passkey.Transports))
+	}
+
+	ceremonyID, challenge, err := s.startCeremony(ctx, &model.Ceremony{Type: model.CeremonyRegistration, UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	// The user handle is the user ID, which passkey logins map back to the account
+	displayName := strings.TrimSpace(user.FirstName + " " + user.LastName)
+	if displayName == "" {
+		displayName = user.Email
+	}
+	options := s.rp.CreationOptions(challenge, webauthn.User{
+		ID:          []byte(user.ID),
+		Name:        user.Email,
+		DisplayName: displayName,
+	}, existing)
+
+	return &model.BeginRegistrationResponse{CeremonyID: ceremonyID, PublicKey: options}, nil
+}
+
+// FinishRegistration verifies the authenticator's response and stores the passkey
+func (s *PasskeyService) FinishRegistration(ctx context.Context, userID string, req *model.FinishRegistrationRequest) (*model.PasskeyResponse, error) {
+	ceremony, err := s.takeCeremony(ctx, req.CeremonyID)
+	if err != nil || ceremony.Type != model.CeremonyRegistration || ceremony.UserID != userID {
+		return nil, errors.ErrPasskeyRegistrationFailed
+	}
+
+	credential, err := s.rp.VerifyRegistration(ceremony.Challenge, &req.Credential)
+	if err != nil {
+		fmt.Printf("Passkey registration rejected for user %s: %v\n", userID, err)
+		return nil, errors.ErrPasskeyRegistrationFailed
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = defaultPasskeyName
+	}
+	transports := credential.Transports
+	if transports == nil {
+		transports = []string{}
+	}
+
+	passkey := &model.Passkey{
+		UserID:         userID,
+		CredentialID:   credential.ID,
+		PublicKey:      credential.PublicKey,
+		SignCount:      credential.SignCount,
+		AAGUID:         credential.AAGUID,
+		Transports:     transports,
+		BackupEligible: credential.BackupEligible,
+		BackedUp:       credential.BackedUp,
+		Name:           name,
+	}
+	if err := s.repo.CreatePasskey(ctx, passkey); err != nil {
+		return nil, err
+	}
+
+	if err := s.users.CreateSecurityEvent(ctx, userID, authModel.SecurityEventPasskeyAdded, req.IP, ""); err != nil {
+		fmt.Printf("Failed to record passkey security event for user %s: %v\n", userID, err)
+	}
+
+	return toResponse(passkey), nil
+}
+
+// BeginLogin starts a passwordless login. No account is named up front: the
+// authenticator offers its discoverable passkeys and the chosen one
+// identifies the user.
+func (s *PasskeyService) BeginLogin(ctx context.Context) (*model.BeginLoginResponse, error) {
+	ceremonyID, challenge, err := s.startCeremony(ctx, &model.Ceremony{Type: model.CeremonyLogin})
+	if err != nil {
+		return nil, err
+	}
+	return &model.BeginLoginResponse{CeremonyID: ceremonyID, PublicKey: s.rp.RequestOptions(challenge)}, nil
+}
+
+// FinishLogin verifies a passkey assertion and issues the same tokens as a
+// password login. The passkey verified the user, so no second factor is asked for.
+func (s *PasskeyService) FinishLogin(ctx context.Context, req *model.FinishLoginRequest) (*authService.LoginResponse, error) {
+	ceremony, err := s.takeCeremony(ctx, req.CeremonyID)
+	if err != nil || ceremony.Type != model.CeremonyLogin {
+		return nil, errors.ErrPasskeyLoginFailed
+	}
+
+	credentialID, err := req.Credential.CredentialID()
+	if err != nil {
+		return nil, errors.ErrPasskeyLoginFailed
+	}
+	passkey, err := s.repo.GetPasskeyByCredentialID(ctx, credentialID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrPasskeyLoginFailed
+		}
+		return nil, err
+	}
+
+	assertion, err := s.rp.VerifyAssertion(ceremony.Challenge, &req.Credential, passkey.PublicKey, passkey.SignCount)
+	if err != nil {
+		fmt.Printf("Passkey login rejected for user %s: %v\n", passkey.UserID, err)
+		if err == webauthn.ErrSignCountRegression {
+			if err := s.users.CreateSecurityEvent(ctx, passkey.UserID, authModel.SecurityEventPasskeyCloned, req.IP, ""); err != nil {
+				fmt.Printf("Failed to record passkey security event for user %s: %v\n", passkey.UserID, err)
+			}
+		}
+		return nil, errors.ErrPasskeyLoginFailed
+	}
+	if string(assertion.UserHandle) != passkey.UserID {
+		return nil, errors.ErrPasskeyLoginFailed
+	}
+
+	if err := s.repo.UpdatePasskeyUsage(ctx, passkey.ID, assertion.SignCount, assertion.BackedUp); err != nil {
+		return nil, err
+	}
+
+	return s.logins.CompleteExternalLogin(ctx, passkey.UserID, "", req.IP, req.UserAgent)
+}
+
+// ListPasskeys lists a user's passkeys
+func (s *PasskeyService) ListPasskeys(ctx context.Context, userID string) ([]*model.PasskeyResponse, error) {
+	passkeys, err := s.repo.ListPasskeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*model.PasskeyResponse, 0, len(passkeys))
+	for _, passkey := range passkeys {
+		responses = append(responses, toResponse(passkey))
+	}
+	return responses, nil
+}
+
+// DeletePasskey removes one of a user's passkeys
+func (s *PasskeyService) DeletePasskey(ctx context.Context, userID, passkeyID, ip string) error {
+	if err := s.repo.DeletePasskey(ctx, userID, passkeyID); err != nil {
+		return err
+	}
+
+	if err := s.users.CreateSecurityEvent(ctx, userID, authModel.SecurityEventPasskeyRemoved, ip, ""); err != nil {
+		fmt.Printf("Failed to record passkey security event for user %s: %v\n", userID, err)
+	}
+	return nil
+}
+
+// startCeremony saves a ceremony under a new ID with a fresh challenge
+func (s *PasskeyService) startCeremony(ctx context.Context, ceremony *model.Ceremony) (string, string, error) {
+	ceremonyID, err := webauthn.NewChallenge()
+	if err != nil {
+		return "", "", errors.WrapError(err, "failed to generate ceremony ID")
+	}
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return "", "", errors.WrapError(err, "failed to generate challenge")
+	}
+	ceremony.Challenge = challenge
+
+	data, err := json.Marshal(ceremony)
+	if err != nil {
+		return "", "", errors.WrapError(err, "failed to encode ceremony")
+	}
+	if err := s.ceremonies.Set(ctx, ceremonyKey(ceremonyID), string(data), ceremonyTTL); err != nil {
+		return "", "", errors.WrapError(err, "failed to save ceremony")
+	}
+	return ceremonyID, challenge, nil
+}
+
+// takeCeremony loads and deletes a ceremony, so each challenge is answered once
+func (s *PasskeyService) takeCeremony(ctx context.Context, ceremonyID string) (*model.Ceremony, error) {
+	data, err := s.ceremonies.Get(ctx, ceremonyKey(ceremonyID))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ceremonies.Delete(ctx, ceremonyKey(ceremonyID)); err != nil {
+		return nil, err
+	}
+
+	var ceremony model.Ceremony
+	if err := json.Unmarshal([]byte(data), &ceremony); err != nil {
+		return nil, err
+	}
+	return &ceremony, nil
+}
+
+func ceremonyKey(ceremonyID string) string {
+	return "webauthn:ceremony:" + ceremonyID
+}
+
+func toResponse(passkey *model.Passkey) *model.PasskeyResponse {
+	return &model.PasskeyResponse{
+		ID:             passkey.ID,
+		Name:           passkey.Name,
+		Transports:     passkey.Transports,
+		BackupEligible: passkey.BackupEligible,
+		BackedUp:       passkey.BackedUp,
+		CreatedAt:      passkey.CreatedAt,
+		LastUsedAt:     passkey.LastUsedAt,
+	}
+}
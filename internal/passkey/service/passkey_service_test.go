@@ -0,0 +1,323 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	authModel "ethos/internal/auth/model"
+	authService "ethos/internal/auth/service"
+	"ethos/internal/passkey/model"
+	"ethos/pkg/errors"
+	"ethos/pkg/webauthn"
+	"ethos/pkg/webauthn/webauthntest"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testUserID = "user-1"
+	testOrigin = "https://app.example.com"
+)
+
+type fakeRepository struct {
+	passkeys []*model.Passkey
+}
+
+func (r *fakeRepository) CreatePasskey(ctx context.Context, passkey *model.Passkey) error {
+	for _, existing := range r.passkeys {
+		if bytes.Equal(existing.CredentialID, passkey.CredentialID) {
+			return errors.ErrPasskeyAlreadyRegistered
+		}
+	}
+	passkey.ID = fmt.Sprintf("passkey-%d", len(r.passkeys)+1)
+	passkey.CreatedAt = time.Now()
+	copied := *passkey
+	r.passkeys = append(r.passkeys, &copied)
+	return nil
+}
+
+func (r *fakeRepository) GetPasskeyByCredentialID(ctx context.Context, credentialID []byte) (*model.Passkey, error) {
+	for _, passkey := range r.passkeys {
+		if bytes.Equal(passkey.CredentialID, credentialID) {
+			copied := *passkey
+			return &copied, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (r *fakeRepository) ListPasskeys(ctx context.Context, userID string) ([]*model.Passkey, error) {
+	passkeys := []*model.Passkey{}
+	for _, passkey := range r.passkeys {
+		if passkey.UserID == userID {
+			copied := *passkey
+			passkeys = append(passkeys, &copied)
+		}
+	}
+	return passkeys, nil
+}
+
+func (r *fakeRepository) UpdatePasskeyUsage(ctx context.Context, id string, signCount uint32, backedUp bool) error {
+	for _, passkey := range r.passkeys {
+		if passkey.ID == id {
+			now := time.Now()
+			passkey.SignCount = signCount
+			passkey.BackedUp = backedUp
+			passkey.LastUsedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *fakeRepository) DeletePasskey(ctx context.Context, userID, id string) error {
+	for i, passkey := range r.passkeys {
+		if passkey.ID == id && passkey.UserID == userID {
+			r.passkeys = append(r.passkeys[:i], r.passkeys[i+1:]...)
+			return nil
+		}
+	}
+	return errors.ErrNotFound
+}
+
+type fakeUsers struct {
+	users  map[string]*authModel.User
+	events []string
+}
+
+func (u *fakeUsers) GetUserByID(ctx context.Context, userID string) (*authModel.User, error) {
+	user, ok := u.users[userID]
+	if !ok {
+		return nil, errors.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (u *fakeUsers) CreateSecurityEvent(ctx context.Context, userID, eventType, ip, location string) error {
+	u.events = append(u.events, eventType)
+	return nil
+}
+
+type fakeLogins struct {
+	userID string
+}
+
+func (l *fakeLogins) CompleteExternalLogin(ctx context.Context, userID, organizationID, ip, userAgent string) (*authService.LoginResponse, error) {
+	l.userID = userID
+	return &authService.LoginResponse{AccessToken: "access-" + userID, RefreshToken: "refresh-" + userID}, nil
+}
+
+// memoryCache implements cache.Cache for ceremonies
+type memoryCache struct {
+	values map[string]string
+}
+
+func (m *memoryCache) Get(ctx context.Context, key string) (string, error) {
+	value, ok := m.values[key]
+	if !ok {
+		return "", redis.Nil
+	}
+	return value, nil
+}
+
+func (m *memoryCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	m.values[key] = value.(string)
+	return nil
+}
+
+func (m *memoryCache) Delete(ctx context.Context, key string) error {
+	delete(m.values, key)
+	return nil
+}
+
+func (m *memoryCache) Exists(ctx context.Context, key string) bool {
+	_, ok := m.values[key]
+	return ok
+}
+
+func (m *memoryCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return nil
+}
+
+func (m *memoryCache) Incr(ctx context.Context, key string) (int64, error) {
+	return 0, nil
+}
+
+func (m *memoryCache) FlushAll(ctx context.Context) error {
+	m.values = map[string]string{}
+	return nil
+}
+
+func (m *memoryCache) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+func (m *memoryCache) Close() error {
+	return nil
+}
+
+type passkeyTestEnv struct {
+	service       *PasskeyService
+	repo          *fakeRepository
+	users         *fakeUsers
+	logins        *fakeLogins
+	authenticator *webauthntest.Authenticator
+}
+
+func newPasskeyTestEnv() *passkeyTestEnv {
+	env := &passkeyTestEnv{
+		repo: &fakeRepository{},
+		users: &fakeUsers{users: map[string]*authModel.User{
+			testUserID: {ID: testUserID, Email: "jane@example.com", FirstName: "Jane", LastName: "Doe"},
+		}},
+		logins:        &fakeLogins{},
+		authenticator: webauthntest.NewAuthenticator(testOrigin),
+	}
+	env.service = NewPasskeyService(env.repo, env.users, env.logins, &memoryCache{values: map[string]string{}}, Config{
+		RPID:    "example.com",
+		RPName:  "Ethos",
+		Origins: []string{testOrigin},
+	}).(*PasskeyService)
+	return env
+}
+
+// register runs a registration ceremony for the test user
+func (env *passkeyTestEnv) register(t *testing.T) *model.PasskeyResponse {
+	t.Helper()
+	ctx := context.Background()
+	begin, err := env.service.BeginRegistration(ctx, testUserID)
+	require.NoError(t, err)
+
+	credential, err := env.authenticator.Register(begin.PublicKey)
+	require.NoError(t, err)
+
+	passkey, err := env.service.FinishRegistration(ctx, testUserID, &model.FinishRegistrationRequest{
+		CeremonyID: begin.CeremonyID,
+		Name:       "Laptop",
+		Credential: *credential,
+	})
+	require.NoError(t, err)
+	return passkey
+}
+
+// loginRequest runs the browser side of a login ceremony
+func (env *passkeyTestEnv) loginRequest(t *testing.T) *model.FinishLoginRequest {
+	t.Helper()
+	begin, err := env.service.BeginLogin(context.Background())
+	require.NoError(t, err)
+
+	assertion, err := env.authenticator.Assert(begin.PublicKey)
+	require.NoError(t, err)
+	return &model.FinishLoginRequest{CeremonyID: begin.CeremonyID, Credential: *assertion}
+}
+
+func TestPasskeyService_RegisterAndLogin(t *testing.T) {
+	env := newPasskeyTestEnv()
+	ctx := context.Background()
+
+	passkey := env.register(t)
+	assert.Equal(t, "Laptop", passkey.Name)
+	assert.Equal(t, []string{"internal"}, passkey.Transports)
+	assert.Equal(t, []string{authModel.SecurityEventPasskeyAdded}, env.users.events)
+
+	resp, err := env.service.FinishLogin(ctx, env.loginRequest(t))
+	require.NoError(t, err)
+	assert.Equal(t, "access-"+testUserID, resp.AccessToken)
+	assert.Equal(t, testUserID, env.logins.userID)
+
+	passkeys, err := env.service.ListPasskeys(ctx, testUserID)
+	require.NoError(t, err)
+	require.Len(t, passkeys, 1)
+	assert.NotNil(t, passkeys[0].LastUsedAt)
+	assert.Equal(t, uint32(1), env.repo.passkeys[0].SignCount)
+}
+
+func TestPasskeyService_BeginRegistration_ExcludesExistingPasskeys(t *testing.T) {
+	env := newPasskeyTestEnv()
+	env.register(t)
+
+	begin, err := env.service.BeginRegistration(context.Background(), testUserID)
+	require.NoError(t, err)
+	require.Len(t, begin.PublicKey.ExcludeCredentials, 1)
+	userHandle, err := webauthn.DecodeID(begin.PublicKey.User.ID)
+	require.NoError(t, err)
+	assert.Equal(t, testUserID, string(userHandle))
+
+	_, err = env.authenticator.Register(begin.PublicKey)
+	assert.Error(t, err, "authenticator must refuse an excluded credential")
+}
+
+func TestPasskeyService_FinishRegistration_RejectsOtherUsersCeremony(t *testing.T) {
+	env := newPasskeyTestEnv()
+	ctx := context.Background()
+
+	begin, err := env.service.BeginRegistration(ctx, testUserID)
+	require.NoError(t, err)
+	credential, err := env.authenticator.Register(begin.PublicKey)
+	require.NoError(t, err)
+
+	_, err = env.service.FinishRegistration(ctx, "user-2", &model.FinishRegistrationRequest{CeremonyID: begin.CeremonyID, Credential: *credential})
+	assert.Equal(t, errors.ErrPasskeyRegistrationFailed, err)
+	assert.Empty(t, env.repo.passkeys)
+}
+
+func TestPasskeyService_FinishLogin_Rejections(t *testing.T) {
+	t.Run("ceremony replay", func(t *testing.T) {
+		env := newPasskeyTestEnv()
+		env.register(t)
+		req := env.loginRequest(t)
+
+		_, err := env.service.FinishLogin(context.Background(), req)
+		require.NoError(t, err)
+		_, err = env.service.FinishLogin(context.Background(), req)
+		assert.Equal(t, errors.ErrPasskeyLoginFailed, err)
+	})
+
+	t.Run("unknown credential", func(t *testing.T) {
+		env := newPasskeyTestEnv()
+		env.register(t)
+		env.repo.passkeys = nil
+
+		_, err := env.service.FinishLogin(context.Background(), env.loginRequest(t))
+		assert.Equal(t, errors.ErrPasskeyLoginFailed, err)
+		assert.Empty(t, env.logins.userID)
+	})
+
+	t.Run("user handle of another account", func(t *testing.T) {
+		env := newPasskeyTestEnv()
+		env.register(t)
+		env.repo.passkeys[0].UserID = "user-2"
+
+		_, err := env.service.FinishLogin(context.Background(), env.loginRequest(t))
+		assert.Equal(t, errors.ErrPasskeyLoginFailed, err)
+		assert.Empty(t, env.logins.userID)
+	})
+
+	t.Run("cloned authenticator", func(t *testing.T) {
+		env := newPasskeyTestEnv()
+		env.register(t)
+		env.repo.passkeys[0].SignCount = 10
+
+		_, err := env.service.FinishLogin(context.Background(), env.loginRequest(t))
+		assert.Equal(t, errors.ErrPasskeyLoginFailed, err)
+		assert.Contains(t, env.users.events, authModel.SecurityEventPasskeyCloned)
+		assert.Equal(t, uint32(10), env.repo.passkeys[0].SignCount)
+	})
+}
+
+func TestPasskeyService_DeletePasskey(t *testing.T) {
+	env := newPasskeyTestEnv()
+	ctx := context.Background()
+	passkey := env.register(t)
+
+	assert.Equal(t, errors.ErrNotFound, env.service.DeletePasskey(ctx, "user-2", passkey.ID, "127.0.0.1"))
+	require.NoError(t, env.service.DeletePasskey(ctx, testUserID, passkey.ID, "127.0.0.1"))
+	assert.Contains(t, env.users.events, authModel.SecurityEventPasskeyRemoved)
+
+	_, err := env.service.FinishLogin(ctx, env.loginRequest(t))
+	assert.Equal(t, errors.ErrPasskeyLoginFailed, err)
+}
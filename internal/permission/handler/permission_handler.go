@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"net/http"
+
+	"ethos/internal/permission/model"
+	"ethos/internal/permission/service"
+	"ethos/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PermissionHandler handles organization role HTTP requests
+type PermissionHandler struct {
+	service service.Service
+}
+
+// NewPermissionHandler creates a new permission handler
+func NewPermissionHandler(svc service.Service) *PermissionHandler {
+	return &PermissionHandler{
+		service: svc,
+	}
+}
+
+// ListRoles handles GET /api/v1/organizations/:org_id/roles
+// The response also lists the permissions custom roles can grant
+func (h *PermissionHandler) ListRoles(c *gin.Context) {
+	roles, err := h.service.ListRoles(c.Request.Context(), c.Param("org_id"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"roles":       roles,
+		"count":       len(roles),
+		"permissions": h.service.ListPermissions(),
+	})
+}
+
+// CreateRole handles POST /api/v1/organizations/:org_id/roles
+func (h *PermissionHandler) CreateRole(c *gin.Context) {
+	var req model.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	role, err := h.service.CreateRole(c.Request.Context(), actor(c), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, role)
+}
+
+// UpdateRole handles PUT /api/v1/organizations/:org_id/roles/:role_id
+func (h *PermissionHandler) UpdateRole(c *gin.Context) {
+	var req model.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	role, err := h.service.UpdateRole(c.Request.Context(), actor(c), c.Param("role_id"), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, role)
+}
+
+// DeleteRole handles DELETE /api/v1/organizations/:org_id/roles/:role_id
+func (h *PermissionHandler) DeleteRole(c *gin.Context) {
+	if err := h.service.DeleteRole(c.Request.Context(), c.Param("org_id"), c.Param("role_id")); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Role deleted.",
+	})
+}
+
+// AssignRole handles PUT /api/v1/organizations/:org_id/members/:user_id
+func (h *PermissionHandler) AssignRole(c *gin.Context) {
+	var req model.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	if err := h.service.AssignRole(c.Request.Context(), actor(c), c.Param("user_id"), &req); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Role assigned.",
+	})
+}
+
+// ListRoleGrants handles GET /api/v1/organizations/:org_id/role-grants
+func (h *PermissionHandler) ListRoleGrants(c *gin.Context) {
+	grants, err := h.service.ListRoleGrants(c.Request.Context(), c.Param("org_id"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"grants": grants,
+		"count":  len(grants),
+	})
+}
+
+// GrantRole handles POST /api/v1/organizations/:org_id/role-grants
+func (h *PermissionHandler) GrantRole(c *gin.Context) {
+	var req model.GrantRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+	req.IP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	grant, err := h.service.GrantRole(c.Request.Context(), actor(c), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, grant)
+}
+
+// RevokeRoleGrant handles DELETE /api/v1/organizations/:org_id/role-grants/:grant_id
+func (h *PermissionHandler) RevokeRoleGrant(c *gin.Context) {
+	err := h.service.RevokeRoleGrant(c.Request.Context(), actor(c), c.Param("grant_id"), c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Role grant revoked.",
+	})
+}
+
+// actor is the user making the request, in the organization from the URL
+func actor(c *gin.Context) model.Subject {
+	return model.Subject{
+		UserID:           c.GetString("user_id"),
+		OrganizationID:   c.Param("org_id"),
+		OrganizationRole: c.GetString("user_role_in_org"),
+	}
+}
@@ -0,0 +1,250 @@
+package model
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Permissions are dotted strings of the form area.resource.action. A grant
+// ending in ".*" covers everything below it, and "*" covers everything.
+const (
+	PermissionOrgDelete               = "org.delete"
+	PermissionOrgSettingsWrite        = "org.settings.write"
+	PermissionOrgMembersWrite         = "org.members.write"
+	PermissionOrgRolesWrite           = "org.roles.write"
+	PermissionOrgRolesDelegate        = "org.roles.delegate"
+	PermissionOrgSSOManage            = "org.sso.manage"
+	PermissionOrgAPIKeysManage        = "org.api_keys.manage"
+	PermissionModerationAppealsRead   = "moderation.appeals.read"
+	PermissionModerationAppealsReview = "moderation.appeals.review"
+	PermissionModerationActionsRead   = "moderation.actions.read"
+	PermissionModerationHistoryRead   = "moderation.history.read"
+	PermissionFeedbackTemplatesManage = "feedback.templates.manage"
+)
+
+// KnownPermissions lists every permission a route can require, with a short description
+var KnownPermissions = map[string]string{
+	PermissionOrgDelete:               "Delete the organization (owners only)",
+	PermissionOrgSettingsWrite:        "Change organization settings",
+	PermissionOrgMembersWrite:         "Add and remove members and change their roles",
+	PermissionOrgRolesWrite:           "Create, edit and delete custom roles",
+	PermissionOrgRolesDelegate:        "Grant roles to members for a limited time",
+	PermissionOrgSSOManage:            "Configure single sign-on",
+	PermissionOrgAPIKeysManage:        "Create and revoke organization API keys",
+	PermissionModerationAppealsRead:   "List moderation appeals",
+	PermissionModerationAppealsReview: "Review moderation appeals and their context",
+	PermissionModerationActionsRead:   "List moderation actions",
+	PermissionModerationHistoryRead:   "View a member's moderation history",
+	PermissionFeedbackTemplatesManage: "Create, edit and archive feedback templates and review template suggestions",
+}
+
+// BuiltinRoles are the organization roles every organization has. They can't
+// be edited, and custom roles can't reuse their names.
+var BuiltinRoles = map[string]*Role{
+	"owner": {
+		Name:        "owner",
+		Description: "Full control of the organization",
+		Permissions: []string{"*"},
+		Builtin:     true,
+	},
+	"admin": {
+		Name:        "admin",
+		Description: "Manages the organization and its members",
+		Permissions: []string{"org.*", "moderation.*"},
+		Builtin:     true,
+	},
+	"moderator": {
+		Name:        "moderator",
+		Description: "Reviews appeals and moderation history",
+		Permissions: []string{"moderation.*"},
+		Builtin:     true,
+	},
+	"member": {
+		Name:        "member",
+		Description: "Regular member",
+		Permissions: []string{},
+		Builtin:     true,
+	},
+	"user": {
+		Name:        "user",
+		Description: "Regular member",
+		Permissions: []string{},
+		Builtin:     true,
+	},
+	"viewer": {
+		Name:        "viewer",
+		Description: "Read-only member",
+		Permissions: []string{},
+		Builtin:     true,
+	},
+}
+
+// ownerOnlyPermissions are covered by "*" alone: no wildcard below it covers
+// them and custom roles can't grant them, so only owners hold them
+var ownerOnlyPermissions = map[string]bool{
+	PermissionOrgDelete: true,
+}
+
+// Grants reports whether a single granted permission covers the required one
+func Grants(granted, required string) bool {
+	if granted == "*" {
+		return true
+	}
+	if ownerOnlyPermissions[required] {
+		return false
+	}
+	if granted == required {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(granted, ".*"); ok {
+		return strings.HasPrefix(required, prefix+".")
+	}
+	return false
+}
+
+// IsValidGrant reports whether a permission may be granted to a role: either
+// a known permission or a wildcard covering at least one of them
+func IsValidGrant(granted string) bool {
+	for permission := range KnownPermissions {
+		if Grants(granted, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// GrantedPermissions lists the permissions set to true in a permissions
+// document, the format shared by user_roles and organization_roles
+func GrantedPermissions(permissions map[string]interface{}) []string {
+	granted := []string{}
+	for permission, value := range permissions {
+		if enabled, ok := value.(bool); ok && enabled {
+			granted = append(granted, permission)
+		}
+	}
+	sort.Strings(granted)
+	return granted
+}
+
+// Role is a named set of permissions within an organization
+type Role struct {
+	ID             string     `json:"id,omitempty"`
+	OrganizationID string     `json:"organization_id,omitempty"`
+	Name           string     `json:"name"`
+	Description    string     `json:"description"`
+	Permissions    []string   `json:"permissions"`
+	Builtin        bool       `json:"builtin"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+// Grants reports whether the role grants the permission, and through which grant
+func (r *Role) Grants(permission string) (string, bool) {
+	for _, granted := range r.Permissions {
+		if Grants(granted, permission) {
+			return granted, true
+		}
+	}
+	return "", false
+}
+
+// Subject is who a permission is checked for
+type Subject struct {
+	UserID string
+	// OrganizationID is empty for routes outside an organization, where
+	// only platform roles apply
+	OrganizationID   string
+	OrganizationRole string
+}
+
+// Decision is the outcome of a permission check with the reason for it
+type Decision struct {
+	Allowed    bool   `json:"allowed"`
+	Permission string `json:"permission"`
+	Reason     string `json:"reason"`
+}
+
+// CreateRoleRequest represents a request to create a custom organization role
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required,min=1,max=50"`
+	Description string   `json:"description" binding:"max=500"`
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// UpdateRoleRequest represents a request to edit a custom role. Roles can't
+// be renamed, since members refer to them by name.
+type UpdateRoleRequest struct {
+	Description *string  `json:"description" binding:"omitempty,max=500"`
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// AssignRoleRequest represents a request to give a member a built-in or custom role
+type AssignRoleRequest struct {
+	Role string `json:"role" binding:"required,min=1,max=50"`
+}
+
+// PermissionInfo describes a permission for clients building role editors
+type PermissionInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Role grant statuses
+const (
+	GrantStatusScheduled = "scheduled"
+	GrantStatusActive    = "active"
+	GrantStatusExpired   = "expired"
+	GrantStatusRevoked   = "revoked"
+)
+
+// RoleGrant gives a member an extra role between StartsAt and EndsAt, for
+// example a temporary moderator during a review cycle
+type RoleGrant struct {
+	ID             string     `json:"id"`
+	OrganizationID string     `json:"organization_id"`
+	UserID         string     `json:"user_id"`
+	Role           string     `json:"role"`
+	Reason         string     `json:"reason,omitempty"`
+	GrantedBy      string     `json:"granted_by,omitempty"`
+	StartsAt       time.Time  `json:"starts_at"`
+	EndsAt         time.Time  `json:"ends_at"`
+	ExpiredAt      *time.Time `json:"expired_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	RevokedBy      string     `json:"revoked_by,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Status         string     `json:"status"`
+}
+
+// StatusAt returns the grant's status at a point in time
+func (g *RoleGrant) StatusAt(now time.Time) string {
+	switch {
+	case g.RevokedAt != nil:
+		return GrantStatusRevoked
+	case g.ExpiredAt != nil || !now.Before(g.EndsAt):
+		return GrantStatusExpired
+	case now.Before(g.StartsAt):
+		return GrantStatusScheduled
+	default:
+		return GrantStatusActive
+	}
+}
+
+// PlatformRoleExpiry identifies a platform role assignment that is expiring or has expired
+type PlatformRoleExpiry struct {
+	UserID    string
+	Role      string
+	ExpiresAt time.Time
+}
+
+// GrantRoleRequest represents a request to grant a member a role for a limited time
+type GrantRoleRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	Role   string `json:"role" binding:"required,min=1,max=50"`
+	Reason string `json:"reason" binding:"max=500"`
+	// StartsAt defaults to now
+	StartsAt  *time.Time `json:"starts_at"`
+	EndsAt    time.Time  `json:"ends_at" binding:"required"`
+	IP        string     `json:"-"`
+	UserAgent string     `json:"-"`
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	authModel "ethos/internal/auth/model"
+	"ethos/internal/permission/model"
+)
+
+// Repository defines the interface for roles and permission data access
+type Repository interface {
+	// GetPlatformRoles returns the user's active, unexpired platform roles with their permissions
+	GetPlatformRoles(ctx context.Context, userID string) ([]authModel.UserRole, error)
+
+	// ListOrganizationRoles lists an organization's custom roles by name
+	ListOrganizationRoles(ctx context.Context, organizationID string) ([]*model.Role, error)
+
+	// GetOrganizationRole retrieves a custom role by name, or ErrNotFound
+	GetOrganizationRole(ctx context.Context, organizationID, name string) (*model.Role, error)
+
+	// GetOrganizationRoleByID retrieves a custom role by ID, or ErrNotFound
+	GetOrganizationRoleByID(ctx context.Context, organizationID, id string) (*model.Role, error)
+
+	// CreateOrganizationRole stores a new custom role
+	CreateOrganizationRole(ctx context.Context, role *model.Role) error
+
+	// UpdateOrganizationRole updates a custom role's description and permissions
+	UpdateOrganizationRole(ctx context.Context, role *model.Role) error
+
+	// DeleteOrganizationRole deletes a custom role, or returns ErrNotFound
+	DeleteOrganizationRole(ctx context.Context, organizationID, id string) error
+
+	// CountMembersWithRole counts the organization's members holding a role
+	CountMembersWithRole(ctx context.Context, organizationID, name string) (int, error)
+
+	// SetMemberRole changes a member's role, or returns ErrNotFound if they aren't a member
+	SetMemberRole(ctx context.Context, organizationID, userID, role string) error
+
+	// GetMemberRole returns a member's organization role, or ErrNotFound if they aren't a member
+	GetMemberRole(ctx context.Context, organizationID, userID string) (string, error)
+
+	// CreateRoleGrant stores a new time-bound role grant
+	CreateRoleGrant(ctx context.Context, grant *model.RoleGrant) error
+
+	// GetRoleGrant retrieves a role grant by ID, or ErrNotFound
+	GetRoleGrant(ctx context.Context, organizationID, id string) (*model.RoleGrant, error)
+
+	// ListRoleGrants lists an organization's grants that haven't expired or been revoked, by end time
+	ListRoleGrants(ctx context.Context, organizationID string) ([]*model.RoleGrant, error)
+
+	// ListActiveRoleGrants lists a member's grants in effect right now
+	ListActiveRoleGrants(ctx context.Context, organizationID, userID string) ([]*model.RoleGrant, error)
+
+	// RevokeRoleGrant ends a grant early, or returns ErrNotFound if it's no longer open
+	RevokeRoleGrant(ctx context.Context, organizationID, id, revokedBy string) (*model.RoleGrant, error)
+
+	// ExpireRoleGrants marks grants whose end time has passed as expired and returns them
+	ExpireRoleGrants(ctx context.Context) ([]*model.RoleGrant, error)
+
+	// ClaimExpiringRoleGrants returns open grants ending before the given time
+	// that haven't had an expiry notice, marking them as notified
+	ClaimExpiringRoleGrants(ctx context.Context, before time.Time) ([]*model.RoleGrant, error)
+
+	// DeactivateExpiredPlatformRoles deactivates platform role assignments
+	// whose expiry has passed and returns them
+	DeactivateExpiredPlatformRoles(ctx context.Context) ([]model.PlatformRoleExpiry, error)
+
+	// ClaimExpiringPlatformRoles returns active platform role assignments
+	// expiring before the given time that haven't had an expiry notice, marking them as notified
+	ClaimExpiringPlatformRoles(ctx context.Context, before time.Time) ([]model.PlatformRoleExpiry, error)
+}
@@ -0,0 +1,315 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	authModel "ethos/internal/auth/model"
+	"ethos/internal/database"
+	"ethos/internal/permission/model"
+	"ethos/pkg/errors"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const roleColumns = `id::text, organization_id::text, name, COALESCE(description, ''), permissions, created_at, updated_at`
+
+// PostgresRepository implements the Repository interface using PostgreSQL
+type PostgresRepository struct {
+	db *database.DB
+}
+
+// NewPostgresRepository creates a new PostgreSQL repository
+func NewPostgresRepository(db *database.DB) Repository {
+	return &PostgresRepository{db: db}
+}
+
+// GetPlatformRoles returns the user's active, unexpired platform roles with their permissions
+func (r *PostgresRepository) GetPlatformRoles(ctx context.Context, userID string) ([]authModel.UserRole, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetPlatformRoles")
+	defer span.End()
+
+	query := `
+		SELECT ur.id::text, ur.name, COALESCE(ur.description, ''), COALESCE(ur.permissions, '{}'),
+		       ura.assigned_at, ura.expires_at
+		FROM user_role_assignments ura
+		JOIN user_roles ur ON ur.id = ura.role_id
+		WHERE ura.user_id = $1
+		AND ura.is_active = true
+		AND (ura.expires_at IS NULL OR ura.expires_at > NOW())
+		ORDER BY ur.name
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get platform roles")
+	}
+	defer rows.Close()
+
+	var roles []authModel.UserRole
+	for rows.Next() {
+		role := authModel.UserRole{IsActive: true}
+		var permissions []byte
+		if err := rows.Scan(&role.ID, &role.Name, &role.Description, &permissions, &role.AssignedAt, &role.ExpiresAt); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, errors.WrapError(err, "failed to scan platform role")
+		}
+		if err := json.Unmarshal(permissions, &role.Permissions); err != nil {
+			return nil, errors.WrapError(err, "failed to decode role permissions")
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get platform roles")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return roles, nil
+}
+
+// ListOrganizationRoles lists an organization's custom roles by name
+func (r *PostgresRepository) ListOrganizationRoles(ctx context.Context, organizationID string) ([]*model.Role, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ListOrganizationRoles")
+	defer span.End()
+
+	query := `SELECT ` + roleColumns + ` FROM organization_roles WHERE organization_id::text = $1 ORDER BY name`
+
+	rows, err := r.db.Pool.Query(ctx, query, organizationID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list organization roles")
+	}
+	defer rows.Close()
+
+	roles := []*model.Role{}
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, errors.WrapError(err, "failed to scan organization role")
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list organization roles")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return roles, nil
+}
+
+// GetOrganizationRole retrieves a custom role by name, or ErrNotFound
+func (r *PostgresRepository) GetOrganizationRole(ctx context.Context, organizationID, name string) (*model.Role, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetOrganizationRole")
+	defer span.End()
+
+	query := `SELECT ` + roleColumns + ` FROM organization_roles WHERE organization_id::text = $1 AND name = $2`
+
+	role, err := scanRole(r.db.Pool.QueryRow(ctx, query, organizationID, name))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get organization role")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return role, nil
+}
+
+// GetOrganizationRoleByID retrieves a custom role by ID, or ErrNotFound
+func (r *PostgresRepository) GetOrganizationRoleByID(ctx context.Context, organizationID, id string) (*model.Role, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetOrganizationRoleByID")
+	defer span.End()
+
+	query := `SELECT ` + roleColumns + ` FROM organization_roles WHERE organization_id::text = $1 AND id::text = $2`
+
+	role, err := scanRole(r.db.Pool.QueryRow(ctx, query, organizationID, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get organization role")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return role, nil
+}
+
+// CreateOrganizationRole stores a new custom role
+func (r *PostgresRepository) CreateOrganizationRole(ctx context.Context, role *model.Role) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.CreateOrganizationRole")
+	defer span.End()
+
+	permissions, err := encodePermissions(role.Permissions)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO organization_roles (organization_id, name, description, permissions)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id::text, created_at, updated_at
+	`
+
+	err = r.db.Pool.QueryRow(ctx, query, role.OrganizationID, role.Name, role.Description, permissions).
+		Scan(&role.ID, &role.CreatedAt, &role.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key value") || strings.Contains(err.Error(), "23505") {
+			return errors.ErrRoleAlreadyExists
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to create organization role")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// UpdateOrganizationRole updates a custom role's description and permissions
+func (r *PostgresRepository) UpdateOrganizationRole(ctx context.Context, role *model.Role) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.UpdateOrganizationRole")
+	defer span.End()
+
+	permissions, err := encodePermissions(role.Permissions)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE organization_roles
+		SET description = $3, permissions = $4, updated_at = NOW()
+		WHERE organization_id::text = $1 AND id::text = $2
+		RETURNING updated_at
+	`
+
+	err = r.db.Pool.QueryRow(ctx, query, role.OrganizationID, role.ID, role.Description, permissions).Scan(&role.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return errors.ErrNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to update organization role")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// DeleteOrganizationRole deletes a custom role, or returns ErrNotFound
+func (r *PostgresRepository) DeleteOrganizationRole(ctx context.Context, organizationID, id string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.DeleteOrganizationRole")
+	defer span.End()
+
+	query := `DELETE FROM organization_roles WHERE organization_id::text = $1 AND id::text = $2`
+
+	result, err := r.db.Pool.Exec(ctx, query, organizationID, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to delete organization role")
+	}
+	if result.RowsAffected() == 0 {
+		return errors.ErrNotFound
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// CountMembersWithRole counts the organization's members holding a role
+func (r *PostgresRepository) CountMembersWithRole(ctx context.Context, organizationID, name string) (int, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.CountMembersWithRole")
+	defer span.End()
+
+	query := `SELECT COUNT(*) FROM organization_members WHERE organization_id::text = $1 AND role = $2`
+
+	var count int
+	if err := r.db.Pool.QueryRow(ctx, query, organizationID, name).Scan(&count); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return 0, errors.WrapError(err, "failed to count role members")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return count, nil
+}
+
+// SetMemberRole changes a member's role, or returns ErrNotFound if they aren't a member
+func (r *PostgresRepository) SetMemberRole(ctx context.Context, organizationID, userID, role string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.SetMemberRole")
+	defer span.End()
+
+	query := `
+		UPDATE organization_members
+		SET role = $3, updated_at = NOW()
+		WHERE organization_id::text = $1 AND user_id = $2
+	`
+
+	result, err := r.db.Pool.Exec(ctx, query, organizationID, userID, role)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to set member role")
+	}
+	if result.RowsAffected() == 0 {
+		return errors.ErrNotFound
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+func scanRole(row pgx.Row) (*model.Role, error) {
+	role := &model.Role{}
+	var permissions []byte
+	err := row.Scan(
+		&role.ID,
+		&role.OrganizationID,
+		&role.Name,
+		&role.Description,
+		&permissions,
+		&role.CreatedAt,
+		&role.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var granted map[string]interface{}
+	if err := json.Unmarshal(permissions, &granted); err != nil {
+		return nil, err
+	}
+	role.Permissions = model.GrantedPermissions(granted)
+	return role, nil
+}
+
+func encodePermissions(permissions []string) ([]byte, error) {
+	document := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		document[permission] = true
+	}
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to encode role permissions")
+	}
+	return encoded, nil
+}
@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ethos/internal/permission/model"
+	"ethos/pkg/errors"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const roleGrantColumns = `id::text, organization_id::text, user_id, role, COALESCE(reason, ''), COALESCE(granted_by, ''),
+	starts_at, ends_at, expired_at, revoked_at, COALESCE(revoked_by, ''), created_at`
+
+// GetMemberRole returns a member's organization role, or ErrNotFound if they aren't a member
+func (r *PostgresRepository) GetMemberRole(ctx context.Context, organizationID, userID string) (string, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetMemberRole")
+	defer span.End()
+
+	query := `SELECT role FROM organization_members WHERE organization_id::text = $1 AND user_id = $2`
+
+	var role string
+	if err := r.db.Pool.QueryRow(ctx, query, organizationID, userID).Scan(&role); err != nil {
+		if err == pgx.ErrNoRows {
+			return "", errors.ErrNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", errors.WrapError(err, "failed to get member role")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return role, nil
+}
+
+// CreateRoleGrant stores a new time-bound role grant
+func (r *PostgresRepository) CreateRoleGrant(ctx context.Context, grant *model.RoleGrant) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.CreateRoleGrant")
+	defer span.End()
+
+	query := `
+		INSERT INTO organization_role_grants (organization_id, user_id, role, reason, granted_by, starts_at, ends_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7)
+		RETURNING id::text, created_at
+	`
+
+	err := r.db.Pool.QueryRow(ctx, query,
+		grant.OrganizationID, grant.UserID, grant.Role, grant.Reason, grant.GrantedBy, grant.StartsAt, grant.EndsAt,
+	).Scan(&grant.ID, &grant.CreatedAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to create role grant")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// GetRoleGrant retrieves a role grant by ID, or ErrNotFound
+func (r *PostgresRepository) GetRoleGrant(ctx context.Context, organizationID, id string) (*model.RoleGrant, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetRoleGrant")
+	defer span.End()
+
+	query := `SELECT ` + roleGrantColumns + ` FROM organization_role_grants WHERE organization_id::text = $1 AND id::text = $2`
+
+	grant, err := scanRoleGrant(r.db.Pool.QueryRow(ctx, query, organizationID, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get role grant")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return grant, nil
+}
+
+// ListRoleGrants lists an organization's grants that haven't expired or been revoked, by end time
+func (r *PostgresRepository) ListRoleGrants(ctx context.Context, organizationID string) ([]*model.RoleGrant, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ListRoleGrants")
+	defer span.End()
+
+	query := `
+		SELECT ` + roleGrantColumns + `
+		FROM organization_role_grants
+		WHERE organization_id::text = $1 AND expired_at IS NULL AND revoked_at IS NULL
+		ORDER BY ends_at
+	`
+
+	grants, err := r.queryRoleGrants(ctx, query, organizationID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list role grants")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return grants, nil
+}
+
+// ListActiveRoleGrants lists a member's grants in effect right now
+func (r *PostgresRepository) ListActiveRoleGrants(ctx context.Context, organizationID, userID string) ([]*model.RoleGrant, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ListActiveRoleGrants")
+	defer span.End()
+
+	// ends_at is checked directly so a grant stops working on time even if
+	// the expiry job hasn't run yet
+	query := `
+		SELECT ` + roleGrantColumns + `
+		FROM organization_role_grants
+		WHERE organization_id::text = $1 AND user_id = $2
+		AND expired_at IS NULL AND revoked_at IS NULL
+		AND starts_at <= NOW() AND ends_at > NOW()
+		ORDER BY ends_at
+	`
+
+	grants, err := r.queryRoleGrants(ctx, query, organizationID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list active role grants")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return grants, nil
+}
+
+// RevokeRoleGrant ends a grant early, or returns ErrNotFound if it's no longer open
+func (r *PostgresRepository) RevokeRoleGrant(ctx context.Context, organizationID, id, revokedBy string) (*model.RoleGrant, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.RevokeRoleGrant")
+	defer span.End()
+
+	query := `
+		UPDATE organization_role_grants
+		SET revoked_at = NOW(), revoked_by = NULLIF($3, '')
+		WHERE organization_id::text = $1 AND id::text = $2
+		AND expired_at IS NULL AND revoked_at IS NULL
+		RETURNING ` + roleGrantColumns
+
+	grant, err := scanRoleGrant(r.db.Pool.QueryRow(ctx, query, organizationID, id, revokedBy))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to revoke role grant")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return grant, nil
+}
+
+// ExpireRoleGrants marks grants whose end time has passed as expired and returns them
+func (r *PostgresRepository) ExpireRoleGrants(ctx context.Context) ([]*model.RoleGrant, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ExpireRoleGrants")
+	defer span.End()
+
+	query := `
+		UPDATE organization_role_grants
+		SET expired_at = NOW()
+		WHERE ends_at <= NOW() AND expired_at IS NULL AND revoked_at IS NULL
+		RETURNING ` + roleGrantColumns
+
+	grants, err := r.queryRoleGrants(ctx, query)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to expire role grants")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return grants, nil
+}
+
+// ClaimExpiringRoleGrants returns open grants ending before the given time
+// that haven't had an expiry notice, marking them as notified
+func (r *PostgresRepository) ClaimExpiringRoleGrants(ctx context.Context, before time.Time) ([]*model.RoleGrant, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ClaimExpiringRoleGrants")
+	defer span.End()
+
+	query := `
+		UPDATE organization_role_grants
+		SET expiry_notified_at = NOW()
+		WHERE ends_at <= $1 AND ends_at > NOW() AND starts_at <= NOW()
+		AND expiry_notified_at IS NULL AND expired_at IS NULL AND revoked_at IS NULL
+		RETURNING ` + roleGrantColumns
+
+	grants, err := r.queryRoleGrants(ctx, query, before)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to claim expiring role grants")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return grants, nil
+}
+
+// DeactivateExpiredPlatformRoles deactivates platform role assignments
+// whose expiry has passed and returns them
+func (r *PostgresRepository) DeactivateExpiredPlatformRoles(ctx context.Context) ([]model.PlatformRoleExpiry, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.DeactivateExpiredPlatformRoles")
+	defer span.End()
+
+	query := `
+		UPDATE user_role_assignments ura
+		SET is_active = false
+		FROM user_roles ur
+		WHERE ur.id = ura.role_id
+		AND ura.is_active = true AND ura.expires_at IS NOT NULL AND ura.expires_at <= NOW()
+		RETURNING ura.user_id, ur.name, ura.expires_at
+	`
+
+	expiries, err := r.queryPlatformRoleExpiries(ctx, query)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to deactivate expired platform roles")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return expiries, nil
+}
+
+// ClaimExpiringPlatformRoles returns active platform role assignments
+// expiring before the given time that haven't had an expiry notice, marking them as notified
+func (r *PostgresRepository) ClaimExpiringPlatformRoles(ctx context.Context, before time.Time) ([]model.PlatformRoleExpiry, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ClaimExpiringPlatformRoles")
+	defer span.End()
+
+	query := `
+		UPDATE user_role_assignments ura
+		SET expiry_notified_at = NOW()
+		FROM user_roles ur
+		WHERE ur.id = ura.role_id
+		AND ura.is_active = true AND ura.expiry_notified_at IS NULL
+		AND ura.expires_at <= $1 AND ura.expires_at > NOW()
+		RETURNING ura.user_id, ur.name, ura.expires_at
+	`
+
+	expiries, err := r.queryPlatformRoleExpiries(ctx, query, before)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to claim expiring platform roles")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return expiries, nil
+}
+
+func (r *PostgresRepository) queryRoleGrants(ctx context.Context, query string, args ...interface{}) ([]*model.RoleGrant, error) {
+	rows, err := r.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	grants := []*model.RoleGrant{}
+	for rows.Next() {
+		grant, err := scanRoleGrant(rows)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, grant)
+	}
+	return grants, rows.Err()
+}
+
+func (r *PostgresRepository) queryPlatformRoleExpiries(ctx context.Context, query string, args ...interface{}) ([]model.PlatformRoleExpiry, error) {
+	rows, err := r.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var expiries []model.PlatformRoleExpiry
+	for rows.Next() {
+		var expiry model.PlatformRoleExpiry
+		if err := rows.Scan(&expiry.UserID, &expiry.Role, &expiry.ExpiresAt); err != nil {
+			return nil, err
+		}
+		expiries = append(expiries, expiry)
+	}
+	return expiries, rows.Err()
+}
+
+func scanRoleGrant(row pgx.Row) (*model.RoleGrant, error) {
+	grant := &model.RoleGrant{}
+	err := row.Scan(
+		&grant.ID,
+		&grant.OrganizationID,
+		&grant.UserID,
+		&grant.Role,
+		&grant.Reason,
+		&grant.GrantedBy,
+		&grant.StartsAt,
+		&grant.EndsAt,
+		&grant.ExpiredAt,
+		&grant.RevokedAt,
+		&grant.RevokedBy,
+		&grant.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	grant.Status = grant.StatusAt(time.Now())
+	return grant, nil
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+
+	"ethos/internal/permission/model"
+)
+
+// Service defines the interface for permission checks and organization roles
+type Service interface {
+	// Authorize decides whether the subject has a permission, explaining why
+	Authorize(ctx context.Context, subject model.Subject, permission string) (*model.Decision, error)
+
+	// ListPermissions lists the permissions roles can grant
+	ListPermissions() []model.PermissionInfo
+
+	// ListRoles lists an organization's built-in and custom roles
+	ListRoles(ctx context.Context, organizationID string) ([]*model.Role, error)
+
+	// CreateRole creates a custom role in the actor's organization
+	CreateRole(ctx context.Context, actor model.Subject, req *model.CreateRoleRequest) (*model.Role, error)
+
+	// UpdateRole edits a custom role in the actor's organization
+	UpdateRole(ctx context.Context, actor model.Subject, roleID string, req *model.UpdateRoleRequest) (*model.Role, error)
+
+	// DeleteRole deletes a custom organization role that no member holds
+	DeleteRole(ctx context.Context, organizationID, roleID string) error
+
+	// AssignRole gives a member of the actor's organization a built-in or custom role
+	AssignRole(ctx context.Context, actor model.Subject, userID string, req *model.AssignRoleRequest) error
+
+	// CheckAssignable resolves a role the actor may give to others in their organization
+	CheckAssignable(ctx context.Context, actor model.Subject, roleName string) (string, error)
+
+	// GrantRole gives a member of the actor's organization a role for a limited time
+	GrantRole(ctx context.Context, actor model.Subject, req *model.GrantRoleRequest) (*model.RoleGrant, error)
+
+	// ListRoleGrants lists an organization's scheduled and active role grants
+	ListRoleGrants(ctx context.Context, organizationID string) ([]*model.RoleGrant, error)
+
+	// RevokeRoleGrant ends a role grant in the actor's organization early
+	RevokeRoleGrant(ctx context.Context, actor model.Subject, grantID, ipAddress, userAgent string) error
+
+	// ProcessRoleExpiry expires lapsed organization grants and platform
+	// roles, and notifies holders whose roles expire soon
+	ProcessRoleExpiry(ctx context.Context) error
+}
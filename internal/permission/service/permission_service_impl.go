@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	notificationModel "ethos/internal/notifications/model"
+	"ethos/internal/permission/model"
+	"ethos/internal/permission/repository"
+	"ethos/pkg/errors"
+)
+
+// ownerRole is the built-in role that owns an organization
+const ownerRole = "owner"
+
+// roleNamePattern limits custom role names to what fits in organization_members.role
+var roleNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+
+// ActivityLogger records actions in an organization's activity log
+// (implemented by the organization context repository)
+type ActivityLogger interface {
+	LogOrganizationActivity(ctx context.Context, organizationID, userID, action, resourceType, resourceID, ipAddress, userAgent string, changes map[string]interface{}) error
+}
+
+// Notifier sends in-app notifications (implemented by the notifications repository)
+type Notifier interface {
+	CreateNotification(ctx context.Context, userID string, notificationType notificationModel.NotificationType, message string) error
+}
+
+// Config holds the limits for time-bound role grants
+type Config struct {
+	// ExpiryNotice is how long before a role expires its holder is notified
+	ExpiryNotice time.Duration
+	// MaxGrantDuration caps how long a time-bound grant may last
+	MaxGrantDuration time.Duration
+}
+
+// PermissionService implements the Service interface
+type PermissionService struct {
+	repo     repository.Repository
+	activity ActivityLogger
+	notifier Notifier
+	config   Config
+}
+
+// NewPermissionService creates a new permission service
+func NewPermissionService(repo repository.Repository, activity ActivityLogger, notifier Notifier, cfg Config) Service {
+	return &PermissionService{
+		repo:     repo,
+		activity: activity,
+		notifier: notifier,
+		config:   cfg,
+	}
+}
+
+// Authorize decides whether the subject has a permission. Platform roles
+// apply everywhere; the organization role only within its organization.
+// Denials carry a reason that's safe to show to the subject.
+func (s *PermissionService) Authorize(ctx context.Context, subject model.Subject, permission string) (*model.Decision, error) {
+	platformRoles, err := s.repo.GetPlatformRoles(ctx, subject.UserID)
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range platformRoles {
+		for _, granted := range model.GrantedPermissions(role.Permissions) {
+			if model.Grants(granted, permission) {
+				return allow(permission, fmt.Sprintf("granted by platform role %q", role.Name)), nil
+			}
+		}
+	}
+
+	if subject.OrganizationID == "" {
+		return deny(permission, fmt.Sprintf("%s can only be granted by an organization role, and this request isn't for an organization", permission)), nil
+	}
+	if subject.OrganizationRole == "" {
+		return deny(permission, "you are not a member of this organization"), nil
+	}
+
+	role, err := s.findRole(ctx, subject.OrganizationID, subject.OrganizationRole)
+	if err == errors.ErrNotFound {
+		return deny(permission, fmt.Sprintf("your role %q no longer exists in this organization", subject.OrganizationRole)), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := role.Grants(permission); ok {
+		return allow(permission, fmt.Sprintf("granted by organization role %q", role.Name)), nil
+	}
+
+	grants, err := s.repo.ListActiveRoleGrants(ctx, subject.OrganizationID, subject.UserID)
+	if err != nil {
+		return nil, err
+	}
+	for _, grant := range grants {
+		granted, err := s.findRole(ctx, subject.OrganizationID, grant.Role)
+		if err == errors.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := granted.Grants(permission); ok {
+			return allow(permission, fmt.Sprintf("granted by role %q until %s", granted.Name, grant.EndsAt.UTC().Format(time.RFC3339))), nil
+		}
+	}
+
+	reason := fmt.Sprintf("your role %q does not grant %s", role.Name, permission)
+	if granting, err := s.rolesGranting(ctx, subject.OrganizationID, permission); err == nil && len(granting) > 0 {
+		reason += fmt.Sprintf("; roles that do: %s", strings.Join(granting, ", "))
+	}
+	return deny(permission, reason), nil
+}
+
+// ListPermissions lists the permissions roles can grant
+func (s *PermissionService) ListPermissions() []model.PermissionInfo {
+	permissions := make([]model.PermissionInfo, 0, len(model.KnownPermissions))
+	for _, name := range knownPermissionNames() {
+		permissions = append(permissions, model.PermissionInfo{Name: name, Description: model.KnownPermissions[name]})
+	}
+	return permissions
+}
+
+// ListRoles lists an organization's built-in roles followed by its custom roles
+func (s *PermissionService) ListRoles(ctx context.Context, organizationID string) ([]*model.Role, error) {
+	custom, err := s.repo.ListOrganizationRoles(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(model.BuiltinRoles))
+	for name := range model.BuiltinRoles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	roles := make([]*model.Role, 0, len(names)+len(custom))
+	for _, name := range names {
+		roles = append(roles, model.BuiltinRoles[name])
+	}
+	return append(roles, custom...), nil
+}
+
+// CreateRole creates a custom role in the actor's organization. A role can
+// only grant permissions the actor has, so holding org.roles.write doesn't
+// lead to every other permission.
+func (s *PermissionService) CreateRole(ctx context.Context, actor model.Subject, req *model.CreateRoleRequest) (*model.Role, error) {
+	name := strings.ToLower(strings.TrimSpace(req.Name))
+	if !roleNamePattern.MatchString(name) {
+		return nil, errors.NewValidationError("role names may only contain lowercase letters, digits, '-' and '_'")
+	}
+	if _, builtin := model.BuiltinRoles[name]; builtin {
+		return nil, errors.ErrRoleAlreadyExists
+	}
+
+	permissions, err := s.validateGrants(ctx, actor, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &model.Role{
+		OrganizationID: actor.OrganizationID,
+		Name:           name,
+		Description:    strings.TrimSpace(req.Description),
+		Permissions:    permissions,
+	}
+	if err := s.repo.CreateOrganizationRole(ctx, role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// UpdateRole replaces a custom role's permissions, and its description if given
+func (s *PermissionService) UpdateRole(ctx context.Context, actor model.Subject, roleID string, req *model.UpdateRoleRequest) (*model.Role, error) {
+	role, err := s.repo.GetOrganizationRoleByID(ctx, actor.OrganizationID, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := s.validateGrants(ctx, actor, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role.Permissions = permissions
+	if req.Description != nil {
+		role.Description = strings.TrimSpace(*req.Description)
+	}
+	if err := s.repo.UpdateOrganizationRole(ctx, role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// DeleteRole deletes a custom role. Members must be moved to another role
+// first, so nobody is left holding a role that no longer exists.
+func (s *PermissionService) DeleteRole(ctx context.Context, organizationID, roleID string) error {
+	role, err := s.repo.GetOrganizationRoleByID(ctx, organizationID, roleID)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.repo.CountMembersWithRole(ctx, organizationID, role.Name)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.ErrRoleInUse
+	}
+
+	return s.repo.DeleteOrganizationRole(ctx, organizationID, roleID)
+}
+
+// AssignRole gives a member a built-in or custom role. Like editing roles,
+// the actor can't hand out permissions they don't have, nor take away a
+// role granting permissions they don't have. The owner role can't be given
+// or taken away here.
+func (s *PermissionService) AssignRole(ctx context.Context, actor model.Subject, userID string, req *model.AssignRoleRequest) error {
+	role, err := s.CheckAssignable(ctx, actor, req.Role)
+	if err != nil {
+		return err
+	}
+
+	current, err := s.repo.GetMemberRole(ctx, actor.OrganizationID, userID)
+	if err != nil {
+		return err
+	}
+	if current == role {
+		return nil
+	}
+	if role == ownerRole || current == ownerRole {
+		return errors.NewValidationError("the owner role can't be granted or changed")
+	}
+	if err := s.checkReplaceable(ctx, actor, current); err != nil {
+		return err
+	}
+
+	return s.repo.SetMemberRole(ctx, actor.OrganizationID, userID, role)
+}
+
+// checkReplaceable checks the actor holds every permission a member's
+// current role grants, so changing it can't take away permissions the actor
+// couldn't grant back
+func (s *PermissionService) checkReplaceable(ctx context.Context, actor model.Subject, current string) error {
+	role, err := s.findRole(ctx, actor.OrganizationID, current)
+	if err == errors.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range append(knownPermissionNames(), "*") {
+		if _, ok := role.Grants(permission); !ok {
+			continue
+		}
+		decision, err := s.Authorize(ctx, actor, permission)
+		if err != nil {
+			return err
+		}
+		if !decision.Allowed {
+			return errors.NewPermissionDeniedError(fmt.Sprintf("You can't change the role of a member whose role %q grants %s: %s", role.Name, permission, decision.Reason))
+		}
+	}
+	return nil
+}
+
+// CheckAssignable resolves a role name in the actor's organization and
+// checks the actor holds every permission it grants, returning its
+// canonical name
+func (s *PermissionService) CheckAssignable(ctx context.Context, actor model.Subject, roleName string) (string, error) {
+	role, err := s.findRole(ctx, actor.OrganizationID, strings.ToLower(strings.TrimSpace(roleName)))
+	if err == errors.ErrNotFound {
+		return "", errors.NewValidationError(fmt.Sprintf("role %q does not exist in this organization", roleName))
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := s.validateGrants(ctx, actor, role.Permissions); err != nil {
+		return "", err
+	}
+	return role.Name, nil
+}
+
+// findRole resolves a role name to a built-in role or one of the organization's custom roles
+func (s *PermissionService) findRole(ctx context.Context, organizationID, name string) (*model.Role, error) {
+	if role, ok := model.BuiltinRoles[name]; ok {
+		return role, nil
+	}
+	return s.repo.GetOrganizationRole(ctx, organizationID, name)
+}
+
+// rolesGranting lists the organization's roles that grant a permission
+func (s *PermissionService) rolesGranting(ctx context.Context, organizationID, permission string) ([]string, error) {
+	roles, err := s.ListRoles(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, role := range roles {
+		if _, ok := role.Grants(permission); ok {
+			names = append(names, role.Name)
+		}
+	}
+	return names, nil
+}
+
+// validateGrants rejects unknown permissions and permissions the actor
+// doesn't have, and drops duplicates
+func (s *PermissionService) validateGrants(ctx context.Context, actor model.Subject, requested []string) ([]string, error) {
+	grants := make([]string, 0, len(requested))
+	seen := make(map[string]bool)
+	for _, granted := range requested {
+		if !model.IsValidGrant(granted) {
+			return nil, errors.NewValidationError(fmt.Sprintf("unknown permission %q", granted))
+		}
+		if !seen[granted] {
+			seen[granted] = true
+			grants = append(grants, granted)
+		}
+	}
+
+	// "*" also covers permissions added later, so only those holding it may grant it
+	checks := knownPermissionNames()
+	if seen["*"] {
+		checks = append(checks, "*")
+	}
+
+	role := &model.Role{Permissions: grants}
+	for _, permission := range checks {
+		if _, ok := role.Grants(permission); !ok {
+			continue
+		}
+		decision, err := s.Authorize(ctx, actor, permission)
+		if err != nil {
+			return nil, err
+		}
+		if !decision.Allowed {
+			return nil, errors.NewPermissionDeniedError(fmt.Sprintf("You can't grant %s: %s", permission, decision.Reason))
+		}
+	}
+
+	sort.Strings(grants)
+	return grants, nil
+}
+
+func knownPermissionNames() []string {
+	names := make([]string, 0, len(model.KnownPermissions))
+	for name := range model.KnownPermissions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func allow(permission, reason string) *model.Decision {
+	return &model.Decision{Allowed: true, Permission: permission, Reason: reason}
+}
+
+func deny(permission, reason string) *model.Decision {
+	return &model.Decision{Allowed: false, Permission: permission, Reason: reason}
+}
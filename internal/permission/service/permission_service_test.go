@@ -0,0 +1,468 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authModel "ethos/internal/auth/model"
+	notificationModel "ethos/internal/notifications/model"
+	"ethos/internal/permission/model"
+	"ethos/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRepository keeps roles, memberships and grants in memory
+type fakeRepository struct {
+	platformRoles  map[string][]authModel.UserRole
+	roles          map[string]*model.Role // by ID
+	memberRoles    map[string]string      // by user ID
+	grants         []*model.RoleGrant
+	notified       map[string]bool // by grant ID
+	platformExpiry []model.PlatformRoleExpiry
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{
+		platformRoles: map[string][]authModel.UserRole{},
+		roles:         map[string]*model.Role{},
+		memberRoles:   map[string]string{},
+		notified:      map[string]bool{},
+	}
+}
+
+func (r *fakeRepository) GetPlatformRoles(ctx context.Context, userID string) ([]authModel.UserRole, error) {
+	return r.platformRoles[userID], nil
+}
+
+func (r *fakeRepository) ListOrganizationRoles(ctx context.Context, organizationID string) ([]*model.Role, error) {
+	roles := []*model.Role{}
+	for _, role := range r.roles {
+		if role.OrganizationID == organizationID {
+			roles = append(roles, role)
+		}
+	}
+	return roles, nil
+}
+
+func (r *fakeRepository) GetOrganizationRole(ctx context.Context, organizationID, name string) (*model.Role, error) {
+	for _, role := range r.roles {
+		if role.OrganizationID == organizationID && role.Name == name {
+			return role, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (r *fakeRepository) GetOrganizationRoleByID(ctx context.Context, organizationID, id string) (*model.Role, error) {
+	role, ok := r.roles[id]
+	if !ok || role.OrganizationID != organizationID {
+		return nil, errors.ErrNotFound
+	}
+	return role, nil
+}
+
+func (r *fakeRepository) CreateOrganizationRole(ctx context.Context, role *model.Role) error {
+	if _, err := r.GetOrganizationRole(ctx, role.OrganizationID, role.Name); err == nil {
+		return errors.ErrRoleAlreadyExists
+	}
+	role.ID = "role-" + role.Name
+	r.roles[role.ID] = role
+	return nil
+}
+
+func (r *fakeRepository) UpdateOrganizationRole(ctx context.Context, role *model.Role) error {
+	r.roles[role.ID] = role
+	return nil
+}
+
+func (r *fakeRepository) DeleteOrganizationRole(ctx context.Context, organizationID, id string) error {
+	delete(r.roles, id)
+	return nil
+}
+
+func (r *fakeRepository) CountMembersWithRole(ctx context.Context, organizationID, name string) (int, error) {
+	count := 0
+	for _, role := range r.memberRoles {
+		if role == name {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeRepository) SetMemberRole(ctx context.Context, organizationID, userID, role string) error {
+	if _, ok := r.memberRoles[userID]; !ok {
+		return errors.ErrNotFound
+	}
+	r.memberRoles[userID] = role
+	return nil
+}
+
+func (r *fakeRepository) GetMemberRole(ctx context.Context, organizationID, userID string) (string, error) {
+	role, ok := r.memberRoles[userID]
+	if !ok {
+		return "", errors.ErrNotFound
+	}
+	return role, nil
+}
+
+func (r *fakeRepository) CreateRoleGrant(ctx context.Context, grant *model.RoleGrant) error {
+	grant.ID = "grant-" + grant.UserID + "-" + grant.Role
+	grant.CreatedAt = time.Now()
+	r.grants = append(r.grants, grant)
+	return nil
+}
+
+func (r *fakeRepository) GetRoleGrant(ctx context.Context, organizationID, id string) (*model.RoleGrant, error) {
+	for _, grant := range r.grants {
+		if grant.OrganizationID == organizationID && grant.ID == id {
+			return grant, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (r *fakeRepository) ListRoleGrants(ctx context.Context, organizationID string) ([]*model.RoleGrant, error) {
+	return r.filterGrants(func(grant *model.RoleGrant) bool {
+		return grant.OrganizationID == organizationID && grant.ExpiredAt == nil && grant.RevokedAt == nil
+	}), nil
+}
+
+func (r *fakeRepository) ListActiveRoleGrants(ctx context.Context, organizationID, userID string) ([]*model.RoleGrant, error) {
+	now := time.Now()
+	return r.filterGrants(func(grant *model.RoleGrant) bool {
+		return grant.OrganizationID == organizationID && grant.UserID == userID && grant.StatusAt(now) == model.GrantStatusActive
+	}), nil
+}
+
+func (r *fakeRepository) RevokeRoleGrant(ctx context.Context, organizationID, id, revokedBy string) (*model.RoleGrant, error) {
+	grant, err := r.GetRoleGrant(ctx, organizationID, id)
+	if err != nil || grant.RevokedAt != nil || grant.ExpiredAt != nil {
+		return nil, errors.ErrNotFound
+	}
+	now := time.Now()
+	grant.RevokedAt = &now
+	grant.RevokedBy = revokedBy
+	return grant, nil
+}
+
+func (r *fakeRepository) ExpireRoleGrants(ctx context.Context) ([]*model.RoleGrant, error) {
+	now := time.Now()
+	expired := r.filterGrants(func(grant *model.RoleGrant) bool {
+		return grant.ExpiredAt == nil && grant.RevokedAt == nil && !now.Before(grant.EndsAt)
+	})
+	for _, grant := range expired {
+		grant.ExpiredAt = &now
+	}
+	return expired, nil
+}
+
+func (r *fakeRepository) ClaimExpiringRoleGrants(ctx context.Context, before time.Time) ([]*model.RoleGrant, error) {
+	now := time.Now()
+	expiring := r.filterGrants(func(grant *model.RoleGrant) bool {
+		return !r.notified[grant.ID] && grant.StatusAt(now) == model.GrantStatusActive && grant.EndsAt.Before(before)
+	})
+	for _, grant := range expiring {
+		r.notified[grant.ID] = true
+	}
+	return expiring, nil
+}
+
+func (r *fakeRepository) DeactivateExpiredPlatformRoles(ctx context.Context) ([]model.PlatformRoleExpiry, error) {
+	return nil, nil
+}
+
+func (r *fakeRepository) ClaimExpiringPlatformRoles(ctx context.Context, before time.Time) ([]model.PlatformRoleExpiry, error) {
+	expiring := r.platformExpiry
+	r.platformExpiry = nil
+	return expiring, nil
+}
+
+func (r *fakeRepository) filterGrants(keep func(*model.RoleGrant) bool) []*model.RoleGrant {
+	var grants []*model.RoleGrant
+	for _, grant := range r.grants {
+		if keep(grant) {
+			grants = append(grants, grant)
+		}
+	}
+	return grants
+}
+
+// recorder captures activity log entries and notifications
+type recorder struct {
+	actions       []string
+	notifications map[string][]string // by user ID
+}
+
+func (r *recorder) LogOrganizationActivity(ctx context.Context, organizationID, userID, action, resourceType, resourceID, ipAddress, userAgent string, changes map[string]interface{}) error {
+	r.actions = append(r.actions, action)
+	return nil
+}
+
+func (r *recorder) CreateNotification(ctx context.Context, userID string, notificationType notificationModel.NotificationType, message string) error {
+	if r.notifications == nil {
+		r.notifications = map[string][]string{}
+	}
+	r.notifications[userID] = append(r.notifications[userID], message)
+	return nil
+}
+
+func TestGrants(t *testing.T) {
+	assert.True(t, model.Grants("*", model.PermissionOrgRolesWrite))
+	assert.True(t, model.Grants("moderation.*", model.PermissionModerationAppealsReview))
+	assert.True(t, model.Grants("moderation.appeals.*", model.PermissionModerationAppealsReview))
+	assert.True(t, model.Grants(model.PermissionOrgSSOManage, model.PermissionOrgSSOManage))
+	assert.False(t, model.Grants("moderation.*", model.PermissionOrgRolesWrite))
+	assert.False(t, model.Grants("org.settings", model.PermissionOrgSettingsWrite))
+	assert.False(t, model.Grants("mod*", model.PermissionModerationAppealsRead))
+
+	// Only "*" covers owner-only permissions
+	assert.True(t, model.Grants("*", model.PermissionOrgDelete))
+	assert.False(t, model.Grants("org.*", model.PermissionOrgDelete))
+	assert.False(t, model.Grants(model.PermissionOrgDelete, model.PermissionOrgDelete))
+	assert.False(t, model.IsValidGrant(model.PermissionOrgDelete))
+}
+
+func TestAuthorize(t *testing.T) {
+	repo := newFakeRepository()
+	repo.platformRoles["staff"] = []authModel.UserRole{
+		{Name: "user", Permissions: map[string]interface{}{"read_feedback": true}},
+		{Name: "moderator", Permissions: map[string]interface{}{"moderation.*": true}},
+	}
+	repo.roles["role-triage"] = &model.Role{ID: "role-triage", OrganizationID: "org-1", Name: "triage", Permissions: []string{model.PermissionModerationAppealsRead}}
+	svc := NewPermissionService(repo, nil, nil, Config{})
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		subject    model.Subject
+		permission string
+		allowed    bool
+		reason     string
+	}{
+		{"built-in role", model.Subject{UserID: "u1", OrganizationID: "org-1", OrganizationRole: "admin"}, model.PermissionOrgSSOManage, true, `granted by organization role "admin"`},
+		{"custom role", model.Subject{UserID: "u1", OrganizationID: "org-1", OrganizationRole: "triage"}, model.PermissionModerationAppealsRead, true, `granted by organization role "triage"`},
+		{"platform role in any organization", model.Subject{UserID: "staff", OrganizationID: "org-2", OrganizationRole: "viewer"}, model.PermissionModerationHistoryRead, true, `granted by platform role "moderator"`},
+		{"role lacks permission", model.Subject{UserID: "u1", OrganizationID: "org-1", OrganizationRole: "triage"}, model.PermissionModerationAppealsReview, false, `your role "triage" does not grant moderation.appeals.review; roles that do: admin, moderator, owner`},
+		{"owner-only permission", model.Subject{UserID: "u1", OrganizationID: "org-1", OrganizationRole: "admin"}, model.PermissionOrgDelete, false, `your role "admin" does not grant org.delete; roles that do: owner`},
+		{"not a member", model.Subject{UserID: "u1", OrganizationID: "org-1"}, model.PermissionOrgSettingsWrite, false, "you are not a member of this organization"},
+		{"custom role from another organization", model.Subject{UserID: "u1", OrganizationID: "org-2", OrganizationRole: "triage"}, model.PermissionModerationAppealsRead, false, `your role "triage" no longer exists in this organization`},
+		{"no organization", model.Subject{UserID: "u1"}, model.PermissionOrgSettingsWrite, false, "org.settings.write can only be granted by an organization role, and this request isn't for an organization"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := svc.Authorize(ctx, tt.subject, tt.permission)
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, decision.Allowed)
+			assert.Equal(t, tt.permission, decision.Permission)
+			assert.Equal(t, tt.reason, decision.Reason)
+		})
+	}
+}
+
+func TestCreateRole(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewPermissionService(repo, nil, nil, Config{})
+	ctx := context.Background()
+	admin := model.Subject{UserID: "u1", OrganizationID: "org-1", OrganizationRole: "admin"}
+
+	role, err := svc.CreateRole(ctx, admin, &model.CreateRoleRequest{
+		Name:        " Triage ",
+		Permissions: []string{"moderation.appeals.*", model.PermissionModerationActionsRead, model.PermissionModerationActionsRead},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "triage", role.Name)
+	assert.Equal(t, []string{model.PermissionModerationActionsRead, "moderation.appeals.*"}, role.Permissions)
+
+	_, err = svc.CreateRole(ctx, admin, &model.CreateRoleRequest{Name: "triage", Permissions: []string{}})
+	assert.Equal(t, errors.ErrRoleAlreadyExists, err)
+
+	_, err = svc.CreateRole(ctx, admin, &model.CreateRoleRequest{Name: "owner", Permissions: []string{}})
+	assert.Equal(t, errors.ErrRoleAlreadyExists, err)
+
+	_, err = svc.CreateRole(ctx, admin, &model.CreateRoleRequest{Name: "auditor", Permissions: []string{"feedback.delete"}})
+	apiErr, ok := err.(*errors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+}
+
+func TestCreateRole_CannotGrantMissingPermissions(t *testing.T) {
+	repo := newFakeRepository()
+	repo.roles["role-role-editor"] = &model.Role{ID: "role-role-editor", OrganizationID: "org-1", Name: "role-editor", Permissions: []string{model.PermissionOrgRolesWrite}}
+	svc := NewPermissionService(repo, nil, nil, Config{})
+	editor := model.Subject{UserID: "u1", OrganizationID: "org-1", OrganizationRole: "role-editor"}
+
+	_, err := svc.CreateRole(context.Background(), editor, &model.CreateRoleRequest{Name: "everything", Permissions: []string{"*"}})
+	apiErr, ok := err.(*errors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "PERMISSION_DENIED", apiErr.Code)
+	assert.Contains(t, apiErr.Message, `your role "role-editor" does not grant`)
+
+	_, err = svc.CreateRole(context.Background(), editor, &model.CreateRoleRequest{Name: "editors", Permissions: []string{model.PermissionOrgRolesWrite}})
+	assert.NoError(t, err)
+}
+
+func TestAssignRole(t *testing.T) {
+	repo := newFakeRepository()
+	repo.memberRoles["u2"] = "member"
+	svc := NewPermissionService(repo, nil, nil, Config{})
+	ctx := context.Background()
+	admin := model.Subject{UserID: "u1", OrganizationID: "org-1", OrganizationRole: "admin"}
+
+	require.NoError(t, svc.AssignRole(ctx, admin, "u2", &model.AssignRoleRequest{Role: "moderator"}))
+	assert.Equal(t, "moderator", repo.memberRoles["u2"])
+
+	err := svc.AssignRole(ctx, admin, "u2", &model.AssignRoleRequest{Role: "owner"})
+	apiErr, ok := err.(*errors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "PERMISSION_DENIED", apiErr.Code)
+	assert.Equal(t, "moderator", repo.memberRoles["u2"])
+
+	err = svc.AssignRole(ctx, admin, "u2", &model.AssignRoleRequest{Role: "nonexistent"})
+	apiErr, ok = err.(*errors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+
+	assert.Equal(t, errors.ErrNotFound, svc.AssignRole(ctx, admin, "u3", &model.AssignRoleRequest{Role: "member"}))
+}
+
+func TestAssignRole_AdminCannotDemoteOwner(t *testing.T) {
+	repo := newFakeRepository()
+	repo.memberRoles["u2"] = "owner"
+	svc := NewPermissionService(repo, nil, nil, Config{})
+	admin := model.Subject{UserID: "u1", OrganizationID: "org-1", OrganizationRole: "admin"}
+
+	err := svc.AssignRole(context.Background(), admin, "u2", &model.AssignRoleRequest{Role: "member"})
+	apiErr, ok := err.(*errors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+	assert.Equal(t, "owner", repo.memberRoles["u2"])
+}
+
+func TestAssignRole_CannotDemoteARoleWithPermissionsTheActorLacks(t *testing.T) {
+	repo := newFakeRepository()
+	repo.roles["role-curator"] = &model.Role{ID: "role-curator", OrganizationID: "org-1", Name: "curator", Permissions: []string{model.PermissionFeedbackTemplatesManage}}
+	repo.memberRoles["u2"] = "curator"
+	svc := NewPermissionService(repo, nil, nil, Config{})
+	admin := model.Subject{UserID: "u1", OrganizationID: "org-1", OrganizationRole: "admin"}
+
+	err := svc.AssignRole(context.Background(), admin, "u2", &model.AssignRoleRequest{Role: "member"})
+	apiErr, ok := err.(*errors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "PERMISSION_DENIED", apiErr.Code)
+	assert.Contains(t, apiErr.Message, model.PermissionFeedbackTemplatesManage)
+	assert.Equal(t, "curator", repo.memberRoles["u2"])
+}
+
+func TestDeleteRole_InUse(t *testing.T) {
+	repo := newFakeRepository()
+	repo.roles["role-triage"] = &model.Role{ID: "role-triage", OrganizationID: "org-1", Name: "triage", Permissions: []string{}}
+	repo.memberRoles["u2"] = "triage"
+	svc := NewPermissionService(repo, nil, nil, Config{})
+	ctx := context.Background()
+
+	assert.Equal(t, errors.ErrRoleInUse, svc.DeleteRole(ctx, "org-1", "role-triage"))
+
+	repo.memberRoles["u2"] = "member"
+	assert.NoError(t, svc.DeleteRole(ctx, "org-1", "role-triage"))
+	assert.Empty(t, repo.roles)
+}
+
+func TestGrantRole(t *testing.T) {
+	repo := newFakeRepository()
+	repo.memberRoles["u2"] = "member"
+	events := &recorder{}
+	svc := NewPermissionService(repo, events, events, Config{MaxGrantDuration: 30 * 24 * time.Hour})
+	ctx := context.Background()
+	admin := model.Subject{UserID: "u1", OrganizationID: "org-1", OrganizationRole: "admin"}
+	member := model.Subject{UserID: "u2", OrganizationID: "org-1", OrganizationRole: "member"}
+	now := time.Now()
+
+	decision, err := svc.Authorize(ctx, member, model.PermissionModerationAppealsReview)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+
+	grant, err := svc.GrantRole(ctx, admin, &model.GrantRoleRequest{UserID: "u2", Role: "Moderator", EndsAt: now.Add(7 * 24 * time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, "moderator", grant.Role)
+	assert.Equal(t, model.GrantStatusActive, grant.Status)
+	assert.Equal(t, "u1", grant.GrantedBy)
+
+	decision, err = svc.Authorize(ctx, member, model.PermissionModerationAppealsReview)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Contains(t, decision.Reason, `granted by role "moderator" until`)
+
+	require.NoError(t, svc.RevokeRoleGrant(ctx, admin, grant.ID, "", ""))
+	decision, err = svc.Authorize(ctx, member, model.PermissionModerationAppealsReview)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, []string{"role_granted", "role_grant_revoked"}, events.actions)
+
+	invalid := []*model.GrantRoleRequest{
+		{UserID: "u2", Role: "moderator", EndsAt: now.Add(-time.Hour)},
+		{UserID: "u2", Role: "moderator", EndsAt: now.Add(60 * 24 * time.Hour)},
+		{UserID: "u3", Role: "moderator", EndsAt: now.Add(time.Hour)},
+		{UserID: "u2", Role: "nonexistent", EndsAt: now.Add(time.Hour)},
+	}
+	for _, req := range invalid {
+		_, err := svc.GrantRole(ctx, admin, req)
+		apiErr, ok := err.(*errors.APIError)
+		require.True(t, ok)
+		assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+	}
+
+	// Delegating can't hand out more than the delegator has
+	_, err = svc.GrantRole(ctx, admin, &model.GrantRoleRequest{UserID: "u2", Role: "owner", EndsAt: now.Add(time.Hour)})
+	apiErr, ok := err.(*errors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "PERMISSION_DENIED", apiErr.Code)
+}
+
+func TestRevokeRoleGrant_OthersGrantsNeedMembersWrite(t *testing.T) {
+	repo := newFakeRepository()
+	repo.roles["role-delegate"] = &model.Role{ID: "role-delegate", OrganizationID: "org-1", Name: "delegate", Permissions: []string{model.PermissionOrgRolesDelegate}}
+	repo.grants = []*model.RoleGrant{{ID: "g1", OrganizationID: "org-1", UserID: "u2", Role: "member", GrantedBy: "u1", StartsAt: time.Now(), EndsAt: time.Now().Add(time.Hour)}}
+	svc := NewPermissionService(repo, nil, nil, Config{})
+	ctx := context.Background()
+
+	err := svc.RevokeRoleGrant(ctx, model.Subject{UserID: "u3", OrganizationID: "org-1", OrganizationRole: "delegate"}, "g1", "", "")
+	apiErr, ok := err.(*errors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "PERMISSION_DENIED", apiErr.Code)
+
+	require.NoError(t, svc.RevokeRoleGrant(ctx, model.Subject{UserID: "u1", OrganizationID: "org-1", OrganizationRole: "delegate"}, "g1", "", ""))
+	assert.Equal(t, errors.ErrNotFound, svc.RevokeRoleGrant(ctx, model.Subject{UserID: "u1", OrganizationID: "org-1", OrganizationRole: "delegate"}, "g1", "", ""))
+}
+
+func TestProcessRoleExpiry(t *testing.T) {
+	repo := newFakeRepository()
+	now := time.Now()
+	repo.grants = []*model.RoleGrant{
+		{ID: "lapsed", OrganizationID: "org-1", UserID: "u2", Role: "moderator", StartsAt: now.Add(-48 * time.Hour), EndsAt: now.Add(-time.Minute)},
+		{ID: "ending", OrganizationID: "org-1", UserID: "u3", Role: "moderator", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+		{ID: "later", OrganizationID: "org-1", UserID: "u4", Role: "moderator", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(72 * time.Hour)},
+	}
+	repo.platformExpiry = []model.PlatformRoleExpiry{{UserID: "u5", Role: "moderator", ExpiresAt: now.Add(2 * time.Hour)}}
+	events := &recorder{}
+	svc := NewPermissionService(repo, events, events, Config{ExpiryNotice: 24 * time.Hour})
+	ctx := context.Background()
+
+	require.NoError(t, svc.ProcessRoleExpiry(ctx))
+	assert.NotNil(t, repo.grants[0].ExpiredAt)
+	assert.Nil(t, repo.grants[1].ExpiredAt)
+	assert.Equal(t, []string{"role_grant_expired"}, events.actions)
+	assert.Len(t, events.notifications["u3"], 1)
+	assert.Len(t, events.notifications["u5"], 1)
+	assert.Empty(t, events.notifications["u2"])
+	assert.Empty(t, events.notifications["u4"])
+
+	// Notices and expiries are only sent once
+	require.NoError(t, svc.ProcessRoleExpiry(ctx))
+	assert.Equal(t, []string{"role_grant_expired"}, events.actions)
+	assert.Len(t, events.notifications["u3"], 1)
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	notificationModel "ethos/internal/notifications/model"
+	"ethos/internal/permission/model"
+	"ethos/pkg/errors"
+)
+
+// GrantRole gives a member a role between req.StartsAt and req.EndsAt, on
+// top of their own role. As with AssignRole, the actor can only delegate
+// permissions they have.
+func (s *PermissionService) GrantRole(ctx context.Context, actor model.Subject, req *model.GrantRoleRequest) (*model.RoleGrant, error) {
+	role, err := s.findRole(ctx, actor.OrganizationID, strings.ToLower(strings.TrimSpace(req.Role)))
+	if err == errors.ErrNotFound {
+		return nil, errors.NewValidationError(fmt.Sprintf("role %q does not exist in this organization", req.Role))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetMemberRole(ctx, actor.OrganizationID, req.UserID); err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.NewValidationError("roles can only be granted to members of this organization")
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	startsAt := now
+	if req.StartsAt != nil {
+		startsAt = *req.StartsAt
+	}
+	switch {
+	case !req.EndsAt.After(startsAt):
+		return nil, errors.NewValidationError("ends_at must be after starts_at")
+	case !req.EndsAt.After(now):
+		return nil, errors.NewValidationError("ends_at must be in the future")
+	case s.config.MaxGrantDuration > 0 && req.EndsAt.Sub(startsAt) > s.config.MaxGrantDuration:
+		return nil, errors.NewValidationError(fmt.Sprintf("roles can be granted for at most %s", s.config.MaxGrantDuration))
+	}
+
+	if _, err := s.validateGrants(ctx, actor, role.Permissions); err != nil {
+		return nil, err
+	}
+
+	grant := &model.RoleGrant{
+		OrganizationID: actor.OrganizationID,
+		UserID:         req.UserID,
+		Role:           role.Name,
+		Reason:         strings.TrimSpace(req.Reason),
+		GrantedBy:      actor.UserID,
+		StartsAt:       startsAt,
+		EndsAt:         req.EndsAt,
+	}
+	if err := s.repo.CreateRoleGrant(ctx, grant); err != nil {
+		return nil, err
+	}
+	grant.Status = grant.StatusAt(now)
+
+	s.logActivity(ctx, grant.OrganizationID, actor.UserID, "role_granted", grant.ID, req.IP, req.UserAgent, map[string]interface{}{
+		"user_id":   grant.UserID,
+		"role":      grant.Role,
+		"starts_at": grant.StartsAt,
+		"ends_at":   grant.EndsAt,
+		"reason":    grant.Reason,
+	})
+	return grant, nil
+}
+
+// ListRoleGrants lists an organization's scheduled and active role grants
+func (s *PermissionService) ListRoleGrants(ctx context.Context, organizationID string) ([]*model.RoleGrant, error) {
+	return s.repo.ListRoleGrants(ctx, organizationID)
+}
+
+// RevokeRoleGrant ends a grant early. Anyone who can delegate roles may
+// revoke their own grants; revoking someone else's needs org.members.write.
+func (s *PermissionService) RevokeRoleGrant(ctx context.Context, actor model.Subject, grantID, ipAddress, userAgent string) error {
+	grant, err := s.repo.GetRoleGrant(ctx, actor.OrganizationID, grantID)
+	if err != nil {
+		return err
+	}
+
+	if grant.GrantedBy != actor.UserID {
+		decision, err := s.Authorize(ctx, actor, model.PermissionOrgMembersWrite)
+		if err != nil {
+			return err
+		}
+		if !decision.Allowed {
+			return errors.NewPermissionDeniedError(fmt.Sprintf("You can't revoke a grant someone else made: %s", decision.Reason))
+		}
+	}
+
+	grant, err = s.repo.RevokeRoleGrant(ctx, actor.OrganizationID, grantID, actor.UserID)
+	if err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, grant.OrganizationID, actor.UserID, "role_grant_revoked", grant.ID, ipAddress, userAgent, map[string]interface{}{
+		"user_id": grant.UserID,
+		"role":    grant.Role,
+		"ends_at": grant.EndsAt,
+	})
+	return nil
+}
+
+// ProcessRoleExpiry expires lapsed organization grants and platform roles,
+// then notifies holders whose roles expire within the configured notice.
+// Each step is claimed atomically, so running it on several instances
+// doesn't log or notify twice.
+func (s *PermissionService) ProcessRoleExpiry(ctx context.Context) error {
+	expired, err := s.repo.ExpireRoleGrants(ctx)
+	if err != nil {
+		return err
+	}
+	for _, grant := range expired {
+		s.logActivity(ctx, grant.OrganizationID, "", "role_grant_expired", grant.ID, "", "", map[string]interface{}{
+			"user_id": grant.UserID,
+			"role":    grant.Role,
+			"ends_at": grant.EndsAt,
+		})
+	}
+
+	deactivated, err := s.repo.DeactivateExpiredPlatformRoles(ctx)
+	if err != nil {
+		return err
+	}
+	for _, expiry := range deactivated {
+		fmt.Printf("Platform role %q expired for user %s\n", expiry.Role, expiry.UserID)
+	}
+
+	before := time.Now().Add(s.config.ExpiryNotice)
+
+	expiring, err := s.repo.ClaimExpiringRoleGrants(ctx, before)
+	if err != nil {
+		return err
+	}
+	for _, grant := range expiring {
+		s.notify(ctx, grant.UserID, fmt.Sprintf("Your %q role in this organization expires on %s.", grant.Role, grant.EndsAt.UTC().Format(time.RFC1123)))
+	}
+
+	expiringPlatform, err := s.repo.ClaimExpiringPlatformRoles(ctx, before)
+	if err != nil {
+		return err
+	}
+	for _, expiry := range expiringPlatform {
+		s.notify(ctx, expiry.UserID, fmt.Sprintf("Your %q role expires on %s.", expiry.Role, expiry.ExpiresAt.UTC().Format(time.RFC1123)))
+	}
+
+	return nil
+}
+
+// RunRoleExpiry calls ProcessRoleExpiry every interval until ctx is cancelled
+func RunRoleExpiry(ctx context.Context, svc Service, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := svc.ProcessRoleExpiry(ctx); err != nil && ctx.Err() == nil {
+			fmt.Printf("Failed to process role expiry: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *PermissionService) logActivity(ctx context.Context, organizationID, userID, action, grantID, ipAddress, userAgent string, changes map[string]interface{}) {
+	if s.activity == nil {
+		return
+	}
+	if err := s.activity.LogOrganizationActivity(ctx, organizationID, userID, action, "role_grant", grantID, ipAddress, userAgent, changes); err != nil {
+		// Log but don't fail if activity logging fails
+		fmt.Printf("Failed to log organization activity: %v\n", err)
+	}
+}
+
+func (s *PermissionService) notify(ctx context.Context, userID, message string) {
+	if s.notifier == nil {
+		return
+	}
+	if err := s.notifier.CreateNotification(ctx, userID, notificationModel.NotificationTypeRoleExpiring, message); err != nil {
+		fmt.Printf("Failed to send role expiry notice: %v\n", err)
+	}
+}
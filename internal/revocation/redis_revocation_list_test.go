@@ -0,0 +1,117 @@
+package revocation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"ethos/pkg/jwt"
+
+	"github.com/go-redis/redis/v8"
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCache is an in-memory cache.Cache that reports missing keys like Redis
+type memoryCache struct {
+	values map[string]string
+	ttls   map[string]time.Duration
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{values: map[string]string{}, ttls: map[string]time.Duration{}}
+}
+
+func (m *memoryCache) Get(ctx context.Context, key string) (string, error) {
+	value, ok := m.values[key]
+	if !ok {
+		return "", redis.Nil
+	}
+	return value, nil
+}
+
+func (m *memoryCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	m.values[key] = fmt.Sprintf("%v", value)
+	m.ttls[key] = expiration
+	return nil
+}
+
+func (m *memoryCache) Delete(ctx context.Context, key string) error {
+	delete(m.values, key)
+	return nil
+}
+
+func (m *memoryCache) Exists(ctx context.Context, key string) bool {
+	_, ok := m.values[key]
+	return ok
+}
+
+func (m *memoryCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return nil
+}
+func (m *memoryCache) Incr(ctx context.Context, key string) (int64, error) { return 0, nil }
+func (m *memoryCache) FlushAll(ctx context.Context) error                  { return nil }
+func (m *memoryCache) HealthCheck(ctx context.Context) error               { return nil }
+func (m *memoryCache) Close() error                                        { return nil }
+
+func claimsIssuedAt(userID, sessionID, tokenID string, issuedAt time.Time) *jwt.Claims {
+	return &jwt.Claims{
+		UserID:    userID,
+		SessionID: sessionID,
+		RegisteredClaims: gojwt.RegisteredClaims{
+			ID:       tokenID,
+			IssuedAt: gojwt.NewNumericDate(issuedAt),
+		},
+	}
+}
+
+func TestRedisList_RevokesSessionsAndTokens(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryCache()
+	list := NewRedisList(store, 15*time.Minute)
+	now := time.Now()
+
+	require.NoError(t, list.RevokeSession(ctx, "session-1"))
+	require.NoError(t, list.RevokeToken(ctx, "token-2"))
+	assert.Equal(t, 15*time.Minute, store.ttls["revoked:session:session-1"])
+
+	tests := []struct {
+		name    string
+		claims  *jwt.Claims
+		revoked bool
+	}{
+		{"revoked session", claimsIssuedAt("user-1", "session-1", "token-1", now), true},
+		{"revoked token", claimsIssuedAt("user-1", "", "token-2", now), true},
+		{"other session", claimsIssuedAt("user-1", "session-2", "token-3", now), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revoked, err := list.IsRevoked(ctx, tt.claims)
+			require.NoError(t, err)
+			assert.Equal(t, tt.revoked, revoked)
+		})
+	}
+}
+
+func TestRedisList_RevokeUserTokensOnlyAffectsEarlierTokens(t *testing.T) {
+	ctx := context.Background()
+	list := NewRedisList(newMemoryCache(), 15*time.Minute)
+	logoutAt := time.Now()
+
+	require.NoError(t, list.RevokeUserTokens(ctx, "user-1", logoutAt))
+
+	revoked, err := list.IsRevoked(ctx, claimsIssuedAt("user-1", "session-1", "token-1", logoutAt.Add(-time.Minute)))
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = list.IsRevoked(ctx, claimsIssuedAt("user-1", "session-2", "token-2", logoutAt.Add(time.Minute)))
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	revoked, err = list.IsRevoked(ctx, claimsIssuedAt("user-2", "session-3", "token-3", logoutAt.Add(-time.Minute)))
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
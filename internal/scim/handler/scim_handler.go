@@ -0,0 +1,478 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	apikeyModel "ethos/internal/apikey/model"
+	permissionModel "ethos/internal/permission/model"
+	"ethos/internal/scim/model"
+	"ethos/internal/scim/service"
+	"ethos/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// callerKey is the gin context key holding the authenticated *model.Caller
+const callerKey = "scim_caller"
+
+// KeyAuthenticator resolves API keys (implemented by the API key service)
+type KeyAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (*apikeyModel.Principal, error)
+}
+
+// MemberRoles looks up a member's current role (implemented by the organization context repository)
+type MemberRoles interface {
+	GetUserRoleInOrganization(ctx context.Context, userID, organizationID string) (string, error)
+}
+
+// Authorizer decides whether a member has a permission (implemented by the permission service)
+type Authorizer interface {
+	Authorize(ctx context.Context, subject permissionModel.Subject, permission string) (*permissionModel.Decision, error)
+}
+
+// SCIMHandler handles SCIM 2.0 provisioning requests. Responses use the
+// SCIM media type and error format rather than the API's usual JSON.
+type SCIMHandler struct {
+	service    service.Service
+	apiKeys    KeyAuthenticator
+	roles      MemberRoles
+	authorizer Authorizer
+}
+
+// NewSCIMHandler creates a new SCIM handler
+func NewSCIMHandler(svc service.Service, apiKeys KeyAuthenticator, roles MemberRoles, authorizer Authorizer) *SCIMHandler {
+	return &SCIMHandler{
+		service:    svc,
+		apiKeys:    apiKeys,
+		roles:      roles,
+		authorizer: authorizer,
+	}
+}
+
+// Authenticate accepts organization API keys with the scim:provision scope.
+// The key acts as the member who created it, so it stops working if they
+// leave, are suspended or lose org.members.write.
+func (h *SCIMHandler) Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !strings.HasPrefix(token, apikeyModel.TokenPrefix) {
+			writeError(c, http.StatusUnauthorized, "", "An organization API key is required")
+			c.Abort()
+			return
+		}
+
+		principal, err := h.apiKeys.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			h.respondError(c, err)
+			c.Abort()
+			return
+		}
+		if principal.OrganizationID == "" || !apikeyModel.HasScope(principal.Scopes, apikeyModel.ScopeSCIM) {
+			writeError(c, http.StatusForbidden, "", "API key must belong to an organization and have the "+apikeyModel.ScopeSCIM+" scope")
+			c.Abort()
+			return
+		}
+
+		role, err := h.roles.GetUserRoleInOrganization(c.Request.Context(), principal.UserID, principal.OrganizationID)
+		if err == errors.ErrNotFound {
+			writeError(c, http.StatusForbidden, "", "The API key's creator is no longer an active member of the organization")
+			c.Abort()
+			return
+		}
+		if err != nil {
+			h.respondError(c, err)
+			c.Abort()
+			return
+		}
+
+		caller := &model.Caller{
+			OrganizationID: principal.OrganizationID,
+			UserID:         principal.UserID,
+			Role:           role,
+			APIKeyID:       principal.KeyID,
+			IPAddress:      c.ClientIP(),
+			UserAgent:      c.Request.UserAgent(),
+		}
+		c.Set(callerKey, caller)
+		c.Set("user_id", principal.UserID)
+		c.Set("api_key_id", principal.KeyID)
+
+		h.Authorize(permissionModel.PermissionOrgMembersWrite)(c)
+	}
+}
+
+// Authorize allows the request only if the key's creator has the permission
+func (h *SCIMHandler) Authorize(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		caller := getCaller(c)
+		decision, err := h.authorizer.Authorize(c.Request.Context(), permissionModel.Subject{
+			UserID:           caller.UserID,
+			OrganizationID:   caller.OrganizationID,
+			OrganizationRole: caller.Role,
+		}, permission)
+		if err != nil {
+			h.respondError(c, err)
+			c.Abort()
+			return
+		}
+		if !decision.Allowed {
+			writeError(c, http.StatusForbidden, "", "The API key's creator can't do this: "+decision.Reason)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// ListUsers handles GET /scim/v2/Users
+func (h *SCIMHandler) ListUsers(c *gin.Context) {
+	query, err := parseListQuery(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	users, total, err := h.service.ListUsers(c.Request.Context(), getCaller(c), query)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	resources := make([]interface{}, 0, len(users))
+	for _, user := range users {
+		user.Meta.Location = baseURL(c) + "/Users/" + user.ID
+		resources = append(resources, user)
+	}
+	writeList(c, query, total, resources)
+}
+
+// GetUser handles GET /scim/v2/Users/:id
+func (h *SCIMHandler) GetUser(c *gin.Context) {
+	user, err := h.service.GetUser(c.Request.Context(), getCaller(c), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.writeUser(c, http.StatusOK, user)
+}
+
+// CreateUser handles POST /scim/v2/Users
+func (h *SCIMHandler) CreateUser(c *gin.Context) {
+	var req model.UserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "invalidSyntax", "Request body must be a SCIM User")
+		return
+	}
+
+	user, err := h.service.CreateUser(c.Request.Context(), getCaller(c), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.writeUser(c, http.StatusCreated, user)
+}
+
+// ReplaceUser handles PUT /scim/v2/Users/:id
+func (h *SCIMHandler) ReplaceUser(c *gin.Context) {
+	var req model.UserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "invalidSyntax", "Request body must be a SCIM User")
+		return
+	}
+
+	user, err := h.service.ReplaceUser(c.Request.Context(), getCaller(c), c.Param("id"), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.writeUser(c, http.StatusOK, user)
+}
+
+// PatchUser handles PATCH /scim/v2/Users/:id
+func (h *SCIMHandler) PatchUser(c *gin.Context) {
+	var req model.PatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "invalidSyntax", "Request body must be a SCIM PatchOp")
+		return
+	}
+
+	user, err := h.service.PatchUser(c.Request.Context(), getCaller(c), c.Param("id"), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.writeUser(c, http.StatusOK, user)
+}
+
+// DeleteUser handles DELETE /scim/v2/Users/:id
+func (h *SCIMHandler) DeleteUser(c *gin.Context) {
+	if err := h.service.DeleteUser(c.Request.Context(), getCaller(c), c.Param("id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListGroups handles GET /scim/v2/Groups
+func (h *SCIMHandler) ListGroups(c *gin.Context) {
+	query, err := parseListQuery(c)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	groups, total, err := h.service.ListGroups(c.Request.Context(), getCaller(c), query)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	resources := make([]interface{}, 0, len(groups))
+	for _, group := range groups {
+		setGroupLocations(c, group)
+		resources = append(resources, group)
+	}
+	writeList(c, query, total, resources)
+}
+
+// GetGroup handles GET /scim/v2/Groups/:id
+func (h *SCIMHandler) GetGroup(c *gin.Context) {
+	group, err := h.service.GetGroup(c.Request.Context(), getCaller(c), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.writeGroup(c, http.StatusOK, group)
+}
+
+// CreateGroup handles POST /scim/v2/Groups
+func (h *SCIMHandler) CreateGroup(c *gin.Context) {
+	var req model.GroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "invalidSyntax", "Request body must be a SCIM Group")
+		return
+	}
+
+	group, err := h.service.CreateGroup(c.Request.Context(), getCaller(c), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.writeGroup(c, http.StatusCreated, group)
+}
+
+// ReplaceGroup handles PUT /scim/v2/Groups/:id
+func (h *SCIMHandler) ReplaceGroup(c *gin.Context) {
+	var req model.GroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "invalidSyntax", "Request body must be a SCIM Group")
+		return
+	}
+
+	group, err := h.service.ReplaceGroup(c.Request.Context(), getCaller(c), c.Param("id"), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.writeGroup(c, http.StatusOK, group)
+}
+
+// PatchGroup handles PATCH /scim/v2/Groups/:id
+func (h *SCIMHandler) PatchGroup(c *gin.Context) {
+	var req model.PatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "invalidSyntax", "Request body must be a SCIM PatchOp")
+		return
+	}
+
+	group, err := h.service.PatchGroup(c.Request.Context(), getCaller(c), c.Param("id"), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.writeGroup(c, http.StatusOK, group)
+}
+
+// DeleteGroup handles DELETE /scim/v2/Groups/:id
+func (h *SCIMHandler) DeleteGroup(c *gin.Context) {
+	if err := h.service.DeleteGroup(c.Request.Context(), getCaller(c), c.Param("id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetServiceProviderConfig handles GET /scim/v2/ServiceProviderConfig
+func (h *SCIMHandler) GetServiceProviderConfig(c *gin.Context) {
+	unsupported := gin.H{"supported": false}
+	writeJSON(c, http.StatusOK, gin.H{
+		"schemas":        []string{model.SchemaServiceProviderConfig},
+		"patch":          gin.H{"supported": true},
+		"bulk":           gin.H{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         gin.H{"supported": true, "maxResults": model.MaxCount},
+		"changePassword": unsupported,
+		"sort":           unsupported,
+		"etag":           unsupported,
+		"authenticationSchemes": []gin.H{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "An organization API key with the " + apikeyModel.ScopeSCIM + " scope",
+		}},
+		"meta": gin.H{"resourceType": "ServiceProviderConfig", "location": baseURL(c) + "/ServiceProviderConfig"},
+	})
+}
+
+// GetResourceTypes handles GET /scim/v2/ResourceTypes
+func (h *SCIMHandler) GetResourceTypes(c *gin.Context) {
+	resourceTypes := []interface{}{
+		gin.H{
+			"schemas":  []string{model.SchemaResourceType},
+			"id":       "User",
+			"name":     "User",
+			"endpoint": "/Users",
+			"schema":   model.SchemaUser,
+			"meta":     gin.H{"resourceType": "ResourceType", "location": baseURL(c) + "/ResourceTypes/User"},
+		},
+		gin.H{
+			"schemas":  []string{model.SchemaResourceType},
+			"id":       "Group",
+			"name":     "Group",
+			"endpoint": "/Groups",
+			"schema":   model.SchemaGroup,
+			"meta":     gin.H{"resourceType": "ResourceType", "location": baseURL(c) + "/ResourceTypes/Group"},
+		},
+	}
+	writeJSON(c, http.StatusOK, model.ListResponse{
+		Schemas:      []string{model.SchemaListResponse},
+		TotalResults: len(resourceTypes),
+		StartIndex:   1,
+		ItemsPerPage: len(resourceTypes),
+		Resources:    resourceTypes,
+	})
+}
+
+func (h *SCIMHandler) writeUser(c *gin.Context, status int, user *model.User) {
+	user.Meta.Location = baseURL(c) + "/Users/" + user.ID
+	c.Header("Location", user.Meta.Location)
+	writeJSON(c, status, user)
+}
+
+func (h *SCIMHandler) writeGroup(c *gin.Context, status int, group *model.Group) {
+	setGroupLocations(c, group)
+	c.Header("Location", group.Meta.Location)
+	writeJSON(c, status, group)
+}
+
+// respondError writes an error in the SCIM format, with a scimType where RFC 7644 defines one
+func (h *SCIMHandler) respondError(c *gin.Context, err error) {
+	apiErr, ok := err.(*errors.APIError)
+	if !ok {
+		log.Printf("SCIM request failed: %v", err)
+		writeError(c, http.StatusInternalServerError, "", "Internal server error")
+		return
+	}
+
+	scimType := ""
+	switch {
+	case apiErr.Code == "INVALID_FILTER":
+		scimType = "invalidFilter"
+	case apiErr.HTTPStatus == http.StatusBadRequest:
+		scimType = "invalidValue"
+	case apiErr.HTTPStatus == http.StatusConflict:
+		scimType = "uniqueness"
+	}
+	writeError(c, apiErr.HTTPStatus, scimType, apiErr.Message)
+}
+
+// parseListQuery reads the filter, startIndex and count parameters. Out of
+// range values are clamped, as RFC 7644 asks.
+func parseListQuery(c *gin.Context) (*model.ListQuery, error) {
+	query := &model.ListQuery{StartIndex: 1, Count: model.DefaultCount}
+
+	if filter := strings.TrimSpace(c.Query("filter")); filter != "" {
+		parsed, err := model.ParseFilter(filter)
+		if err != nil {
+			return nil, errors.NewInvalidFilterError(err.Error())
+		}
+		query.Filter = parsed
+	}
+
+	if raw := c.Query("startIndex"); raw != "" {
+		startIndex, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, errors.NewValidationError("startIndex must be an integer")
+		}
+		if startIndex > 1 {
+			query.StartIndex = startIndex
+		}
+	}
+
+	if raw := c.Query("count"); raw != "" {
+		count, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, errors.NewValidationError("count must be an integer")
+		}
+		switch {
+		case count < 0:
+			query.Count = 0
+		case count > model.MaxCount:
+			query.Count = model.MaxCount
+		default:
+			query.Count = count
+		}
+	}
+	return query, nil
+}
+
+func writeList(c *gin.Context, query *model.ListQuery, total int, resources []interface{}) {
+	writeJSON(c, http.StatusOK, model.ListResponse{
+		Schemas:      []string{model.SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   query.StartIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func writeError(c *gin.Context, status int, scimType, detail string) {
+	writeJSON(c, status, model.Error{
+		Schemas:  []string{model.SchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+// writeJSON writes a response with the SCIM media type
+func writeJSON(c *gin.Context, status int, body interface{}) {
+	c.Header("Content-Type", model.ContentType+"; charset=utf-8")
+	c.JSON(status, body)
+}
+
+func setGroupLocations(c *gin.Context, group *model.Group) {
+	group.Meta.Location = baseURL(c) + "/Groups/" + group.ID
+	for i := range group.Members {
+		group.Members[i].Ref = baseURL(c) + "/Users/" + group.Members[i].Value
+	}
+}
+
+// baseURL is the SCIM endpoint's public URL, for meta.location
+func baseURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + c.Request.Host + "/scim/v2"
+}
+
+func getCaller(c *gin.Context) *model.Caller {
+	caller, _ := c.Get(callerKey)
+	return caller.(*model.Caller)
+}
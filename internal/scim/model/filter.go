@@ -0,0 +1,279 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Filter is a parsed RFC 7644 filter expression. Logical nodes ("and",
+// "or", "not") use Left and Right; comparisons use Attr and Value.
+type Filter struct {
+	Op    string
+	Left  *Filter
+	Right *Filter
+	// Attr is the lowercased attribute path, without any schema URN
+	Attr string
+	// Value is a string, bool, float64 or nil
+	Value interface{}
+}
+
+// comparisonOps are the RFC 7644 attribute operators
+var comparisonOps = map[string]bool{
+	"eq": true, "ne": true, "co": true, "sw": true, "ew": true,
+	"gt": true, "ge": true, "lt": true, "le": true, "pr": true,
+}
+
+// FilterError reports a filter that can't be parsed or isn't supported
+type FilterError struct {
+	Message string
+}
+
+func (e *FilterError) Error() string {
+	return e.Message
+}
+
+// ParseFilter parses a filter expression such as
+// `userName eq "jane@acme.com" and active eq true`. Complex attribute
+// filters (`emails[type eq "work"]`) aren't supported.
+func ParseFilter(input string) (*Filter, error) {
+	tokens, err := tokenizeFilter(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, &FilterError{Message: fmt.Sprintf("unexpected %q in filter", p.tokens[p.pos].text)}
+	}
+	return f, nil
+}
+
+// Match evaluates a filter against a resource's attribute values,
+// comparing strings case-insensitively. Attributes missing from values
+// never match.
+func (f *Filter) Match(values map[string][]string) bool {
+	switch f.Op {
+	case "and":
+		return f.Left.Match(values) && f.Right.Match(values)
+	case "or":
+		return f.Left.Match(values) || f.Right.Match(values)
+	case "not":
+		return !f.Left.Match(values)
+	}
+
+	attrValues := values[f.Attr]
+	if f.Op == "pr" {
+		for _, v := range attrValues {
+			if v != "" {
+				return true
+			}
+		}
+		return false
+	}
+
+	want := strings.ToLower(fmt.Sprint(f.Value))
+	matched := false
+	for _, v := range attrValues {
+		v = strings.ToLower(v)
+		switch f.Op {
+		case "eq", "ne":
+			matched = v == want
+		case "co":
+			matched = strings.Contains(v, want)
+		case "sw":
+			matched = strings.HasPrefix(v, want)
+		case "ew":
+			matched = strings.HasSuffix(v, want)
+		case "gt":
+			matched = v > want
+		case "ge":
+			matched = v >= want
+		case "lt":
+			matched = v < want
+		case "le":
+			matched = v <= want
+		}
+		if matched {
+			break
+		}
+	}
+	if f.Op == "ne" {
+		return !matched
+	}
+	return matched
+}
+
+type filterToken struct {
+	text   string
+	quoted bool
+}
+
+// tokenizeFilter splits a filter into words, parentheses and quoted strings
+func tokenizeFilter(input string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(input); {
+		switch c := input[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, filterToken{text: string(c)})
+			i++
+		case c == '[' || c == ']':
+			return nil, &FilterError{Message: "complex attribute filters are not supported"}
+		case c == '"':
+			end := i + 1
+			for ; end < len(input); end++ {
+				if input[end] == '\\' {
+					end++
+				} else if input[end] == '"' {
+					break
+				}
+			}
+			if end >= len(input) {
+				return nil, &FilterError{Message: "unterminated string in filter"}
+			}
+			var value string
+			if err := json.Unmarshal([]byte(input[i:end+1]), &value); err != nil {
+				return nil, &FilterError{Message: "invalid string in filter"}
+			}
+			tokens = append(tokens, filterToken{text: value, quoted: true})
+			i = end + 1
+		default:
+			end := i
+			for end < len(input) && !strings.ContainsRune(" \t()[]\"", rune(input[end])) {
+				end++
+			}
+			tokens = append(tokens, filterToken{text: input[i:end]})
+			i = end
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, &FilterError{Message: "filter is empty"}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peekKeyword(keyword string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && strings.EqualFold(p.tokens[p.pos].text, keyword)
+}
+
+func (p *filterParser) parseOr() (*Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Filter{Op: "or", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (*Filter, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("and") {
+		p.pos++
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = &Filter{Op: "and", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseFactor() (*Filter, error) {
+	if p.peekKeyword("not") {
+		p.pos++
+		if !p.peekKeyword("(") {
+			return nil, &FilterError{Message: "not must be followed by a parenthesized filter"}
+		}
+		inner, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return &Filter{Op: "not", Left: inner}, nil
+	}
+
+	if p.peekKeyword("(") {
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peekKeyword(")") {
+			return nil, &FilterError{Message: "missing ) in filter"}
+		}
+		p.pos++
+		return inner, nil
+	}
+
+	if p.pos+1 >= len(p.tokens) || p.tokens[p.pos].quoted {
+		return nil, &FilterError{Message: "expected an attribute and operator in filter"}
+	}
+	attr := normalizeAttr(p.tokens[p.pos].text)
+	op := strings.ToLower(p.tokens[p.pos+1].text)
+	if !comparisonOps[op] || p.tokens[p.pos+1].quoted {
+		return nil, &FilterError{Message: fmt.Sprintf("unknown filter operator %q", p.tokens[p.pos+1].text)}
+	}
+	p.pos += 2
+	if op == "pr" {
+		return &Filter{Op: op, Attr: attr}, nil
+	}
+
+	if p.pos >= len(p.tokens) {
+		return nil, &FilterError{Message: fmt.Sprintf("missing value after %s", op)}
+	}
+	value, err := parseFilterValue(p.tokens[p.pos])
+	if err != nil {
+		return nil, err
+	}
+	p.pos++
+	return &Filter{Op: op, Attr: attr, Value: value}, nil
+}
+
+func parseFilterValue(token filterToken) (interface{}, error) {
+	if token.quoted {
+		return token.text, nil
+	}
+	switch strings.ToLower(token.text) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if n, err := strconv.ParseFloat(token.text, 64); err == nil {
+		return n, nil
+	}
+	return nil, &FilterError{Message: fmt.Sprintf("invalid filter value %q", token.text)}
+}
+
+// normalizeAttr lowercases an attribute path and strips a core schema URN prefix
+func normalizeAttr(attr string) string {
+	attr = strings.ToLower(attr)
+	for _, schema := range []string{SchemaUser, SchemaGroup} {
+		if prefix := strings.ToLower(schema) + ":"; strings.HasPrefix(attr, prefix) {
+			return strings.TrimPrefix(attr, prefix)
+		}
+	}
+	return attr
+}
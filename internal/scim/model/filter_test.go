@@ -0,0 +1,67 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	f, err := ParseFilter(`userName Eq "jane@acme.com" and (active eq true or not (externalId pr))`)
+	require.NoError(t, err)
+
+	assert.Equal(t, "and", f.Op)
+	assert.Equal(t, &Filter{Op: "eq", Attr: "username", Value: "jane@acme.com"}, f.Left)
+	assert.Equal(t, "or", f.Right.Op)
+	assert.Equal(t, &Filter{Op: "eq", Attr: "active", Value: true}, f.Right.Left)
+	assert.Equal(t, "not", f.Right.Right.Op)
+	assert.Equal(t, &Filter{Op: "pr", Attr: "externalid"}, f.Right.Right.Left)
+}
+
+func TestParseFilter_SchemaPrefixAndEscapes(t *testing.T) {
+	f, err := ParseFilter(`urn:ietf:params:scim:schemas:core:2.0:User:name.familyName co "O\"Brien"`)
+	require.NoError(t, err)
+
+	assert.Equal(t, &Filter{Op: "co", Attr: "name.familyname", Value: `O"Brien`}, f)
+}
+
+func TestParseFilter_Invalid(t *testing.T) {
+	for _, input := range []string{
+		``,
+		`userName`,
+		`userName is "jane"`,
+		`userName eq`,
+		`userName eq "jane`,
+		`userName eq jane`,
+		`(userName eq "jane"`,
+		`userName eq "jane" extra`,
+		`emails[type eq "work"]`,
+		`not userName eq "jane"`,
+	} {
+		_, err := ParseFilter(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	values := map[string][]string{
+		"displayname": {"Engineering"},
+		"members":     {"user-1", "user-2"},
+	}
+
+	for input, want := range map[string]bool{
+		`displayName eq "engineering"`:                   true,
+		`displayName sw "eng" and members eq "user-2"`:   true,
+		`displayName ne "engineering"`:                   false,
+		`members eq "user-3" or displayName ew "ring"`:   true,
+		`not (members eq "user-1")`:                      false,
+		`members pr`:                                     true,
+		`id pr`:                                          false,
+		`displayName co "neer" and not (members co "9")`: true,
+	} {
+		f, err := ParseFilter(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, f.Match(values), input)
+	}
+}
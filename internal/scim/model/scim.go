@@ -0,0 +1,168 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Schema URNs from RFC 7643 and RFC 7644
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+)
+
+// ContentType is the media type of SCIM requests and responses
+const ContentType = "application/scim+json"
+
+// Paging limits. RFC 7644 lets servers cap count; clients page with startIndex.
+const (
+	DefaultCount = 100
+	MaxCount     = 200
+)
+
+// Activity log actions recorded for SCIM provisioning
+const (
+	ActionUserProvisioned   = "scim_user_provisioned"
+	ActionUserUpdated       = "scim_user_updated"
+	ActionUserDeactivated   = "scim_user_deactivated"
+	ActionUserReactivated   = "scim_user_reactivated"
+	ActionUserDeprovisioned = "scim_user_deprovisioned"
+	ActionGroupCreated      = "scim_group_created"
+	ActionGroupUpdated      = "scim_group_updated"
+	ActionGroupDeleted      = "scim_group_deleted"
+)
+
+// Member is an organization member as SCIM sees them
+type Member struct {
+	UserID     string
+	Email      string
+	FirstName  string
+	LastName   string
+	ExternalID string
+	Role       string
+	// Active is false while the member is suspended from the organization
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Name is a user's name
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// MultiValue is a multi-valued attribute entry, such as an email address
+type MultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// Meta describes a resource
+type Meta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+// User is the SCIM User resource
+type User struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	UserName    string       `json:"userName"`
+	Name        *Name        `json:"name,omitempty"`
+	DisplayName string       `json:"displayName,omitempty"`
+	Emails      []MultiValue `json:"emails,omitempty"`
+	Active      bool         `json:"active"`
+	Groups      []MultiValue `json:"groups,omitempty"`
+	Meta        Meta         `json:"meta"`
+}
+
+// Group is the SCIM Group resource. Groups are organization roles: a
+// group's members are the members holding the role.
+type Group struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id"`
+	DisplayName string       `json:"displayName"`
+	Members     []MultiValue `json:"members"`
+	Meta        Meta         `json:"meta"`
+}
+
+// UserRequest is the body of a User create or replace
+type UserRequest struct {
+	Schemas    []string     `json:"schemas"`
+	ExternalID string       `json:"externalId"`
+	UserName   string       `json:"userName"`
+	Name       *Name        `json:"name"`
+	Emails     []MultiValue `json:"emails"`
+	// Active defaults to true when omitted
+	Active *bool `json:"active"`
+}
+
+// GroupRequest is the body of a Group create or replace
+type GroupRequest struct {
+	Schemas     []string     `json:"schemas"`
+	DisplayName string       `json:"displayName"`
+	Members     []MultiValue `json:"members"`
+}
+
+// PatchRequest is the body of a PATCH
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation is one add, remove or replace operation
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ListQuery holds the filter and pagination parameters of a list request
+type ListQuery struct {
+	Filter *Filter
+	// StartIndex is 1-based
+	StartIndex int
+	Count      int
+}
+
+// ListResponse is a page of resources
+type ListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// Error is a SCIM error response
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// Caller is the identity provider making a request: an organization API key
+// acting as the member who created it
+type Caller struct {
+	OrganizationID string
+	UserID         string
+	// Role is the key creator's current role, which bounds the roles they can hand out
+	Role      string
+	APIKeyID  string
+	IPAddress string
+	UserAgent string
+}
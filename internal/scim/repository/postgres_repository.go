@@ -0,0 +1,399 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ethos/internal/database"
+	orgRepository "ethos/internal/organization/repository"
+	"ethos/internal/scim/model"
+	"ethos/pkg/errors"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// activeExpr is true unless the member is currently suspended
+const activeExpr = `NOT (om.suspended_at IS NOT NULL AND (om.suspended_until IS NULL OR om.suspended_until > NOW()))`
+
+const memberColumns = `
+	u.id, u.email, COALESCE(u.first_name, ''), COALESCE(u.last_name, ''), COALESCE(om.scim_external_id, ''),
+	om.role, ` + activeExpr + `, om.joined_at, GREATEST(om.updated_at, u.updated_at)
+`
+
+const memberFrom = `
+	FROM organization_members om
+	JOIN users u ON u.id = om.user_id
+	WHERE om.organization_id::text = $1
+`
+
+// Kinds of filterable column
+const (
+	columnExact  = "exact"
+	columnIgnore = "caseIgnore"
+	columnBool   = "bool"
+	columnTime   = "time"
+)
+
+type filterColumn struct {
+	expr string
+	kind string
+}
+
+// userColumns maps the User attributes clients may filter on to SQL.
+// Attribute names are lowercase, as model.ParseFilter leaves them.
+var userColumns = map[string]filterColumn{
+	"id":                {"u.id", columnExact},
+	"username":          {"u.email", columnIgnore},
+	"emails":            {"u.email", columnIgnore},
+	"emails.value":      {"u.email", columnIgnore},
+	"externalid":        {"om.scim_external_id", columnExact},
+	"name.givenname":    {"u.first_name", columnIgnore},
+	"name.familyname":   {"u.last_name", columnIgnore},
+	"displayname":       {"TRIM(COALESCE(u.first_name, '') || ' ' || COALESCE(u.last_name, ''))", columnIgnore},
+	"groups":            {"om.role", columnIgnore},
+	"groups.value":      {"om.role", columnIgnore},
+	"active":            {activeExpr, columnBool},
+	"meta.created":      {"om.joined_at", columnTime},
+	"meta.lastmodified": {"GREATEST(om.updated_at, u.updated_at)", columnTime},
+}
+
+// PostgresRepository implements the Repository interface using PostgreSQL
+type PostgresRepository struct {
+	db *database.DB
+}
+
+// NewPostgresRepository creates a new PostgreSQL repository
+func NewPostgresRepository(db *database.DB) Repository {
+	return &PostgresRepository{db: db}
+}
+
+// ListMembers returns a page of an organization's members matching the query
+func (r *PostgresRepository) ListMembers(ctx context.Context, organizationID string, query *model.ListQuery) ([]*model.Member, int, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ListMembers")
+	defer span.End()
+
+	args := []interface{}{organizationID}
+	where := ""
+	if query.Filter != nil {
+		condition, err := filterSQL(query.Filter, &args)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, 0, err
+		}
+		where = " AND (" + condition + ")"
+	}
+
+	var total int
+	if err := r.db.Pool.QueryRow(ctx, `SELECT COUNT(*) `+memberFrom+where, args...).Scan(&total); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, 0, errors.WrapError(err, "failed to count members")
+	}
+	if query.Count == 0 || total < query.StartIndex {
+		span.SetStatus(codes.Ok, "")
+		return []*model.Member{}, total, nil
+	}
+
+	args = append(args, query.Count, query.StartIndex-1)
+	sql := `SELECT ` + memberColumns + memberFrom + where +
+		fmt.Sprintf(` ORDER BY om.joined_at, u.id LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+
+	members, err := r.queryMembers(ctx, sql, args...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, 0, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return members, total, nil
+}
+
+// ListAllMembers lists every member of an organization
+func (r *PostgresRepository) ListAllMembers(ctx context.Context, organizationID string) ([]*model.Member, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ListAllMembers")
+	defer span.End()
+
+	members, err := r.queryMembers(ctx, `SELECT `+memberColumns+memberFrom+` ORDER BY om.joined_at, u.id`, organizationID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return members, nil
+}
+
+// GetMember retrieves a member
+func (r *PostgresRepository) GetMember(ctx context.Context, organizationID, userID string) (*model.Member, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetMember")
+	defer span.End()
+
+	member, err := scanMember(r.db.Pool.QueryRow(ctx, `SELECT `+memberColumns+memberFrom+` AND om.user_id = $2`, organizationID, userID))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, "failed to get member")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return member, nil
+}
+
+// AddMember adds a user as a member under the organization's seat lock
+func (r *PostgresRepository) AddMember(ctx context.Context, organizationID, userID, role, externalID string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.AddMember")
+	defer span.End()
+
+	err := r.addMember(ctx, organizationID, userID, role, externalID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+func (r *PostgresRepository) addMember(ctx context.Context, organizationID, userID, role, externalID string) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return errors.WrapError(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	if err := orgRepository.LockSeats(ctx, tx, organizationID); err != nil {
+		return err
+	}
+
+	var isMember bool
+	if err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM organization_members WHERE organization_id::text = $1 AND user_id = $2)`,
+		organizationID, userID,
+	).Scan(&isMember); err != nil {
+		return errors.WrapError(err, "failed to check existing membership")
+	}
+	if isMember {
+		return errors.ErrAlreadyMember
+	}
+
+	if err := orgRepository.CheckSeatAvailable(ctx, tx, organizationID, ""); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO organization_members (organization_id, user_id, role, scim_external_id, joined_at)
+		VALUES ($1::uuid, $2, $3, NULLIF($4, ''), NOW())`,
+		organizationID, userID, role, externalID,
+	); err != nil {
+		if isUniqueViolation(err) {
+			return errors.ErrExternalIDTaken
+		}
+		return errors.WrapError(err, "failed to add organization member")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.WrapError(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+// SetExternalID replaces a member's identity provider ID
+func (r *PostgresRepository) SetExternalID(ctx context.Context, organizationID, userID, externalID string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.SetExternalID")
+	defer span.End()
+
+	result, err := r.db.Pool.Exec(ctx, `
+		UPDATE organization_members
+		SET scim_external_id = NULLIF($3, ''), updated_at = NOW()
+		WHERE organization_id::text = $1 AND user_id = $2`,
+		organizationID, userID, externalID,
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if isUniqueViolation(err) {
+			return errors.ErrExternalIDTaken
+		}
+		return errors.WrapError(err, "failed to set external ID")
+	}
+	if result.RowsAffected() == 0 {
+		span.SetStatus(codes.Error, "member not found")
+		return errors.ErrNotFound
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+func (r *PostgresRepository) queryMembers(ctx context.Context, sql string, args ...interface{}) ([]*model.Member, error) {
+	rows, err := r.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to list members")
+	}
+	defer rows.Close()
+
+	members := []*model.Member{}
+	for rows.Next() {
+		member, err := scanMember(rows)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to scan member")
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, "failed to list members")
+	}
+	return members, nil
+}
+
+func scanMember(row pgx.Row) (*model.Member, error) {
+	var member model.Member
+	err := row.Scan(
+		&member.UserID,
+		&member.Email,
+		&member.FirstName,
+		&member.LastName,
+		&member.ExternalID,
+		&member.Role,
+		&member.Active,
+		&member.CreatedAt,
+		&member.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// filterSQL translates a filter into a SQL condition, appending its
+// parameters to args
+func filterSQL(f *model.Filter, args *[]interface{}) (string, error) {
+	switch f.Op {
+	case "and", "or":
+		left, err := filterSQL(f.Left, args)
+		if err != nil {
+			return "", err
+		}
+		right, err := filterSQL(f.Right, args)
+		if err != nil {
+			return "", err
+		}
+		return "(" + left + " " + strings.ToUpper(f.Op) + " " + right + ")", nil
+	case "not":
+		inner, err := filterSQL(f.Left, args)
+		if err != nil {
+			return "", err
+		}
+		return "NOT COALESCE(" + inner + ", FALSE)", nil
+	}
+
+	column, ok := userColumns[f.Attr]
+	if !ok {
+		return "", errors.NewInvalidFilterError(fmt.Sprintf("filtering on %q is not supported", f.Attr))
+	}
+
+	switch column.kind {
+	case columnBool:
+		return boolCondition(column, f, args)
+	case columnTime:
+		return timeCondition(column, f, args)
+	default:
+		return textCondition(column, f, args)
+	}
+}
+
+func textCondition(column filterColumn, f *model.Filter, args *[]interface{}) (string, error) {
+	if f.Op == "pr" {
+		return "(" + column.expr + " IS NOT NULL AND " + column.expr + " <> '')", nil
+	}
+
+	value, ok := f.Value.(string)
+	if !ok {
+		return "", errors.NewInvalidFilterError(fmt.Sprintf("%s must be compared with a string", f.Attr))
+	}
+	lhs := column.expr
+	if column.kind == columnIgnore {
+		lhs = "LOWER(" + lhs + ")"
+		value = strings.ToLower(value)
+	}
+
+	switch f.Op {
+	case "co", "sw", "ew":
+		value = escapeLike(value)
+		if f.Op != "sw" {
+			value = "%" + value
+		}
+		if f.Op != "ew" {
+			value = value + "%"
+		}
+		return lhs + " LIKE " + addArg(args, value), nil
+	case "ne":
+		return "(" + lhs + " IS NULL OR " + lhs + " <> " + addArg(args, value) + ")", nil
+	}
+	return lhs + " " + sqlOperator(f.Op) + " " + addArg(args, value), nil
+}
+
+func boolCondition(column filterColumn, f *model.Filter, args *[]interface{}) (string, error) {
+	if f.Op == "pr" {
+		return "TRUE", nil
+	}
+	value, ok := f.Value.(bool)
+	if !ok || (f.Op != "eq" && f.Op != "ne") {
+		return "", errors.NewInvalidFilterError(fmt.Sprintf("%s supports only eq and ne with true or false", f.Attr))
+	}
+	return "(" + column.expr + ") " + sqlOperator(f.Op) + " " + addArg(args, value), nil
+}
+
+func timeCondition(column filterColumn, f *model.Filter, args *[]interface{}) (string, error) {
+	if f.Op == "pr" {
+		return column.expr + " IS NOT NULL", nil
+	}
+	raw, _ := f.Value.(string)
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil || f.Op == "co" || f.Op == "sw" || f.Op == "ew" {
+		return "", errors.NewInvalidFilterError(fmt.Sprintf("%s must be compared with an RFC 3339 date-time", f.Attr))
+	}
+	return column.expr + " " + sqlOperator(f.Op) + " " + addArg(args, value), nil
+}
+
+func sqlOperator(op string) string {
+	switch op {
+	case "ne":
+		return "<>"
+	case "gt":
+		return ">"
+	case "ge":
+		return ">="
+	case "lt":
+		return "<"
+	case "le":
+		return "<="
+	}
+	return "="
+}
+
+// addArg appends a parameter and returns its placeholder
+func addArg(args *[]interface{}, value interface{}) string {
+	*args = append(*args, value)
+	return fmt.Sprintf("$%d", len(*args))
+}
+
+// escapeLike escapes LIKE wildcards, using PostgreSQL's default backslash escape
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+func isUniqueViolation(err error) bool {
+	return strings.Contains(err.Error(), "duplicate key value") || strings.Contains(err.Error(), "23505")
+}
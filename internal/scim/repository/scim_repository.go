@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"ethos/internal/scim/model"
+)
+
+// Repository defines the interface for SCIM data access
+type Repository interface {
+	// ListMembers returns a page of an organization's members matching the
+	// query's filter, and how many match in total. Returns an invalid filter
+	// error for attributes that can't be filtered on.
+	ListMembers(ctx context.Context, organizationID string, query *model.ListQuery) ([]*model.Member, int, error)
+
+	// ListAllMembers lists every member of an organization, for building groups
+	ListAllMembers(ctx context.Context, organizationID string) ([]*model.Member, error)
+
+	// GetMember retrieves a member, or returns ErrNotFound
+	GetMember(ctx context.Context, organizationID, userID string) (*model.Member, error)
+
+	// AddMember adds a user as a member if a seat is free. Returns
+	// ErrAlreadyMember, ErrSeatLimitReached or ErrExternalIDTaken.
+	AddMember(ctx context.Context, organizationID, userID, role, externalID string) error
+
+	// SetExternalID replaces a member's identity provider ID. Returns
+	// ErrNotFound or ErrExternalIDTaken.
+	SetExternalID(ctx context.Context, organizationID, userID, externalID string) error
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	"ethos/internal/scim/model"
+)
+
+// Service defines the interface for SCIM provisioning. Users are
+// organization members and groups are organization roles.
+type Service interface {
+	// ListUsers returns a page of the organization's members and how many match in total
+	ListUsers(ctx context.Context, caller *model.Caller, query *model.ListQuery) ([]*model.User, int, error)
+
+	// GetUser retrieves a member
+	GetUser(ctx context.Context, caller *model.Caller, id string) (*model.User, error)
+
+	// CreateUser adds a user at one of the organization's verified domains as
+	// a member, creating their account if needed
+	CreateUser(ctx context.Context, caller *model.Caller, req *model.UserRequest) (*model.User, error)
+
+	// ReplaceUser replaces a member's name, external ID and active state
+	ReplaceUser(ctx context.Context, caller *model.Caller, id string, req *model.UserRequest) (*model.User, error)
+
+	// PatchUser applies PATCH operations to a member
+	PatchUser(ctx context.Context, caller *model.Caller, id string, req *model.PatchRequest) (*model.User, error)
+
+	// DeleteUser removes a member from the organization. Their account is kept.
+	DeleteUser(ctx context.Context, caller *model.Caller, id string) error
+
+	// ListGroups returns a page of the organization's roles and how many match in total
+	ListGroups(ctx context.Context, caller *model.Caller, query *model.ListQuery) ([]*model.Group, int, error)
+
+	// GetGroup retrieves a role with its members
+	GetGroup(ctx context.Context, caller *model.Caller, id string) (*model.Group, error)
+
+	// CreateGroup creates a custom role with no permissions and gives it to the listed members
+	CreateGroup(ctx context.Context, caller *model.Caller, req *model.GroupRequest) (*model.Group, error)
+
+	// ReplaceGroup makes the listed members exactly those holding a role
+	ReplaceGroup(ctx context.Context, caller *model.Caller, id string, req *model.GroupRequest) (*model.Group, error)
+
+	// PatchGroup applies PATCH operations to a role's members
+	PatchGroup(ctx context.Context, caller *model.Caller, id string, req *model.PatchRequest) (*model.Group, error)
+
+	// DeleteGroup deletes a custom role, moving its members back to the member role
+	DeleteGroup(ctx context.Context, caller *model.Caller, id string) error
+}
@@ -0,0 +1,916 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	authModel "ethos/internal/auth/model"
+	orgdomainModel "ethos/internal/orgdomain/model"
+	permissionModel "ethos/internal/permission/model"
+	"ethos/internal/scim/model"
+	"ethos/internal/scim/repository"
+	"ethos/pkg/errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// memberRole is the role provisioned users start with, and the role
+	// members go back to when removed from a group
+	memberRole = "member"
+
+	// ownerRole is never granted or taken away over SCIM, as with SSO
+	ownerRole = "owner"
+
+	// deactivationReason is recorded on suspensions made by identity providers
+	deactivationReason = "Deactivated by SCIM"
+
+	// groupRoleDescription describes roles created for SCIM groups
+	groupRoleDescription = "Provisioned by SCIM"
+)
+
+// memberPathPattern matches a PATCH path selecting one group member
+var memberPathPattern = regexp.MustCompile(`(?i)^members\[\s*value\s+eq\s+"([^"]*)"\s*\]$`)
+
+// groupFilterAttributes are the Group attributes clients may filter on
+var groupFilterAttributes = map[string]bool{
+	"id": true, "displayname": true, "members": true, "members.value": true,
+}
+
+// UserStore looks up, creates and updates users (implemented by the auth repository)
+type UserStore interface {
+	GetUserByEmail(ctx context.Context, email string) (*authModel.User, error)
+	GetUserByID(ctx context.Context, userID string) (*authModel.User, error)
+	CreateUser(ctx context.Context, user *authModel.User) error
+	UpdateUser(ctx context.Context, user *authModel.User) error
+}
+
+// DomainVerifier finds verified domain claims (implemented by the organization domain repository)
+type DomainVerifier interface {
+	GetVerifiedDomain(ctx context.Context, domain string) (*orgdomainModel.Domain, error)
+}
+
+// MemberRemover removes organization members (implemented by the organization repository)
+type MemberRemover interface {
+	RemoveOrganizationMember(ctx context.Context, orgID, userID string) error
+}
+
+// Suspender suspends organization members (implemented by the organization service)
+type Suspender interface {
+	SuspendOrganizationUser(ctx context.Context, orgID, userID, reason string, duration *int, adminID string) error
+	UnsuspendOrganizationUser(ctx context.Context, orgID, userID, adminID string) error
+}
+
+// RoleManager manages organization roles (implemented by the permission service)
+type RoleManager interface {
+	ListRoles(ctx context.Context, organizationID string) ([]*permissionModel.Role, error)
+	CreateRole(ctx context.Context, actor permissionModel.Subject, req *permissionModel.CreateRoleRequest) (*permissionModel.Role, error)
+	DeleteRole(ctx context.Context, organizationID, roleID string) error
+	AssignRole(ctx context.Context, actor permissionModel.Subject, userID string, req *permissionModel.AssignRoleRequest) error
+}
+
+// ActivityLogger writes the organization activity log (implemented by the organization context repository)
+type ActivityLogger interface {
+	LogOrganizationActivity(ctx context.Context, organizationID, userID, action, resourceType, resourceID, ipAddress, userAgent string, changes map[string]interface{}) error
+}
+
+// SCIMService implements the Service interface
+type SCIMService struct {
+	repo      repository.Repository
+	users     UserStore
+	domains   DomainVerifier
+	members   MemberRemover
+	suspender Suspender
+	roles     RoleManager
+	activity  ActivityLogger
+}
+
+// NewSCIMService creates a new SCIM service
+func NewSCIMService(repo repository.Repository, users UserStore, domains DomainVerifier, members MemberRemover, suspender Suspender, roles RoleManager, activity ActivityLogger) Service {
+	return &SCIMService{
+		repo:      repo,
+		users:     users,
+		domains:   domains,
+		members:   members,
+		suspender: suspender,
+		roles:     roles,
+		activity:  activity,
+	}
+}
+
+// ListUsers returns a page of the organization's members
+func (s *SCIMService) ListUsers(ctx context.Context, caller *model.Caller, query *model.ListQuery) ([]*model.User, int, error) {
+	members, total, err := s.repo.ListMembers(ctx, caller.OrganizationID, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	users := make([]*model.User, 0, len(members))
+	for _, member := range members {
+		users = append(users, toUser(member))
+	}
+	return users, total, nil
+}
+
+// GetUser retrieves a member
+func (s *SCIMService) GetUser(ctx context.Context, caller *model.Caller, id string) (*model.User, error) {
+	member, err := s.repo.GetMember(ctx, caller.OrganizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	return toUser(member), nil
+}
+
+// CreateUser adds a user as a member. Only addresses at domains the
+// organization has verified can be provisioned, so an identity provider
+// can't pull in accounts it doesn't own.
+func (s *SCIMService) CreateUser(ctx context.Context, caller *model.Caller, req *model.UserRequest) (*model.User, error) {
+	address := strings.ToLower(strings.TrimSpace(req.UserName))
+	at := strings.LastIndex(address, "@")
+	if at < 1 || at == len(address)-1 {
+		return nil, errors.NewValidationError("userName must be the user's email address")
+	}
+	if err := s.requireVerifiedDomain(ctx, caller.OrganizationID, address[at+1:]); err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.GetUserByEmail(ctx, address)
+	createdAccount := false
+	if err == errors.ErrUserNotFound {
+		user, err = s.createUser(ctx, address, req.Name)
+		createdAccount = true
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AddMember(ctx, caller.OrganizationID, user.ID, memberRole, strings.TrimSpace(req.ExternalID)); err != nil {
+		return nil, err
+	}
+	s.logActivity(ctx, caller, model.ActionUserProvisioned, "organization_member", user.ID, map[string]interface{}{
+		"email":           address,
+		"created_account": createdAccount,
+	})
+
+	if req.Active != nil && !*req.Active {
+		member, err := s.repo.GetMember(ctx, caller.OrganizationID, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.setActive(ctx, caller, member, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetUser(ctx, caller, user.ID)
+}
+
+// ReplaceUser replaces a member's name, external ID and active state. An
+// omitted active means active, since PUT replaces the whole resource.
+func (s *SCIMService) ReplaceUser(ctx context.Context, caller *model.Caller, id string, req *model.UserRequest) (*model.User, error) {
+	member, err := s.repo.GetMember(ctx, caller.OrganizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUserName(member, req.UserName); err != nil {
+		return nil, err
+	}
+
+	externalID := strings.TrimSpace(req.ExternalID)
+	active := req.Active == nil || *req.Active
+	changes := &userChanges{externalID: &externalID, active: &active}
+	if req.Name != nil {
+		changes.firstName = &req.Name.GivenName
+		changes.lastName = &req.Name.FamilyName
+	}
+
+	if err := s.applyUserChanges(ctx, caller, member, changes); err != nil {
+		return nil, err
+	}
+	return s.GetUser(ctx, caller, id)
+}
+
+// PatchUser applies PATCH operations to a member. Attributes this service
+// doesn't store, such as titles or phone numbers, are ignored so identity
+// providers that always send them still work.
+func (s *SCIMService) PatchUser(ctx context.Context, caller *model.Caller, id string, req *model.PatchRequest) (*model.User, error) {
+	member, err := s.repo.GetMember(ctx, caller.OrganizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &userChanges{}
+	for _, op := range req.Operations {
+		if err := changes.apply(member, op); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.applyUserChanges(ctx, caller, member, changes); err != nil {
+		return nil, err
+	}
+	return s.GetUser(ctx, caller, id)
+}
+
+// DeleteUser removes a member from the organization
+func (s *SCIMService) DeleteUser(ctx context.Context, caller *model.Caller, id string) error {
+	member, err := s.repo.GetMember(ctx, caller.OrganizationID, id)
+	if err != nil {
+		return err
+	}
+	if member.Role == ownerRole {
+		return errors.NewValidationError("the organization owner can't be deprovisioned over SCIM")
+	}
+
+	if err := s.members.RemoveOrganizationMember(ctx, caller.OrganizationID, id); err != nil {
+		return err
+	}
+	s.logActivity(ctx, caller, model.ActionUserDeprovisioned, "organization_member", id, map[string]interface{}{
+		"email": member.Email,
+	})
+	return nil
+}
+
+// ListGroups returns a page of the organization's roles. Organizations have
+// few roles, so filtering and paging happen in memory.
+func (s *SCIMService) ListGroups(ctx context.Context, caller *model.Caller, query *model.ListQuery) ([]*model.Group, int, error) {
+	if query.Filter != nil {
+		if err := checkFilterAttributes(query.Filter, groupFilterAttributes); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	groups, err := s.listGroups(ctx, caller.OrganizationID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	matched := make([]*model.Group, 0, len(groups))
+	for _, group := range groups {
+		if query.Filter == nil || query.Filter.Match(groupValues(group)) {
+			matched = append(matched, group)
+		}
+	}
+
+	total := len(matched)
+	start := query.StartIndex - 1
+	if start > total {
+		start = total
+	}
+	end := start + query.Count
+	if end > total {
+		end = total
+	}
+	return matched[start:end], total, nil
+}
+
+// GetGroup retrieves a role with its members
+func (s *SCIMService) GetGroup(ctx context.Context, caller *model.Caller, id string) (*model.Group, error) {
+	groups, err := s.listGroups(ctx, caller.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if group.ID == strings.ToLower(id) {
+			return group, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+// CreateGroup creates a custom role. It grants no permissions until an
+// organization admin edits it; the identity provider only decides who holds it.
+func (s *SCIMService) CreateGroup(ctx context.Context, caller *model.Caller, req *model.GroupRequest) (*model.Group, error) {
+	name := groupRoleName(req.DisplayName)
+	if name == "" {
+		return nil, errors.NewValidationError("displayName is required")
+	}
+
+	role, err := s.roles.CreateRole(ctx, subject(caller), &permissionModel.CreateRoleRequest{
+		Name:        name,
+		Description: groupRoleDescription,
+		Permissions: []string{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.logActivity(ctx, caller, model.ActionGroupCreated, "role", role.Name, nil)
+
+	group, err := s.GetGroup(ctx, caller, role.Name)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateGroupMembers(ctx, caller, group, memberIDs(req.Members), nil)
+}
+
+// ReplaceGroup makes the listed members exactly those holding a role.
+// Roles can't be renamed, since members refer to them by name.
+func (s *SCIMService) ReplaceGroup(ctx context.Context, caller *model.Caller, id string, req *model.GroupRequest) (*model.Group, error) {
+	group, err := s.GetGroup(ctx, caller, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkGroupName(group, req.DisplayName); err != nil {
+		return nil, err
+	}
+
+	add, remove := membershipDiff(group, memberIDs(req.Members))
+	return s.updateGroupMembers(ctx, caller, group, add, remove)
+}
+
+// PatchGroup applies PATCH operations to a role's members
+func (s *SCIMService) PatchGroup(ctx context.Context, caller *model.Caller, id string, req *model.PatchRequest) (*model.Group, error) {
+	group, err := s.GetGroup(ctx, caller, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var add, remove []string
+	for _, op := range req.Operations {
+		opAdd, opRemove, err := groupPatch(group, op)
+		if err != nil {
+			return nil, err
+		}
+		add = append(add, opAdd...)
+		remove = append(remove, opRemove...)
+	}
+	return s.updateGroupMembers(ctx, caller, group, add, remove)
+}
+
+// DeleteGroup deletes a custom role. Its members go back to the member
+// role first, since roles still held can't be deleted.
+func (s *SCIMService) DeleteGroup(ctx context.Context, caller *model.Caller, id string) error {
+	role, err := s.findRole(ctx, caller.OrganizationID, id)
+	if err != nil {
+		return err
+	}
+	if role.Builtin {
+		return errors.NewValidationError("built-in roles can't be deleted")
+	}
+
+	group, err := s.GetGroup(ctx, caller, role.Name)
+	if err != nil {
+		return err
+	}
+	if _, err := s.updateGroupMembers(ctx, caller, group, nil, memberIDs(group.Members)); err != nil {
+		return err
+	}
+
+	if err := s.roles.DeleteRole(ctx, caller.OrganizationID, role.ID); err != nil {
+		return err
+	}
+	s.logActivity(ctx, caller, model.ActionGroupDeleted, "role", role.Name, nil)
+	return nil
+}
+
+// userChanges collects the attributes a request sets; nil means unchanged
+type userChanges struct {
+	firstName  *string
+	lastName   *string
+	externalID *string
+	active     *bool
+}
+
+// apply records one PATCH operation
+func (c *userChanges) apply(member *model.Member, op model.PatchOperation) error {
+	path := strings.ToLower(strings.TrimSpace(op.Path))
+	switch strings.ToLower(op.Op) {
+	case "add", "replace":
+		if path != "" {
+			return c.set(member, path, op.Value)
+		}
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &values); err != nil {
+			return errors.NewValidationError("a patch operation without a path needs an object value")
+		}
+		for attr, value := range values {
+			if err := c.set(member, strings.ToLower(attr), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "remove":
+		empty := ""
+		switch path {
+		case "externalid":
+			c.externalID = &empty
+		case "name.givenname":
+			c.firstName = &empty
+		case "name.familyname":
+			c.lastName = &empty
+		case "":
+			return errors.NewValidationError("remove operations need a path")
+		}
+		return nil
+	}
+	return errors.NewValidationError(fmt.Sprintf("unsupported patch operation %q", op.Op))
+}
+
+// set records a new value for a lowercase attribute path
+func (c *userChanges) set(member *model.Member, attr string, value json.RawMessage) error {
+	switch attr {
+	case "active":
+		active, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		c.active = &active
+	case "externalid":
+		externalID, err := parseString(attr, value)
+		if err != nil {
+			return err
+		}
+		externalID = strings.TrimSpace(externalID)
+		c.externalID = &externalID
+	case "name":
+		var name model.Name
+		if err := json.Unmarshal(value, &name); err != nil {
+			return errors.NewValidationError("name must be an object")
+		}
+		c.firstName = &name.GivenName
+		c.lastName = &name.FamilyName
+	case "name.givenname":
+		firstName, err := parseString(attr, value)
+		if err != nil {
+			return err
+		}
+		c.firstName = &firstName
+	case "name.familyname":
+		lastName, err := parseString(attr, value)
+		if err != nil {
+			return err
+		}
+		c.lastName = &lastName
+	case "username":
+		userName, err := parseString(attr, value)
+		if err != nil {
+			return err
+		}
+		return checkUserName(member, userName)
+	}
+	return nil
+}
+
+// applyUserChanges saves changed names, external ID and active state. Names
+// belong to the account, which may be a member of other organizations too, so
+// they are only changed for accounts at a domain the organization has
+// verified, which includes every account it provisioned; other name changes
+// are ignored.
+func (s *SCIMService) applyUserChanges(ctx context.Context, caller *model.Caller, member *model.Member, changes *userChanges) error {
+	logged := map[string]interface{}{}
+
+	renamed := (changes.firstName != nil && *changes.firstName != member.FirstName) ||
+		(changes.lastName != nil && *changes.lastName != member.LastName)
+	if renamed {
+		owned, err := s.ownsDomain(ctx, caller.OrganizationID, member.Email[strings.LastIndex(member.Email, "@")+1:])
+		if err != nil {
+			return err
+		}
+		renamed = owned
+	}
+
+	if renamed {
+		user, err := s.users.GetUserByID(ctx, member.UserID)
+		if err != nil {
+			return err
+		}
+		if changes.firstName != nil {
+			user.FirstName = strings.TrimSpace(*changes.firstName)
+			logged["first_name"] = user.FirstName
+		}
+		if changes.lastName != nil {
+			user.LastName = strings.TrimSpace(*changes.lastName)
+			logged["last_name"] = user.LastName
+		}
+		if err := s.users.UpdateUser(ctx, user); err != nil {
+			return err
+		}
+	}
+
+	if changes.externalID != nil && *changes.externalID != member.ExternalID {
+		if err := s.repo.SetExternalID(ctx, caller.OrganizationID, member.UserID, *changes.externalID); err != nil {
+			return err
+		}
+		logged["external_id"] = *changes.externalID
+	}
+
+	if len(logged) > 0 {
+		s.logActivity(ctx, caller, model.ActionUserUpdated, "organization_member", member.UserID, logged)
+	}
+
+	if changes.active != nil && *changes.active != member.Active {
+		return s.setActive(ctx, caller, member, *changes.active)
+	}
+	return nil
+}
+
+// setActive suspends or unsuspends a member
+func (s *SCIMService) setActive(ctx context.Context, caller *model.Caller, member *model.Member, active bool) error {
+	if active {
+		if err := s.suspender.UnsuspendOrganizationUser(ctx, caller.OrganizationID, member.UserID, caller.UserID); err != nil {
+			return err
+		}
+		s.logActivity(ctx, caller, model.ActionUserReactivated, "organization_member", member.UserID, nil)
+		return nil
+	}
+
+	if member.Role == ownerRole {
+		return errors.NewValidationError("the organization owner can't be deactivated over SCIM")
+	}
+	if err := s.suspender.SuspendOrganizationUser(ctx, caller.OrganizationID, member.UserID, deactivationReason, nil, caller.UserID); err != nil {
+		return err
+	}
+	s.logActivity(ctx, caller, model.ActionUserDeactivated, "organization_member", member.UserID, nil)
+	return nil
+}
+
+// requireVerifiedDomain checks the organization has verified a domain
+func (s *SCIMService) requireVerifiedDomain(ctx context.Context, organizationID, domain string) error {
+	owned, err := s.ownsDomain(ctx, organizationID, domain)
+	if err != nil {
+		return err
+	}
+	if !owned {
+		return errors.NewValidationError("userName must be at a domain the organization has verified")
+	}
+	return nil
+}
+
+// ownsDomain reports whether the organization has verified a domain
+func (s *SCIMService) ownsDomain(ctx context.Context, organizationID, domain string) (bool, error) {
+	verified, err := s.domains.GetVerifiedDomain(ctx, domain)
+	if err == errors.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return verified.OrganizationID == organizationID, nil
+}
+
+// createUser creates a verified account with an unusable random password;
+// the user signs in through SSO or resets their password
+func (s *SCIMService) createUser(ctx context.Context, address string, name *model.Name) (*authModel.User, error) {
+	password := make([]byte, 32)
+	if _, err := rand.Read(password); err != nil {
+		return nil, errors.WrapError(err, "failed to generate password")
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(base64.RawURLEncoding.EncodeToString(password)), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to hash password")
+	}
+
+	user := &authModel.User{
+		Email:         address,
+		PasswordHash:  string(hashedPassword),
+		EmailVerified: true,
+	}
+	if name != nil {
+		user.FirstName = strings.TrimSpace(name.GivenName)
+		user.LastName = strings.TrimSpace(name.FamilyName)
+	}
+	if user.FirstName == "" {
+		user.FirstName, _, _ = strings.Cut(address, "@")
+	}
+
+	if err := s.users.CreateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// listGroups builds a group for each of the organization's roles
+func (s *SCIMService) listGroups(ctx context.Context, organizationID string) ([]*model.Group, error) {
+	roles, err := s.roles.ListRoles(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.repo.ListAllMembers(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	holders := make(map[string][]model.MultiValue)
+	for _, member := range members {
+		holders[member.Role] = append(holders[member.Role], model.MultiValue{Value: member.UserID, Display: member.Email})
+	}
+
+	groups := make([]*model.Group, 0, len(roles))
+	for _, role := range roles {
+		group := &model.Group{
+			Schemas:     []string{model.SchemaGroup},
+			ID:          role.Name,
+			DisplayName: role.Name,
+			Members:     holders[role.Name],
+			Meta: model.Meta{
+				ResourceType: "Group",
+				Created:      role.CreatedAt,
+				LastModified: role.UpdatedAt,
+			},
+		}
+		if group.Members == nil {
+			group.Members = []model.MultiValue{}
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// findRole resolves a group ID to one of the organization's roles
+func (s *SCIMService) findRole(ctx context.Context, organizationID, id string) (*permissionModel.Role, error) {
+	roles, err := s.roles.ListRoles(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		if role.Name == strings.ToLower(id) {
+			return role, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+// updateGroupMembers gives the group's role to add and moves members of
+// remove who hold it back to the member role
+func (s *SCIMService) updateGroupMembers(ctx context.Context, caller *model.Caller, group *model.Group, add, remove []string) (*model.Group, error) {
+	if len(add) == 0 && len(remove) == 0 {
+		return group, nil
+	}
+
+	added := []string{}
+	for _, userID := range add {
+		changed, err := s.assignRole(ctx, caller, userID, group.ID)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			added = append(added, userID)
+		}
+	}
+
+	removed := []string{}
+	for _, userID := range remove {
+		member, err := s.repo.GetMember(ctx, caller.OrganizationID, userID)
+		if err == errors.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if member.Role != group.ID || group.ID == memberRole {
+			continue
+		}
+		if _, err := s.assignRole(ctx, caller, userID, memberRole); err != nil {
+			return nil, err
+		}
+		removed = append(removed, userID)
+	}
+
+	if len(added) > 0 || len(removed) > 0 {
+		s.logActivity(ctx, caller, model.ActionGroupUpdated, "role", group.ID, map[string]interface{}{
+			"added":   added,
+			"removed": removed,
+		})
+	}
+	return s.GetGroup(ctx, caller, group.ID)
+}
+
+// assignRole gives a member a role, reporting whether it changed. The owner
+// role is never granted or taken away.
+func (s *SCIMService) assignRole(ctx context.Context, caller *model.Caller, userID, role string) (bool, error) {
+	member, err := s.repo.GetMember(ctx, caller.OrganizationID, userID)
+	if err == errors.ErrNotFound {
+		return false, errors.NewValidationError(fmt.Sprintf("user %q is not a member of this organization", userID))
+	}
+	if err != nil {
+		return false, err
+	}
+	if member.Role == role {
+		return false, nil
+	}
+	if role == ownerRole || member.Role == ownerRole {
+		return false, errors.NewValidationError("the owner role can't be granted or changed over SCIM")
+	}
+
+	if err := s.roles.AssignRole(ctx, subject(caller), userID, &permissionModel.AssignRoleRequest{Role: role}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// logActivity records a SCIM change as the key's creator, noting the key
+func (s *SCIMService) logActivity(ctx context.Context, caller *model.Caller, action, resourceType, resourceID string, changes map[string]interface{}) {
+	if changes == nil {
+		changes = map[string]interface{}{}
+	}
+	changes["api_key_id"] = caller.APIKeyID
+	if err := s.activity.LogOrganizationActivity(ctx, caller.OrganizationID, caller.UserID, action, resourceType, resourceID, caller.IPAddress, caller.UserAgent, changes); err != nil {
+		fmt.Printf("Failed to log %s for %s %s: %v\n", action, resourceType, resourceID, err)
+	}
+}
+
+// groupPatch turns one PATCH operation into members to add and remove
+func groupPatch(group *model.Group, op model.PatchOperation) ([]string, []string, error) {
+	path := strings.TrimSpace(op.Path)
+	operation := strings.ToLower(op.Op)
+
+	if match := memberPathPattern.FindStringSubmatch(path); match != nil {
+		if operation != "remove" {
+			return nil, nil, errors.NewValidationError("only remove can select a single member")
+		}
+		return nil, []string{match[1]}, nil
+	}
+
+	switch strings.ToLower(path) {
+	case "members":
+		var members []model.MultiValue
+		if len(op.Value) > 0 && string(op.Value) != "null" {
+			if err := json.Unmarshal(op.Value, &members); err != nil {
+				return nil, nil, errors.NewValidationError("members must be a list")
+			}
+		}
+		return membersPatch(group, operation, memberIDs(members))
+	case "displayname":
+		name, err := parseString("displayName", op.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, checkGroupName(group, name)
+	case "":
+		var value model.GroupRequest
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, nil, errors.NewValidationError("a patch operation without a path needs an object value")
+		}
+		if err := checkGroupName(group, value.DisplayName); err != nil {
+			return nil, nil, err
+		}
+		if value.Members == nil {
+			return nil, nil, nil
+		}
+		return membersPatch(group, operation, memberIDs(value.Members))
+	}
+	return nil, nil, errors.NewValidationError(fmt.Sprintf("unsupported patch path %q", op.Path))
+}
+
+// membersPatch applies an operation on the members attribute
+func membersPatch(group *model.Group, operation string, ids []string) ([]string, []string, error) {
+	switch operation {
+	case "add":
+		return ids, nil, nil
+	case "remove":
+		// Removing without a value empties the group
+		if len(ids) == 0 {
+			return nil, memberIDs(group.Members), nil
+		}
+		return nil, ids, nil
+	case "replace":
+		add, remove := membershipDiff(group, ids)
+		return add, remove, nil
+	}
+	return nil, nil, errors.NewValidationError(fmt.Sprintf("unsupported patch operation %q", operation))
+}
+
+// membershipDiff compares a group's members with the wanted ones
+func membershipDiff(group *model.Group, wanted []string) ([]string, []string) {
+	current := make(map[string]bool)
+	for _, member := range group.Members {
+		current[member.Value] = true
+	}
+	keep := make(map[string]bool)
+	var add, remove []string
+	for _, id := range wanted {
+		keep[id] = true
+		if !current[id] {
+			add = append(add, id)
+		}
+	}
+	for _, member := range group.Members {
+		if !keep[member.Value] {
+			remove = append(remove, member.Value)
+		}
+	}
+	return add, remove
+}
+
+// checkFilterAttributes rejects filters on attributes outside allowed
+func checkFilterAttributes(f *model.Filter, allowed map[string]bool) error {
+	if f.Left != nil {
+		if err := checkFilterAttributes(f.Left, allowed); err != nil {
+			return err
+		}
+		if f.Right != nil {
+			return checkFilterAttributes(f.Right, allowed)
+		}
+		return nil
+	}
+	if !allowed[f.Attr] {
+		return errors.NewInvalidFilterError(fmt.Sprintf("filtering on %q is not supported", f.Attr))
+	}
+	return nil
+}
+
+// groupValues lists a group's filterable attribute values
+func groupValues(group *model.Group) map[string][]string {
+	ids := memberIDs(group.Members)
+	return map[string][]string{
+		"id":            {group.ID},
+		"displayname":   {group.DisplayName},
+		"members":       ids,
+		"members.value": ids,
+	}
+}
+
+// checkUserName rejects attempts to change a member's userName, which is their email address
+func checkUserName(member *model.Member, userName string) error {
+	if userName != "" && !strings.EqualFold(strings.TrimSpace(userName), member.Email) {
+		return errors.NewValidationError("userName can't be changed")
+	}
+	return nil
+}
+
+// checkGroupName rejects attempts to rename a group
+func checkGroupName(group *model.Group, displayName string) error {
+	if displayName != "" && groupRoleName(displayName) != group.ID {
+		return errors.NewValidationError("groups can't be renamed")
+	}
+	return nil
+}
+
+// groupRoleName turns a group display name into a role name
+func groupRoleName(displayName string) string {
+	return strings.Join(strings.Fields(strings.ToLower(displayName)), "-")
+}
+
+func memberIDs(members []model.MultiValue) []string {
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		if member.Value != "" {
+			ids = append(ids, member.Value)
+		}
+	}
+	return ids
+}
+
+func subject(caller *model.Caller) permissionModel.Subject {
+	return permissionModel.Subject{
+		UserID:           caller.UserID,
+		OrganizationID:   caller.OrganizationID,
+		OrganizationRole: caller.Role,
+	}
+}
+
+func toUser(member *model.Member) *model.User {
+	created, modified := member.CreatedAt, member.UpdatedAt
+	return &model.User{
+		Schemas:    []string{model.SchemaUser},
+		ID:         member.UserID,
+		ExternalID: member.ExternalID,
+		UserName:   member.Email,
+		Name: &model.Name{
+			Formatted:  strings.TrimSpace(member.FirstName + " " + member.LastName),
+			GivenName:  member.FirstName,
+			FamilyName: member.LastName,
+		},
+		DisplayName: strings.TrimSpace(member.FirstName + " " + member.LastName),
+		Emails:      []model.MultiValue{{Value: member.Email, Type: "work", Primary: true}},
+		Active:      member.Active,
+		Groups:      []model.MultiValue{{Value: member.Role, Display: member.Role}},
+		Meta: model.Meta{
+			ResourceType: "User",
+			Created:      &created,
+			LastModified: &modified,
+		},
+	}
+}
+
+// parseBool reads a boolean, accepting the "True" and "False" strings some
+// identity providers send
+func parseBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		switch strings.ToLower(s) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	return false, errors.NewValidationError("active must be true or false")
+}
+
+func parseString(attr string, value json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return "", errors.NewValidationError(fmt.Sprintf("%s must be a string", attr))
+	}
+	return s, nil
+}
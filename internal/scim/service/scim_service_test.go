@@ -0,0 +1,462 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	authModel "ethos/internal/auth/model"
+	orgdomainModel "ethos/internal/orgdomain/model"
+	permissionModel "ethos/internal/permission/model"
+	"ethos/internal/scim/model"
+	"ethos/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOrgID = "org-1"
+
+// fakeRepository keeps members in memory
+type fakeRepository struct {
+	members map[string]*model.Member
+	order   []string
+	seats   int // free seats; negative is unlimited
+}
+
+func (r *fakeRepository) ListMembers(ctx context.Context, organizationID string, query *model.ListQuery) ([]*model.Member, int, error) {
+	members, _ := r.ListAllMembers(ctx, organizationID)
+	return members, len(members), nil
+}
+
+func (r *fakeRepository) ListAllMembers(ctx context.Context, organizationID string) ([]*model.Member, error) {
+	members := []*model.Member{}
+	for _, id := range r.order {
+		if member, ok := r.members[id]; ok {
+			copied := *member
+			members = append(members, &copied)
+		}
+	}
+	return members, nil
+}
+
+func (r *fakeRepository) GetMember(ctx context.Context, organizationID, userID string) (*model.Member, error) {
+	member, ok := r.members[userID]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	copied := *member
+	return &copied, nil
+}
+
+func (r *fakeRepository) AddMember(ctx context.Context, organizationID, userID, role, externalID string) error {
+	if _, ok := r.members[userID]; ok {
+		return errors.ErrAlreadyMember
+	}
+	if r.seats == 0 {
+		return errors.ErrSeatLimitReached
+	}
+	r.seats--
+	r.members[userID] = &model.Member{UserID: userID, Email: userID + "@acme.com", Role: role, ExternalID: externalID, Active: true}
+	r.order = append(r.order, userID)
+	return nil
+}
+
+func (r *fakeRepository) SetExternalID(ctx context.Context, organizationID, userID, externalID string) error {
+	r.members[userID].ExternalID = externalID
+	return nil
+}
+
+// fakeUsers keeps accounts in memory
+type fakeUsers struct {
+	users map[string]*authModel.User // by email
+}
+
+func (u *fakeUsers) GetUserByEmail(ctx context.Context, email string) (*authModel.User, error) {
+	user, ok := u.users[email]
+	if !ok {
+		return nil, errors.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (u *fakeUsers) GetUserByID(ctx context.Context, userID string) (*authModel.User, error) {
+	for _, user := range u.users {
+		if user.ID == userID {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	return nil, errors.ErrUserNotFound
+}
+
+func (u *fakeUsers) CreateUser(ctx context.Context, user *authModel.User) error {
+	user.ID = user.Email[:len(user.Email)-len("@acme.com")]
+	u.users[user.Email] = user
+	return nil
+}
+
+func (u *fakeUsers) UpdateUser(ctx context.Context, user *authModel.User) error {
+	u.users[user.Email] = user
+	return nil
+}
+
+// fakeDomains knows which organization verified each domain
+type fakeDomains map[string]string
+
+func (d fakeDomains) GetVerifiedDomain(ctx context.Context, domain string) (*orgdomainModel.Domain, error) {
+	organizationID, ok := d[domain]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	return &orgdomainModel.Domain{Domain: domain, OrganizationID: organizationID, Verified: true}, nil
+}
+
+// fakeOrganization removes and suspends members of the fake repository
+type fakeOrganization struct {
+	repo      *fakeRepository
+	suspended map[string]string // user ID to reason
+}
+
+func (o *fakeOrganization) RemoveOrganizationMember(ctx context.Context, orgID, userID string) error {
+	delete(o.repo.members, userID)
+	return nil
+}
+
+func (o *fakeOrganization) SuspendOrganizationUser(ctx context.Context, orgID, userID, reason string, duration *int, adminID string) error {
+	o.suspended[userID] = reason
+	o.repo.members[userID].Active = false
+	return nil
+}
+
+func (o *fakeOrganization) UnsuspendOrganizationUser(ctx context.Context, orgID, userID, adminID string) error {
+	delete(o.suspended, userID)
+	o.repo.members[userID].Active = true
+	return nil
+}
+
+// fakeRoles has the built-in roles plus custom roles, assigning through the fake repository
+type fakeRoles struct {
+	repo   *fakeRepository
+	custom []*permissionModel.Role
+}
+
+func (f *fakeRoles) ListRoles(ctx context.Context, organizationID string) ([]*permissionModel.Role, error) {
+	roles := []*permissionModel.Role{
+		permissionModel.BuiltinRoles["admin"],
+		permissionModel.BuiltinRoles["member"],
+		permissionModel.BuiltinRoles["owner"],
+	}
+	return append(roles, f.custom...), nil
+}
+
+func (f *fakeRoles) CreateRole(ctx context.Context, actor permissionModel.Subject, req *permissionModel.CreateRoleRequest) (*permissionModel.Role, error) {
+	if _, ok := permissionModel.BuiltinRoles[req.Name]; ok {
+		return nil, errors.ErrRoleAlreadyExists
+	}
+	role := &permissionModel.Role{ID: "role-" + req.Name, Name: req.Name, Permissions: req.Permissions}
+	f.custom = append(f.custom, role)
+	return role, nil
+}
+
+func (f *fakeRoles) DeleteRole(ctx context.Context, organizationID, roleID string) error {
+	for i, role := range f.custom {
+		if role.ID == roleID {
+			for _, member := range f.repo.members {
+				if member.Role == role.Name {
+					return errors.ErrRoleInUse
+				}
+			}
+			f.custom = append(f.custom[:i], f.custom[i+1:]...)
+			return nil
+		}
+	}
+	return errors.ErrNotFound
+}
+
+func (f *fakeRoles) AssignRole(ctx context.Context, actor permissionModel.Subject, userID string, req *permissionModel.AssignRoleRequest) error {
+	f.repo.members[userID].Role = req.Role
+	return nil
+}
+
+// fakeActivity records logged actions
+type fakeActivity struct {
+	actions []string
+	changes []map[string]interface{}
+}
+
+func (a *fakeActivity) LogOrganizationActivity(ctx context.Context, organizationID, userID, action, resourceType, resourceID, ipAddress, userAgent string, changes map[string]interface{}) error {
+	a.actions = append(a.actions, action)
+	a.changes = append(a.changes, changes)
+	return nil
+}
+
+type fixture struct {
+	svc      Service
+	repo     *fakeRepository
+	users    *fakeUsers
+	org      *fakeOrganization
+	roles    *fakeRoles
+	activity *fakeActivity
+	caller   *model.Caller
+}
+
+func newFixture() *fixture {
+	repo := &fakeRepository{members: map[string]*model.Member{}, seats: -1}
+	users := &fakeUsers{users: map[string]*authModel.User{}}
+	org := &fakeOrganization{repo: repo, suspended: map[string]string{}}
+	roles := &fakeRoles{repo: repo}
+	activity := &fakeActivity{}
+	domains := fakeDomains{"acme.com": testOrgID, "other.com": "org-2"}
+
+	repo.members["admin-1"] = &model.Member{UserID: "admin-1", Email: "admin-1@acme.com", Role: "admin", Active: true}
+	repo.members["owner-1"] = &model.Member{UserID: "owner-1", Email: "owner-1@acme.com", Role: "owner", Active: true}
+	repo.order = []string{"admin-1", "owner-1"}
+
+	return &fixture{
+		svc:      NewSCIMService(repo, users, domains, org, org, roles, activity),
+		repo:     repo,
+		users:    users,
+		org:      org,
+		roles:    roles,
+		activity: activity,
+		caller:   &model.Caller{OrganizationID: testOrgID, UserID: "admin-1", Role: "admin", APIKeyID: "key-1"},
+	}
+}
+
+func (f *fixture) createUser(t *testing.T, userName string) *model.User {
+	user, err := f.svc.CreateUser(context.Background(), f.caller, &model.UserRequest{UserName: userName, ExternalID: "ext-" + userName})
+	require.NoError(t, err)
+	return user
+}
+
+func TestCreateUser_CreatesAccountAndMember(t *testing.T) {
+	f := newFixture()
+
+	user := f.createUser(t, "Jane@Acme.com")
+
+	assert.Equal(t, "jane", user.ID)
+	assert.Equal(t, "jane@acme.com", user.UserName)
+	assert.True(t, user.Active)
+	assert.Equal(t, "member", user.Groups[0].Value)
+	assert.Equal(t, "ext-Jane@Acme.com", user.ExternalID)
+	assert.True(t, f.users.users["jane@acme.com"].EmailVerified)
+	assert.Equal(t, []string{model.ActionUserProvisioned}, f.activity.actions)
+	assert.Equal(t, "key-1", f.activity.changes[0]["api_key_id"])
+}
+
+func TestCreateUser_RejectsUnverifiedDomains(t *testing.T) {
+	f := newFixture()
+
+	for _, userName := range []string{"jane@other.com", "jane@unclaimed.com", "not-an-email"} {
+		_, err := f.svc.CreateUser(context.Background(), f.caller, &model.UserRequest{UserName: userName})
+		require.Error(t, err, userName)
+		assert.Equal(t, "VALIDATION_FAILED", err.(*errors.APIError).Code, userName)
+	}
+	assert.Empty(t, f.users.users)
+}
+
+func TestCreateUser_ExistingMemberAndSeatLimit(t *testing.T) {
+	f := newFixture()
+	f.createUser(t, "jane@acme.com")
+
+	_, err := f.svc.CreateUser(context.Background(), f.caller, &model.UserRequest{UserName: "jane@acme.com"})
+	assert.Equal(t, errors.ErrAlreadyMember, err)
+
+	f.repo.seats = 0
+	_, err = f.svc.CreateUser(context.Background(), f.caller, &model.UserRequest{UserName: "john@acme.com"})
+	assert.Equal(t, errors.ErrSeatLimitReached, err)
+}
+
+func TestCreateUser_Inactive(t *testing.T) {
+	f := newFixture()
+	active := false
+
+	user, err := f.svc.CreateUser(context.Background(), f.caller, &model.UserRequest{UserName: "jane@acme.com", Active: &active})
+	require.NoError(t, err)
+
+	assert.False(t, user.Active)
+	assert.Equal(t, deactivationReason, f.org.suspended["jane"])
+}
+
+func TestPatchUser_DeactivatesAndReactivates(t *testing.T) {
+	f := newFixture()
+	f.createUser(t, "jane@acme.com")
+
+	// Azure AD sends booleans as strings
+	user, err := f.svc.PatchUser(context.Background(), f.caller, "jane", &model.PatchRequest{Operations: []model.PatchOperation{
+		{Op: "Replace", Path: "active", Value: json.RawMessage(`"False"`)},
+	}})
+	require.NoError(t, err)
+	assert.False(t, user.Active)
+	assert.Contains(t, f.org.suspended, "jane")
+
+	// Okta sends a value map without a path
+	user, err = f.svc.PatchUser(context.Background(), f.caller, "jane", &model.PatchRequest{Operations: []model.PatchOperation{
+		{Op: "replace", Value: json.RawMessage(`{"active": true, "name.givenName": "Janet"}`)},
+	}})
+	require.NoError(t, err)
+	assert.True(t, user.Active)
+	assert.NotContains(t, f.org.suspended, "jane")
+	assert.Equal(t, "Janet", f.users.users["jane@acme.com"].FirstName)
+	assert.Contains(t, f.activity.actions, model.ActionUserDeactivated)
+	assert.Contains(t, f.activity.actions, model.ActionUserReactivated)
+}
+
+func TestPatchUser_RejectsUserNameChange(t *testing.T) {
+	f := newFixture()
+	f.createUser(t, "jane@acme.com")
+
+	_, err := f.svc.PatchUser(context.Background(), f.caller, "jane", &model.PatchRequest{Operations: []model.PatchOperation{
+		{Op: "replace", Path: "userName", Value: json.RawMessage(`"janet@acme.com"`)},
+	}})
+	require.Error(t, err)
+	assert.Equal(t, "VALIDATION_FAILED", err.(*errors.APIError).Code)
+}
+
+func TestReplaceUser_OmittedActiveMeansActive(t *testing.T) {
+	f := newFixture()
+	f.createUser(t, "jane@acme.com")
+	_, err := f.svc.PatchUser(context.Background(), f.caller, "jane", &model.PatchRequest{Operations: []model.PatchOperation{
+		{Op: "replace", Path: "active", Value: json.RawMessage(`false`)},
+	}})
+	require.NoError(t, err)
+
+	user, err := f.svc.ReplaceUser(context.Background(), f.caller, "jane", &model.UserRequest{
+		UserName:   "jane@acme.com",
+		ExternalID: "new-ext",
+		Name:       &model.Name{GivenName: "Jane", FamilyName: "Doe"},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, user.Active)
+	assert.Equal(t, "new-ext", user.ExternalID)
+	assert.Equal(t, "Doe", f.users.users["jane@acme.com"].LastName)
+}
+
+func TestPatchUser_IgnoresNamesOfAccountsOutsideVerifiedDomains(t *testing.T) {
+	f := newFixture()
+	// An account from another organization's domain that joined this one
+	f.users.users["sam@other.com"] = &authModel.User{ID: "sam", Email: "sam@other.com", FirstName: "Sam"}
+	f.repo.members["sam"] = &model.Member{UserID: "sam", Email: "sam@other.com", FirstName: "Sam", Role: "member", Active: true}
+	f.repo.order = append(f.repo.order, "sam")
+
+	_, err := f.svc.PatchUser(context.Background(), f.caller, "sam", &model.PatchRequest{Operations: []model.PatchOperation{
+		{Op: "replace", Value: json.RawMessage(`{"name.givenName": "Mallory", "externalId": "ext-sam"}`)},
+	}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Sam", f.users.users["sam@other.com"].FirstName)
+	assert.Equal(t, "ext-sam", f.repo.members["sam"].ExternalID)
+	assert.NotContains(t, f.activity.changes[0], "first_name")
+}
+
+func TestOwnerIsNeverChanged(t *testing.T) {
+	f := newFixture()
+
+	_, err := f.svc.PatchUser(context.Background(), f.caller, "owner-1", &model.PatchRequest{Operations: []model.PatchOperation{
+		{Op: "replace", Path: "active", Value: json.RawMessage(`false`)},
+	}})
+	assert.Error(t, err)
+
+	assert.Error(t, f.svc.DeleteUser(context.Background(), f.caller, "owner-1"))
+
+	_, err = f.svc.PatchGroup(context.Background(), f.caller, "admin", &model.PatchRequest{Operations: []model.PatchOperation{
+		{Op: "add", Path: "members", Value: json.RawMessage(`[{"value": "owner-1"}]`)},
+	}})
+	assert.Error(t, err)
+
+	_, err = f.svc.PatchGroup(context.Background(), f.caller, "owner", &model.PatchRequest{Operations: []model.PatchOperation{
+		{Op: "add", Path: "members", Value: json.RawMessage(`[{"value": "admin-1"}]`)},
+	}})
+	assert.Error(t, err)
+
+	assert.Equal(t, "owner", f.repo.members["owner-1"].Role)
+	assert.True(t, f.repo.members["owner-1"].Active)
+}
+
+func TestDeleteUser_RemovesMembership(t *testing.T) {
+	f := newFixture()
+	f.createUser(t, "jane@acme.com")
+
+	require.NoError(t, f.svc.DeleteUser(context.Background(), f.caller, "jane"))
+
+	_, err := f.svc.GetUser(context.Background(), f.caller, "jane")
+	assert.Equal(t, errors.ErrNotFound, err)
+	assert.Contains(t, f.users.users, "jane@acme.com")
+}
+
+func TestGroups_MembershipFollowsRoles(t *testing.T) {
+	f := newFixture()
+	f.createUser(t, "jane@acme.com")
+	f.createUser(t, "john@acme.com")
+
+	group, err := f.svc.CreateGroup(context.Background(), f.caller, &model.GroupRequest{
+		DisplayName: "Engineering Leads",
+		Members:     []model.MultiValue{{Value: "jane"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "engineering-leads", group.ID)
+	assert.Equal(t, "engineering-leads", f.repo.members["jane"].Role)
+	assert.Empty(t, f.roles.custom[0].Permissions)
+
+	group, err = f.svc.PatchGroup(context.Background(), f.caller, group.ID, &model.PatchRequest{Operations: []model.PatchOperation{
+		{Op: "add", Path: "members", Value: json.RawMessage(`[{"value": "john"}]`)},
+		{Op: "remove", Path: `members[value eq "jane"]`},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"john"}, memberIDs(group.Members))
+	assert.Equal(t, "member", f.repo.members["jane"].Role)
+
+	group, err = f.svc.ReplaceGroup(context.Background(), f.caller, group.ID, &model.GroupRequest{
+		DisplayName: "engineering leads",
+		Members:     []model.MultiValue{{Value: "jane"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jane"}, memberIDs(group.Members))
+	assert.Equal(t, "member", f.repo.members["john"].Role)
+
+	_, err = f.svc.ReplaceGroup(context.Background(), f.caller, group.ID, &model.GroupRequest{DisplayName: "Renamed"})
+	assert.Error(t, err)
+
+	require.NoError(t, f.svc.DeleteGroup(context.Background(), f.caller, group.ID))
+	assert.Empty(t, f.roles.custom)
+	assert.Equal(t, "member", f.repo.members["jane"].Role)
+}
+
+func TestDeleteGroup_BuiltinRole(t *testing.T) {
+	f := newFixture()
+
+	err := f.svc.DeleteGroup(context.Background(), f.caller, "admin")
+	require.Error(t, err)
+	assert.Equal(t, "VALIDATION_FAILED", err.(*errors.APIError).Code)
+}
+
+func TestListGroups_FiltersAndPages(t *testing.T) {
+	f := newFixture()
+	f.createUser(t, "jane@acme.com")
+
+	filter, err := model.ParseFilter(`displayName eq "ADMIN" or members eq "jane"`)
+	require.NoError(t, err)
+	groups, total, err := f.svc.ListGroups(context.Background(), f.caller, &model.ListQuery{Filter: filter, StartIndex: 1, Count: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, "admin", groups[0].ID)
+	assert.Equal(t, "member", groups[1].ID)
+
+	groups, total, err = f.svc.ListGroups(context.Background(), f.caller, &model.ListQuery{StartIndex: 2, Count: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "member", groups[0].ID)
+
+	groups, _, err = f.svc.ListGroups(context.Background(), f.caller, &model.ListQuery{StartIndex: 10, Count: 5})
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+
+	filter, err = model.ParseFilter(`meta.created gt "2024-01-01T00:00:00Z"`)
+	require.NoError(t, err)
+	_, _, err = f.svc.ListGroups(context.Background(), f.caller, &model.ListQuery{Filter: filter, StartIndex: 1, Count: 10})
+	require.Error(t, err)
+	assert.Equal(t, "INVALID_FILTER", err.(*errors.APIError).Code)
+}
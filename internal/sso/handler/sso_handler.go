@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"net/http"
+
+	"ethos/internal/sso/model"
+	"ethos/internal/sso/service"
+	"ethos/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SSOHandler handles single sign-on HTTP requests
+type SSOHandler struct {
+	service service.Service
+}
+
+// NewSSOHandler creates a new SSO handler
+func NewSSOHandler(svc service.Service) *SSOHandler {
+	return &SSOHandler{
+		service: svc,
+	}
+}
+
+// StartLogin handles POST /api/v1/auth/sso/start
+func (h *SSOHandler) StartLogin(c *gin.Context) {
+	var req model.StartLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	resp, err := h.service.StartLogin(c.Request.Context(), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Callback handles POST /api/v1/auth/sso/callback
+func (h *SSOHandler) Callback(c *gin.Context) {
+	var req model.CallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+	req.IP = c.ClientIP()
+	req.UserAgent = c.GetHeader("User-Agent")
+
+	resp, err := h.service.CompleteLogin(c.Request.Context(), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetOIDCProvider handles GET /api/v1/organizations/:org_id/settings/sso
+func (h *SSOHandler) GetOIDCProvider(c *gin.Context) {
+	provider, err := h.service.GetOIDCProvider(c.Request.Context(), c.Param("org_id"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, provider)
+}
+
+// ConfigureOIDCProvider handles PUT /api/v1/organizations/:org_id/settings/sso
+func (h *SSOHandler) ConfigureOIDCProvider(c *gin.Context) {
+	var req model.ConfigureOIDCRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	provider, err := h.service.ConfigureOIDCProvider(c.Request.Context(), c.Param("org_id"), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, provider)
+}
+
+// GetSAMLProvider handles GET /api/v1/organizations/:org_id/settings/saml
+func (h *SSOHandler) GetSAMLProvider(c *gin.Context) {
+	provider, err := h.service.GetSAMLProvider(c.Request.Context(), c.Param("org_id"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, provider)
+}
+
+// ConfigureSAMLProvider handles PUT /api/v1/organizations/:org_id/settings/saml
+func (h *SSOHandler) ConfigureSAMLProvider(c *gin.Context) {
+	var req model.ConfigureSAMLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	provider, err := h.service.ConfigureSAMLProvider(c.Request.Context(), c.Param("org_id"), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, provider)
+}
+
+// SAMLMetadata handles GET /api/v1/auth/sso/saml/:org_id/metadata
+func (h *SSOHandler) SAMLMetadata(c *gin.Context) {
+	metadata, err := h.service.GetSAMLMetadata(c.Request.Context(), c.Param("org_id"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/samlmetadata+xml", metadata)
+}
+
+// AssertionConsumerService handles POST /api/v1/auth/sso/saml/:org_id/acs.
+// The identity provider posts the browser here; on success it is redirected
+// to the frontend callback page to finish signing in.
+func (h *SSOHandler) AssertionConsumerService(c *gin.Context) {
+	var req model.ACSRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	redirectURL, err := h.service.ConsumeAssertion(c.Request.Context(), c.Param("org_id"), &req)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, redirectURL)
+}
@@ -0,0 +1,124 @@
+package model
+
+import "time"
+
+// Identity provider types recorded on linked identities
+const (
+	ProviderOIDC = "oidc"
+	ProviderSAML = "saml"
+)
+
+// OIDCProvider is an organization's OpenID Connect identity provider. The
+// client secret is stored encrypted.
+type OIDCProvider struct {
+	OrganizationID        string
+	Issuer                string
+	ClientID              string
+	EncryptedClientSecret string
+	Enabled               bool
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// OIDCProviderResponse represents an organization's OIDC settings for API
+// responses. The client secret is never returned.
+type OIDCProviderResponse struct {
+	OrganizationID  string    `json:"organization_id"`
+	Issuer          string    `json:"issuer"`
+	ClientID        string    `json:"client_id"`
+	HasClientSecret bool      `json:"has_client_secret"`
+	Enabled         bool      `json:"enabled"`
+	RedirectURL     string    `json:"redirect_url"` // To register with the identity provider
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// ConfigureOIDCRequest represents a request to register or update an organization's OIDC provider
+type ConfigureOIDCRequest struct {
+	Issuer       string `json:"issuer" binding:"required,url"`
+	ClientID     string `json:"client_id" binding:"required"`
+	ClientSecret string `json:"client_secret"` // The stored secret is kept when empty
+	Enabled      *bool  `json:"enabled"`
+}
+
+// SAMLProvider is an enterprise organization's SAML identity provider,
+// configured from the provider's metadata
+type SAMLProvider struct {
+	OrganizationID   string
+	IdPMetadata      string
+	IdPEntityID      string
+	AttributeMapping AttributeMapping
+	RoleMapping      map[string]string // Role attribute value to organization role
+	Enabled          bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// AttributeMapping names the SAML attributes read into user fields. The
+// email falls back to the assertion's NameID when its attribute is absent.
+type AttributeMapping struct {
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Role      string `json:"role"`
+}
+
+// SAMLProviderResponse represents an organization's SAML settings for API
+// responses, with the service provider values to register with the identity provider
+type SAMLProviderResponse struct {
+	OrganizationID   string            `json:"organization_id"`
+	IdPEntityID      string            `json:"idp_entity_id"`
+	IdPSSOURL        string            `json:"idp_sso_url"`
+	AttributeMapping AttributeMapping  `json:"attribute_mapping"`
+	RoleMapping      map[string]string `json:"role_mapping"`
+	Enabled          bool              `json:"enabled"`
+	SPEntityID       string            `json:"sp_entity_id"`
+	ACSURL           string            `json:"acs_url"`
+	MetadataURL      string            `json:"metadata_url"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+}
+
+// ConfigureSAMLRequest represents a request to register or update an organization's SAML provider
+type ConfigureSAMLRequest struct {
+	IdPMetadata      string            `json:"idp_metadata" binding:"required"` // The identity provider's EntityDescriptor XML
+	AttributeMapping *AttributeMapping `json:"attribute_mapping"`               // Empty names keep their defaults
+	RoleMapping      map[string]string `json:"role_mapping"`
+	Enabled          *bool             `json:"enabled"`
+}
+
+// ACSRequest is the HTTP-POST binding form an identity provider sends to the assertion consumer service
+type ACSRequest struct {
+	SAMLResponse string `form:"SAMLResponse" binding:"required"`
+	RelayState   string `form:"RelayState" binding:"required"`
+}
+
+// StartLoginRequest represents a request to begin SSO login for an email address
+type StartLoginRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// StartLoginResponse carries the identity provider URL to send the browser to
+type StartLoginResponse struct {
+	OrganizationID   string `json:"organization_id"`
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+// CallbackRequest carries the code and state the identity provider redirected back with
+type CallbackRequest struct {
+	Code      string `json:"code" binding:"required"`
+	State     string `json:"state" binding:"required"`
+	IP        string `json:"-"` // Set by the handler for the session
+	UserAgent string `json:"-"` // Set by the handler for the session
+}
+
+// LoginState is kept between starting a login and its callback. SAML logins
+// record the signed-in user when the assertion arrives, along with the code
+// the frontend must present to finish.
+type LoginState struct {
+	OrganizationID string `json:"organization_id"`
+	Protocol       string `json:"protocol,omitempty"` // ProviderOIDC when empty
+	Nonce          string `json:"nonce,omitempty"`
+	CodeVerifier   string `json:"code_verifier,omitempty"`
+	RequestID      string `json:"request_id,omitempty"`
+	UserID         string `json:"user_id,omitempty"`
+	Code           string `json:"code,omitempty"`
+}
@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"ethos/internal/database"
+	"ethos/internal/sso/model"
+	"ethos/pkg/errors"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// PostgresRepository implements the Repository interface using PostgreSQL
+type PostgresRepository struct {
+	db *database.DB
+}
+
+// NewPostgresRepository creates a new PostgreSQL repository
+func NewPostgresRepository(db *database.DB) Repository {
+	return &PostgresRepository{db: db}
+}
+
+// GetOIDCProvider retrieves an organization's OIDC provider, or ErrNotFound
+func (r *PostgresRepository) GetOIDCProvider(ctx context.Context, organizationID string) (*model.OIDCProvider, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetOIDCProvider")
+	defer span.End()
+
+	query := `
+		SELECT organization_id::text, issuer, client_id, client_secret_encrypted, enabled, created_at, updated_at
+		FROM organization_oidc_providers
+		WHERE organization_id = $1
+	`
+
+	provider := &model.OIDCProvider{}
+	err := r.db.Pool.QueryRow(ctx, query, organizationID).Scan(
+		&provider.OrganizationID,
+		&provider.Issuer,
+		&provider.ClientID,
+		&provider.EncryptedClientSecret,
+		&provider.Enabled,
+		&provider.CreatedAt,
+		&provider.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get OIDC provider")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return provider, nil
+}
+
+// SaveOIDCProvider creates or replaces an organization's OIDC provider
+func (r *PostgresRepository) SaveOIDCProvider(ctx context.Context, provider *model.OIDCProvider) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.SaveOIDCProvider")
+	defer span.End()
+
+	query := `
+		INSERT INTO organization_oidc_providers (organization_id, issuer, client_id, client_secret_encrypted, enabled)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (organization_id) DO UPDATE
+		SET issuer = EXCLUDED.issuer,
+		    client_id = EXCLUDED.client_id,
+		    client_secret_encrypted = EXCLUDED.client_secret_encrypted,
+		    enabled = EXCLUDED.enabled
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.Pool.QueryRow(ctx, query,
+		provider.OrganizationID,
+		provider.Issuer,
+		provider.ClientID,
+		provider.EncryptedClientSecret,
+		provider.Enabled,
+	).Scan(&provider.CreatedAt, &provider.UpdatedAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to save OIDC provider")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// GetSAMLProvider retrieves an organization's SAML provider, or ErrNotFound
+func (r *PostgresRepository) GetSAMLProvider(ctx context.Context, organizationID string) (*model.SAMLProvider, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetSAMLProvider")
+	defer span.End()
+
+	query := `
+		SELECT organization_id::text, idp_metadata, idp_entity_id, attribute_mapping, role_mapping, enabled, created_at, updated_at
+		FROM organization_saml_providers
+		WHERE organization_id = $1
+	`
+
+	provider := &model.SAMLProvider{}
+	var attributeMapping, roleMapping []byte
+	err := r.db.Pool.QueryRow(ctx, query, organizationID).Scan(
+		&provider.OrganizationID,
+		&provider.IdPMetadata,
+		&provider.IdPEntityID,
+		&attributeMapping,
+		&roleMapping,
+		&provider.Enabled,
+		&provider.CreatedAt,
+		&provider.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get SAML provider")
+	}
+
+	if err := json.Unmarshal(attributeMapping, &provider.AttributeMapping); err != nil {
+		return nil, errors.WrapError(err, "failed to decode attribute mapping")
+	}
+	if err := json.Unmarshal(roleMapping, &provider.RoleMapping); err != nil {
+		return nil, errors.WrapError(err, "failed to decode role mapping")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return provider, nil
+}
+
+// SaveSAMLProvider creates or replaces an organization's SAML provider
+func (r *PostgresRepository) SaveSAMLProvider(ctx context.Context, provider *model.SAMLProvider) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.SaveSAMLProvider")
+	defer span.End()
+
+	attributeMapping, err := json.Marshal(provider.AttributeMapping)
+	if err != nil {
+		return errors.WrapError(err, "failed to encode attribute mapping")
+	}
+	roleMapping := provider.RoleMapping
+	if roleMapping == nil {
+		roleMapping = map[string]string{}
+	}
+	roleMappingJSON, err := json.Marshal(roleMapping)
+	if err != nil {
+		return errors.WrapError(err, "failed to encode role mapping")
+	}
+
+	query := `
+		INSERT INTO organization_saml_providers (organization_id, idp_metadata, idp_entity_id, attribute_mapping, role_mapping, enabled)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (organization_id) DO UPDATE
+		SET idp_metadata = EXCLUDED.idp_metadata,
+		    idp_entity_id = EXCLUDED.idp_entity_id,
+		    attribute_mapping = EXCLUDED.attribute_mapping,
+		    role_mapping = EXCLUDED.role_mapping,
+		    enabled = EXCLUDED.enabled
+		RETURNING created_at, updated_at
+	`
+
+	err = r.db.Pool.QueryRow(ctx, query,
+		provider.OrganizationID,
+		provider.IdPMetadata,
+		provider.IdPEntityID,
+		attributeMapping,
+		roleMappingJSON,
+		provider.Enabled,
+	).Scan(&provider.CreatedAt, &provider.UpdatedAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to save SAML provider")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// GetLinkedUserID retrieves the user linked to a provider subject in an organization, or ErrNotFound
+func (r *PostgresRepository) GetLinkedUserID(ctx context.Context, organizationID, provider, subject string) (string, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetLinkedUserID")
+	defer span.End()
+
+	query := `
+		SELECT user_id
+		FROM user_sso_identities
+		WHERE organization_id = $1 AND provider = $2 AND subject = $3
+	`
+
+	var userID string
+	err := r.db.Pool.QueryRow(ctx, query, organizationID, provider, subject).Scan(&userID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", errors.ErrNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", errors.WrapError(err, "failed to get linked identity")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return userID, nil
+}
+
+// LinkIdentity links a provider subject to a user and records the login
+func (r *PostgresRepository) LinkIdentity(ctx context.Context, organizationID, provider, subject, userID string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.LinkIdentity")
+	defer span.End()
+
+	query := `
+		INSERT INTO user_sso_identities (organization_id, provider, subject, user_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (organization_id, provider, subject) DO UPDATE
+		SET user_id = EXCLUDED.user_id, last_login_at = CURRENT_TIMESTAMP
+	`
+
+	if _, err := r.db.Pool.Exec(ctx, query, organizationID, provider, subject, userID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to link identity")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"ethos/internal/sso/model"
+)
+
+// Repository defines the interface for SSO configuration and linked identity data access
+type Repository interface {
+	// GetOIDCProvider retrieves an organization's OIDC provider, or ErrNotFound
+	GetOIDCProvider(ctx context.Context, organizationID string) (*model.OIDCProvider, error)
+
+	// SaveOIDCProvider creates or replaces an organization's OIDC provider
+	SaveOIDCProvider(ctx context.Context, provider *model.OIDCProvider) error
+
+	// GetSAMLProvider retrieves an organization's SAML provider, or ErrNotFound
+	GetSAMLProvider(ctx context.Context, organizationID string) (*model.SAMLProvider, error)
+
+	// SaveSAMLProvider creates or replaces an organization's SAML provider
+	SaveSAMLProvider(ctx context.Context, provider *model.SAMLProvider) error
+
+	// GetLinkedUserID retrieves the user linked to a provider subject in an organization, or ErrNotFound
+	GetLinkedUserID(ctx context.Context, organizationID, provider, subject string) (string, error)
+
+	// LinkIdentity links a provider subject to a user and records the login
+	LinkIdentity(ctx context.Context, organizationID, provider, subject, userID string) error
+}
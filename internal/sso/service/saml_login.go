@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	authService "ethos/internal/auth/service"
+	orgModel "ethos/internal/organization/model"
+	"ethos/internal/sso/model"
+	"ethos/pkg/errors"
+	"ethos/pkg/oidc"
+	"ethos/pkg/saml"
+)
+
+const (
+	// enterprisePlan is the organization plan that may use SAML
+	enterprisePlan = "enterprise"
+
+	// samlCompletionTTL is how long the frontend has to finish a login after the assertion arrives
+	samlCompletionTTL = 2 * time.Minute
+)
+
+// defaultAttributeMapping names the attributes read when an organization doesn't override them
+var defaultAttributeMapping = model.AttributeMapping{
+	Email:     "email",
+	FirstName: "firstName",
+	LastName:  "lastName",
+	Role:      "role",
+}
+
+// mappableRoles ranks the organization roles an identity provider may assign;
+// a user matching several gets the highest
+var mappableRoles = map[string]int{
+	"viewer": 1,
+	"member": 2,
+	"admin":  3,
+}
+
+// GetSAMLProvider retrieves an organization's SAML settings
+func (s *SSOService) GetSAMLProvider(ctx context.Context, organizationID string) (*model.SAMLProviderResponse, error) {
+	provider, err := s.repo.GetSAMLProvider(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toSAMLResponse(provider), nil
+}
+
+// ConfigureSAMLProvider registers or updates an enterprise organization's
+// SAML provider from its metadata
+func (s *SSOService) ConfigureSAMLProvider(ctx context.Context, organizationID string, req *model.ConfigureSAMLRequest) (*model.SAMLProviderResponse, error) {
+	if err := s.requireEnterprisePlan(ctx, organizationID); err != nil {
+		return nil, err
+	}
+
+	idp, err := saml.ParseIdentityProviderMetadata([]byte(req.IdPMetadata))
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid identity provider metadata: %v", err))
+	}
+	for value, role := range req.RoleMapping {
+		if _, ok := mappableRoles[role]; !ok {
+			return nil, errors.NewValidationError(fmt.Sprintf("role_mapping for %q must be one of admin, member or viewer", value))
+		}
+	}
+
+	provider, err := s.repo.GetSAMLProvider(ctx, organizationID)
+	if err != nil && err != errors.ErrNotFound {
+		return nil, err
+	}
+	if provider == nil {
+		provider = &model.SAMLProvider{OrganizationID: organizationID, AttributeMapping: defaultAttributeMapping, Enabled: true}
+	}
+
+	provider.IdPMetadata = req.IdPMetadata
+	provider.IdPEntityID = idp.EntityID
+	if req.AttributeMapping != nil {
+		provider.AttributeMapping = withDefaultAttributes(*req.AttributeMapping)
+	}
+	if req.RoleMapping != nil {
+		provider.RoleMapping = req.RoleMapping
+	}
+	if req.Enabled != nil {
+		provider.Enabled = *req.Enabled
+	}
+
+	if err := s.repo.SaveSAMLProvider(ctx, provider); err != nil {
+		return nil, err
+	}
+	return s.toSAMLResponse(provider), nil
+}
+
+// GetSAMLMetadata returns the service provider metadata an enterprise
+// organization registers with its identity provider
+func (s *SSOService) GetSAMLMetadata(ctx context.Context, organizationID string) ([]byte, error) {
+	if err := s.requireEnterprisePlan(ctx, organizationID); err != nil {
+		return nil, err
+	}
+	metadata, err := s.serviceProvider(organizationID).Metadata()
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to generate SAML metadata")
+	}
+	return metadata, nil
+}
+
+// startSAMLLogin sends the user to the identity provider with an
+// authentication request. The request ID is kept with the state, which the
+// provider echoes back as RelayState.
+func (s *SSOService) startSAMLLogin(ctx context.Context, org *orgModel.Organization, address string) (*model.StartLoginResponse, error) {
+	provider, idp, err := s.enabledSAMLProvider(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := oidc.RandomString()
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to generate state")
+	}
+	requestID, err := saml.NewRequestID()
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to generate request ID")
+	}
+
+	loginState := &model.LoginState{OrganizationID: provider.OrganizationID, Protocol: model.ProviderSAML, RequestID: requestID}
+	if err := s.saveLoginState(ctx, state, loginState, loginStateTTL); err != nil {
+		return nil, err
+	}
+
+	authURL, err := s.serviceProvider(org.ID).AuthnRequestURL(idp, requestID, state, time.Now())
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to build SAML request")
+	}
+	return &model.StartLoginResponse{OrganizationID: org.ID, AuthorizationURL: authURL}, nil
+}
+
+// ConsumeAssertion validates a SAML response posted to an organization's
+// assertion consumer service and provisions the user. It returns the
+// frontend callback URL, whose code and state finish the login through
+// CompleteLogin the same way as OIDC. Only responses to our own requests are
+// accepted; IdP-initiated logins are not supported.
+func (s *SSOService) ConsumeAssertion(ctx context.Context, organizationID string, req *model.ACSRequest) (string, error) {
+	state, err := s.loadLoginState(ctx, req.RelayState)
+	if err != nil {
+		return "", errors.ErrSSOLoginFailed
+	}
+	if state.Protocol != model.ProviderSAML || state.OrganizationID != organizationID || state.UserID != "" {
+		return "", errors.ErrSSOLoginFailed
+	}
+
+	org, err := s.orgs.GetOrganization(ctx, organizationID)
+	if err != nil {
+		return "", err
+	}
+	provider, idp, err := s.enabledSAMLProvider(ctx, org)
+	if err != nil {
+		return "", err
+	}
+
+	assertion, err := s.serviceProvider(organizationID).ParseResponse(req.SAMLResponse, idp, state.RequestID, time.Now())
+	if err != nil {
+		fmt.Printf("SAML response rejected for organization %s: %v\n", organizationID, err)
+		return "", errors.ErrSSOLoginFailed
+	}
+
+	userID, err := s.provisionUser(ctx, organizationID, samlIdentity(provider, assertion))
+	if err != nil {
+		return "", err
+	}
+
+	code, err := oidc.RandomString()
+	if err != nil {
+		return "", errors.WrapError(err, "failed to generate code")
+	}
+	state.UserID = userID
+	state.Code = code
+	if err := s.saveLoginState(ctx, req.RelayState, state, samlCompletionTTL); err != nil {
+		return "", err
+	}
+
+	callback, err := url.Parse(s.config.RedirectURL)
+	if err != nil {
+		return "", errors.WrapError(err, "invalid SSO redirect URL")
+	}
+	query := callback.Query()
+	query.Set("code", code)
+	query.Set("state", req.RelayState)
+	callback.RawQuery = query.Encode()
+	return callback.String(), nil
+}
+
+// completeSAMLLogin signs in the user recorded by ConsumeAssertion
+func (s *SSOService) completeSAMLLogin(ctx context.Context, state *model.LoginState, req *model.CallbackRequest) (*authService.LoginResponse, error) {
+	if state.UserID == "" || subtle.ConstantTimeCompare([]byte(req.Code), []byte(state.Code)) != 1 {
+		return nil, errors.ErrSSOLoginFailed
+	}
+	return s.logins.CompleteExternalLogin(ctx, state.UserID, state.OrganizationID, req.IP, req.UserAgent)
+}
+
+// enabledSAMLProvider loads an organization's SAML provider and its parsed
+// metadata, treating a disabled provider or a non-enterprise plan as not configured
+func (s *SSOService) enabledSAMLProvider(ctx context.Context, org *orgModel.Organization) (*model.SAMLProvider, *saml.IdentityProvider, error) {
+	if org.Plan != enterprisePlan {
+		return nil, nil, errors.ErrSSONotConfigured
+	}
+
+	provider, err := s.repo.GetSAMLProvider(ctx, org.ID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, nil, errors.ErrSSONotConfigured
+		}
+		return nil, nil, err
+	}
+	if !provider.Enabled {
+		return nil, nil, errors.ErrSSONotConfigured
+	}
+
+	idp, err := saml.ParseIdentityProviderMetadata([]byte(provider.IdPMetadata))
+	if err != nil {
+		return nil, nil, errors.WrapError(err, "failed to parse identity provider metadata")
+	}
+	return provider, idp, nil
+}
+
+// requireEnterprisePlan checks that an organization may use SAML
+func (s *SSOService) requireEnterprisePlan(ctx context.Context, organizationID string) error {
+	org, err := s.orgs.GetOrganization(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+	if org.Plan != enterprisePlan {
+		return errors.ErrEnterprisePlanRequired
+	}
+	return nil
+}
+
+// serviceProvider returns this API's SAML identity for an organization. The
+// entity ID is the metadata URL, as identity providers commonly expect.
+func (s *SSOService) serviceProvider(organizationID string) *saml.ServiceProvider {
+	base := strings.TrimSuffix(s.config.ServiceProviderBaseURL, "/") + "/api/v1/auth/sso/saml/" + url.PathEscape(organizationID)
+	return &saml.ServiceProvider{
+		EntityID: base + "/metadata",
+		ACSURL:   base + "/acs",
+	}
+}
+
+// samlIdentity maps a verified assertion to an external identity using the
+// organization's attribute and role mappings. With a role mapping configured,
+// the member's role follows the identity provider and defaults to member.
+func samlIdentity(provider *model.SAMLProvider, assertion *saml.Assertion) *externalIdentity {
+	mapping := withDefaultAttributes(provider.AttributeMapping)
+
+	email := assertion.Attribute(mapping.Email)
+	if email == "" && strings.Contains(assertion.NameID, "@") {
+		email = assertion.NameID
+	}
+
+	role := ""
+	if len(provider.RoleMapping) > 0 {
+		role = provisionedMemberRole
+		for _, value := range assertion.Attributes[mapping.Role] {
+			if mapped, ok := provider.RoleMapping[value]; ok && mappableRoles[mapped] > mappableRoles[role] {
+				role = mapped
+			}
+		}
+	}
+
+	return &externalIdentity{
+		provider: model.ProviderSAML,
+		subject:  assertion.NameID,
+		email:    email,
+		// The identity provider vouches for the address, and it must be in the organization's domain
+		emailVerified: true,
+		firstName:     assertion.Attribute(mapping.FirstName),
+		lastName:      assertion.Attribute(mapping.LastName),
+		role:          role,
+	}
+}
+
+func withDefaultAttributes(mapping model.AttributeMapping) model.AttributeMapping {
+	if mapping.Email == "" {
+		mapping.Email = defaultAttributeMapping.Email
+	}
+	if mapping.FirstName == "" {
+		mapping.FirstName = defaultAttributeMapping.FirstName
+	}
+	if mapping.LastName == "" {
+		mapping.LastName = defaultAttributeMapping.LastName
+	}
+	if mapping.Role == "" {
+		mapping.Role = defaultAttributeMapping.Role
+	}
+	return mapping
+}
+
+func (s *SSOService) toSAMLResponse(provider *model.SAMLProvider) *model.SAMLProviderResponse {
+	sp := s.serviceProvider(provider.OrganizationID)
+	resp := &model.SAMLProviderResponse{
+		OrganizationID:   provider.OrganizationID,
+		IdPEntityID:      provider.IdPEntityID,
+		AttributeMapping: withDefaultAttributes(provider.AttributeMapping),
+		RoleMapping:      provider.RoleMapping,
+		Enabled:          provider.Enabled,
+		SPEntityID:       sp.EntityID,
+		ACSURL:           sp.ACSURL,
+		MetadataURL:      sp.EntityID,
+		UpdatedAt:        provider.UpdatedAt,
+	}
+	if idp, err := saml.ParseIdentityProviderMetadata([]byte(provider.IdPMetadata)); err == nil {
+		resp.IdPSSOURL = idp.SSOURL
+	}
+	if resp.RoleMapping == nil {
+		resp.RoleMapping = map[string]string{}
+	}
+	return resp
+}
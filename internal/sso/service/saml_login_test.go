@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	authModel "ethos/internal/auth/model"
+	"ethos/internal/sso/model"
+	"ethos/pkg/errors"
+	"ethos/pkg/saml/samltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSAMLTestEnv(t *testing.T) (*ssoTestEnv, *samltest.IdentityProvider) {
+	t.Helper()
+	env := newSSOTestEnv(t)
+	idp := samltest.NewIdentityProvider("https://idp.corp.test")
+
+	_, err := env.service.ConfigureSAMLProvider(context.Background(), testEnterpriseOrgID, &model.ConfigureSAMLRequest{
+		IdPMetadata:      string(idp.Metadata()),
+		AttributeMapping: &model.AttributeMapping{Role: "groups"},
+		RoleMapping:      map[string]string{"it-admins": "admin", "contractors": "viewer"},
+	})
+	require.NoError(t, err)
+	return env, idp
+}
+
+// samlLogin runs the browser side of an SP-initiated SAML login and returns
+// the frontend callback's code and state
+func (env *ssoTestEnv) samlLogin(t *testing.T, idp *samltest.IdentityProvider, email string) (string, string) {
+	t.Helper()
+	start, err := env.service.StartLogin(context.Background(), &model.StartLoginRequest{Email: email})
+	require.NoError(t, err)
+	assert.Equal(t, testEnterpriseOrgID, start.OrganizationID)
+
+	samlResponse, relayState, err := idp.Respond(start.AuthorizationURL)
+	require.NoError(t, err)
+
+	redirectURL, err := env.service.ConsumeAssertion(context.Background(), testEnterpriseOrgID, &model.ACSRequest{
+		SAMLResponse: samlResponse,
+		RelayState:   relayState,
+	})
+	require.NoError(t, err)
+
+	callback, err := url.Parse(redirectURL)
+	require.NoError(t, err)
+	assert.Equal(t, "/sso/callback", callback.Path)
+	return callback.Query().Get("code"), callback.Query().Get("state")
+}
+
+func TestSSOService_SAMLLogin_ProvisionsUserWithMappedRole(t *testing.T) {
+	env, idp := newSAMLTestEnv(t)
+	idp.SetIdentity(samltest.Identity{
+		NameID: "ada@corp.test",
+		Attributes: map[string][]string{
+			"firstName": {"Ada"},
+			"lastName":  {"Lovelace"},
+			"groups":    {"everyone", "it-admins"},
+		},
+	})
+
+	code, state := env.samlLogin(t, idp, "ada@corp.test")
+	resp, err := env.service.CompleteLogin(context.Background(), &model.CallbackRequest{Code: code, State: state})
+	require.NoError(t, err)
+	assert.Equal(t, "access-user-ada@corp.test", resp.AccessToken)
+	assert.Equal(t, testEnterpriseOrgID, env.logins.organizationID)
+
+	user := env.users.users["user-ada@corp.test"]
+	require.NotNil(t, user)
+	assert.Equal(t, "Ada", user.FirstName)
+	assert.Equal(t, "Lovelace", user.LastName)
+	assert.Equal(t, "admin", env.directory.members[testEnterpriseOrgID+"/"+user.ID])
+
+	// Leaving the group demotes the member on the next login
+	idp.SetIdentity(samltest.Identity{NameID: "ada@corp.test", Attributes: map[string][]string{"groups": {"everyone"}}})
+	code, state = env.samlLogin(t, idp, "ada@corp.test")
+	_, err = env.service.CompleteLogin(context.Background(), &model.CallbackRequest{Code: code, State: state})
+	require.NoError(t, err)
+	assert.Equal(t, "member", env.directory.members[testEnterpriseOrgID+"/"+user.ID])
+}
+
+func TestSSOService_SAMLLogin_KeepsOwnerRole(t *testing.T) {
+	env, idp := newSAMLTestEnv(t)
+	env.users.users["user-owner"] = &authModel.User{ID: "user-owner", Email: "owner@corp.test"}
+	env.directory.members[testEnterpriseOrgID+"/user-owner"] = "owner"
+	idp.SetIdentity(samltest.Identity{NameID: "owner@corp.test", Attributes: map[string][]string{"groups": {"contractors"}}})
+
+	code, state := env.samlLogin(t, idp, "owner@corp.test")
+	_, err := env.service.CompleteLogin(context.Background(), &model.CallbackRequest{Code: code, State: state})
+	require.NoError(t, err)
+	assert.Equal(t, "owner", env.directory.members[testEnterpriseOrgID+"/user-owner"])
+}
+
+func TestSSOService_SAMLLogin_RejectsReplayAndWrongCode(t *testing.T) {
+	env, idp := newSAMLTestEnv(t)
+	idp.SetIdentity(samltest.Identity{NameID: "ada@corp.test"})
+
+	start, err := env.service.StartLogin(context.Background(), &model.StartLoginRequest{Email: "ada@corp.test"})
+	require.NoError(t, err)
+	samlResponse, relayState, err := idp.Respond(start.AuthorizationURL)
+	require.NoError(t, err)
+	req := &model.ACSRequest{SAMLResponse: samlResponse, RelayState: relayState}
+
+	_, err = env.service.ConsumeAssertion(context.Background(), testEnterpriseOrgID, req)
+	require.NoError(t, err)
+
+	_, err = env.service.ConsumeAssertion(context.Background(), testEnterpriseOrgID, req)
+	assert.Equal(t, errors.ErrSSOLoginFailed, err)
+
+	_, err = env.service.CompleteLogin(context.Background(), &model.CallbackRequest{Code: "guessed", State: relayState})
+	assert.Equal(t, errors.ErrSSOLoginFailed, err)
+	assert.Empty(t, env.logins.userID)
+}
+
+func TestSSOService_ConfigureSAMLProvider_Validation(t *testing.T) {
+	env := newSSOTestEnv(t)
+	idp := samltest.NewIdentityProvider("https://idp.acme.test")
+
+	_, err := env.service.ConfigureSAMLProvider(context.Background(), testOrgID, &model.ConfigureSAMLRequest{IdPMetadata: string(idp.Metadata())})
+	assert.Equal(t, errors.ErrEnterprisePlanRequired, err)
+
+	_, err = env.service.ConfigureSAMLProvider(context.Background(), testEnterpriseOrgID, &model.ConfigureSAMLRequest{IdPMetadata: "<EntityDescriptor/>"})
+	require.Error(t, err)
+	assert.Equal(t, "VALIDATION_FAILED", err.(*errors.APIError).Code)
+
+	_, err = env.service.ConfigureSAMLProvider(context.Background(), testEnterpriseOrgID, &model.ConfigureSAMLRequest{
+		IdPMetadata: string(idp.Metadata()),
+		RoleMapping: map[string]string{"founders": "owner"},
+	})
+	require.Error(t, err)
+	assert.Equal(t, "VALIDATION_FAILED", err.(*errors.APIError).Code)
+
+	resp, err := env.service.ConfigureSAMLProvider(context.Background(), testEnterpriseOrgID, &model.ConfigureSAMLRequest{IdPMetadata: string(idp.Metadata())})
+	require.NoError(t, err)
+	assert.Equal(t, "https://idp.acme.test", resp.IdPEntityID)
+	assert.Equal(t, "https://api.example.com/api/v1/auth/sso/saml/org-3/acs", resp.ACSURL)
+	assert.Equal(t, "email", resp.AttributeMapping.Email)
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	authService "ethos/internal/auth/service"
+	"ethos/internal/sso/model"
+)
+
+// Service defines the interface for organization single sign-on
+type Service interface {
+	// GetOIDCProvider retrieves an organization's OIDC settings
+	GetOIDCProvider(ctx context.Context, organizationID string) (*model.OIDCProviderResponse, error)
+
+	// ConfigureOIDCProvider registers or updates an organization's OIDC provider
+	ConfigureOIDCProvider(ctx context.Context, organizationID string, req *model.ConfigureOIDCRequest) (*model.OIDCProviderResponse, error)
+
+	// GetSAMLProvider retrieves an organization's SAML settings
+	GetSAMLProvider(ctx context.Context, organizationID string) (*model.SAMLProviderResponse, error)
+
+	// ConfigureSAMLProvider registers or updates an enterprise organization's SAML provider
+	ConfigureSAMLProvider(ctx context.Context, organizationID string, req *model.ConfigureSAMLRequest) (*model.SAMLProviderResponse, error)
+
+	// GetSAMLMetadata returns the service provider metadata for an enterprise organization
+	GetSAMLMetadata(ctx context.Context, organizationID string) ([]byte, error)
+
+	// StartLogin finds the organization for an email domain and returns its identity provider URL
+	StartLogin(ctx context.Context, req *model.StartLoginRequest) (*model.StartLoginResponse, error)
+
+	// ConsumeAssertion validates a SAML response and returns the frontend callback URL that finishes the login
+	ConsumeAssertion(ctx context.Context, organizationID string, req *model.ACSRequest) (string, error)
+
+	// CompleteLogin verifies the identity provider's response, provisions the user and issues tokens
+	CompleteLogin(ctx context.Context, req *model.CallbackRequest) (*authService.LoginResponse, error)
+}
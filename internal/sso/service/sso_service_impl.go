@@ -0,0 +1,468 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	authModel "ethos/internal/auth/model"
+	authService "ethos/internal/auth/service"
+	"ethos/internal/cache"
+	orgModel "ethos/internal/organization/model"
+	orgdomainModel "ethos/internal/orgdomain/model"
+	"ethos/internal/sso/model"
+	"ethos/internal/sso/repository"
+	"ethos/pkg/errors"
+	"ethos/pkg/oidc"
+	"ethos/pkg/secretbox"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// loginStateTTL is how long a user has to finish signing in at the identity provider
+	loginStateTTL = 10 * time.Minute
+
+	// provisionedMemberRole is the organization role given to users created on first SSO login
+	provisionedMemberRole = "member"
+
+	// ownerRole is never granted or taken away by an identity provider
+	ownerRole = "owner"
+)
+
+// OrganizationDirectory finds organizations and manages members (implemented by the organization repository)
+type OrganizationDirectory interface {
+	GetOrganization(ctx context.Context, orgID string) (*orgModel.Organization, error)
+	GetOrganizationByDomain(ctx context.Context, domain string) (*orgModel.Organization, error)
+	AddOrganizationMember(ctx context.Context, member *orgModel.OrganizationMember) error
+	UpdateOrganizationMember(ctx context.Context, member *orgModel.OrganizationMember) error
+}
+
+// UserStore looks up and creates users (implemented by the auth repository)
+type UserStore interface {
+	GetUserByEmail(ctx context.Context, email string) (*authModel.User, error)
+	GetUserByID(ctx context.Context, userID string) (*authModel.User, error)
+	CreateUser(ctx context.Context, user *authModel.User) error
+}
+
+// DomainVerifier finds verified domain claims (implemented by the organization domain repository)
+type DomainVerifier interface {
+	GetVerifiedDomain(ctx context.Context, domain string) (*orgdomainModel.Domain, error)
+}
+
+// LoginIssuer issues tokens for externally authenticated users (implemented by the auth service)
+type LoginIssuer interface {
+	CompleteExternalLogin(ctx context.Context, userID, organizationID, ip, userAgent string) (*authService.LoginResponse, error)
+}
+
+// Config holds SSO service settings
+type Config struct {
+	// RedirectURL is the frontend callback page registered with identity providers
+	RedirectURL string
+	// SecretEncryptionKey encrypts identity provider client secrets at rest
+	SecretEncryptionKey string
+	// ServiceProviderBaseURL is this API's public URL, used in SAML service provider metadata
+	ServiceProviderBaseURL string
+}
+
+// SSOService implements the Service interface
+type SSOService struct {
+	repo      repository.Repository
+	orgs      OrganizationDirectory
+	users     UserStore
+	domains   DomainVerifier
+	logins    LoginIssuer
+	states    cache.Cache
+	oidc      *oidc.Client
+	config    Config
+	secretBox *secretbox.Box
+}
+
+// NewSSOService creates a new SSO service
+func NewSSOService(repo repository.Repository, orgs OrganizationDirectory, users UserStore, domains DomainVerifier, logins LoginIssuer, states cache.Cache, oidcClient *oidc.Client, cfg Config) Service {
+	// A missing key leaves secretBox nil, which disables configuring providers
+	box, _ := secretbox.New(cfg.SecretEncryptionKey)
+
+	return &SSOService{
+		repo:      repo,
+		orgs:      orgs,
+		users:     users,
+		domains:   domains,
+		logins:    logins,
+		states:    states,
+		oidc:      oidcClient,
+		config:    cfg,
+		secretBox: box,
+	}
+}
+
+// GetOIDCProvider retrieves an organization's OIDC settings
+func (s *SSOService) GetOIDCProvider(ctx context.Context, organizationID string) (*model.OIDCProviderResponse, error) {
+	provider, err := s.repo.GetOIDCProvider(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(provider), nil
+}
+
+// ConfigureOIDCProvider registers or updates an organization's OIDC provider.
+// The issuer is discovered before saving so a typo fails here rather than at
+// the first login.
+func (s *SSOService) ConfigureOIDCProvider(ctx context.Context, organizationID string, req *model.ConfigureOIDCRequest) (*model.OIDCProviderResponse, error) {
+	if s.secretBox == nil {
+		return nil, errors.NewValidationError("SSO is not available: secret encryption key not configured")
+	}
+
+	provider, err := s.repo.GetOIDCProvider(ctx, organizationID)
+	if err != nil && err != errors.ErrNotFound {
+		return nil, err
+	}
+	if provider == nil {
+		if req.ClientSecret == "" {
+			return nil, errors.NewValidationError("client_secret is required")
+		}
+		provider = &model.OIDCProvider{OrganizationID: organizationID, Enabled: true}
+	}
+
+	issuer := strings.TrimSuffix(strings.TrimSpace(req.Issuer), "/")
+	if _, err := s.oidc.Discover(ctx, issuer); err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("issuer discovery failed: %v", err))
+	}
+
+	provider.Issuer = issuer
+	provider.ClientID = strings.TrimSpace(req.ClientID)
+	if req.ClientSecret != "" {
+		encrypted, err := s.secretBox.Seal(req.ClientSecret)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to encrypt client secret")
+		}
+		provider.EncryptedClientSecret = encrypted
+	}
+	if req.Enabled != nil {
+		provider.Enabled = *req.Enabled
+	}
+
+	if err := s.repo.SaveOIDCProvider(ctx, provider); err != nil {
+		return nil, err
+	}
+	return s.toResponse(provider), nil
+}
+
+// StartLogin finds the organization for an email domain and returns its
+// identity provider URL. OIDC is used when the organization has it enabled,
+// otherwise SAML.
+func (s *SSOService) StartLogin(ctx context.Context, req *model.StartLoginRequest) (*model.StartLoginResponse, error) {
+	address := strings.ToLower(strings.TrimSpace(req.Email))
+	domain := emailDomain(address)
+	if domain == "" {
+		return nil, errors.NewValidationError("invalid email format")
+	}
+
+	org, err := s.orgs.GetOrganizationByDomain(ctx, domain)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrSSONotConfigured
+		}
+		return nil, err
+	}
+
+	config, err := s.repo.GetOIDCProvider(ctx, org.ID)
+	if err != nil && err != errors.ErrNotFound {
+		return nil, err
+	}
+	if config != nil && config.Enabled {
+		return s.startOIDCLogin(ctx, org, config, address)
+	}
+	return s.startSAMLLogin(ctx, org, address)
+}
+
+// startOIDCLogin begins an authorization code flow. The nonce and PKCE
+// verifier stay server-side, keyed by the state parameter the provider
+// echoes back.
+func (s *SSOService) startOIDCLogin(ctx context.Context, org *orgModel.Organization, config *model.OIDCProvider, address string) (*model.StartLoginResponse, error) {
+	provider, err := s.oidc.Discover(ctx, config.Issuer)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to discover identity provider")
+	}
+
+	state, err := oidc.RandomString()
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to generate state")
+	}
+	nonce, err := oidc.RandomString()
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to generate nonce")
+	}
+	verifier, err := oidc.RandomString()
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to generate code verifier")
+	}
+
+	loginState := &model.LoginState{OrganizationID: org.ID, Protocol: model.ProviderOIDC, Nonce: nonce, CodeVerifier: verifier}
+	if err := s.saveLoginState(ctx, state, loginState, loginStateTTL); err != nil {
+		return nil, err
+	}
+
+	return &model.StartLoginResponse{
+		OrganizationID: org.ID,
+		AuthorizationURL: provider.AuthCodeURL(oidc.AuthRequest{
+			ClientID:      config.ClientID,
+			RedirectURL:   s.config.RedirectURL,
+			State:         state,
+			Nonce:         nonce,
+			CodeChallenge: oidc.CodeChallenge(verifier),
+			LoginHint:     address,
+		}),
+	}, nil
+}
+
+// CompleteLogin finishes a login on the frontend callback page and signs the
+// user in. For OIDC it exchanges the authorization code and verifies the ID
+// token; for SAML the assertion was already consumed and the code is the one
+// handed out by ConsumeAssertion.
+func (s *SSOService) CompleteLogin(ctx context.Context, req *model.CallbackRequest) (*authService.LoginResponse, error) {
+	state, err := s.takeLoginState(ctx, req.State)
+	if err != nil {
+		return nil, errors.ErrSSOLoginFailed
+	}
+	if state.Protocol == model.ProviderSAML {
+		return s.completeSAMLLogin(ctx, state, req)
+	}
+
+	config, err := s.repo.GetOIDCProvider(ctx, state.OrganizationID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrSSONotConfigured
+		}
+		return nil, err
+	}
+	if !config.Enabled {
+		return nil, errors.ErrSSONotConfigured
+	}
+	if s.secretBox == nil {
+		return nil, errors.ErrSSONotConfigured
+	}
+	clientSecret, err := s.secretBox.Open(config.EncryptedClientSecret)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to decrypt client secret")
+	}
+
+	provider, err := s.oidc.Discover(ctx, config.Issuer)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to discover identity provider")
+	}
+
+	token, err := s.oidc.Exchange(ctx, provider, config.ClientID, clientSecret, s.config.RedirectURL, req.Code, state.CodeVerifier)
+	if err != nil {
+		fmt.Printf("SSO code exchange failed for organization %s: %v\n", state.OrganizationID, err)
+		return nil, errors.ErrSSOLoginFailed
+	}
+
+	claims, err := s.oidc.VerifyIDToken(ctx, provider, token.IDToken, config.ClientID, state.Nonce)
+	if err != nil {
+		fmt.Printf("SSO ID token rejected for organization %s: %v\n", state.OrganizationID, err)
+		return nil, errors.ErrSSOLoginFailed
+	}
+
+	userID, err := s.provisionUser(ctx, state.OrganizationID, oidcIdentity(claims))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.logins.CompleteExternalLogin(ctx, userID, state.OrganizationID, req.IP, req.UserAgent)
+}
+
+// externalIdentity is a user as asserted by an organization's identity provider
+type externalIdentity struct {
+	provider      string
+	subject       string
+	email         string
+	emailVerified bool
+	firstName     string
+	lastName      string
+	// role is the organization role to keep the membership at; empty leaves it alone
+	role string
+}
+
+func oidcIdentity(claims *oidc.IDTokenClaims) *externalIdentity {
+	firstName, lastName := claims.GivenName, claims.FamilyName
+	if firstName == "" && lastName == "" {
+		firstName, lastName, _ = strings.Cut(strings.TrimSpace(claims.Name), " ")
+	}
+	return &externalIdentity{
+		provider:      model.ProviderOIDC,
+		subject:       claims.Subject,
+		email:         claims.Email,
+		emailVerified: claims.EmailVerified == nil || *claims.EmailVerified,
+		firstName:     firstName,
+		lastName:      lastName,
+	}
+}
+
+// provisionUser resolves the user for an external identity. Users are
+// matched by their linked provider subject, then by email; unknown users are
+// created. Either way they are made members of the organization. The email
+// must be at a domain the organization has verified, so one organization's
+// provider can't sign in another's users or take over their accounts.
+func (s *SSOService) provisionUser(ctx context.Context, organizationID string, identity *externalIdentity) (string, error) {
+	userID, err := s.repo.GetLinkedUserID(ctx, organizationID, identity.provider, identity.subject)
+	if err != nil && err != errors.ErrNotFound {
+		return "", err
+	}
+
+	if userID == "" {
+		address := strings.ToLower(strings.TrimSpace(identity.email))
+		if address == "" || !identity.emailVerified {
+			return "", errors.ErrSSOLoginFailed
+		}
+		if err := s.requireOrganizationDomain(ctx, organizationID, address); err != nil {
+			return "", err
+		}
+
+		user, err := s.users.GetUserByEmail(ctx, address)
+		if err == errors.ErrUserNotFound {
+			user, err = s.createUser(ctx, address, identity)
+		}
+		if err != nil {
+			return "", err
+		}
+		userID = user.ID
+	}
+
+	if err := s.repo.LinkIdentity(ctx, organizationID, identity.provider, identity.subject, userID); err != nil {
+		return "", err
+	}
+
+	member := &orgModel.OrganizationMember{
+		OrganizationID: organizationID,
+		UserID:         userID,
+		Role:           provisionedMemberRole,
+	}
+	if identity.role != "" {
+		member.Role = identity.role
+	}
+	if err := s.orgs.AddOrganizationMember(ctx, member); err != nil {
+		return "", err
+	}
+
+	// Existing members follow the identity provider's role, except owners
+	if identity.role != "" && member.Role != identity.role && member.Role != ownerRole {
+		member.Role = identity.role
+		if err := s.orgs.UpdateOrganizationMember(ctx, member); err != nil {
+			return "", err
+		}
+	}
+
+	return userID, nil
+}
+
+// requireOrganizationDomain checks that an address is at a domain the
+// organization has verified. The organization's own domain setting isn't
+// enough: whoever creates an organization can set it to any domain.
+func (s *SSOService) requireOrganizationDomain(ctx context.Context, organizationID, address string) error {
+	verified, err := s.domains.GetVerifiedDomain(ctx, emailDomain(address))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return errors.ErrSSOLoginFailed
+		}
+		return err
+	}
+	if verified.OrganizationID != organizationID {
+		return errors.ErrSSOLoginFailed
+	}
+	return nil
+}
+
+// createUser creates a user for a first SSO login. The random password means
+// the account can only use SSO until the user sets one through password reset.
+func (s *SSOService) createUser(ctx context.Context, address string, identity *externalIdentity) (*authModel.User, error) {
+	password, err := oidc.RandomString()
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to generate password")
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to hash password")
+	}
+
+	firstName, lastName := identity.firstName, identity.lastName
+	if firstName == "" {
+		firstName, _, _ = strings.Cut(address, "@")
+	}
+
+	user := &authModel.User{
+		Email:         address,
+		PasswordHash:  string(hashedPassword),
+		FirstName:     firstName,
+		LastName:      lastName,
+		EmailVerified: true,
+	}
+	if err := s.users.CreateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// saveLoginState stores login state under the state parameter
+func (s *SSOService) saveLoginState(ctx context.Context, state string, loginState *model.LoginState, ttl time.Duration) error {
+	data, err := json.Marshal(loginState)
+	if err != nil {
+		return errors.WrapError(err, "failed to encode login state")
+	}
+	if err := s.states.Set(ctx, stateKey(state), string(data), ttl); err != nil {
+		return errors.WrapError(err, "failed to save login state")
+	}
+	return nil
+}
+
+// loadLoginState loads the state saved by StartLogin
+func (s *SSOService) loadLoginState(ctx context.Context, state string) (*model.LoginState, error) {
+	data, err := s.states.Get(ctx, stateKey(state))
+	if err != nil {
+		return nil, err
+	}
+
+	var loginState model.LoginState
+	if err := json.Unmarshal([]byte(data), &loginState); err != nil {
+		return nil, err
+	}
+	return &loginState, nil
+}
+
+// takeLoginState loads and deletes the state saved by StartLogin, so each state is used once
+func (s *SSOService) takeLoginState(ctx context.Context, state string) (*model.LoginState, error) {
+	loginState, err := s.loadLoginState(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.states.Delete(ctx, stateKey(state)); err != nil {
+		return nil, err
+	}
+	return loginState, nil
+}
+
+func (s *SSOService) toResponse(provider *model.OIDCProvider) *model.OIDCProviderResponse {
+	return &model.OIDCProviderResponse{
+		OrganizationID:  provider.OrganizationID,
+		Issuer:          provider.Issuer,
+		ClientID:        provider.ClientID,
+		HasClientSecret: provider.EncryptedClientSecret != "",
+		Enabled:         provider.Enabled,
+		RedirectURL:     s.config.RedirectURL,
+		UpdatedAt:       provider.UpdatedAt,
+	}
+}
+
+func stateKey(state string) string {
+	return "sso:state:" + state
+}
+
+func emailDomain(address string) string {
+	_, domain, ok := strings.Cut(address, "@")
+	if !ok {
+		return ""
+	}
+	return domain
+}
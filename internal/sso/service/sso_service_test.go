@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authModel "ethos/internal/auth/model"
+	authService "ethos/internal/auth/service"
+	orgModel "ethos/internal/organization/model"
+	orgdomainModel "ethos/internal/orgdomain/model"
+	"ethos/internal/sso/model"
+	"ethos/pkg/errors"
+	"ethos/pkg/oidc"
+	"ethos/pkg/oidc/oidctest"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testOrgID           = "org-1"
+	testEnterpriseOrgID = "org-3"
+	testRedirectURL     = "http://localhost:5173/sso/callback"
+)
+
+type fakeRepository struct {
+	providers     map[string]*model.OIDCProvider
+	samlProviders map[string]*model.SAMLProvider
+	identities    map[string]string
+}
+
+func (r *fakeRepository) GetOIDCProvider(ctx context.Context, organizationID string) (*model.OIDCProvider, error) {
+	provider, ok := r.providers[organizationID]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	copied := *provider
+	return &copied, nil
+}
+
+func (r *fakeRepository) SaveOIDCProvider(ctx context.Context, provider *model.OIDCProvider) error {
+	copied := *provider
+	r.providers[provider.OrganizationID] = &copied
+	return nil
+}
+
+func (r *fakeRepository) GetSAMLProvider(ctx context.Context, organizationID string) (*model.SAMLProvider, error) {
+	provider, ok := r.samlProviders[organizationID]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	copied := *provider
+	return &copied, nil
+}
+
+func (r *fakeRepository) SaveSAMLProvider(ctx context.Context, provider *model.SAMLProvider) error {
+	copied := *provider
+	r.samlProviders[provider.OrganizationID] = &copied
+	return nil
+}
+
+func (r *fakeRepository) GetLinkedUserID(ctx context.Context, organizationID, provider, subject string) (string, error) {
+	userID, ok := r.identities[organizationID+"/"+provider+"/"+subject]
+	if !ok {
+		return "", errors.ErrNotFound
+	}
+	return userID, nil
+}
+
+func (r *fakeRepository) LinkIdentity(ctx context.Context, organizationID, provider, subject, userID string) error {
+	r.identities[organizationID+"/"+provider+"/"+subject] = userID
+	return nil
+}
+
+type fakeDirectory struct {
+	orgs    map[string]*orgModel.Organization
+	members map[string]string
+}
+
+func (d *fakeDirectory) GetOrganization(ctx context.Context, orgID string) (*orgModel.Organization, error) {
+	for _, org := range d.orgs {
+		if org.ID == orgID {
+			return org, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (d *fakeDirectory) GetOrganizationByDomain(ctx context.Context, domain string) (*orgModel.Organization, error) {
+	org, ok := d.orgs[domain]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	return org, nil
+}
+
+// AddOrganizationMember keeps an existing member's role and reports it, like the repository
+func (d *fakeDirectory) AddOrganizationMember(ctx context.Context, member *orgModel.OrganizationMember) error {
+	key := member.OrganizationID + "/" + member.UserID
+	if role, ok := d.members[key]; ok {
+		member.Role = role
+		return nil
+	}
+	d.members[key] = member.Role
+	return nil
+}
+
+func (d *fakeDirectory) UpdateOrganizationMember(ctx context.Context, member *orgModel.OrganizationMember) error {
+	d.members[member.OrganizationID+"/"+member.UserID] = member.Role
+	return nil
+}
+
+type fakeUsers struct {
+	users map[string]*authModel.User
+}
+
+func (u *fakeUsers) GetUserByEmail(ctx context.Context, email string) (*authModel.User, error) {
+	for _, user := range u.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, errors.ErrUserNotFound
+}
+
+func (u *fakeUsers) GetUserByID(ctx context.Context, userID string) (*authModel.User, error) {
+	user, ok := u.users[userID]
+	if !ok {
+		return nil, errors.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (u *fakeUsers) CreateUser(ctx context.Context, user *authModel.User) error {
+	user.ID = "user-" + user.Email
+	u.users[user.ID] = user
+	return nil
+}
+
+// fakeDomains maps verified domains to the organization that verified them
+type fakeDomains struct {
+	verified map[string]string
+}
+
+func (d *fakeDomains) GetVerifiedDomain(ctx context.Context, domain string) (*orgdomainModel.Domain, error) {
+	organizationID, ok := d.verified[domain]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	return &orgdomainModel.Domain{OrganizationID: organizationID, Domain: domain}, nil
+}
+
+type fakeLogins struct {
+	userID         string
+	organizationID string
+}
+
+func (l *fakeLogins) CompleteExternalLogin(ctx context.Context, userID, organizationID, ip, userAgent string) (*authService.LoginResponse, error) {
+	l.userID = userID
+	l.organizationID = organizationID
+	return &authService.LoginResponse{AccessToken: "access-" + userID}, nil
+}
+
+// memoryCache implements cache.Cache for login state
+type memoryCache struct {
+	values map[string]string
+}
+
+func (m *memoryCache) Get(ctx context.Context, key string) (string, error) {
+	value, ok := m.values[key]
+	if !ok {
+		return "", redis.Nil
+	}
+	return value, nil
+}
+
+func (m *memoryCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	m.values[key] = value.(string)
+	return nil
+}
+
+func (m *memoryCache) Delete(ctx context.Context, key string) error {
+	delete(m.values, key)
+	return nil
+}
+
+func (m *memoryCache) Exists(ctx context.Context, key string) bool {
+	_, ok := m.values[key]
+	return ok
+}
+
+func (m *memoryCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return nil
+}
+
+func (m *memoryCache) Incr(ctx context.Context, key string) (int64, error) {
+	return 0, nil
+}
+
+func (m *memoryCache) FlushAll(ctx context.Context) error {
+	m.values = map[string]string{}
+	return nil
+}
+
+func (m *memoryCache) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+func (m *memoryCache) Close() error {
+	return nil
+}
+
+type ssoTestEnv struct {
+	idp       *oidctest.Server
+	service   *SSOService
+	directory *fakeDirectory
+	users     *fakeUsers
+	domains   *fakeDomains
+	logins    *fakeLogins
+}
+
+func newSSOTestEnv(t *testing.T) *ssoTestEnv {
+	t.Helper()
+	idp := oidctest.NewServer("client-1", "secret-1")
+	t.Cleanup(idp.Close)
+
+	env := &ssoTestEnv{
+		idp: idp,
+		directory: &fakeDirectory{
+			orgs: map[string]*orgModel.Organization{
+				"acme.test":  {ID: testOrgID, Domain: "acme.test"},
+				"other.test": {ID: "org-2", Domain: "other.test"},
+				"corp.test":  {ID: testEnterpriseOrgID, Domain: "corp.test", Plan: "enterprise"},
+			},
+			members: map[string]string{},
+		},
+		users: &fakeUsers{users: map[string]*authModel.User{}},
+		domains: &fakeDomains{verified: map[string]string{
+			"acme.test":  testOrgID,
+			"other.test": "org-2",
+			"corp.test":  testEnterpriseOrgID,
+		}},
+		logins: &fakeLogins{},
+	}
+	repo := &fakeRepository{
+		providers:     map[string]*model.OIDCProvider{},
+		samlProviders: map[string]*model.SAMLProvider{},
+		identities:    map[string]string{},
+	}
+	env.service = NewSSOService(repo, env.directory, env.users, env.domains, env.logins, &memoryCache{values: map[string]string{}}, oidc.NewClient(nil), Config{
+		RedirectURL:            testRedirectURL,
+		SecretEncryptionKey:    "sso-test-key",
+		ServiceProviderBaseURL: "https://api.example.com",
+	}).(*SSOService)
+
+	_, err := env.service.ConfigureOIDCProvider(context.Background(), testOrgID, &model.ConfigureOIDCRequest{
+		Issuer:       idp.Issuer(),
+		ClientID:     "client-1",
+		ClientSecret: "secret-1",
+	})
+	require.NoError(t, err)
+	return env
+}
+
+// login runs the browser side of the flow against the stub provider
+func (env *ssoTestEnv) login(t *testing.T, email string) (*authService.LoginResponse, error) {
+	t.Helper()
+	start, err := env.service.StartLogin(context.Background(), &model.StartLoginRequest{Email: email})
+	require.NoError(t, err)
+
+	code, state, err := env.idp.Authorize(start.AuthorizationURL)
+	require.NoError(t, err)
+
+	return env.service.CompleteLogin(context.Background(), &model.CallbackRequest{Code: code, State: state})
+}
+
+func TestSSOService_CompleteLogin_ProvisionsUserAndMembership(t *testing.T) {
+	env := newSSOTestEnv(t)
+	env.idp.SetIdentity(oidctest.Identity{Subject: "idp-sub-1", Email: "Jane@Acme.test", GivenName: "Jane", FamilyName: "Doe"})
+
+	resp, err := env.login(t, "jane@acme.test")
+	require.NoError(t, err)
+	assert.Equal(t, "access-user-jane@acme.test", resp.AccessToken)
+
+	user := env.users.users["user-jane@acme.test"]
+	require.NotNil(t, user)
+	assert.True(t, user.EmailVerified)
+	assert.Equal(t, "Jane", user.FirstName)
+	assert.Equal(t, "Doe", user.LastName)
+	assert.Equal(t, "member", env.directory.members[testOrgID+"/"+user.ID])
+	assert.Equal(t, testOrgID, env.logins.organizationID)
+
+	// A second login finds the linked identity even if the provider's email changed
+	env.idp.SetIdentity(oidctest.Identity{Subject: "idp-sub-1", Email: "jane.doe@acme.test"})
+	_, err = env.login(t, "jane@acme.test")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, env.logins.userID)
+	assert.Len(t, env.users.users, 1)
+}
+
+func TestSSOService_CompleteLogin_RejectsForeignDomainEmail(t *testing.T) {
+	env := newSSOTestEnv(t)
+	env.idp.SetIdentity(oidctest.Identity{Subject: "idp-sub-2", Email: "mallory@other.test"})
+
+	_, err := env.login(t, "mallory@acme.test")
+	assert.Equal(t, errors.ErrSSOLoginFailed, err)
+	assert.Empty(t, env.users.users)
+	assert.Empty(t, env.directory.members)
+}
+
+func TestSSOService_CompleteLogin_RequiresVerifiedDomain(t *testing.T) {
+	env := newSSOTestEnv(t)
+	// acme.test is still the organization's declared domain, but unverified
+	delete(env.domains.verified, "acme.test")
+	env.users.users["user-1"] = &authModel.User{ID: "user-1", Email: "jane@acme.test"}
+	env.idp.SetIdentity(oidctest.Identity{Subject: "idp-sub-1", Email: "jane@acme.test"})
+
+	_, err := env.login(t, "jane@acme.test")
+	assert.Equal(t, errors.ErrSSOLoginFailed, err)
+	assert.Empty(t, env.directory.members)
+	assert.Empty(t, env.logins.userID)
+}
+
+func TestSSOService_CompleteLogin_StateIsSingleUse(t *testing.T) {
+	env := newSSOTestEnv(t)
+	env.idp.SetIdentity(oidctest.Identity{Subject: "idp-sub-1", Email: "jane@acme.test"})
+
+	start, err := env.service.StartLogin(context.Background(), &model.StartLoginRequest{Email: "jane@acme.test"})
+	require.NoError(t, err)
+	code, state, err := env.idp.Authorize(start.AuthorizationURL)
+	require.NoError(t, err)
+
+	_, err = env.service.CompleteLogin(context.Background(), &model.CallbackRequest{Code: code, State: state})
+	require.NoError(t, err)
+
+	_, err = env.service.CompleteLogin(context.Background(), &model.CallbackRequest{Code: code, State: state})
+	assert.Equal(t, errors.ErrSSOLoginFailed, err)
+}
+
+func TestSSOService_StartLogin_UnconfiguredDomain(t *testing.T) {
+	env := newSSOTestEnv(t)
+
+	_, err := env.service.StartLogin(context.Background(), &model.StartLoginRequest{Email: "bob@other.test"})
+	assert.Equal(t, errors.ErrSSONotConfigured, err)
+
+	_, err = env.service.StartLogin(context.Background(), &model.StartLoginRequest{Email: "bob@unknown.test"})
+	assert.Equal(t, errors.ErrSSONotConfigured, err)
+}
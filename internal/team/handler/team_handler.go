@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"net/http"
+
+	"ethos/internal/team/model"
+	"ethos/internal/team/service"
+	"ethos/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TeamHandler handles organization team HTTP requests
+type TeamHandler struct {
+	service service.Service
+}
+
+// NewTeamHandler creates a new team handler
+func NewTeamHandler(svc service.Service) *TeamHandler {
+	return &TeamHandler{
+		service: svc,
+	}
+}
+
+// CreateTeam handles POST /api/v1/organizations/:org_id/teams
+func (h *TeamHandler) CreateTeam(c *gin.Context) {
+	var req model.CreateTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Validation failed",
+			"code":  "VALIDATION_FAILED",
+		})
+		return
+	}
+
+	team, err := h.service.CreateTeam(c.Request.Context(), c.Param("org_id"), c.GetString("user_id"), &req, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, team)
+}
+
+// ListTeams handles GET /api/v1/organizations/:org_id/teams
+func (h *TeamHandler) ListTeams(c *gin.Context) {
+	teams, err := h.service.ListTeams(c.Request.Context(), c.Param("org_id"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"teams": teams,
+		"count": len(teams),
+	})
+}
+
+// GetTeam handles GET /api/v1/organizations/:org_id/teams/:team_id
+func (h *TeamHandler) GetTeam(c *gin.Context) {
+	team, err := h.service.GetTeam(c.Request.Context(), c.Param("org_id"), c.Param("team_id"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	members, err := h.service.ListTeamMembers(c.Request.Context(), c.Param("org_id"), c.Param("team_id"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"team":    team,
+		"members": members,
+	})
+}
+
+// DeleteTeam handles DELETE /api/v1/organizations/:org_id/teams/:team_id
+func (h *TeamHandler) DeleteTeam(c *gin.Context) {
+	err := h.service.DeleteTeam(c.Request.Context(), c.Param("org_id"), c.GetString("user_id"), c.Param("team_id"), c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Team deleted.",
+	})
+}
+
+// AddTeamMember handles PUT /api/v1/organizations/:org_id/teams/:team_id/members/:user_id
+func (h *TeamHandler) AddTeamMember(c *gin.Context) {
+	err := h.service.AddTeamMember(c.Request.Context(), c.Param("org_id"), c.GetString("user_id"), c.Param("team_id"), c.Param("user_id"), c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Member added to team.",
+	})
+}
+
+// RemoveTeamMember handles DELETE /api/v1/organizations/:org_id/teams/:team_id/members/:user_id
+func (h *TeamHandler) RemoveTeamMember(c *gin.Context) {
+	err := h.service.RemoveTeamMember(c.Request.Context(), c.Param("org_id"), c.GetString("user_id"), c.Param("team_id"), c.Param("user_id"), c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.HTTPStatus, gin.H{
+				"error": apiErr.Message,
+				"code":  apiErr.Code,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal server error",
+			"code":  "SERVER_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Member removed from team.",
+	})
+}
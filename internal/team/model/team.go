@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// Activity log actions recorded for teams
+const (
+	ActionTeamCreated       = "team_created"
+	ActionTeamDeleted       = "team_deleted"
+	ActionTeamMemberAdded   = "team_member_added"
+	ActionTeamMemberRemoved = "team_member_removed"
+)
+
+// Team is a named group of organization members that feedback can be
+// addressed to
+type Team struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organization_id"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description,omitempty"`
+	MemberCount    int       `json:"member_count"`
+	CreatedBy      string    `json:"created_by,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// TeamMember is an organization member who belongs to a team
+type TeamMember struct {
+	UserID  string    `json:"user_id"`
+	Name    string    `json:"name"`
+	Email   string    `json:"email"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// CreateTeamRequest represents a request to create a team
+type CreateTeamRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=1000"`
+}
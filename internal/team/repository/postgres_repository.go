@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"ethos/internal/database"
+	"ethos/internal/team/model"
+	"ethos/pkg/errors"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const teamColumns = `
+	t.id::text, t.organization_id::text, t.name, COALESCE(t.description, ''),
+	(SELECT COUNT(*) FROM organization_team_members m WHERE m.team_id = t.id),
+	COALESCE(t.created_by, ''), t.created_at
+`
+
+// PostgresRepository implements the Repository interface using PostgreSQL
+type PostgresRepository struct {
+	db *database.DB
+}
+
+// NewPostgresRepository creates a new PostgreSQL repository
+func NewPostgresRepository(db *database.DB) Repository {
+	return &PostgresRepository{db: db}
+}
+
+// CreateTeam stores a team
+func (r *PostgresRepository) CreateTeam(ctx context.Context, team *model.Team) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.CreateTeam")
+	defer span.End()
+
+	query := `
+		INSERT INTO organization_teams (organization_id, name, description, created_by)
+		VALUES ($1::uuid, $2, NULLIF($3, ''), NULLIF($4, ''))
+		RETURNING id::text, created_at
+	`
+
+	err := r.db.Pool.QueryRow(ctx, query,
+		team.OrganizationID,
+		team.Name,
+		team.Description,
+		team.CreatedBy,
+	).Scan(&team.ID, &team.CreatedAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if strings.Contains(err.Error(), "duplicate key value") || strings.Contains(err.Error(), "23505") {
+			return errors.ErrTeamAlreadyExists
+		}
+		return errors.WrapError(err, "failed to create team")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// ListTeams lists an organization's teams by name
+func (r *PostgresRepository) ListTeams(ctx context.Context, organizationID string) ([]*model.Team, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ListTeams")
+	defer span.End()
+
+	query := `SELECT ` + teamColumns + ` FROM organization_teams t WHERE t.organization_id::text = $1 ORDER BY LOWER(t.name)`
+
+	rows, err := r.db.Pool.Query(ctx, query, organizationID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list teams")
+	}
+	defer rows.Close()
+
+	teams := []*model.Team{}
+	for rows.Next() {
+		team, err := scanTeam(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, errors.WrapError(err, "failed to scan team")
+		}
+		teams = append(teams, team)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list teams")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return teams, nil
+}
+
+// GetTeam retrieves one of an organization's teams, or ErrNotFound
+func (r *PostgresRepository) GetTeam(ctx context.Context, organizationID, id string) (*model.Team, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.GetTeam")
+	defer span.End()
+
+	query := `SELECT ` + teamColumns + ` FROM organization_teams t WHERE t.id::text = $1 AND t.organization_id::text = $2`
+
+	team, err := scanTeam(r.db.Pool.QueryRow(ctx, query, id, organizationID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to get team")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return team, nil
+}
+
+// DeleteTeam removes one of an organization's teams
+func (r *PostgresRepository) DeleteTeam(ctx context.Context, organizationID, id string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.DeleteTeam")
+	defer span.End()
+
+	result, err := r.db.Pool.Exec(ctx, `DELETE FROM organization_teams WHERE id::text = $1 AND organization_id::text = $2`, id, organizationID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to delete team")
+	}
+	if result.RowsAffected() == 0 {
+		return errors.ErrNotFound
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// ListTeamMembers lists a team's members by name
+func (r *PostgresRepository) ListTeamMembers(ctx context.Context, teamID string) ([]*model.TeamMember, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.ListTeamMembers")
+	defer span.End()
+
+	query := `
+		SELECT m.user_id, COALESCE(u.name, ''), u.email, m.added_at
+		FROM organization_team_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.team_id::text = $1
+		ORDER BY LOWER(COALESCE(u.name, u.email))
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list team members")
+	}
+	defer rows.Close()
+
+	members := []*model.TeamMember{}
+	for rows.Next() {
+		var member model.TeamMember
+		if err := rows.Scan(&member.UserID, &member.Name, &member.Email, &member.AddedAt); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, errors.WrapError(err, "failed to scan team member")
+		}
+		members = append(members, &member)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.WrapError(err, "failed to list team members")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return members, nil
+}
+
+// AddTeamMember adds a user to a team
+func (r *PostgresRepository) AddTeamMember(ctx context.Context, teamID, userID string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.AddTeamMember")
+	defer span.End()
+
+	query := `
+		INSERT INTO organization_team_members (team_id, user_id)
+		VALUES ($1::uuid, $2)
+		ON CONFLICT (team_id, user_id) DO NOTHING
+	`
+
+	if _, err := r.db.Pool.Exec(ctx, query, teamID, userID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to add team member")
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// RemoveTeamMember removes a user from a team
+func (r *PostgresRepository) RemoveTeamMember(ctx context.Context, teamID, userID string) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "repository.RemoveTeamMember")
+	defer span.End()
+
+	result, err := r.db.Pool.Exec(ctx, `DELETE FROM organization_team_members WHERE team_id::text = $1 AND user_id = $2`, teamID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.WrapError(err, "failed to remove team member")
+	}
+	if result.RowsAffected() == 0 {
+		return errors.ErrNotFound
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+func scanTeam(row pgx.Row) (*model.Team, error) {
+	var team model.Team
+	err := row.Scan(
+		&team.ID,
+		&team.OrganizationID,
+		&team.Name,
+		&team.Description,
+		&team.MemberCount,
+		&team.CreatedBy,
+		&team.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"ethos/internal/team/model"
+)
+
+// Repository defines the interface for team data access
+type Repository interface {
+	// CreateTeam stores a team, or returns ErrTeamAlreadyExists if the
+	// organization has a team with the same name
+	CreateTeam(ctx context.Context, team *model.Team) error
+
+	// ListTeams lists an organization's teams by name
+	ListTeams(ctx context.Context, organizationID string) ([]*model.Team, error)
+
+	// GetTeam retrieves one of an organization's teams, or ErrNotFound
+	GetTeam(ctx context.Context, organizationID, id string) (*model.Team, error)
+
+	// DeleteTeam removes one of an organization's teams, or returns ErrNotFound
+	DeleteTeam(ctx context.Context, organizationID, id string) error
+
+	// ListTeamMembers lists a team's members by name
+	ListTeamMembers(ctx context.Context, teamID string) ([]*model.TeamMember, error)
+
+	// AddTeamMember adds a user to a team. Adding an existing member is a no-op.
+	AddTeamMember(ctx context.Context, teamID, userID string) error
+
+	// RemoveTeamMember removes a user from a team, or returns ErrNotFound
+	RemoveTeamMember(ctx context.Context, teamID, userID string) error
+}
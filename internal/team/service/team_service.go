@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"ethos/internal/team/model"
+)
+
+// Service defines the interface for organization teams
+type Service interface {
+	// CreateTeam creates a team in an organization
+	CreateTeam(ctx context.Context, organizationID, userID string, req *model.CreateTeamRequest, ipAddress, userAgent string) (*model.Team, error)
+
+	// ListTeams lists an organization's teams
+	ListTeams(ctx context.Context, organizationID string) ([]*model.Team, error)
+
+	// GetTeam retrieves one of an organization's teams
+	GetTeam(ctx context.Context, organizationID, teamID string) (*model.Team, error)
+
+	// ListTeamMembers lists the members of one of an organization's teams
+	ListTeamMembers(ctx context.Context, organizationID, teamID string) ([]*model.TeamMember, error)
+
+	// DeleteTeam deletes one of an organization's teams
+	DeleteTeam(ctx context.Context, organizationID, userID, teamID, ipAddress, userAgent string) error
+
+	// AddTeamMember adds an organization member to a team
+	AddTeamMember(ctx context.Context, organizationID, userID, teamID, memberID, ipAddress, userAgent string) error
+
+	// RemoveTeamMember removes a member from a team
+	RemoveTeamMember(ctx context.Context, organizationID, userID, teamID, memberID, ipAddress, userAgent string) error
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"ethos/internal/team/model"
+	"ethos/internal/team/repository"
+	"ethos/pkg/errors"
+)
+
+// MembershipChecker checks organization membership (implemented by the organization context repository)
+type MembershipChecker interface {
+	IsUserInOrganization(ctx context.Context, userID, organizationID string) (bool, error)
+}
+
+// ActivityLogger writes the organization activity log (implemented by the organization context repository)
+type ActivityLogger interface {
+	LogOrganizationActivity(ctx context.Context, organizationID, userID, action, resourceType, resourceID, ipAddress, userAgent string, changes map[string]interface{}) error
+}
+
+// TeamService implements the Service interface
+type TeamService struct {
+	repo     repository.Repository
+	members  MembershipChecker
+	activity ActivityLogger
+}
+
+// NewTeamService creates a new team service
+func NewTeamService(repo repository.Repository, members MembershipChecker, activity ActivityLogger) Service {
+	return &TeamService{
+		repo:     repo,
+		members:  members,
+		activity: activity,
+	}
+}
+
+// CreateTeam creates a team in an organization
+func (s *TeamService) CreateTeam(ctx context.Context, organizationID, userID string, req *model.CreateTeamRequest, ipAddress, userAgent string) (*model.Team, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.NewValidationError("team name is required")
+	}
+
+	team := &model.Team{
+		OrganizationID: organizationID,
+		Name:           name,
+		Description:    strings.TrimSpace(req.Description),
+		CreatedBy:      userID,
+	}
+	if err := s.repo.CreateTeam(ctx, team); err != nil {
+		return nil, err
+	}
+
+	s.logActivity(ctx, organizationID, userID, model.ActionTeamCreated, team.ID, ipAddress, userAgent, map[string]interface{}{
+		"name": team.Name,
+	})
+	return team, nil
+}
+
+// ListTeams lists an organization's teams
+func (s *TeamService) ListTeams(ctx context.Context, organizationID string) ([]*model.Team, error) {
+	return s.repo.ListTeams(ctx, organizationID)
+}
+
+// GetTeam retrieves one of an organization's teams
+func (s *TeamService) GetTeam(ctx context.Context, organizationID, teamID string) (*model.Team, error) {
+	return s.repo.GetTeam(ctx, organizationID, teamID)
+}
+
+// ListTeamMembers lists the members of one of an organization's teams
+func (s *TeamService) ListTeamMembers(ctx context.Context, organizationID, teamID string) ([]*model.TeamMember, error) {
+	if _, err := s.repo.GetTeam(ctx, organizationID, teamID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListTeamMembers(ctx, teamID)
+}
+
+// DeleteTeam deletes one of an organization's teams. Feedback addressed to
+// the team is kept.
+func (s *TeamService) DeleteTeam(ctx context.Context, organizationID, userID, teamID, ipAddress, userAgent string) error {
+	team, err := s.repo.GetTeam(ctx, organizationID, teamID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteTeam(ctx, organizationID, teamID); err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, organizationID, userID, model.ActionTeamDeleted, teamID, ipAddress, userAgent, map[string]interface{}{
+		"name": team.Name,
+	})
+	return nil
+}
+
+// AddTeamMember adds an organization member to a team. Only current
+// members of the team's organization can join it.
+func (s *TeamService) AddTeamMember(ctx context.Context, organizationID, userID, teamID, memberID, ipAddress, userAgent string) error {
+	if _, err := s.repo.GetTeam(ctx, organizationID, teamID); err != nil {
+		return err
+	}
+
+	isMember, err := s.members.IsUserInOrganization(ctx, memberID, organizationID)
+	if err != nil {
+		return errors.WrapError(err, "failed to check organization membership")
+	}
+	if !isMember {
+		return errors.NewValidationError("user is not a member of this organization")
+	}
+
+	if err := s.repo.AddTeamMember(ctx, teamID, memberID); err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, organizationID, userID, model.ActionTeamMemberAdded, teamID, ipAddress, userAgent, map[string]interface{}{
+		"user_id": memberID,
+	})
+	return nil
+}
+
+// RemoveTeamMember removes a member from a team
+func (s *TeamService) RemoveTeamMember(ctx context.Context, organizationID, userID, teamID, memberID, ipAddress, userAgent string) error {
+	if _, err := s.repo.GetTeam(ctx, organizationID, teamID); err != nil {
+		return err
+	}
+	if err := s.repo.RemoveTeamMember(ctx, teamID, memberID); err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, organizationID, userID, model.ActionTeamMemberRemoved, teamID, ipAddress, userAgent, map[string]interface{}{
+		"user_id": memberID,
+	})
+	return nil
+}
+
+func (s *TeamService) logActivity(ctx context.Context, organizationID, userID, action, teamID, ipAddress, userAgent string, changes map[string]interface{}) {
+	if err := s.activity.LogOrganizationActivity(ctx, organizationID, userID, action, "team", teamID, ipAddress, userAgent, changes); err != nil {
+		fmt.Printf("Failed to log team activity: %v\n", err)
+	}
+}
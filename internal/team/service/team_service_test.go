@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"ethos/internal/team/model"
+	"ethos/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRepository keeps teams and their members in memory
+type fakeRepository struct {
+	teams   map[string]*model.Team
+	members map[string]map[string]bool // team ID to user IDs
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{teams: map[string]*model.Team{}, members: map[string]map[string]bool{}}
+}
+
+func (r *fakeRepository) CreateTeam(ctx context.Context, team *model.Team) error {
+	for _, existing := range r.teams {
+		if existing.OrganizationID == team.OrganizationID && existing.Name == team.Name {
+			return errors.ErrTeamAlreadyExists
+		}
+	}
+	team.ID = fmt.Sprintf("team-%d", len(r.teams)+1)
+	r.teams[team.ID] = team
+	r.members[team.ID] = map[string]bool{}
+	return nil
+}
+
+func (r *fakeRepository) ListTeams(ctx context.Context, organizationID string) ([]*model.Team, error) {
+	var teams []*model.Team
+	for _, team := range r.teams {
+		if team.OrganizationID == organizationID {
+			teams = append(teams, team)
+		}
+	}
+	return teams, nil
+}
+
+func (r *fakeRepository) GetTeam(ctx context.Context, organizationID, id string) (*model.Team, error) {
+	team, ok := r.teams[id]
+	if !ok || team.OrganizationID != organizationID {
+		return nil, errors.ErrNotFound
+	}
+	return team, nil
+}
+
+func (r *fakeRepository) DeleteTeam(ctx context.Context, organizationID, id string) error {
+	delete(r.teams, id)
+	delete(r.members, id)
+	return nil
+}
+
+func (r *fakeRepository) ListTeamMembers(ctx context.Context, teamID string) ([]*model.TeamMember, error) {
+	var members []*model.TeamMember
+	for userID := range r.members[teamID] {
+		members = append(members, &model.TeamMember{UserID: userID})
+	}
+	return members, nil
+}
+
+func (r *fakeRepository) AddTeamMember(ctx context.Context, teamID, userID string) error {
+	r.members[teamID][userID] = true
+	return nil
+}
+
+func (r *fakeRepository) RemoveTeamMember(ctx context.Context, teamID, userID string) error {
+	if !r.members[teamID][userID] {
+		return errors.ErrNotFound
+	}
+	delete(r.members[teamID], userID)
+	return nil
+}
+
+// fakeOrganizations maps organization IDs to their members and records activity
+type fakeOrganizations struct {
+	members map[string][]string
+	actions []string
+}
+
+func (o *fakeOrganizations) IsUserInOrganization(ctx context.Context, userID, organizationID string) (bool, error) {
+	for _, member := range o.members[organizationID] {
+		if member == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (o *fakeOrganizations) LogOrganizationActivity(ctx context.Context, organizationID, userID, action, resourceType, resourceID, ipAddress, userAgent string, changes map[string]interface{}) error {
+	o.actions = append(o.actions, action)
+	return nil
+}
+
+func TestTeamService_CreateAndManageMembers(t *testing.T) {
+	repo := newFakeRepository()
+	orgs := &fakeOrganizations{members: map[string][]string{"org-1": {"admin", "user-1"}, "org-2": {"user-2"}}}
+	svc := NewTeamService(repo, orgs, orgs)
+	ctx := context.Background()
+
+	team, err := svc.CreateTeam(ctx, "org-1", "admin", &model.CreateTeamRequest{Name: "  Platform "}, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "Platform", team.Name)
+
+	_, err = svc.CreateTeam(ctx, "org-1", "admin", &model.CreateTeamRequest{Name: "Platform"}, "", "")
+	assert.Equal(t, errors.ErrTeamAlreadyExists, err)
+
+	require.NoError(t, svc.AddTeamMember(ctx, "org-1", "admin", team.ID, "user-1", "", ""))
+
+	// Only members of the team's organization can join it
+	err = svc.AddTeamMember(ctx, "org-1", "admin", team.ID, "user-2", "", "")
+	assert.Equal(t, "VALIDATION_FAILED", err.(*errors.APIError).Code)
+
+	// Teams can't be reached through another organization
+	_, err = svc.ListTeamMembers(ctx, "org-2", team.ID)
+	assert.Equal(t, errors.ErrNotFound, err)
+
+	members, err := svc.ListTeamMembers(ctx, "org-1", team.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.TeamMember{{UserID: "user-1"}}, members)
+
+	require.NoError(t, svc.RemoveTeamMember(ctx, "org-1", "admin", team.ID, "user-1", "", ""))
+	require.NoError(t, svc.DeleteTeam(ctx, "org-1", "admin", team.ID, "", ""))
+
+	assert.Equal(t, []string{
+		model.ActionTeamCreated,
+		model.ActionTeamMemberAdded,
+		model.ActionTeamMemberRemoved,
+		model.ActionTeamDeleted,
+	}, orgs.actions)
+}
@@ -2178,3 +2178,45 @@ const orgMemberAddedTemplate = "<!DOCTYPE html>\n" +
 	"    </div>\n" +
 	"</body>\n" +
 	"</html>"
+
+// Domain Verification Template
+const domainVerificationTemplate = `
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Verify Your Organization's Domain - Ethos Platform</title>
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; margin: 0; padding: 0; background-color: #f9fafb; }
+        .container { max-width: 600px; margin: 0 auto; background-color: #ffffff; }
+        .header { background: linear-gradient(135deg, #8b5cf6 0%, #7c3aed 100%); padding: 40px 30px; text-align: center; }
+        .content { padding: 40px 30px; }
+        .code { background-color: #faf5ff; border: 1px solid #d8b4fe; border-radius: 8px; padding: 20px; margin: 20px 0; text-align: center; font-size: 28px; font-weight: 700; letter-spacing: 6px; color: #6b21a8; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1 style="color: #ffffff; margin: 0; font-size: 28px; font-weight: 700;">Domain Verification</h1>
+        </div>
+
+        <div class="content">
+            <p style="color: #4b5563; line-height: 1.6; margin-bottom: 20px;">
+                The <strong>{{.Organization}}</strong> organization on the Ethos Platform has asked to verify that it controls <strong>{{.Domain}}</strong>.
+                Once verified, people who sign up with an @{{.Domain}} address can be added to the organization.
+            </p>
+
+            <p style="color: #4b5563; line-height: 1.6;">Enter this code to verify the domain:</p>
+
+            <div class="code">{{.Code}}</div>
+
+            <p style="color: #6b7280; font-size: 14px; line-height: 1.6;">
+                This code expires {{.ExpiryDate}}. If you don't recognize this organization, ignore this email and the domain will stay unverified.
+            </p>
+        </div>
+
+        ` + canSpamFooter + `
+    </div>
+</body>
+</html>`
@@ -149,6 +149,7 @@ const (
 	TemplateOrgMemberRemoved        = "org_member_removed"
 	TemplateOrgRoleChanged          = "org_role_changed"
 	TemplateOrgSettingsChanged      = "org_settings_changed"
+	TemplateDomainVerification      = "domain_verification"
 
 	// Escalation & Support Templates
 	TemplateEscalationReceived      = "escalation_received"
@@ -236,6 +237,10 @@ func GetTemplate(templateID string) map[string]interface{} {
 			"subject": "Organization Invitation - Ethos Platform",
 			"template_id": TemplateOrgInvitation,
 		},
+		TemplateDomainVerification: {
+			"subject": "Verify Your Organization's Domain - Ethos Platform",
+			"template_id": TemplateDomainVerification,
+		},
 		TemplateEscalationReceived: {
 			"subject": "New Escalation Requires Attention - Ethos",
 			"template_id": TemplateEscalationReceived,
@@ -303,6 +308,7 @@ func getTemplateHTML(templateID string) (string, error) {
 		TemplateAppealResolved: appealResolvedTemplate,
 		TemplateOrgInvitation: orgInvitationTemplate,
 		TemplateOrgMemberAdded: orgMemberAddedTemplate,
+		TemplateDomainVerification: domainVerificationTemplate,
 		TemplateEscalationReceived: escalationReceivedTemplate,
 		TemplateModerationAlert: moderationAlertTemplate,
 		TemplateAuditReportAvailable: auditReportAvailableTemplate,
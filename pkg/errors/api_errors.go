@@ -42,6 +42,117 @@ var (
 		HTTPStatus: http.StatusForbidden,
 	}
 
+	ErrInvalidTwoFactorCode = &APIError{
+		Message:    "Invalid two-factor authentication code",
+		Code:       "AUTH_2FA_INVALID_CODE",
+		HTTPStatus: http.StatusUnauthorized,
+	}
+
+	ErrAccountLocked = &APIError{
+		Message:    "Account is temporarily locked due to too many failed login attempts",
+		Code:       "AUTH_ACCOUNT_LOCKED",
+		HTTPStatus: http.StatusLocked,
+	}
+
+	ErrTooManyLoginAttempts = &APIError{
+		Message:    "Too many failed login attempts, please try again later",
+		Code:       "AUTH_TOO_MANY_ATTEMPTS",
+		HTTPStatus: http.StatusTooManyRequests,
+	}
+
+	ErrSSONotConfigured = &APIError{
+		Message:    "Single sign-on is not configured for this email domain",
+		Code:       "SSO_NOT_CONFIGURED",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrSSOLoginFailed = &APIError{
+		Message:    "Single sign-on login failed",
+		Code:       "SSO_LOGIN_FAILED",
+		HTTPStatus: http.StatusUnauthorized,
+	}
+
+	ErrPasskeyLoginFailed = &APIError{
+		Message:    "Passkey login failed",
+		Code:       "PASSKEY_LOGIN_FAILED",
+		HTTPStatus: http.StatusUnauthorized,
+	}
+
+	ErrPasskeyRegistrationFailed = &APIError{
+		Message:    "Passkey registration failed",
+		Code:       "PASSKEY_REGISTRATION_FAILED",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrPasskeyAlreadyRegistered = &APIError{
+		Message:    "Passkey is already registered",
+		Code:       "PASSKEY_ALREADY_REGISTERED",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrEnterprisePlanRequired = &APIError{
+		Message:    "This feature requires an enterprise plan",
+		Code:       "ENTERPRISE_PLAN_REQUIRED",
+		HTTPStatus: http.StatusForbidden,
+	}
+
+	ErrRoleAlreadyExists = &APIError{
+		Message:    "A role with this name already exists",
+		Code:       "ROLE_ALREADY_EXISTS",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrRoleInUse = &APIError{
+		Message:    "Role is still assigned to members",
+		Code:       "ROLE_IN_USE",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrAlreadyMember = &APIError{
+		Message:    "User is already a member of this organization",
+		Code:       "ALREADY_MEMBER",
+		HTTPStatus: http.StatusConflict,
+	}
+	ErrDomainAlreadyAdded = &APIError{
+		Message:    "This domain has already been added to the organization",
+		Code:       "DOMAIN_ALREADY_ADDED",
+		HTTPStatus: http.StatusConflict,
+	}
+	ErrDomainTaken = &APIError{
+		Message:    "This domain is verified by another organization",
+		Code:       "DOMAIN_TAKEN",
+		HTTPStatus: http.StatusConflict,
+	}
+	ErrInvitationPending = &APIError{
+		Message:    "An invitation is already pending for this address; resend it instead",
+		Code:       "INVITATION_PENDING",
+		HTTPStatus: http.StatusConflict,
+	}
+	ErrInvitationInvalid = &APIError{
+		Message:    "Invitation is invalid or has expired",
+		Code:       "INVITATION_INVALID",
+		HTTPStatus: http.StatusNotFound,
+	}
+	ErrSeatLimitReached = &APIError{
+		Message:    "Organization has no seats left; remove members or revoke pending invitations",
+		Code:       "SEAT_LIMIT_REACHED",
+		HTTPStatus: http.StatusConflict,
+	}
+	ErrExternalIDTaken = &APIError{
+		Message:    "Another member already has this external ID",
+		Code:       "EXTERNAL_ID_TAKEN",
+		HTTPStatus: http.StatusConflict,
+	}
+	ErrTeamAlreadyExists = &APIError{
+		Message:    "A team with this name already exists",
+		Code:       "TEAM_ALREADY_EXISTS",
+		HTTPStatus: http.StatusConflict,
+	}
+	ErrSuggestionAlreadyReviewed = &APIError{
+		Message:    "This template suggestion has already been reviewed",
+		Code:       "SUGGESTION_ALREADY_REVIEWED",
+		HTTPStatus: http.StatusConflict,
+	}
 	ErrValidationFailed = &APIError{
 		Message:    "Validation failed",
 		Code:       "VALIDATION_FAILED",
@@ -94,6 +205,24 @@ func NewValidationError(message string) *APIError {
 	}
 }
 
+// NewPermissionDeniedError creates a forbidden error explaining why a permission check failed
+func NewPermissionDeniedError(message string) *APIError {
+	return &APIError{
+		Message:    message,
+		Code:       "PERMISSION_DENIED",
+		HTTPStatus: http.StatusForbidden,
+	}
+}
+
+// NewInvalidFilterError creates an error for a list filter that can't be parsed or isn't supported
+func NewInvalidFilterError(message string) *APIError {
+	return &APIError{
+		Message:    message,
+		Code:       "INVALID_FILTER",
+		HTTPStatus: http.StatusBadRequest,
+	}
+}
+
 // WrapError wraps an error with context
 func WrapError(err error, context string) error {
 	return fmt.Errorf("%s: %w", context, err)
@@ -0,0 +1,160 @@
+// Package geoip looks up the approximate location of IP addresses in an
+// offline database, so logins can be located without calling a third party.
+//
+// The database is a CSV file of address ranges in the DB-IP "IP to City
+// Lite" layout, one range per line:
+//
+//	ip_start,ip_end,continent,country,region,city,latitude,longitude
+//
+// IPv4 and IPv6 ranges may be mixed; lines don't need to be sorted.
+package geoip
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidDatabase is returned when a database line can't be parsed
+var ErrInvalidDatabase = errors.New("geoip: invalid database")
+
+// earthRadiusKm is the mean radius of the Earth
+const earthRadiusKm = 6371.0
+
+// Location is where an IP address is registered
+type Location struct {
+	City      string
+	Region    string
+	Country   string // ISO 3166-1 alpha-2 code
+	Latitude  float64
+	Longitude float64
+}
+
+// String formats the location for people, e.g. "Lyon, Auvergne-Rhone-Alpes, FR"
+func (l *Location) String() string {
+	var parts []string
+	for _, part := range []string{l.City, l.Region, l.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// DistanceKm returns the great-circle distance between two locations
+func DistanceKm(aLat, aLon, bLat, bLon float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(bLat - aLat)
+	dLon := toRad(bLon - aLon)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(toRad(aLat))*math.Cos(toRad(bLat))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+type ipRange struct {
+	start    net.IP // 16-byte form
+	end      net.IP
+	location *Location
+}
+
+// Database is an in-memory GeoIP database, safe for concurrent lookups
+type Database struct {
+	ranges []ipRange // sorted by start
+}
+
+// Open loads a database file
+func Open(path string) (*Database, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("geoip: %w", err)
+	}
+	defer file.Close()
+	return Load(file)
+}
+
+// Load reads a database in CSV form
+func Load(r io.Reader) (*Database, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	db := &Database{}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidDatabase, line, err)
+		}
+		entry, err := parseRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidDatabase, line, err)
+		}
+		db.ranges = append(db.ranges, entry)
+	}
+
+	sort.Slice(db.ranges, func(i, j int) bool {
+		return bytes.Compare(db.ranges[i].start, db.ranges[j].start) < 0
+	})
+	return db, nil
+}
+
+// Lookup returns the location of an IP address, if the database covers it
+func (db *Database) Lookup(ip string) (*Location, bool) {
+	addr := net.ParseIP(strings.TrimSpace(ip))
+	if addr == nil {
+		return nil, false
+	}
+	addr = addr.To16()
+
+	// The last range starting at or before addr is the only one that can contain it
+	i := sort.Search(len(db.ranges), func(i int) bool {
+		return bytes.Compare(db.ranges[i].start, addr) > 0
+	}) - 1
+	if i < 0 || bytes.Compare(addr, db.ranges[i].end) > 0 {
+		return nil, false
+	}
+	return db.ranges[i].location, true
+}
+
+func parseRecord(record []string) (ipRange, error) {
+	if len(record) < 8 {
+		return ipRange{}, fmt.Errorf("expected 8 fields, got %d", len(record))
+	}
+	start := net.ParseIP(record[0])
+	end := net.ParseIP(record[1])
+	if start == nil || end == nil {
+		return ipRange{}, fmt.Errorf("invalid address range %q-%q", record[0], record[1])
+	}
+	if (start.To4() == nil) != (end.To4() == nil) || bytes.Compare(start.To16(), end.To16()) > 0 {
+		return ipRange{}, fmt.Errorf("invalid address range %q-%q", record[0], record[1])
+	}
+	latitude, err := strconv.ParseFloat(record[6], 64)
+	if err != nil {
+		return ipRange{}, fmt.Errorf("invalid latitude %q", record[6])
+	}
+	longitude, err := strconv.ParseFloat(record[7], 64)
+	if err != nil {
+		return ipRange{}, fmt.Errorf("invalid longitude %q", record[7])
+	}
+
+	return ipRange{
+		start: start.To16(),
+		end:   end.To16(),
+		location: &Location{
+			Country:   record[3],
+			Region:    record[4],
+			City:      record[5],
+			Latitude:  latitude,
+			Longitude: longitude,
+		},
+	}, nil
+}
@@ -0,0 +1,50 @@
+package geoip
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDatabase = `203.0.113.0,203.0.113.255,OC,AU,New South Wales,Sydney,-33.8688,151.2093
+198.51.100.0,198.51.100.127,EU,FR,Auvergne-Rhone-Alpes,Lyon,45.7640,4.8357
+2001:db8::,2001:db8:0:ffff:ffff:ffff:ffff:ffff,NA,US,California,San Francisco,37.7749,-122.4194
+`
+
+func TestLookup(t *testing.T) {
+	db, err := Load(strings.NewReader(testDatabase))
+	require.NoError(t, err)
+
+	location, ok := db.Lookup("198.51.100.42")
+	require.True(t, ok)
+	assert.Equal(t, "Lyon, Auvergne-Rhone-Alpes, FR", location.String())
+
+	location, ok = db.Lookup("2001:db8::1")
+	require.True(t, ok)
+	assert.Equal(t, "San Francisco", location.City)
+
+	location, ok = db.Lookup("203.0.113.255")
+	require.True(t, ok)
+	assert.Equal(t, "AU", location.Country)
+
+	for _, ip := range []string{"198.51.100.200", "192.0.2.1", "10.0.0.1", "not-an-ip", ""} {
+		_, ok := db.Lookup(ip)
+		assert.False(t, ok, ip)
+	}
+}
+
+func TestLoad_RejectsInvalidLines(t *testing.T) {
+	_, err := Load(strings.NewReader("198.51.100.0,198.51.100.127,EU,FR,,Lyon,north,4.8\n"))
+	assert.ErrorIs(t, err, ErrInvalidDatabase)
+
+	_, err = Load(strings.NewReader("198.51.100.127,198.51.100.0,EU,FR,,Lyon,45.7,4.8\n"))
+	assert.ErrorIs(t, err, ErrInvalidDatabase)
+}
+
+func TestDistanceKm(t *testing.T) {
+	// Lyon to Sydney is about 16,900 km
+	assert.InDelta(t, 16900, DistanceKm(45.7640, 4.8357, -33.8688, 151.2093), 100)
+	assert.Zero(t, DistanceKm(45.7640, 4.8357, 45.7640, 4.8357))
+}
@@ -15,16 +15,16 @@ import (
 )
 
 // CreateFeedbackService creates a feedback service based on protocol configuration
-func CreateFeedbackService(cfg *config.Config, grpcManager *ClientManager, repo feedbackRepo.Repository) feedbackService.Service {
+func CreateFeedbackService(cfg *config.Config, grpcManager *ClientManager, repo feedbackRepo.Repository, members feedbackService.MembershipChecker, notifier feedbackService.Notifier) feedbackService.Service {
 	if strings.ToLower(cfg.GRPC.FeedbackProtocol) == "grpc" && cfg.GRPC.Enabled {
 		grpcClient := grpcManager.GetFeedbackClient()
 		if grpcClient != nil {
 			client := feedbackService.NewGRPCFeedbackClient(grpcClient)
-			return feedbackService.NewFeedbackServiceWithClient(client, repo)
+			return feedbackService.NewFeedbackServiceWithClient(client, repo, members, notifier)
 		}
 	}
 	// Default to REST
-	return feedbackService.NewFeedbackService(repo)
+	return feedbackService.NewFeedbackService(repo, members, notifier)
 }
 
 // CreateDashboardService creates a dashboard service based on protocol configuration
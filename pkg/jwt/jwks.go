@@ -0,0 +1,108 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// JWK is a public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519 (RFC 8037)
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set as served from /.well-known/jwks.json
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public verification keys in JWK Set format
+func (k *Keyring) JWKS() JWKS {
+	set := JWKS{Keys: []JWK{}}
+	for _, key := range k.Keys() {
+		jwk := JWK{KeyID: key.ID, Use: "sig", Algorithm: key.Algorithm}
+		switch public := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+// ParseJWKS builds a verification-only keyring from a JWK Set document. Services
+// that only validate tokens use this with the document fetched from the
+// issuer's /.well-known/jwks.json, so they never hold a signing secret.
+func ParseJWKS(data []byte) (*Keyring, error) {
+	var set JWKS
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("jwt: invalid JWKS: %w", err)
+	}
+
+	keyring := NewKeyring()
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		var err error
+		switch jwk.KeyType {
+		case "RSA":
+			err = addRSAJWK(keyring, jwk)
+		case "OKP":
+			err = addEd25519JWK(keyring, jwk)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return keyring, nil
+}
+
+func addRSAJWK(keyring *Keyring, jwk JWK) error {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return fmt.Errorf("jwt: invalid modulus for key %q: %w", jwk.KeyID, err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return fmt.Errorf("jwt: invalid exponent for key %q: %w", jwk.KeyID, err)
+	}
+
+	return keyring.AddVerificationKey(jwk.KeyID, &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	})
+}
+
+func addEd25519JWK(keyring *Keyring, jwk JWK) error {
+	if jwk.Curve != "Ed25519" {
+		return nil
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	if err != nil || len(x) != ed25519.PublicKeySize {
+		return fmt.Errorf("jwt: invalid Ed25519 key %q", jwk.KeyID)
+	}
+	return keyring.AddVerificationKey(jwk.KeyID, ed25519.PublicKey(x))
+}
@@ -0,0 +1,215 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported asymmetric signing algorithms
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// Key is a verification key identified by its kid. Keys added with
+// AddSigningKey also hold the private half.
+type Key struct {
+	ID        string
+	Algorithm string
+	Public    crypto.PublicKey
+	private   crypto.PrivateKey
+}
+
+// Keyring holds one signing key and any number of verification keys. During
+// rotation the new key becomes the signing key while the previous public keys
+// stay in the ring until every token they signed has expired.
+type Keyring struct {
+	mu      sync.RWMutex
+	signing *Key
+	keys    map[string]*Key
+	order   []string
+}
+
+// NewKeyring creates an empty keyring
+func NewKeyring() *Keyring {
+	return &Keyring{keys: make(map[string]*Key)}
+}
+
+// AddSigningKey makes an RSA or Ed25519 private key the active signing key.
+// Its public half is also added for verification.
+func (k *Keyring) AddSigningKey(kid string, private crypto.PrivateKey) error {
+	var public crypto.PublicKey
+	switch key := private.(type) {
+	case *rsa.PrivateKey:
+		public = &key.PublicKey
+	case ed25519.PrivateKey:
+		public = key.Public()
+	default:
+		return fmt.Errorf("jwt: unsupported signing key type %T", private)
+	}
+
+	if err := k.AddVerificationKey(kid, public); err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys[kid].private = private
+	k.signing = k.keys[kid]
+	return nil
+}
+
+// AddVerificationKey adds an RSA or Ed25519 public key that tokens may be verified against
+func (k *Keyring) AddVerificationKey(kid string, public crypto.PublicKey) error {
+	if kid == "" {
+		return errors.New("jwt: key ID must not be empty")
+	}
+
+	var algorithm string
+	switch key := public.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < 2048 {
+			return errors.New("jwt: RSA keys must be at least 2048 bits")
+		}
+		algorithm = AlgorithmRS256
+	case ed25519.PublicKey:
+		algorithm = AlgorithmEdDSA
+	default:
+		return fmt.Errorf("jwt: unsupported verification key type %T", public)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if _, exists := k.keys[kid]; !exists {
+		k.order = append(k.order, kid)
+	}
+	k.keys[kid] = &Key{ID: kid, Algorithm: algorithm, Public: public}
+	return nil
+}
+
+// RemoveKey drops a retired verification key. The active signing key cannot be removed.
+func (k *Keyring) RemoveKey(kid string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.signing != nil && k.signing.ID == kid {
+		return errors.New("jwt: cannot remove the active signing key")
+	}
+
+	delete(k.keys, kid)
+	for i, id := range k.order {
+		if id == kid {
+			k.order = append(k.order[:i], k.order[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// Keys returns the verification keys in the order they were added
+func (k *Keyring) Keys() []*Key {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	keys := make([]*Key, 0, len(k.order))
+	for _, id := range k.order {
+		keys = append(keys, k.keys[id])
+	}
+	return keys
+}
+
+// sign signs claims with the active signing key and sets the kid header
+func (k *Keyring) sign(claims jwt.Claims) (string, error) {
+	k.mu.RLock()
+	signing := k.signing
+	k.mu.RUnlock()
+
+	if signing == nil {
+		return "", errors.New("jwt: keyring has no signing key")
+	}
+
+	token := jwt.NewWithClaims(signingMethod(signing.Algorithm), claims)
+	token.Header["kid"] = signing.ID
+	return token.SignedString(signing.private)
+}
+
+// keyFunc resolves the verification key from the token's kid header and
+// rejects tokens whose alg doesn't match that key
+func (k *Keyring) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	k.mu.RLock()
+	key, ok := k.keys[kid]
+	k.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown key ID %q", kid)
+	}
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.Public, nil
+}
+
+// ParseWithClaims verifies a token signed by one of the ring's keys and
+// decodes it into claims. It is used for tokens issued by other parties,
+// such as OpenID Connect ID tokens verified against the provider's JWKS.
+func (k *Keyring) ParseWithClaims(tokenString string, claims jwt.Claims, options ...jwt.ParserOption) error {
+	_, err := jwt.ParseWithClaims(tokenString, claims, k.keyFunc, options...)
+	return err
+}
+
+// HasKey reports whether the ring holds a verification key with the given kid
+func (k *Keyring) HasKey(kid string) bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	_, ok := k.keys[kid]
+	return ok
+}
+
+func signingMethod(algorithm string) jwt.SigningMethod {
+	if algorithm == AlgorithmEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+// ParsePrivateKeyPEM parses a PKCS#1 RSA or PKCS#8 RSA/Ed25519 private key
+func ParsePrivateKeyPEM(data []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("jwt: no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("jwt: failed to parse private key: %w", err)
+	}
+	return key, nil
+}
+
+// ParsePublicKeyPEM parses a PKIX RSA or Ed25519 public key
+func ParsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("jwt: no PEM block found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("jwt: failed to parse public key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,157 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRSAKeyring(t *testing.T, kid string) *Keyring {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyring := NewKeyring()
+	require.NoError(t, keyring.AddSigningKey(kid, key))
+	return keyring
+}
+
+func newEd25519Keyring(t *testing.T, kid string) *Keyring {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keyring := NewKeyring()
+	require.NoError(t, keyring.AddSigningKey(kid, key))
+	return keyring
+}
+
+func TestAsymmetricTokens_SignAndVerify(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyring *Keyring
+		alg     string
+	}{
+		{"RS256", newRSAKeyring(t, "rsa-1"), AlgorithmRS256},
+		{"EdDSA", newEd25519Keyring(t, "ed-1"), AlgorithmEdDSA},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tg := NewAsymmetricTokenGenerator(tt.keyring, "refresh-secret", time.Minute, time.Hour)
+			token, err := tg.GenerateAccessToken("user-1")
+			require.NoError(t, err)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &Claims{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.alg, parsed.Method.Alg())
+			assert.Equal(t, tt.keyring.Keys()[0].ID, parsed.Header["kid"])
+
+			userID, err := tg.ValidateAccessToken(token)
+			require.NoError(t, err)
+			assert.Equal(t, "user-1", userID)
+
+			// Refresh tokens still use the shared refresh secret and can't pass as access tokens
+			refresh, err := tg.GenerateRefreshToken("user-1")
+			require.NoError(t, err)
+			_, err = tg.ValidateAccessToken(refresh)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestKeyring_RotationKeepsOldKeysValid(t *testing.T) {
+	keyring := newRSAKeyring(t, "2024-01")
+	tg := NewAsymmetricTokenGenerator(keyring, "refresh-secret", time.Minute, time.Hour)
+	oldToken, err := tg.GenerateAccessToken("user-1")
+	require.NoError(t, err)
+
+	_, next, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, keyring.AddSigningKey("2024-02", next))
+
+	newToken, err := tg.GenerateAccessToken("user-1")
+	require.NoError(t, err)
+
+	for _, token := range []string{oldToken, newToken} {
+		_, err := tg.ValidateAccessToken(token)
+		assert.NoError(t, err)
+	}
+
+	require.NoError(t, keyring.RemoveKey("2024-01"))
+	_, err = tg.ValidateAccessToken(oldToken)
+	assert.Error(t, err)
+	assert.Error(t, keyring.RemoveKey("2024-02"))
+}
+
+func TestValidateAccessToken_RejectsAlgorithmConfusion(t *testing.T) {
+	keyring := newRSAKeyring(t, "rsa-1")
+	tg := NewAsymmetricTokenGenerator(keyring, "refresh-secret", time.Minute, time.Hour)
+
+	// An HS256 token using the public key bytes as the HMAC secret must not verify
+	publicDER, err := x509.MarshalPKIXPublicKey(keyring.Keys()[0].Public)
+	require.NoError(t, err)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{UserID: "user-1"})
+	forged.Header["kid"] = "rsa-1"
+	token, err := forged.SignedString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	require.NoError(t, err)
+
+	_, err = tg.ValidateAccessToken(token)
+	assert.Error(t, err)
+
+	// Unknown kid
+	other := NewAsymmetricTokenGenerator(newRSAKeyring(t, "rsa-2"), "refresh-secret", time.Minute, time.Hour)
+	token, err = other.GenerateAccessToken("user-1")
+	require.NoError(t, err)
+	_, err = tg.ValidateAccessToken(token)
+	assert.Error(t, err)
+}
+
+func TestJWKS_RoundTripToVerifier(t *testing.T) {
+	keyring := newRSAKeyring(t, "rsa-1")
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, keyring.AddVerificationKey("ed-old", edKey.Public()))
+
+	doc, err := json.Marshal(keyring.JWKS())
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(string(doc), `"kid":"rsa-1"`))
+	assert.NotContains(t, string(doc), `"d":`)
+
+	verifierKeys, err := ParseJWKS(doc)
+	require.NoError(t, err)
+	assert.Len(t, verifierKeys.Keys(), 2)
+
+	token, err := NewAsymmetricTokenGenerator(keyring, "refresh-secret", time.Minute, time.Hour).GenerateAccessToken("user-1")
+	require.NoError(t, err)
+
+	verifier := NewVerifier(verifierKeys)
+	userID, err := verifier.ValidateAccessToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", userID)
+
+	_, err = verifier.GenerateAccessToken("user-1")
+	assert.Error(t, err)
+}
+
+func TestParsePrivateKeyPEM(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+
+	parsed, err := ParsePrivateKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	require.NoError(t, err)
+	assert.Equal(t, edKey, parsed)
+
+	_, err = ParsePrivateKeyPEM([]byte("not a key"))
+	assert.Error(t, err)
+}
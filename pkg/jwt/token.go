@@ -6,20 +6,46 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
-// Claims represents JWT claims
+// Claims represents JWT claims. Access tokens also carry the session and
+// organization context they were issued for so middleware doesn't need a
+// database round trip to resolve them.
 type Claims struct {
-	UserID string `json:"user_id"`
+	UserID           string   `json:"user_id"`
+	SessionID        string   `json:"sid,omitempty"`
+	OrganizationID   string   `json:"org_id,omitempty"`
+	OrganizationRole string   `json:"org_role,omitempty"`
+	Roles            []string `json:"roles,omitempty"`
+	// ImpersonatorID is the platform admin acting as UserID, on impersonation tokens only
+	ImpersonatorID string `json:"impersonator_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// TokenGenerator handles JWT token generation and validation
+// SessionContext is the session and tenant context embedded in an access token
+type SessionContext struct {
+	SessionID        string
+	OrganizationID   string
+	OrganizationRole string
+	// Roles are the user's platform roles, e.g. platform_admin
+	Roles []string
+	// ImpersonatorID marks the token as issued to a platform admin acting as the user
+	ImpersonatorID string
+}
+
+// TokenGenerator handles JWT token generation and validation. Access tokens
+// are signed with HS256 and accessSecret, or with the keyring's asymmetric
+// signing key when one is configured. Refresh tokens are only ever read by
+// this service and always use HS256 with refreshSecret.
 type TokenGenerator struct {
 	accessSecret  []byte
 	refreshSecret []byte
+	keyring       *Keyring
 	accessExpiry  time.Duration
 	refreshExpiry time.Duration
+	issuer        string
+	audience      string
 }
 
 // NewTokenGenerator creates a new token generator
@@ -32,26 +58,97 @@ func NewTokenGenerator(accessSecret, refreshSecret string, accessExpiry, refresh
 	}
 }
 
+// NewAsymmetricTokenGenerator creates a token generator that signs access
+// tokens with the keyring's signing key (RS256 or EdDSA) so other services can
+// verify them from the published JWKS
+func NewAsymmetricTokenGenerator(keyring *Keyring, refreshSecret string, accessExpiry, refreshExpiry time.Duration) *TokenGenerator {
+	return &TokenGenerator{
+		refreshSecret: []byte(refreshSecret),
+		keyring:       keyring,
+		accessExpiry:  accessExpiry,
+		refreshExpiry: refreshExpiry,
+	}
+}
+
+// NewVerifier creates a validate-only token generator for services that
+// verify access tokens against a keyring, typically built with ParseJWKS
+func NewVerifier(keyring *Keyring) *TokenGenerator {
+	return &TokenGenerator{keyring: keyring}
+}
+
+// Keyring returns the asymmetric keyring, or nil when access tokens use HS256
+func (tg *TokenGenerator) Keyring() *Keyring {
+	return tg.keyring
+}
+
+// SetIssuer sets the iss and aud claims stamped on access tokens. Once set,
+// access tokens with a different issuer or audience are rejected.
+func (tg *TokenGenerator) SetIssuer(issuer, audience string) {
+	tg.issuer = issuer
+	tg.audience = audience
+}
+
 // GenerateAccessToken generates a new access token
 func (tg *TokenGenerator) GenerateAccessToken(userID string) (string, error) {
+	return tg.GenerateAccessTokenWithContext(userID, SessionContext{})
+}
+
+// GenerateAccessTokenWithContext generates an access token carrying the
+// session ID, active organization and roles. The jti lets a single token be
+// revoked before it expires.
+func (tg *TokenGenerator) GenerateAccessTokenWithContext(userID string, sc SessionContext) (string, error) {
+	return tg.generateAccessToken(userID, sc, tg.accessExpiry)
+}
+
+// GenerateImpersonationToken generates an access token for a platform admin
+// acting as userID. It expires after ttl, or the normal access token
+// lifetime if that is shorter.
+func (tg *TokenGenerator) GenerateImpersonationToken(userID, impersonatorID string, sc SessionContext, ttl time.Duration) (string, error) {
+	if impersonatorID == "" {
+		return "", errors.New("impersonation tokens need an impersonator")
+	}
+	if ttl <= 0 || ttl > tg.accessExpiry {
+		ttl = tg.accessExpiry
+	}
+	sc.ImpersonatorID = impersonatorID
+	return tg.generateAccessToken(userID, sc, ttl)
+}
+
+func (tg *TokenGenerator) generateAccessToken(userID string, sc SessionContext, expiry time.Duration) (string, error) {
 	claims := &Claims{
-		UserID: userID,
+		UserID:           userID,
+		SessionID:        sc.SessionID,
+		OrganizationID:   sc.OrganizationID,
+		OrganizationRole: sc.OrganizationRole,
+		Roles:            sc.Roles,
+		ImpersonatorID:   sc.ImpersonatorID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(tg.accessExpiry)),
+			ID:        uuid.New().String(),
+			Issuer:    tg.issuer,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
 	}
+	if tg.audience != "" {
+		claims.Audience = jwt.ClaimStrings{tg.audience}
+	}
+
+	if tg.keyring != nil {
+		return tg.keyring.sign(claims)
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(tg.accessSecret)
 }
 
-// GenerateRefreshToken generates a new refresh token
+// GenerateRefreshToken generates a new refresh token. Each token carries a
+// unique ID so tokens issued in the same second never collide.
 func (tg *TokenGenerator) GenerateRefreshToken(userID string) (string, error) {
 	claims := &Claims{
 		UserID: userID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(tg.refreshExpiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
@@ -64,34 +161,66 @@ func (tg *TokenGenerator) GenerateRefreshToken(userID string) (string, error) {
 
 // ValidateAccessToken validates an access token and returns the user ID
 func (tg *TokenGenerator) ValidateAccessToken(tokenString string) (string, error) {
-	return tg.validateToken(tokenString, tg.accessSecret)
+	claims, err := tg.ParseAccessToken(tokenString)
+	if err != nil {
+		return "", err
+	}
+	return claims.UserID, nil
+}
+
+// ParseAccessToken validates an access token and returns all of its claims
+func (tg *TokenGenerator) ParseAccessToken(tokenString string) (*Claims, error) {
+	var options []jwt.ParserOption
+	if tg.issuer != "" {
+		options = append(options, jwt.WithIssuer(tg.issuer))
+	}
+	if tg.audience != "" {
+		options = append(options, jwt.WithAudience(tg.audience))
+	}
+
+	if tg.keyring != nil {
+		return tg.validateToken(tokenString, tg.keyring.keyFunc, options...)
+	}
+	return tg.validateToken(tokenString, hmacKeyFunc(tg.accessSecret), options...)
 }
 
 // ValidateRefreshToken validates a refresh token and returns the user ID
 func (tg *TokenGenerator) ValidateRefreshToken(tokenString string) (string, error) {
-	return tg.validateToken(tokenString, tg.refreshSecret)
+	if len(tg.refreshSecret) == 0 {
+		return "", errors.New("invalid token")
+	}
+	claims, err := tg.validateToken(tokenString, hmacKeyFunc(tg.refreshSecret))
+	if err != nil {
+		return "", err
+	}
+	return claims.UserID, nil
 }
 
-func (tg *TokenGenerator) validateToken(tokenString string, secret []byte) (string, error) {
-	claims := &Claims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+// hmacKeyFunc only accepts HMAC-signed tokens, so an attacker can't pick the algorithm
+func hmacKeyFunc(secret []byte) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return secret, nil
-	})
+	}
+}
+
+func (tg *TokenGenerator) validateToken(tokenString string, keyFunc jwt.Keyfunc, options ...jwt.ParserOption) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, keyFunc, options...)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
-			return "", errors.New("token expired")
+			return nil, errors.New("token expired")
 		}
-		return "", fmt.Errorf("invalid token: %w", err)
+		return nil, fmt.Errorf("invalid token: %w", err)
 	}
 
 	if !token.Valid {
-		return "", errors.New("invalid token")
+		return nil, errors.New("invalid token")
 	}
 
-	return claims.UserID, nil
+	return claims, nil
 }
 
@@ -0,0 +1,78 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAccessTokenWithContext_RoundTrip(t *testing.T) {
+	tg := NewTokenGenerator("access-secret", "refresh-secret", time.Minute, time.Hour)
+	tg.SetIssuer("ethos", "ethos-api")
+
+	token, err := tg.GenerateAccessTokenWithContext("user-1", SessionContext{
+		SessionID:        "session-1",
+		OrganizationID:   "org-1",
+		OrganizationRole: "admin",
+		Roles:            []string{"platform_admin"},
+	})
+	require.NoError(t, err)
+
+	claims, err := tg.ParseAccessToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.UserID)
+	assert.Equal(t, "session-1", claims.SessionID)
+	assert.Equal(t, "org-1", claims.OrganizationID)
+	assert.Equal(t, "admin", claims.OrganizationRole)
+	assert.Equal(t, []string{"platform_admin"}, claims.Roles)
+	assert.Equal(t, "ethos", claims.Issuer)
+	assert.Equal(t, []string{"ethos-api"}, []string(claims.Audience))
+}
+
+func TestParseAccessToken_EnforcesIssuerAndAudience(t *testing.T) {
+	issuer := NewTokenGenerator("access-secret", "refresh-secret", time.Minute, time.Hour)
+	issuer.SetIssuer("ethos", "ethos-api")
+
+	otherAudience := NewTokenGenerator("access-secret", "refresh-secret", time.Minute, time.Hour)
+	otherAudience.SetIssuer("ethos", "billing-api")
+
+	unset := NewTokenGenerator("access-secret", "refresh-secret", time.Minute, time.Hour)
+
+	token, err := issuer.GenerateAccessToken("user-1")
+	require.NoError(t, err)
+
+	_, err = otherAudience.ParseAccessToken(token)
+	assert.Error(t, err)
+
+	// Tokens without iss/aud are rejected once they are configured
+	legacy, err := unset.GenerateAccessToken("user-1")
+	require.NoError(t, err)
+	_, err = issuer.ParseAccessToken(legacy)
+	assert.Error(t, err)
+}
+
+func TestGenerateImpersonationToken(t *testing.T) {
+	tg := NewTokenGenerator("access-secret", "refresh-secret", 15*time.Minute, time.Hour)
+
+	token, err := tg.GenerateImpersonationToken("user-1", "admin-1", SessionContext{OrganizationID: "org-1"}, 5*time.Minute)
+	require.NoError(t, err)
+
+	claims, err := tg.ParseAccessToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.UserID)
+	assert.Equal(t, "admin-1", claims.ImpersonatorID)
+	assert.Equal(t, "org-1", claims.OrganizationID)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), claims.ExpiresAt.Time, 5*time.Second)
+
+	// Never outlives a normal access token
+	token, err = tg.GenerateImpersonationToken("user-1", "admin-1", SessionContext{}, time.Hour)
+	require.NoError(t, err)
+	claims, err = tg.ParseAccessToken(token)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), claims.ExpiresAt.Time, 5*time.Second)
+
+	_, err = tg.GenerateImpersonationToken("user-1", "", SessionContext{}, time.Minute)
+	assert.Error(t, err)
+}
@@ -0,0 +1,278 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	ethosjwt "ethos/pkg/jwt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	// ErrInvalidIDToken is returned for ID tokens with a bad signature, issuer, audience, expiry or nonce
+	ErrInvalidIDToken = errors.New("oidc: invalid ID token")
+	// ErrExchangeFailed is returned when the token endpoint rejects an authorization code
+	ErrExchangeFailed = errors.New("oidc: authorization code exchange failed")
+)
+
+// discoveryTTL is how long discovery documents and key sets are reused before being fetched again
+const discoveryTTL = time.Hour
+
+// Provider is the subset of an OpenID Provider's discovery document used for the authorization code flow
+type Provider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// IDTokenClaims are the standard claims read from a verified ID token
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce         string `json:"nonce"`
+	Email         string `json:"email"`
+	EmailVerified *bool  `json:"email_verified,omitempty"`
+	Name          string `json:"name"`
+	GivenName     string `json:"given_name"`
+	FamilyName    string `json:"family_name"`
+}
+
+// TokenResponse is the token endpoint response for an authorization code grant
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// AuthRequest holds the per-login values sent to the authorization endpoint
+type AuthRequest struct {
+	ClientID      string
+	RedirectURL   string
+	State         string
+	Nonce         string
+	CodeChallenge string
+	LoginHint     string
+}
+
+type providerEntry struct {
+	provider  *Provider
+	keys      *ethosjwt.Keyring
+	fetchedAt time.Time
+}
+
+// Client performs OpenID Connect discovery, code exchange and ID token
+// verification. Discovery documents and key sets are cached per issuer.
+type Client struct {
+	httpClient *http.Client
+	mu         sync.Mutex
+	providers  map[string]*providerEntry
+}
+
+// NewClient creates an OIDC client. A nil httpClient uses a client with a 10 second timeout.
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Client{
+		httpClient: httpClient,
+		providers:  make(map[string]*providerEntry),
+	}
+}
+
+// Discover fetches the issuer's /.well-known/openid-configuration and key set
+func (c *Client) Discover(ctx context.Context, issuer string) (*Provider, error) {
+	entry, err := c.entry(ctx, issuer, false)
+	if err != nil {
+		return nil, err
+	}
+	return entry.provider, nil
+}
+
+// AuthCodeURL builds the authorization endpoint URL for the code flow with PKCE (S256)
+func (p *Provider) AuthCodeURL(req AuthRequest) string {
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {req.ClientID},
+		"redirect_uri":          {req.RedirectURL},
+		"scope":                 {"openid email profile"},
+		"state":                 {req.State},
+		"nonce":                 {req.Nonce},
+		"code_challenge":        {req.CodeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+	if req.LoginHint != "" {
+		params.Set("login_hint", req.LoginHint)
+	}
+
+	separator := "?"
+	if strings.Contains(p.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return p.AuthorizationEndpoint + separator + params.Encode()
+}
+
+// Exchange trades an authorization code for tokens, authenticating with client_secret_post
+func (c *Client) Exchange(ctx context.Context, p *Provider, clientID, clientSecret, redirectURL, code, codeVerifier string) (*TokenResponse, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURL},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+		"code_verifier": {codeVerifier},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: token endpoint returned %d", ErrExchangeFailed, resp.StatusCode)
+	}
+
+	var token TokenResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&token); err != nil {
+		return nil, fmt.Errorf("oidc: invalid token response: %w", err)
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("%w: no id_token in response", ErrExchangeFailed)
+	}
+	return &token, nil
+}
+
+// VerifyIDToken checks the ID token's signature against the provider's key
+// set, its issuer, audience and expiry, and that it carries the expected nonce.
+// An unknown kid triggers one key set refresh to follow provider key rotation.
+func (c *Client) VerifyIDToken(ctx context.Context, p *Provider, rawIDToken, clientID, nonce string) (*IDTokenClaims, error) {
+	entry, err := c.entry(ctx, p.Issuer, false)
+	if err != nil {
+		return nil, err
+	}
+
+	unverified, _, err := jwt.NewParser().ParseUnverified(rawIDToken, &IDTokenClaims{})
+	if err != nil {
+		return nil, ErrInvalidIDToken
+	}
+	if kid, _ := unverified.Header["kid"].(string); !entry.keys.HasKey(kid) {
+		if entry, err = c.entry(ctx, p.Issuer, true); err != nil {
+			return nil, err
+		}
+	}
+
+	claims := &IDTokenClaims{}
+	err = entry.keys.ParseWithClaims(rawIDToken, claims,
+		jwt.WithIssuer(p.Issuer),
+		jwt.WithAudience(clientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+	if nonce == "" || claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	}
+	return claims, nil
+}
+
+// entry returns the cached discovery document and key set, fetching them
+// when missing, stale or when refresh is set
+func (c *Client) entry(ctx context.Context, issuer string, refresh bool) (*providerEntry, error) {
+	issuer = strings.TrimSuffix(issuer, "/")
+
+	c.mu.Lock()
+	entry, ok := c.providers[issuer]
+	c.mu.Unlock()
+	if ok && !refresh && time.Since(entry.fetchedAt) < discoveryTTL {
+		return entry, nil
+	}
+
+	var provider Provider
+	if err := c.getJSON(ctx, issuer+"/.well-known/openid-configuration", &provider); err != nil {
+		return nil, err
+	}
+	// The issuer in the document must match the one configured (OIDC Discovery 4.3)
+	if strings.TrimSuffix(provider.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("oidc: discovery issuer %q does not match %q", provider.Issuer, issuer)
+	}
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" || provider.JWKSURI == "" {
+		return nil, errors.New("oidc: discovery document is missing endpoints")
+	}
+
+	var rawKeys json.RawMessage
+	if err := c.getJSON(ctx, provider.JWKSURI, &rawKeys); err != nil {
+		return nil, err
+	}
+	keys, err := ethosjwt.ParseJWKS(rawKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	entry = &providerEntry{provider: &provider, keys: keys, fetchedAt: time.Now()}
+	c.mu.Lock()
+	c.providers[issuer] = entry
+	c.mu.Unlock()
+	return entry, nil
+}
+
+func (c *Client) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("oidc: fetching %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("oidc: fetching %s: status %d", url, resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v); err != nil {
+		return fmt.Errorf("oidc: decoding %s: %w", url, err)
+	}
+	return nil
+}
+
+// RandomString returns a URL-safe random value for state, nonce and PKCE verifiers
+func RandomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CodeChallenge derives the S256 PKCE challenge for a verifier
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,77 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ethos/pkg/oidc"
+	"ethos/pkg/oidc/oidctest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const redirectURL = "http://localhost:5173/sso/callback"
+
+func authorize(t *testing.T, idp *oidctest.Server, provider *oidc.Provider, nonce, verifier string) string {
+	t.Helper()
+	authURL := provider.AuthCodeURL(oidc.AuthRequest{
+		ClientID:      idp.ClientID,
+		RedirectURL:   redirectURL,
+		State:         "state-1",
+		Nonce:         nonce,
+		CodeChallenge: oidc.CodeChallenge(verifier),
+	})
+	code, state, err := idp.Authorize(authURL)
+	require.NoError(t, err)
+	require.Equal(t, "state-1", state)
+	return code
+}
+
+func TestClient_CodeFlow(t *testing.T) {
+	idp := oidctest.NewServer("client-1", "secret-1")
+	defer idp.Close()
+	idp.SetIdentity(oidctest.Identity{Subject: "sub-1", Email: "jane@acme.test", GivenName: "Jane"})
+
+	client := oidc.NewClient(nil)
+	ctx := context.Background()
+
+	provider, err := client.Discover(ctx, idp.Issuer())
+	require.NoError(t, err)
+
+	code := authorize(t, idp, provider, "nonce-1", "verifier-1")
+	token, err := client.Exchange(ctx, provider, "client-1", "secret-1", redirectURL, code, "verifier-1")
+	require.NoError(t, err)
+
+	claims, err := client.VerifyIDToken(ctx, provider, token.IDToken, "client-1", "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, "sub-1", claims.Subject)
+	assert.Equal(t, "jane@acme.test", claims.Email)
+	assert.Equal(t, "Jane", claims.GivenName)
+}
+
+func TestClient_RejectsWrongVerifierAndNonce(t *testing.T) {
+	idp := oidctest.NewServer("client-1", "secret-1")
+	defer idp.Close()
+	idp.SetIdentity(oidctest.Identity{Subject: "sub-1", Email: "jane@acme.test"})
+
+	client := oidc.NewClient(nil)
+	ctx := context.Background()
+	provider, err := client.Discover(ctx, idp.Issuer())
+	require.NoError(t, err)
+
+	code := authorize(t, idp, provider, "nonce-1", "verifier-1")
+	_, err = client.Exchange(ctx, provider, "client-1", "secret-1", redirectURL, code, "other-verifier")
+	assert.True(t, errors.Is(err, oidc.ErrExchangeFailed))
+
+	code = authorize(t, idp, provider, "nonce-1", "verifier-1")
+	token, err := client.Exchange(ctx, provider, "client-1", "secret-1", redirectURL, code, "verifier-1")
+	require.NoError(t, err)
+
+	_, err = client.VerifyIDToken(ctx, provider, token.IDToken, "client-1", "nonce-2")
+	assert.True(t, errors.Is(err, oidc.ErrInvalidIDToken))
+
+	_, err = client.VerifyIDToken(ctx, provider, token.IDToken, "client-2", "nonce-1")
+	assert.True(t, errors.Is(err, oidc.ErrInvalidIDToken))
+}
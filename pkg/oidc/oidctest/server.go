@@ -0,0 +1,219 @@
+// Package oidctest provides a stub OpenID Provider for tests. It implements
+// discovery, a key set, an authorization endpoint that approves immediately
+// and a token endpoint that checks client credentials and PKCE.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"ethos/pkg/oidc"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Identity is the user the stub provider signs in on the next authorization
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified *bool
+	GivenName     string
+	FamilyName    string
+}
+
+type pendingCode struct {
+	identity      Identity
+	redirectURL   string
+	nonce         string
+	codeChallenge string
+}
+
+// Server is a stub OpenID Provider backed by httptest.Server
+type Server struct {
+	*httptest.Server
+	ClientID     string
+	ClientSecret string
+	// Audience overrides the aud claim of issued ID tokens when set
+	Audience string
+
+	key      *rsa.PrivateKey
+	keyID    string
+	mu       sync.Mutex
+	identity Identity
+	codes    map[string]pendingCode
+}
+
+// NewServer starts a stub provider. Close it when done.
+func NewServer(clientID, clientSecret string) *Server {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	s := &Server{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		key:          key,
+		keyID:        "stub-key-1",
+		codes:        make(map[string]pendingCode),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/jwks", s.jwks)
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Issuer returns the provider's issuer identifier
+func (s *Server) Issuer() string {
+	return s.URL
+}
+
+// SetIdentity sets the user signed in by subsequent authorizations
+func (s *Server) SetIdentity(identity Identity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.identity = identity
+}
+
+// Authorize follows an authorization URL the way a browser would and returns
+// the code and state the provider redirects back with
+func (s *Server) Authorize(authURL string) (code, state string, err error) {
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return "", "", err
+	}
+	return location.Query().Get("code"), location.Query().Get("state"), nil
+}
+
+func (s *Server) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, oidc.Provider{
+		Issuer:                s.URL,
+		AuthorizationEndpoint: s.URL + "/authorize",
+		TokenEndpoint:         s.URL + "/token",
+		JWKSURI:               s.URL + "/jwks",
+	})
+}
+
+func (s *Server) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": s.keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != s.ClientID || query.Get("response_type") != "code" || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+
+	code, _ := oidc.RandomString()
+	s.mu.Lock()
+	s.codes[code] = pendingCode{
+		identity:      s.identity,
+		redirectURL:   query.Get("redirect_uri"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+	}
+	s.mu.Unlock()
+
+	redirect, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+	params := redirect.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	redirect.RawQuery = params.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	if r.PostForm.Get("client_id") != s.ClientID || r.PostForm.Get("client_secret") != s.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	s.mu.Lock()
+	pending, ok := s.codes[r.PostForm.Get("code")]
+	delete(s.codes, r.PostForm.Get("code"))
+	s.mu.Unlock()
+
+	if !ok || pending.redirectURL != r.PostForm.Get("redirect_uri") || oidc.CodeChallenge(r.PostForm.Get("code_verifier")) != pending.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	audience := s.Audience
+	if audience == "" {
+		audience = s.ClientID
+	}
+	now := time.Now()
+	claims := oidc.IDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.URL,
+			Subject:   pending.identity.Subject,
+			Audience:  jwt.ClaimStrings{audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(5 * time.Minute)),
+		},
+		Nonce:         pending.nonce,
+		Email:         pending.identity.Email,
+		EmailVerified: pending.identity.EmailVerified,
+		GivenName:     pending.identity.GivenName,
+		FamilyName:    pending.identity.FamilyName,
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.keyID
+	idToken, err := token.SignedString(s.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, oidc.TokenResponse{
+		AccessToken: "stub-access-token",
+		TokenType:   "Bearer",
+		IDToken:     idToken,
+		ExpiresIn:   300,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
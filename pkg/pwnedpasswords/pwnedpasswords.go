@@ -0,0 +1,84 @@
+// Package pwnedpasswords checks passwords against an offline copy of the
+// Have I Been Pwned "Pwned Passwords" dataset, so no part of a password's
+// hash ever leaves the server.
+//
+// The dataset is the k-anonymity range layout the HIBP downloader produces:
+// a directory holding one file per five-character SHA-1 prefix, named
+// "<PREFIX>.txt", whose lines list the remaining 35 characters of each
+// breached hash and how often it was seen:
+//
+//	0018A45C4D1DEF81644B54AB7F969B88D65:10
+//
+// Only the range file for a password's prefix is read.
+package pwnedpasswords
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrRangeMissing is returned when the dataset has no file for a hash
+// prefix, which means the download is incomplete
+var ErrRangeMissing = errors.New("pwnedpasswords: range file missing")
+
+// prefixLength is how many hex characters of the SHA-1 hash name a range file
+const prefixLength = 5
+
+// Dataset is an offline Pwned Passwords range directory
+type Dataset struct {
+	dir string
+}
+
+// Open opens the range directory at dir
+func Open(dir string) (*Dataset, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("pwnedpasswords: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("pwnedpasswords: %s is not a directory", dir)
+	}
+	return &Dataset{dir: dir}, nil
+}
+
+// Count returns how many times password appears in the dataset, or zero
+// if it was never seen in a breach
+func (d *Dataset) Count(password string) (int, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+
+	file, err := os.Open(filepath.Join(d.dir, prefix+".txt"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, fmt.Errorf("%w: %s", ErrRangeMissing, prefix)
+		}
+		return 0, fmt.Errorf("pwnedpasswords: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		candidate, count, ok := strings.Cut(line, ":")
+		if !ok || !strings.EqualFold(candidate, suffix) {
+			continue
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			return 0, fmt.Errorf("pwnedpasswords: invalid count in range %s: %q", prefix, line)
+		}
+		return n, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("pwnedpasswords: %w", err)
+	}
+	return 0, nil
+}
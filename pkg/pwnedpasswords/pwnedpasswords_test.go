@@ -0,0 +1,46 @@
+package pwnedpasswords
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCount(t *testing.T) {
+	dir := t.TempDir()
+	// SHA-1("password") is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "5BAA6.txt"), []byte(
+		"003D68EB55068C33ACE09247EE4C639306B:3\r\n"+
+			"1E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\r\n"+
+			"1E4C9B93F3F0682250B6CF8331B7EE68FD9:1\r\n",
+	), 0o600))
+
+	dataset, err := Open(dir)
+	require.NoError(t, err)
+
+	count, err := dataset.Count("password")
+	require.NoError(t, err)
+	assert.Equal(t, 9545824, count)
+
+	// A range without the hash suffix means the password was never breached
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "5BAA6.txt"), []byte("003D68EB55068C33ACE09247EE4C639306B:3\n"), 0o600))
+	count, err = dataset.Count("password")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	_, err = dataset.Count("correct horse battery staple")
+	assert.ErrorIs(t, err, ErrRangeMissing)
+}
+
+func TestOpen_RequiresDirectory(t *testing.T) {
+	_, err := Open(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+
+	file := filepath.Join(t.TempDir(), "range.txt")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	_, err = Open(file)
+	assert.Error(t, err)
+}
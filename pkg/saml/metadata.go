@@ -0,0 +1,129 @@
+package saml
+
+import (
+	"crypto/x509"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// SAML 2.0 namespaces, bindings and name ID formats
+const (
+	MetadataNamespace  = "urn:oasis:names:tc:SAML:2.0:metadata"
+	AssertionNamespace = "urn:oasis:names:tc:SAML:2.0:assertion"
+	ProtocolNamespace  = "urn:oasis:names:tc:SAML:2.0:protocol"
+
+	HTTPRedirectBinding = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	HTTPPostBinding     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	EmailAddressNameIDFormat = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+)
+
+// IdentityProvider is what the service provider needs from an identity provider's metadata
+type IdentityProvider struct {
+	EntityID     string
+	SSOURL       string // HTTP-Redirect single sign-on endpoint
+	Certificates []*x509.Certificate
+}
+
+type entityDescriptor struct {
+	XMLName          xml.Name `xml:"EntityDescriptor"`
+	EntityID         string   `xml:"entityID,attr"`
+	IDPSSODescriptor *struct {
+		KeyDescriptors []struct {
+			Use          string   `xml:"use,attr"`
+			Certificates []string `xml:"KeyInfo>X509Data>X509Certificate"`
+		} `xml:"KeyDescriptor"`
+		SingleSignOnServices []struct {
+			Binding  string `xml:"Binding,attr"`
+			Location string `xml:"Location,attr"`
+		} `xml:"SingleSignOnService"`
+	} `xml:"IDPSSODescriptor"`
+}
+
+// ParseIdentityProviderMetadata reads an identity provider's EntityDescriptor.
+// It needs an HTTP-Redirect single sign-on endpoint and at least one signing
+// certificate.
+func ParseIdentityProviderMetadata(data []byte) (*IdentityProvider, error) {
+	if _, err := parseXML(data); err != nil {
+		return nil, fmt.Errorf("invalid metadata: %w", err)
+	}
+
+	var descriptor entityDescriptor
+	if err := xml.Unmarshal(data, &descriptor); err != nil {
+		return nil, fmt.Errorf("invalid metadata: %w", err)
+	}
+	if descriptor.EntityID == "" {
+		return nil, fmt.Errorf("metadata has no entityID")
+	}
+	if descriptor.IDPSSODescriptor == nil {
+		return nil, fmt.Errorf("metadata has no IDPSSODescriptor")
+	}
+
+	idp := &IdentityProvider{EntityID: descriptor.EntityID}
+	for _, service := range descriptor.IDPSSODescriptor.SingleSignOnServices {
+		if service.Binding == HTTPRedirectBinding {
+			idp.SSOURL = service.Location
+			break
+		}
+	}
+	if idp.SSOURL == "" {
+		return nil, fmt.Errorf("metadata has no HTTP-Redirect SingleSignOnService")
+	}
+
+	for _, key := range descriptor.IDPSSODescriptor.KeyDescriptors {
+		if key.Use != "" && key.Use != "signing" {
+			continue
+		}
+		for _, encoded := range key.Certificates {
+			der, err := decodeBase64(encoded)
+			if err != nil {
+				return nil, fmt.Errorf("invalid certificate: %w", err)
+			}
+			cert, err := x509.ParseCertificate(der)
+			if err != nil {
+				return nil, fmt.Errorf("invalid certificate: %w", err)
+			}
+			idp.Certificates = append(idp.Certificates, cert)
+		}
+	}
+	if len(idp.Certificates) == 0 {
+		return nil, fmt.Errorf("metadata has no signing certificate")
+	}
+
+	return idp, nil
+}
+
+type spEntityDescriptor struct {
+	XMLName         xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID        string   `xml:"entityID,attr"`
+	SPSSODescriptor struct {
+		AuthnRequestsSigned        bool   `xml:"AuthnRequestsSigned,attr"`
+		WantAssertionsSigned       bool   `xml:"WantAssertionsSigned,attr"`
+		ProtocolSupportEnumeration string `xml:"protocolSupportEnumeration,attr"`
+		NameIDFormat               string `xml:"NameIDFormat"`
+		AssertionConsumerService   struct {
+			Binding  string `xml:"Binding,attr"`
+			Location string `xml:"Location,attr"`
+			Index    int    `xml:"index,attr"`
+		} `xml:"AssertionConsumerService"`
+	} `xml:"SPSSODescriptor"`
+}
+
+// Metadata returns the service provider's EntityDescriptor for registering
+// with an identity provider
+func (sp *ServiceProvider) Metadata() ([]byte, error) {
+	var descriptor spEntityDescriptor
+	descriptor.EntityID = sp.EntityID
+	descriptor.SPSSODescriptor.WantAssertionsSigned = true
+	descriptor.SPSSODescriptor.ProtocolSupportEnumeration = ProtocolNamespace
+	descriptor.SPSSODescriptor.NameIDFormat = EmailAddressNameIDFormat
+	descriptor.SPSSODescriptor.AssertionConsumerService.Binding = HTTPPostBinding
+	descriptor.SPSSODescriptor.AssertionConsumerService.Location = sp.ACSURL
+
+	data, err := xml.MarshalIndent(descriptor, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return []byte(xml.Header + strings.TrimSpace(string(data)) + "\n"), nil
+}
@@ -0,0 +1,268 @@
+// Package saml implements the parts of a SAML 2.0 service provider needed
+// for SP-initiated web browser SSO: metadata, HTTP-Redirect authentication
+// requests and HTTP-POST responses with signed assertions.
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidResponse is returned for responses that fail validation
+	ErrInvalidResponse = errors.New("saml: invalid response")
+)
+
+// clockSkew is the allowance for clock differences with identity providers
+const clockSkew = 2 * time.Minute
+
+const successStatus = "urn:oasis:names:tc:SAML:2.0:status:Success"
+
+// ServiceProvider identifies this application to an identity provider
+type ServiceProvider struct {
+	EntityID string
+	ACSURL   string // Assertion consumer service, where responses are posted
+}
+
+// Assertion holds the verified subject and attributes of a response
+type Assertion struct {
+	NameID       string
+	NameIDFormat string
+	SessionIndex string
+	// Attributes are keyed by Name and, when different, by FriendlyName
+	Attributes map[string][]string
+}
+
+// Attribute returns the first value of an attribute, or an empty string
+func (a *Assertion) Attribute(name string) string {
+	if values := a.Attributes[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// NewRequestID returns a random ID for an authentication request
+func NewRequestID() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	// IDs are xsd:ID values, which can't start with a digit
+	return "_" + hex.EncodeToString(b), nil
+}
+
+// AuthnRequestURL returns the identity provider URL that starts a login,
+// using the HTTP-Redirect binding. The relay state comes back with the response.
+func (sp *ServiceProvider) AuthnRequestURL(idp *IdentityProvider, requestID, relayState string, now time.Time) (string, error) {
+	var request bytes.Buffer
+	request.WriteString(`<samlp:AuthnRequest xmlns:samlp="` + ProtocolNamespace + `" xmlns:saml="` + AssertionNamespace + `"`)
+	writeAttr(&request, "ID", requestID)
+	writeAttr(&request, "Version", "2.0")
+	writeAttr(&request, "IssueInstant", now.UTC().Format(time.RFC3339))
+	writeAttr(&request, "Destination", idp.SSOURL)
+	writeAttr(&request, "AssertionConsumerServiceURL", sp.ACSURL)
+	writeAttr(&request, "ProtocolBinding", HTTPPostBinding)
+	request.WriteString(`><saml:Issuer>`)
+	writeEscaped(&request, sp.EntityID, false)
+	request.WriteString(`</saml:Issuer><samlp:NameIDPolicy AllowCreate="true"/></samlp:AuthnRequest>`)
+
+	var deflated bytes.Buffer
+	writer, err := flate.NewWriter(&deflated, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := writer.Write(request.Bytes()); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	ssoURL, err := url.Parse(idp.SSOURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid single sign-on URL: %w", err)
+	}
+	query := ssoURL.Query()
+	query.Set("SAMLRequest", base64.StdEncoding.EncodeToString(deflated.Bytes()))
+	if relayState != "" {
+		query.Set("RelayState", relayState)
+	}
+	ssoURL.RawQuery = query.Encode()
+	return ssoURL.String(), nil
+}
+
+func writeAttr(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(" " + name + `="`)
+	writeEscaped(buf, value, true)
+	buf.WriteByte('"')
+}
+
+type assertionXML struct {
+	Issuer  string `xml:"Issuer"`
+	Subject struct {
+		NameID struct {
+			Format string `xml:"Format,attr"`
+			Value  string `xml:",chardata"`
+		} `xml:"NameID"`
+		SubjectConfirmations []struct {
+			Method string `xml:"Method,attr"`
+			Data   struct {
+				InResponseTo string    `xml:"InResponseTo,attr"`
+				Recipient    string    `xml:"Recipient,attr"`
+				NotOnOrAfter time.Time `xml:"NotOnOrAfter,attr"`
+			} `xml:"SubjectConfirmationData"`
+		} `xml:"SubjectConfirmation"`
+	} `xml:"Subject"`
+	Conditions *struct {
+		NotBefore    time.Time `xml:"NotBefore,attr"`
+		NotOnOrAfter time.Time `xml:"NotOnOrAfter,attr"`
+		Audiences    []string  `xml:"AudienceRestriction>Audience"`
+	} `xml:"Conditions"`
+	AuthnStatement struct {
+		SessionIndex string `xml:"SessionIndex,attr"`
+	} `xml:"AuthnStatement"`
+	Attributes []struct {
+		Name         string   `xml:"Name,attr"`
+		FriendlyName string   `xml:"FriendlyName,attr"`
+		Values       []string `xml:"AttributeValue"`
+	} `xml:"AttributeStatement>Attribute"`
+}
+
+// ParseResponse validates a base64 SAMLResponse posted to the assertion
+// consumer service and returns its assertion. The response must answer
+// requestID, come from idp, and carry exactly one assertion signed by one of
+// the identity provider's certificates, either directly or through a signed
+// response. Assertion fields are read only from the signed XML.
+func (sp *ServiceProvider) ParseResponse(encoded string, idp *IdentityProvider, requestID string, now time.Time) (*Assertion, error) {
+	data, err := decodeBase64(encoded)
+	if err != nil {
+		return nil, invalidResponse("invalid base64: %v", err)
+	}
+	response, err := parseXML(data)
+	if err != nil {
+		return nil, invalidResponse("invalid XML: %v", err)
+	}
+	if !response.is(ProtocolNamespace, "Response") {
+		return nil, invalidResponse("not a Response")
+	}
+
+	status := response.childElement(ProtocolNamespace, "Status")
+	var statusCode *element
+	if status != nil {
+		statusCode = status.childElement(ProtocolNamespace, "StatusCode")
+	}
+	if statusCode == nil || statusCode.attr("Value") != successStatus {
+		return nil, invalidResponse("unsuccessful status")
+	}
+	if len(response.childElements(AssertionNamespace, "EncryptedAssertion")) > 0 {
+		return nil, invalidResponse("encrypted assertions are not supported")
+	}
+	assertion := response.childElement(AssertionNamespace, "Assertion")
+	if assertion == nil {
+		return nil, invalidResponse("expected exactly one assertion")
+	}
+
+	switch {
+	case hasSignature(assertion):
+		if err := verifySignature(assertion, idp.Certificates); err != nil {
+			return nil, invalidResponse("assertion signature: %v", err)
+		}
+	case hasSignature(response):
+		if err := verifySignature(response, idp.Certificates); err != nil {
+			return nil, invalidResponse("response signature: %v", err)
+		}
+	default:
+		return nil, invalidResponse("neither the response nor the assertion is signed")
+	}
+
+	if destination := response.attr("Destination"); destination != "" && destination != sp.ACSURL {
+		return nil, invalidResponse("wrong destination %q", destination)
+	}
+	if inResponseTo := response.attr("InResponseTo"); inResponseTo != "" && inResponseTo != requestID {
+		return nil, invalidResponse("response is for another request")
+	}
+
+	// Unmarshal the canonical form of the verified assertion so nothing
+	// outside the signature can be read by mistake. The signature element is
+	// nil when it was the response that was signed.
+	var parsed assertionXML
+	signature := assertion.childElement(dsigNamespace, "Signature")
+	if err := xml.Unmarshal(canonicalize(assertion, signature, nil), &parsed); err != nil {
+		return nil, invalidResponse("invalid assertion: %v", err)
+	}
+
+	if parsed.Issuer != idp.EntityID {
+		return nil, invalidResponse("wrong issuer %q", parsed.Issuer)
+	}
+	if err := sp.checkConditions(&parsed, requestID, now); err != nil {
+		return nil, err
+	}
+
+	result := &Assertion{
+		NameID:       strings.TrimSpace(parsed.Subject.NameID.Value),
+		NameIDFormat: parsed.Subject.NameID.Format,
+		SessionIndex: parsed.AuthnStatement.SessionIndex,
+		Attributes:   map[string][]string{},
+	}
+	if result.NameID == "" {
+		return nil, invalidResponse("assertion has no NameID")
+	}
+	for _, attr := range parsed.Attributes {
+		values := make([]string, 0, len(attr.Values))
+		for _, value := range attr.Values {
+			values = append(values, strings.TrimSpace(value))
+		}
+		result.Attributes[attr.Name] = append(result.Attributes[attr.Name], values...)
+		if attr.FriendlyName != "" && attr.FriendlyName != attr.Name {
+			result.Attributes[attr.FriendlyName] = append(result.Attributes[attr.FriendlyName], values...)
+		}
+	}
+	return result, nil
+}
+
+// checkConditions enforces the assertion's audience and validity window and
+// requires a bearer confirmation for this request and endpoint
+func (sp *ServiceProvider) checkConditions(parsed *assertionXML, requestID string, now time.Time) error {
+	if parsed.Conditions == nil {
+		return invalidResponse("assertion has no conditions")
+	}
+	if !parsed.Conditions.NotBefore.IsZero() && now.Add(clockSkew).Before(parsed.Conditions.NotBefore) {
+		return invalidResponse("assertion not yet valid")
+	}
+	if !parsed.Conditions.NotOnOrAfter.IsZero() && !now.Add(-clockSkew).Before(parsed.Conditions.NotOnOrAfter) {
+		return invalidResponse("assertion expired")
+	}
+	audienceOK := false
+	for _, audience := range parsed.Conditions.Audiences {
+		if strings.TrimSpace(audience) == sp.EntityID {
+			audienceOK = true
+		}
+	}
+	if !audienceOK {
+		return invalidResponse("assertion is not for this service provider")
+	}
+
+	for _, confirmation := range parsed.Subject.SubjectConfirmations {
+		data := confirmation.Data
+		if confirmation.Method == "urn:oasis:names:tc:SAML:2.0:cm:bearer" &&
+			data.InResponseTo == requestID &&
+			data.Recipient == sp.ACSURL &&
+			now.Add(-clockSkew).Before(data.NotOnOrAfter) {
+			return nil
+		}
+	}
+	return invalidResponse("no valid bearer subject confirmation")
+}
+
+func invalidResponse(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidResponse, fmt.Sprintf(format, args...))
+}
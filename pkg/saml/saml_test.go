@@ -0,0 +1,144 @@
+package saml_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"ethos/pkg/saml"
+	"ethos/pkg/saml/samltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSP = &saml.ServiceProvider{
+	EntityID: "https://api.example.com/saml/org-1/metadata",
+	ACSURL:   "https://api.example.com/saml/org-1/acs",
+}
+
+// login returns a response to a fresh authentication request and its request ID
+func login(t *testing.T, idp *samltest.IdentityProvider, metadata *saml.IdentityProvider) (string, string) {
+	t.Helper()
+	requestID, err := saml.NewRequestID()
+	require.NoError(t, err)
+	authURL, err := testSP.AuthnRequestURL(metadata, requestID, "relay-1", time.Now())
+	require.NoError(t, err)
+
+	response, relayState, err := idp.Respond(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, "relay-1", relayState)
+	return response, requestID
+}
+
+func newIdentityProvider(t *testing.T) (*samltest.IdentityProvider, *saml.IdentityProvider) {
+	t.Helper()
+	idp := samltest.NewIdentityProvider("https://idp.example.com")
+	metadata, err := saml.ParseIdentityProviderMetadata(idp.Metadata())
+	require.NoError(t, err)
+	idp.SetIdentity(samltest.Identity{
+		NameID: "jane@example.com",
+		Attributes: map[string][]string{
+			"firstName": {"Jane"},
+			"lastName":  {"O'Brien & <Sons>"},
+			"groups":    {"staff", "admins"},
+		},
+	})
+	return idp, metadata
+}
+
+func TestParseResponse_SignedAssertion(t *testing.T) {
+	idp, metadata := newIdentityProvider(t)
+	assert.Equal(t, "https://idp.example.com", metadata.EntityID)
+	assert.Equal(t, "https://idp.example.com/sso", metadata.SSOURL)
+
+	response, requestID := login(t, idp, metadata)
+	assertion, err := testSP.ParseResponse(response, metadata, requestID, time.Now())
+	require.NoError(t, err)
+
+	assert.Equal(t, "jane@example.com", assertion.NameID)
+	assert.Equal(t, saml.EmailAddressNameIDFormat, assertion.NameIDFormat)
+	assert.Equal(t, "Jane", assertion.Attribute("firstName"))
+	assert.Equal(t, "O'Brien & <Sons>", assertion.Attribute("lastName"))
+	assert.Equal(t, []string{"staff", "admins"}, assertion.Attributes["groups"])
+}
+
+func TestParseResponse_RejectsTamperedAssertion(t *testing.T) {
+	idp, metadata := newIdentityProvider(t)
+	response, requestID := login(t, idp, metadata)
+
+	decoded, err := base64.StdEncoding.DecodeString(response)
+	require.NoError(t, err)
+
+	tampered := strings.Replace(string(decoded), "jane@example.com", "admin@example.com", 1)
+	_, err = testSP.ParseResponse(base64.StdEncoding.EncodeToString([]byte(tampered)), metadata, requestID, time.Now())
+	assert.ErrorIs(t, err, saml.ErrInvalidResponse)
+
+	// A second, unsigned assertion next to the signed one is not accepted either
+	start := strings.Index(string(decoded), "<saml:Assertion")
+	end := strings.LastIndex(string(decoded), "</samlp:Response>")
+	wrapped := string(decoded[:end]) + strings.Replace(string(decoded[start:end]), "jane@example.com", "admin@example.com", 1) + "</samlp:Response>"
+	_, err = testSP.ParseResponse(base64.StdEncoding.EncodeToString([]byte(wrapped)), metadata, requestID, time.Now())
+	assert.ErrorIs(t, err, saml.ErrInvalidResponse)
+}
+
+func TestParseResponse_Rejections(t *testing.T) {
+	idp, metadata := newIdentityProvider(t)
+	other, otherMetadata := newIdentityProvider(t)
+	otherMetadata.EntityID = metadata.EntityID
+
+	tests := []struct {
+		name  string
+		setup func() (response, requestID string, trusted *saml.IdentityProvider, now time.Time)
+	}{
+		{
+			name: "signed by another provider's key",
+			setup: func() (string, string, *saml.IdentityProvider, time.Time) {
+				response, requestID := login(t, other, metadata)
+				return response, requestID, metadata, time.Now()
+			},
+		},
+		{
+			name: "answers a different request",
+			setup: func() (string, string, *saml.IdentityProvider, time.Time) {
+				response, _ := login(t, idp, metadata)
+				return response, "_other-request", metadata, time.Now()
+			},
+		},
+		{
+			name: "expired",
+			setup: func() (string, string, *saml.IdentityProvider, time.Time) {
+				response, requestID := login(t, idp, metadata)
+				return response, requestID, metadata, time.Now().Add(10 * time.Minute)
+			},
+		},
+		{
+			name: "wrong audience",
+			setup: func() (string, string, *saml.IdentityProvider, time.Time) {
+				idp.Audience = "https://elsewhere.example.com"
+				defer func() { idp.Audience = "" }()
+				response, requestID := login(t, idp, metadata)
+				return response, requestID, metadata, time.Now()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, requestID, trusted, now := tt.setup()
+			_, err := testSP.ParseResponse(response, trusted, requestID, now)
+			assert.ErrorIs(t, err, saml.ErrInvalidResponse)
+		})
+	}
+}
+
+func TestServiceProviderMetadata(t *testing.T) {
+	data, err := testSP.Metadata()
+	require.NoError(t, err)
+
+	metadata := string(data)
+	assert.Contains(t, metadata, `entityID="https://api.example.com/saml/org-1/metadata"`)
+	assert.Contains(t, metadata, `Location="https://api.example.com/saml/org-1/acs"`)
+	assert.Contains(t, metadata, `WantAssertionsSigned="true"`)
+}
@@ -0,0 +1,237 @@
+// Package samltest provides a stub SAML identity provider for tests. It
+// answers HTTP-Redirect authentication requests with HTTP-POST responses
+// whose assertions are signed with a generated key. Assertions are written
+// directly in canonical form, so they also check the service provider's
+// canonicalization against an independent serialization.
+package samltest
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"ethos/pkg/saml"
+)
+
+const dsigNamespace = "http://www.w3.org/2000/09/xmldsig#"
+
+// Identity is the user the stub provider asserts on the next response
+type Identity struct {
+	NameID     string
+	Attributes map[string][]string
+}
+
+// IdentityProvider is a stub SAML identity provider
+type IdentityProvider struct {
+	EntityID string
+	SSOURL   string
+	// Audience overrides the assertion audience when set
+	Audience string
+
+	key      *rsa.PrivateKey
+	cert     []byte
+	mu       sync.Mutex
+	identity Identity
+}
+
+// NewIdentityProvider creates a stub provider with a fresh signing key
+func NewIdentityProvider(entityID string) *IdentityProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: entityID},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+
+	return &IdentityProvider{
+		EntityID: entityID,
+		SSOURL:   strings.TrimSuffix(entityID, "/") + "/sso",
+		key:      key,
+		cert:     cert,
+	}
+}
+
+// SetIdentity sets the user asserted by subsequent responses
+func (p *IdentityProvider) SetIdentity(identity Identity) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.identity = identity
+}
+
+// Metadata returns the provider's EntityDescriptor
+func (p *IdentityProvider) Metadata() []byte {
+	return []byte(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<md:EntityDescriptor xmlns:md="%s" entityID="%s">
+  <md:IDPSSODescriptor protocolSupportEnumeration="%s">
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo xmlns:ds="%s">
+        <ds:X509Data>
+          <ds:X509Certificate>%s</ds:X509Certificate>
+        </ds:X509Data>
+      </ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleSignOnService Binding="%s" Location="%s"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>
+`, saml.MetadataNamespace, escapeAttr(p.EntityID), saml.ProtocolNamespace, dsigNamespace,
+		base64.StdEncoding.EncodeToString(p.cert), saml.HTTPRedirectBinding, escapeAttr(p.SSOURL)))
+}
+
+type authnRequest struct {
+	ID     string `xml:"ID,attr"`
+	ACSURL string `xml:"AssertionConsumerServiceURL,attr"`
+	Issuer string `xml:"Issuer"`
+}
+
+// Respond signs in the current identity for an authentication request URL
+// and returns the base64 SAMLResponse and RelayState the browser would post
+// to the service provider
+func (p *IdentityProvider) Respond(authnRequestURL string) (samlResponse, relayState string, err error) {
+	parsed, err := url.Parse(authnRequestURL)
+	if err != nil {
+		return "", "", err
+	}
+	deflated, err := base64.StdEncoding.DecodeString(parsed.Query().Get("SAMLRequest"))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid SAMLRequest: %w", err)
+	}
+	inflated, err := io.ReadAll(flate.NewReader(bytes.NewReader(deflated)))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid SAMLRequest: %w", err)
+	}
+	var request authnRequest
+	if err := xml.Unmarshal(inflated, &request); err != nil {
+		return "", "", fmt.Errorf("invalid SAMLRequest: %w", err)
+	}
+
+	p.mu.Lock()
+	identity := p.identity
+	p.mu.Unlock()
+
+	audience := request.Issuer
+	if p.Audience != "" {
+		audience = p.Audience
+	}
+
+	assertion, err := p.signedAssertion(identity, request, audience, time.Now().UTC())
+	if err != nil {
+		return "", "", err
+	}
+
+	response := fmt.Sprintf(`<samlp:Response xmlns:samlp="%s" Destination="%s" ID="%s" InResponseTo="%s" IssueInstant="%s" Version="2.0">`+
+		`<saml:Issuer xmlns:saml="%s">%s</saml:Issuer>`+
+		`<samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>%s</samlp:Response>`,
+		saml.ProtocolNamespace, escapeAttr(request.ACSURL), newID(), escapeAttr(request.ID), time.Now().UTC().Format(time.RFC3339),
+		saml.AssertionNamespace, escapeText(p.EntityID), assertion)
+
+	return base64.StdEncoding.EncodeToString([]byte(response)), parsed.Query().Get("RelayState"), nil
+}
+
+// signedAssertion builds an assertion in exclusive canonical form and
+// inserts an enveloped signature after its Issuer
+func (p *IdentityProvider) signedAssertion(identity Identity, request authnRequest, audience string, now time.Time) (string, error) {
+	id := newID()
+	issueInstant := now.Format(time.RFC3339)
+	notOnOrAfter := now.Add(5 * time.Minute).Format(time.RFC3339)
+
+	var attributes strings.Builder
+	names := make([]string, 0, len(identity.Attributes))
+	for name := range identity.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		attributes.WriteString(`<saml:Attribute Name="` + escapeAttr(name) + `">`)
+		for _, value := range identity.Attributes[name] {
+			attributes.WriteString(`<saml:AttributeValue>` + escapeText(value) + `</saml:AttributeValue>`)
+		}
+		attributes.WriteString(`</saml:Attribute>`)
+	}
+
+	head := `<saml:Assertion xmlns:saml="` + saml.AssertionNamespace + `" ID="` + id + `" IssueInstant="` + issueInstant + `" Version="2.0">` +
+		`<saml:Issuer>` + escapeText(p.EntityID) + `</saml:Issuer>`
+	body := `<saml:Subject>` +
+		`<saml:NameID Format="` + saml.EmailAddressNameIDFormat + `">` + escapeText(identity.NameID) + `</saml:NameID>` +
+		`<saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">` +
+		`<saml:SubjectConfirmationData InResponseTo="` + escapeAttr(request.ID) + `" NotOnOrAfter="` + notOnOrAfter + `" Recipient="` + escapeAttr(request.ACSURL) + `"></saml:SubjectConfirmationData>` +
+		`</saml:SubjectConfirmation></saml:Subject>` +
+		`<saml:Conditions NotBefore="` + issueInstant + `" NotOnOrAfter="` + notOnOrAfter + `">` +
+		`<saml:AudienceRestriction><saml:Audience>` + escapeText(audience) + `</saml:Audience></saml:AudienceRestriction></saml:Conditions>` +
+		`<saml:AuthnStatement AuthnInstant="` + issueInstant + `" SessionIndex="` + id + `">` +
+		`<saml:AuthnContext><saml:AuthnContextClassRef>urn:oasis:names:tc:SAML:2.0:ac:classes:PasswordProtectedTransport</saml:AuthnContextClassRef></saml:AuthnContext>` +
+		`</saml:AuthnStatement>` +
+		`<saml:AttributeStatement>` + attributes.String() + `</saml:AttributeStatement>` +
+		`</saml:Assertion>`
+
+	digest := sha256.Sum256([]byte(head + body))
+	signedInfo := `<ds:SignedInfo>` +
+		`<ds:CanonicalizationMethod Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"></ds:CanonicalizationMethod>` +
+		`<ds:SignatureMethod Algorithm="http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"></ds:SignatureMethod>` +
+		`<ds:Reference URI="#` + id + `"><ds:Transforms>` +
+		`<ds:Transform Algorithm="http://www.w3.org/2000/09/xmldsig#enveloped-signature"></ds:Transform>` +
+		`<ds:Transform Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"></ds:Transform>` +
+		`</ds:Transforms>` +
+		`<ds:DigestMethod Algorithm="http://www.w3.org/2001/04/xmlenc#sha256"></ds:DigestMethod>` +
+		`<ds:DigestValue>` + base64.StdEncoding.EncodeToString(digest[:]) + `</ds:DigestValue>` +
+		`</ds:Reference></ds:SignedInfo>`
+
+	// Canonicalized on its own, SignedInfo declares the ds namespace it inherits
+	canonicalSignedInfo := strings.Replace(signedInfo, `<ds:SignedInfo>`, `<ds:SignedInfo xmlns:ds="`+dsigNamespace+`">`, 1)
+	hashed := sha256.Sum256([]byte(canonicalSignedInfo))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", err
+	}
+
+	return head +
+		`<ds:Signature xmlns:ds="` + dsigNamespace + `">` + signedInfo +
+		`<ds:SignatureValue>` + base64.StdEncoding.EncodeToString(signature) + `</ds:SignatureValue>` +
+		`<ds:KeyInfo><ds:X509Data><ds:X509Certificate>` + base64.StdEncoding.EncodeToString(p.cert) + `</ds:X509Certificate></ds:X509Data></ds:KeyInfo>` +
+		`</ds:Signature>` + body, nil
+}
+
+func newID() string {
+	id, err := saml.NewRequestID()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+)
+
+// escapeText and escapeAttr escape as canonical XML does
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func escapeAttr(s string) string {
+	return attrEscaper.Replace(s)
+}
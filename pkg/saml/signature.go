@@ -0,0 +1,163 @@
+package saml
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+// XML Signature identifiers accepted in assertions. SHA-1 is not accepted.
+const (
+	dsigNamespace    = "http://www.w3.org/2000/09/xmldsig#"
+	excC14NAlgorithm = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	envelopedSigAlgo = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+	rsaSHA256Algo    = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	rsaSHA512Algo    = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha512"
+	sha256DigestAlgo = "http://www.w3.org/2001/04/xmlenc#sha256"
+	sha512DigestAlgo = "http://www.w3.org/2001/04/xmlenc#sha512"
+)
+
+// hasSignature reports whether the element carries an enveloped signature
+func hasSignature(e *element) bool {
+	return len(e.childElements(dsigNamespace, "Signature")) > 0
+}
+
+// verifySignature checks the element's enveloped XML signature against the
+// trusted certificates. Only a single reference to the element itself is
+// accepted, so a valid signature can't vouch for some other part of the
+// document. KeyInfo in the signature is ignored.
+func verifySignature(e *element, certs []*x509.Certificate) error {
+	signature := e.childElement(dsigNamespace, "Signature")
+	if signature == nil {
+		return fmt.Errorf("expected exactly one signature")
+	}
+	signedInfo := signature.childElement(dsigNamespace, "SignedInfo")
+	if signedInfo == nil {
+		return fmt.Errorf("signature has no SignedInfo")
+	}
+
+	c14nMethod := signedInfo.childElement(dsigNamespace, "CanonicalizationMethod")
+	if c14nMethod == nil || c14nMethod.attr("Algorithm") != excC14NAlgorithm {
+		return fmt.Errorf("unsupported canonicalization method")
+	}
+
+	signatureMethod := signedInfo.childElement(dsigNamespace, "SignatureMethod")
+	if signatureMethod == nil {
+		return fmt.Errorf("signature has no SignatureMethod")
+	}
+	var signatureHash crypto.Hash
+	switch signatureMethod.attr("Algorithm") {
+	case rsaSHA256Algo:
+		signatureHash = crypto.SHA256
+	case rsaSHA512Algo:
+		signatureHash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported signature method %q", signatureMethod.attr("Algorithm"))
+	}
+
+	reference := signedInfo.childElement(dsigNamespace, "Reference")
+	if reference == nil {
+		return fmt.Errorf("expected exactly one reference")
+	}
+	id := e.attr("ID")
+	if id == "" || reference.attr("URI") != "#"+id {
+		return fmt.Errorf("signature does not reference the signed element")
+	}
+
+	var inclusivePrefixes []string
+	sawExcC14N := false
+	if transforms := reference.childElement(dsigNamespace, "Transforms"); transforms != nil {
+		for _, transform := range transforms.childElements(dsigNamespace, "Transform") {
+			switch transform.attr("Algorithm") {
+			case envelopedSigAlgo:
+			case excC14NAlgorithm:
+				sawExcC14N = true
+				inclusivePrefixes = inclusiveNamespaces(transform)
+			default:
+				return fmt.Errorf("unsupported transform %q", transform.attr("Algorithm"))
+			}
+		}
+	}
+	if !sawExcC14N {
+		return fmt.Errorf("reference must use exclusive canonicalization")
+	}
+
+	digestMethod := reference.childElement(dsigNamespace, "DigestMethod")
+	if digestMethod == nil {
+		return fmt.Errorf("reference has no DigestMethod")
+	}
+	var digest hash.Hash
+	switch digestMethod.attr("Algorithm") {
+	case sha256DigestAlgo:
+		digest = sha256.New()
+	case sha512DigestAlgo:
+		digest = sha512.New()
+	default:
+		return fmt.Errorf("unsupported digest method %q", digestMethod.attr("Algorithm"))
+	}
+
+	digestValue := reference.childElement(dsigNamespace, "DigestValue")
+	if digestValue == nil {
+		return fmt.Errorf("reference has no DigestValue")
+	}
+	expectedDigest, err := decodeBase64(digestValue.text())
+	if err != nil {
+		return fmt.Errorf("invalid digest value: %w", err)
+	}
+	digest.Write(canonicalize(e, signature, inclusivePrefixes))
+	if subtle.ConstantTimeCompare(digest.Sum(nil), expectedDigest) != 1 {
+		return fmt.Errorf("digest mismatch")
+	}
+
+	signatureValue := signature.childElement(dsigNamespace, "SignatureValue")
+	if signatureValue == nil {
+		return fmt.Errorf("signature has no SignatureValue")
+	}
+	sig, err := decodeBase64(signatureValue.text())
+	if err != nil {
+		return fmt.Errorf("invalid signature value: %w", err)
+	}
+
+	signedInfoHash := signatureHash.New()
+	signedInfoHash.Write(canonicalize(signedInfo, nil, inclusiveNamespaces(c14nMethod)))
+	hashed := signedInfoHash.Sum(nil)
+	for _, cert := range certs {
+		key, ok := cert.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			continue
+		}
+		if rsa.VerifyPKCS1v15(key, signatureHash, hashed, sig) == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("signature not made by a trusted certificate")
+}
+
+// inclusiveNamespaces returns the PrefixList of a canonicalization method or transform
+func inclusiveNamespaces(method *element) []string {
+	for _, child := range method.children {
+		if el, ok := child.(*element); ok && el.is(excC14NAlgorithm, "InclusiveNamespaces") {
+			return strings.Fields(el.attr("PrefixList"))
+		}
+	}
+	return nil
+}
+
+// decodeBase64 decodes base64 that may be wrapped across lines
+func decodeBase64(s string) ([]byte, error) {
+	var b bytes.Buffer
+	for _, r := range s {
+		if r != ' ' && r != '\t' && r != '\n' && r != '\r' {
+			b.WriteRune(r)
+		}
+	}
+	return base64.StdEncoding.DecodeString(b.String())
+}
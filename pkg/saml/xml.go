@@ -0,0 +1,295 @@
+package saml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// xmlNamespace is bound to the xml prefix in every document
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// element is a parsed XML element that keeps the raw prefixes and namespace
+// declarations, which encoding/xml's Unmarshal discards but signature
+// verification needs for canonicalization
+type element struct {
+	parent   *element
+	prefix   string
+	local    string
+	nsDecls  map[string]string // Prefix ("" for the default namespace) to URI, as declared here
+	attrs    []xml.Attr        // Attribute Name.Space holds the raw prefix
+	children []interface{}     // *element or string character data
+}
+
+// parseXML parses a document into an element tree. Comments and the XML
+// declaration are dropped; DTDs and processing instructions are rejected.
+func parseXML(data []byte) (*element, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = true
+
+	var root, current *element
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if current == nil && root != nil {
+				return nil, fmt.Errorf("multiple root elements")
+			}
+			el := &element{parent: current, prefix: t.Name.Space, local: t.Name.Local, nsDecls: map[string]string{}}
+			for _, attr := range t.Attr {
+				switch {
+				case attr.Name.Space == "xmlns":
+					el.nsDecls[attr.Name.Local] = attr.Value
+				case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+					el.nsDecls[""] = attr.Value
+				default:
+					el.attrs = append(el.attrs, attr)
+				}
+			}
+			if _, ok := el.namespace(el.prefix); !ok && el.prefix != "" {
+				return nil, fmt.Errorf("undeclared namespace prefix %q", el.prefix)
+			}
+			for _, attr := range el.attrs {
+				if _, ok := el.namespace(attr.Name.Space); !ok && attr.Name.Space != "" {
+					return nil, fmt.Errorf("undeclared namespace prefix %q", attr.Name.Space)
+				}
+			}
+			if current == nil {
+				root = el
+			} else {
+				current.children = append(current.children, el)
+			}
+			current = el
+		case xml.EndElement:
+			if current == nil || t.Name.Space != current.prefix || t.Name.Local != current.local {
+				return nil, fmt.Errorf("unexpected end element %s", t.Name.Local)
+			}
+			current = current.parent
+		case xml.CharData:
+			if current != nil {
+				current.children = append(current.children, string(t))
+			} else if len(bytes.TrimSpace(t)) > 0 {
+				return nil, fmt.Errorf("character data outside the root element")
+			}
+		case xml.ProcInst:
+			if t.Target != "xml" || root != nil {
+				return nil, fmt.Errorf("processing instructions are not supported")
+			}
+		case xml.Directive:
+			return nil, fmt.Errorf("document type declarations are not supported")
+		}
+	}
+
+	if root == nil || current != nil {
+		return nil, fmt.Errorf("incomplete document")
+	}
+	return root, nil
+}
+
+// namespace returns the in-scope URI for a prefix
+func (e *element) namespace(prefix string) (string, bool) {
+	if prefix == "xml" {
+		return xmlNamespace, true
+	}
+	for el := e; el != nil; el = el.parent {
+		if uri, ok := el.nsDecls[prefix]; ok {
+			return uri, true
+		}
+	}
+	return "", false
+}
+
+// is reports whether the element has the given namespace and local name
+func (e *element) is(space, local string) bool {
+	uri, _ := e.namespace(e.prefix)
+	return e.local == local && uri == space
+}
+
+// attr returns the value of an unqualified attribute
+func (e *element) attr(local string) string {
+	for _, attr := range e.attrs {
+		if attr.Name.Space == "" && attr.Name.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// childElements returns the child elements with the given namespace and local name
+func (e *element) childElements(space, local string) []*element {
+	var found []*element
+	for _, child := range e.children {
+		if el, ok := child.(*element); ok && el.is(space, local) {
+			found = append(found, el)
+		}
+	}
+	return found
+}
+
+// childElement returns the only child element with the given name, or nil
+// if there is not exactly one
+func (e *element) childElement(space, local string) *element {
+	found := e.childElements(space, local)
+	if len(found) != 1 {
+		return nil
+	}
+	return found[0]
+}
+
+// text returns the element's concatenated character data
+func (e *element) text() string {
+	var b strings.Builder
+	for _, child := range e.children {
+		if s, ok := child.(string); ok {
+			b.WriteString(s)
+		}
+	}
+	return b.String()
+}
+
+// canonicalize serializes the element with Exclusive XML Canonicalization
+// (without comments). The excluded element, if any, is left out, which
+// implements the enveloped-signature transform. inclusivePrefixes are the
+// InclusiveNamespaces PrefixList, with "#default" for the default namespace.
+func canonicalize(e *element, excluded *element, inclusivePrefixes []string) []byte {
+	inclusive := map[string]bool{}
+	for _, prefix := range inclusivePrefixes {
+		if prefix == "#default" {
+			prefix = ""
+		}
+		inclusive[prefix] = true
+	}
+
+	var buf bytes.Buffer
+	writeCanonical(&buf, e, excluded, inclusive, map[string]string{})
+	return buf.Bytes()
+}
+
+func writeCanonical(buf *bytes.Buffer, e *element, excluded *element, inclusive map[string]bool, rendered map[string]string) {
+	// Namespaces visibly utilized by the element and its attributes, plus
+	// any in scope that the prefix list includes
+	utilized := map[string]bool{e.prefix: true}
+	for _, attr := range e.attrs {
+		if attr.Name.Space != "" && attr.Name.Space != "xml" {
+			utilized[attr.Name.Space] = true
+		}
+	}
+	for prefix := range inclusive {
+		if _, ok := e.namespace(prefix); ok {
+			utilized[prefix] = true
+		}
+	}
+
+	var prefixes []string
+	childRendered := rendered
+	for prefix := range utilized {
+		uri, _ := e.namespace(prefix)
+		current, ok := rendered[prefix]
+		if (ok && current == uri) || (!ok && prefix == "" && uri == "") {
+			continue
+		}
+		if len(prefixes) == 0 {
+			childRendered = make(map[string]string, len(rendered)+len(utilized))
+			for k, v := range rendered {
+				childRendered[k] = v
+			}
+		}
+		childRendered[prefix] = uri
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	attrs := make([]xml.Attr, len(e.attrs))
+	copy(attrs, e.attrs)
+	attrURI := func(attr xml.Attr) string {
+		if attr.Name.Space == "" {
+			return ""
+		}
+		uri, _ := e.namespace(attr.Name.Space)
+		return uri
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		ui, uj := attrURI(attrs[i]), attrURI(attrs[j])
+		if ui != uj {
+			return ui < uj
+		}
+		return attrs[i].Name.Local < attrs[j].Name.Local
+	})
+
+	buf.WriteByte('<')
+	writeQName(buf, e.prefix, e.local)
+	for _, prefix := range prefixes {
+		if prefix == "" {
+			buf.WriteString(` xmlns="`)
+		} else {
+			buf.WriteString(` xmlns:` + prefix + `="`)
+		}
+		writeEscaped(buf, childRendered[prefix], true)
+		buf.WriteByte('"')
+	}
+	for _, attr := range attrs {
+		buf.WriteByte(' ')
+		writeQName(buf, attr.Name.Space, attr.Name.Local)
+		buf.WriteString(`="`)
+		writeEscaped(buf, attr.Value, true)
+		buf.WriteByte('"')
+	}
+	buf.WriteByte('>')
+
+	for _, child := range e.children {
+		switch c := child.(type) {
+		case *element:
+			if c != excluded {
+				writeCanonical(buf, c, excluded, inclusive, childRendered)
+			}
+		case string:
+			writeEscaped(buf, c, false)
+		}
+	}
+
+	buf.WriteString("</")
+	writeQName(buf, e.prefix, e.local)
+	buf.WriteByte('>')
+}
+
+func writeQName(buf *bytes.Buffer, prefix, local string) {
+	if prefix != "" {
+		buf.WriteString(prefix)
+		buf.WriteByte(':')
+	}
+	buf.WriteString(local)
+}
+
+// writeEscaped escapes text or attribute values as canonical XML requires
+func writeEscaped(buf *bytes.Buffer, s string, attribute bool) {
+	for _, r := range s {
+		switch {
+		case r == '&':
+			buf.WriteString("&amp;")
+		case r == '<':
+			buf.WriteString("&lt;")
+		case r == '>' && !attribute:
+			buf.WriteString("&gt;")
+		case r == '"' && attribute:
+			buf.WriteString("&quot;")
+		case r == '\t' && attribute:
+			buf.WriteString("&#x9;")
+		case r == '\n' && attribute:
+			buf.WriteString("&#xA;")
+		case r == '\r':
+			buf.WriteString("&#xD;")
+		default:
+			buf.WriteRune(r)
+		}
+	}
+}
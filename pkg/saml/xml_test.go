@@ -0,0 +1,62 @@
+package saml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Expected outputs are from xmllint --exc-c14n
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "namespaces, attribute order and escaping",
+			input: `<?xml version="1.0" encoding="UTF-8"?>
+<!-- comment -->
+<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns="urn:default" Version="2.0" ID="_r1" >
+  <saml:Issuer>https://idp.example.com/</saml:Issuer>
+  <saml:Assertion Version="2.0" IssueInstant="2024-01-01T00:00:00Z" ID="_a1">
+    <saml:Subject><saml:NameID Format="x" SPNameQualifier="y">jane&amp;co@example.com</saml:NameID></saml:Subject>
+    <Plain b="2" a='1 &quot;q&quot; &lt;' xml:lang="en"/>
+    <saml:AttributeStatement><saml:Attribute Name="role"><saml:AttributeValue xsi:type="xs:string">a &gt; b &#13; c</saml:AttributeValue></saml:Attribute></saml:AttributeStatement>
+    <inner xmlns="">no default</inner>
+  </saml:Assertion>
+</samlp:Response>`,
+			expected: `<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" ID="_r1" Version="2.0">
+  <saml:Issuer xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion">https://idp.example.com/</saml:Issuer>
+  <saml:Assertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_a1" IssueInstant="2024-01-01T00:00:00Z" Version="2.0">
+    <saml:Subject><saml:NameID Format="x" SPNameQualifier="y">jane&amp;co@example.com</saml:NameID></saml:Subject>
+    <Plain xmlns="urn:default" a="1 &quot;q&quot; &lt;" b="2" xml:lang="en"></Plain>
+    <saml:AttributeStatement><saml:Attribute Name="role"><saml:AttributeValue xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="xs:string">a &gt; b &#xD; c</saml:AttributeValue></saml:Attribute></saml:AttributeStatement>
+    <inner>no default</inner>
+  </saml:Assertion>
+</samlp:Response>`,
+		},
+		{
+			name:     "qualified attributes and redeclared prefixes",
+			input:    `<a:root xmlns:a="urn:a" xmlns:b="urn:b" xmlns:c="urn:c"><a:x b:attr="1" c:z="2" plain="3"><b:y xmlns:b="urn:b"><a:z xmlns:a="urn:a2"/></b:y></a:x></a:root>`,
+			expected: `<a:root xmlns:a="urn:a"><a:x xmlns:b="urn:b" xmlns:c="urn:c" plain="3" b:attr="1" c:z="2"><b:y><a:z xmlns:a="urn:a2"></a:z></b:y></a:x></a:root>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := parseXML([]byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(canonicalize(root, nil, nil)))
+		})
+	}
+}
+
+func TestParseXML_RejectsDocumentTypes(t *testing.T) {
+	_, err := parseXML([]byte(`<!DOCTYPE r [<!ENTITY x "y">]><r>&x;</r>`))
+	assert.Error(t, err)
+
+	_, err = parseXML([]byte(`<p:r>undeclared</p:r>`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// Box encrypts small secrets (such as TOTP seeds) for storage at rest using AES-256-GCM
+type Box struct {
+	aead cipher.AEAD
+}
+
+// New creates a Box from a passphrase. The passphrase is stretched to a
+// 256-bit key with SHA-256, so it must come from configuration rather than
+// user input.
+func New(passphrase string) (*Box, error) {
+	if passphrase == "" {
+		return nil, errors.New("secretbox: passphrase must not be empty")
+	}
+
+	key := sha256.Sum256([]byte(passphrase))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("secretbox: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("secretbox: %w", err)
+	}
+
+	return &Box{aead: aead}, nil
+}
+
+// Seal encrypts plaintext and returns a base64-encoded nonce||ciphertext
+func (b *Box) Seal(plaintext string) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("secretbox: failed to generate nonce: %w", err)
+	}
+
+	sealed := b.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a value produced by Seal
+func (b *Box) Open(ciphertext string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("secretbox: invalid encoding: %w", err)
+	}
+
+	nonceSize := b.aead.NonceSize()
+	if len(raw) < nonceSize {
+		return "", errors.New("secretbox: ciphertext too short")
+	}
+
+	plaintext, err := b.aead.Open(nil, raw[:nonceSize], raw[nonceSize:], nil)
+	if err != nil {
+		return "", fmt.Errorf("secretbox: failed to decrypt: %w", err)
+	}
+
+	return string(plaintext), nil
+}
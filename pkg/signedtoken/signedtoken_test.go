@@ -0,0 +1,70 @@
+package signedtoken
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	signer, err := New("secret")
+	require.NoError(t, err)
+
+	now := time.Now()
+	token, err := signer.Sign(Claims{Purpose: "verify", Subject: "user-1", Email: "jane@example.com", ExpiresAt: now.Add(time.Hour).Unix()})
+	require.NoError(t, err)
+
+	claims, err := signer.Verify(token, "verify", now)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+	assert.Equal(t, "jane@example.com", claims.Email)
+}
+
+func TestVerify_Rejects(t *testing.T) {
+	signer, err := New("secret")
+	require.NoError(t, err)
+	other, err := New("other-secret")
+	require.NoError(t, err)
+
+	now := time.Now()
+	claims := Claims{Purpose: "verify", Subject: "user-1", ExpiresAt: now.Add(time.Hour).Unix()}
+	token, err := signer.Sign(claims)
+	require.NoError(t, err)
+	forged, err := other.Sign(claims)
+	require.NoError(t, err)
+
+	payload, sig, _ := strings.Cut(token, ".")
+	tampered, err := signer.Sign(Claims{Purpose: "verify", Subject: "user-2", ExpiresAt: claims.ExpiresAt})
+	require.NoError(t, err)
+	tamperedPayload, _, _ := strings.Cut(tampered, ".")
+
+	tests := []struct {
+		name    string
+		token   string
+		purpose string
+		now     time.Time
+		want    error
+	}{
+		{"wrong key", forged, "verify", now, ErrInvalid},
+		{"swapped payload", tamperedPayload + "." + sig, "verify", now, ErrInvalid},
+		{"wrong purpose", token, "reset", now, ErrInvalid},
+		{"no signature", payload, "verify", now, ErrInvalid},
+		{"legacy prefix token", "verify_user-1234567890123", "verify", now, ErrInvalid},
+		{"expired", token, "verify", now.Add(2 * time.Hour), ErrExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := signer.Verify(tt.token, tt.purpose, tt.now)
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
+
+func TestNew_RequiresSecret(t *testing.T) {
+	_, err := New("")
+	assert.Error(t, err)
+}
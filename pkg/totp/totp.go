@@ -0,0 +1,104 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Digits is the number of digits in a generated code
+	Digits = 6
+
+	// Period is the time step in seconds (RFC 6238 default)
+	Period = 30
+
+	// Skew is the number of time steps accepted either side of the current one
+	Skew = 1
+
+	secretSize = 20
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret generates a random base32-encoded 160-bit secret
+func GenerateSecret() (string, error) {
+	buf := make([]byte, secretSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return encoding.EncodeToString(buf), nil
+}
+
+// GenerateCode generates the code for the given secret at time t
+func GenerateCode(secret string, t time.Time) (string, error) {
+	return generateCode(secret, timeStep(t))
+}
+
+// Validate checks a code against the secret at time t, allowing for clock
+// skew. It returns the matched time step so callers can reject replays of
+// an already-used code.
+func Validate(secret, code string, t time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	current := timeStep(t)
+	for i := int64(-Skew); i <= Skew; i++ {
+		expected, err := generateCode(secret, current+i)
+		if err != nil {
+			return 0, false
+		}
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return current + i, true
+		}
+	}
+	return 0, false
+}
+
+// ProvisioningURI builds the otpauth:// URI used to enroll an authenticator app
+func ProvisioningURI(issuer, accountName, secret string) string {
+	label := url.PathEscape(issuer + ":" + accountName)
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprintf("%d", Digits))
+	params.Set("period", fmt.Sprintf("%d", Period))
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+func timeStep(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+// generateCode implements the HOTP truncation from RFC 4226 section 5.3
+func generateCode(secret string, counter int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+	if err != nil {
+		return "", fmt.Errorf("invalid secret: %w", err)
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", Digits, value%mod), nil
+}
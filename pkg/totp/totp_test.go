@@ -0,0 +1,73 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 shared secret from RFC 6238 Appendix B
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateCode_RFC6238Vectors(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := GenerateCode(rfcSecret, time.Unix(tt.unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, code, "time %d", tt.unix)
+	}
+}
+
+func TestValidate_AllowsSkew(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	previous, err := GenerateCode(rfcSecret, now.Add(-Period*time.Second))
+	require.NoError(t, err)
+
+	step, ok := Validate(rfcSecret, previous, now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/Period-1, step)
+
+	stale, err := GenerateCode(rfcSecret, now.Add(-3*Period*time.Second))
+	require.NoError(t, err)
+	_, ok = Validate(rfcSecret, stale, now)
+	assert.False(t, ok)
+}
+
+func TestValidate_RejectsMalformedCode(t *testing.T) {
+	now := time.Now()
+	_, ok := Validate(rfcSecret, "12345", now)
+	assert.False(t, ok)
+	_, ok = Validate("not base32!", "123456", now)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	require.NoError(t, err)
+	b, err := GenerateSecret()
+	require.NoError(t, err)
+
+	assert.Len(t, a, 32)
+	assert.NotEqual(t, a, b)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("Ethos", "jane@example.com", "ABCDEF")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Ethos:jane@example.com?"))
+	assert.Contains(t, uri, "secret=ABCDEF")
+	assert.Contains(t, uri, "issuer=Ethos")
+}
@@ -0,0 +1,159 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// maxCBORDepth bounds nesting so hostile input can't exhaust the stack
+const maxCBORDepth = 16
+
+// decodeCBOR decodes the CBOR data item at the start of data and returns it
+// with the number of bytes it used. Only the subset WebAuthn needs is
+// supported: integers (as int64), byte strings, text strings, arrays, maps
+// with integer or text keys, booleans and null, all with definite lengths.
+func decodeCBOR(data []byte) (interface{}, int, error) {
+	d := &cborDecoder{data: data}
+	value, err := d.decode(0)
+	if err != nil {
+		return nil, 0, err
+	}
+	return value, d.pos, nil
+}
+
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *cborDecoder) decode(depth int) (interface{}, error) {
+	if depth > maxCBORDepth {
+		return nil, fmt.Errorf("cbor: nesting too deep")
+	}
+	if d.pos >= len(d.data) {
+		return nil, fmt.Errorf("cbor: unexpected end of data")
+	}
+
+	initial := d.data[d.pos]
+	d.pos++
+	major, info := initial>>5, initial&0x1f
+
+	if major == 7 {
+		switch info {
+		case 20:
+			return false, nil
+		case 21:
+			return true, nil
+		case 22:
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("cbor: unsupported simple value %d", info)
+		}
+	}
+
+	arg, err := d.argument(info)
+	if err != nil {
+		return nil, err
+	}
+	remaining := uint64(len(d.data) - d.pos)
+
+	switch major {
+	case 0:
+		if arg > math.MaxInt64 {
+			return nil, fmt.Errorf("cbor: integer overflow")
+		}
+		return int64(arg), nil
+	case 1:
+		if arg > math.MaxInt64 {
+			return nil, fmt.Errorf("cbor: integer overflow")
+		}
+		return -1 - int64(arg), nil
+	case 2, 3:
+		if arg > remaining {
+			return nil, fmt.Errorf("cbor: string longer than data")
+		}
+		b := d.data[d.pos : d.pos+int(arg)]
+		d.pos += int(arg)
+		if major == 3 {
+			return string(b), nil
+		}
+		return append([]byte(nil), b...), nil
+	case 4:
+		// Each item takes at least a byte, which bounds the allocation
+		if arg > remaining {
+			return nil, fmt.Errorf("cbor: array longer than data")
+		}
+		items := make([]interface{}, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			item, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case 5:
+		if arg > remaining/2 {
+			return nil, fmt.Errorf("cbor: map longer than data")
+		}
+		m := make(map[interface{}]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			key, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, fmt.Errorf("cbor: unsupported map key type %T", key)
+			}
+			if _, exists := m[key]; exists {
+				return nil, fmt.Errorf("cbor: duplicate map key %v", key)
+			}
+			value, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = value
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("cbor: unsupported major type %d", major)
+	}
+}
+
+// argument reads the value or length that follows an initial byte
+func (d *cborDecoder) argument(info byte) (uint64, error) {
+	var size int
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, fmt.Errorf("cbor: indefinite lengths are not supported")
+	}
+
+	if len(d.data)-d.pos < size {
+		return 0, fmt.Errorf("cbor: unexpected end of data")
+	}
+	b := d.data[d.pos : d.pos+size]
+	d.pos += size
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	default:
+		return binary.BigEndian.Uint64(b), nil
+	}
+}
@@ -0,0 +1,134 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+)
+
+// COSE algorithm identifiers accepted for passkeys
+const (
+	AlgorithmES256 = -7
+	AlgorithmEdDSA = -8
+	AlgorithmRS256 = -257
+)
+
+// COSE key parameters (RFC 9052 and RFC 9053)
+const (
+	coseKeyType   = 1
+	coseAlgorithm = 3
+	coseCurve     = -1 // also the RSA modulus
+	coseX         = -2 // also the RSA exponent
+	coseY         = -3
+
+	coseKeyTypeOKP = 1
+	coseKeyTypeEC2 = 2
+	coseKeyTypeRSA = 3
+
+	coseCurveP256    = 1
+	coseCurveEd25519 = 6
+)
+
+// minRSABits is the smallest RSA key accepted
+const minRSABits = 2048
+
+// publicKey verifies signatures for a COSE algorithm
+type publicKey struct {
+	algorithm int64
+	key       crypto.PublicKey
+}
+
+// parsePublicKey decodes a COSE_Key, accepting only the algorithms offered
+// at registration
+func parsePublicKey(data []byte) (*publicKey, error) {
+	decoded, n, err := decodeCBOR(data)
+	if err != nil {
+		return nil, err
+	}
+	if n != len(data) {
+		return nil, fmt.Errorf("trailing data after public key")
+	}
+	key, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("public key is not a map")
+	}
+
+	keyType, _ := key[int64(coseKeyType)].(int64)
+	algorithm, _ := key[int64(coseAlgorithm)].(int64)
+
+	switch {
+	case keyType == coseKeyTypeEC2 && algorithm == AlgorithmES256:
+		curve, _ := key[int64(coseCurve)].(int64)
+		x, _ := key[int64(coseX)].([]byte)
+		y, _ := key[int64(coseY)].([]byte)
+		if curve != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return nil, fmt.Errorf("invalid P-256 public key")
+		}
+		// ecdh rejects points that are not on the curve
+		point := append(append([]byte{4}, x...), y...)
+		if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+			return nil, fmt.Errorf("invalid P-256 public key: %w", err)
+		}
+		return &publicKey{algorithm: algorithm, key: &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}}, nil
+
+	case keyType == coseKeyTypeOKP && algorithm == AlgorithmEdDSA:
+		curve, _ := key[int64(coseCurve)].(int64)
+		x, _ := key[int64(coseX)].([]byte)
+		if curve != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 public key")
+		}
+		return &publicKey{algorithm: algorithm, key: ed25519.PublicKey(x)}, nil
+
+	case keyType == coseKeyTypeRSA && algorithm == AlgorithmRS256:
+		modulus, _ := key[int64(coseCurve)].([]byte)
+		exponent, _ := key[int64(coseX)].([]byte)
+		if len(exponent) == 0 || len(exponent) > 4 {
+			return nil, fmt.Errorf("invalid RSA public key")
+		}
+		e := 0
+		for _, b := range exponent {
+			e = e<<8 | int(b)
+		}
+		n := new(big.Int).SetBytes(modulus)
+		if n.BitLen() < minRSABits || e < 3 || e%2 == 0 {
+			return nil, fmt.Errorf("invalid RSA public key")
+		}
+		return &publicKey{algorithm: algorithm, key: &rsa.PublicKey{N: n, E: e}}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported public key type %d with algorithm %d", keyType, algorithm)
+	}
+}
+
+// verify checks a signature over data
+func (k *publicKey) verify(data, signature []byte) error {
+	switch key := k.key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return fmt.Errorf("invalid signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, signature) {
+			return fmt.Errorf("invalid signature")
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported public key")
+	}
+	return nil
+}
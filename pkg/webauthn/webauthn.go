@@ -0,0 +1,475 @@
+// Package webauthn implements the relying party side of WebAuthn
+// registration and authentication ceremonies for passkeys. Attestation
+// statements are not verified: passkeys are requested with "none"
+// conveyance, so the authenticator model is never trusted.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrInvalidResponse is returned for ceremony responses that fail verification
+	ErrInvalidResponse = errors.New("webauthn: invalid response")
+	// ErrSignCountRegression is returned when an authenticator's signature
+	// counter didn't increase, which suggests a cloned authenticator
+	ErrSignCountRegression = errors.New("webauthn: signature counter did not increase")
+)
+
+// Timeout is how long, in milliseconds, browsers are asked to wait for the user
+const Timeout = 300000
+
+const (
+	credentialType = "public-key"
+	createType     = "webauthn.create"
+	getType        = "webauthn.get"
+)
+
+// Authenticator data flags
+const (
+	flagUserPresent    = 0x01
+	flagUserVerified   = 0x04
+	flagBackupEligible = 0x08
+	flagBackedUp       = 0x10
+	flagAttestedData   = 0x40
+	flagExtensionData  = 0x80
+)
+
+// RelyingParty identifies this application to authenticators
+type RelyingParty struct {
+	ID      string   // Registrable domain credentials are scoped to
+	Name    string   // Shown by the browser during registration
+	Origins []string // Origins ceremonies may run on
+}
+
+// User is the account a credential is registered for
+type User struct {
+	ID          []byte // Opaque user handle returned with assertions
+	Name        string
+	DisplayName string
+}
+
+// RelyingPartyEntity is the rp member of creation options
+type RelyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserEntity is the user member of creation options
+type UserEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// CredentialParameter names an acceptable public key algorithm
+type CredentialParameter struct {
+	Type      string `json:"type"`
+	Algorithm int    `json:"alg"`
+}
+
+// CredentialDescriptor refers to an existing credential
+type CredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+// AuthenticatorSelection states the authenticator requirements for registration
+type AuthenticatorSelection struct {
+	ResidentKey        string `json:"residentKey"`
+	RequireResidentKey bool   `json:"requireResidentKey"`
+	UserVerification   string `json:"userVerification"`
+}
+
+// CreationOptions are the PublicKeyCredentialCreationOptions for
+// navigator.credentials.create, with binary values base64url encoded
+type CreationOptions struct {
+	Challenge              string                 `json:"challenge"`
+	RelyingParty           RelyingPartyEntity     `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	Parameters             []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int                    `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions are the PublicKeyCredentialRequestOptions for
+// navigator.credentials.get. AllowCredentials is empty so the authenticator
+// offers its discoverable credentials.
+type RequestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	Timeout          int                    `json:"timeout"`
+	RelyingPartyID   string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// RegistrationResponse is the JSON form of a PublicKeyCredential returned by
+// navigator.credentials.create
+type RegistrationResponse struct {
+	ID       string `json:"id"`
+	RawID    string `json:"rawId"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    string   `json:"clientDataJSON"`
+		AttestationObject string   `json:"attestationObject"`
+		Transports        []string `json:"transports"`
+	} `json:"response"`
+}
+
+// AssertionResponse is the JSON form of a PublicKeyCredential returned by
+// navigator.credentials.get
+type AssertionResponse struct {
+	ID       string `json:"id"`
+	RawID    string `json:"rawId"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    string `json:"clientDataJSON"`
+		AuthenticatorData string `json:"authenticatorData"`
+		Signature         string `json:"signature"`
+		UserHandle        string `json:"userHandle"`
+	} `json:"response"`
+}
+
+// Credential is a verified new credential to store for the user
+type Credential struct {
+	ID             []byte
+	PublicKey      []byte // COSE_Key
+	SignCount      uint32
+	AAGUID         []byte
+	BackupEligible bool
+	BackedUp       bool
+	Transports     []string
+}
+
+// Assertion is the verified result of an authentication ceremony
+type Assertion struct {
+	SignCount  uint32
+	BackedUp   bool
+	UserHandle []byte
+}
+
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+type authenticatorData struct {
+	rpIDHash     []byte
+	flags        byte
+	signCount    uint32
+	aaguid       []byte
+	credentialID []byte
+	publicKey    []byte
+}
+
+// NewChallenge returns a random base64url challenge
+func NewChallenge() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// EncodeID base64url encodes a credential ID or user handle
+func EncodeID(id []byte) string {
+	return base64.RawURLEncoding.EncodeToString(id)
+}
+
+// DecodeID decodes a base64url credential ID or user handle, with or without padding
+func DecodeID(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// CreationOptions returns the options for registering a discoverable,
+// user-verified credential. Existing credentials are excluded so the same
+// authenticator isn't registered twice.
+func (rp *RelyingParty) CreationOptions(challenge string, user User, existing []CredentialDescriptor) *CreationOptions {
+	if existing == nil {
+		existing = []CredentialDescriptor{}
+	}
+	return &CreationOptions{
+		Challenge:    challenge,
+		RelyingParty: RelyingPartyEntity{ID: rp.ID, Name: rp.Name},
+		User: UserEntity{
+			ID:          EncodeID(user.ID),
+			Name:        user.Name,
+			DisplayName: user.DisplayName,
+		},
+		Parameters: []CredentialParameter{
+			{Type: credentialType, Algorithm: AlgorithmES256},
+			{Type: credentialType, Algorithm: AlgorithmEdDSA},
+			{Type: credentialType, Algorithm: AlgorithmRS256},
+		},
+		Timeout:            Timeout,
+		ExcludeCredentials: existing,
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:        "required",
+			RequireResidentKey: true,
+			UserVerification:   "required",
+		},
+		Attestation: "none",
+	}
+}
+
+// RequestOptions returns the options for a passkey login
+func (rp *RelyingParty) RequestOptions(challenge string) *RequestOptions {
+	return &RequestOptions{
+		Challenge:        challenge,
+		Timeout:          Timeout,
+		RelyingPartyID:   rp.ID,
+		AllowCredentials: []CredentialDescriptor{},
+		UserVerification: "required",
+	}
+}
+
+// Descriptor refers to a stored credential in creation options
+func Descriptor(id []byte, transports []string) CredentialDescriptor {
+	return CredentialDescriptor{Type: credentialType, ID: EncodeID(id), Transports: transports}
+}
+
+// VerifyRegistration checks a registration response against the challenge
+// it was created for and returns the new credential
+func (rp *RelyingParty) VerifyRegistration(challenge string, resp *RegistrationResponse) (*Credential, error) {
+	rawID, err := credentialID(resp.ID, resp.RawID, resp.Type)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := rp.verifyClientData(resp.Response.ClientDataJSON, createType, challenge); err != nil {
+		return nil, err
+	}
+
+	attestation, err := DecodeID(resp.Response.AttestationObject)
+	if err != nil {
+		return nil, invalidResponse("attestationObject is not base64url")
+	}
+	decoded, n, err := decodeCBOR(attestation)
+	if err != nil || n != len(attestation) {
+		return nil, invalidResponse("malformed attestation object")
+	}
+	object, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, invalidResponse("malformed attestation object")
+	}
+	if _, ok := object["fmt"].(string); !ok {
+		return nil, invalidResponse("attestation format missing")
+	}
+	rawAuthData, ok := object["authData"].([]byte)
+	if !ok {
+		return nil, invalidResponse("authenticator data missing")
+	}
+
+	authData, err := parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if err := rp.checkAuthenticatorData(authData); err != nil {
+		return nil, err
+	}
+	if authData.flags&flagAttestedData == 0 {
+		return nil, invalidResponse("attested credential data missing")
+	}
+	if !bytes.Equal(authData.credentialID, rawID) {
+		return nil, invalidResponse("credential ID does not match attested credential")
+	}
+	if _, err := parsePublicKey(authData.publicKey); err != nil {
+		return nil, invalidResponse("%v", err)
+	}
+
+	return &Credential{
+		ID:             rawID,
+		PublicKey:      authData.publicKey,
+		SignCount:      authData.signCount,
+		AAGUID:         authData.aaguid,
+		BackupEligible: authData.flags&flagBackupEligible != 0,
+		BackedUp:       authData.flags&flagBackedUp != 0,
+		Transports:     resp.Response.Transports,
+	}, nil
+}
+
+// CredentialID returns the ID of the credential that produced an assertion,
+// used to look up its public key before verification
+func (resp *AssertionResponse) CredentialID() ([]byte, error) {
+	return credentialID(resp.ID, resp.RawID, resp.Type)
+}
+
+// VerifyAssertion checks an authentication response against the challenge
+// and the stored credential's public key and signature counter. Counters
+// that fail to increase return ErrSignCountRegression; authenticators that
+// always report zero, as synced passkeys do, are accepted.
+func (rp *RelyingParty) VerifyAssertion(challenge string, resp *AssertionResponse, publicKeyData []byte, storedSignCount uint32) (*Assertion, error) {
+	if _, err := resp.CredentialID(); err != nil {
+		return nil, err
+	}
+	clientDataJSON, err := rp.verifyClientData(resp.Response.ClientDataJSON, getType, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	rawAuthData, err := DecodeID(resp.Response.AuthenticatorData)
+	if err != nil {
+		return nil, invalidResponse("authenticatorData is not base64url")
+	}
+	authData, err := parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if err := rp.checkAuthenticatorData(authData); err != nil {
+		return nil, err
+	}
+
+	signature, err := DecodeID(resp.Response.Signature)
+	if err != nil {
+		return nil, invalidResponse("signature is not base64url")
+	}
+	key, err := parsePublicKey(publicKeyData)
+	if err != nil {
+		return nil, fmt.Errorf("webauthn: stored public key: %w", err)
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte{}, rawAuthData...), clientDataHash[:]...)
+	if err := key.verify(signed, signature); err != nil {
+		return nil, invalidResponse("%v", err)
+	}
+
+	if (authData.signCount != 0 || storedSignCount != 0) && authData.signCount <= storedSignCount {
+		return nil, ErrSignCountRegression
+	}
+
+	var userHandle []byte
+	if resp.Response.UserHandle != "" {
+		if userHandle, err = DecodeID(resp.Response.UserHandle); err != nil {
+			return nil, invalidResponse("userHandle is not base64url")
+		}
+	}
+
+	return &Assertion{
+		SignCount:  authData.signCount,
+		BackedUp:   authData.flags&flagBackedUp != 0,
+		UserHandle: userHandle,
+	}, nil
+}
+
+// credentialID checks a response's type and that its id and rawId agree
+func credentialID(id, rawID, credType string) ([]byte, error) {
+	if credType != credentialType {
+		return nil, invalidResponse("unexpected credential type %q", credType)
+	}
+	raw, err := DecodeID(rawID)
+	if err != nil || len(raw) == 0 {
+		return nil, invalidResponse("rawId is not base64url")
+	}
+	if decoded, err := DecodeID(id); err != nil || !bytes.Equal(decoded, raw) {
+		return nil, invalidResponse("id does not match rawId")
+	}
+	return raw, nil
+}
+
+// verifyClientData checks the collected client data and returns its raw JSON
+func (rp *RelyingParty) verifyClientData(encoded, ceremony, challenge string) ([]byte, error) {
+	raw, err := DecodeID(encoded)
+	if err != nil {
+		return nil, invalidResponse("clientDataJSON is not base64url")
+	}
+	var data clientData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, invalidResponse("malformed clientDataJSON")
+	}
+	if data.Type != ceremony {
+		return nil, invalidResponse("unexpected client data type %q", data.Type)
+	}
+	if challenge == "" || subtle.ConstantTimeCompare([]byte(data.Challenge), []byte(challenge)) != 1 {
+		return nil, invalidResponse("challenge mismatch")
+	}
+	if data.CrossOrigin {
+		return nil, invalidResponse("cross-origin ceremonies are not allowed")
+	}
+	for _, origin := range rp.Origins {
+		if data.Origin == origin {
+			return raw, nil
+		}
+	}
+	return nil, invalidResponse("unexpected origin %q", data.Origin)
+}
+
+// checkAuthenticatorData requires the relying party ID and a verified user
+func (rp *RelyingParty) checkAuthenticatorData(authData *authenticatorData) error {
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if subtle.ConstantTimeCompare(authData.rpIDHash, rpIDHash[:]) != 1 {
+		return invalidResponse("relying party ID mismatch")
+	}
+	if authData.flags&flagUserPresent == 0 {
+		return invalidResponse("user not present")
+	}
+	if authData.flags&flagUserVerified == 0 {
+		return invalidResponse("user not verified")
+	}
+	if authData.flags&flagBackedUp != 0 && authData.flags&flagBackupEligible == 0 {
+		return invalidResponse("backed up credential is not backup eligible")
+	}
+	return nil
+}
+
+// parseAuthenticatorData splits authenticator data into its fields
+func parseAuthenticatorData(data []byte) (*authenticatorData, error) {
+	if len(data) < 37 {
+		return nil, invalidResponse("authenticator data too short")
+	}
+	authData := &authenticatorData{
+		rpIDHash:  data[:32],
+		flags:     data[32],
+		signCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+	rest := data[37:]
+
+	if authData.flags&flagAttestedData != 0 {
+		if len(rest) < 18 {
+			return nil, invalidResponse("attested credential data too short")
+		}
+		authData.aaguid = rest[:16]
+		idLength := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if idLength == 0 || idLength > 1023 || len(rest) < idLength {
+			return nil, invalidResponse("invalid credential ID length")
+		}
+		authData.credentialID = rest[:idLength]
+		rest = rest[idLength:]
+
+		_, n, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, invalidResponse("malformed credential public key")
+		}
+		authData.publicKey = rest[:n]
+		rest = rest[n:]
+	}
+
+	if authData.flags&flagExtensionData != 0 {
+		_, n, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, invalidResponse("malformed extensions")
+		}
+		rest = rest[n:]
+	}
+	if len(rest) != 0 {
+		return nil, invalidResponse("trailing authenticator data")
+	}
+	return authData, nil
+}
+
+func invalidResponse(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidResponse, fmt.Sprintf(format, args...))
+}
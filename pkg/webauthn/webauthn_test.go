@@ -0,0 +1,153 @@
+package webauthn_test
+
+import (
+	"testing"
+
+	"ethos/pkg/webauthn"
+	"ethos/pkg/webauthn/webauthntest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRP = &webauthn.RelyingParty{
+	ID:      "example.com",
+	Name:    "Ethos",
+	Origins: []string{"https://app.example.com"},
+}
+
+var testUser = webauthn.User{ID: []byte("user-1"), Name: "jane@example.com", DisplayName: "Jane Doe"}
+
+// register creates a credential on the authenticator and verifies it
+func register(t *testing.T, authenticator *webauthntest.Authenticator) *webauthn.Credential {
+	t.Helper()
+	challenge, err := webauthn.NewChallenge()
+	require.NoError(t, err)
+	resp, err := authenticator.Register(testRP.CreationOptions(challenge, testUser, nil))
+	require.NoError(t, err)
+
+	credential, err := testRP.VerifyRegistration(challenge, resp)
+	require.NoError(t, err)
+	return credential
+}
+
+func assertion(t *testing.T, authenticator *webauthntest.Authenticator) (string, *webauthn.AssertionResponse) {
+	t.Helper()
+	challenge, err := webauthn.NewChallenge()
+	require.NoError(t, err)
+	resp, err := authenticator.Assert(testRP.RequestOptions(challenge))
+	require.NoError(t, err)
+	return challenge, resp
+}
+
+func TestRegistrationAndAssertion(t *testing.T) {
+	authenticator := webauthntest.NewAuthenticator("https://app.example.com")
+	credential := register(t, authenticator)
+	assert.Len(t, credential.AAGUID, 16)
+	assert.Equal(t, []string{"internal"}, credential.Transports)
+	assert.Equal(t, uint32(0), credential.SignCount)
+
+	challenge, resp := assertion(t, authenticator)
+	id, err := resp.CredentialID()
+	require.NoError(t, err)
+	assert.Equal(t, credential.ID, id)
+
+	result, err := testRP.VerifyAssertion(challenge, resp, credential.PublicKey, credential.SignCount)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), result.SignCount)
+	assert.Equal(t, testUser.ID, result.UserHandle)
+}
+
+func TestVerifyRegistration_Rejections(t *testing.T) {
+	authenticator := webauthntest.NewAuthenticator("https://app.example.com")
+	challenge, err := webauthn.NewChallenge()
+	require.NoError(t, err)
+	resp, err := authenticator.Register(testRP.CreationOptions(challenge, testUser, nil))
+	require.NoError(t, err)
+
+	otherChallenge, err := webauthn.NewChallenge()
+	require.NoError(t, err)
+	_, err = testRP.VerifyRegistration(otherChallenge, resp)
+	assert.ErrorIs(t, err, webauthn.ErrInvalidResponse)
+
+	otherRP := &webauthn.RelyingParty{ID: "evil.example", Origins: testRP.Origins}
+	_, err = otherRP.VerifyRegistration(challenge, resp)
+	assert.ErrorIs(t, err, webauthn.ErrInvalidResponse)
+
+	otherOrigin := &webauthn.RelyingParty{ID: testRP.ID, Origins: []string{"https://evil.example"}}
+	_, err = otherOrigin.VerifyRegistration(challenge, resp)
+	assert.ErrorIs(t, err, webauthn.ErrInvalidResponse)
+
+	tampered := *resp
+	tampered.RawID = webauthn.EncodeID([]byte("another-credential"))
+	tampered.ID = tampered.RawID
+	_, err = testRP.VerifyRegistration(challenge, &tampered)
+	assert.ErrorIs(t, err, webauthn.ErrInvalidResponse)
+}
+
+func TestVerifyAssertion_Rejections(t *testing.T) {
+	authenticator := webauthntest.NewAuthenticator("https://app.example.com")
+	credential := register(t, authenticator)
+	other := register(t, webauthntest.NewAuthenticator("https://app.example.com"))
+
+	tests := []struct {
+		name   string
+		modify func(challenge *string, resp *webauthn.AssertionResponse, publicKey *[]byte)
+	}{
+		{"other challenge", func(challenge *string, _ *webauthn.AssertionResponse, _ *[]byte) {
+			*challenge = "c29tZXRoaW5nIGVsc2U"
+		}},
+		{"other key", func(_ *string, _ *webauthn.AssertionResponse, publicKey *[]byte) {
+			*publicKey = other.PublicKey
+		}},
+		{"tampered authenticator data", func(_ *string, resp *webauthn.AssertionResponse, _ *[]byte) {
+			data, _ := webauthn.DecodeID(resp.Response.AuthenticatorData)
+			data[36]++
+			resp.Response.AuthenticatorData = webauthn.EncodeID(data)
+		}},
+		{"registration client data", func(_ *string, resp *webauthn.AssertionResponse, _ *[]byte) {
+			resp.Response.ClientDataJSON = webauthn.EncodeID([]byte(`{"type":"webauthn.create"}`))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			challenge, resp := assertion(t, authenticator)
+			publicKey := credential.PublicKey
+			tt.modify(&challenge, resp, &publicKey)
+			_, err := testRP.VerifyAssertion(challenge, resp, publicKey, 0)
+			assert.ErrorIs(t, err, webauthn.ErrInvalidResponse)
+		})
+	}
+}
+
+func TestVerifyAssertion_SignCountRegression(t *testing.T) {
+	authenticator := webauthntest.NewAuthenticator("https://app.example.com")
+	credential := register(t, authenticator)
+
+	authenticator.SignCount = 4
+	challenge, resp := assertion(t, authenticator)
+	_, err := testRP.VerifyAssertion(challenge, resp, credential.PublicKey, 5)
+	assert.ErrorIs(t, err, webauthn.ErrSignCountRegression)
+
+	challenge, resp = assertion(t, authenticator)
+	result, err := testRP.VerifyAssertion(challenge, resp, credential.PublicKey, 5)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(6), result.SignCount)
+}
+
+func TestDecodeCBOR_RejectsMalformedKeys(t *testing.T) {
+	authenticator := webauthntest.NewAuthenticator("https://app.example.com")
+	credential := register(t, authenticator)
+	challenge, resp := assertion(t, authenticator)
+
+	for _, key := range [][]byte{
+		credential.PublicKey[:len(credential.PublicKey)-1],
+		{0xbf, 0xff},                   // indefinite-length map
+		{0xa1, 0x01, 0x1b, 0xff, 0xff}, // truncated integer
+		append(append([]byte{}, credential.PublicKey...), 0x00),
+	} {
+		_, err := testRP.VerifyAssertion(challenge, resp, key, 0)
+		assert.Error(t, err)
+	}
+}
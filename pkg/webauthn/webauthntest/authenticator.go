@@ -0,0 +1,211 @@
+// Package webauthntest provides a virtual passkey authenticator for tests.
+// It creates discoverable P-256 credentials with "none" attestation and
+// answers ceremonies the way a browser would return them as JSON.
+package webauthntest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"ethos/pkg/webauthn"
+)
+
+const (
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttestedData = 0x40
+)
+
+type credential struct {
+	id         []byte
+	key        *ecdsa.PrivateKey
+	rpID       string
+	userHandle []byte
+}
+
+// Authenticator is a virtual platform authenticator
+type Authenticator struct {
+	Origin string
+	// SignCount is the counter value of the last signature; assertions
+	// increment it first. Tests may lower it to simulate a cloned authenticator.
+	SignCount uint32
+
+	mu          sync.Mutex
+	credentials []*credential
+}
+
+// NewAuthenticator creates an authenticator whose ceremonies run on origin
+func NewAuthenticator(origin string) *Authenticator {
+	return &Authenticator{Origin: origin}
+}
+
+// Register creates a credential for the creation options
+func (a *Authenticator) Register(options *webauthn.CreationOptions) (*webauthn.RegistrationResponse, error) {
+	userHandle, err := webauthn.DecodeID(options.User.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, excluded := range options.ExcludeCredentials {
+		for _, existing := range a.credentials {
+			if excluded.ID == webauthn.EncodeID(existing.id) {
+				return nil, fmt.Errorf("authenticator already holds an excluded credential")
+			}
+		}
+	}
+	a.credentials = append(a.credentials, &credential{id: id, key: key, rpID: options.RelyingParty.ID, userHandle: userHandle})
+
+	// COSE_Key for an ES256 public key
+	publicKey := encodeMap([][2]interface{}{
+		{1, 2},
+		{3, webauthn.AlgorithmES256},
+		{-1, 1},
+		{-2, pad32(key.X.Bytes())},
+		{-3, pad32(key.Y.Bytes())},
+	})
+	attested := make([]byte, 16, 18+len(id)+len(publicKey))
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(id)))
+	attested = append(attested, id...)
+	attested = append(attested, publicKey...)
+
+	authData := a.authenticatorData(options.RelyingParty.ID, flagUserPresent|flagUserVerified|flagAttestedData, attested)
+	attestation := encodeMap([][2]interface{}{
+		{"fmt", "none"},
+		{"attStmt", encodedMap(nil)},
+		{"authData", authData},
+	})
+
+	resp := &webauthn.RegistrationResponse{
+		ID:    webauthn.EncodeID(id),
+		RawID: webauthn.EncodeID(id),
+		Type:  "public-key",
+	}
+	resp.Response.ClientDataJSON = a.clientData("webauthn.create", options.Challenge)
+	resp.Response.AttestationObject = webauthn.EncodeID(attestation)
+	resp.Response.Transports = []string{"internal"}
+	return resp, nil
+}
+
+// Assert signs in with the most recently registered credential for the
+// options' relying party
+func (a *Authenticator) Assert(options *webauthn.RequestOptions) (*webauthn.AssertionResponse, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var cred *credential
+	for i := len(a.credentials) - 1; i >= 0; i-- {
+		if a.credentials[i].rpID == options.RelyingPartyID {
+			cred = a.credentials[i]
+			break
+		}
+	}
+	if cred == nil {
+		return nil, fmt.Errorf("no credential for %s", options.RelyingPartyID)
+	}
+
+	a.SignCount++
+	authData := a.authenticatorData(options.RelyingPartyID, flagUserPresent|flagUserVerified, nil)
+	clientDataJSON := a.clientData("webauthn.get", options.Challenge)
+	rawClientData, _ := webauthn.DecodeID(clientDataJSON)
+	clientDataHash := sha256.Sum256(rawClientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, cred.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &webauthn.AssertionResponse{
+		ID:    webauthn.EncodeID(cred.id),
+		RawID: webauthn.EncodeID(cred.id),
+		Type:  "public-key",
+	}
+	resp.Response.ClientDataJSON = clientDataJSON
+	resp.Response.AuthenticatorData = webauthn.EncodeID(authData)
+	resp.Response.Signature = webauthn.EncodeID(signature)
+	resp.Response.UserHandle = webauthn.EncodeID(cred.userHandle)
+	return resp, nil
+}
+
+func (a *Authenticator) authenticatorData(rpID string, flags byte, attested []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append(rpIDHash[:], flags)
+	data = binary.BigEndian.AppendUint32(data, a.SignCount)
+	return append(data, attested...)
+}
+
+func (a *Authenticator) clientData(ceremony, challenge string) string {
+	data, _ := json.Marshal(map[string]interface{}{
+		"type":        ceremony,
+		"challenge":   challenge,
+		"origin":      a.Origin,
+		"crossOrigin": false,
+	})
+	return webauthn.EncodeID(data)
+}
+
+func pad32(b []byte) []byte {
+	return append(make([]byte, 32-len(b)), b...)
+}
+
+// encodedMap marks a value that is already CBOR encoded
+type encodedMap []byte
+
+// encodeMap encodes key/value pairs as a CBOR map in the given order
+func encodeMap(pairs [][2]interface{}) []byte {
+	out := encodeHead(5, uint64(len(pairs)))
+	for _, pair := range pairs {
+		out = append(out, encodeValue(pair[0])...)
+		out = append(out, encodeValue(pair[1])...)
+	}
+	return out
+}
+
+func encodeValue(value interface{}) []byte {
+	switch v := value.(type) {
+	case int:
+		if v < 0 {
+			return encodeHead(1, uint64(-1-v))
+		}
+		return encodeHead(0, uint64(v))
+	case string:
+		return append(encodeHead(3, uint64(len(v))), v...)
+	case []byte:
+		return append(encodeHead(2, uint64(len(v))), v...)
+	case encodedMap:
+		if v == nil {
+			return encodeHead(5, 0)
+		}
+		return v
+	default:
+		panic(fmt.Sprintf("webauthntest: cannot encode %T", value))
+	}
+}
+
+func encodeHead(major byte, arg uint64) []byte {
+	switch {
+	case arg < 24:
+		return []byte{major<<5 | byte(arg)}
+	case arg <= 0xff:
+		return []byte{major<<5 | 24, byte(arg)}
+	case arg <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(arg))
+	default:
+		return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(arg))
+	}
+}
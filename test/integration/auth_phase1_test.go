@@ -21,6 +21,7 @@ import (
 	"ethos/internal/auth/service"
 	"ethos/internal/database"
 	"ethos/pkg/jwt"
+	"ethos/pkg/signedtoken"
 	"ethos/test/testutil"
 )
 
@@ -30,11 +31,11 @@ func TestVerifyEmailSuccess(t *testing.T) {
 	defer db.Close()
 
 	user := createTestUser(t, db, "verify@example.com", false)
-	verificationToken := fmt.Sprintf("verify_%s", user.ID)
+	verificationToken := generateTestVerificationToken(t, user)
 
 	authRepo := repository.NewPostgresRepository(db)
 	tokenGen := jwt.NewTokenGenerator("secret", "secret", 15*time.Minute, 14*24*time.Hour)
-	authService := service.NewAuthService(authRepo, tokenGen, nil, nil)
+	authService := service.NewAuthService(authRepo, tokenGen, nil, nil, nil, nil, nil, nil, nil, nil, nil, service.Config{EmailVerificationSecret: testVerificationSecret})
 	authHandler := handler.NewAuthHandler(authService)
 
 	ginCtx, w := createGinContext(http.MethodGet, "/api/v1/auth/verify-email/"+verificationToken, nil)
@@ -51,7 +52,7 @@ func TestVerifyEmailInvalidToken(t *testing.T) {
 
 	authRepo := repository.NewPostgresRepository(db)
 	tokenGen := jwt.NewTokenGenerator("secret", "secret", 15*time.Minute, 14*24*time.Hour)
-	authService := service.NewAuthService(authRepo, tokenGen, nil, nil)
+	authService := service.NewAuthService(authRepo, tokenGen, nil, nil, nil, nil, nil, nil, nil, nil, nil, service.Config{EmailVerificationSecret: testVerificationSecret})
 	authHandler := handler.NewAuthHandler(authService)
 
 	ginCtx, w := createGinContext(http.MethodGet, "/api/v1/auth/verify-email/invalid_token", nil)
@@ -71,11 +72,11 @@ func TestVerifyEmailAlreadyVerified(t *testing.T) {
 	defer db.Close()
 
 	user := createTestUser(t, db, "verified@example.com", true)
-	verificationToken := fmt.Sprintf("verify_%s", user.ID)
+	verificationToken := generateTestVerificationToken(t, user)
 
 	authRepo := repository.NewPostgresRepository(db)
 	tokenGen := jwt.NewTokenGenerator("secret", "secret", 15*time.Minute, 14*24*time.Hour)
-	authService := service.NewAuthService(authRepo, tokenGen, nil, nil)
+	authService := service.NewAuthService(authRepo, tokenGen, nil, nil, nil, nil, nil, nil, nil, nil, nil, service.Config{EmailVerificationSecret: testVerificationSecret})
 	authHandler := handler.NewAuthHandler(authService)
 
 	ginCtx, w := createGinContext(http.MethodGet, "/api/v1/auth/verify-email/"+verificationToken, nil)
@@ -95,7 +96,7 @@ func TestChangePasswordSuccess(t *testing.T) {
 
 	authRepo := repository.NewPostgresRepository(db)
 	tokenGen := jwt.NewTokenGenerator("secret", "secret", 15*time.Minute, 14*24*time.Hour)
-	authService := service.NewAuthService(authRepo, tokenGen, nil, nil)
+	authService := service.NewAuthService(authRepo, tokenGen, nil, nil, nil, nil, nil, nil, nil, nil, nil, service.Config{EmailVerificationSecret: testVerificationSecret})
 	authHandler := handler.NewAuthHandler(authService)
 
 	changeReq := map[string]string{
@@ -121,7 +122,7 @@ func TestChangePasswordWrongCurrent(t *testing.T) {
 
 	authRepo := repository.NewPostgresRepository(db)
 	tokenGen := jwt.NewTokenGenerator("secret", "secret", 15*time.Minute, 14*24*time.Hour)
-	authService := service.NewAuthService(authRepo, tokenGen, nil, nil)
+	authService := service.NewAuthService(authRepo, tokenGen, nil, nil, nil, nil, nil, nil, nil, nil, nil, service.Config{EmailVerificationSecret: testVerificationSecret})
 	authHandler := handler.NewAuthHandler(authService)
 
 	changeReq := map[string]string{
@@ -144,7 +145,7 @@ func TestChangePasswordUnauthorized(t *testing.T) {
 
 	authRepo := repository.NewPostgresRepository(db)
 	tokenGen := jwt.NewTokenGenerator("secret", "secret", 15*time.Minute, 14*24*time.Hour)
-	authService := service.NewAuthService(authRepo, tokenGen, nil, nil)
+	authService := service.NewAuthService(authRepo, tokenGen, nil, nil, nil, nil, nil, nil, nil, nil, nil, service.Config{EmailVerificationSecret: testVerificationSecret})
 	authHandler := handler.NewAuthHandler(authService)
 
 	changeReq := map[string]string{
@@ -168,7 +169,7 @@ func TestSetup2FASuccess(t *testing.T) {
 
 	authRepo := repository.NewPostgresRepository(db)
 	tokenGen := jwt.NewTokenGenerator("secret", "secret", 15*time.Minute, 14*24*time.Hour)
-	authService := service.NewAuthService(authRepo, tokenGen, nil, nil)
+	authService := service.NewAuthService(authRepo, tokenGen, nil, nil, nil, nil, nil, nil, nil, nil, nil, service.Config{EmailVerificationSecret: testVerificationSecret})
 	authHandler := handler.NewAuthHandler(authService)
 
 	setup2FAReq := map[string]string{
@@ -195,7 +196,7 @@ func TestSetup2FAUnauthorized(t *testing.T) {
 
 	authRepo := repository.NewPostgresRepository(db)
 	tokenGen := jwt.NewTokenGenerator("secret", "secret", 15*time.Minute, 14*24*time.Hour)
-	authService := service.NewAuthService(authRepo, tokenGen, nil, nil)
+	authService := service.NewAuthService(authRepo, tokenGen, nil, nil, nil, nil, nil, nil, nil, nil, nil, service.Config{EmailVerificationSecret: testVerificationSecret})
 	authHandler := handler.NewAuthHandler(authService)
 
 	setup2FAReq := map[string]string{
@@ -267,6 +268,22 @@ func generateTestJWT(t *testing.T, userID string) string {
 	return token
 }
 
+const testVerificationSecret = "verification-secret"
+
+func generateTestVerificationToken(t *testing.T, user *model.User) string {
+	t.Helper()
+	signer, err := signedtoken.New(testVerificationSecret)
+	require.NoError(t, err)
+	token, err := signer.Sign(signedtoken.Claims{
+		Purpose:   "email_verification",
+		Subject:   user.ID,
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+	return token
+}
+
 func hashPassword(password string) string {
 	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {